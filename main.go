@@ -14,6 +14,7 @@ import (
 	"github.com/zarf-dev/zarf/src/cmd"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
 //go:embed cosign.pub
@@ -36,6 +37,9 @@ func main() {
 				cancel()
 				continue
 			}
+			// Exiting skips the deferred cleanup of the command, remove the temp paths it created instead.
+			//nolint:errcheck // Zarf is exiting, the temp paths are removed on a best effort basis.
+			utils.RemoveTempPaths()
 			os.Exit(1)
 		}
 	}()
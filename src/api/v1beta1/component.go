@@ -26,6 +26,9 @@ type ZarfComponent struct {
 	// Filter when this component is included in package creation or deployment.
 	Only ZarfComponentOnlyTarget `json:"only,omitempty"`
 
+	// The alias of the cluster to deploy the component to, mapped to a kube context with --cluster-context during package deploy. Components without a cluster are deployed to the current kube context.
+	Cluster string `json:"cluster,omitempty" jsonschema:"pattern=^[a-z0-9][a-z0-9\\-]*$"`
+
 	// Import a component from another Zarf package.
 	Import ZarfComponentImport `json:"import,omitempty"`
 
@@ -47,11 +50,20 @@ type ZarfComponent struct {
 	// List of git repos to include in the package.
 	Repos []string `json:"repos,omitempty"`
 
+	// Options that limit how much of the git repos is cloned into the package, matched to the repos by their url.
+	RepoOptions []ZarfRepoOptions `json:"repoOptions,omitempty"`
+
+	// Pinned PyPI or npm packages to mirror into the artifact server during package deploy.
+	PackageMirrors []ZarfPackageMirror `json:"packageMirrors,omitempty"`
+
 	// Custom commands to run at various stages of a package lifecycle.
 	Actions ZarfComponentActions `json:"actions,omitempty"`
 
 	// List of resources to health check after deployment
 	HealthChecks []NamespacedObjectKindReference `json:"healthChecks,omitempty"`
+
+	// Notes printed after the component is deployed, templated with the package variables and constants.
+	Notes string `json:"notes,omitempty"`
 }
 
 // NamespacedObjectKindReference is a reference to a specific resource in a namespace using its kind and API version.
@@ -72,9 +84,10 @@ func (c ZarfComponent) RequiresCluster() bool {
 	hasCharts := len(c.Charts) > 0
 	hasManifests := len(c.Manifests) > 0
 	hasRepos := len(c.Repos) > 0
+	hasPackageMirrors := len(c.PackageMirrors) > 0
 	hasDataInjections := len(c.DataInjections) > 0
 
-	if hasImages || hasCharts || hasManifests || hasRepos || hasDataInjections {
+	if hasImages || hasCharts || hasManifests || hasRepos || hasPackageMirrors || hasDataInjections {
 		return true
 	}
 
@@ -109,7 +122,7 @@ type ZarfComponentOnlyCluster struct {
 
 // ZarfFile defines a file to deploy.
 type ZarfFile struct {
-	// Local folder or file path or remote URL to pull into the package.
+	// Local folder or file path, remote URL, OCI blob (oci://repo@sha256:digest), git path (git::https://repo//path@ref) or release asset (github-release://org/repo@tag#asset) to pull into the package.
 	Source string `json:"source"`
 	// (files only) Optional SHA256 checksum of the file.
 	Shasum string `json:"shasum,omitempty"`
@@ -117,10 +130,33 @@ type ZarfFile struct {
 	Target string `json:"target"`
 	// (files only) Determines if the file should be made executable during package deploy.
 	Executable bool `json:"executable,omitempty"`
-	// List of symlinks to create during package deploy.
+	// Octal permissions (e.g. 0644) set on the file, or on every file within the folder, during package deploy; overrides 'executable'.
+	Mode string `json:"mode,omitempty"`
+	// User name or ID that owns the file or folder after package deploy.
+	Owner string `json:"owner,omitempty"`
+	// Group name or ID that owns the file or folder after package deploy.
+	Group string `json:"group,omitempty"`
+	// (Linux only) SELinux context (e.g. system_u:object_r:bin_t:s0) set on the file or folder during package deploy.
+	SELinuxLabel string `json:"selinuxLabel,omitempty"`
+	// List of symlinks to create during package deploy, they are replaced if they already exist and point to the target relative to their own location.
 	Symlinks []string `json:"symlinks,omitempty"`
+	// Replace ###ZARF_VAR_*### and ###ZARF_CONST_*### markers in the file, or the text files within the folder, during package deploy.
+	// Binary files are never templated. When not set every text file is templated.
+	Templated *bool `json:"templated,omitempty"`
 	// Local folder or file to be extracted from a 'source' archive.
 	ExtractPath string `json:"extractPath,omitempty"`
+	// Archive format of the 'source' (e.g. tar.gz, zip) when it cannot be determined from its extension, such as for OCI blobs.
+	ExtractFormat string `json:"extractFormat,omitempty"`
+	// Extract the members of a 'source' archive into the target directory.
+	Extract bool `json:"extract,omitempty"`
+	// Number of leading path elements removed from the archive members when extracting them.
+	StripComponents int `json:"stripComponents,omitempty"`
+	// Glob patterns of the archive members to extract, a pattern matching a directory selects everything within it; all members are extracted when empty.
+	ExtractMembers []string `json:"extractMembers,omitempty"`
+	// (files only) Handle the file as a large binary such as a VM disk image or ISO: it is never templated, holes are preserved when it is copied and it is checksummed in chunks.
+	LargeBinary bool `json:"largeBinary,omitempty"`
+	// Store the file, or the files within the folder, without compression in the package archive, for content such as qcow2 images that is already compressed.
+	Uncompressed bool `json:"uncompressed,omitempty"`
 }
 
 // ZarfChart defines a helm chart to be deployed.
@@ -145,8 +181,16 @@ type ZarfChart struct {
 	Wait *bool `json:"wait,omitempty"`
 	// List of local values file paths or remote URLs to include in the package; these will be merged together when deployed.
 	ValuesFiles []string `json:"valuesFiles,omitempty"`
+	// List of values layers that are merged over the values files when they are selected by the package flavor or a deploy time values profile.
+	ValuesLayers []ZarfValuesLayer `json:"valuesLayers,omitempty"`
 	// [alpha] List of variables to set in the Helm chart.
 	Variables []ZarfChartVariable `json:"variables,omitempty"`
+	// Resource conditions to wait for after the chart is installed.
+	WaitFor []ZarfWaitFor `json:"waitFor,omitempty"`
+	// Whether to skip running the Helm hooks of the chart during install, upgrade and rollback.
+	NoHooks bool `json:"noHooks,omitempty"`
+	// Whether to leave the Helm test hooks of the chart out when templating the chart, so the images of the tests are not found.
+	SkipTests bool `json:"skipTests,omitempty"`
 }
 
 // HelmRepoSource represents a Helm chart stored in a Helm repository.
@@ -177,6 +221,16 @@ type OCISource struct {
 	URL string `json:"url"`
 }
 
+// ZarfValuesLayer is a list of values files that is merged over the values files of a chart when it is selected.
+type ZarfValuesLayer struct {
+	// The package flavor that selects the layer during package create, layers of other flavors are left out of the package.
+	Flavor string `json:"flavor,omitempty"`
+	// The values profile that selects the layer during package deploy with --values-profile, layers without a profile are always selected.
+	Profile string `json:"profile,omitempty" jsonschema:"pattern=^[a-z0-9][a-z0-9\\-]*$"`
+	// List of local values file paths or remote URLs to include in the package; these will be merged together in order.
+	Files []string `json:"files"`
+}
+
 // ZarfChartVariable represents a variable that can be set for a Helm chart overrides.
 type ZarfChartVariable struct {
 	// The name of the variable.
@@ -187,20 +241,60 @@ type ZarfChartVariable struct {
 	Path string `json:"path"`
 }
 
+// ZarfWaitFor specifies a resource condition to wait for after a chart or manifests are installed.
+type ZarfWaitFor struct {
+	// The kind of resource to wait for.
+	Kind string `json:"kind" jsonschema:"example=Pod,example=Deployment"`
+	// The name of the resource or selector to wait for.
+	Name string `json:"name,omitempty" jsonschema:"example=podinfo,example=app=podinfo"`
+	// The namespace of the resource to wait for. (Defaults to the namespace of the chart or manifests)
+	Namespace string `json:"namespace,omitempty"`
+	// The condition or jsonpath state to wait for; defaults to exist, a special condition that will wait for the resource to exist.
+	Condition string `json:"condition,omitempty" jsonschema:"example=Ready,example=Available,'{.status.availableReplicas}'=23"`
+	// Maximum number of seconds to wait for the condition. (Defaults to the deploy timeout)
+	MaxTotalSeconds int `json:"maxTotalSeconds,omitempty"`
+}
+
 // ZarfManifest defines raw manifests Zarf will deploy as a helm chart.
 type ZarfManifest struct {
 	// A name to give this collection of manifests; this will become the name of the dynamically-created helm chart.
 	Name string `json:"name"`
 	// The namespace to deploy the manifests to.
 	Namespace string `json:"namespace,omitempty"`
+	// Whether to create the namespace when it does not exist, namespaces created by Zarf are removed with the package when nothing else is deployed to them. (Defaults to true)
+	CreateNamespace *bool `json:"createNamespace,omitempty"`
+	// Labels to add to the namespace when Zarf creates or adopts it.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	// Annotations to add to the namespace when Zarf creates or adopts it.
+	NamespaceAnnotations map[string]string `json:"namespaceAnnotations,omitempty"`
 	// List of local K8s YAML files or remote URLs to deploy (in order).
 	Files []string `json:"files,omitempty"`
 	// Allow traversing directory above the current directory if needed for kustomization. (Defaults to false)
 	KustomizeAllowAnyDirectory bool `json:"kustomizeAllowAnyDirectory,omitempty"`
 	// List of local kustomization paths or remote URLs to include in the package.
 	Kustomizations []string `json:"kustomizations,omitempty"`
+	// Build options used for the kustomizations, matching the flags of kustomize build.
+	KustomizeOptions *ZarfKustomizeOptions `json:"kustomizeOptions,omitempty"`
 	// Whether to not wait for manifest resources to be ready before continuing. (Defaults to true)
 	Wait *bool `json:"wait,omitempty"`
+	// Resource conditions to wait for after the manifests are installed.
+	WaitFor []ZarfWaitFor `json:"waitFor,omitempty"`
+}
+
+// ZarfKustomizeOptions are the build options used for the kustomizations of a manifest.
+type ZarfKustomizeOptions struct {
+	// Inflate the Helm charts of the kustomizations with helm from the PATH (kustomize build --enable-helm). (Defaults to false)
+	EnableHelm bool `json:"enableHelm,omitempty"`
+	// Kubernetes version used by Helm for Capabilities.KubeVersion when inflating charts (kustomize build --helm-kube-version).
+	HelmKubeVersion string `json:"helmKubeVersion,omitempty"`
+	// Kubernetes API versions used by Helm for Capabilities.APIVersions when inflating charts (kustomize build --helm-api-versions).
+	HelmAPIVersions []string `json:"helmAPIVersions,omitempty"`
+	// Restriction on loading files from outside the kustomization root (kustomize build --load-restrictor).
+	LoadRestrictor string `json:"loadRestrictor,omitempty" jsonschema:"enum=LoadRestrictionsRootOnly,enum=LoadRestrictionsNone"`
+	// Run KRM function and exec plugins (kustomize build --enable-alpha-plugins --enable-exec), only plugins in allowedPlugins may run. (Defaults to false)
+	EnableAlphaPlugins bool `json:"enableAlphaPlugins,omitempty"`
+	// Container images, exec paths and apiVersion/kind of legacy plugins that may run when enableAlphaPlugins is set.
+	AllowedPlugins []string `json:"allowedPlugins,omitempty"`
 }
 
 // ZarfComponentActions are ActionSets that map to different zarf package operations.
@@ -255,7 +349,7 @@ type ZarfComponentAction struct {
 	Dir *string `json:"dir,omitempty"`
 	// Additional environment variables to set for the command.
 	Env []string `json:"env,omitempty"`
-	// The command to run. Must specify either cmd or wait for the action to do anything.
+	// The command to run. Must specify one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile for the action to do anything.
 	Cmd string `json:"cmd,omitempty"`
 	// (cmd only) Indicates a preference for a shell for the provided cmd to be executed in on supported operating systems.
 	Shell *Shell `json:"shell,omitempty"`
@@ -263,8 +357,65 @@ type ZarfComponentAction struct {
 	SetVariables []Variable `json:"setVariables,omitempty"`
 	// Description of the action to be displayed during package execution instead of the command.
 	Description string `json:"description,omitempty"`
-	// Wait for a condition to be met before continuing. Must specify either cmd or wait for the action. See the 'zarf tools wait-for' command for more info.
+	// Wait for a condition to be met before continuing. Only one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile can be specified. See the 'zarf tools wait-for' command for more info.
 	Wait *ZarfComponentActionWait `json:"wait,omitempty"`
+	// Run kubectl with the given arguments without a shell, using the kubectl built into Zarf. Only one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile can be specified.
+	Kubectl *ZarfComponentActionKubectl `json:"kubectl,omitempty"`
+	// Install or upgrade a local Helm chart with the Helm SDK. Only one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile can be specified.
+	HelmUpgrade *ZarfComponentActionHelmUpgrade `json:"helmUpgrade,omitempty"`
+	// Send an HTTP GET request and check the status code of the response, the response body is the output of the action. Only one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile can be specified.
+	HTTPGet *ZarfComponentActionHTTPGet `json:"httpGet,omitempty"`
+	// Copy a file or directory. Only one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile can be specified.
+	CopyFile *ZarfComponentActionCopyFile `json:"copyFile,omitempty"`
+}
+
+// ZarfComponentActionKubectl runs kubectl with the given arguments.
+type ZarfComponentActionKubectl struct {
+	// The arguments to pass to kubectl, ${VAR} references are expanded from the environment of the action.
+	Args []string `json:"args" jsonschema:"minItems=1,example=apply,example=-f,example=manifest.yaml"`
+}
+
+// ZarfComponentActionHelmUpgrade installs or upgrades a local Helm chart.
+type ZarfComponentActionHelmUpgrade struct {
+	// The name of the Helm release.
+	ReleaseName string `json:"releaseName" jsonschema:"example=podinfo"`
+	// The path to the chart directory or archive, relative to the working directory of the action.
+	Chart string `json:"chart" jsonschema:"example=chart,example=podinfo-6.4.0.tgz"`
+	// The namespace to install the release into, created if it does not exist (default is the namespace of the kubeconfig context).
+	Namespace string `json:"namespace,omitempty"`
+	// Paths to values files for the chart, relative to the working directory of the action. Later files take precedence.
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+	// Wait for the resources of the release to be ready (default false).
+	Wait bool `json:"wait,omitempty"`
+}
+
+// ZarfComponentActionHTTPGet sends an HTTP GET request.
+type ZarfComponentActionHTTPGet struct {
+	// The URL to send the request to.
+	URL string `json:"url" jsonschema:"example=http://localhost:8080/healthz"`
+	// The HTTP status code the response must have (default is any 2xx code).
+	Code int `json:"code,omitempty" jsonschema:"example=200,example=404"`
+}
+
+// ZarfComponentActionCopyFile copies a file or directory.
+type ZarfComponentActionCopyFile struct {
+	// The file or directory to copy, relative to the working directory of the action.
+	Source string `json:"source"`
+	// The path to copy to, relative to the working directory of the action. Missing parent directories are created.
+	Target string `json:"target"`
+}
+
+// JSONSchemaExtend extends the generated json schema during `zarf internal gen-config-schema`
+func (ZarfComponentAction) JSONSchemaExtend(schema *jsonschema.Schema) {
+	// Allow at most one kind of action.
+	kinds := []string{"cmd", "wait", "kubectl", "helmUpgrade", "httpGet", "copyFile"}
+	pairs := []*jsonschema.Schema{}
+	for i := range kinds {
+		for _, other := range kinds[i+1:] {
+			pairs = append(pairs, &jsonschema.Schema{Required: []string{kinds[i], other}})
+		}
+	}
+	schema.Not = &jsonschema.Schema{AnyOf: pairs}
 }
 
 // ZarfComponentActionWait specifies a condition to wait for before continuing
@@ -279,6 +430,8 @@ type ZarfComponentActionWait struct {
 type ZarfComponentActionWaitCluster struct {
 	// The kind of resource to wait for.
 	Kind string `json:"kind" jsonschema:"example=Pod,example=Deployment"`
+	// The API version of the resource to wait for. When set the resource is waited for with the Kubernetes API instead of kubectl, the kind may then also be the resource name of a custom resource.
+	APIVersion string `json:"apiVersion,omitempty" jsonschema:"example=cert-manager.io/v1,example=apps/v1"`
 	// The name of the resource or selector to wait for.
 	Name string `json:"name" jsonschema:"example=podinfo,example=app=podinfo"`
 	// The namespace of the resource to wait for.
@@ -309,6 +462,30 @@ type ZarfContainerTarget struct {
 	Path string `json:"path"`
 }
 
+// ZarfPackageMirror defines a set of pinned language packages to mirror into the artifact server.
+type ZarfPackageMirror struct {
+	// The type of packages to mirror.
+	Type string `json:"type" jsonschema:"enum=pypi,enum=npm"`
+	// Local path to the requirements.txt (pypi) or package-lock.json (npm) that pins the packages.
+	Source string `json:"source"`
+	// URL of the PyPI index to pull packages from, defaults to https://pypi.org. npm packages are pulled from the URLs resolved in the lockfile.
+	Index string `json:"index,omitempty"`
+}
+
+// ZarfRepoOptions limits how much of a git repo is cloned into the package.
+type ZarfRepoOptions struct {
+	// The url of the repo in repos the options apply to, including its '@' ref.
+	URL string `json:"url"`
+	// Number of commits from the tip of each ref to clone, the full history is cloned when not set.
+	Depth int `json:"depth,omitempty" jsonschema:"minimum=0"`
+	// Only clone the default branch when the url does not have a ref. Urls with a ref always clone a single branch or tag.
+	SingleBranch bool `json:"singleBranch,omitempty"`
+	// Whether to clone the tags of the repo. Defaults to true for urls without a ref and false for urls with a ref.
+	IncludeTags *bool `json:"includeTags,omitempty"`
+	// Only check out these directories of the repo, the git history still contains all of the files.
+	SparsePaths []string `json:"sparsePaths,omitempty"`
+}
+
 // ZarfDataInjection is a data-injection definition.
 type ZarfDataInjection struct {
 	// Either a path to a local folder/file or a remote URL of a file to inject into the given target pod + container.
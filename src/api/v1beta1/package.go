@@ -52,6 +52,12 @@ type ZarfPackage struct {
 	Constants []Constant `json:"constants,omitempty"`
 	// Variable template values applied on deploy for K8s resources.
 	Variables []InteractiveVariable `json:"variables,omitempty"`
+	// Notes printed after a successful deploy, templated with the package variables and constants.
+	Notes string `json:"notes,omitempty"`
+	// Variables exported to the cluster after a successful deploy for packages deployed later to import.
+	Exports []PackageExport `json:"exports,omitempty"`
+	// Variables imported from the exports of packages deployed before this package.
+	Imports []PackageImport `json:"imports,omitempty"`
 }
 
 // IsInitConfig returns whether a Zarf package is an init config.
@@ -104,6 +110,22 @@ type InteractiveVariable struct {
 	Prompt bool `json:"prompt,omitempty"`
 }
 
+// PackageExport is a variable a package exports for packages deployed after it to import.
+type PackageExport struct {
+	// The name of the variable to export, set by a package variable or the setVariables of an action.
+	Name string `json:"name" jsonschema:"pattern=^[A-Z0-9_]+$"`
+	// A description of the exported variable.
+	Description string `json:"description,omitempty"`
+}
+
+// PackageImport is a variable a package imports from the exports of a package deployed before it.
+type PackageImport struct {
+	// The name of the deployed package that exports the variable.
+	Package string `json:"package"`
+	// The name of the exported variable, which is set as the variable of the same name in this package.
+	Name string `json:"name" jsonschema:"pattern=^[A-Z0-9_]+$"`
+}
+
 // Constant are constants that can be used to dynamically template K8s resources or run in actions.
 type Constant struct {
 	// The name to be used for the constant
@@ -147,6 +169,8 @@ type ZarfMetadata struct {
 	Airgap *bool `json:"airgap,omitempty"`
 	// Annotations are key-value pairs that can be used to store metadata about the package.
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// The minimum version of the Helm engine built into Zarf that the package can be created and deployed with.
+	MinimumHelmVersion string `json:"minimumHelmVersion,omitempty" jsonschema:"example=3.16.0"`
 }
 
 // ZarfBuildData is written during the packager.Create() operation to track details of the created package.
@@ -159,14 +183,20 @@ type ZarfBuildData struct {
 	User string `json:"user"`
 	// The architecture this package was created on.
 	Architecture string `json:"architecture"`
+	// The architectures of the images and components in a package created for multiple architectures.
+	Architectures []string `json:"architectures,omitempty"`
 	// The timestamp when this package was created.
 	Timestamp string `json:"timestamp"`
 	// The version of Zarf used to build this package.
 	Version string `json:"version"`
+	// The version of the Helm engine built into the Zarf used to build this package.
+	HelmVersion string `json:"helmVersion,omitempty"`
 	// Any migrations that have been run on this package.
 	Migrations []string `json:"migrations,omitempty"`
 	// Any registry domains that were overridden on package create when pulling images.
 	RegistryOverrides map[string]string `json:"registryOverrides,omitempty"`
+	// Any images that were flattened into a single layer on package create.
+	FlattenedImages []string `json:"flattenedImages,omitempty"`
 	// Whether this package was created with differential components.
 	Differential bool `json:"differential,omitempty"`
 	// Version of a previously built package used as the basis for creating this differential package.
@@ -177,4 +207,50 @@ type ZarfBuildData struct {
 	LastNonBreakingVersion string `json:"lastNonBreakingVersion,omitempty"`
 	// The flavor of Zarf used to build this package.
 	Flavor string `json:"flavor,omitempty"`
+	// The size and content of each component in this package.
+	Components []ZarfComponentBuildData `json:"components,omitempty"`
+}
+
+// ZarfComponentBuildData records the size and content of a component when the package was created.
+type ZarfComponentBuildData struct {
+	// The name of the component.
+	Name string `json:"name"`
+	// The uncompressed size in bytes of the component files, charts, manifests and data injections.
+	Size int64 `json:"size"`
+	// The number of images in the component.
+	ImageCount int `json:"imageCount,omitempty"`
+	// The compressed size in bytes of the images in the component, layers shared between images are counted once.
+	ImagesSize int64 `json:"imagesSize,omitempty"`
+	// The images in the component.
+	Images []ZarfImageBuildData `json:"images,omitempty"`
+	// The checksums of the charts in the component, in the same order as the component charts.
+	Charts []ZarfContentBuildData `json:"charts,omitempty"`
+	// The checksums of the files in the component, in the same order as the component files.
+	Files []ZarfContentBuildData `json:"files,omitempty"`
+}
+
+// ZarfContentBuildData records the checksum of a chart or file when the package was created.
+type ZarfContentBuildData struct {
+	// The name of the chart or the target of the file.
+	Name string `json:"name"`
+	// The SHA256 checksum of the chart archive contents and values files, or of the file or directory.
+	Checksum string `json:"checksum"`
+	// Whether the content was left out of this differential package because it did not change from the package it was created against.
+	Differential bool `json:"differential,omitempty"`
+	// The size in bytes of the chunks of a large binary file.
+	ChunkSize int64 `json:"chunkSize,omitempty"`
+	// The SHA256 checksums of the consecutive chunks of a large binary file.
+	Chunks []string `json:"chunks,omitempty"`
+}
+
+// ZarfImageBuildData records the digest and size of an image when the package was created.
+type ZarfImageBuildData struct {
+	// The image reference from the component.
+	Name string `json:"name"`
+	// The manifest digest of the image in the package.
+	Digest string `json:"digest"`
+	// The compressed size in bytes of the image manifest, config and layers.
+	Size int64 `json:"size"`
+	// The architecture of the image in a package created for multiple architectures.
+	Architecture string `json:"architecture,omitempty"`
 }
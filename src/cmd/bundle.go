@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zarf-dev/zarf/src/cmd/common"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/bundle"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+// NewBundleCommand creates the `bundle` sub-command and its nested children.
+func NewBundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: lang.CmdBundleShort,
+	}
+
+	v := common.GetViper()
+
+	cmd.AddCommand(NewBundleCreateCommand(v))
+	cmd.AddCommand(NewBundleDeployCommand(v))
+
+	return cmd
+}
+
+// BundleCreateOptions holds the command-line options for 'bundle create' sub-command.
+type BundleCreateOptions struct {
+	output string
+}
+
+// NewBundleCreateCommand creates the `bundle create` sub-command.
+func NewBundleCreateCommand(v *viper.Viper) *cobra.Command {
+	o := &BundleCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [ BUNDLE_YAML ]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: lang.CmdBundleCreateShort,
+		Long:  lang.CmdBundleCreateLong,
+		RunE:  o.Run,
+	}
+
+	cmd.Flags().StringVarP(&o.output, "output", "o", v.GetString(common.VBundleCreateOutput), lang.CmdBundleCreateFlagOutput)
+
+	return cmd
+}
+
+// Run performs the execution of 'bundle create' sub-command.
+func (o *BundleCreateOptions) Run(cmd *cobra.Command, args []string) error {
+	path := "bundle.yaml"
+	if len(args) > 0 {
+		path = args[0]
+	}
+	output := o.output
+	if output == "" {
+		output = "."
+	}
+	dst, err := bundle.Create(cmd.Context(), path, bundle.CreateOptions{Output: output})
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	message.Successf("Created bundle %s", dst)
+	return nil
+}
+
+// BundleDeployOptions holds the command-line options for 'bundle deploy' sub-command.
+type BundleDeployOptions struct {
+	setVariables            map[string]string
+	publicKeyPath           string
+	skipSignatureValidation bool
+}
+
+// NewBundleDeployCommand creates the `bundle deploy` sub-command.
+func NewBundleDeployCommand(v *viper.Viper) *cobra.Command {
+	o := &BundleDeployOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "deploy BUNDLE",
+		Args:  cobra.ExactArgs(1),
+		Short: lang.CmdBundleDeployShort,
+		Long:  lang.CmdBundleDeployLong,
+		RunE:  o.Run,
+	}
+
+	// Always require confirm flag (no viper)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdBundleDeployFlagConfirm)
+
+	cmd.Flags().StringToStringVar(&o.setVariables, "set", v.GetStringMapString(common.VBundleDeploySet), lang.CmdBundleDeployFlagSet)
+	cmd.Flags().StringVarP(&o.publicKeyPath, "key", "k", v.GetString(common.VBundleDeployKey), lang.CmdBundleDeployFlagKey)
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", false, lang.CmdBundleDeployFlagSkipSignatureValidation)
+
+	return cmd
+}
+
+// Run performs the execution of 'bundle deploy' sub-command.
+func (o *BundleDeployOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	v := common.GetViper()
+	setVariables := helpers.TransformAndMergeMap(v.GetStringMapString(common.VBundleDeploySet), o.setVariables, strings.ToUpper)
+
+	c, _ := cluster.NewCluster() //nolint:errcheck
+	policy, err := verificationPolicy(ctx, c, true)
+	if err != nil {
+		return err
+	}
+	opt := bundle.DeployOptions{
+		SetVariables:            setVariables,
+		PublicKeyPath:           o.publicKeyPath,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		VerificationPolicy:      policy,
+	}
+	if err := bundle.Deploy(ctx, args[0], opt); err != nil {
+		offerSupportBundle(ctx)
+		return fmt.Errorf("failed to deploy bundle: %w", err)
+	}
+	message.Successf("Zarf bundle deployment complete")
+	return nil
+}
@@ -19,11 +19,12 @@ import (
 // OutputWriter provides a writer to stdout for user-focused output
 var OutputWriter = os.Stdout
 
-// PrintFindings prints the findings in the LintError as a table.
+// PrintFindings prints the findings in the LintError as a table, with the findings of the security rules in a separate table.
 func PrintFindings(ctx context.Context, lintErr *lint.LintError) {
 	mapOfFindingsByPath := lint.GroupFindingsByPath(lintErr.Findings, lintErr.PackageName)
 	for _, findings := range mapOfFindingsByPath {
 		lintData := [][]string{}
+		securityData := [][]string{}
 		for _, finding := range findings {
 			sevColor := color.FgWhite
 			switch finding.Severity {
@@ -33,6 +34,15 @@ func PrintFindings(ctx context.Context, lintErr *lint.LintError) {
 				sevColor = color.FgYellow
 			}
 
+			if finding.Rule != "" {
+				securityData = append(securityData, []string{
+					colorWrap(string(finding.Severity), sevColor),
+					finding.Rule,
+					colorWrap(finding.YqPath, color.FgCyan),
+					finding.ItemizedDescription(),
+				})
+				continue
+			}
 			lintData = append(lintData, []string{
 				colorWrap(string(finding.Severity), sevColor),
 				colorWrap(finding.YqPath, color.FgCyan),
@@ -49,7 +59,13 @@ func PrintFindings(ctx context.Context, lintErr *lint.LintError) {
 		// Print table to our OutputWriter
 		message.Notef("Linting package %q at %s", findings[0].PackageNameOverride, packagePathFromUser)
 		logger.From(ctx).Info("linting package", "name", findings[0].PackageNameOverride, "path", packagePathFromUser)
-		message.TableWithWriter(OutputWriter, []string{"Type", "Path", "Message"}, lintData)
+		if len(lintData) > 0 {
+			message.TableWithWriter(OutputWriter, []string{"Type", "Path", "Message"}, lintData)
+		}
+		if len(securityData) > 0 {
+			message.Notef("Security findings of package %q", findings[0].PackageNameOverride)
+			message.TableWithWriter(OutputWriter, []string{"Type", "Rule", "Path", "Message"}, securityData)
+		}
 	}
 }
 
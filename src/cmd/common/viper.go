@@ -9,12 +9,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"github.com/spf13/viper"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // Constants for use when loading configurations from viper config files
@@ -28,6 +30,11 @@ const (
 	VInsecure              = "insecure"
 	VPlainHTTP             = "plain_http"
 	VInsecureSkipTLSVerify = "insecure_skip_tls_verify"
+	VStateKey              = "state_key"
+	VResourceLabels        = "resource_labels"
+	VResourceAnnotations   = "resource_annotations"
+	VRegistryPresets       = "registry_presets"
+	VChartRepos            = "chart_repos"
 
 	// Root config, Logging
 
@@ -39,8 +46,9 @@ const (
 
 	// Init config keys
 
-	VInitComponents   = "init.components"
-	VInitStorageClass = "init.storage_class"
+	VInitComponents      = "init.components"
+	VInitStorageClass    = "init.storage_class"
+	VInitStateRecipients = "init.state_recipients"
 
 	// Init Git config keys
 
@@ -60,6 +68,19 @@ const (
 	VInitRegistryPullUser = "init.registry.pull_username"
 	VInitRegistryPullPass = "init.registry.pull_password"
 
+	// Init Injector config keys
+
+	VInitInjectorImage         = "init.injector.image"
+	VInitInjectorBinary        = "init.injector.binary"
+	VInitInjectorBinaryShasum  = "init.injector.binary_shasum"
+	VInitInjectorSeedImage     = "init.injector.seed_image"
+	VInitInjectorCPURequest    = "init.injector.cpu_request"
+	VInitInjectorMemoryRequest = "init.injector.memory_request"
+	VInitInjectorCPULimit      = "init.injector.cpu_limit"
+	VInitInjectorMemoryLimit   = "init.injector.memory_limit"
+	VInitInjectorTimeout       = "init.injector.timeout"
+	VInitInjectorChunkSize     = "init.injector.payload_chunk_size"
+
 	// Init Package config keys
 
 	VInitArtifactURL       = "init.artifact.url"
@@ -68,44 +89,125 @@ const (
 
 	// Package config keys
 
-	VPkgOCIConcurrency = "package.oci_concurrency"
-	VPkgPublicKey      = "package.public_key"
+	VPkgOCIConcurrency        = "package.oci_concurrency"
+	VPkgPublicKey             = "package.public_key"
+	VPkgVerificationPolicy    = "package.verification_policy"
+	VPkgCertificateIdentity   = "package.certificate_identity"
+	VPkgCertificateOIDCIssuer = "package.certificate_oidc_issuer"
 
 	// Package create config keys
 
-	VPkgCreateSet                = "package.create.set"
-	VPkgCreateOutput             = "package.create.output"
-	VPkgCreateSbom               = "package.create.sbom"
-	VPkgCreateSbomOutput         = "package.create.sbom_output"
-	VPkgCreateSkipSbom           = "package.create.skip_sbom"
-	VPkgCreateMaxPackageSize     = "package.create.max_package_size"
-	VPkgCreateSigningKey         = "package.create.signing_key"
-	VPkgCreateSigningKeyPassword = "package.create.signing_key_password"
-	VPkgCreateDifferential       = "package.create.differential"
-	VPkgCreateRegistryOverride   = "package.create.registry_override"
-	VPkgCreateFlavor             = "package.create.flavor"
+	VPkgCreateSet                     = "package.create.set"
+	VPkgCreateOutput                  = "package.create.output"
+	VPkgCreateSbom                    = "package.create.sbom"
+	VPkgCreateSbomOutput              = "package.create.sbom_output"
+	VPkgCreateSkipSbom                = "package.create.skip_sbom"
+	VPkgCreateSkipSbomViewer          = "package.create.skip_sbom_viewer"
+	VPkgCreateMaxPackageSize          = "package.create.max_package_size"
+	VPkgCreateSigningKey              = "package.create.signing_key"
+	VPkgCreateSigningKeyPassword      = "package.create.signing_key_password"
+	VPkgCreateSignChecksums           = "package.create.sign_checksums"
+	VPkgCreateTSAURL                  = "package.create.tsa_url"
+	VPkgCreateKeyless                 = "package.create.keyless"
+	VPkgCreateDifferential            = "package.create.differential"
+	VPkgCreateRegistryOverride        = "package.create.registry_override"
+	VPkgCreateFlavor                  = "package.create.flavor"
+	VPkgCreateFlattenImages           = "package.create.flatten_images"
+	VPkgCreateConcurrency             = "package.create.create_concurrency"
+	VPkgCreateDownloadCacheTTL        = "package.create.download_cache_ttl"
+	VPkgCreateSkipDownloadCacheVerify = "package.create.skip_download_cache_verify"
+	VPkgCreateDownloadConnections     = "package.create.download_connections"
+	VPkgCreateRecursive               = "package.create.recursive"
+	VPkgCreateImageAllow              = "package.create.image_allow"
+	VPkgCreateImageDeny               = "package.create.image_deny"
+	VPkgCreateRegistryPresets         = "package.create.registry_presets"
+	VPkgCreateSnapshotCharts          = "package.create.snapshot_charts"
+	VPkgCreateArchitectures           = "package.create.architectures"
 
 	// Package deploy config keys
 
-	VPkgDeploySet        = "package.deploy.set"
-	VPkgDeployComponents = "package.deploy.components"
-	VPkgDeployShasum     = "package.deploy.shasum"
-	VPkgDeploySget       = "package.deploy.sget"
-	VPkgDeployTimeout    = "package.deploy.timeout"
-	VPkgRetries          = "package.deploy.retries"
+	VPkgDeploySet                    = "package.deploy.set"
+	VPkgDeployComponents             = "package.deploy.components"
+	VPkgDeployShasum                 = "package.deploy.shasum"
+	VPkgDeploySget                   = "package.deploy.sget"
+	VPkgDeployTimeout                = "package.deploy.timeout"
+	VPkgDeployReadinessTimeout       = "package.deploy.readiness_timeout"
+	VPkgDeployScopedCredentials      = "package.deploy.scoped_credentials"
+	VPkgDeployStrictCredentialExpiry = "package.deploy.strict_credential_expiry"
+	VPkgDeployClusterContexts        = "package.deploy.cluster_contexts"
+	VPkgDeployValuesProfiles         = "package.deploy.values_profiles"
+	VPkgDeploySkipImagePush          = "package.deploy.skip_image_push"
+	VPkgDeployImagePushDryRun        = "package.deploy.image_push_dry_run"
+	VPkgDeployAttestationKey         = "package.deploy.attestation_key"
+	VPkgDeployKeylessAttestations    = "package.deploy.keyless_attestations"
+	VPkgDeploySetHelmValues          = "package.deploy.set_helm_values"
+	VPkgDeployPostRenderer           = "package.deploy.post_renderer"
+	VPkgRetries                      = "package.deploy.retries"
 
 	// Package publish config keys
 
 	VPkgPublishSigningKey         = "package.publish.signing_key"
 	VPkgPublishSigningKeyPassword = "package.publish.signing_key_password"
+	VPkgPublishTSAURL             = "package.publish.tsa_url"
+	VPkgPublishKeyless            = "package.publish.keyless"
+	VPkgPublishRetries            = "package.publish.retries"
+	VPkgPublishManifestType       = "package.publish.manifest_type"
+	VPkgPublishAttestations       = "package.publish.attestations"
 
 	// Package pull config keys
 
 	VPkgPullOutputDir = "package.pull.output_directory"
 
+	// Bundle config keys
+
+	VBundleCreateOutput = "bundle.create.output"
+	VBundleDeploySet    = "bundle.deploy.set"
+	VBundleDeployKey    = "bundle.deploy.public_key"
+
 	// Dev deploy config keys
 
 	VDevDeployNoYolo = "dev.deploy.no_yolo"
+
+	// Dev lint config keys
+
+	VDevLintSecuritySeverity = "dev.lint.security_severity"
+
+	// Dev registry config keys
+
+	VDevRegistryAddress    = "dev.registry.address"
+	VDevRegistryRuntime    = "dev.registry.runtime"
+	VDevRegistryStorageDir = "dev.registry.storage_dir"
+	VDevRegistryImage      = "dev.registry.image"
+
+	// Dev cluster config keys
+
+	VDevClusterName       = "dev.cluster.name"
+	VDevClusterProvider   = "dev.cluster.provider"
+	VDevClusterImage      = "dev.cluster.image"
+	VDevClusterComponents = "dev.cluster.components"
+
+	// Dev release config keys
+
+	VDevReleaseBase      = "dev.release.base"
+	VDevReleaseChangelog = "dev.release.changelog"
+	VDevReleaseTag       = "dev.release.tag"
+
+	// Serve config keys
+
+	VServeAddress = "serve.address"
+	VServeToken   = "serve.token"
+	VServeAuth    = "serve.auth"
+	VServeTLSCert = "serve.tls_cert"
+	VServeTLSKey  = "serve.tls_key"
+	VServeRoot    = "serve.root"
+
+	// Internal agent config keys
+
+	VInternalAgentSecretSync            = "internal.agent.secret_sync"
+	VInternalAgentImageCheck            = "internal.agent.image_check"
+	VInternalAgentRegistryProxy         = "internal.agent.registry_proxy"
+	VInternalAgentRegistryProxyMirrors  = "internal.agent.registry_proxy_mirrors"
+	VInternalAgentRegistryProxyNodePort = "internal.agent.registry_proxy_node_port"
 )
 
 var (
@@ -161,6 +263,81 @@ func GetViper() *viper.Viper {
 	return v
 }
 
+// GetRegistryPresets returns the registry presets with the given names from the registry_presets of the config file.
+func GetRegistryPresets(v *viper.Viper, names []string) ([]types.RegistryPreset, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	configured := map[string]struct {
+		URL            string   `mapstructure:"url"`
+		Auth           string   `mapstructure:"auth"`
+		Username       string   `mapstructure:"username"`
+		Password       string   `mapstructure:"password"`
+		RequiredLabels []string `mapstructure:"required_labels"`
+	}{}
+	if err := v.UnmarshalKey(VRegistryPresets, &configured); err != nil {
+		return nil, fmt.Errorf("unable to read the registry presets of the config file: %w", err)
+	}
+	presets := []types.RegistryPreset{}
+	for _, name := range names {
+		// Viper lower cases the keys of the config file.
+		c, ok := configured[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("registry preset %s is not set in the %s of the config file", name, VRegistryPresets)
+		}
+		preset := types.RegistryPreset{
+			Name:           name,
+			URL:            c.URL,
+			Auth:           c.Auth,
+			Username:       c.Username,
+			Password:       c.Password,
+			RequiredLabels: c.RequiredLabels,
+		}
+		if err := preset.Validate(); err != nil {
+			return nil, err
+		}
+		presets = append(presets, preset)
+	}
+	return presets, nil
+}
+
+// GetChartRepoCredentials returns the credentials of the helm chart repositories set in the chart_repos of the config file.
+func GetChartRepoCredentials(v *viper.Viper) ([]types.ChartRepoCredential, error) {
+	configured := map[string]struct {
+		URL      string `mapstructure:"url"`
+		Username string `mapstructure:"username"`
+		Password string `mapstructure:"password"`
+		Token    string `mapstructure:"token"`
+		CAFile   string `mapstructure:"ca_file"`
+		CertFile string `mapstructure:"cert_file"`
+		KeyFile  string `mapstructure:"key_file"`
+	}{}
+	if err := v.UnmarshalKey(VChartRepos, &configured); err != nil {
+		return nil, fmt.Errorf("unable to read the chart repos of the config file: %w", err)
+	}
+	creds := []types.ChartRepoCredential{}
+	for name, c := range configured {
+		if c.URL == "" {
+			return nil, fmt.Errorf("chart repo %s in the %s of the config file must have a url", name, VChartRepos)
+		}
+		creds = append(creds, types.ChartRepoCredential{
+			Name:     name,
+			URL:      c.URL,
+			Username: c.Username,
+			Password: c.Password,
+			Token:    c.Token,
+			CAFile:   c.CAFile,
+			CertFile: c.CertFile,
+			KeyFile:  c.KeyFile,
+		})
+	}
+	// Map iteration order is random, keep the credentials stable for matching and logging.
+	slices.SortFunc(creds, func(a, b types.ChartRepoCredential) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return creds, nil
+}
+
 func isVersionCmd() bool {
 	args := os.Args
 	return len(args) > 1 && (args[1] == "version" || args[1] == "v")
@@ -197,7 +374,29 @@ func setDefaults() {
 	// Package defaults that are non-zero values
 	v.SetDefault(VPkgOCIConcurrency, 3)
 	v.SetDefault(VPkgRetries, config.ZarfDefaultRetries)
+	v.SetDefault(VPkgPublishRetries, config.ZarfDefaultRetries)
+	v.SetDefault(VPkgPublishManifestType, "default")
+	v.SetDefault(VPkgCreateConcurrency, 1)
+	v.SetDefault(VPkgCreateDownloadConnections, 1)
 
 	// Deploy opts that are non-zero values
 	v.SetDefault(VPkgDeployTimeout, config.ZarfDefaultTimeout)
+
+	// Dev registry opts that are non-zero values
+	v.SetDefault(VDevRegistryAddress, "127.0.0.1:5000")
+	v.SetDefault(VDevRegistryRuntime, "auto")
+	v.SetDefault(VDevRegistryImage, "docker.io/library/registry:2")
+	v.SetDefault(VDevReleaseChangelog, "CHANGELOG.md")
+
+	// Dev cluster opts that are non-zero values
+	v.SetDefault(VDevClusterName, "zarf-dev")
+	v.SetDefault(VDevClusterProvider, "auto")
+
+	// Serve opts that are non-zero values
+	v.SetDefault(VServeAddress, "127.0.0.1:8675")
+	v.SetDefault(VServeAuth, "token")
+
+	// Internal agent opts that are non-zero values
+	v.SetDefault(VInternalAgentSecretSync, "none")
+	v.SetDefault(VInternalAgentRegistryProxyNodePort, 31998)
 }
@@ -5,6 +5,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -14,12 +15,15 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // ConnectOptions holds the command-line options for 'connect' sub-command.
 type ConnectOptions struct {
-	cliOnly bool
-	zt      cluster.TunnelInfo
+	cliOnly    bool
+	printCreds bool
+	embedCreds bool
+	zt         cluster.TunnelInfo
 }
 
 // NewConnectCommand creates the `connect` sub-command and its nested children.
@@ -40,6 +44,9 @@ func NewConnectCommand() *cobra.Command {
 	cmd.Flags().IntVar(&o.zt.LocalPort, "local-port", 0, lang.CmdConnectFlagLocalPort)
 	cmd.Flags().IntVar(&o.zt.RemotePort, "remote-port", 0, lang.CmdConnectFlagRemotePort)
 	cmd.Flags().BoolVar(&o.cliOnly, "cli-only", false, lang.CmdConnectFlagCliOnly)
+	cmd.Flags().BoolVar(&o.printCreds, "print-creds", false, lang.CmdConnectFlagPrintCreds)
+	cmd.Flags().BoolVar(&o.embedCreds, "embed-creds", false, lang.CmdConnectFlagEmbedCreds)
+	cmd.MarkFlagsMutuallyExclusive("cli-only", "embed-creds")
 
 	// TODO(soltysh): consider splitting sub-commands into separate files
 	cmd.AddCommand(NewConnectListCommand())
@@ -64,6 +71,14 @@ func (o *ConnectOptions) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var credential types.Credential
+	if o.printCreds || o.embedCreds {
+		credential, err = connectCredential(ctx, c, target)
+		if err != nil {
+			return err
+		}
+	}
+
 	var tunnel *cluster.Tunnel
 	if target == "" {
 		tunnel, err = c.ConnectTunnelInfo(ctx, o.zt)
@@ -85,13 +100,22 @@ func (o *ConnectOptions) Run(cmd *cobra.Command, args []string) error {
 
 	defer tunnel.Close()
 
+	if o.printCreds {
+		message.PrintCredentials([]types.Credential{credential})
+		l.Info("credentials for the service", "application", credential.Application, "username", credential.Username, "get-creds-key", credential.Key)
+	}
+
 	if o.cliOnly {
 		spinner.Updatef(lang.CmdConnectEstablishedCLI, tunnel.FullURL())
 		l.Info("Tunnel established, waiting for user to interrupt (ctrl-c to end)", "url", tunnel.FullURL())
 	} else {
 		spinner.Updatef(lang.CmdConnectEstablishedWeb, tunnel.FullURL())
 		l.Info("Tunnel established, opening your default web browser (ctrl-c to end)", "url", tunnel.FullURL())
-		if err := exec.LaunchURL(tunnel.FullURL()); err != nil {
+		launchURL := tunnel.FullURL()
+		if o.embedCreds {
+			launchURL = tunnel.FullURLWithCredentials(credential.Username, credential.Password)
+		}
+		if err := exec.LaunchURL(launchURL); err != nil {
 			return err
 		}
 	}
@@ -106,6 +130,19 @@ func (o *ConnectOptions) Run(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// connectCredential returns the credential of the Zarf service that the target connects to from the Zarf state.
+func connectCredential(ctx context.Context, c *cluster.Cluster, target string) (types.Credential, error) {
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return types.Credential{}, err
+	}
+	credential, ok := message.ConnectCredential(state, target)
+	if !ok {
+		return types.Credential{}, fmt.Errorf("no credentials are stored in the Zarf state for %q, credentials can only be retrieved for %s and %s", target, message.RegistryKey, message.GitKey)
+	}
+	return credential, nil
+}
+
 // ConnectListOptions holds the command-line options for 'connect list' sub-command.
 type ConnectListOptions struct{}
 
@@ -22,10 +22,15 @@ import (
 	"github.com/zarf-dev/zarf/src/cmd/common"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/devcluster"
+	"github.com/zarf-dev/zarf/src/internal/registry"
+	"github.com/zarf-dev/zarf/src/internal/release"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/packager/composer"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/types"
@@ -50,6 +55,10 @@ func NewDevCommand() *cobra.Command {
 	cmd.AddCommand(NewDevFindImagesCommand(v))
 	cmd.AddCommand(NewDevGenerateConfigCommand())
 	cmd.AddCommand(NewDevLintCommand(v))
+	cmd.AddCommand(NewDevShowCommand(v))
+	cmd.AddCommand(NewDevRegistryCommand(v))
+	cmd.AddCommand(NewDevClusterCommand(v))
+	cmd.AddCommand(NewDevReleaseCommand(v))
 
 	return cmd
 }
@@ -73,6 +82,10 @@ func NewDevDeployCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringToStringVar(&pkgConfig.CreateOpts.SetVariables, "create-set", v.GetStringMapString(common.VPkgCreateSet), lang.CmdPackageCreateFlagSet)
 	cmd.Flags().StringToStringVar(&pkgConfig.CreateOpts.RegistryOverrides, "registry-override", v.GetStringMapString(common.VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
 	cmd.Flags().StringVarP(&pkgConfig.CreateOpts.Flavor, "flavor", "f", v.GetString(common.VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	cmd.Flags().IntVar(&pkgConfig.CreateOpts.CreateConcurrency, "create-concurrency", v.GetInt(common.VPkgCreateConcurrency), lang.CmdPackageCreateFlagConcurrency)
+	cmd.Flags().DurationVar(&config.CommonOptions.DownloadCacheTTL, "download-cache-ttl", v.GetDuration(common.VPkgCreateDownloadCacheTTL), lang.CmdPackageCreateFlagDownloadCacheTTL)
+	cmd.Flags().BoolVar(&config.CommonOptions.SkipDownloadCacheVerify, "skip-download-cache-verify", v.GetBool(common.VPkgCreateSkipDownloadCacheVerify), lang.CmdPackageCreateFlagSkipDownloadCacheVerify)
+	cmd.Flags().IntVar(&config.CommonOptions.DownloadConnections, "download-connections", v.GetInt(common.VPkgCreateDownloadConnections), lang.CmdPackageCreateFlagDownloadConnections)
 
 	cmd.Flags().StringVar(&pkgConfig.DeployOpts.RegistryURL, "registry-url", defaultRegistry, lang.CmdDevFlagRegistry)
 	err := cmd.Flags().MarkHidden("registry-url")
@@ -85,6 +98,7 @@ func NewDevDeployCommand(v *viper.Viper) *cobra.Command {
 	// Always require adopt-existing-resources flag (no viper)
 	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AdoptExistingResources, "adopt-existing-resources", false, lang.CmdPackageDeployFlagAdoptExistingResources)
 	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.Timeout, "timeout", v.GetDuration(common.VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
+	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.ReadinessTimeout, "readiness-timeout", v.GetDuration(common.VPkgDeployReadinessTimeout), lang.CmdPackageDeployFlagReadinessTimeout)
 
 	cmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(common.VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VPkgDeployComponents), lang.CmdPackageDeployFlagComponents)
@@ -103,6 +117,7 @@ func (o *DevDeployOptions) Run(cmd *cobra.Command, args []string) error {
 	pkgConfig.CreateOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(common.VPkgCreateSet), pkgConfig.CreateOpts.SetVariables, strings.ToUpper)
 
+	pkgConfig.PkgOpts.ConfigVariables = configVariables(cmd, "deploy-set", v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables)
 	pkgConfig.PkgOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
 
@@ -449,7 +464,12 @@ func (o *DevGenerateConfigOptions) Run(_ *cobra.Command, args []string) error {
 }
 
 // DevLintOptions holds the command-line options for 'dev lint' sub-command.
-type DevLintOptions struct{}
+type DevLintOptions struct {
+	securitySeverity map[string]string
+	imageAllow       []string
+	imageDeny        []string
+	registryPresets  []string
+}
 
 // NewDevLintCommand creates the `dev lint` sub-command.
 func NewDevLintCommand(v *viper.Viper) *cobra.Command {
@@ -466,6 +486,10 @@ func NewDevLintCommand(v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().StringToStringVar(&pkgConfig.CreateOpts.SetVariables, "set", v.GetStringMapString(common.VPkgCreateSet), lang.CmdPackageCreateFlagSet)
 	cmd.Flags().StringVarP(&pkgConfig.CreateOpts.Flavor, "flavor", "f", v.GetString(common.VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	cmd.Flags().StringToStringVar(&o.securitySeverity, "security-severity", v.GetStringMapString(common.VDevLintSecuritySeverity), lang.CmdDevLintFlagSecuritySeverity)
+	cmd.Flags().StringSliceVar(&o.imageAllow, "image-allow", v.GetStringSlice(common.VPkgCreateImageAllow), lang.CmdPackageCreateFlagImageAllow)
+	cmd.Flags().StringSliceVar(&o.imageDeny, "image-deny", v.GetStringSlice(common.VPkgCreateImageDeny), lang.CmdPackageCreateFlagImageDeny)
+	cmd.Flags().StringSliceVar(&o.registryPresets, "registry-preset", v.GetStringSlice(common.VPkgCreateRegistryPresets), lang.CmdPackageCreateFlagRegistryPreset)
 
 	return cmd
 }
@@ -479,7 +503,20 @@ func (o *DevLintOptions) Run(cmd *cobra.Command, args []string) error {
 	pkgConfig.CreateOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(common.VPkgCreateSet), pkgConfig.CreateOpts.SetVariables, strings.ToUpper)
 
-	err := lint.Validate(ctx, pkgConfig.CreateOpts.BaseDir, pkgConfig.CreateOpts.Flavor, pkgConfig.CreateOpts.SetVariables)
+	securitySeverities, err := lint.ParseSecuritySeverities(o.securitySeverity)
+	if err != nil {
+		return err
+	}
+	imagePolicy, err := lint.ParseImagePolicy(o.imageAllow, o.imageDeny)
+	if err != nil {
+		return err
+	}
+	registryPresets, err := common.GetRegistryPresets(v, o.registryPresets)
+	if err != nil {
+		return err
+	}
+	imagePolicy = imagePolicy.AllowRegistryPresets(registryPresets)
+	err = lint.Validate(ctx, pkgConfig.CreateOpts.BaseDir, pkgConfig.CreateOpts.Flavor, pkgConfig.CreateOpts.SetVariables, securitySeverities, imagePolicy)
 	var lintErr *lint.LintError
 	if errors.As(err, &lintErr) {
 		common.PrintFindings(ctx, lintErr)
@@ -493,3 +530,261 @@ func (o *DevLintOptions) Run(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// NewDevShowCommand creates the `dev show` sub-command and its nested children.
+func NewDevShowCommand(v *viper.Viper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: lang.CmdDevShowShort,
+	}
+
+	cmd.AddCommand(NewDevShowImportChainCommand(v))
+
+	return cmd
+}
+
+// DevShowImportChainOptions holds the command-line options for 'dev show import-chain' sub-command.
+type DevShowImportChainOptions struct {
+	baseDir      string
+	flavor       string
+	outputFormat string
+}
+
+// NewDevShowImportChainCommand creates the `dev show import-chain` sub-command.
+func NewDevShowImportChainCommand(v *viper.Viper) *cobra.Command {
+	o := &DevShowImportChainOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "import-chain [ COMPONENT ]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   lang.CmdDevShowImportChainShort,
+		Long:    lang.CmdDevShowImportChainLong,
+		Example: lang.CmdDevShowImportChainExample,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.baseDir, "dir", ".", lang.CmdDevShowImportChainFlagDir)
+	cmd.Flags().StringVarP(&o.flavor, "flavor", "f", v.GetString(common.VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "tree", lang.CmdDevShowImportChainFlagOutput)
+
+	return cmd
+}
+
+// Run performs the execution of 'dev show import-chain' sub-command.
+func (o *DevShowImportChainOptions) Run(cmd *cobra.Command, args []string) error {
+	if o.outputFormat != "tree" && o.outputFormat != "dot" {
+		return fmt.Errorf("invalid output format %s, valid options are tree and dot", o.outputFormat)
+	}
+	componentName := ""
+	if len(args) > 0 {
+		componentName = args[0]
+	}
+	chains, err := lint.ImportChains(cmd.Context(), o.baseDir, o.flavor, componentName)
+	if err != nil {
+		return err
+	}
+
+	if o.outputFormat == "dot" {
+		fmt.Fprint(cmd.OutOrStdout(), composer.ImportChainsDOT(chains))
+		return nil
+	}
+	for i, chain := range chains {
+		if i > 0 {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+		fmt.Fprint(cmd.OutOrStdout(), chain.Tree())
+	}
+	return nil
+}
+
+// DevRegistryOptions holds the command-line options for 'dev registry' sub-command.
+type DevRegistryOptions struct {
+	address    string
+	runtime    string
+	storageDir string
+	image      string
+}
+
+// NewDevRegistryCommand creates the `dev registry` sub-command.
+func NewDevRegistryCommand(v *viper.Viper) *cobra.Command {
+	o := &DevRegistryOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "registry",
+		Args:    cobra.NoArgs,
+		Short:   lang.CmdDevRegistryShort,
+		Long:    lang.CmdDevRegistryLong,
+		Example: lang.CmdDevRegistryExample,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.address, "address", v.GetString(common.VDevRegistryAddress), lang.CmdDevRegistryFlagAddress)
+	cmd.Flags().StringVar(&o.runtime, "runtime", v.GetString(common.VDevRegistryRuntime), lang.CmdDevRegistryFlagRuntime)
+	cmd.Flags().StringVar(&o.storageDir, "storage-dir", v.GetString(common.VDevRegistryStorageDir), lang.CmdDevRegistryFlagStorageDir)
+	cmd.Flags().StringVar(&o.image, "image", v.GetString(common.VDevRegistryImage), lang.CmdDevRegistryFlagImage)
+
+	return cmd
+}
+
+// Run performs the execution of 'dev registry' sub-command.
+func (o *DevRegistryOptions) Run(cmd *cobra.Command, _ []string) error {
+	runtime, err := registry.ParseRuntime(o.runtime)
+	if err != nil {
+		return err
+	}
+	opt := registry.Options{
+		Address:    o.address,
+		Runtime:    runtime,
+		StorageDir: o.storageDir,
+		Image:      o.image,
+	}
+	return registry.Run(cmd.Context(), opt)
+}
+
+// NewDevClusterCommand creates the `dev cluster` sub-command and its nested children.
+func NewDevClusterCommand(v *viper.Viper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: lang.CmdDevClusterShort,
+	}
+
+	cmd.AddCommand(NewDevClusterCreateCommand(v))
+	cmd.AddCommand(NewDevClusterDestroyCommand(v))
+
+	return cmd
+}
+
+// DevClusterCreateOptions holds the command-line options for 'dev cluster create' sub-command.
+type DevClusterCreateOptions struct {
+	name       string
+	provider   string
+	image      string
+	components string
+}
+
+// NewDevClusterCreateCommand creates the `dev cluster create` sub-command.
+func NewDevClusterCreateCommand(v *viper.Viper) *cobra.Command {
+	o := &DevClusterCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Args:    cobra.NoArgs,
+		Short:   lang.CmdDevClusterCreateShort,
+		Long:    lang.CmdDevClusterCreateLong,
+		Example: lang.CmdDevClusterCreateExample,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.name, "name", v.GetString(common.VDevClusterName), lang.CmdDevClusterFlagName)
+	cmd.Flags().StringVar(&o.provider, "provider", v.GetString(common.VDevClusterProvider), lang.CmdDevClusterFlagProvider)
+	cmd.Flags().StringVar(&o.image, "image", v.GetString(common.VDevClusterImage), lang.CmdDevClusterCreateFlagImage)
+	cmd.Flags().StringVar(&o.components, "components", v.GetString(common.VDevClusterComponents), lang.CmdDevClusterCreateFlagComponents)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdDevClusterCreateFlagConfirm)
+
+	return cmd
+}
+
+// Run performs the execution of 'dev cluster create' sub-command.
+func (o *DevClusterCreateOptions) Run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	provider, err := devcluster.ParseProvider(o.provider)
+	if err != nil {
+		return err
+	}
+
+	// The init package is found before the cluster is created so that a missing package fails fast.
+	initPackageName := sources.GetInitPackageName()
+	if pkgConfig.PkgOpts.PackageSource, err = findInitPackage(ctx, initPackageName); err != nil {
+		return err
+	}
+
+	opt := devcluster.Options{
+		Name:     o.name,
+		Provider: provider,
+		Image:    o.image,
+	}
+	if err := devcluster.Create(ctx, opt); err != nil {
+		return err
+	}
+
+	// The cluster is throwaway so the init package is deployed without prompting.
+	config.CommonOptions.Confirm = true
+	pkgConfig.PkgOpts.OptionalComponents = o.components
+	return deployInitPackage(ctx)
+}
+
+// DevClusterDestroyOptions holds the command-line options for 'dev cluster destroy' sub-command.
+type DevClusterDestroyOptions struct {
+	name     string
+	provider string
+}
+
+// NewDevClusterDestroyCommand creates the `dev cluster destroy` sub-command.
+func NewDevClusterDestroyCommand(v *viper.Viper) *cobra.Command {
+	o := &DevClusterDestroyOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "destroy",
+		Args:    cobra.NoArgs,
+		Short:   lang.CmdDevClusterDestroyShort,
+		Example: lang.CmdDevClusterDestroyExample,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.name, "name", v.GetString(common.VDevClusterName), lang.CmdDevClusterFlagName)
+	cmd.Flags().StringVar(&o.provider, "provider", v.GetString(common.VDevClusterProvider), lang.CmdDevClusterFlagProvider)
+
+	return cmd
+}
+
+// Run performs the execution of 'dev cluster destroy' sub-command.
+func (o *DevClusterDestroyOptions) Run(cmd *cobra.Command, _ []string) error {
+	provider, err := devcluster.ParseProvider(o.provider)
+	if err != nil {
+		return err
+	}
+	return devcluster.Destroy(cmd.Context(), devcluster.Options{Name: o.name, Provider: provider})
+}
+
+// DevReleaseOptions holds the command-line options for 'dev release' sub-command.
+type DevReleaseOptions struct {
+	base      string
+	changelog string
+	tag       bool
+}
+
+// NewDevReleaseCommand creates the `dev release` sub-command.
+func NewDevReleaseCommand(v *viper.Viper) *cobra.Command {
+	o := &DevReleaseOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "release [ DIRECTORY ]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   lang.CmdDevReleaseShort,
+		Long:    lang.CmdDevReleaseLong,
+		Example: lang.CmdDevReleaseExample,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.base, "base", v.GetString(common.VDevReleaseBase), lang.CmdDevReleaseFlagBase)
+	cmd.Flags().StringVar(&o.changelog, "changelog", v.GetString(common.VDevReleaseChangelog), lang.CmdDevReleaseFlagChangelog)
+	cmd.Flags().BoolVar(&o.tag, "tag", v.GetBool(common.VDevReleaseTag), lang.CmdDevReleaseFlagTag)
+
+	return cmd
+}
+
+// Run performs the execution of 'dev release' sub-command.
+func (o *DevReleaseOptions) Run(cmd *cobra.Command, args []string) error {
+	opt := release.Options{
+		Path:          setBaseDirectory(args),
+		BaseRef:       o.base,
+		ChangelogPath: o.changelog,
+		Tag:           o.tag,
+	}
+	result, err := release.Run(cmd.Context(), opt)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(os.Stdout, "%s -> %s (%s)\n", result.PreviousVersion, result.Version, result.Bump)
+	return err
+}
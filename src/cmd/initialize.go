@@ -51,6 +51,12 @@ func NewInitCommand() *cobra.Command {
 	// NOTE: these are not in common.setDefaults so that zarf tools update-creds does not erroneously update values back to the default
 	v.SetDefault(common.VInitGitPushUser, types.ZarfGitPushUser)
 	v.SetDefault(common.VInitRegistryPushUser, types.ZarfRegistryPushUser)
+	v.SetDefault(common.VInitInjectorCPURequest, types.DefaultInjectorCPURequest)
+	v.SetDefault(common.VInitInjectorMemoryRequest, types.DefaultInjectorMemoryRequest)
+	v.SetDefault(common.VInitInjectorCPULimit, types.DefaultInjectorCPULimit)
+	v.SetDefault(common.VInitInjectorMemoryLimit, types.DefaultInjectorMemoryLimit)
+	v.SetDefault(common.VInitInjectorTimeout, types.DefaultInjectorTimeout)
+	v.SetDefault(common.VInitInjectorChunkSize, types.DefaultInjectorPayloadChunkSize)
 
 	// Init package set variable flags
 	cmd.Flags().StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(common.VPkgDeploySet), lang.CmdInitFlagSet)
@@ -59,6 +65,7 @@ func NewInitCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdInitFlagConfirm)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VInitComponents), lang.CmdInitFlagComponents)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.StorageClass, "storage-class", v.GetString(common.VInitStorageClass), lang.CmdInitFlagStorageClass)
+	cmd.Flags().StringSliceVar(&pkgConfig.InitOpts.StateRecipients, "state-recipient", v.GetStringSlice(common.VInitStateRecipients), lang.CmdInitFlagStateRecipient)
 
 	// Flags for using an external Git server
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Address, "git-url", v.GetString(common.VInitGitURL), lang.CmdInitFlagGitURL)
@@ -76,6 +83,18 @@ func NewInitCommand() *cobra.Command {
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.PullPassword, "registry-pull-password", v.GetString(common.VInitRegistryPullPass), lang.CmdInitFlagRegPullPass)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.Secret, "registry-secret", v.GetString(common.VInitRegistrySecret), lang.CmdInitFlagRegSecret)
 
+	// Flags for overriding the injector
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.Image, "injector-image", v.GetString(common.VInitInjectorImage), lang.CmdInitFlagInjectorImage)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.BinaryPath, "injector-binary", v.GetString(common.VInitInjectorBinary), lang.CmdInitFlagInjectorBinary)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.BinaryShasum, "injector-binary-shasum", v.GetString(common.VInitInjectorBinaryShasum), lang.CmdInitFlagInjectorBinaryShasum)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.SeedImagePath, "seed-image", v.GetString(common.VInitInjectorSeedImage), lang.CmdInitFlagSeedImage)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.CPURequest, "injector-cpu-request", v.GetString(common.VInitInjectorCPURequest), lang.CmdInitFlagInjectorCPUReq)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.MemoryRequest, "injector-memory-request", v.GetString(common.VInitInjectorMemoryRequest), lang.CmdInitFlagInjectorMemReq)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.CPULimit, "injector-cpu-limit", v.GetString(common.VInitInjectorCPULimit), lang.CmdInitFlagInjectorCPULim)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.MemoryLimit, "injector-memory-limit", v.GetString(common.VInitInjectorMemoryLimit), lang.CmdInitFlagInjectorMemLim)
+	cmd.Flags().DurationVar(&pkgConfig.InitOpts.Injector.Timeout, "injector-timeout", v.GetDuration(common.VInitInjectorTimeout), lang.CmdInitFlagInjectorTimeout)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.Injector.PayloadChunkSize, "injector-payload-chunk-size", v.GetString(common.VInitInjectorChunkSize), lang.CmdInitFlagInjectorChunkSize)
+
 	// Flags for using an external artifact server
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.ArtifactServer.Address, "artifact-url", v.GetString(common.VInitArtifactURL), lang.CmdInitFlagArtifactURL)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.ArtifactServer.PushUsername, "artifact-push-username", v.GetString(common.VInitArtifactPushUser), lang.CmdInitFlagArtifactPushUser)
@@ -85,10 +104,13 @@ func NewInitCommand() *cobra.Command {
 	// Always require adopt-existing-resources flag (no viper)
 	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AdoptExistingResources, "adopt-existing-resources", false, lang.CmdPackageDeployFlagAdoptExistingResources)
 	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.Timeout, "timeout", v.GetDuration(common.VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
+	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.ReadinessTimeout, "readiness-timeout", v.GetDuration(common.VPkgDeployReadinessTimeout), lang.CmdPackageDeployFlagReadinessTimeout)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.StrictCredentialExpiry, "strict-credential-expiry", v.GetBool(common.VPkgDeployStrictCredentialExpiry), lang.CmdPackageDeployFlagStrictCredentialExpiry)
 
 	cmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(common.VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().StringVarP(&pkgConfig.PkgOpts.PublicKeyPath, "key", "k", v.GetString(common.VPkgPublicKey), lang.CmdPackageFlagFlagPublicKey)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringVar(&pkgConfig.PkgOpts.VerificationPolicyPath, "verification-policy", v.GetString(common.VPkgVerificationPolicy), lang.CmdInitFlagVerificationPolicy)
 
 	cmd.Flags().SortFlags = true
 
@@ -111,6 +133,19 @@ func (o *InitOptions) Run(cmd *cobra.Command, _ []string) error {
 	if pkgConfig.PkgOpts.PackageSource, err = findInitPackage(cmd.Context(), initPackageName); err != nil {
 		return err
 	}
+	return deployInitPackage(ctx)
+}
+
+// deployInitPackage deploys the init package at pkgConfig.PkgOpts.PackageSource.
+func deployInitPackage(ctx context.Context) error {
+	if pkgConfig.PkgOpts.VerificationPolicyPath != "" {
+		policy, err := sources.LoadVerificationPolicy(pkgConfig.PkgOpts.VerificationPolicyPath)
+		if err != nil {
+			return err
+		}
+		pkgConfig.PkgOpts.VerificationPolicy = policy
+		pkgConfig.InitOpts.VerificationPolicy = policy
+	}
 
 	src, err := sources.New(ctx, &pkgConfig.PkgOpts)
 	if err != nil {
@@ -129,6 +164,7 @@ func (o *InitOptions) Run(cmd *cobra.Command, _ []string) error {
 
 	err = pkgClient.Deploy(ctx)
 	if err != nil {
+		offerSupportBundle(ctx)
 		return err
 	}
 	// Since the new logger ignores pterm output the credential table is no longer printed on init.
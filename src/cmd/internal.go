@@ -34,6 +34,7 @@ func NewInternalCommand(rootCmd *cobra.Command) *cobra.Command {
 
 	cmd.AddCommand(NewInternalAgentCommand())
 	cmd.AddCommand(NewInternalHTTPProxyCommand())
+	cmd.AddCommand(NewInternalRegistryExporterCommand())
 	cmd.AddCommand(NewInternalGenCliDocsCommand(rootCmd))
 	cmd.AddCommand(NewInternalCreateReadOnlyGiteaUserCommand())
 	cmd.AddCommand(NewInternalCreateArtifactRegistryTokenCommand())
@@ -45,7 +46,13 @@ func NewInternalCommand(rootCmd *cobra.Command) *cobra.Command {
 }
 
 // InternalAgentOptions holds the command-line options for 'internal agent' sub-command.
-type InternalAgentOptions struct{}
+type InternalAgentOptions struct {
+	secretSync            string
+	imageCheck            bool
+	registryProxy         bool
+	registryProxyMirrors  []string
+	registryProxyNodePort int
+}
 
 // NewInternalAgentCommand creates the `internal agent` sub-command.
 func NewInternalAgentCommand() *cobra.Command {
@@ -58,16 +65,36 @@ func NewInternalAgentCommand() *cobra.Command {
 		RunE:  o.Run,
 	}
 
+	v := common.GetViper()
+	cmd.Flags().StringVar(&o.secretSync, "secret-sync", v.GetString(common.VInternalAgentSecretSync), lang.CmdInternalAgentFlagSecretSync)
+	cmd.Flags().BoolVar(&o.imageCheck, "image-check", v.GetBool(common.VInternalAgentImageCheck), lang.CmdInternalAgentFlagImageCheck)
+	cmd.Flags().BoolVar(&o.registryProxy, "registry-proxy", v.GetBool(common.VInternalAgentRegistryProxy), lang.CmdInternalAgentFlagRegistryProxy)
+	cmd.Flags().StringSliceVar(&o.registryProxyMirrors, "registry-proxy-mirrors", v.GetStringSlice(common.VInternalAgentRegistryProxyMirrors), lang.CmdInternalAgentFlagRegistryProxyMirrors)
+	cmd.Flags().IntVar(&o.registryProxyNodePort, "registry-proxy-node-port", v.GetInt(common.VInternalAgentRegistryProxyNodePort), lang.CmdInternalAgentFlagRegistryProxyNodePort)
+
 	return cmd
 }
 
 // Run performs the execution of 'internal agent' sub-command.
 func (o *InternalAgentOptions) Run(cmd *cobra.Command, _ []string) error {
+	secretSyncPolicy, err := agent.ParseSecretSyncPolicy(o.secretSync)
+	if err != nil {
+		return err
+	}
 	cluster, err := cluster.NewCluster()
 	if err != nil {
 		return err
 	}
-	return agent.StartWebhook(cmd.Context(), cluster)
+	// Mirrors set through the environment are a single comma-separated value.
+	mirrors := []string{}
+	for _, value := range o.registryProxyMirrors {
+		for _, mirror := range strings.Split(value, ",") {
+			if mirror = strings.TrimSpace(mirror); mirror != "" {
+				mirrors = append(mirrors, mirror)
+			}
+		}
+	}
+	return agent.StartWebhook(cmd.Context(), cluster, secretSyncPolicy, o.imageCheck, o.registryProxy, mirrors, o.registryProxyNodePort)
 }
 
 // InternalHTTPProxyOptions holds the command-line options for 'internal http-proxy' sub-command.
@@ -96,6 +123,34 @@ func (o *InternalHTTPProxyOptions) Run(cmd *cobra.Command, _ []string) error {
 	return agent.StartHTTPProxy(cmd.Context(), cluster)
 }
 
+// InternalRegistryExporterOptions holds the command-line options for 'internal registry-exporter' sub-command.
+type InternalRegistryExporterOptions struct {
+	path string
+	port int
+}
+
+// NewInternalRegistryExporterCommand creates the `internal registry-exporter` sub-command.
+func NewInternalRegistryExporterCommand() *cobra.Command {
+	o := &InternalRegistryExporterOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "registry-exporter",
+		Short: lang.CmdInternalRegistryExporterShort,
+		Long:  lang.CmdInternalRegistryExporterLong,
+		RunE:  o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.path, "path", "/var/lib/registry", lang.CmdInternalRegistryExporterFlagPath)
+	cmd.Flags().IntVar(&o.port, "port", 9102, lang.CmdInternalRegistryExporterFlagPort)
+
+	return cmd
+}
+
+// Run performs the execution of 'internal registry-exporter' sub-command.
+func (o *InternalRegistryExporterOptions) Run(cmd *cobra.Command, _ []string) error {
+	return agent.StartRegistryExporter(cmd.Context(), o.path, o.port)
+}
+
 // InternalGenCliDocsOptions holds the command-line options for 'internal gen-cli-docs' sub-command.
 type InternalGenCliDocsOptions struct {
 	rootCmd *cobra.Command
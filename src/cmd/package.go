@@ -6,18 +6,25 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	goyaml "github.com/goccy/go-yaml"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"oras.land/oras-go/v2/registry"
 
 	"github.com/zarf-dev/zarf/src/cmd/common"
@@ -31,7 +38,9 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -48,28 +57,42 @@ func NewPackageCommand() *cobra.Command {
 	persistentFlags := cmd.PersistentFlags()
 	persistentFlags.IntVar(&config.CommonOptions.OCIConcurrency, "oci-concurrency", v.GetInt(common.VPkgOCIConcurrency), lang.CmdPackageFlagConcurrency)
 	persistentFlags.StringVarP(&pkgConfig.PkgOpts.PublicKeyPath, "key", "k", v.GetString(common.VPkgPublicKey), lang.CmdPackageFlagFlagPublicKey)
+	persistentFlags.StringVar(&pkgConfig.PkgOpts.VerificationPolicyPath, "verification-policy", v.GetString(common.VPkgVerificationPolicy), lang.CmdPackageFlagVerificationPolicy)
+	persistentFlags.StringVar(&pkgConfig.PkgOpts.CertificateIdentity, "certificate-identity", v.GetString(common.VPkgCertificateIdentity), lang.CmdPackageFlagCertificateIdentity)
+	persistentFlags.StringVar(&pkgConfig.PkgOpts.CertificateOIDCIssuer, "certificate-oidc-issuer", v.GetString(common.VPkgCertificateOIDCIssuer), lang.CmdPackageFlagCertificateOIDCIssuer)
 
 	cmd.AddCommand(NewPackageCreateCommand(v))
 	cmd.AddCommand(NewPackageDeployCommand(v))
 	cmd.AddCommand(NewPackageMirrorResourcesCommand(v))
 	cmd.AddCommand(NewPackageInspectCommand())
+	cmd.AddCommand(NewPackageVerifyCommand())
+	cmd.AddCommand(NewPackageDiffCommand())
 	cmd.AddCommand(NewPackageRemoveCommand(v))
 	cmd.AddCommand(NewPackageListCommand())
+	cmd.AddCommand(NewPackagePruneCommand())
 	cmd.AddCommand(NewPackagePublishCommand(v))
 	cmd.AddCommand(NewPackagePullCommand(v))
+	cmd.AddCommand(NewPackageSearchCommand())
 
 	return cmd
 }
 
 // PackageCreateOptions holds the command-line options for 'package create' sub-command.
-type PackageCreateOptions struct{}
+type PackageCreateOptions struct {
+	recursive       bool
+	imageAllow      []string
+	imageDeny       []string
+	overridePolicy  bool
+	registryPresets []string
+	architectures   []string
+}
 
 // NewPackageCreateCommand creates the `package create` sub-command.
 func NewPackageCreateCommand(v *viper.Viper) *cobra.Command {
 	o := &PackageCreateOptions{}
 
 	cmd := &cobra.Command{
-		Use:     "create [ DIRECTORY ]",
+		Use:     "create [ DIRECTORY | GIT_URL ]",
 		Aliases: []string{"c"},
 		Args:    cobra.MaximumNArgs(1),
 		Short:   lang.CmdPackageCreateShort,
@@ -93,12 +116,29 @@ func NewPackageCreateCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().BoolVarP(&pkgConfig.CreateOpts.ViewSBOM, "sbom", "s", v.GetBool(common.VPkgCreateSbom), lang.CmdPackageCreateFlagSbom)
 	cmd.Flags().StringVar(&pkgConfig.CreateOpts.SBOMOutputDir, "sbom-out", v.GetString(common.VPkgCreateSbomOutput), lang.CmdPackageCreateFlagSbomOut)
 	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.SkipSBOM, "skip-sbom", v.GetBool(common.VPkgCreateSkipSbom), lang.CmdPackageCreateFlagSkipSbom)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.SkipSBOMViewer, "skip-sbom-viewer", v.GetBool(common.VPkgCreateSkipSbomViewer), lang.CmdPackageCreateFlagSkipSbomViewer)
 	cmd.Flags().IntVarP(&pkgConfig.CreateOpts.MaxPackageSizeMB, "max-package-size", "m", v.GetInt(common.VPkgCreateMaxPackageSize), lang.CmdPackageCreateFlagMaxPackageSize)
 	cmd.Flags().StringToStringVar(&pkgConfig.CreateOpts.RegistryOverrides, "registry-override", v.GetStringMapString(common.VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
 	cmd.Flags().StringVarP(&pkgConfig.CreateOpts.Flavor, "flavor", "f", v.GetString(common.VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	cmd.Flags().StringSliceVar(&pkgConfig.CreateOpts.FlattenImages, "flatten-image", v.GetStringSlice(common.VPkgCreateFlattenImages), lang.CmdPackageCreateFlagFlattenImage)
+	cmd.Flags().BoolVar(&o.recursive, "recursive", v.GetBool(common.VPkgCreateRecursive), lang.CmdPackageCreateFlagRecursive)
+	cmd.Flags().StringSliceVar(&o.imageAllow, "image-allow", v.GetStringSlice(common.VPkgCreateImageAllow), lang.CmdPackageCreateFlagImageAllow)
+	cmd.Flags().StringSliceVar(&o.imageDeny, "image-deny", v.GetStringSlice(common.VPkgCreateImageDeny), lang.CmdPackageCreateFlagImageDeny)
+	cmd.Flags().BoolVar(&o.overridePolicy, "override-policy", false, lang.CmdPackageCreateFlagOverridePolicy)
+	cmd.Flags().StringSliceVar(&o.registryPresets, "registry-preset", v.GetStringSlice(common.VPkgCreateRegistryPresets), lang.CmdPackageCreateFlagRegistryPreset)
+	cmd.Flags().IntVar(&pkgConfig.CreateOpts.CreateConcurrency, "create-concurrency", v.GetInt(common.VPkgCreateConcurrency), lang.CmdPackageCreateFlagConcurrency)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.SnapshotCharts, "snapshot-charts", v.GetBool(common.VPkgCreateSnapshotCharts), lang.CmdPackageCreateFlagSnapshotCharts)
+	cmd.Flags().StringSliceVar(&o.architectures, "architectures", v.GetStringSlice(common.VPkgCreateArchitectures), lang.CmdPackageCreateFlagArchitectures)
+	cmd.Flags().DurationVar(&config.CommonOptions.DownloadCacheTTL, "download-cache-ttl", v.GetDuration(common.VPkgCreateDownloadCacheTTL), lang.CmdPackageCreateFlagDownloadCacheTTL)
+	cmd.Flags().BoolVar(&config.CommonOptions.SkipDownloadCacheVerify, "skip-download-cache-verify", v.GetBool(common.VPkgCreateSkipDownloadCacheVerify), lang.CmdPackageCreateFlagSkipDownloadCacheVerify)
+	cmd.Flags().IntVar(&config.CommonOptions.DownloadConnections, "download-connections", v.GetInt(common.VPkgCreateDownloadConnections), lang.CmdPackageCreateFlagDownloadConnections)
 
 	cmd.Flags().StringVar(&pkgConfig.CreateOpts.SigningKeyPath, "signing-key", v.GetString(common.VPkgCreateSigningKey), lang.CmdPackageCreateFlagSigningKey)
 	cmd.Flags().StringVar(&pkgConfig.CreateOpts.SigningKeyPassword, "signing-key-pass", v.GetString(common.VPkgCreateSigningKeyPassword), lang.CmdPackageCreateFlagSigningKeyPassword)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.SignChecksums, "sign-checksums", v.GetBool(common.VPkgCreateSignChecksums), lang.CmdPackageCreateFlagSignChecksums)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.TSAURL, "tsa-url", v.GetString(common.VPkgCreateTSAURL), lang.CmdPackageCreateFlagTSAURL)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.Keyless, "keyless", v.GetBool(common.VPkgCreateKeyless), lang.CmdPackageCreateFlagKeyless)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.IdentityToken, "identity-token", "", lang.CmdPackageCreateFlagIdentityToken)
 
 	cmd.Flags().StringVarP(&pkgConfig.CreateOpts.SigningKeyPath, "key", "k", v.GetString(common.VPkgCreateSigningKey), lang.CmdPackageCreateFlagDeprecatedKey)
 	cmd.Flags().StringVar(&pkgConfig.CreateOpts.SigningKeyPassword, "key-pass", v.GetString(common.VPkgCreateSigningKeyPassword), lang.CmdPackageCreateFlagDeprecatedKeyPassword)
@@ -126,6 +166,9 @@ func (o *PackageCreateOptions) Run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	l := logger.From(ctx)
 	pkgConfig.CreateOpts.BaseDir = setBaseDirectory(args)
+	if err := validateKeyless(pkgConfig.CreateOpts.Keyless, pkgConfig.CreateOpts.SigningKeyPath, pkgConfig.CreateOpts.TSAURL); err != nil {
+		return err
+	}
 
 	var isCleanPathRegex = regexp.MustCompile(`^[a-zA-Z0-9\_\-\/\.\~\\:]+$`)
 	if !isCleanPathRegex.MatchString(config.CommonOptions.CachePath) {
@@ -139,19 +182,51 @@ func (o *PackageCreateOptions) Run(cmd *cobra.Command, args []string) error {
 	pkgConfig.CreateOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(common.VPkgCreateSet), pkgConfig.CreateOpts.SetVariables, strings.ToUpper)
 
+	imagePolicy, err := lint.ParseImagePolicy(o.imageAllow, o.imageDeny)
+	if err != nil {
+		return err
+	}
+	registryPresets, err := common.GetRegistryPresets(v, o.registryPresets)
+	if err != nil {
+		return err
+	}
+	imagePolicy = imagePolicy.AllowRegistryPresets(registryPresets)
+
 	opt := packager2.CreateOptions{
 		Flavor:                  pkgConfig.CreateOpts.Flavor,
 		RegistryOverrides:       pkgConfig.CreateOpts.RegistryOverrides,
+		FlattenImages:           pkgConfig.CreateOpts.FlattenImages,
 		SigningKeyPath:          pkgConfig.CreateOpts.SigningKeyPath,
 		SigningKeyPassword:      pkgConfig.CreateOpts.SigningKeyPassword,
+		SignChecksums:           pkgConfig.CreateOpts.SignChecksums,
+		TSAURL:                  pkgConfig.CreateOpts.TSAURL,
+		Keyless:                 pkgConfig.CreateOpts.Keyless,
+		KeylessOptions:          utils.KeylessOptions{IdentityToken: pkgConfig.CreateOpts.IdentityToken},
 		SetVariables:            pkgConfig.CreateOpts.SetVariables,
 		MaxPackageSizeMB:        pkgConfig.CreateOpts.MaxPackageSizeMB,
 		SBOMOut:                 pkgConfig.CreateOpts.SBOMOutputDir,
 		SkipSBOM:                pkgConfig.CreateOpts.SkipSBOM,
+		SkipSBOMViewer:          pkgConfig.CreateOpts.SkipSBOMViewer,
 		Output:                  pkgConfig.CreateOpts.Output,
 		DifferentialPackagePath: pkgConfig.CreateOpts.DifferentialPackagePath,
+		Concurrency:             pkgConfig.CreateOpts.CreateConcurrency,
+		SnapshotCharts:          pkgConfig.CreateOpts.SnapshotCharts,
+		ImagePolicy:             imagePolicy,
+		OverrideImagePolicy:     o.overridePolicy,
+		RegistryPresets:         registryPresets,
+		Architectures:           o.architectures,
 	}
-	err := packager2.Create(cmd.Context(), pkgConfig.CreateOpts.BaseDir, opt)
+	if o.recursive {
+		results, err := packager2.CreateRecursive(ctx, pkgConfig.CreateOpts.BaseDir, opt)
+		if len(results) > 0 {
+			printCreateResults(results)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create packages: %w", err)
+		}
+		return nil
+	}
+	err = packager2.Create(cmd.Context(), pkgConfig.CreateOpts.BaseDir, opt)
 	// NOTE(mkcp): LintErrors are rendered with a table
 	var lintErr *lint.LintError
 	if errors.As(err, &lintErr) {
@@ -163,8 +238,27 @@ func (o *PackageCreateOptions) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printCreateResults prints a table of the packages created by a recursive create.
+func printCreateResults(results []packager2.CreateResult) {
+	data := [][]string{}
+	for _, result := range results {
+		status := "created"
+		if result.Skipped {
+			status = "skipped"
+		} else if result.Err != nil {
+			status = fmt.Sprintf("failed: %s", result.Err.Error())
+		}
+		data = append(data, []string{result.Name, result.Path, result.Duration.Round(time.Second).String(), status})
+	}
+	message.TableWithWriter(message.OutputWriter, []string{"Package", "Path", "Duration", "Status"}, data)
+}
+
 // PackageDeployOptions holds the command-line options for 'package deploy' sub-command.
-type PackageDeployOptions struct{}
+type PackageDeployOptions struct {
+	jsonIO       bool
+	outputFormat string
+	outputFile   string
+}
 
 // NewPackageDeployCommand creates the `package deploy` sub-command.
 func NewPackageDeployCommand(v *viper.Viper) *cobra.Command {
@@ -186,6 +280,19 @@ func NewPackageDeployCommand(v *viper.Viper) *cobra.Command {
 	// Always require adopt-existing-resources flag (no viper)
 	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AdoptExistingResources, "adopt-existing-resources", false, lang.CmdPackageDeployFlagAdoptExistingResources)
 	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.Timeout, "timeout", v.GetDuration(common.VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
+	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.ReadinessTimeout, "readiness-timeout", v.GetDuration(common.VPkgDeployReadinessTimeout), lang.CmdPackageDeployFlagReadinessTimeout)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.ScopedCredentials, "scoped-credentials", v.GetBool(common.VPkgDeployScopedCredentials), lang.CmdPackageDeployFlagScopedCredentials)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.StrictCredentialExpiry, "strict-credential-expiry", v.GetBool(common.VPkgDeployStrictCredentialExpiry), lang.CmdPackageDeployFlagStrictCredentialExpiry)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.ClusterContexts, "cluster-context", v.GetStringMapString(common.VPkgDeployClusterContexts), lang.CmdPackageDeployFlagClusterContext)
+	cmd.Flags().StringSliceVar(&pkgConfig.DeployOpts.ValuesProfiles, "values-profile", v.GetStringSlice(common.VPkgDeployValuesProfiles), lang.CmdPackageDeployFlagValuesProfile)
+	cmd.Flags().StringArrayVar(&pkgConfig.DeployOpts.SetHelmValues, "set-helm-values", v.GetStringSlice(common.VPkgDeploySetHelmValues), lang.CmdPackageDeployFlagSetHelmValues)
+	cmd.Flags().StringVar(&pkgConfig.DeployOpts.PostRenderer, "post-renderer", v.GetString(common.VPkgDeployPostRenderer), lang.CmdPackageDeployFlagPostRenderer)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.SkipImagePush, "skip-image-push", v.GetBool(common.VPkgDeploySkipImagePush), lang.CmdPackageDeployFlagSkipImagePush)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.ImagePushDryRun, "image-push-dry-run", v.GetBool(common.VPkgDeployImagePushDryRun), lang.CmdPackageDeployFlagImagePushDryRun)
+	cmd.MarkFlagsMutuallyExclusive("skip-image-push", "image-push-dry-run")
+	cmd.Flags().StringVar(&pkgConfig.PkgOpts.AttestationKeyPath, "attestation-key", v.GetString(common.VPkgDeployAttestationKey), lang.CmdPackageDeployFlagAttestationKey)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.KeylessAttestations, "keyless-attestations", v.GetBool(common.VPkgDeployKeylessAttestations), lang.CmdPackageDeployFlagKeylessAttestations)
+	cmd.MarkFlagsMutuallyExclusive("attestation-key", "keyless-attestations")
 
 	cmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(common.VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(common.VPkgDeploySet), lang.CmdPackageDeployFlagSet)
@@ -193,6 +300,10 @@ func NewPackageDeployCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.Shasum, "shasum", v.GetString(common.VPkgDeployShasum), lang.CmdPackageDeployFlagShasum)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.SGetKeyPath, "sget", v.GetString(common.VPkgDeploySget), lang.CmdPackageDeployFlagSget)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().BoolVar(&o.jsonIO, "json-io", false, lang.CmdPackageDeployFlagJSONIO)
+	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "", lang.CmdPackageDeployFlagOutput)
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "", lang.CmdPackageDeployFlagOutputFile)
+	cmd.MarkFlagsMutuallyExclusive("json-io", "output")
 
 	err := cmd.Flags().MarkHidden("sget")
 	if err != nil {
@@ -213,6 +324,22 @@ func (o *PackageDeployOptions) PreRun(_ *cobra.Command, _ []string) {
 // Run performs the execution of 'package deploy' sub-command.
 func (o *PackageDeployOptions) Run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
+	if o.jsonIO {
+		if len(args) > 0 {
+			return errors.New("the package source must be given in the input when using --json-io")
+		}
+		return o.runJSONIO(cmd)
+	}
+	if o.outputFormat != "" && o.outputFormat != "json" && o.outputFormat != "yaml" {
+		return fmt.Errorf("invalid output format %s, valid options are json and yaml", o.outputFormat)
+	}
+	if o.outputFile != "" && o.outputFormat == "" {
+		return errors.New("--output-file requires --output")
+	}
+	if o.outputFormat != "" && o.outputFile == "" {
+		// Keep stdout for the deployment result.
+		message.OutputWriter = os.Stderr
+	}
 	packageSource, err := choosePackage(ctx, args)
 	if err != nil {
 		return err
@@ -220,9 +347,16 @@ func (o *PackageDeployOptions) Run(cmd *cobra.Command, args []string) error {
 	pkgConfig.PkgOpts.PackageSource = packageSource
 
 	v := common.GetViper()
+	pkgConfig.PkgOpts.ConfigVariables = configVariables(cmd, "set", v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables)
 	pkgConfig.PkgOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
 
+	c, _ := cluster.NewCluster() //nolint:errcheck
+	pkgConfig.PkgOpts.VerificationPolicy, err = verificationPolicy(ctx, c, true)
+	if err != nil {
+		return err
+	}
+
 	pkgClient, err := packager.New(&pkgConfig, packager.WithContext(cmd.Context()))
 	if err != nil {
 		return err
@@ -230,9 +364,188 @@ func (o *PackageDeployOptions) Run(cmd *cobra.Command, args []string) error {
 	defer pkgClient.ClearTempPaths()
 
 	if err := pkgClient.Deploy(ctx); err != nil {
+		offerSupportBundle(ctx)
 		return fmt.Errorf("failed to deploy package: %w", err)
 	}
-	return nil
+	if o.outputFormat == "" {
+		return nil
+	}
+	return writeDeployResult(cmd.OutOrStdout(), pkgClient.GetDeployResult(), o.outputFormat, o.outputFile)
+}
+
+// writeDeployResult writes the deployment result in the format to the file, or to the writer when no file is given.
+func writeDeployResult(w io.Writer, result types.DeployResult, format, path string) error {
+	var b []byte
+	var err error
+	switch format {
+	case "json":
+		b, err = json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal json output: %w", err)
+		}
+		b = append(b, '\n')
+	case "yaml":
+		b, err = goyaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("could not marshal yaml output: %w", err)
+		}
+	}
+	if path == "" {
+		_, err = w.Write(b)
+		return err
+	}
+	// The result may hold generated credentials.
+	return os.WriteFile(path, b, helpers.ReadWriteUser)
+}
+
+// runJSONIO reads a deploy request as JSON from stdin and writes the result as JSON to stdout.
+// Human readable output is written to stderr so that stdout only holds the result.
+func (o *PackageDeployOptions) runJSONIO(cmd *cobra.Command) error {
+	message.OutputWriter = os.Stderr
+	// There is nobody to answer prompts when the request is read from stdin.
+	config.CommonOptions.Confirm = true
+
+	out, err := deployJSONIO(cmd.Context(), cmd.InOrStdin())
+	if err != nil {
+		out.Error = err.Error()
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(out); encErr != nil {
+		return encErr
+	}
+	return err
+}
+
+// deployJSONIO plans, applies or reads a package deployment. Applying only deploys the package when the plan has
+// changes so that repeating a request is idempotent, deploy time variables are not part of the plan.
+func deployJSONIO(ctx context.Context, r io.Reader) (types.DeployOutput, error) {
+	out := types.DeployOutput{Version: types.JSONIOVersion}
+	in := types.DeployInput{}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		return out, fmt.Errorf("unable to read the input: %w", err)
+	}
+	if in.Version != "" && in.Version != types.JSONIOVersion {
+		return out, fmt.Errorf("unsupported input version %s, expected %s", in.Version, types.JSONIOVersion)
+	}
+	if in.Action == "" {
+		in.Action = types.DeployActionApply
+	}
+	out.Action = in.Action
+	switch in.Action {
+	case types.DeployActionRead:
+		if in.Name == "" {
+			return out, errors.New("name is required to read a package")
+		}
+	case types.DeployActionPlan, types.DeployActionApply:
+		if in.Source == "" {
+			return out, fmt.Errorf("source is required to %s a package", in.Action)
+		}
+	default:
+		return out, fmt.Errorf("unknown action %s, valid actions are plan, apply and read", in.Action)
+	}
+	timeout := config.ZarfDefaultTimeout
+	if in.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(in.Timeout)
+		if err != nil {
+			return out, fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return out, err
+	}
+
+	if in.Action == types.DeployActionRead {
+		out.Package, err = getDeployedPackage(ctx, c, in.Name)
+		return out, err
+	}
+
+	skipSignatureValidation := in.SkipSignatureValidation || pkgConfig.PkgOpts.SkipSignatureValidation
+	policy, err := verificationPolicy(ctx, c, true)
+	if err != nil {
+		return out, err
+	}
+	planOpt := packager2.PlanDeployOptions{
+		Source:                  in.Source,
+		Shasum:                  in.Shasum,
+		OptionalComponents:      in.Components,
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		CertificateIdentity:     sources.CertificateIdentity(pkgConfig.PkgOpts),
+		SkipSignatureValidation: skipSignatureValidation,
+		VerificationPolicy:      policy,
+		Cluster:                 c,
+	}
+	plan, err := packager2.PlanDeploy(ctx, planOpt)
+	if err != nil {
+		return out, err
+	}
+	out.Plan = &plan
+	if in.Action == types.DeployActionPlan || (!plan.HasChanges() && !in.Force) {
+		out.Package, err = getDeployedPackage(ctx, c, plan.Package)
+		return out, err
+	}
+
+	cfg := types.PackagerConfig{
+		PkgOpts: types.ZarfPackageOptions{
+			PackageSource:           in.Source,
+			OptionalComponents:      in.Components,
+			SetVariables:            helpers.TransformMapKeys(in.SetVariables, strings.ToUpper),
+			Shasum:                  in.Shasum,
+			PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+			CertificateIdentity:     pkgConfig.PkgOpts.CertificateIdentity,
+			CertificateOIDCIssuer:   pkgConfig.PkgOpts.CertificateOIDCIssuer,
+			Retries:                 in.Retries,
+			SkipSignatureValidation: skipSignatureValidation,
+			VerificationPolicy:      policy,
+		},
+		DeployOpts: types.ZarfDeployOptions{
+			AdoptExistingResources: in.AdoptExistingResources,
+			Timeout:                timeout,
+			ScopedCredentials:      in.ScopedCredentials || pkgConfig.DeployOpts.ScopedCredentials,
+			ClusterContexts:        in.ClusterContexts,
+			ValuesProfiles:         in.ValuesProfiles,
+		},
+	}
+	if cfg.PkgOpts.Retries == 0 {
+		cfg.PkgOpts.Retries = config.ZarfDefaultRetries
+	}
+	pkgClient, err := packager.New(&cfg, packager.WithContext(ctx), packager.WithCluster(c))
+	if err != nil {
+		return out, err
+	}
+	defer pkgClient.ClearTempPaths()
+	// Failed deployments may have changed the cluster before failing.
+	out.Changed = true
+	if err := pkgClient.Deploy(ctx); err != nil {
+		return out, fmt.Errorf("failed to deploy package: %w", err)
+	}
+	out.Package, err = getDeployedPackage(ctx, c, plan.Package)
+	return out, err
+}
+
+// getDeployedPackage returns the deployed package with the given name, or nil when it is not deployed.
+func getDeployedPackage(ctx context.Context, c *cluster.Cluster, name string) (*types.DeployedPackage, error) {
+	depPkg, err := c.GetDeployedPackage(ctx, name)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the deployed package %s: %w", name, err)
+	}
+	return depPkg, nil
+}
+
+// offerSupportBundle points the user to the support bundle command after a failed deployment.
+func offerSupportBundle(ctx context.Context) {
+	message.Note(lang.CmdSupportBundleHint)
+	logger.From(ctx).Info("to collect diagnostic information for a support request run `zarf tools support-bundle`")
 }
 
 // PackageMirrorResourcesOptions holds the command-line options for 'package mirror-resources' sub-command.
@@ -309,11 +622,17 @@ func (o *PackageMirrorResourcesOptions) Run(cmd *cobra.Command, args []string) (
 		filters.BySelectState(pkgConfig.PkgOpts.OptionalComponents),
 	)
 
+	policy, err := verificationPolicy(ctx, nil, true)
+	if err != nil {
+		return err
+	}
 	loadOpt := packager2.LoadOptions{
 		Source:                  src,
 		Shasum:                  pkgConfig.PkgOpts.Shasum,
 		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		CertificateIdentity:     sources.CertificateIdentity(pkgConfig.PkgOpts),
 		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+		VerificationPolicy:      policy,
 		Filter:                  filter,
 	}
 	pkgLayout, err := packager2.LoadPackage(cmd.Context(), loadOpt)
@@ -360,6 +679,13 @@ func NewPackageInspectCommand() *cobra.Command {
 	cmd.Flags().BoolVarP(&pkgConfig.InspectOpts.ViewSBOM, "sbom", "s", false, lang.CmdPackageInspectFlagSbom)
 	cmd.Flags().StringVar(&pkgConfig.InspectOpts.SBOMOutputDir, "sbom-out", "", lang.CmdPackageInspectFlagSbomOut)
 	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ListImages, "list-images", false, lang.CmdPackageInspectFlagListImages)
+	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ListAnnotations, "list-annotations", false, lang.CmdPackageInspectFlagListAnnotations)
+	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ListSizes, "list-sizes", false, lang.CmdPackageInspectFlagListSizes)
+	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ListSignatures, "list-signatures", false, lang.CmdPackageInspectFlagListSignatures)
+	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ChartSnapshots, "chart-snapshots", false, lang.CmdPackageInspectFlagChartSnapshots)
+	cmd.Flags().StringVar(&pkgConfig.PkgOpts.AttestationKeyPath, "attestation-key", "", lang.CmdPackageInspectFlagAttestationKey)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.KeylessAttestations, "keyless-attestations", false, lang.CmdPackageInspectFlagKeylessAttestations)
+	cmd.MarkFlagsMutuallyExclusive("attestation-key", "keyless-attestations")
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
 
 	return cmd
@@ -380,6 +706,21 @@ func (o *PackageInspectOptions) Run(cmd *cobra.Command, args []string) error {
 	if pkgConfig.InspectOpts.ListImages && (pkgConfig.InspectOpts.SBOMOutputDir != "" || pkgConfig.InspectOpts.ViewSBOM) {
 		return fmt.Errorf("cannot use --sbom or --sbom-out and --list-images at the same time")
 	}
+	if pkgConfig.InspectOpts.ListAnnotations && (pkgConfig.InspectOpts.ListImages || pkgConfig.InspectOpts.SBOMOutputDir != "" || pkgConfig.InspectOpts.ViewSBOM) {
+		return fmt.Errorf("cannot use --list-annotations with --sbom, --sbom-out or --list-images")
+	}
+	if pkgConfig.InspectOpts.ListSizes && (pkgConfig.InspectOpts.ListImages || pkgConfig.InspectOpts.ListAnnotations || pkgConfig.InspectOpts.SBOMOutputDir != "" || pkgConfig.InspectOpts.ViewSBOM) {
+		return fmt.Errorf("cannot use --list-sizes with --sbom, --sbom-out, --list-images or --list-annotations")
+	}
+	if pkgConfig.InspectOpts.ListSignatures && (pkgConfig.InspectOpts.ListImages || pkgConfig.InspectOpts.ListAnnotations || pkgConfig.InspectOpts.ListSizes || pkgConfig.InspectOpts.SBOMOutputDir != "" || pkgConfig.InspectOpts.ViewSBOM) {
+		return fmt.Errorf("cannot use --list-signatures with --sbom, --sbom-out, --list-images, --list-annotations or --list-sizes")
+	}
+	if sources.VerifiesAttestations(pkgConfig.PkgOpts) && (pkgConfig.InspectOpts.ListSignatures || pkgConfig.InspectOpts.ListImages || pkgConfig.InspectOpts.ListAnnotations || pkgConfig.InspectOpts.ListSizes || pkgConfig.InspectOpts.SBOMOutputDir != "" || pkgConfig.InspectOpts.ViewSBOM) {
+		return fmt.Errorf("cannot use --attestation-key or --keyless-attestations with --sbom, --sbom-out, --list-images, --list-annotations, --list-sizes or --list-signatures")
+	}
+	if pkgConfig.InspectOpts.ChartSnapshots && (sources.VerifiesAttestations(pkgConfig.PkgOpts) || pkgConfig.InspectOpts.ListSignatures || pkgConfig.InspectOpts.ListImages || pkgConfig.InspectOpts.ListAnnotations || pkgConfig.InspectOpts.ListSizes || pkgConfig.InspectOpts.SBOMOutputDir != "" || pkgConfig.InspectOpts.ViewSBOM) {
+		return fmt.Errorf("cannot use --chart-snapshots with --sbom, --sbom-out, --list-images, --list-annotations, --list-sizes, --list-signatures, --attestation-key or --keyless-attestations")
+	}
 
 	// NOTE(mkcp): Gets user input with message
 	src, err := choosePackage(ctx, args)
@@ -388,6 +729,10 @@ func (o *PackageInspectOptions) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	cluster, _ := cluster.NewCluster() //nolint:errcheck
+	policy, err := verificationPolicy(ctx, cluster, false)
+	if err != nil {
+		return err
+	}
 	inspectOpt := packager2.ZarfInspectOptions{
 		Source:                  src,
 		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
@@ -396,6 +741,23 @@ func (o *PackageInspectOptions) Run(cmd *cobra.Command, args []string) error {
 		ViewSBOM:                pkgConfig.InspectOpts.ViewSBOM,
 		SBOMOutputDir:           pkgConfig.InspectOpts.SBOMOutputDir,
 		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		CertificateIdentity:     sources.CertificateIdentity(pkgConfig.PkgOpts),
+		VerificationPolicy:      policy,
+		AttestationKeyPath:      pkgConfig.PkgOpts.AttestationKeyPath,
+		KeylessAttestations:     pkgConfig.PkgOpts.KeylessAttestations,
+	}
+
+	if sources.VerifiesAttestations(pkgConfig.PkgOpts) {
+		attestations, err := packager2.InspectAttestations(ctx, inspectOpt)
+		if err != nil {
+			return fmt.Errorf("failed to inspect package: %w", err)
+		}
+		attestationData := [][]string{}
+		for _, attestation := range attestations {
+			attestationData = append(attestationData, []string{attestation.PredicateType, attestation.Name, attestation.Digest.String()})
+		}
+		message.TableWithWriter(message.OutputWriter, []string{"Predicate Type", "Name", "Digest"}, attestationData)
+		return nil
 	}
 
 	if pkgConfig.InspectOpts.ListImages {
@@ -412,6 +774,71 @@ func (o *PackageInspectOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if pkgConfig.InspectOpts.ListAnnotations {
+		annotations, err := packager2.InspectAnnotations(ctx, inspectOpt)
+		if err != nil {
+			return fmt.Errorf("failed to inspect package: %w", err)
+		}
+		return utils.ColorPrintYAML(annotations, nil, false)
+	}
+
+	if pkgConfig.InspectOpts.ListSizes {
+		components, err := packager2.InspectSizes(ctx, inspectOpt)
+		if err != nil {
+			return fmt.Errorf("failed to inspect package: %w", err)
+		}
+		componentData := [][]string{}
+		imageData := [][]string{}
+		for _, component := range components {
+			componentData = append(componentData, []string{
+				component.Name,
+				utils.ByteFormat(float64(component.Size), 2),
+				strconv.Itoa(component.ImageCount),
+				utils.ByteFormat(float64(component.ImagesSize), 2),
+			})
+			for _, image := range component.Images {
+				imageData = append(imageData, []string{component.Name, image.Name, image.Digest, utils.ByteFormat(float64(image.Size), 2)})
+			}
+		}
+		message.TableWithWriter(message.OutputWriter, []string{"Component", "Size", "Images", "Images Size"}, componentData)
+		if len(imageData) > 0 {
+			message.TableWithWriter(message.OutputWriter, []string{"Component", "Image", "Digest", "Size"}, imageData)
+		}
+		return nil
+	}
+
+	if pkgConfig.InspectOpts.ListSignatures {
+		signatures, err := packager2.InspectSignatures(ctx, inspectOpt)
+		if err != nil {
+			return fmt.Errorf("failed to inspect package: %w", err)
+		}
+		signatureData := [][]string{}
+		for _, sig := range signatures {
+			timestamp, authority := "not timestamped", ""
+			if sig.Timestamp != nil {
+				timestamp = sig.Timestamp.Time.UTC().Format(time.RFC3339)
+				authority = sig.Timestamp.Authority
+			}
+			signatureData = append(signatureData, []string{sig.Signature, sig.Blob, timestamp, authority})
+		}
+		message.TableWithWriter(message.OutputWriter, []string{"Signature", "Covers", "Timestamp", "Timestamp Authority"}, signatureData)
+		return nil
+	}
+
+	if pkgConfig.InspectOpts.ChartSnapshots {
+		snapshots, err := packager2.InspectChartSnapshots(ctx, inspectOpt)
+		if err != nil {
+			return fmt.Errorf("failed to inspect package: %w", err)
+		}
+		for _, snapshot := range snapshots {
+			_, err := fmt.Fprintf(os.Stdout, "# Component: %s, Chart: %s\n%s\n", snapshot.Component, snapshot.Chart, snapshot.Manifest)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	output, err := packager2.Inspect(ctx, inspectOpt)
 	if err != nil {
 		return fmt.Errorf("failed to inspect package: %w", err)
@@ -423,8 +850,188 @@ func (o *PackageInspectOptions) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// PackageVerifyOptions holds the command-line options for 'package verify' sub-command.
+type PackageVerifyOptions struct{}
+
+// NewPackageVerifyCommand creates the `package verify` sub-command.
+func NewPackageVerifyCommand() *cobra.Command {
+	o := &PackageVerifyOptions{}
+	cmd := &cobra.Command{
+		Use:     "verify [ PACKAGE_SOURCE ]",
+		Short:   lang.CmdPackageVerifyShort,
+		Long:    lang.CmdPackageVerifyLong,
+		Example: lang.CmdPackageVerifyExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&pkgConfig.PkgOpts.Shasum, "shasum", "", lang.CmdPackageVerifyFlagShasum)
+
+	return cmd
+}
+
+// Run performs the execution of 'package verify' sub-command.
+func (o *PackageVerifyOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	packageSource, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	pkgConfig.PkgOpts.PackageSource = packageSource
+	// Verifying the signature is the point of this command so it can never be skipped.
+	pkgConfig.PkgOpts.SkipSignatureValidation = false
+
+	// Verification runs offline so only the policy given with --verification-policy is used.
+	pkgConfig.PkgOpts.VerificationPolicy, err = verificationPolicy(ctx, nil, true)
+	if err != nil {
+		return err
+	}
+
+	pkgClient, err := packager.New(&pkgConfig, packager.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer pkgClient.ClearTempPaths()
+
+	signed, err := pkgClient.Verify(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify package: %w", err)
+	}
+	if !signed {
+		message.Warnf(lang.CmdPackageVerifyUnsigned, packageSource)
+		logger.From(ctx).Warn("package is not signed, only the integrity of its content was verified", "source", packageSource)
+		return nil
+	}
+	message.Successf("Package %s verified", packageSource)
+	logger.From(ctx).Info("package verified", "source", packageSource)
+	return nil
+}
+
+// PackageDiffOptions holds the command-line options for 'package diff' sub-command.
+type PackageDiffOptions struct {
+	outputFormat string
+}
+
+// NewPackageDiffCommand creates the `package diff` sub-command.
+func NewPackageDiffCommand() *cobra.Command {
+	o := &PackageDiffOptions{}
+	cmd := &cobra.Command{
+		Use:     "diff FROM_PACKAGE_SOURCE TO_PACKAGE_SOURCE",
+		Short:   lang.CmdPackageDiffShort,
+		Long:    lang.CmdPackageDiffLong,
+		Example: lang.CmdPackageDiffExample,
+		Args:    cobra.ExactArgs(2),
+		PreRun:  o.PreRun,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "", lang.CmdPackageDiffFlagOutput)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+
+	return cmd
+}
+
+// PreRun performs the pre-run checks for 'package diff' sub-command.
+func (o *PackageDiffOptions) PreRun(_ *cobra.Command, _ []string) {
+	// If --insecure was provided, set --skip-signature-validation to match
+	if config.CommonOptions.Insecure {
+		pkgConfig.PkgOpts.SkipSignatureValidation = true
+	}
+}
+
+// Run performs the execution of 'package diff' sub-command.
+func (o *PackageDiffOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if o.outputFormat != "" && o.outputFormat != "json" && o.outputFormat != "yaml" {
+		return fmt.Errorf("invalid output format %s, valid options are json and yaml", o.outputFormat)
+	}
+
+	cluster, _ := cluster.NewCluster() //nolint:errcheck
+	policy, err := verificationPolicy(ctx, cluster, false)
+	if err != nil {
+		return err
+	}
+	diff, err := packager2.Diff(ctx, packager2.DiffOptions{
+		From:                    args[0],
+		To:                      args[1],
+		Cluster:                 cluster,
+		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		CertificateIdentity:     sources.CertificateIdentity(pkgConfig.PkgOpts),
+		VerificationPolicy:      policy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to diff packages: %w", err)
+	}
+
+	switch o.outputFormat {
+	case "json":
+		b, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal json output: %w", err)
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+	case "yaml":
+		b, err := goyaml.Marshal(diff)
+		if err != nil {
+			return fmt.Errorf("could not marshal yaml output: %w", err)
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+	default:
+		printPackageDiff(diff)
+	}
+	return nil
+}
+
+// printPackageDiff prints the difference between two packages as tables.
+func printPackageDiff(diff packager2.PackageDiff) {
+	if diff.Empty() {
+		fmt.Fprintf(message.OutputWriter, "%s and %s do not differ\n", diff.From, diff.To)
+		return
+	}
+	fmt.Fprintf(message.OutputWriter, "Comparing %s to %s\n", diff.From, diff.To)
+
+	componentData := [][]string{}
+	imageData := [][]string{}
+	chartData := [][]string{}
+	repoData := [][]string{}
+	for _, component := range diff.Components {
+		componentData = append(componentData, []string{component.Name, component.Change, strings.Join(component.Modified, ", ")})
+		for _, image := range component.Images {
+			imageData = append(imageData, []string{component.Name, image.Change, image.From, image.To})
+		}
+		for _, chart := range component.Charts {
+			chartData = append(chartData, []string{component.Name, chart.Name, chart.Change, chart.From, chart.To})
+		}
+		for _, repo := range component.Repos {
+			repoData = append(repoData, []string{component.Name, repo.Change, repo.Name})
+		}
+	}
+	if len(componentData) > 0 {
+		message.TableWithWriter(message.OutputWriter, []string{"Component", "Change", "Modified"}, componentData)
+	}
+	if len(imageData) > 0 {
+		message.TableWithWriter(message.OutputWriter, []string{"Component", "Change", "From Image", "To Image"}, imageData)
+	}
+	if len(chartData) > 0 {
+		message.TableWithWriter(message.OutputWriter, []string{"Component", "Chart", "Change", "From Version", "To Version"}, chartData)
+	}
+	if len(repoData) > 0 {
+		message.TableWithWriter(message.OutputWriter, []string{"Component", "Change", "Repository"}, repoData)
+	}
+	if len(diff.Variables) > 0 {
+		variableData := [][]string{}
+		for _, v := range diff.Variables {
+			variableData = append(variableData, []string{v.Name, v.Change, v.From, v.To})
+		}
+		message.TableWithWriter(message.OutputWriter, []string{"Variable", "Change", "From Default", "To Default"}, variableData)
+	}
+}
+
 // PackageListOptions holds the command-line options for 'package list' sub-command.
-type PackageListOptions struct{}
+type PackageListOptions struct {
+	metrics bool
+}
 
 // NewPackageListCommand creates the `package list` sub-command.
 func NewPackageListCommand() *cobra.Command {
@@ -437,6 +1044,8 @@ func NewPackageListCommand() *cobra.Command {
 		RunE:    o.Run,
 	}
 
+	cmd.Flags().BoolVar(&o.metrics, "metrics", false, lang.CmdPackageListFlagMetrics)
+
 	return cmd
 }
 
@@ -455,6 +1064,16 @@ func (o *PackageListOptions) Run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to get the packages deployed to the cluster: %w", err)
 	}
 
+	if o.metrics {
+		if metricsErr := cluster.WritePackageMetrics(cmd.OutOrStdout(), deployedZarfPackages); metricsErr != nil {
+			return metricsErr
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read all of the packages deployed to the cluster: %w", err)
+		}
+		return nil
+	}
+
 	// Populate a matrix of all the deployed packages
 	packageData := [][]string{}
 
@@ -465,12 +1084,22 @@ func (o *PackageListOptions) Run(cmd *cobra.Command, _ []string) error {
 			components = append(components, component.Name)
 		}
 
+		// Packages created before component sizes were recorded have no size.
+		size := "-"
+		if len(pkg.Data.Build.Components) > 0 {
+			var total int64
+			for _, component := range pkg.Data.Build.Components {
+				total += component.Size + component.ImagesSize
+			}
+			size = utils.ByteFormat(float64(total), 2)
+		}
+
 		packageData = append(packageData, []string{
-			pkg.Name, pkg.Data.Metadata.Version, fmt.Sprintf("%v", components),
+			pkg.Name, pkg.Data.Metadata.Version, fmt.Sprintf("%v", components), size,
 		})
 	}
 
-	header := []string{"Package", "Version", "Components"}
+	header := []string{"Package", "Version", "Components", "Size"}
 	message.TableWithWriter(message.OutputWriter, header, packageData)
 
 	// Print out any unmarshalling errors
@@ -480,6 +1109,53 @@ func (o *PackageListOptions) Run(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// PackagePruneOptions holds the command-line options for 'package prune' sub-command.
+type PackagePruneOptions struct {
+	keep int
+}
+
+// NewPackagePruneCommand creates the `package prune` sub-command.
+func NewPackagePruneCommand() *cobra.Command {
+	o := &PackagePruneOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "prune PACKAGE_NAME --confirm",
+		Args:              cobra.ExactArgs(1),
+		Short:             lang.CmdPackagePruneShort,
+		Long:              lang.CmdPackagePruneLong,
+		Example:           lang.CmdPackagePruneExample,
+		RunE:              o.Run,
+		ValidArgsFunction: getPackageCompletionArgs,
+	}
+
+	cmd.Flags().IntVar(&o.keep, "keep", 3, lang.CmdPackagePruneFlagKeep)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackagePruneFlagConfirm)
+	_ = cmd.MarkFlagRequired("confirm")
+
+	return cmd
+}
+
+// Run performs the execution of 'package prune' sub-command.
+func (o *PackagePruneOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+	pruneOpt := packager2.PruneOptions{
+		Cluster:     c,
+		PackageName: args[0],
+		Keep:        o.keep,
+	}
+	err = packager2.Prune(ctx, pruneOpt)
+	if err != nil {
+		return fmt.Errorf("unable to prune package %s: %w", args[0], err)
+	}
+	return nil
+}
+
 // PackageRemoveOptions holds the command-line options for 'package remove' sub-command.
 type PackageRemoveOptions struct{}
 
@@ -502,6 +1178,8 @@ func NewPackageRemoveCommand(v *viper.Viper) *cobra.Command {
 	_ = cmd.MarkFlagRequired("confirm")
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VPkgDeployComponents), lang.CmdPackageRemoveFlagComponents)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.ClusterContexts, "cluster-context", v.GetStringMapString(common.VPkgDeployClusterContexts), lang.CmdPackageRemoveFlagClusterContext)
+	cmd.Flags().StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(common.VPkgDeploySet), lang.CmdPackageRemoveFlagSet)
 
 	return cmd
 }
@@ -525,13 +1203,24 @@ func (o *PackageRemoveOptions) Run(cmd *cobra.Command, args []string) error {
 		filters.ByLocalOS(runtime.GOOS),
 		filters.BySelectState(pkgConfig.PkgOpts.OptionalComponents),
 	)
+	v := common.GetViper()
+	setVariables := helpers.TransformAndMergeMap(
+		v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
 	cluster, _ := cluster.NewCluster() //nolint:errcheck
+	policy, err := verificationPolicy(ctx, cluster, false)
+	if err != nil {
+		return err
+	}
 	removeOpt := packager2.RemoveOptions{
 		Source:                  packageSource,
 		Cluster:                 cluster,
 		Filter:                  filter,
 		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
 		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		CertificateIdentity:     sources.CertificateIdentity(pkgConfig.PkgOpts),
+		VerificationPolicy:      policy,
+		ClusterContexts:         pkgConfig.DeployOpts.ClusterContexts,
+		SetVariables:            setVariables,
 	}
 	err = packager2.Remove(ctx, removeOpt)
 	if err != nil {
@@ -540,6 +1229,69 @@ func (o *PackageRemoveOptions) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// PackageSearchOptions holds the command-line options for 'package search' sub-command.
+type PackageSearchOptions struct {
+	outputFormat string
+}
+
+// NewPackageSearchCommand creates the `package search` sub-command.
+func NewPackageSearchCommand() *cobra.Command {
+	o := &PackageSearchOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "search REGISTRY",
+		Short:   lang.CmdPackageSearchShort,
+		Long:    lang.CmdPackageSearchLong,
+		Example: lang.CmdPackageSearchExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "", lang.CmdPackageSearchFlagOutput)
+
+	return cmd
+}
+
+// Run performs the execution of 'package search' sub-command.
+func (o *PackageSearchOptions) Run(cmd *cobra.Command, args []string) error {
+	if o.outputFormat != "" && o.outputFormat != "json" && o.outputFormat != "yaml" {
+		return fmt.Errorf("invalid output format %s, valid options are json and yaml", o.outputFormat)
+	}
+	if !helpers.IsOCIURL(args[0]) {
+		return errors.New("Registry must be prefixed with 'oci://'")
+	}
+
+	summaries, err := zoci.SearchPackages(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("unable to search for packages: %w", err)
+	}
+
+	switch o.outputFormat {
+	case "json":
+		b, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal json output: %w", err)
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+	case "yaml":
+		b, err := goyaml.Marshal(summaries)
+		if err != nil {
+			return fmt.Errorf("could not marshal yaml output: %w", err)
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+	default:
+		packageData := [][]string{}
+		for _, summary := range summaries {
+			packageData = append(packageData, []string{
+				summary.Name, summary.Version, summary.Architecture, summary.Description, helpers.OCIURLPrefix + summary.Reference,
+			})
+		}
+		header := []string{"Package", "Version", "Architecture", "Description", "Reference"}
+		message.TableWithWriter(message.OutputWriter, header, packageData)
+	}
+	return nil
+}
+
 // PackagePublishOptions holds the command-line options for 'package publish' sub-command.
 type PackagePublishOptions struct{}
 
@@ -558,8 +1310,15 @@ func NewPackagePublishCommand(v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().StringVar(&pkgConfig.PublishOpts.SigningKeyPath, "signing-key", v.GetString(common.VPkgPublishSigningKey), lang.CmdPackagePublishFlagSigningKey)
 	cmd.Flags().StringVar(&pkgConfig.PublishOpts.SigningKeyPassword, "signing-key-pass", v.GetString(common.VPkgPublishSigningKeyPassword), lang.CmdPackagePublishFlagSigningKeyPassword)
+	cmd.Flags().StringVar(&pkgConfig.PublishOpts.TSAURL, "tsa-url", v.GetString(common.VPkgPublishTSAURL), lang.CmdPackagePublishFlagTSAURL)
+	cmd.Flags().BoolVar(&pkgConfig.PublishOpts.Keyless, "keyless", v.GetBool(common.VPkgPublishKeyless), lang.CmdPackagePublishFlagKeyless)
+	cmd.Flags().StringVar(&pkgConfig.PublishOpts.IdentityToken, "identity-token", "", lang.CmdPackagePublishFlagIdentityToken)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
 	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackagePublishFlagConfirm)
+	cmd.Flags().IntVar(&pkgConfig.PublishOpts.Retries, "retries", v.GetInt(common.VPkgPublishRetries), lang.CmdPackagePublishFlagRetries)
+	cmd.Flags().BoolVar(&pkgConfig.PublishOpts.Resume, "resume", false, lang.CmdPackagePublishFlagResume)
+	cmd.Flags().StringVar(&pkgConfig.PublishOpts.ManifestType, "manifest-type", v.GetString(common.VPkgPublishManifestType), lang.CmdPackagePublishFlagManifestType)
+	cmd.Flags().StringSliceVar(&pkgConfig.PublishOpts.Attestations, "attest", v.GetStringSlice(common.VPkgPublishAttestations), lang.CmdPackagePublishFlagAttest)
 
 	return cmd
 }
@@ -575,6 +1334,9 @@ func (o *PackagePublishOptions) PreRun(_ *cobra.Command, _ []string) {
 // Run performs the execution of 'package publish' sub-command.
 func (o *PackagePublishOptions) Run(cmd *cobra.Command, args []string) error {
 	pkgConfig.PkgOpts.PackageSource = args[0]
+	if err := validateKeyless(pkgConfig.PublishOpts.Keyless, pkgConfig.PublishOpts.SigningKeyPath, pkgConfig.PublishOpts.TSAURL); err != nil {
+		return err
+	}
 
 	if !helpers.IsOCIURL(args[1]) {
 		return errors.New("Registry must be prefixed with 'oci://'")
@@ -588,6 +1350,12 @@ func (o *PackagePublishOptions) Run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if _, err := zoci.ParseManifestType(pkgConfig.PublishOpts.ManifestType); err != nil {
+		return err
+	}
+	if err := packager.ValidateAttestations(pkgConfig.PublishOpts.Attestations, pkgConfig.PublishOpts.SigningKeyPath, pkgConfig.PublishOpts.Keyless); err != nil {
+		return err
+	}
 
 	if helpers.IsDir(pkgConfig.PkgOpts.PackageSource) {
 		pkgConfig.CreateOpts.BaseDir = pkgConfig.PkgOpts.PackageSource
@@ -596,6 +1364,11 @@ func (o *PackagePublishOptions) Run(cmd *cobra.Command, args []string) error {
 
 	pkgConfig.PublishOpts.PackageDestination = ref.String()
 
+	pkgConfig.PkgOpts.VerificationPolicy, err = verificationPolicy(cmd.Context(), nil, true)
+	if err != nil {
+		return err
+	}
+
 	pkgClient, err := packager.New(&pkgConfig, packager.WithContext(cmd.Context()))
 	if err != nil {
 		return err
@@ -640,13 +1413,62 @@ func (o *PackagePullOptions) Run(cmd *cobra.Command, args []string) error {
 		}
 		outputDir = wd
 	}
-	err := packager2.Pull(cmd.Context(), args[0], outputDir, pkgConfig.PkgOpts.Shasum, filters.Empty(), pkgConfig.PkgOpts.PublicKeyPath, pkgConfig.PkgOpts.SkipSignatureValidation)
+	policy, err := verificationPolicy(cmd.Context(), nil, true)
+	if err != nil {
+		return err
+	}
+	err = packager2.Pull(cmd.Context(), args[0], outputDir, pkgConfig.PkgOpts.Shasum, filters.Empty(), pkgConfig.PkgOpts.PublicKeyPath, sources.CertificateIdentity(pkgConfig.PkgOpts), pkgConfig.PkgOpts.SkipSignatureValidation, policy)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// verificationPolicy returns the verification policy from --verification-policy merged with the policy stored in the
+// Zarf state of the cluster, when a cluster is given and has been initialized. Commands that do not deploy packages,
+// such as inspect and remove, only warn when the state can not be read, as users of these commands may not be allowed
+// to read the state or the state may be encrypted for the agent.
+func verificationPolicy(ctx context.Context, c *cluster.Cluster, deploys bool) (types.VerificationPolicy, error) {
+	policies := []types.VerificationPolicy{}
+	if pkgConfig.PkgOpts.VerificationPolicyPath != "" {
+		policy, err := sources.LoadVerificationPolicy(pkgConfig.PkgOpts.VerificationPolicyPath)
+		if err != nil {
+			return types.VerificationPolicy{}, err
+		}
+		policies = append(policies, policy)
+	}
+	if c != nil {
+		state, err := c.LoadZarfState(ctx)
+		switch {
+		case err == nil, kerrors.IsNotFound(err):
+		case !deploys:
+			message.Warnf("Unable to load the verification policy from the cluster, only the --verification-policy is applied: %s", err)
+			logger.From(ctx).Warn("unable to load the verification policy from the cluster, only the --verification-policy is applied", "error", err)
+		default:
+			return types.VerificationPolicy{}, fmt.Errorf("unable to load the verification policy from the cluster: %w", err)
+		}
+		if state != nil {
+			policies = append(policies, state.VerificationPolicy)
+		}
+	}
+	return sources.MergeVerificationPolicies(policies...), nil
+}
+
+// configVariables returns the names of the variables set in the Zarf config file that are not overridden by the set flag.
+func configVariables(cmd *cobra.Command, flagName string, configSet, flagSet map[string]string) []string {
+	flagSet = helpers.TransformMapKeys(flagSet, strings.ToUpper)
+	names := []string{}
+	for name := range configSet {
+		name = strings.ToUpper(name)
+		if _, ok := flagSet[name]; ok && cmd.Flags().Changed(flagName) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
 func choosePackage(ctx context.Context, args []string) (string, error) {
 	if len(args) > 0 {
 		return args[0], nil
@@ -709,3 +1531,17 @@ func getPackageCompletionArgs(cmd *cobra.Command, _ []string, _ string) ([]strin
 
 	return pkgCandidates, cobra.ShellCompDirectiveDefault
 }
+
+// validateKeyless returns an error when keyless signing is combined with the options of signing with a key.
+func validateKeyless(keyless bool, signingKeyPath, tsaURL string) error {
+	if !keyless {
+		return nil
+	}
+	if signingKeyPath != "" {
+		return errors.New("--keyless cannot be used with --signing-key")
+	}
+	if tsaURL != "" {
+		return errors.New("--keyless cannot be used with --tsa-url, keyless signatures are timestamped by the Rekor transparency log")
+	}
+	return nil
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/zarf-dev/zarf/src/cmd/tools"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -53,6 +55,16 @@ func preRun(cmd *cobra.Command, _ []string) error {
 		config.CommonOptions.PlainHTTP = true
 	}
 
+	if err := cluster.ValidateCommonMetadata(config.CommonOptions.Labels, config.CommonOptions.Annotations); err != nil {
+		return fmt.Errorf("invalid resource labels or annotations: %w", err)
+	}
+
+	chartRepoCredentials, err := common.GetChartRepoCredentials(common.GetViper())
+	if err != nil {
+		return err
+	}
+	config.CommonOptions.ChartRepoCredentials = chartRepoCredentials
+
 	// Skip for vendor only commands
 	if common.CheckVendorOnlyFromPath(cmd) {
 		return nil
@@ -106,9 +118,24 @@ func preRun(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+
+	removeStaleTempDirs(cmd.Context())
 	return nil
 }
 
+// removeStaleTempDirs removes the temp directories left behind by Zarf processes that exited without cleaning up, such
+// as when they crashed. Failing to remove them doesn't stop the command.
+func removeStaleTempDirs(ctx context.Context) {
+	l := logger.From(ctx)
+	removed, err := utils.RemoveStaleTempDirs(config.CommonOptions.TempDirectory, config.ZarfStaleTempDirAge)
+	for _, dir := range removed {
+		l.Debug("removed stale temp directory", "path", dir)
+	}
+	if err != nil {
+		l.Debug("unable to remove stale temp directories", "error", err)
+	}
+}
+
 func run(cmd *cobra.Command, _ []string) {
 	err := cmd.Help()
 	if err != nil {
@@ -138,12 +165,15 @@ func NewZarfCommand() *cobra.Command {
 	rootCmd.AddCommand(tools.NewToolsCommand())
 
 	// TODO(soltysh): consider adding command groups
+	rootCmd.AddCommand(NewBundleCommand())
 	rootCmd.AddCommand(NewConnectCommand())
 	rootCmd.AddCommand(NewDestroyCommand())
 	rootCmd.AddCommand(NewDevCommand())
 	rootCmd.AddCommand(NewInitCommand())
 	rootCmd.AddCommand(NewInternalCommand(rootCmd))
 	rootCmd.AddCommand(NewPackageCommand())
+	rootCmd.AddCommand(NewServeCommand())
+	rootCmd.AddCommand(NewStatusCommand())
 
 	rootCmd.AddCommand(NewVersionCommand())
 
@@ -202,6 +232,11 @@ func init() {
 	rootCmd.PersistentFlags().MarkDeprecated("insecure", "please use --plain-http, --insecure-skip-tls-verify, or --skip-signature-validation instead.")
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.PlainHTTP, "plain-http", v.GetBool(common.VPlainHTTP), lang.RootCmdFlagPlainHTTP)
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.InsecureSkipTLSVerify, "insecure-skip-tls-verify", v.GetBool(common.VInsecureSkipTLSVerify), lang.RootCmdFlagInsecureSkipTLSVerify)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.StateKeyPath, "state-key", v.GetString(common.VStateKey), lang.RootCmdFlagStateKey)
+
+	// Resource metadata
+	rootCmd.PersistentFlags().StringToStringVar(&config.CommonOptions.Labels, "resource-labels", v.GetStringMapString(common.VResourceLabels), lang.RootCmdFlagResourceLabels)
+	rootCmd.PersistentFlags().StringToStringVar(&config.CommonOptions.Annotations, "resource-annotations", v.GetStringMapString(common.VResourceAnnotations), lang.RootCmdFlagResourceAnnotations)
 }
 
 // setup Logger handles creating a logger and setting it as the global default.
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/cmd/common"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/server"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+// ServeOptions holds the command-line options for 'serve' sub-command.
+type ServeOptions struct {
+	address string
+	token   string
+	auth    string
+	tlsCert string
+	tlsKey  string
+	root    string
+	// skipSignatureValidation applies to every request, requests can not skip signature validation themselves.
+	skipSignatureValidation bool
+}
+
+// NewServeCommand creates the `serve` sub-command.
+func NewServeCommand() *cobra.Command {
+	o := ServeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: lang.CmdServeShort,
+		Long:  lang.CmdServeLong,
+		Args:  cobra.NoArgs,
+		RunE:  o.Run,
+	}
+
+	v := common.GetViper()
+	cmd.Flags().StringVar(&o.address, "address", v.GetString(common.VServeAddress), lang.CmdServeFlagAddress)
+	cmd.Flags().StringVar(&o.token, "token", v.GetString(common.VServeToken), lang.CmdServeFlagToken)
+	cmd.Flags().StringVar(&o.auth, "auth", v.GetString(common.VServeAuth), lang.CmdServeFlagAuth)
+	cmd.Flags().StringVar(&o.tlsCert, "tls-cert", v.GetString(common.VServeTLSCert), lang.CmdServeFlagTLSCert)
+	cmd.Flags().StringVar(&o.tlsKey, "tls-key", v.GetString(common.VServeTLSKey), lang.CmdServeFlagTLSKey)
+	cmd.Flags().StringVar(&o.root, "root", v.GetString(common.VServeRoot), lang.CmdServeFlagRoot)
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", false, lang.CmdServeFlagSkipSignatureValidation)
+
+	return cmd
+}
+
+// Run performs the execution of 'serve' sub-command.
+func (o *ServeOptions) Run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+	if (o.tlsCert == "") != (o.tlsKey == "") {
+		return errors.New("--tls-cert and --tls-key must be used together")
+	}
+	// Kubernetes tokens are credentials to the cluster and must not be sent over the network in plain text.
+	if o.auth == "kubernetes" && o.tlsCert == "" && !isLoopbackAddress(o.address) {
+		return fmt.Errorf("--tls-cert and --tls-key are required to serve with kubernetes authentication on %s, which is not a loopback address", o.address)
+	}
+	auth, err := o.authorizer(ctx)
+	if err != nil {
+		return err
+	}
+	// There is nobody to answer prompts for requests made over the API.
+	config.CommonOptions.Confirm = true
+
+	srv, err := server.New(ctx, auth, server.Options{Root: o.root, SkipSignatureValidation: o.skipSignatureValidation})
+	if err != nil {
+		return err
+	}
+	scheme := "http"
+	if o.tlsCert != "" {
+		scheme = "https"
+	}
+	// TODO(mkcp): Remove message on logger release
+	message.Infof(lang.CmdServeListening, scheme, o.address)
+	l.Info("serving the Zarf API", "address", o.address, "scheme", scheme, "auth", o.auth)
+	err = srv.ListenAndServe(ctx, o.address, o.tlsCert, o.tlsKey)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// isLoopbackAddress returns true when the host of the address only accepts connections from the local machine.
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// authorizer returns the authorizer of the --auth mode, generating a token when token authentication has none.
+func (o *ServeOptions) authorizer(ctx context.Context) (server.Authorizer, error) {
+	switch o.auth {
+	case "token":
+		token := o.token
+		if token == "" {
+			var err error
+			token, err = server.GenerateToken()
+			if err != nil {
+				return nil, err
+			}
+			// TODO(mkcp): Remove message on logger release
+			message.Infof(lang.CmdServeToken, token)
+			logger.From(ctx).Info("generated a token for the Zarf API, requests must send it as a bearer token", "token", token)
+		}
+		return server.NewTokenAuthorizer(token)
+	case "kubernetes":
+		if o.token != "" {
+			return nil, errors.New("--token cannot be used with kubernetes authentication")
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return nil, err
+		}
+		return server.NewTokenReviewAuthorizer(c.Clientset), nil
+	default:
+		return nil, fmt.Errorf("unknown auth %q, must be token or kubernetes", o.auth)
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	goyaml "github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// StatusOptions holds the command-line options for 'status' sub-command.
+type StatusOptions struct {
+	outputFormat string
+}
+
+// NewStatusCommand creates the `status` sub-command.
+func NewStatusCommand() *cobra.Command {
+	o := StatusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: lang.CmdStatusShort,
+		Long:  lang.CmdStatusLong,
+		Args:  cobra.NoArgs,
+		RunE:  o.Run,
+	}
+
+	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "", lang.CmdStatusFlagOutput)
+
+	return cmd
+}
+
+// Run performs the execution of 'status' sub-command.
+func (o *StatusOptions) Run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	if o.outputFormat != "" && o.outputFormat != "json" && o.outputFormat != "yaml" {
+		return fmt.Errorf("invalid output format %s, valid options are json and yaml", o.outputFormat)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the status of the Zarf infrastructure: %w", err)
+	}
+
+	switch o.outputFormat {
+	case "json":
+		b, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal json output: %w", err)
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+	case "yaml":
+		b, err := goyaml.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("could not marshal yaml output: %w", err)
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+	default:
+		printStatus(ctx, status)
+	}
+
+	if !status.Healthy {
+		return errors.New("the Zarf infrastructure is unhealthy")
+	}
+	return nil
+}
+
+func printStatus(ctx context.Context, status cluster.Status) {
+	l := logger.From(ctx)
+	data := [][]string{}
+	for _, check := range status.Checks {
+		health := "healthy"
+		switch {
+		case check.Skipped:
+			health = "skipped"
+		case !check.Healthy:
+			health = "unhealthy"
+		}
+		data = append(data, []string{check.Name, health, check.Message})
+		l.Info("status check", "name", check.Name, "status", health, "message", check.Message)
+	}
+	header := []string{"Check", "Status", "Message"}
+	message.TableWithWriter(message.OutputWriter, header, data)
+
+	if status.RegistryUsage != nil {
+		used := utils.ByteFormat(float64(status.RegistryUsage.UsedBytes), 2)
+		capacity := utils.ByteFormat(float64(status.RegistryUsage.CapacityBytes), 2)
+		message.Infof(lang.CmdStatusRegistryUsage, used, capacity)
+		l.Info("registry disk usage", "used", used, "capacity", capacity)
+		if status.RegistryUsage.NearlyFull() {
+			percent := float64(status.RegistryUsage.UsedBytes) / float64(status.RegistryUsage.CapacityBytes) * 100
+			message.Warnf(lang.CmdStatusRegistryNearlyFullWarn, percent)
+			l.Warn("registry storage is nearly full", "percent", fmt.Sprintf("%.0f", percent))
+		}
+	}
+	if status.RegistryBlobs != nil {
+		message.Infof(lang.CmdStatusRegistryBlobs, *status.RegistryBlobs)
+		l.Info("registry blobs", "count", *status.RegistryBlobs)
+	}
+	message.Infof(lang.CmdStatusDeployedPackages, status.DeployedPackages)
+	l.Info("deployed packages", "count", status.DeployedPackages)
+}
@@ -36,6 +36,8 @@ func NewToolsCommand() *cobra.Command {
 	cmd.AddCommand(NewDownloadInitCommand())
 	cmd.AddCommand(NewGenPKICommand())
 	cmd.AddCommand(NewGenKeyCommand())
+	cmd.AddCommand(NewSupportBundleCommand())
+	cmd.AddCommand(NewRemapImagesCommand())
 
 	return cmd
 }
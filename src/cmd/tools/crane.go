@@ -8,7 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -16,12 +18,14 @@ import (
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/google/go-containerregistry/pkg/logs"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/spf13/cobra"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -83,6 +87,7 @@ func NewRegistryCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(NewRegistryPruneCommand())
+	cmd.AddCommand(NewRegistryMigrateCommand())
 	cmd.AddCommand(NewRegistryLoginCommand())
 	cmd.AddCommand(NewRegistryCopyCommand())
 	cmd.AddCommand(NewRegistryCatalogCommand())
@@ -180,7 +185,9 @@ func (o *RegistryCatalogOptions) Run(cmd *cobra.Command, args []string) error {
 }
 
 // RegistryPruneOptions holds the command-line options for 'tools registry prune' sub-command.
-type RegistryPruneOptions struct{}
+type RegistryPruneOptions struct {
+	dryRun bool
+}
 
 // NewRegistryPruneCommand creates the `tools registry prune` sub-command.
 func NewRegistryPruneCommand() *cobra.Command {
@@ -190,11 +197,13 @@ func NewRegistryPruneCommand() *cobra.Command {
 		Use:     "prune",
 		Aliases: []string{"p"},
 		Short:   lang.CmdToolsRegistryPruneShort,
+		Long:    lang.CmdToolsRegistryPruneLong,
 		RunE:    o.Run,
 	}
 
 	// Always require confirm flag (no viper)
 	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsRegistryPruneFlagConfirm)
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, lang.CmdToolsRegistryPruneFlagDryRun)
 
 	return cmd
 }
@@ -219,6 +228,15 @@ func (o *RegistryPruneOptions) Run(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return lang.ErrUnableToGetPackages
 	}
+	// The images of the previous versions kept by the package retention policy, see 'zarf package prune', are still
+	// needed to roll back to them.
+	for _, pkg := range slices.Clone(zarfPackages) {
+		history, err := c.GetDeployedPackageHistory(ctx, pkg.Name)
+		if err != nil {
+			return err
+		}
+		zarfPackages = append(zarfPackages, history...)
+	}
 
 	// Set up a tunnel to the registry if applicable
 	registryEndpoint, tunnel, err := c.ConnectToZarfRegistryEndpoint(ctx, zarfState.RegistryInfo)
@@ -229,13 +247,32 @@ func (o *RegistryPruneOptions) Run(cmd *cobra.Command, _ []string) error {
 	if tunnel != nil {
 		l.Info("opening a tunnel to the Zarf registry", "local-endpoint", tunnel.Endpoint(), "cluster-address", zarfState.RegistryInfo.Address)
 		defer tunnel.Close()
-		return tunnel.Wrap(func() error { return doPruneImagesForPackages(ctx, zarfState, zarfPackages, registryEndpoint) })
+		return tunnel.Wrap(func() error {
+			return doPruneImagesForPackages(ctx, zarfState, zarfPackages, registryEndpoint, o.dryRun)
+		})
 	}
 
-	return doPruneImagesForPackages(ctx, zarfState, zarfPackages, registryEndpoint)
+	return doPruneImagesForPackages(ctx, zarfState, zarfPackages, registryEndpoint, o.dryRun)
 }
 
-func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, zarfPackages []types.DeployedPackage, registryEndpoint string) error {
+// isManifestNotFound returns true when the error is the response of a registry that does not have the manifest.
+func isManifestNotFound(err error) bool {
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+	if transportErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	for _, diagnostic := range transportErr.Errors {
+		if diagnostic.Code == transport.ManifestUnknownErrorCode {
+			return true
+		}
+	}
+	return false
+}
+
+func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, zarfPackages []types.DeployedPackage, registryEndpoint string, dryRun bool) error {
 	l := logger.From(ctx)
 	authOption := images.WithPushAuth(zarfState.RegistryInfo)
 
@@ -259,6 +296,11 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 					}
 
 					digest, err := crane.Digest(transformedImageNoCheck, authOption)
+					if isManifestNotFound(err) {
+						// Images that are not in the registry can not share a digest with the images to prune.
+						l.Debug("skipping package image that is not in the registry", "name", image, "error", err)
+						continue
+					}
 					if err != nil {
 						return err
 					}
@@ -304,15 +346,24 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 	}
 
 	if len(imageDigestsToPrune) == 0 {
+		message.Note(lang.CmdToolsRegistryPruneNoImages)
 		l.Info("there are no images to prune")
 		return nil
 	}
 
+	message.Note(lang.CmdToolsRegistryPruneImageList)
 	l.Info("the following image digests will be pruned from the registry:")
 	for digestRef := range imageDigestsToPrune {
+		message.Info(digestRef)
 		l.Info(digestRef)
 	}
 
+	if dryRun {
+		message.Notef(lang.CmdToolsRegistryPruneDryRun, len(imageDigestsToPrune))
+		l.Info("dry run, no images were pruned", "count", len(imageDigestsToPrune))
+		return nil
+	}
+
 	confirm := config.CommonOptions.Confirm
 	if !confirm {
 		prompt := &survey.Confirm{
@@ -337,6 +388,158 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 	return nil
 }
 
+// RegistryMigrateOptions holds the command-line options for 'tools registry migrate' sub-command.
+type RegistryMigrateOptions struct {
+	registry types.RegistryInfo
+}
+
+// NewRegistryMigrateCommand creates the `tools registry migrate` sub-command.
+func NewRegistryMigrateCommand() *cobra.Command {
+	o := RegistryMigrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "migrate",
+		Short:   lang.CmdToolsRegistryMigrateShort,
+		Long:    lang.CmdToolsRegistryMigrateLong,
+		Example: lang.CmdToolsRegistryMigrateExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.registry.Address, "to-external", "", lang.CmdToolsRegistryMigrateFlagToExternal)
+	cmd.Flags().StringVar(&o.registry.PushUsername, "push-username", "", lang.CmdInitFlagRegPushUser)
+	cmd.Flags().StringVar(&o.registry.PushPassword, "push-password", "", lang.CmdInitFlagRegPushPass)
+	cmd.Flags().StringVar(&o.registry.PullUsername, "pull-username", "", lang.CmdInitFlagRegPullUser)
+	cmd.Flags().StringVar(&o.registry.PullPassword, "pull-password", "", lang.CmdInitFlagRegPullPass)
+	// Always require confirm flag (no viper)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsRegistryMigrateFlagConfirm)
+	_ = cmd.MarkFlagRequired("to-external")
+	_ = cmd.MarkFlagRequired("push-username")
+	_ = cmd.MarkFlagRequired("push-password")
+
+	return cmd
+}
+
+// Run performs the execution of 'tools registry migrate' sub-command.
+func (o *RegistryMigrateOptions) Run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+	zarfState, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	if !zarfState.RegistryInfo.IsInternal() {
+		return fmt.Errorf("the cluster already uses the external registry %s", zarfState.RegistryInfo.Address)
+	}
+	zarfPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return lang.ErrUnableToGetPackages
+	}
+	// The images of the previous versions kept by the package retention policy, see 'zarf package prune', are still
+	// needed to roll back to them.
+	for _, pkg := range slices.Clone(zarfPackages) {
+		history, err := c.GetDeployedPackageHistory(ctx, pkg.Name)
+		if err != nil {
+			return err
+		}
+		zarfPackages = append(zarfPackages, history...)
+	}
+
+	registryEndpoint, tunnel, err := c.ConnectToZarfRegistryEndpoint(ctx, zarfState.RegistryInfo)
+	if err != nil {
+		return err
+	}
+	copyImages := func() error {
+		references, err := untaggedReferences(zarfPackages, registryEndpoint)
+		if err != nil {
+			return err
+		}
+		_, err = images.CopyRegistry(ctx, images.CopyRegistryConfig{
+			SourceURL:       registryEndpoint,
+			SourceInfo:      zarfState.RegistryInfo,
+			DestinationInfo: o.registry,
+			References:      references,
+		})
+		return err
+	}
+	if tunnel != nil {
+		l.Info("opening a tunnel to the Zarf registry", "local-endpoint", tunnel.Endpoint(), "cluster-address", zarfState.RegistryInfo.Address)
+		defer tunnel.Close()
+		err = tunnel.Wrap(copyImages)
+	} else {
+		err = copyImages()
+	}
+	if err != nil {
+		return fmt.Errorf("unable to copy the images of the internal registry: %w", err)
+	}
+
+	confirm := config.CommonOptions.Confirm
+	if !confirm {
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf(lang.CmdToolsRegistryMigrateConfirmContinue, o.registry.Address),
+		}
+		if err := survey.AskOne(prompt, &confirm); err != nil {
+			return fmt.Errorf("confirm selection canceled: %w", err)
+		}
+	}
+	if !confirm {
+		return nil
+	}
+
+	_, remapped, err := c.MigrateRegistry(ctx, zarfState, o.registry)
+	if err != nil {
+		return fmt.Errorf("unable to migrate to the external registry: %w", err)
+	}
+	if len(remapped) > 0 {
+		data := [][]string{}
+		for _, image := range remapped {
+			data = append(data, []string{image.Kind, fmt.Sprintf("%s/%s", image.Namespace, image.Name), image.Container, image.From, image.To, image.Action})
+		}
+		message.Table([]string{"Kind", "Workload", "Container", "From", "To", "Action"}, data)
+	}
+	message.Successf(lang.CmdToolsRegistryMigrateSuccess, o.registry.Address)
+	l.Info("migrated to the external registry", "address", o.registry.Address, "workloads-remapped", len(remapped))
+	return nil
+}
+
+// untaggedReferences returns the images of deployed packages that are pushed by digest, relative to the registry.
+// These are not listed by the tags of the registry catalog.
+func untaggedReferences(zarfPackages []types.DeployedPackage, registryEndpoint string) ([]string, error) {
+	references := []string{}
+	for _, pkg := range zarfPackages {
+		for _, component := range pkg.Data.Components {
+			for _, image := range component.Images {
+				refInfo, err := transform.ParseImageRef(image)
+				if err != nil {
+					return nil, err
+				}
+				if refInfo.Digest == "" {
+					continue
+				}
+				withChecksum, err := transform.ImageTransformHost(registryEndpoint, image)
+				if err != nil {
+					return nil, err
+				}
+				withoutChecksum, err := transform.ImageTransformHostWithoutChecksum(registryEndpoint, image)
+				if err != nil {
+					return nil, err
+				}
+				for _, name := range []string{withChecksum, withoutChecksum} {
+					references = append(references, strings.TrimPrefix(name, registryEndpoint+"/"))
+				}
+			}
+		}
+	}
+	return references, nil
+}
+
 // Wrap the original crane list with a zarf specific version
 func zarfCraneInternalWrapper(commandToWrap func(*[]crane.Option) *cobra.Command, cranePlatformOptions *[]crane.Option, exampleText string, imageNameArgumentIndex int) *cobra.Command {
 	wrappedCommand := commandToWrap(cranePlatformOptions)
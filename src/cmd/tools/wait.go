@@ -5,11 +5,13 @@
 package tools
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 
@@ -19,8 +21,9 @@ import (
 
 // WaitForOptions holds the command-line options for 'tools registry' sub-command.
 type WaitForOptions struct {
-	waitTimeout   string
-	waitNamespace string
+	waitTimeout    string
+	waitNamespace  string
+	waitAPIVersion string
 }
 
 // NewWaitForCommand creates the `tools wait-for` sub-command.
@@ -38,13 +41,14 @@ func NewWaitForCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&o.waitTimeout, "timeout", "5m", lang.CmdToolsWaitForFlagTimeout)
 	cmd.Flags().StringVarP(&o.waitNamespace, "namespace", "n", "", lang.CmdToolsWaitForFlagNamespace)
+	cmd.Flags().StringVar(&o.waitAPIVersion, "api-version", "", lang.CmdToolsWaitForFlagAPIVersion)
 	cmd.Flags().BoolVar(&message.NoProgress, "no-progress", false, lang.RootCmdFlagNoProgress)
 
 	return cmd
 }
 
 // Run performs the execution of 'tools wait-for' sub-command.
-func (o *WaitForOptions) Run(_ *cobra.Command, args []string) error {
+func (o *WaitForOptions) Run(cmd *cobra.Command, args []string) error {
 	// Parse the timeout string
 	timeout, err := time.ParseDuration(o.waitTimeout)
 	if err != nil {
@@ -65,6 +69,28 @@ func (o *WaitForOptions) Run(_ *cobra.Command, args []string) error {
 		condition = args[2]
 	}
 
+	if o.waitAPIVersion != "" {
+		return o.waitForResource(cmd.Context(), kind, identifier, condition, timeout)
+	}
+
 	// Execute the wait command.
 	return utils.ExecuteWait(o.waitTimeout, o.waitNamespace, condition, kind, identifier, timeout)
 }
+
+// waitForResource waits for the resource with the Kubernetes API, which unlike kubectl can wait for custom resources
+// whose definitions do not exist yet.
+func (o *WaitForOptions) waitForResource(ctx context.Context, kind, identifier, condition string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	c, err := cluster.NewCluster()
+	if err != nil {
+		return err
+	}
+	spinner := message.NewProgressSpinner("Waiting for %s %s to be %s.", kind, identifier, condition)
+	defer spinner.Stop()
+	if err := c.WaitForResource(ctx, o.waitAPIVersion, kind, identifier, o.waitNamespace, condition); err != nil {
+		return err
+	}
+	spinner.Success()
+	return nil
+}
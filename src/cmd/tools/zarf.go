@@ -6,15 +6,19 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
+	"github.com/mholt/archiver/v3"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,10 +29,12 @@ import (
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -47,7 +53,9 @@ const (
 )
 
 // GetCredsOptions holds the command-line options for 'tools get-creds' sub-command.
-type GetCredsOptions struct{}
+type GetCredsOptions struct {
+	outputFormat string
+}
 
 // NewGetCredsCommand creates the `tools get-creds` sub-command.
 func NewGetCredsCommand() *cobra.Command {
@@ -63,12 +71,17 @@ func NewGetCredsCommand() *cobra.Command {
 		RunE:    o.Run,
 	}
 
+	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "", lang.CmdToolsGetCredsFlagOutput)
+
 	return cmd
 }
 
 // Run performs the execution of 'tools get-creds' sub-command.
 func (o *GetCredsOptions) Run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
+	if o.outputFormat != "" && o.outputFormat != "json" {
+		return fmt.Errorf("invalid output format %s, valid options are json", o.outputFormat)
+	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
 	defer cancel()
@@ -86,6 +99,10 @@ func (o *GetCredsOptions) Run(cmd *cobra.Command, args []string) error {
 		return errors.New("zarf state secret did not load properly")
 	}
 
+	if o.outputFormat == "json" {
+		return printCredentialsJSON(state, args)
+	}
+
 	if len(args) > 0 {
 		// If a component name is provided, only show that component's credentials
 		// Printing both the pterm output and slogger for now
@@ -97,6 +114,24 @@ func (o *GetCredsOptions) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printCredentialsJSON prints the credential of the given component, or all credentials, as JSON.
+func printCredentialsJSON(state *types.ZarfState, args []string) error {
+	var output any = message.Credentials(state, nil)
+	if len(args) > 0 {
+		credential, ok := message.ComponentCredential(state, args[0])
+		if !ok {
+			return fmt.Errorf("unknown component: %s", args[0])
+		}
+		output = credential
+	}
+	b, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal json output: %w", err)
+	}
+	fmt.Fprintln(message.OutputWriter, string(b))
+	return nil
+}
+
 func printComponentCredential(ctx context.Context, state *types.ZarfState, componentName string) {
 	// TODO (@austinabro321) when we move over to the new logger, we can should add fmt.Println calls
 	// to this function as they will be removed from message.PrintComponentCredential
@@ -317,7 +352,9 @@ func printCredentialUpdates(ctx context.Context, oldState *types.ZarfState, newS
 }
 
 // ClearCacheOptions holds the command-line options for 'tools clear-cache' sub-command.
-type ClearCacheOptions struct{}
+type ClearCacheOptions struct {
+	temp bool
+}
 
 // NewClearCacheCommand creates the `tools clear-cache` sub-command.
 func NewClearCacheCommand() *cobra.Command {
@@ -331,6 +368,7 @@ func NewClearCacheCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&config.CommonOptions.CachePath, "zarf-cache", config.ZarfDefaultCachePath, lang.CmdToolsClearCacheFlagCachePath)
+	cmd.Flags().BoolVar(&o.temp, "temp", false, lang.CmdToolsClearCacheFlagTemp)
 
 	return cmd
 }
@@ -338,6 +376,9 @@ func NewClearCacheCommand() *cobra.Command {
 // Run performs the execution of 'tools clear-cache' sub-command.
 func (o *ClearCacheOptions) Run(cmd *cobra.Command, _ []string) error {
 	l := logger.From(cmd.Context())
+	if o.temp {
+		return clearTemp(cmd.Context())
+	}
 	cachePath, err := config.GetAbsCachePath()
 	if err != nil {
 		return err
@@ -352,6 +393,28 @@ func (o *ClearCacheOptions) Run(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// clearTemp removes the temp directories and the SBOM directory left behind by Zarf processes that exited without
+// cleaning up. Temp directories of Zarf processes that are still running are kept.
+func clearTemp(ctx context.Context) error {
+	l := logger.From(ctx)
+	removed, err := utils.RemoveStaleTempDirs(config.CommonOptions.TempDirectory, config.ZarfClearTempDirAge)
+	if err != nil {
+		return fmt.Errorf("unable to clear the temp directories: %w", err)
+	}
+	for _, dir := range removed {
+		l.Debug("removed stale temp directory", "path", dir)
+	}
+	if _, err := os.Stat(layout.SBOMDir); err == nil {
+		if err := os.RemoveAll(layout.SBOMDir); err != nil {
+			return fmt.Errorf("unable to remove the %s directory: %w", layout.SBOMDir, err)
+		}
+		removed = append(removed, layout.SBOMDir)
+	}
+	message.Successf(lang.CmdToolsClearCacheTempSuccess, len(removed))
+	l.Info("cleared temp directories", "count", len(removed))
+	return nil
+}
+
 // DownloadInitOptions holds the command-line options for 'tools download-init' sub-command.
 type DownloadInitOptions struct{}
 
@@ -386,6 +449,175 @@ func (o *DownloadInitOptions) Run(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// supportBundleLogFiles is the number of recent Zarf CLI log files included in a support bundle.
+const supportBundleLogFiles = 3
+
+// SupportBundleOptions holds the command-line options for 'tools support-bundle' sub-command.
+type SupportBundleOptions struct {
+	outputDirectory string
+}
+
+// NewSupportBundleCommand creates the `tools support-bundle` sub-command.
+func NewSupportBundleCommand() *cobra.Command {
+	o := &SupportBundleOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "support-bundle",
+		Aliases: []string{"sb"},
+		Short:   lang.CmdToolsSupportBundleShort,
+		Long:    lang.CmdToolsSupportBundleLong,
+		Args:    cobra.NoArgs,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVarP(&o.outputDirectory, "output-directory", "o", "", lang.CmdToolsSupportBundleFlagOutputDirectory)
+
+	return cmd
+}
+
+// Run performs the execution of 'tools support-bundle' sub-command.
+func (o *SupportBundleOptions) Run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	name := fmt.Sprintf("zarf-support-bundle-%s", time.Now().Format("2006-01-02-15-04-05"))
+	bundleDir := filepath.Join(tmpDir, name)
+	if err := helpers.CreateDirectory(bundleDir, helpers.ReadExecuteAllWriteUser); err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		// The CLI logs are still useful when the cluster can not be reached.
+		message.Warnf(lang.CmdToolsSupportBundleNoCluster, err)
+		l.Warn("unable to connect to the cluster, only CLI logs will be collected", "error", err)
+	} else {
+		spinner := message.NewProgressSpinner(lang.CmdToolsSupportBundleCollecting)
+		defer spinner.Stop()
+		l.Info("collecting cluster diagnostics")
+		if err := c.CollectSupportBundle(ctx, filepath.Join(bundleDir, "cluster")); err != nil {
+			return err
+		}
+		spinner.Success()
+	}
+
+	if err := copyRecentLogFiles(filepath.Join(bundleDir, "logs")); err != nil {
+		return err
+	}
+
+	if o.outputDirectory != "" {
+		if err := helpers.CreateDirectory(o.outputDirectory, helpers.ReadExecuteAllWriteUser); err != nil {
+			return err
+		}
+	}
+	dst := filepath.Join(o.outputDirectory, name+".tar.gz")
+	if err := archiver.Archive([]string{bundleDir}, dst); err != nil {
+		return fmt.Errorf("unable to create the support bundle archive: %w", err)
+	}
+	message.Successf(lang.CmdToolsSupportBundleSuccess, dst)
+	l.Info("created support bundle, review its contents before sharing it", "path", dst)
+	return nil
+}
+
+// copyRecentLogFiles copies the most recent Zarf CLI log files from the temporary directory into dst.
+func copyRecentLogFiles(dst string) error {
+	matches, err := filepath.Glob(filepath.Join(os.TempDir(), "zarf-*.log"))
+	if err != nil {
+		return err
+	}
+	// Log file names start with their creation timestamp so a reverse sort puts the newest first.
+	slices.Sort(matches)
+	slices.Reverse(matches)
+	if len(matches) > supportBundleLogFiles {
+		matches = matches[:supportBundleLogFiles]
+	}
+	if err := helpers.CreateDirectory(dst, helpers.ReadExecuteAllWriteUser); err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if err := helpers.CreatePathAndCopy(match, filepath.Join(dst, filepath.Base(match))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemapImagesOptions holds the command-line options for 'tools remap-images' sub-command.
+type RemapImagesOptions struct {
+	from   string
+	to     string
+	dryRun bool
+}
+
+// NewRemapImagesCommand creates the `tools remap-images` sub-command.
+func NewRemapImagesCommand() *cobra.Command {
+	o := &RemapImagesOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "remap-images",
+		Short:   lang.CmdToolsRemapImagesShort,
+		Long:    lang.CmdToolsRemapImagesLong,
+		Example: lang.CmdToolsRemapImagesExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.Run,
+	}
+
+	cmd.Flags().StringVar(&o.from, "from", "", lang.CmdToolsRemapImagesFlagFrom)
+	cmd.Flags().StringVar(&o.to, "to", "", lang.CmdToolsRemapImagesFlagTo)
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, lang.CmdToolsRemapImagesFlagDryRun)
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// Run performs the execution of 'tools remap-images' sub-command.
+func (o *RemapImagesOptions) Run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+
+	to := o.to
+	if to == "" {
+		state, err := c.LoadZarfState(ctx)
+		if err != nil {
+			return err
+		}
+		to = state.RegistryInfo.Address
+	}
+
+	remapped, err := c.RemapImages(ctx, o.from, to, o.dryRun)
+	if err != nil {
+		return err
+	}
+	if len(remapped) == 0 {
+		message.Notef(lang.CmdToolsRemapImagesNone, o.from)
+		logger.From(ctx).Info("no workloads reference images of deployed packages at the old registry address", "from", o.from)
+		return nil
+	}
+	data := [][]string{}
+	for _, image := range remapped {
+		data = append(data, []string{image.Kind, fmt.Sprintf("%s/%s", image.Namespace, image.Name), image.Container, image.From, image.To, image.Action})
+	}
+	message.Table([]string{"Kind", "Workload", "Container", "From", "To", "Action"}, data)
+	if o.dryRun {
+		return nil
+	}
+	message.Successf(lang.CmdToolsRemapImagesSuccess, len(remapped), to)
+	return nil
+}
+
 // GenPKIOptions holds the command-line options for 'tools gen-pki' sub-command.
 type GenPKIOptions struct{}
 
@@ -23,7 +23,8 @@ const (
 
 	ZarfCleanupScriptsPath = "/opt/zarf"
 
-	ZarfPackagePrefix = "zarf-package-"
+	ZarfPackagePrefix        = "zarf-package-"
+	ZarfPackageHistoryPrefix = "zarf-history-"
 
 	ZarfDeployStage = "Deploy"
 	ZarfCreateStage = "Create"
@@ -69,6 +70,14 @@ var (
 	// Default Time Vars
 	ZarfDefaultTimeout = 15 * time.Minute
 	ZarfDefaultRetries = 3
+
+	// ZarfStaleTempDirAge is the age after which temp directories that aren't named after the lock of the Zarf process
+	// that created them are removed when Zarf starts.
+	ZarfStaleTempDirAge = 24 * time.Hour
+
+	// ZarfClearTempDirAge is the age after which temp directories that aren't named after the lock of the Zarf process
+	// that created them are removed by clear-cache --temp, as they can belong to Zarf processes that are still running.
+	ZarfClearTempDirAge = time.Hour
 )
 
 // GetArch returns the arch based on a priority list with options for overriding.
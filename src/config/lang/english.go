@@ -50,6 +50,9 @@ const (
 	RootCmdFlagTempDir               = "Specify the temporary directory to use for intermediate files"
 	RootCmdFlagInsecure              = "Allow access to insecure registries and disable other recommended security enforcements such as package checksum and signature validation. This flag should only be used if you have a specific reason and accept the reduced security posture."
 	RootCmdFlagPlainHTTP             = "Force the connections over HTTP instead of HTTPS. This flag should only be used if you have a specific reason and accept the reduced security posture."
+	RootCmdFlagResourceLabels        = "Labels to add to every secret, namespace, chart resource and init component resource Zarf creates (e.g. --resource-labels=team=platform)."
+	RootCmdFlagResourceAnnotations   = "Annotations to add to every secret, namespace, chart resource and init component resource Zarf creates (e.g. --resource-annotations=owner=ops)."
+	RootCmdFlagStateKey              = "Path to the age key that decrypts the Zarf state and deployed package secrets when they are encrypted. Defaults to the sops age key (SOPS_AGE_KEY, SOPS_AGE_KEY_FILE or the sops keys.txt)."
 	RootCmdFlagInsecureSkipTLSVerify = "Skip checking server's certificate for validity. This flag should only be used if you have a specific reason and accept the reduced security posture."
 
 	RootCmdDeprecatedDeploy = "Deprecated: Please use \"zarf package deploy %s\" to deploy this package.  This warning will be removed in Zarf v1.0.0."
@@ -74,9 +77,11 @@ const (
 	CmdConnectFlagName       = "Specify the resource name.  E.g. name=unicorns or name=unicorn-pod-7448499f4d-b5bk6. Ignored if connect-name is supplied."
 	CmdConnectFlagNamespace  = "Specify the namespace.  E.g. namespace=default. Ignored if connect-name is supplied."
 	CmdConnectFlagType       = "Specify the resource type.  E.g. type=svc or type=pod. Ignored if connect-name is supplied."
-	CmdConnectFlagLocalPort  = "(Optional, autogenerated if not provided) Specify the local port to bind to.  E.g. local-port=42000."
+	CmdConnectFlagLocalPort  = "(Optional, autogenerated if not provided) Specify the local port to bind to, the tunnel fails if it is in use. When not provided a free port derived from the service is used.  E.g. local-port=42000."
 	CmdConnectFlagRemotePort = "Specify the remote port of the resource to bind to.  E.g. remote-port=8080. Ignored if connect-name is supplied."
 	CmdConnectFlagCliOnly    = "Disable browser auto-open"
+	CmdConnectFlagPrintCreds = "Print the credentials of the Zarf registry or git server from the Zarf state once the tunnel is established"
+	CmdConnectFlagEmbedCreds = "Embed the credentials of the Zarf registry or git server from the Zarf state in the URL opened in the browser, for basic authentication"
 
 	CmdConnectPreparingTunnel = "Preparing a tunnel to connect to %s"
 	CmdConnectEstablishedCLI  = "Tunnel established at %s, waiting for user to interrupt (ctrl-c to end)"
@@ -164,6 +169,17 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	CmdInitFlagRegPullPass = "Password for the pull-only user to access the registry"
 	CmdInitFlagRegSecret   = "Registry secret value"
 
+	CmdInitFlagInjectorImage        = "Image already present on the cluster nodes to run the Zarf injector in, instead of discovering one from a running pod"
+	CmdInitFlagInjectorBinary       = "Path to a local zarf-injector binary to use instead of the one included in the init package"
+	CmdInitFlagInjectorBinaryShasum = "SHA256 checksum of the --injector-binary override, the injector binary is only used if it matches"
+	CmdInitFlagSeedImage            = "Path to an image tarball (i.e. from docker save) to bootstrap the seed registry with instead of the registry image included in the init package, such as a hardened equivalent"
+	CmdInitFlagInjectorCPUReq       = "CPU request for the Zarf injector pod, the injector is only scheduled on nodes with this much allocatable CPU"
+	CmdInitFlagInjectorMemReq       = "Memory request for the Zarf injector pod, the injector is only scheduled on nodes with this much allocatable memory"
+	CmdInitFlagInjectorCPULim       = "CPU limit for the Zarf injector pod"
+	CmdInitFlagInjectorMemLim       = "Memory limit for the Zarf injector pod"
+	CmdInitFlagInjectorTimeout      = "Time to wait for the Zarf injector pod to become ready"
+	CmdInitFlagInjectorChunkSize    = "Size of the configmaps the seed image is split into for the Zarf injector, smaller chunks ease the load on small control planes (between 64Ki and 768Ki)"
+
 	CmdInitFlagArtifactURL       = "[alpha] External artifact registry url to use for this Zarf cluster"
 	CmdInitFlagArtifactPushUser  = "[alpha] Username to access to the artifact registry Zarf is configured to use. User must be able to upload package artifacts."
 	CmdInitFlagArtifactPushToken = "[alpha] API Token for the push-user to access the artifact registry"
@@ -175,12 +191,24 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	CmdInternalAgentLong  = "NOTE: This command is a hidden command and generally shouldn't be run by a human.\n" +
 		"This command starts up a http webhook that Zarf deployments use to mutate pods to conform " +
 		"with the Zarf container registry and Gitea server URLs."
+	CmdInternalAgentFlagSecretSync            = "Namespaces to keep the Zarf registry and git server secrets in (all, labeled or none), namespaces opt in or out with the zarf.dev/secret-sync=true|false label"
+	CmdInternalAgentFlagImageCheck            = "Check that the images pods are mutated to exist in the internal registry and report missing images with a pod annotation and a warning event"
+	CmdInternalAgentFlagRegistryProxy         = "Serve the internal registry as a pull-through registry mirror for container runtimes, so that images that are not mutated still resolve inside the air gap"
+	CmdInternalAgentFlagRegistryProxyMirrors  = "Registries the registry proxy falls back to in order when an image is not in the internal registry, as a host with an optional repository prefix"
+	CmdInternalAgentFlagRegistryProxyNodePort = "The node port the registry proxy is reached at by container runtimes, the node port service is only created while the registry proxy is enabled"
 
 	CmdInternalProxyShort = "[alpha] Runs the zarf agent http proxy"
 	CmdInternalProxyLong  = "[alpha] NOTE: This command is a hidden command and generally shouldn't be run by a human.\n" +
 		"This command starts up a http proxy that can be used by running pods to transform queries " +
 		"that conform to Gitea / Gitlab repository and package URLs in the airgap."
 
+	CmdInternalRegistryExporterShort = "Runs the zarf registry storage exporter"
+	CmdInternalRegistryExporterLong  = "NOTE: This command is a hidden command and generally shouldn't be run by a human.\n" +
+		"This command serves the storage usage and blob count of the Zarf registry as Prometheus metrics " +
+		"from a sidecar of the registry that mounts the registry storage."
+	CmdInternalRegistryExporterFlagPath = "Path the registry storage is mounted at"
+	CmdInternalRegistryExporterFlagPort = "Port to serve the metrics on"
+
 	CmdInternalGenerateCliDocsShort   = "Creates auto-generated markdown of all the commands for the CLI"
 	CmdInternalGenerateCliDocsSuccess = "Successfully created the CLI documentation"
 
@@ -210,12 +238,19 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	// zarf package
 	CmdPackageShort                       = "Zarf package commands for creating, deploying, and inspecting packages"
 	CmdPackageFlagConcurrency             = "Number of concurrent layer operations to perform when interacting with a remote package."
-	CmdPackageFlagFlagPublicKey           = "Path to public key file for validating signed packages"
+	CmdPackageFlagFlagPublicKey           = "Public key for validating signed packages. Accepts either a local file path or a Cosign-supported key provider (awskms://, azurekms://, gcpkms://, hashivault://, k8s:// or pkcs11:)"
 	CmdPackageFlagSkipSignatureValidation = "Skip validating the signature of the Zarf package"
+	CmdPackageFlagCertificateIdentity     = "Identity the certificate of a keyless package signature must be issued to, i.e. an email address or the URI of a CI workflow"
+	CmdPackageFlagCertificateOIDCIssuer   = "OIDC issuer that authenticated the identity of a keyless package signature (e.g. https://token.actions.githubusercontent.com)"
+	CmdPackageFlagVerificationPolicy      = "Path to a verification policy mapping package names and OCI repositories to the public keys of their trusted publishers, enforced when --key is not provided"
+	CmdInitFlagVerificationPolicy         = "Path to a verification policy mapping package names and OCI repositories to the public keys of their trusted publishers, stored in the cluster and enforced on every deploy"
+	CmdInitFlagStateRecipient             = "age recipient (public key) to encrypt the Zarf state and deployed package secrets to, so they can only be read with the matching --state-key. Can be repeated"
 	CmdPackageFlagRetries                 = "Number of retries to perform for Zarf deploy operations like git/image pushes or Helm installs"
 
-	CmdPackageCreateShort = "Creates a Zarf package from a given directory or the current directory"
+	CmdPackageCreateShort = "Creates a Zarf package from a given directory, git repository or the current directory"
 	CmdPackageCreateLong  = "Builds an archive of resources and dependencies defined by the 'zarf.yaml' in the specified directory.\n" +
+		"The directory can also be in a remote git repository, given as https://host/org/repo//path@ref, " +
+		"which is shallow cloned before the package is built.\n" +
 		"Private registries and repositories are accessed via credentials in your local '~/.docker/config.json', " +
 		"'~/.git-credentials' and '~/.netrc'.\n"
 
@@ -225,7 +260,9 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 
 	CmdPackageMirrorShort = "Mirrors a Zarf package's internal resources to specified image registries and git repositories"
 	CmdPackageMirrorLong  = "Unpacks resources and dependencies from a Zarf package archive and mirrors them into the specified\n" +
-		"image registries and git repositories within the target environment"
+		"image registries and git repositories within the target environment.\n\n" +
+		"Only the images and repositories are pushed, no charts, manifests or actions of the package are deployed.\n" +
+		"Failed pushes are retried with an increasing delay up to the number of --retries."
 	CmdPackageMirrorExample = `
 # Mirror resources to internal Zarf resources
 $ zarf package mirror-resources <your-package.tar.zst> \
@@ -249,47 +286,143 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 	CmdPackageInspectShort = "Displays the definition of a Zarf package (runs offline)"
 	CmdPackageInspectLong  = "Displays the 'zarf.yaml' definition for the specified package and optionally allows SBOMs to be viewed"
 
+	CmdPackageVerifyShort = "Verifies the checksums, signature and image layer digests of a Zarf package without deploying it (runs offline)"
+	CmdPackageVerifyLong  = "Verifies the checksums.txt, the cosign signature and the digests of every image blob of the specified package. " +
+		"The package is extracted to a temporary directory that is removed when verification completes. " +
+		"Unsigned packages pass with a warning that only their integrity was verified, unless a key is given with --key or the --verification-policy requires a signature."
+	CmdPackageVerifyExample = `
+# Verify the integrity of an unsigned package
+$ zarf package verify zarf-package-dos-games-amd64-1.0.0.tar.zst
+
+# Verify the integrity and signature of a package
+$ zarf package verify zarf-package-dos-games-amd64-1.0.0.tar.zst --key cosign.pub
+
+# Verify a package against the trusted publishers of a verification policy
+$ zarf package verify oci://ghcr.io/zarf-dev/packages/dos-games:1.0.0 --verification-policy policy.yaml
+`
+	CmdPackageVerifyFlagShasum = "Shasum of the package to verify. Required if verifying a https package. A shasum can be retrieved using 'zarf dev sha256sum <url>'"
+	CmdPackageVerifyUnsigned   = "Package %s is not signed, only the integrity of its content was verified"
+
+	CmdPackageDiffShort = "Compares two Zarf packages and lists the components, images, chart versions and variables that changed"
+	CmdPackageDiffLong  = "Loads two packages from any package source, or deployed packages by name, and lists the components that were added, removed or changed between them. " +
+		"Changed components list the images, chart versions and repositories that changed and any other part of the component that was modified. " +
+		"Variables whose definition changed are listed with their defaults, defaults of sensitive variables are not shown."
+	CmdPackageDiffExample = `
+# Compare a differential package to the package it was built from before shipping it
+$ zarf package diff zarf-package-podinfo-amd64-1.0.0.tar.zst zarf-package-podinfo-amd64-1.1.0-differential-1.0.0.tar.zst
+
+# Compare a deployed package to a newer version in a registry
+$ zarf package diff podinfo oci://ghcr.io/my-org/packages/podinfo:1.1.0
+
+# Print the comparison as JSON
+$ zarf package diff zarf-package-podinfo-amd64-1.0.0.tar.zst zarf-package-podinfo-amd64-1.1.0.tar.zst -o json
+`
+	CmdPackageDiffFlagOutput = "Output format (json|yaml)"
+
 	CmdPackageListShort         = "Lists out all of the packages that have been deployed to the cluster (runs offline)"
+	CmdPackageListFlagMetrics   = "Print the deploy metrics of the packages in the Prometheus text format instead of a table, such as for a node exporter textfile collector"
 	CmdPackageListNoPackageWarn = "Unable to get the packages deployed to the cluster"
 
-	CmdPackageCreateFlagConfirm               = "Confirm package creation without prompting"
-	CmdPackageCreateFlagSet                   = "Specify package variables to set on the command line (KEY=value)"
-	CmdPackageCreateFlagOutput                = "Specify the output (either a directory or an oci:// URL) for the created Zarf package"
-	CmdPackageCreateFlagSbom                  = "View SBOM contents after creating the package"
-	CmdPackageCreateFlagSbomOut               = "Specify an output directory for the SBOMs from the created Zarf package"
-	CmdPackageCreateFlagSkipSbom              = "Skip generating SBOM for this package"
-	CmdPackageCreateFlagMaxPackageSize        = "Specify the maximum size of the package in megabytes, packages larger than this will be split into multiple parts to be loaded onto smaller media (i.e. DVDs). Use 0 to disable splitting."
-	CmdPackageCreateFlagSigningKey            = "Private key for signing packages. Accepts either a local file path or a Cosign-supported key provider"
-	CmdPackageCreateFlagSigningKeyPassword    = "Password to the private key used for signing packages"
-	CmdPackageCreateFlagDeprecatedKey         = "[Deprecated] Path to private key file for signing packages (use --signing-key instead)"
-	CmdPackageCreateFlagDeprecatedKeyPassword = "[Deprecated] Password to the private key file used for signing packages (use --signing-key-pass instead)"
-	CmdPackageCreateFlagDifferential          = "[beta] Build a package that only contains the differential changes from local resources and differing remote resources from the specified previously built package"
-	CmdPackageCreateFlagRegistryOverride      = "Specify a map of domains to override on package create when pulling images (e.g. --registry-override docker.io=dockerio-reg.enterprise.intranet)"
-	CmdPackageCreateFlagFlavor                = "The flavor of components to include in the resulting package (i.e. have a matching or empty \"only.flavor\" key)"
-	CmdPackageCreateCleanPathErr              = "Invalid characters in Zarf cache path, defaulting to %s"
+	CmdPackageCreateFlagConfirm                 = "Confirm package creation without prompting"
+	CmdPackageCreateFlagSet                     = "Specify package variables to set on the command line (KEY=value)"
+	CmdPackageCreateFlagOutput                  = "Specify the output (either a directory or an oci:// URL) for the created Zarf package"
+	CmdPackageCreateFlagSbom                    = "View SBOM contents after creating the package"
+	CmdPackageCreateFlagSbomOut                 = "Specify an output directory for the SBOMs from the created Zarf package. May be a template of the package such as 'sboms/{{.Name}}-{{.Version}}'"
+	CmdPackageCreateFlagSkipSbom                = "Skip generating SBOM for this package"
+	CmdPackageCreateFlagImageAllow              = "Regular expressions of the approved images. When set, every image of the package must match one of them (e.g. '^registry1\\.dso\\.mil/')"
+	CmdPackageCreateFlagImageDeny               = "Regular expressions of the disallowed images. Images of the package must not match any of them (e.g. '^docker\\.io/')"
+	CmdPackageCreateFlagOverridePolicy          = "Create the package even when its images violate the image policy, warning about each violation"
+	CmdPackageCreateFlagRegistryPreset          = "Names of the registry presets of the Zarf config file that the images of the package must come from. Images are pulled with the auth of their preset and must have the labels it requires"
+	CmdPackageCreateFlagSkipSbomViewer          = "Leave the HTML SBOM viewers out of the package and only include the SBOM JSON. The viewers are created when the SBOMs are viewed or output with 'zarf package inspect'"
+	CmdPackageCreateFlagMaxPackageSize          = "Specify the maximum size of the package in megabytes, packages larger than this will be split into multiple parts to be loaded onto smaller media (i.e. DVDs). Use 0 to disable splitting."
+	CmdPackageCreateFlagSigningKey              = "Private key for signing packages. Accepts either a local file path or a Cosign-supported key provider (awskms://, azurekms://, gcpkms://, hashivault://, k8s:// or pkcs11:)"
+	CmdPackageCreateFlagSigningKeyPassword      = "Password to the private key used for signing packages"
+	CmdPackageCreateFlagSignChecksums           = "Also sign checksums.txt with the signing key, so the signature covers all of the package content and not only the zarf.yaml"
+	CmdPackageCreateFlagTSAURL                  = "URL of an RFC3161 timestamp authority (e.g. https://freetsa.org/tsr) that timestamps the signatures, so they can be verified after the signing key is rotated"
+	CmdPackageCreateFlagKeyless                 = "Sign the package keyless with a short-lived Fulcio certificate for your OIDC identity instead of a signing key. The signatures are recorded in the Rekor transparency log and the log entries are stored in the package for offline verification"
+	CmdPackageCreateFlagIdentityToken           = "OIDC identity token to request the Fulcio certificate of keyless signatures with, the token of the CI environment is used or one is requested in the browser when not set"
+	CmdPackageCreateFlagDeprecatedKey           = "[Deprecated] Path to private key file for signing packages (use --signing-key instead)"
+	CmdPackageCreateFlagDeprecatedKeyPassword   = "[Deprecated] Password to the private key file used for signing packages (use --signing-key-pass instead)"
+	CmdPackageCreateFlagDifferential            = "[beta] Build a package that only contains the differential changes from local resources and differing remote resources from the specified previously built package, either a local tarball or an oci:// reference to a published package"
+	CmdPackageCreateFlagRegistryOverride        = "Specify a map of domains to override on package create when pulling images (e.g. --registry-override docker.io=dockerio-reg.enterprise.intranet)"
+	CmdPackageCreateFlagFlattenImage            = "[alpha] Specify image references to flatten into a single layer on package create, reducing package size for images that do not share layers. Flattening changes the image digest."
+	CmdPackageCreateFlagFlavor                  = "The flavor of components to include in the resulting package (i.e. have a matching or empty \"only.flavor\" key)"
+	CmdPackageCreateFlagDownloadCacheTTL        = "How long remote files and published charts that are not pinned to a checksum are reused from the Zarf cache (e.g. 24h). Downloads pinned to a checksum are always reused, use 0 to always download unpinned files and charts"
+	CmdPackageCreateFlagSkipDownloadCacheVerify = "Skip verifying the checksum of cached downloads before reusing them"
+	CmdPackageCreateFlagDownloadConnections     = "Maximum number of parallel connections to download large remote files over, interrupted downloads are resumed where the server supports range requests"
+	CmdPackageCreateFlagRecursive               = "Create every package found in the directory tree, packages are created after the packages they import components from"
+	CmdPackageCreateFlagConcurrency             = "Number of components to assemble in parallel. Components whose create actions depend on each other must be assembled with a concurrency of 1"
+	CmdPackageCreateFlagSnapshotCharts          = "Store the rendered manifests of each chart in the package with variables left as templates. Deploy verifies the charts render the same and inspect can show the manifests without helm"
+	CmdPackageCreateFlagArchitectures           = "Create a single package for multiple architectures (e.g. amd64,arm64), with the images of each architecture and the components of all of them. Deploy selects the architecture of the cluster nodes"
+	CmdPackageCreateCleanPathErr                = "Invalid characters in Zarf cache path, defaulting to %s"
 
 	CmdPackageDeployFlagConfirm                        = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
 	CmdPackageDeployFlagAdoptExistingResources         = "Adopts any pre-existing K8s resources into the Helm charts managed by Zarf. ONLY use when you have existing deployments you want Zarf to takeover."
+	CmdPackageDeployFlagScopedCredentials              = "Mints read-only registry and git server credentials for the package instead of using the pull credentials generated during init. The credentials are revoked when the package is removed."
+	CmdPackageDeployFlagStrictCredentialExpiry         = "Fail instead of warning when the credentials Zarf manages in the cluster (agent TLS, registry, git and artifact server) are expired or near expiry"
+	CmdPackageDeployFlagClusterContext                 = "Maps the cluster alias of components to the kube context of the cluster to deploy them to (alias=context). Aliases that are not mapped are used as the name of the kube context."
+	CmdPackageDeployFlagValuesProfile                  = "Comma-separated list of values profiles whose chart values layers are applied on top of the chart values files, in the order the layers are defined in the package"
+	CmdPackageDeployFlagSetHelmValues                  = "Set a chart value on deploy as CHART_NAME.KEY=VALUE (e.g. podinfo.nodeSelector.disk=ssd), using the format of helm --set. Takes precedence over the chart values files and variables"
+	CmdPackageDeployFlagPostRenderer                   = "Path to an executable that post-renders the manifests of every chart after Zarf templates them, like the helm --post-renderer flag"
+	CmdPackageDeployFlagSkipImagePush                  = "Skip pushing the images of the package to the registry. Use when the images are already staged in the registry by other tooling, charts and manifests are still deployed"
+	CmdPackageDeployFlagImagePushDryRun                = "List the digests of the images that would be pushed to the registry and the names they would be pushed as and stop without deploying the package"
+	CmdPackageDeployFlagAttestationKey                 = "Public key the attestations attached to a package in a registry must be signed with. Accepts either a local file path or a Cosign-supported key provider (awskms://, azurekms://, gcpkms://, hashivault://, k8s:// or pkcs11:)"
+	CmdPackageDeployFlagKeylessAttestations            = "Verify that the attestations attached to a package in a registry are signed keyless by the --certificate-identity and --certificate-oidc-issuer instead of with a key"
 	CmdPackageDeployFlagSet                            = "Specify deployment variables to set on the command line (KEY=value)"
 	CmdPackageDeployFlagComponents                     = "Comma-separated list of components to deploy.  Adding this flag will skip the prompts for selected components.  Globbing component names with '*' and deselecting 'default' components with a leading '-' are also supported."
 	CmdPackageDeployFlagShasum                         = "Shasum of the package to deploy. Required if deploying a remote https package."
 	CmdPackageDeployFlagSget                           = "[Deprecated] Path to public sget key file for remote packages signed via cosign. This flag will be removed in v1.0.0 please use the --key flag instead."
 	CmdPackageDeployFlagTimeout                        = "Timeout for health checks and Helm operations such as installs and rollbacks"
+	CmdPackageDeployFlagReadinessTimeout               = "Timeout for the resources of each component to be ready after its charts, manifests and actions are deployed. Defaults to --timeout"
+	CmdPackageDeployFlagJSONIO                         = "Read a plan, apply or read request as JSON from stdin and write the result as JSON to stdout, only deploying when the package differs from the deployed package"
+	CmdPackageDeployFlagOutput                         = "Write the result of a successful deployment as a json or yaml document to stdout, all other output is written to stderr"
+	CmdPackageDeployFlagOutputFile                     = "Write the deployment result to the file instead of stdout, requires --output"
 	CmdPackageDeployValidateArchitectureErr            = "this package architecture is %s, but the target cluster only has the %s architecture(s). These architectures must be compatible when \"images\" are present"
 	CmdPackageDeployValidateLastNonBreakingVersionWarn = "The version of this Zarf binary '%s' is less than the LastNonBreakingVersion of '%s'. You may need to upgrade your Zarf version to at least '%s' to deploy this package"
+	CmdPackageDeployHelmVersionWarn                    = "This package was created with helm %s but this Zarf is built with helm %s. Charts may render differently, create the package with chart snapshots to verify them on deploy"
 	CmdPackageDeployInvalidCLIVersionWarn              = "CLIVersion is set to '%s' which can cause issues with package creation and deployment. To avoid such issues, please set the value to the valid semantic version for this version of Zarf."
 
 	CmdPackageMirrorFlagComponents = "Comma-separated list of components to mirror.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
 	CmdPackageMirrorFlagNoChecksum = "Turns off the addition of a checksum to image tags (as would be used by the Zarf Agent) while mirroring images."
 
-	CmdPackageInspectFlagSbom       = "View SBOM contents while inspecting the package"
-	CmdPackageInspectFlagSbomOut    = "Specify an output directory for the SBOMs from the inspected Zarf package"
-	CmdPackageInspectFlagListImages = "List images in the package (prints to stdout)"
+	CmdPackageInspectFlagSbom                = "View SBOM contents while inspecting the package"
+	CmdPackageInspectFlagSbomOut             = "Specify an output directory for the SBOMs from the inspected Zarf package. May be a template of the package such as 'sboms/{{.Name}}-{{.Version}}'"
+	CmdPackageInspectFlagListImages          = "List images in the package (prints to stdout)"
+	CmdPackageInspectFlagListAnnotations     = "List the OCI manifest annotations the package was or would be published with"
+	CmdPackageInspectFlagListSizes           = "List the size, image count and image digests of each component recorded when the package was created"
+	CmdPackageInspectFlagListSignatures      = "List the signatures of the package, the file each covers and when and by which timestamp authority it was timestamped"
+	CmdPackageInspectFlagChartSnapshots      = "Print the rendered manifests of the charts snapshotted when the package was created"
+	CmdPackageInspectFlagAttestationKey      = "Verify the attestations attached to a package in a registry with the public key and list them"
+	CmdPackageInspectFlagKeylessAttestations = "Verify the attestations attached to a package in a registry keyless against the --certificate-identity and --certificate-oidc-issuer and list them"
+
+	CmdPackagePruneShort = "Removes the records, unused images and Helm release history of old versions of a deployed package"
+	CmdPackagePruneLong  = "Removes the records of superseded versions of a deployed package beyond the number of versions to keep. " +
+		"Images that were only used by the removed versions are deleted from the Zarf registry, and Helm release revisions of the package charts beyond the number of versions to keep are removed."
+	CmdPackagePruneExample = `
+# Keep the records of the current and two previous versions of a package
+$ zarf package prune my-package --keep 3 --confirm
+`
+	CmdPackagePruneFlagKeep    = "Number of package versions to keep, including the currently deployed version"
+	CmdPackagePruneFlagConfirm = "REQUIRED. Confirm the prune action to prevent accidental deletions"
+
+	CmdPackageSearchShort = "Lists the Zarf packages available in an OCI registry"
+	CmdPackageSearchLong  = "Lists the Zarf packages in the repositories of an OCI registry, optionally limited to the repositories under a namespace. " +
+		"Registries that do not support listing their repositories can be searched by providing the full repository."
+	CmdPackageSearchExample = `
+# List all Zarf packages in a registry
+$ zarf package search oci://registry.example.com
+
+# List the Zarf packages under a namespace
+$ zarf package search oci://ghcr.io/zarf-dev/packages
+`
+	CmdPackageSearchFlagOutput = "Output format (json|yaml)"
 
-	CmdPackageRemoveShort          = "Removes a Zarf package that has been deployed already (runs offline)"
-	CmdPackageRemoveLong           = "Removes a Zarf package that has been deployed already (runs offline). Remove reverses the deployment order, the last component is removed first."
-	CmdPackageRemoveFlagConfirm    = "REQUIRED. Confirm the removal action to prevent accidental deletions"
-	CmdPackageRemoveFlagComponents = "Comma-separated list of components to remove.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
+	CmdPackageRemoveShort              = "Removes a Zarf package that has been deployed already (runs offline)"
+	CmdPackageRemoveLong               = "Removes a Zarf package that has been deployed already (runs offline). Remove reverses the deployment order, the last component and chart are removed first, and waits for the namespaces created for charts to terminate. Namespaces that still contain resources not deployed by Zarf are kept. The onRemove actions of components are templated with the same variables as onDeploy actions, using the non-sensitive values the package was deployed with unless they are set again with --set."
+	CmdPackageRemoveFlagConfirm        = "REQUIRED. Confirm the removal action to prevent accidental deletions"
+	CmdPackageRemoveFlagComponents     = "Comma-separated list of components to remove.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
+	CmdPackageRemoveFlagSet            = "Specify the deployment variables used to template remove actions on the command line (KEY=value)"
+	CmdPackageRemoveFlagClusterContext = "Maps the cluster alias of components to the kube context of the cluster to remove them from (alias=context). Aliases that are not mapped are used as the name of the kube context."
 
 	CmdPackagePublishShort   = "Publishes a Zarf package to a remote registry"
 	CmdPackagePublishExample = `
@@ -299,9 +432,16 @@ $ zarf package publish my-package.tar oci://my-registry.com/my-namespace
 # Publish a skeleton package to a remote registry
 $ zarf package publish ./path/to/dir oci://my-registry.com/my-namespace
 `
-	CmdPackagePublishFlagSigningKey         = "Private key for signing or re-signing packages with a new key. Accepts either a local file path or a Cosign-supported key provider"
+	CmdPackagePublishFlagSigningKey         = "Private key for signing or re-signing packages with a new key. Accepts either a local file path or a Cosign-supported key provider (awskms://, azurekms://, gcpkms://, hashivault://, k8s:// or pkcs11:)"
 	CmdPackagePublishFlagSigningKeyPassword = "Password to the private key used for publishing packages"
+	CmdPackagePublishFlagTSAURL             = "URL of an RFC3161 timestamp authority that timestamps the signatures when signing or re-signing packages"
+	CmdPackagePublishFlagKeyless            = "Sign or re-sign the package keyless with a short-lived Fulcio certificate for your OIDC identity instead of a signing key"
+	CmdPackagePublishFlagIdentityToken      = "OIDC identity token to request the Fulcio certificate of keyless signatures with"
 	CmdPackagePublishFlagConfirm            = "Confirms package publish without prompting. Skips prompt for the signing key password"
+	CmdPackagePublishFlagRetries            = "Number of attempts to push each package layer, failed pushes are retried with an exponential backoff"
+	CmdPackagePublishFlagResume             = "Continue a failed publish to the same reference, skipping the layers the previous publish pushed"
+	CmdPackagePublishFlagManifestType       = "Type of manifest to publish the package with (default, image, artifact or auto). 'default' publishes the same manifest as previous releases, 'auto' publishes an artifact and falls back to an image manifest if the registry rejects it"
+	CmdPackagePublishFlagAttest             = "Attestations to sign with the signing key, or keyless with --keyless, and attach to the published package as OCI referrers (sbom, provenance)"
 
 	CmdPackagePullShort   = "Pulls a Zarf package from a remote registry and save to the local file system"
 	CmdPackagePullExample = `
@@ -314,7 +454,7 @@ $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a ar
 # Pull a skeleton package
 $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a skeleton`
 	CmdPackagePullFlagOutputDirectory = "Specify the output directory for the pulled Zarf package"
-	CmdPackagePullFlagShasum          = "Shasum of the package to pull. Required if pulling a https package. A shasum can be retrieved using 'zarf dev sha256sum <url>'"
+	CmdPackagePullFlagShasum          = "Shasum of the package to pull. Required if pulling a https package, optional for s3 packages. A shasum can be retrieved using 'zarf dev sha256sum <url>'"
 
 	CmdPackageChoose                = "Choose or type the package file"
 	CmdPackageClusterSourceFallback = "%q does not satisfy any current sources, assuming it is a package deployed to a cluster"
@@ -323,10 +463,69 @@ $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a sk
 	// zarf dev (prepare is an alias for dev)
 	CmdDevShort = "Commands useful for developing packages"
 
-	CmdDevDeployShort      = "[beta] Creates and deploys a Zarf package from a given directory"
-	CmdDevDeployLong       = "[beta] Creates and deploys a Zarf package from a given directory, setting options like YOLO mode for faster iteration."
+	CmdDevDeployShort = "[beta] Creates and deploys a Zarf package from a given directory"
+	CmdDevDeployLong  = "[beta] Creates and deploys a Zarf package from a given directory, setting options like YOLO mode for faster iteration.\n" +
+		"Components are deployed straight from the assembled directory without building an archive, SBOM, checksums or signatures."
 	CmdDevDeployFlagNoYolo = "Disable the YOLO mode default override and create / deploy the package as-defined"
 
+	CmdDevRegistryShort = "Runs a throwaway OCI registry for local development"
+	CmdDevRegistryLong  = "Runs a throwaway OCI registry on this machine until interrupted, for publishing and pulling packages and images while developing.\n" +
+		"By default the first of docker, podman or nerdctl found on the PATH runs the registry image, without a container runtime the registry runs within Zarf."
+	CmdDevRegistryExample = `
+# Run a registry with the detected container runtime
+$ zarf dev registry
+
+# Run a registry within Zarf, no container runtime needed
+$ zarf dev registry --runtime go
+
+# Publish a package to the registry
+$ zarf package publish zarf-package-dos-games-amd64-1.1.0.tar.zst oci://127.0.0.1:5000 --plain-http
+`
+	CmdDevRegistryFlagAddress    = "Address the registry listens on"
+	CmdDevRegistryFlagRuntime    = "What runs the registry (auto, go, docker, podman or nerdctl), auto uses the first container runtime found and falls back to go"
+	CmdDevRegistryFlagStorageDir = "Directory to keep the registry contents in, the contents are kept in memory and lost on exit when not set"
+	CmdDevRegistryFlagImage      = "Registry image run by container runtimes"
+
+	CmdDevClusterShort       = "Creates and destroys local clusters for testing packages"
+	CmdDevClusterCreateShort = "Creates a local k3d or kind cluster initialized with Zarf"
+	CmdDevClusterCreateLong  = "Creates a local cluster with k3d or kind, whichever is found first on the PATH, and deploys the init package to it.\n" +
+		"The init package is found the same way as by 'zarf init', so a package in the cache is reused. Only the registry and agent are deployed unless optional components are given."
+	CmdDevClusterCreateExample = `
+# Create a cluster with the registry and agent
+$ zarf dev cluster create
+
+# Create a kind cluster that also runs the git server
+$ zarf dev cluster create --provider kind --components git-server
+
+# Deploy a package to the cluster
+$ zarf package deploy zarf-package-dos-games-amd64-1.1.0.tar.zst --confirm
+`
+	CmdDevClusterCreateFlagImage      = "Node image of the cluster, the default of the provider is used when not set"
+	CmdDevClusterCreateFlagComponents = "Comma-separated list of optional components of the init package to deploy (e.g. git-server)"
+	CmdDevClusterCreateFlagConfirm    = "Does not prompt to download the init package when it is not found locally, the command fails instead"
+	CmdDevClusterDestroyShort         = "Destroys a local cluster created with 'zarf dev cluster create'"
+	CmdDevClusterDestroyExample       = `
+# Destroy the cluster
+$ zarf dev cluster destroy
+`
+	CmdDevClusterFlagName     = "Name of the cluster"
+	CmdDevClusterFlagProvider = "What runs the cluster (auto, k3d or kind), auto uses the first of k3d or kind found"
+
+	CmdDevReleaseShort = "Bumps the package version and adds the changes to the changelog"
+	CmdDevReleaseLong  = "Compares the zarf.yaml in a directory to the one at a git ref, by default the latest tag, and bumps metadata.version from the version at that ref.\n" +
+		"Removed components bump the major version, added components bump the minor version and any other component change, such as a new image, bumps the patch version.\n" +
+		"The changes are added to the top of the changelog."
+	CmdDevReleaseExample = `
+# Bump the version and write the changelog from the changes since the latest tag
+$ zarf dev release .
+
+# Compare against a branch and commit and tag the release
+$ zarf dev release . --base main --tag
+`
+	CmdDevReleaseFlagBase      = "Git ref with the previous package definition, defaults to the latest tag"
+	CmdDevReleaseFlagChangelog = "Changelog the release is added to, relative to the package directory"
+	CmdDevReleaseFlagTag       = "Commit the package definition and changelog and tag the commit with the new version"
+
 	CmdDevGenerateShort   = "[alpha] Creates a zarf.yaml automatically from a given remote (git) Helm chart"
 	CmdDevGenerateExample = "zarf dev generate podinfo --url https://github.com/stefanprodan/podinfo.git --version 6.4.0 --gitPath charts/podinfo"
 
@@ -357,7 +556,25 @@ $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a sk
 	CmdDevFlagFindImagesSkipCosign = "Skip searching for cosign artifacts related to discovered images"
 
 	CmdDevLintShort = "Lints the given package for valid schema and recommended practices"
-	CmdDevLintLong  = "Verifies the package schema, checks if any variables won't be evaluated, and checks for unpinned images/repos/files"
+	CmdDevLintLong  = "Verifies the package schema, checks if any variables won't be evaluated, checks for unpinned images/repos/files, " +
+		"and renders the charts and manifests of the package to check for privileged containers, host networking, host path volumes and wildcard RBAC"
+	CmdDevLintFlagSecuritySeverity = "Severity of the security rules (privileged, host-network, host-path, wildcard-rbac) as rule=severity, where severity is error, warning or off"
+
+	CmdDevShowShort = "Shows how the given package is composed"
+
+	CmdDevShowImportChainShort = "Shows the import chains of the components in the given package"
+	CmdDevShowImportChainLong  = "Shows the components that each component imports, where each imported component was loaded from, a local path or an OCI skeleton, " +
+		"and which fields each component overrides in the components it imports, with the value it overrides and where that value was set. " +
+		"Only the import chain of the given component is shown when a component is given."
+	CmdDevShowImportChainExample = `
+# Show the import chains of all components of the package in the current directory
+$ zarf dev show import-chain
+
+# Show the import chain of a component as a graph and render it with graphviz
+$ zarf dev show import-chain podinfo --dir ./my-package -o dot | dot -Tsvg > import-chain.svg
+`
+	CmdDevShowImportChainFlagDir    = "Directory of the package to show the import chains of"
+	CmdDevShowImportChainFlagOutput = "Output format of the import chains (tree|dot)"
 
 	// zarf tools
 	CmdToolsShort = "Collection of additional tools to make airgap easier"
@@ -417,15 +634,38 @@ $ zarf tools registry digest 127.0.0.1:31999/stefanprodan/podinfo:6.4.0
 $ zarf tools registry digest reg.example.com/stefanprodan/podinfo:6.4.0
 `
 
-	CmdToolsRegistryPruneShort       = "Prunes images from the registry that are not currently being used by any Zarf packages."
+	CmdToolsRegistryPruneShort = "Prunes images from the registry that are not currently being used by any Zarf packages."
+	CmdToolsRegistryPruneLong  = "Prunes images from the registry that are not used by the deployed components of any Zarf package. " +
+		"Images of the previous package versions kept by the package retention policy (see 'zarf package prune') are kept so that the packages can be rolled back."
 	CmdToolsRegistryPruneFlagConfirm = "Confirm the image prune action to prevent accidental deletions"
+	CmdToolsRegistryPruneFlagDryRun  = "List the image digests that would be pruned without deleting them"
 	CmdToolsRegistryPruneImageList   = "The following image digests will be pruned from the registry:"
 	CmdToolsRegistryPruneNoImages    = "There are no images to prune"
+	CmdToolsRegistryPruneDryRun      = "Dry run, %d image digests were not pruned"
 	CmdToolsRegistryPruneLookup      = "Looking up images within package definitions"
 	CmdToolsRegistryPruneCatalog     = "Cataloging images in the registry"
 	CmdToolsRegistryPruneCalculate   = "Calculating images to prune"
 	CmdToolsRegistryPruneDelete      = "Deleting unused images"
 
+	CmdToolsRegistryMigrateShort = "Migrates the cluster from the internal Zarf registry to an external registry"
+	CmdToolsRegistryMigrateLong  = "Copies every image of the internal Zarf registry to an external registry, then saves the external registry in the Zarf state, " +
+		"updates the image pull secrets of every Zarf managed namespace, rewrites workloads that reference images at the internal registry address and restarts the Zarf agent. " +
+		"Images are copied while the internal registry keeps serving the cluster and the cluster is only switched once every image is copied. " +
+		"Blobs that already exist in the external registry are not copied again, so an interrupted migration can be run again. " +
+		"The internal registry is left running so that it can be removed once the migration is verified."
+	CmdToolsRegistryMigrateExample = `
+# Migrate to an external registry, pull credentials default to the push credentials
+$ zarf tools registry migrate --to-external registry.example.com --push-username zarf-push --push-password "$PUSH_PASSWORD"
+
+# Migrate with separate pull credentials and without prompting
+$ zarf tools registry migrate --to-external registry.example.com --push-username zarf-push --push-password "$PUSH_PASSWORD" \
+	--pull-username zarf-pull --pull-password "$PULL_PASSWORD" --confirm
+`
+	CmdToolsRegistryMigrateFlagToExternal  = "Address of the external registry to migrate to"
+	CmdToolsRegistryMigrateFlagConfirm     = "Confirm switching the cluster to the external registry once the images are copied"
+	CmdToolsRegistryMigrateConfirmContinue = "All images were copied, switch the cluster to %s?"
+	CmdToolsRegistryMigrateSuccess         = "Migrated the cluster to the external registry %s"
+
 	CmdToolsRegistryFlagVerbose  = "Enable debug logs"
 	CmdToolsRegistryFlagInsecure = "Allow image references to be fetched without TLS"
 	CmdToolsRegistryFlagNonDist  = "Allow pushing non-distributable (foreign) layers"
@@ -487,6 +727,8 @@ zarf tools yq e '.a.b = "cool"' -i file.yaml
 	CmdToolsClearCacheDir           = "Cache directory set to: %s"
 	CmdToolsClearCacheSuccess       = "Successfully cleared the cache from %s"
 	CmdToolsClearCacheFlagCachePath = "Specify the location of the Zarf artifact cache (images and git repositories)"
+	CmdToolsClearCacheFlagTemp      = "Clear the temp directories and the zarf-sbom directory left behind by Zarf processes that exited without cleaning up, instead of the cache. Temp directories created by older versions of Zarf are only cleared once they are an hour old"
+	CmdToolsClearCacheTempSuccess   = "Successfully cleared %d temp directories"
 
 	CmdToolsDownloadInitShort               = "Downloads the init package for the current Zarf version into the specified directory"
 	CmdToolsDownloadInitFlagOutputDirectory = "Specify a directory to place the init package in."
@@ -503,6 +745,36 @@ zarf tools yq e '.a.b = "cool"' -i file.yaml
 	CmdToolsGenKeyErrPasswordsNotMatch = "passwords do not match"
 	CmdToolsGenKeySuccess              = "Generated key pair and written to %s and %s"
 
+	CmdToolsSupportBundleShort = "Collects diagnostic information about Zarf into a tarball for support requests"
+	CmdToolsSupportBundleLong  = "Collects the Zarf state (with credentials redacted), deployed package summaries, Zarf pod status and logs " +
+		"(agent, registry, gitea and injector), Zarf namespace events, recent cluster warnings and the most recent Zarf CLI log files into a single tarball.\n\n" +
+		"Review the contents of the bundle before sharing it as pod logs and events may contain information about your environment."
+	CmdToolsSupportBundleFlagOutputDirectory = "Specify a directory to place the support bundle in."
+	CmdToolsSupportBundleCollecting          = "Collecting cluster diagnostics"
+	CmdToolsSupportBundleNoCluster           = "Unable to connect to the cluster, only CLI logs will be collected: %s"
+	CmdToolsSupportBundleSuccess             = "Support bundle saved to %s, review its contents before sharing it"
+
+	CmdToolsRemapImagesShort = "Rewrites the images of workloads to a new Zarf registry address"
+	CmdToolsRemapImagesLong  = "Rewrites the images of the deployments, statefulsets, daemonsets, replicasets and cronjobs in the cluster that reference images of deployed packages at the old registry address to the new address. " +
+		"Only images recorded in the deployed packages are rewritten. Workloads whose running pods were mutated to the old address by the Zarf agent are restarted, so that the agent mutates them to the registry address in the Zarf state. " +
+		"Jobs and pods without a controller can not be changed and are listed to be recreated."
+	CmdToolsRemapImagesExample = `
+# Preview the images that reference the previous registry address
+$ zarf tools remap-images --from 127.0.0.1:31999 --dry-run
+
+# Rewrite them to the registry address in the Zarf state
+$ zarf tools remap-images --from 127.0.0.1:31999
+
+# Rewrite them to a registry that is not in the Zarf state yet
+$ zarf tools remap-images --from 127.0.0.1:31999 --to registry.example.com
+`
+	CmdToolsRemapImagesFlagFrom   = "The previous address of the registry that workloads reference"
+	CmdToolsRemapImagesFlagTo     = "The new address of the registry, defaults to the registry address in the Zarf state"
+	CmdToolsRemapImagesFlagDryRun = "List the images that would be rewritten and the workloads that would be restarted without updating them"
+	CmdToolsRemapImagesNone       = "No workloads reference images of deployed packages at %s"
+	CmdToolsRemapImagesSuccess    = "Remapped %d images to %s"
+	CmdSupportBundleHint          = "To collect diagnostic information for a support request run `zarf tools support-bundle`"
+
 	CmdToolsSbomShort = "Generates a Software Bill of Materials (SBOM) for the given package"
 
 	CmdToolsWaitForShort = "Waits for a given Kubernetes resource to be ready"
@@ -520,6 +792,10 @@ $ zarf tools wait-for svc zarf-docker-registry -n zarf                  #  same
 $ zarf tools wait-for crd addons.k3s.cattle.io                          #  wait for crd addons.k3s.cattle.io to exist
 $ zarf tools wait-for sts test-sts '{.status.availableReplicas}'=23     #  wait for statefulset test-sts to have 23 available replicas
 
+# Wait for custom resources with the Kubernetes API:
+$ zarf tools wait-for certificate my-cert ready -n app --api-version cert-manager.io/v1         #  wait for certificate my-cert to have a Ready condition
+$ zarf tools wait-for kafkas my-cluster .status.phase=Ready -n kafka --api-version kafka.strimzi.io/v1beta2  #  wait for the phase of kafka my-cluster to be Ready
+
 # Wait for network endpoints:
 $ zarf tools wait-for http localhost:8080 200                           #  wait for a 200 response from http://localhost:8080
 $ zarf tools wait-for tcp localhost:8080                                #  wait for a connection to be established on localhost:8080
@@ -527,8 +803,9 @@ $ zarf tools wait-for https 1.1.1.1 200                                 #  wait
 $ zarf tools wait-for http google.com                                   #  wait for any 2xx response from http://google.com
 $ zarf tools wait-for http google.com success                           #  wait for any 2xx response from http://google.com
 `
-	CmdToolsWaitForFlagTimeout   = "Specify the timeout duration for the wait command."
-	CmdToolsWaitForFlagNamespace = "Specify the namespace of the resources to wait for."
+	CmdToolsWaitForFlagTimeout    = "Specify the timeout duration for the wait command."
+	CmdToolsWaitForFlagNamespace  = "Specify the namespace of the resources to wait for."
+	CmdToolsWaitForFlagAPIVersion = "Specify the API version of the resources to wait for, to wait for them with the Kubernetes API instead of kubectl. Use it to wait for custom resources of operators."
 
 	CmdToolsKubectlDocs = "Kubectl command. See https://kubernetes.io/docs/reference/kubectl/overview/ for more information."
 
@@ -544,7 +821,11 @@ $ zarf tools get-creds registry-readonly
 $ zarf tools get-creds git
 $ zarf tools get-creds git-readonly
 $ zarf tools get-creds artifact
+
+# Get Zarf credentials as JSON:
+$ zarf tools get-creds registry -o json
 `
+	CmdToolsGetCredsFlagOutput = "Output format of the credentials (json)"
 
 	CmdToolsUpdateCredsShort   = "Updates the credentials for deployed Zarf services. Pass a service key to update credentials for a single service"
 	CmdToolsUpdateCredsLong    = "Updates the credentials for deployed Zarf services. Pass a service key to update credentials for a single service. i.e. 'zarf tools update-creds registry'"
@@ -582,6 +863,48 @@ $ zarf tools update-creds artifact --artifact-push-username={USERNAME} --artifac
 	CmdToolsUpdateCredsUnableUpdateCreds    = "Unable to update Zarf credentials"
 
 	// zarf version
+	CmdStatusShort = "Shows the health of the Zarf infrastructure in the cluster"
+	CmdStatusLong  = "Checks the health of the Zarf agent webhook, internal registry, git server, artifact server and Zarf state, " +
+		"and shows the number of packages deployed to the cluster. Exits with a non-zero code when any check fails."
+	CmdStatusFlagOutput             = "Output format (json|yaml)"
+	CmdStatusRegistryUsage          = "Registry storage usage: %s of %s"
+	CmdStatusRegistryBlobs          = "Registry blobs: %d"
+	CmdStatusRegistryNearlyFullWarn = "The registry storage is %.0f%% full, remove unused images with \"zarf tools registry prune\" or increase the size of the registry volume"
+	CmdStatusDeployedPackages       = "Deployed packages: %d"
+
+	// zarf serve
+	CmdServeShort = "Serves an API to create, deploy, remove and inspect packages"
+	CmdServeLong  = "Starts an HTTP API that creates, deploys and removes packages, streams the progress of these operations " +
+		"as server-sent events, inspects packages and lists the packages deployed to the cluster. Every request must send a bearer token. " +
+		"With token authentication a random token is generated and printed when none is given. With kubernetes authentication " +
+		"requests send Kubernetes tokens issued for the zarf.dev audience, such as with 'kubectl create token --audience zarf.dev', which are reviewed by the cluster " +
+		"and authorized with RBAC rules for the resources of the zarf.dev API group, and TLS is required unless listening on a loopback address. " +
+		"Listing deployed packages requires the list verb on packages, inspecting a package the get verb and creating, deploying and " +
+		"removing packages the create, deploy and remove verbs. Following jobs requires the get and list verbs on jobs."
+	CmdServeFlagAddress                 = "Address to listen on, listening on addresses other than localhost exposes the API to the network"
+	CmdServeFlagToken                   = "Token that requests must send in the Authorization header as a bearer token"
+	CmdServeFlagAuth                    = "How requests are authenticated, either \"token\" to require the --token or \"kubernetes\" to review Kubernetes tokens with a TokenReview and authorize them with a SubjectAccessReview"
+	CmdServeFlagTLSCert                 = "Path to the certificate to serve the API over TLS with, requires --tls-key"
+	CmdServeFlagTLSKey                  = "Path to the private key of the --tls-cert"
+	CmdServeFlagRoot                    = "Directory that the local paths of requests must be within, relative paths of requests are relative to it (default: the current directory)"
+	CmdServeFlagSkipSignatureValidation = "Skip validating the signatures of the packages of every request, requests can not skip it themselves"
+	CmdServeListening                   = "Serving the Zarf API on %s://%s"
+	CmdServeToken                       = "Requests must send the generated token %s as a bearer token"
+
+	// zarf bundle
+	CmdBundleShort       = "Zarf commands for creating and deploying bundles of several packages"
+	CmdBundleCreateShort = "Creates a bundle of several built packages from a bundle definition"
+	CmdBundleCreateLong  = "Pulls or copies the packages listed in a bundle definition, local paths or URLs and OCI references of " +
+		"built packages, into a single transferable archive with a combined manifest of the packages and their checksums."
+	CmdBundleCreateFlagOutput = "Specify the output directory for the created bundle"
+	CmdBundleDeployShort      = "Deploys the packages of a bundle in order"
+	CmdBundleDeployLong       = "Deploys the packages of a bundle archive, or of a bundle definition, in the order they are listed. " +
+		"Non-sensitive variables set while deploying a package, including those set by actions, are passed on to the packages deployed after it that declare them."
+	CmdBundleDeployFlagConfirm                 = "Confirms the deployment of each package without prompting. Skips prompt for variables and optional components"
+	CmdBundleDeployFlagSet                     = "Specify deployment variables to set on the command line for every package of the bundle (KEY=value)"
+	CmdBundleDeployFlagKey                     = "Path to public key file for validating the signatures of the packages"
+	CmdBundleDeployFlagSkipSignatureValidation = "Skip validating the signatures of the packages"
+
 	CmdVersionShort = "Shows the version of the running Zarf binary"
 	CmdVersionLong  = "Displays the version of the Zarf release that the current binary was built from."
 
@@ -49,7 +49,7 @@ func mutateHelmRepo(ctx context.Context, r *v1.AdmissionRequest, cluster *cluste
 		return &operations.Result{Allowed: true}, nil
 	}
 
-	zarfState, err := cluster.LoadZarfState(ctx)
+	zarfState, err := cluster.LoadAgentState(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks provides HTTP handlers for the mutating webhook.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	// imageCheckTimeout bounds the time an admission request waits for the registry to confirm the images of a pod exist.
+	imageCheckTimeout = 3 * time.Second
+	// missingImagesAnnotation lists the original images of a pod that were not found in the internal registry.
+	missingImagesAnnotation = annotationPrefix + "/missing-images"
+	// missingImagesReason is the reason of the events emitted for pods with images that are not in the internal registry.
+	missingImagesReason = "ImageNotInRegistry"
+	// manifestAcceptHeader lists the manifest media types accepted when checking that an image exists.
+	manifestAcceptHeader = "application/vnd.oci.image.index.v1+json, application/vnd.oci.image.manifest.v1+json, " +
+		"application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// ImageCheck verifies that the images pods are mutated to exist in the internal registry.
+// Pods are always admitted, images that are missing are reported with an annotation and an event.
+type ImageCheck struct {
+	// RegistryURL is the URL the internal registry is reached at from the agent.
+	RegistryURL string
+	// Client is the HTTP client used to reach the registry.
+	Client *http.Client
+}
+
+// NewImageCheck returns an image check against the service of the internal registry.
+func NewImageCheck() *ImageCheck {
+	return &ImageCheck{
+		RegistryURL: fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", cluster.ZarfRegistryName, cluster.ZarfNamespaceName, cluster.ZarfRegistryPort),
+		Client:      &http.Client{Timeout: imageCheckTimeout},
+	}
+}
+
+// missingImages returns the original images whose mutated image is not in the internal registry.
+// Images are reported as present when the registry cannot be reached so that a registry outage does not flood the cluster with events.
+func (ic *ImageCheck) missingImages(ctx context.Context, regInfo types.RegistryInfo, mutated map[string]string) []string {
+	l := logger.From(ctx)
+	ctx, cancel := context.WithTimeout(ctx, imageCheckTimeout)
+	defer cancel()
+	missing := []string{}
+	for original, replacement := range mutated {
+		exists, err := ic.exists(ctx, regInfo, replacement)
+		if err != nil {
+			l.Warn("unable to check that the image exists in the registry", "image", replacement, "error", err)
+			continue
+		}
+		if !exists {
+			missing = append(missing, original)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// exists returns true if the manifest of the image exists in the registry.
+func (ic *ImageCheck) exists(ctx context.Context, regInfo types.RegistryInfo, image string) (bool, error) {
+	ref, err := transform.ParseImageRef(image)
+	if err != nil {
+		return false, err
+	}
+	reference := ref.Tag
+	if ref.Digest != "" {
+		reference = ref.Digest
+	}
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", ic.RegistryURL, ref.Path, reference)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.SetBasicAuth(regInfo.PullUsername, regInfo.PullPassword)
+	req.Header.Set("Accept", manifestAcceptHeader)
+	resp, err := ic.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+// recordMissingImages emits a warning event for a pod with images that are not in the internal registry.
+// Pods created by controllers have no name at admission so the event is recorded on the owner of the pod.
+func recordMissingImages(ctx context.Context, c *cluster.Cluster, namespace string, pod *corev1.Pod, missing []string) error {
+	involved := corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  namespace,
+		Name:       pod.Name,
+		UID:        pod.UID,
+	}
+	if len(pod.OwnerReferences) > 0 {
+		owner := pod.OwnerReferences[0]
+		involved = corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  namespace,
+			Name:       owner.Name,
+			UID:        owner.UID,
+		}
+	}
+	if involved.Name == "" {
+		involved.Name = pod.GenerateName
+	}
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			// Events are named like the events of the Kubernetes event recorder.
+			Name:      fmt.Sprintf("%s.%x", involved.Name, now.UnixNano()),
+			Namespace: namespace,
+		},
+		InvolvedObject: involved,
+		Reason:         missingImagesReason,
+		Message:        fmt.Sprintf("The images %s were not pushed to the Zarf registry, the pod will fail to pull them", strings.Join(missing, ", ")),
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: "zarf-agent"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	_, err := c.Clientset.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/types"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodMutationImageCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	img, err := random.Image(16, 1)
+	require.NoError(t, err)
+	err = crane.Push(img, strings.TrimPrefix(srv.URL, "http://")+"/library/nginx:latest-zarf-3793515731")
+	require.NoError(t, err)
+
+	state := &types.ZarfState{RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999", NodePort: 31999}}
+	c := createTestClientWithZarfState(ctx, t, state)
+	imageCheck := &ImageCheck{RegistryURL: srv.URL, Client: srv.Client()}
+	handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(ctx, c, imageCheck))
+
+	req := createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "podinfo-7d9f8c-",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "podinfo-7d9f8c"},
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:     []corev1.Container{{Name: "nginx", Image: "nginx"}},
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+		},
+	})
+	req.Namespace = "podinfo"
+	rr := sendAdmissionRequest(t, req, handler)
+	verifyAdmission(t, rr, admissionTest{
+		patch: []operations.PatchOperation{
+			operations.ReplacePatchOperation(
+				"/spec/imagePullSecrets",
+				[]corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}},
+			),
+			operations.ReplacePatchOperation(
+				"/spec/initContainers/0/image",
+				"127.0.0.1:31999/library/busybox:latest-zarf-2140033595",
+			),
+			operations.ReplacePatchOperation(
+				"/spec/containers/0/image",
+				"127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+			),
+			operations.ReplacePatchOperation(
+				"/metadata/labels",
+				map[string]string{"zarf-agent": "patched"},
+			),
+			operations.ReplacePatchOperation(
+				"/metadata/annotations",
+				map[string]string{
+					"zarf.dev/original-image-nginx": "nginx",
+					"zarf.dev/original-image-init":  "busybox",
+					"zarf.dev/missing-images":       "busybox",
+				},
+			),
+		},
+		code: http.StatusOK,
+	})
+
+	events, err := c.Clientset.CoreV1().Events("podinfo").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	require.Equal(t, missingImagesReason, events.Items[0].Reason)
+	require.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+	require.Equal(t, "ReplicaSet", events.Items[0].InvolvedObject.Kind)
+	require.Equal(t, "podinfo-7d9f8c", events.Items[0].InvolvedObject.Name)
+	require.Contains(t, events.Items[0].Message, "busybox")
+
+	// Unreachable registries do not report images as missing.
+	unreachable := &ImageCheck{RegistryURL: "http://127.0.0.1:1", Client: &http.Client{}}
+	missing := unreachable.missingImages(ctx, state.RegistryInfo, map[string]string{"nginx": "127.0.0.1:31999/library/nginx:latest-zarf-3793515731"})
+	require.Empty(t, missing)
+}
@@ -24,13 +24,14 @@ import (
 const annotationPrefix = "zarf.dev"
 
 // NewPodMutationHook creates a new instance of pods mutation hook.
-func NewPodMutationHook(ctx context.Context, cluster *cluster.Cluster) operations.Hook {
+// When imageCheck is not nil the images pods are mutated to are checked to exist in the internal registry.
+func NewPodMutationHook(ctx context.Context, cluster *cluster.Cluster, imageCheck *ImageCheck) operations.Hook {
 	return operations.Hook{
 		Create: func(r *v1.AdmissionRequest) (*operations.Result, error) {
-			return mutatePod(ctx, r, cluster)
+			return mutatePod(ctx, r, cluster, imageCheck)
 		},
 		Update: func(r *v1.AdmissionRequest) (*operations.Result, error) {
-			return mutatePod(ctx, r, cluster)
+			return mutatePod(ctx, r, cluster, imageCheck)
 		},
 	}
 }
@@ -59,7 +60,7 @@ func getImageAnnotationKey(ctx context.Context, containerName string) string {
 	return key
 }
 
-func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster) (*operations.Result, error) {
+func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster, imageCheck *ImageCheck) (*operations.Result, error) {
 	l := logger.From(ctx)
 	pod, err := parsePod(r.Object.Raw)
 	if err != nil {
@@ -74,7 +75,7 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 		}, nil
 	}
 
-	state, err := cluster.LoadZarfState(ctx)
+	state, err := cluster.LoadAgentState(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +94,7 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 	if updatedAnnotations == nil {
 		updatedAnnotations = make(map[string]string)
 	}
+	mutatedImages := map[string]string{}
 
 	// update the image host for each init container
 	for idx, container := range pod.Spec.InitContainers {
@@ -102,6 +104,7 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 			return nil, err
 		}
 		updatedAnnotations[getImageAnnotationKey(ctx, container.Name)] = container.Image
+		mutatedImages[container.Image] = replacement
 		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
 	}
 
@@ -113,6 +116,7 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 			return nil, err
 		}
 		updatedAnnotations[getImageAnnotationKey(ctx, container.Name)] = container.Image
+		mutatedImages[container.Image] = replacement
 		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
 	}
 
@@ -124,9 +128,21 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 			return nil, err
 		}
 		updatedAnnotations[getImageAnnotationKey(ctx, container.Name)] = container.Image
+		mutatedImages[container.Image] = replacement
 		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
 	}
 
+	if imageCheck != nil && state.RegistryInfo.IsInternal() {
+		missing := imageCheck.missingImages(ctx, state.RegistryInfo, mutatedImages)
+		if len(missing) > 0 {
+			l.Warn("pod references images that are not in the Zarf registry", "namespace", r.Namespace, "images", missing)
+			updatedAnnotations[missingImagesAnnotation] = strings.Join(missing, ",")
+			if err := recordMissingImages(ctx, cluster, r.Namespace, pod, missing); err != nil {
+				l.Warn("unable to record an event for the missing images", "namespace", r.Namespace, "error", err)
+			}
+		}
+	}
+
 	patches = append(patches, getLabelPatch(pod.Labels))
 
 	patches = append(patches, operations.ReplacePatchOperation("/metadata/annotations", updatedAnnotations))
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package http provides a http server for the webhook and proxy.
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// defaultRegistryHost is the registry of images whose requests do not name the registry they were meant for.
+const defaultRegistryHost = "docker.io"
+
+// registryRequestPath matches the paths of the manifest and blob requests of the OCI distribution API.
+var registryRequestPath = regexp.MustCompile(`^/v2/(.+)/(manifests|blobs)/([^/]+)$`)
+
+// registryProxyStateTTL is how long the registry proxy reuses the Zarf state before loading it again, so that
+// rotated credentials are picked up without loading the state for every blob request.
+const registryProxyStateTTL = time.Minute
+
+// registryResponseHeaders are the headers of upstream responses that are passed on to the client.
+var registryResponseHeaders = []string{"Content-Type", "Content-Length", "Docker-Content-Digest", "Etag", "Last-Modified"}
+
+// RegistryProxy serves images to container runtimes that use it as a registry mirror, so that workloads whose images
+// are not mutated by the agent still resolve inside the air gap. Images are served from the Zarf registry and then from
+// the mirrors in order. Clients authenticate with the pull credentials of the Zarf registry, as the proxy pulls from the
+// Zarf registry with those credentials on their behalf.
+type RegistryProxy struct {
+	// Mirrors are the registries tried in order when an image is not in the Zarf registry, as a host with an optional
+	// scheme and repository prefix (e.g. harbor.example.com/dockerhub).
+	Mirrors []string
+	// RegistryURL is the URL the Zarf registry is reached at, when empty it is derived from the registry info of the state.
+	RegistryURL string
+	// Client is the HTTP client used to reach the Zarf registry and the mirrors.
+	Client *auth.Client
+	// LoadState loads the Zarf state for the credentials of the Zarf registry.
+	LoadState func(ctx context.Context) (*types.ZarfState, error)
+
+	mu       sync.Mutex
+	state    *types.ZarfState
+	loadedAt time.Time
+}
+
+// registryTarget is an upstream location an image is looked up at.
+type registryTarget struct {
+	url      string
+	username string
+	password string
+}
+
+// NewRegistryProxy returns a registry proxy for the Zarf registry of the cluster that falls back to the given mirrors.
+func NewRegistryProxy(c *cluster.Cluster, mirrors []string) *RegistryProxy {
+	return &RegistryProxy{
+		Mirrors:   mirrors,
+		Client:    &auth.Client{Cache: auth.NewCache()},
+		LoadState: c.LoadAgentState,
+	}
+}
+
+// ServeHTTP serves the read-only part of the OCI distribution API.
+func (rp *RegistryProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := logger.From(r.Context())
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeRegistryError(w, http.StatusMethodNotAllowed, "UNSUPPORTED", "the registry proxy is read-only")
+		return
+	}
+	w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+
+	state, err := rp.loadState(r.Context())
+	if err != nil {
+		l.Debug("unable to load the Zarf state", "error", err)
+		writeRegistryError(w, http.StatusInternalServerError, "UNKNOWN", "unable to load the Zarf state, see the Zarf agent logs for more details")
+		return
+	}
+	if !authorized(r, state.RegistryInfo) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="zarf-registry-proxy"`)
+		writeRegistryError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authenticate with the pull credentials of the Zarf registry")
+		return
+	}
+
+	if r.URL.Path == "/v2/" || r.URL.Path == "/v2" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	matches := registryRequestPath.FindStringSubmatch(r.URL.Path)
+	if matches == nil {
+		writeRegistryError(w, http.StatusNotFound, "NAME_UNKNOWN", "unsupported registry request")
+		return
+	}
+	name, kind, reference := matches[1], matches[2], matches[3]
+	// Container runtimes name the registry a mirror request was meant for with the ns query parameter.
+	host := r.URL.Query().Get("ns")
+	if host == "" {
+		host = defaultRegistryHost
+	}
+
+	targets, err := rp.targets(state.RegistryInfo, host, name, kind, reference)
+	if err != nil {
+		l.Debug("unable to resolve the image of the request", "path", r.URL.Path, "error", err)
+		writeRegistryError(w, http.StatusBadRequest, "NAME_INVALID", err.Error())
+		return
+	}
+
+	status := http.StatusNotFound
+	for _, target := range targets {
+		resp, err := rp.fetch(r, target)
+		if err != nil {
+			l.Debug("unable to reach the registry", "url", target.url, "error", err)
+			status = http.StatusBadGateway
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			//nolint: errcheck // ignore
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusNotFound {
+				l.Debug("unexpected registry response", "url", target.url, "status", resp.Status)
+				status = http.StatusBadGateway
+			}
+			continue
+		}
+		defer resp.Body.Close()
+		for _, header := range registryResponseHeaders {
+			if value := resp.Header.Get(header); value != "" {
+				w.Header().Set(header, value)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			//nolint: errcheck // the response has already been started
+			io.Copy(w, resp.Body)
+		}
+		return
+	}
+	if status == http.StatusNotFound {
+		code := "MANIFEST_UNKNOWN"
+		if kind == "blobs" {
+			code = "BLOB_UNKNOWN"
+		}
+		writeRegistryError(w, status, code, fmt.Sprintf("%s/%s %s was not found in the Zarf registry or its mirrors", host, name, reference))
+		return
+	}
+	writeRegistryError(w, status, "UNAVAILABLE", "the Zarf registry and its mirrors could not be reached, see the Zarf agent logs for more details")
+}
+
+// loadState returns the Zarf state, it is loaded again once it is older than registryProxyStateTTL.
+func (rp *RegistryProxy) loadState(ctx context.Context) (*types.ZarfState, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	if rp.state != nil && time.Since(rp.loadedAt) < registryProxyStateTTL {
+		return rp.state, nil
+	}
+	state, err := rp.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rp.state = state
+	rp.loadedAt = time.Now()
+	return state, nil
+}
+
+// authorized returns true when the request carries the pull credentials of the Zarf registry.
+func authorized(r *http.Request, regInfo types.RegistryInfo) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok || regInfo.PullUsername == "" {
+		return false
+	}
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(regInfo.PullUsername)) == 1
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(regInfo.PullPassword)) == 1
+	return usernameMatch && passwordMatch
+}
+
+// targets returns the locations of a manifest or blob in the Zarf registry and the mirrors in the order they are tried.
+// Manifests referenced by tag are looked up by the checksum tag Zarf pushes images with first.
+func (rp *RegistryProxy) targets(regInfo types.RegistryInfo, host, name, kind, reference string) ([]registryTarget, error) {
+	isDigest := strings.Contains(reference, ":")
+	image := fmt.Sprintf("%s/%s:%s", host, name, reference)
+	if isDigest {
+		image = fmt.Sprintf("%s/%s@%s", host, name, reference)
+	}
+	ref, err := transform.ParseImageRef(image)
+	if err != nil {
+		return nil, err
+	}
+	references := []string{reference}
+	if kind == "manifests" && !isDigest {
+		references = []string{fmt.Sprintf("%s-zarf-%d", ref.Tag, helpers.GetCRCHash(ref.Name)), reference}
+	}
+
+	registryURL := rp.RegistryURL
+	if registryURL == "" {
+		registryURL = zarfRegistryURL(regInfo)
+	}
+	targets := []registryTarget{}
+	for _, reference := range references {
+		targets = append(targets, registryTarget{
+			url:      fmt.Sprintf("%s/v2/%s/%s/%s", registryURL, ref.Path, kind, reference),
+			username: regInfo.PullUsername,
+			password: regInfo.PullPassword,
+		})
+	}
+	for _, mirror := range rp.Mirrors {
+		// Mirrors are reached over HTTPS unless a scheme is given.
+		scheme := "https"
+		if before, after, ok := strings.Cut(mirror, "://"); ok {
+			scheme, mirror = before, after
+		}
+		mirrorHost, prefix, _ := strings.Cut(strings.TrimSuffix(mirror, "/"), "/")
+		repository := ref.Path
+		if prefix != "" {
+			repository = prefix + "/" + repository
+		}
+		targets = append(targets, registryTarget{url: fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, mirrorHost, repository, kind, reference)})
+	}
+	return targets, nil
+}
+
+// fetch requests a manifest or blob from an upstream registry on behalf of the client.
+func (rp *RegistryProxy) fetch(r *http.Request, target registryTarget) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, target.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept := r.Header.Get("Accept"); accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if target.username != "" {
+		req.SetBasicAuth(target.username, target.password)
+	}
+	return rp.Client.Do(req)
+}
+
+// zarfRegistryURL returns the URL the Zarf registry is reached at from within the cluster.
+func zarfRegistryURL(regInfo types.RegistryInfo) string {
+	if regInfo.IsInternal() {
+		return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", cluster.ZarfRegistryName, cluster.ZarfNamespaceName, cluster.ZarfRegistryPort)
+	}
+	return "https://" + regInfo.Address
+}
+
+// registryError is an error of the OCI distribution API.
+type registryError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeRegistryError writes an error response of the OCI distribution API.
+func writeRegistryError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint: errcheck // ignore
+	json.NewEncoder(w).Encode(map[string][]registryError{"errors": {{Code: code, Message: message}}})
+}
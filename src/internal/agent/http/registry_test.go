@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRegistryProxy(t *testing.T) {
+	t.Parallel()
+
+	zarfServer := httptest.NewServer(registry.New())
+	t.Cleanup(zarfServer.Close)
+	mirrorServer := httptest.NewServer(registry.New())
+	t.Cleanup(mirrorServer.Close)
+	zarfHost := strings.TrimPrefix(zarfServer.URL, "http://")
+	mirrorHost := strings.TrimPrefix(mirrorServer.URL, "http://")
+
+	push := func(ref string) v1.Image {
+		t.Helper()
+		img, err := random.Image(512, 2)
+		require.NoError(t, err)
+		parsed, err := name.ParseReference(ref)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(parsed, img))
+		return img
+	}
+	// Images are pushed to the Zarf registry with a checksum of their original name in the tag.
+	nginx := push(fmt.Sprintf("%s/library/nginx:1.27-zarf-%d", zarfHost, helpers.GetCRCHash("docker.io/library/nginx")))
+	podinfo := push(fmt.Sprintf("%s/stefanprodan/podinfo:6.4.0-zarf-%d", zarfHost, helpers.GetCRCHash("ghcr.io/stefanprodan/podinfo")))
+	busybox := push(fmt.Sprintf("%s/dockerhub/library/busybox:1.36", mirrorHost))
+
+	stateLoads := atomic.Int32{}
+	rp := &RegistryProxy{
+		Mirrors:     []string{fmt.Sprintf("http://%s/dockerhub", mirrorHost)},
+		RegistryURL: zarfServer.URL,
+		Client:      &auth.Client{},
+		LoadState: func(context.Context) (*types.ZarfState, error) {
+			stateLoads.Add(1)
+			return &types.ZarfState{RegistryInfo: types.RegistryInfo{PullUsername: "zarf-pull", PullPassword: "password"}}, nil
+		},
+	}
+	proxyServer := httptest.NewServer(rp)
+	t.Cleanup(proxyServer.Close)
+	proxyHost := strings.TrimPrefix(proxyServer.URL, "http://")
+	pullAuth := remote.WithAuth(&authn.Basic{Username: "zarf-pull", Password: "password"})
+	get := func(method, path string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, proxyServer.URL+path, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth("zarf-pull", "password")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	// Clients must authenticate with the pull credentials of the Zarf registry.
+	nginxRef, err := name.ParseReference(fmt.Sprintf("%s/library/nginx:1.27", proxyHost))
+	require.NoError(t, err)
+	_, err = remote.Image(nginxRef)
+	require.ErrorContains(t, err, "UNAUTHORIZED")
+	_, err = remote.Image(nginxRef, remote.WithAuth(&authn.Basic{Username: "zarf-pull", Password: "wrong"}))
+	require.ErrorContains(t, err, "UNAUTHORIZED")
+
+	// Images are pulled from the Zarf registry and then from the mirrors.
+	for ref, expected := range map[string]v1.Image{"library/nginx:1.27": nginx, "library/busybox:1.36": busybox} {
+		parsed, err := name.ParseReference(fmt.Sprintf("%s/%s", proxyHost, ref))
+		require.NoError(t, err)
+		img, err := remote.Image(parsed, pullAuth)
+		require.NoError(t, err)
+		expectedDigest, err := expected.Digest()
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+		require.Equal(t, expectedDigest, digest)
+		layers, err := img.Layers()
+		require.NoError(t, err)
+		for _, layer := range layers {
+			_, err := layer.Compressed()
+			require.NoError(t, err)
+		}
+	}
+
+	// Container runtimes name the registry of the image with the ns query parameter.
+	resp := get(http.MethodHead, "/v2/stefanprodan/podinfo/manifests/6.4.0?ns=ghcr.io")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	podinfoDigest, err := podinfo.Digest()
+	require.NoError(t, err)
+	require.Equal(t, podinfoDigest.String(), resp.Header.Get("Docker-Content-Digest"))
+
+	resp = get(http.MethodGet, "/v2/library/redis/manifests/7.2")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Post(fmt.Sprintf("%s/v2/library/nginx/blobs/uploads/", proxyServer.URL), "application/octet-stream", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	// The state is reused across requests instead of being loaded for every blob.
+	require.Equal(t, int32(1), stateLoads.Load())
+}
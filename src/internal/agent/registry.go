@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package agent holds the mutating webhook server.
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// registryExporterInterval is how often the registry storage is walked, walking it on every scrape is too expensive for large registries.
+const registryExporterInterval = time.Minute
+
+// registryStorage is the storage used by a registry with the filesystem storage driver.
+type registryStorage struct {
+	Bytes int64
+	Blobs int
+}
+
+// readRegistryStorage walks the filesystem storage of a registry rooted at root.
+func readRegistryStorage(root string) (registryStorage, error) {
+	storage := registryStorage{}
+	blobsDir := filepath.Join(root, "docker", "registry", "v2", "blobs") + string(filepath.Separator)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		storage.Bytes += info.Size()
+		// Every blob is stored as a data file in a directory named after its digest.
+		if d.Name() == "data" && strings.HasPrefix(path, blobsDir) {
+			storage.Blobs++
+		}
+		return nil
+	})
+	if err != nil {
+		return registryStorage{}, err
+	}
+	return storage, nil
+}
+
+// StartRegistryExporter serves the storage usage of the registry storage at root as Prometheus metrics.
+func StartRegistryExporter(ctx context.Context, root string, port int) error {
+	l := logger.From(ctx)
+	storageBytes := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "zarf_registry_storage_bytes",
+		Help: "Bytes stored by the Zarf registry.",
+	})
+	blobs := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "zarf_registry_blobs",
+		Help: "Number of blobs stored by the Zarf registry.",
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(storageBytes, blobs)
+
+	update := func() {
+		storage, err := readRegistryStorage(root)
+		if err != nil {
+			l.Warn("unable to read the registry storage", "path", root, "error", err)
+			return
+		}
+		storageBytes.Set(float64(storage.Bytes))
+		blobs.Set(float64(storage.Blobs))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.Handle("/healthz", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		//nolint: errcheck // ignore
+		w.Write([]byte("ok"))
+	}))
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second, // Set ReadHeaderTimeout to avoid Slowloris attacks
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		update()
+		ticker := time.NewTicker(registryExporterInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-gCtx.Done():
+				return nil
+			case <-ticker.C:
+				update()
+			}
+		}
+	})
+	g.Go(func() error {
+		return runServer(gCtx, srv, srv.ListenAndServe)
+	})
+	l.Info("registry exporter running", "port", port, "path", root)
+	return g.Wait()
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRegistryStorage(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"docker/registry/v2/blobs/sha256/ab/abc/data":                                      "layer",
+		"docker/registry/v2/blobs/sha256/de/def/data":                                      "config",
+		"docker/registry/v2/repositories/library/nginx/_layers/sha256/abc/link":            "sha256:abc",
+		"docker/registry/v2/repositories/library/nginx/_manifests/tags/1.0.0/current/link": "sha256:def",
+	}
+	expectedBytes := int64(0)
+	for path, content := range files {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		expectedBytes += int64(len(content))
+	}
+
+	storage, err := readRegistryStorage(root)
+	require.NoError(t, err)
+	require.Equal(t, registryStorage{Bytes: expectedBytes, Blobs: 2}, storage)
+
+	_, err = readRegistryStorage(filepath.Join(root, "missing"))
+	require.Error(t, err)
+}
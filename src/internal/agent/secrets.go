@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// SecretSyncPolicy selects the namespaces the agent keeps the Zarf registry and git server secrets in.
+type SecretSyncPolicy string
+
+const (
+	// SecretSyncAll keeps the secrets in every namespace that does not opt out.
+	SecretSyncAll SecretSyncPolicy = "all"
+	// SecretSyncLabeled keeps the secrets in namespaces that opt in with the zarf.dev/secret-sync=true label.
+	SecretSyncLabeled SecretSyncPolicy = "labeled"
+	// SecretSyncNone does not keep the secrets in any namespace.
+	SecretSyncNone SecretSyncPolicy = "none"
+)
+
+// secretSyncResync is how often all namespaces are checked again, so failed and deleted secrets are eventually restored.
+const secretSyncResync = 10 * time.Minute
+
+// ParseSecretSyncPolicy returns the secret sync policy with the given name.
+func ParseSecretSyncPolicy(policy string) (SecretSyncPolicy, error) {
+	switch SecretSyncPolicy(policy) {
+	case SecretSyncAll, SecretSyncLabeled, SecretSyncNone:
+		return SecretSyncPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("invalid secret sync policy %q, valid options are %s, %s and %s", policy, SecretSyncAll, SecretSyncLabeled, SecretSyncNone)
+	}
+}
+
+// StartSecretSync watches namespaces and creates the Zarf registry and git server secrets in the namespaces selected by
+// the policy when they are missing. Namespaces labeled zarf.dev/agent=skip|ignore or zarf.dev/secret-sync=false are
+// never synced.
+func StartSecretSync(ctx context.Context, c *cluster.Cluster, policy SecretSyncPolicy) error {
+	if policy == SecretSyncNone {
+		return nil
+	}
+	l := logger.From(ctx)
+
+	handle := func(obj interface{}) {
+		namespace, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return
+		}
+		if err := syncSecrets(ctx, c, policy, namespace); err != nil {
+			l.Error("unable to sync Zarf secrets", "namespace", namespace.Name, "error", err.Error())
+		}
+	}
+	factory := informers.NewSharedInformerFactory(c.Clientset, secretSyncResync)
+	informer := factory.Core().V1().Namespaces().Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handle,
+		UpdateFunc: func(_, obj interface{}) {
+			handle(obj)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	l.Info("syncing Zarf secrets to namespaces", "policy", policy)
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
+
+// syncSecrets creates the Zarf secrets in the namespace when it is selected by the policy and a secret is missing.
+// Namespaces with an existing secret of the same name that is not managed by Zarf are skipped.
+func syncSecrets(ctx context.Context, c *cluster.Cluster, policy SecretSyncPolicy, namespace *corev1.Namespace) error {
+	if !shouldSyncSecrets(policy, namespace) {
+		return nil
+	}
+
+	missing := false
+	for _, name := range []string{config.ZarfImagePullSecretName, config.ZarfGitServerSecretName} {
+		secret, err := c.Clientset.CoreV1().Secrets(namespace.Name).Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			missing = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if secret.Labels[cluster.ZarfManagedByLabel] != "zarf" {
+			logger.From(ctx).Debug("skipping namespace with secret not managed by Zarf", "namespace", namespace.Name, "secret", name)
+			return nil
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	state, err := c.LoadAgentState(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.ApplyZarfManagedSecrets(ctx, namespace.Name, state); err != nil {
+		return err
+	}
+	logger.From(ctx).Info("created Zarf secrets in namespace", "namespace", namespace.Name)
+	return nil
+}
+
+// shouldSyncSecrets returns if the policy selects the namespace.
+func shouldSyncSecrets(policy SecretSyncPolicy, namespace *corev1.Namespace) bool {
+	if namespace.Name == cluster.ZarfNamespaceName || namespace.Name == metav1.NamespaceSystem {
+		return false
+	}
+	if namespace.Status.Phase == corev1.NamespaceTerminating {
+		return false
+	}
+	switch namespace.Labels[cluster.AgentLabel] {
+	case "skip", "ignore":
+		return false
+	}
+	switch policy {
+	case SecretSyncAll:
+		return namespace.Labels[cluster.SecretSyncLabel] != "false"
+	case SecretSyncLabeled:
+		return namespace.Labels[cluster.SecretSyncLabel] == "true"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestShouldSyncSecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		namespace corev1.Namespace
+		all       bool
+		labeled   bool
+	}{
+		{
+			name:      "unlabeled",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			all:       true,
+		},
+		{
+			name:      "opt in",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{cluster.SecretSyncLabel: "true"}}},
+			all:       true,
+			labeled:   true,
+		},
+		{
+			name:      "opt out",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{cluster.SecretSyncLabel: "false"}}},
+		},
+		{
+			name:      "agent ignore",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{cluster.AgentLabel: "ignore", cluster.SecretSyncLabel: "true"}}},
+		},
+		{
+			name:      "zarf namespace",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cluster.ZarfNamespaceName}},
+		},
+		{
+			name: "terminating",
+			namespace: corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.all, shouldSyncSecrets(SecretSyncAll, &tt.namespace))
+			require.Equal(t, tt.labeled, shouldSyncSecrets(SecretSyncLabeled, &tt.namespace))
+			require.False(t, shouldSyncSecrets(SecretSyncNone, &tt.namespace))
+		})
+	}
+}
+
+func TestSyncSecrets(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999", PullUsername: "pull", PullPassword: "password"},
+		GitServer:    types.GitServerInfo{Address: "http://gitea.example.com", PullUsername: "pull", PullPassword: "password"},
+	}
+	require.NoError(t, c.SaveZarfState(ctx, state))
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	err := syncSecrets(ctx, c, SecretSyncAll, namespace)
+	require.NoError(t, err)
+	for _, name := range []string{config.ZarfImagePullSecretName, config.ZarfGitServerSecretName} {
+		secret, err := c.Clientset.CoreV1().Secrets("app").Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "zarf", secret.Labels[cluster.ZarfManagedByLabel])
+	}
+
+	// Namespaces with secrets not managed by Zarf are skipped.
+	userSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.ZarfImagePullSecretName, Namespace: "user"}}
+	_, err = c.Clientset.CoreV1().Secrets("user").Create(ctx, userSecret, metav1.CreateOptions{})
+	require.NoError(t, err)
+	err = syncSecrets(ctx, c, SecretSyncAll, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "user"}})
+	require.NoError(t, err)
+	_, err = c.Clientset.CoreV1().Secrets("user").Get(ctx, config.ZarfGitServerSecretName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+
+	// Namespaces that are not selected are skipped.
+	err = syncSecrets(ctx, c, SecretSyncLabeled, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}})
+	require.NoError(t, err)
+	_, err = c.Clientset.CoreV1().Secrets("unlabeled").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestParseSecretSyncPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := ParseSecretSyncPolicy("labeled")
+	require.NoError(t, err)
+	require.Equal(t, SecretSyncLabeled, policy)
+
+	_, err = ParseSecretSyncPolicy("some")
+	require.EqualError(t, err, `invalid secret sync policy "some", valid options are all, labeled and none`)
+}
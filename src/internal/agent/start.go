@@ -8,11 +8,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 
 	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
 	agentHttp "github.com/zarf-dev/zarf/src/internal/agent/http"
@@ -26,16 +32,32 @@ import (
 
 // We can hard-code these because we control the entire thing anyway.
 const (
-	httpPort = "8443"
-	tlsCert  = "/etc/certs/tls.crt"
-	tlsKey   = "/etc/certs/tls.key"
+	httpPort          = "8443"
+	registryProxyPort = "8080"
+	// registryProxyServiceName is the node port service container runtimes reach the registry proxy at.
+	registryProxyServiceName = "zarf-registry-proxy"
+	tlsCert                  = "/etc/certs/tls.crt"
+	tlsKey                   = "/etc/certs/tls.key"
 )
 
-// StartWebhook launches the Zarf agent mutating webhook in the cluster.
-func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
+// StartWebhook launches the Zarf agent mutating webhook in the cluster along with the secret sync for the given policy.
+// When imageCheck is true the images pods are mutated to are checked to exist in the internal registry. When
+// registryProxy is true the agent also serves the Zarf registry as a pull-through mirror that falls back to the given
+// mirrors on the given node port.
+func StartWebhook(ctx context.Context, cluster *cluster.Cluster, secretSyncPolicy SecretSyncPolicy, imageCheck bool, registryProxy bool, registryProxyMirrors []string, registryProxyNodePort int) error {
+	// The node port of the registry proxy is only claimed while the registry proxy is enabled.
+	if err := applyRegistryProxyService(ctx, cluster, registryProxy, registryProxyNodePort); err != nil {
+		return err
+	}
+
+	var podImageCheck *hooks.ImageCheck
+	if imageCheck {
+		podImageCheck = hooks.NewImageCheck()
+	}
+
 	// Routers
 	admissionHandler := admission.NewHandler()
-	podsMutation := hooks.NewPodMutationHook(ctx, cluster)
+	podsMutation := hooks.NewPodMutationHook(ctx, cluster, podImageCheck)
 	fluxGitRepositoryMutation := hooks.NewGitRepositoryMutationHook(ctx, cluster)
 	argocdApplicationMutation := hooks.NewApplicationMutationHook(ctx, cluster)
 	argocdRepositoryMutation := hooks.NewRepositorySecretMutationHook(ctx, cluster)
@@ -51,7 +73,65 @@ func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
 	mux.Handle("/mutate/argocd-application", admissionHandler.Serve(ctx, argocdApplicationMutation))
 	mux.Handle("/mutate/argocd-repository", admissionHandler.Serve(ctx, argocdRepositoryMutation))
 
-	return startServer(ctx, httpPort, mux)
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return StartSecretSync(gCtx, cluster, secretSyncPolicy)
+	})
+	g.Go(func() error {
+		return startServer(gCtx, httpPort, mux)
+	})
+	if registryProxy {
+		g.Go(func() error {
+			return startRegistryProxy(gCtx, cluster, registryProxyMirrors)
+		})
+	}
+	return g.Wait()
+}
+
+// startRegistryProxy serves the registry proxy over plain HTTP, container runtimes reach it through a node port the
+// same way they reach the internal registry.
+func startRegistryProxy(ctx context.Context, cluster *cluster.Cluster, mirrors []string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/v2/", agentHttp.NewRegistryProxy(cluster, mirrors))
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%s", registryProxyPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second, // Set ReadHeaderTimeout to avoid Slowloris attacks
+		// Requests carry the logger of the agent.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	logger.From(ctx).Info("registry proxy running", "port", registryProxyPort, "mirrors", mirrors)
+	return runServer(ctx, srv, srv.ListenAndServe)
+}
+
+// applyRegistryProxyService creates the node port service of the registry proxy when it is enabled and removes it when
+// it is not.
+func applyRegistryProxyService(ctx context.Context, c *cluster.Cluster, enabled bool, nodePort int) error {
+	if !enabled {
+		err := c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Delete(ctx, registryProxyServiceName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to remove the registry proxy service: %w", err)
+		}
+		return nil
+	}
+	svc := v1ac.Service(registryProxyServiceName, cluster.ZarfNamespaceName).
+		WithLabels(cluster.CommonLabels(nil)).
+		WithAnnotations(cluster.CommonAnnotations(nil)).
+		WithSpec(v1ac.ServiceSpec().
+			WithType(corev1.ServiceTypeNodePort).
+			WithPorts(
+				v1ac.ServicePort().
+					WithPort(8080).
+					WithTargetPort(intstr.FromString("registry-proxy")).
+					WithNodePort(int32(nodePort)),
+			).WithSelector(map[string]string{
+			"app": "agent-hook",
+		}))
+	_, err := c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Apply(ctx, svc, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to create the registry proxy service: %w", err)
+	}
+	return nil
 }
 
 // StartHTTPProxy launches the zarf agent proxy in the cluster.
@@ -74,9 +154,17 @@ func startServer(ctx context.Context, port string, mux *http.ServeMux) error {
 		ReadHeaderTimeout: 5 * time.Second, // Set ReadHeaderTimeout to avoid Slowloris attacks
 	}
 
+	logger.From(ctx).Info("server running", "port", port)
+	return runServer(ctx, srv, func() error {
+		return srv.ListenAndServeTLS(tlsCert, tlsKey)
+	})
+}
+
+// runServer runs the server with listen until the context is done and then shuts it down.
+func runServer(ctx context.Context, srv *http.Server, listen func() error) error {
 	g, gCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
-		err := srv.ListenAndServeTLS(tlsCert, tlsKey)
+		err := listen()
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			return err
 		}
@@ -92,7 +180,6 @@ func startServer(ctx context.Context, port string, mux *http.ServeMux) error {
 		}
 		return nil
 	})
-	logger.From(ctx).Info("server running", "port", port)
 	err := g.Wait()
 	if err != nil {
 		return err
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+)
+
+func TestApplyRegistryProxyService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+
+	// The node port is not claimed while the registry proxy is disabled.
+	err := applyRegistryProxyService(ctx, c, false, 31998)
+	require.NoError(t, err)
+	_, err = c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Get(ctx, registryProxyServiceName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+
+	err = applyRegistryProxyService(ctx, c, true, 31998)
+	require.NoError(t, err)
+	svc, err := c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Get(ctx, registryProxyServiceName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(31998), svc.Spec.Ports[0].NodePort)
+
+	err = applyRegistryProxyService(ctx, c, false, 31998)
+	require.NoError(t, err)
+	_, err = c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Get(ctx, registryProxyServiceName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package bundle creates and deploys bundles of several Zarf packages.
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	goyaml "github.com/goccy/go-yaml"
+)
+
+const (
+	// ZarfBundleConfig is the kind of a bundle definition.
+	ZarfBundleConfig = "ZarfBundleConfig"
+	// BundleYAML is the name of the combined manifest within a bundle archive.
+	BundleYAML = "zarf-bundle.yaml"
+	// PackagesDir is the directory of the packages within a bundle archive.
+	PackagesDir = "packages"
+)
+
+// isLowercaseNumberHyphen matches the names allowed for bundles and the packages within them.
+var isLowercaseNumberHyphen = regexp.MustCompile(`^[a-z0-9][a-z0-9\-]*$`)
+
+// Bundle is a set of packages that are transferred and deployed together.
+type Bundle struct {
+	// The kind of the bundle, always ZarfBundleConfig.
+	Kind string `json:"kind"`
+	// Metadata of the bundle.
+	Metadata Metadata `json:"metadata"`
+	// The packages of the bundle in the order they are deployed.
+	Packages []Package `json:"packages"`
+}
+
+// Metadata describes a bundle.
+type Metadata struct {
+	// The name of the bundle.
+	Name string `json:"name"`
+	// A description of the bundle.
+	Description string `json:"description,omitempty"`
+	// The version of the bundle.
+	Version string `json:"version,omitempty"`
+}
+
+// Package is a package within a bundle.
+type Package struct {
+	// The name of the package within the bundle.
+	Name string `json:"name"`
+	// The path, URL or OCI reference of the package. Within a bundle archive it is the path of the package in the archive.
+	Source string `json:"source"`
+	// The SHA256 checksum of the package, set for every package within a bundle archive.
+	Shasum string `json:"shasum,omitempty"`
+	// The optional components of the package to deploy.
+	Components []string `json:"components,omitempty"`
+	// Variables of the package to set when it is deployed.
+	Set map[string]string `json:"set,omitempty"`
+}
+
+// Read reads and validates a bundle definition or combined manifest.
+func Read(path string) (Bundle, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Bundle{}, err
+	}
+	var bndl Bundle
+	if err := goyaml.Unmarshal(b, &bndl); err != nil {
+		return Bundle{}, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	if err := bndl.Validate(); err != nil {
+		return Bundle{}, fmt.Errorf("invalid bundle %s: %w", path, err)
+	}
+	return bndl, nil
+}
+
+// Validate checks that the bundle is well formed.
+func (b Bundle) Validate() error {
+	var errs []error
+	if b.Kind != ZarfBundleConfig {
+		errs = append(errs, fmt.Errorf("kind must be %s", ZarfBundleConfig))
+	}
+	if !isLowercaseNumberHyphen.MatchString(b.Metadata.Name) {
+		errs = append(errs, fmt.Errorf("metadata.name %q must be lowercase alphanumeric characters or hyphens", b.Metadata.Name))
+	}
+	if len(b.Packages) == 0 {
+		errs = append(errs, errors.New("at least one package is required"))
+	}
+	names := map[string]bool{}
+	for _, pkg := range b.Packages {
+		if !isLowercaseNumberHyphen.MatchString(pkg.Name) {
+			errs = append(errs, fmt.Errorf("package name %q must be lowercase alphanumeric characters or hyphens", pkg.Name))
+		}
+		if names[pkg.Name] {
+			errs = append(errs, fmt.Errorf("package name %q is used more than once", pkg.Name))
+		}
+		names[pkg.Name] = true
+		if pkg.Source == "" {
+			errs = append(errs, fmt.Errorf("package %q requires a source", pkg.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		bundle      Bundle
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			bundle: Bundle{
+				Kind:     ZarfBundleConfig,
+				Metadata: Metadata{Name: "platform"},
+				Packages: []Package{{Name: "init", Source: "zarf-init-amd64.tar.zst"}, {Name: "app", Source: "oci://ghcr.io/org/app:1.0.0"}},
+			},
+		},
+		{
+			name: "invalid",
+			bundle: Bundle{
+				Kind:     "ZarfPackageConfig",
+				Metadata: Metadata{Name: "Platform"},
+				Packages: []Package{{Name: "app", Source: "app.tar.zst"}, {Name: "app"}},
+			},
+			expectedErr: "kind must be ZarfBundleConfig\n" +
+				"metadata.name \"Platform\" must be lowercase alphanumeric characters or hyphens\n" +
+				"package name \"app\" is used more than once\n" +
+				"package \"app\" requires a source",
+		},
+		{
+			name:        "no packages",
+			bundle:      Bundle{Kind: ZarfBundleConfig, Metadata: Metadata{Name: "platform"}},
+			expectedErr: "at least one package is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.bundle.Validate()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPackageVariables(t *testing.T) {
+	t.Parallel()
+
+	pkgSet := map[string]string{"domain": "app.example.com", "REPLICAS": "2"}
+	set := map[string]string{"REPLICAS": "3"}
+	expected := map[string]string{"DOMAIN": "app.example.com", "REPLICAS": "3"}
+	require.Equal(t, expected, packageVariables(pkgSet, set))
+}
+
+func TestSharedVariables(t *testing.T) {
+	t.Parallel()
+
+	declared := []v1alpha1.InteractiveVariable{
+		{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Default: "example.com"},
+		{Variable: v1alpha1.Variable{Name: "REGION"}, Default: "east"},
+		{Variable: v1alpha1.Variable{Name: "REPLICAS"}, Default: "1"},
+	}
+	set := map[string]string{"REGION": "east"}
+	values := map[string]string{"DOMAIN": "example.com", "REGION": "east", "REPLICAS": "3", "CLUSTER_ID": "abc"}
+	expected := map[string]string{"REGION": "east", "REPLICAS": "3", "CLUSTER_ID": "abc"}
+	require.Equal(t, expected, sharedVariables(declared, set, values))
+}
+
+func TestCreate(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../zarf.schema.json")
+
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "app")
+	require.NoError(t, os.MkdirAll(pkgDir, 0o700))
+	zarfYAML := `kind: ZarfPackageConfig
+metadata:
+  name: app
+  version: 1.0.0
+  architecture: amd64
+components:
+  - name: config
+    required: true
+    files:
+      - source: config.txt
+        target: /tmp/config.txt
+`
+	require.NoError(t, os.WriteFile(filepath.Join(pkgDir, "zarf.yaml"), []byte(zarfYAML), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(pkgDir, "config.txt"), []byte("config"), 0o600))
+	err := packager2.Create(ctx, pkgDir, packager2.CreateOptions{Output: dir, SkipSBOM: true})
+	require.NoError(t, err)
+
+	bundleYAML := `kind: ZarfBundleConfig
+metadata:
+  name: platform
+  version: 0.1.0
+packages:
+  - name: app
+    source: zarf-package-app-amd64-1.0.0.tar.zst
+    set:
+      DOMAIN: example.com
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.yaml"), []byte(bundleYAML), 0o600))
+	output := filepath.Join(dir, "out")
+	dst, err := Create(ctx, filepath.Join(dir, "bundle.yaml"), CreateOptions{Output: output})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(output, "zarf-bundle-platform-0.1.0.tar"), dst)
+
+	extracted := t.TempDir()
+	require.NoError(t, archiver.Unarchive(dst, extracted))
+	bndl, err := Read(filepath.Join(extracted, BundleYAML))
+	require.NoError(t, err)
+	require.Len(t, bndl.Packages, 1)
+	require.Equal(t, "packages/app/zarf-package-app-amd64-1.0.0.tar.zst", bndl.Packages[0].Source)
+	require.Equal(t, map[string]string{"DOMAIN": "example.com"}, bndl.Packages[0].Set)
+	shasum, err := helpers.GetSHA256OfFile(filepath.Join(dir, "zarf-package-app-amd64-1.0.0.tar.zst"))
+	require.NoError(t, err)
+	require.Equal(t, shasum, bndl.Packages[0].Shasum)
+	require.NoError(t, helpers.SHAsMatch(filepath.Join(extracted, bndl.Packages[0].Source), shasum))
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	goyaml "github.com/goccy/go-yaml"
+	"github.com/mholt/archiver/v3"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// CreateOptions are the options for Create.
+type CreateOptions struct {
+	// Output is the directory the bundle archive is written to.
+	Output string
+}
+
+// Create collects the packages of a bundle definition into a bundle archive with a combined manifest and returns the
+// path of the archive. Local package paths are relative to the bundle definition.
+func Create(ctx context.Context, path string, opt CreateOptions) (string, error) {
+	l := logger.From(ctx)
+	bndl, err := Read(path)
+	if err != nil {
+		return "", err
+	}
+	baseDir := filepath.Dir(path)
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for i, pkg := range bndl.Packages {
+		message.Notef("Adding package %s to the bundle from %s", pkg.Name, pkg.Source)
+		l.Info("adding package to the bundle", "name", pkg.Name, "source", pkg.Source)
+		dir := filepath.Join(tmpDir, PackagesDir, pkg.Name)
+		if err := helpers.CreateDirectory(dir, helpers.ReadExecuteAllWriteUser); err != nil {
+			return "", err
+		}
+		pkgPath, err := collectPackage(ctx, baseDir, dir, pkg)
+		if err != nil {
+			return "", fmt.Errorf("unable to add package %s to the bundle: %w", pkg.Name, err)
+		}
+		shasum, err := helpers.GetSHA256OfFile(pkgPath)
+		if err != nil {
+			return "", err
+		}
+		relPath, err := filepath.Rel(tmpDir, pkgPath)
+		if err != nil {
+			return "", err
+		}
+		bndl.Packages[i].Source = filepath.ToSlash(relPath)
+		bndl.Packages[i].Shasum = shasum
+	}
+
+	b, err := goyaml.Marshal(bndl)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, BundleYAML), b, helpers.ReadWriteUser); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("zarf-bundle-%s", bndl.Metadata.Name)
+	if bndl.Metadata.Version != "" {
+		name = fmt.Sprintf("%s-%s", name, bndl.Metadata.Version)
+	}
+	// Packages are already compressed, so the bundle is a plain tarball.
+	dst := filepath.Join(opt.Output, name+".tar")
+	if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if err := helpers.CreateDirectory(opt.Output, helpers.ReadExecuteAllWriteUser); err != nil {
+		return "", err
+	}
+	if err := archiver.Archive([]string{filepath.Join(tmpDir, BundleYAML), filepath.Join(tmpDir, PackagesDir)}, dst); err != nil {
+		return "", fmt.Errorf("unable to write the bundle archive: %w", err)
+	}
+	return dst, nil
+}
+
+// collectPackage pulls or copies the package into the directory and returns the path of the package tarball.
+func collectPackage(ctx context.Context, baseDir, dir string, pkg Package) (string, error) {
+	if helpers.IsURL(pkg.Source) {
+		// Signatures are validated when the bundle is deployed, the packages are added to the bundle as they are.
+		err := packager2.Pull(ctx, pkg.Source, dir, pkg.Shasum, filters.Empty(), "", utils.CertificateIdentity{}, true, types.VerificationPolicy{})
+		if err != nil {
+			return "", err
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return "", err
+		}
+		if len(entries) != 1 {
+			return "", fmt.Errorf("expected a single package to be pulled from %s", pkg.Source)
+		}
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+
+	src := pkg.Source
+	if !filepath.IsAbs(src) {
+		src = filepath.Join(baseDir, src)
+	}
+	fi, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("%s is a directory, packages must be created before they are bundled", pkg.Source)
+	}
+	if pkg.Shasum != "" {
+		if err := helpers.SHAsMatch(src, pkg.Shasum); err != nil {
+			return "", err
+		}
+	}
+	dst := filepath.Join(dir, filepath.Base(src))
+	if err := helpers.CreatePathAndCopy(src, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
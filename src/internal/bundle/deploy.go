@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// DeployOptions are the options for Deploy.
+type DeployOptions struct {
+	// SetVariables are set for every package of the bundle and take precedence over the variables set in the bundle.
+	SetVariables            map[string]string
+	PublicKeyPath           string
+	SkipSignatureValidation bool
+	VerificationPolicy      types.VerificationPolicy
+}
+
+// Deploy deploys the packages of a bundle archive, or of a bundle definition, in order. Non-sensitive variables set
+// explicitly, or by actions, while deploying a package are passed on to the packages deployed after it that declare them.
+func Deploy(ctx context.Context, source string, opt DeployOptions) error {
+	l := logger.From(ctx)
+	path := source
+	baseDir := filepath.Dir(source)
+	if !isBundleDefinition(source) {
+		tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+		if err := archiver.Unarchive(source, tmpDir); err != nil {
+			return fmt.Errorf("unable to extract the bundle %s: %w", source, err)
+		}
+		path = filepath.Join(tmpDir, BundleYAML)
+		baseDir = tmpDir
+	}
+	bndl, err := Read(path)
+	if err != nil {
+		return err
+	}
+
+	shared := map[string]string{}
+	for _, pkg := range bndl.Packages {
+		message.HeaderInfof("📦 BUNDLE PACKAGE %s", pkg.Name)
+		l.Info("deploying bundle package", "bundle", bndl.Metadata.Name, "name", pkg.Name)
+		pkgSource := pkg.Source
+		if !helpers.IsURL(pkgSource) && !filepath.IsAbs(pkgSource) {
+			pkgSource = filepath.Join(baseDir, pkgSource)
+		}
+		pkgConfig := types.PackagerConfig{
+			PkgOpts: types.ZarfPackageOptions{
+				PackageSource:           pkgSource,
+				Shasum:                  pkg.Shasum,
+				OptionalComponents:      strings.Join(pkg.Components, ","),
+				SetVariables:            packageVariables(pkg.Set, opt.SetVariables),
+				SharedVariables:         maps.Clone(shared),
+				Retries:                 config.ZarfDefaultRetries,
+				PublicKeyPath:           opt.PublicKeyPath,
+				SkipSignatureValidation: opt.SkipSignatureValidation,
+				VerificationPolicy:      opt.VerificationPolicy,
+			},
+			DeployOpts: types.ZarfDeployOptions{
+				Timeout: config.ZarfDefaultTimeout,
+			},
+		}
+		pkgClient, err := packager.New(&pkgConfig, packager.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		err = pkgClient.Deploy(ctx)
+		pkgClient.ClearTempPaths()
+		if err != nil {
+			return fmt.Errorf("failed to deploy package %s of the bundle: %w", pkg.Name, err)
+		}
+		maps.Copy(shared, sharedVariables(pkgConfig.Pkg.Variables, pkgConfig.PkgOpts.SetVariables, pkgClient.GetVariableConfig().GetNonSensitiveSetVariables()))
+	}
+	return nil
+}
+
+// packageVariables returns the variables set for a package of a bundle. Variables set for every package take
+// precedence over the variables set for the package in the bundle.
+func packageVariables(pkgSet, set map[string]string) map[string]string {
+	variables := map[string]string{}
+	for _, m := range []map[string]string{pkgSet, set} {
+		for name, value := range m {
+			variables[strings.ToUpper(name)] = value
+		}
+	}
+	return variables
+}
+
+// sharedVariables returns the variables of a deployed package to pass on to the packages deployed after it. Only
+// variables that were set explicitly, by a prompt or by actions are passed on, variables left at the default the
+// package declares for them stay with the package.
+func sharedVariables(declared []v1alpha1.InteractiveVariable, set, values map[string]string) map[string]string {
+	shared := map[string]string{}
+	for name, value := range values {
+		if _, ok := set[name]; ok {
+			shared[name] = value
+			continue
+		}
+		idx := slices.IndexFunc(declared, func(v v1alpha1.InteractiveVariable) bool { return v.Name == name })
+		if idx == -1 || declared[idx].Default != value {
+			shared[name] = value
+		}
+	}
+	return shared
+}
+
+// isBundleDefinition returns true if the source is a bundle definition instead of a bundle archive.
+func isBundleDefinition(source string) bool {
+	ext := filepath.Ext(source)
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package devcluster creates and destroys local Kubernetes clusters for testing packages.
+package devcluster
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	zarfexec "github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// Provider is what runs the cluster.
+type Provider string
+
+const (
+	// ProviderAuto uses the first provider found on the PATH.
+	ProviderAuto Provider = "auto"
+	// ProviderK3d runs the cluster with k3d.
+	ProviderK3d Provider = "k3d"
+	// ProviderKind runs the cluster with kind.
+	ProviderKind Provider = "kind"
+)
+
+// providers are the providers in the order they are detected.
+var providers = []Provider{ProviderK3d, ProviderKind}
+
+// DefaultName is the name of the cluster when none is given.
+const DefaultName = "zarf-dev"
+
+// Options are the options for Create and Destroy.
+type Options struct {
+	// Name of the cluster.
+	Name string
+	// Provider that runs the cluster.
+	Provider Provider
+	// Image of the cluster nodes, the provider default is used when empty.
+	Image string
+}
+
+// ParseProvider returns the provider with the given name.
+func ParseProvider(provider string) (Provider, error) {
+	switch p := Provider(provider); p {
+	case ProviderAuto, ProviderK3d, ProviderKind:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid cluster provider %q, valid options are %s, %s and %s", provider, ProviderAuto, ProviderK3d, ProviderKind)
+	}
+}
+
+// DetectProvider returns the first provider found with lookPath.
+func DetectProvider(lookPath func(string) (string, error)) (Provider, error) {
+	for _, provider := range providers {
+		if _, err := lookPath(string(provider)); err == nil {
+			return provider, nil
+		}
+	}
+	return "", fmt.Errorf("neither %s nor %s was found on the PATH, install one of them to create a cluster", ProviderK3d, ProviderKind)
+}
+
+// Create creates the cluster and points the current kubeconfig context at it.
+func Create(ctx context.Context, opt Options) error {
+	opt, err := resolve(opt)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("creating cluster", "name", opt.Name, "provider", opt.Provider)
+	_, stderr, err := zarfexec.CmdWithContext(ctx, zarfexec.Config{}, string(opt.Provider), createArgs(opt)...)
+	if err != nil {
+		return fmt.Errorf("unable to create the cluster with %s: %s: %w", opt.Provider, stderr, err)
+	}
+	return nil
+}
+
+// Destroy deletes the cluster.
+func Destroy(ctx context.Context, opt Options) error {
+	opt, err := resolve(opt)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("destroying cluster", "name", opt.Name, "provider", opt.Provider)
+	_, stderr, err := zarfexec.CmdWithContext(ctx, zarfexec.Config{}, string(opt.Provider), destroyArgs(opt)...)
+	if err != nil {
+		return fmt.Errorf("unable to destroy the cluster with %s: %s: %w", opt.Provider, stderr, err)
+	}
+	return nil
+}
+
+// resolve sets the defaults of the options.
+func resolve(opt Options) (Options, error) {
+	if opt.Name == "" {
+		opt.Name = DefaultName
+	}
+	if opt.Provider == ProviderAuto || opt.Provider == "" {
+		provider, err := DetectProvider(exec.LookPath)
+		if err != nil {
+			return Options{}, err
+		}
+		opt.Provider = provider
+	}
+	return opt, nil
+}
+
+// createArgs returns the arguments to create the cluster with the provider.
+func createArgs(opt Options) []string {
+	switch opt.Provider {
+	case ProviderKind:
+		args := []string{"create", "cluster", "--name", opt.Name, "--wait", "5m"}
+		if opt.Image != "" {
+			args = append(args, "--image", opt.Image)
+		}
+		return args
+	default:
+		args := []string{"cluster", "create", opt.Name, "--wait", "--timeout", "5m"}
+		if opt.Image != "" {
+			args = append(args, "--image", opt.Image)
+		}
+		return args
+	}
+}
+
+// destroyArgs returns the arguments to delete the cluster with the provider.
+func destroyArgs(opt Options) []string {
+	switch opt.Provider {
+	case ProviderKind:
+		return []string{"delete", "cluster", "--name", opt.Name}
+	default:
+		return []string{"cluster", "delete", opt.Name}
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package devcluster
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectProvider(t *testing.T) {
+	t.Parallel()
+
+	lookPath := func(available ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, a := range available {
+				if a == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		}
+	}
+	provider, err := DetectProvider(lookPath("kind", "k3d"))
+	require.NoError(t, err)
+	require.Equal(t, ProviderK3d, provider)
+	provider, err = DetectProvider(lookPath("kind"))
+	require.NoError(t, err)
+	require.Equal(t, ProviderKind, provider)
+	_, err = DetectProvider(lookPath())
+	require.EqualError(t, err, "neither k3d nor kind was found on the PATH, install one of them to create a cluster")
+}
+
+func TestParseProvider(t *testing.T) {
+	t.Parallel()
+
+	provider, err := ParseProvider("kind")
+	require.NoError(t, err)
+	require.Equal(t, ProviderKind, provider)
+
+	_, err = ParseProvider("minikube")
+	require.EqualError(t, err, `invalid cluster provider "minikube", valid options are auto, k3d and kind`)
+}
+
+func TestArgs(t *testing.T) {
+	t.Parallel()
+
+	k3d := Options{Name: "test", Provider: ProviderK3d}
+	require.Equal(t, []string{"cluster", "create", "test", "--wait", "--timeout", "5m"}, createArgs(k3d))
+	require.Equal(t, []string{"cluster", "delete", "test"}, destroyArgs(k3d))
+
+	kind := Options{Name: "test", Provider: ProviderKind, Image: "kindest/node:v1.31.0"}
+	require.Equal(t, []string{"create", "cluster", "--name", "test", "--wait", "5m", "--image", "kindest/node:v1.31.0"}, createArgs(kind))
+	require.Equal(t, []string{"delete", "cluster", "--name", "test"}, destroyArgs(kind))
+}
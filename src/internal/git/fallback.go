@@ -6,7 +6,11 @@ package git
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing"
 
@@ -14,21 +18,28 @@ import (
 )
 
 // gitCloneFallback is a fallback if go-git fails to clone a repo.
-func (r *Repository) gitCloneFallback(ctx context.Context, gitURL string, ref plumbing.ReferenceName, shallow bool) error {
+func (r *Repository) gitCloneFallback(ctx context.Context, gitURL string, ref plumbing.ReferenceName, opts CloneOptions, includeTags bool) error {
 	// If we can't clone with go-git, fallback to the host clone
 	// Only support "all tags" due to the azure clone url format including a username
 	cloneArgs := []string{"clone", "--origin", onlineRemoteName, gitURL, r.path}
 
 	// Don't clone all tags / refs if we're cloning a specific tag or branch.
 	if ref.IsTag() || ref.IsBranch() {
-		cloneArgs = append(cloneArgs, "--no-tags")
 		cloneArgs = append(cloneArgs, "-b", ref.Short())
 		cloneArgs = append(cloneArgs, "--single-branch")
+	} else if opts.SingleBranch {
+		cloneArgs = append(cloneArgs, "--single-branch")
+	}
+	if !includeTags {
+		cloneArgs = append(cloneArgs, "--no-tags")
 	}
 
-	// If this is a shallow clone set the depth to 1
-	if shallow {
-		cloneArgs = append(cloneArgs, "--depth", "1")
+	// If this is a shallow clone limit the depth
+	if opts.Depth > 0 {
+		cloneArgs = append(cloneArgs, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if len(opts.SparsePaths) > 0 {
+		cloneArgs = append(cloneArgs, "--no-checkout")
 	}
 
 	cloneExecConfig := exec.Config{
@@ -40,15 +51,37 @@ func (r *Repository) gitCloneFallback(ctx context.Context, gitURL string, ref pl
 		return err
 	}
 
+	repoExecConfig := exec.Config{
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+		Dir:    r.path,
+	}
+
 	// If we're cloning the whole repo, we need to also fetch the other branches besides the default.
-	if ref == emptyRef {
-		fetchArgs := []string{"fetch", "--tags", "--update-head-ok", onlineRemoteName, "refs/*:refs/*"}
-		fetchExecConfig := exec.Config{
-			Stdout: io.Discard,
-			Stderr: io.Discard,
-			Dir:    r.path,
+	if ref == emptyRef && !opts.SingleBranch {
+		fetchArgs := []string{"fetch", "--update-head-ok"}
+		if opts.Depth > 0 {
+			fetchArgs = append(fetchArgs, "--depth", strconv.Itoa(opts.Depth))
+		}
+		if includeTags {
+			fetchArgs = append(fetchArgs, "--tags", onlineRemoteName, "refs/*:refs/*")
+		} else {
+			fetchArgs = append(fetchArgs, "--no-tags", onlineRemoteName, "refs/heads/*:refs/heads/*")
+		}
+		_, _, err := exec.CmdWithContext(ctx, repoExecConfig, "git", fetchArgs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Refs that are not branches are checked out sparsely with go-git.
+	if len(opts.SparsePaths) > 0 && !(ref != emptyRef && !ref.IsBranch()) {
+		sparseArgs := append([]string{"sparse-checkout", "set"}, opts.SparsePaths...)
+		_, _, err := exec.CmdWithContext(ctx, repoExecConfig, "git", sparseArgs...)
+		if err != nil {
+			return err
 		}
-		_, _, err := exec.CmdWithContext(ctx, fetchExecConfig, "git", fetchArgs...)
+		_, _, err = exec.CmdWithContext(ctx, repoExecConfig, "git", "checkout")
 		if err != nil {
 			return err
 		}
@@ -56,3 +89,23 @@ func (r *Repository) gitCloneFallback(ctx context.Context, gitURL string, ref pl
 
 	return nil
 }
+
+// gitPushFallback pushes the heads and tags of a shallow repo to the offline remote with the host git. go-git does not
+// send the shallow commits of the repo, which the git server needs to accept the push.
+func (r *Repository) gitPushFallback(ctx context.Context, username, password string) error {
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	pushExecConfig := exec.Config{
+		Dir: r.path,
+		// Pass the credentials through the environment so they are not part of the command.
+		Env: []string{
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic " + auth,
+		},
+	}
+	_, stderr, err := exec.CmdWithContext(ctx, pushExecConfig, "git", "push", offlineRemoteName, "refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*")
+	if err != nil {
+		return fmt.Errorf("unable to push the shallow repo to the gitops service, the git server must allow shallow updates: %s", strings.TrimSpace(stderr))
+	}
+	return nil
+}
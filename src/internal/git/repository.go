@@ -18,6 +18,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
@@ -50,8 +51,39 @@ func Open(rootPath, address string) (*Repository, error) {
 	}, nil
 }
 
+// CloneOptions limit how much of a git repository is cloned.
+type CloneOptions struct {
+	// Depth is the number of commits from the tip of each ref to clone, zero clones the full history.
+	Depth int
+	// SingleBranch only clones the default branch when the address does not have a ref.
+	SingleBranch bool
+	// IncludeTags clones the tags of the repository, when nil tags are only cloned for addresses without a ref.
+	IncludeTags *bool
+	// SparsePaths are the only directories checked out, all directories are checked out when empty.
+	SparsePaths []string
+}
+
+// NewCloneOptions returns the clone options of the repo options of a component.
+func NewCloneOptions(opts v1alpha1.ZarfRepoOptions) CloneOptions {
+	return CloneOptions{
+		Depth:        opts.Depth,
+		SingleBranch: opts.SingleBranch,
+		IncludeTags:  opts.IncludeTags,
+		SparsePaths:  opts.SparsePaths,
+	}
+}
+
 // Clone clones a git repository to the given local path.
 func Clone(ctx context.Context, rootPath, address string, shallow bool) (*Repository, error) {
+	opts := CloneOptions{}
+	if shallow {
+		opts.Depth = 1
+	}
+	return CloneWithOptions(ctx, rootPath, address, opts)
+}
+
+// CloneWithOptions clones a git repository to the given local path, limited by the clone options.
+func CloneWithOptions(ctx context.Context, rootPath, address string, opts CloneOptions) (*Repository, error) {
 	l := logger.From(ctx)
 	// Split the remote url and the zarf reference
 	gitURLNoRef, refPlain, err := transform.GitURLSplitRef(address)
@@ -75,19 +107,31 @@ func Clone(ctx context.Context, rootPath, address string, shallow bool) (*Reposi
 		path: filepath.Join(rootPath, repoFolder),
 	}
 
+	// By default all tags are cloned for the whole repo and no tags are cloned for a branch or tag.
+	var tags git.TagMode
+	switch {
+	case opts.IncludeTags != nil && *opts.IncludeTags:
+		tags = git.AllTags
+	case opts.IncludeTags != nil, ref.IsTag(), ref.IsBranch():
+		tags = git.NoTags
+	case ref == emptyRef:
+		tags = git.AllTags
+	}
+	includeTags := tags != git.NoTags
+
 	// Clone the repository
 	cloneOpts := &git.CloneOptions{
-		URL:        gitURLNoRef,
-		RemoteName: onlineRemoteName,
+		URL:          gitURLNoRef,
+		RemoteName:   onlineRemoteName,
+		Depth:        opts.Depth,
+		SingleBranch: opts.SingleBranch,
+		Tags:         tags,
+		NoCheckout:   len(opts.SparsePaths) > 0,
 	}
 	if ref.IsTag() || ref.IsBranch() {
-		cloneOpts.Tags = git.NoTags
 		cloneOpts.ReferenceName = ref
 		cloneOpts.SingleBranch = true
 	}
-	if shallow {
-		cloneOpts.Depth = 1
-	}
 	gitCred, err := utils.FindAuthForHost(gitURLNoRef)
 	if err != nil {
 		return nil, err
@@ -95,22 +139,30 @@ func Clone(ctx context.Context, rootPath, address string, shallow bool) (*Reposi
 	if gitCred != nil {
 		cloneOpts.Auth = &gitCred.Auth
 	}
+	// The host git fallback fetches the other branches and checks out the sparse paths itself.
+	fellBack := false
 	repo, err := git.PlainCloneContext(ctx, r.path, false, cloneOpts)
 	if err != nil {
 		message.Notef("Falling back to host 'git', failed to clone the repo %q with Zarf: %s", gitURLNoRef, err.Error())
 		l.Info("falling back to host 'git', failed to clone the repo with Zarf", "url", gitURLNoRef, "error", err)
-		err := r.gitCloneFallback(ctx, gitURLNoRef, ref, shallow)
+		err := r.gitCloneFallback(ctx, gitURLNoRef, ref, opts, includeTags)
 		if err != nil {
 			return nil, err
 		}
+		fellBack = true
 	}
 
 	// If we're cloning the whole repo, we need to also fetch the other branches besides the default.
-	if ref == emptyRef {
+	if ref == emptyRef && !opts.SingleBranch && !fellBack {
+		refSpecs := []config.RefSpec{"refs/heads/*:refs/heads/*"}
+		if includeTags {
+			refSpecs = []config.RefSpec{"refs/*:refs/*"}
+		}
 		fetchOpts := &git.FetchOptions{
 			RemoteName: onlineRemoteName,
-			RefSpecs:   []config.RefSpec{"refs/*:refs/*"},
-			Tags:       git.AllTags,
+			RefSpecs:   refSpecs,
+			Depth:      opts.Depth,
+			Tags:       tags,
 		}
 		if gitCred != nil {
 			fetchOpts.Auth = &gitCred.Auth
@@ -128,7 +180,12 @@ func Clone(ctx context.Context, rootPath, address string, shallow bool) (*Reposi
 		alias := fmt.Sprintf("zarf-ref-%s", stripped)
 		trunkBranchName := plumbing.NewBranchReferenceName(alias)
 		// Checkout the ref as a branch.
-		err := r.checkoutRefAsBranch(stripped, trunkBranchName)
+		err := r.checkoutRefAsBranch(stripped, trunkBranchName, opts.SparsePaths)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(opts.SparsePaths) > 0 && !fellBack {
+		err := r.checkoutSparse(repo, opts.SparsePaths)
 		if err != nil {
 			return nil, err
 		}
@@ -180,6 +237,17 @@ func (r *Repository) Push(ctx context.Context, address, username, password strin
 		return fmt.Errorf("failed to create offline remote: %w", err)
 	}
 
+	// go-git cannot push shallow clones, they are pushed with the host git instead.
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return err
+	}
+	if len(shallow) > 0 {
+		message.Debugf("Pushing shallow repo with host 'git'")
+		l.Debug("pushing shallow repo with host 'git'")
+		return r.gitPushFallback(ctx, username, password)
+	}
+
 	// Push to new remote
 	gitCred := http.BasicAuth{
 		Username: username,
@@ -233,7 +301,7 @@ func (r *Repository) Push(ctx context.Context, address, username, password strin
 
 	return nil
 }
-func (r *Repository) checkoutRefAsBranch(ref string, branch plumbing.ReferenceName) error {
+func (r *Repository) checkoutRefAsBranch(ref string, branch plumbing.ReferenceName, sparsePaths []string) error {
 	repo, err := git.PlainOpen(r.path)
 	if err != nil {
 		return fmt.Errorf("not a valid git repo or unable to open: %w", err)
@@ -266,10 +334,11 @@ func (r *Repository) checkoutRefAsBranch(ref string, branch plumbing.ReferenceNa
 	}
 
 	checkoutOpts := &git.CheckoutOptions{
-		Hash:   commitHash,
-		Branch: branch,
-		Create: true,
-		Force:  true,
+		Hash:                      commitHash,
+		Branch:                    branch,
+		Create:                    true,
+		Force:                     true,
+		SparseCheckoutDirectories: sparsePaths,
 	}
 	tree, err := repo.Worktree()
 	if err != nil {
@@ -277,3 +346,20 @@ func (r *Repository) checkoutRefAsBranch(ref string, branch plumbing.ReferenceNa
 	}
 	return tree.Checkout(checkoutOpts)
 }
+
+// checkoutSparse checks out the given directories of the current branch of the repository.
+func (r *Repository) checkoutSparse(repo *git.Repository, sparsePaths []string) error {
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("unable to get the head of the git repo: %w", err)
+	}
+	tree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("unable to load the git repo: %w", err)
+	}
+	return tree.Checkout(&git.CheckoutOptions{
+		Branch:                    head.Name(),
+		Force:                     true,
+		SparseCheckoutDirectories: sparsePaths,
+	})
+}
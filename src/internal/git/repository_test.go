@@ -14,12 +14,15 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/require"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
 	"github.com/zarf-dev/zarf/src/test/testutil"
 )
 
@@ -84,3 +87,84 @@ func TestRepository(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, filepath.Join(rootPath, expectedPath), repo.Path())
 }
+
+func TestCloneWithOptions(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	cfg := gitkit.Config{
+		Dir:        t.TempDir(),
+		AutoCreate: true,
+	}
+	gitSrv := gitkit.New(cfg)
+	err := gitSrv.Setup()
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(gitSrv.ServeHTTP))
+	t.Cleanup(func() {
+		srv.Close()
+	})
+	repoAddress := fmt.Sprintf("%s/%s.git", srv.URL, "test")
+
+	// Create a repo with a commit for each directory and a tag on the first commit.
+	fs := memfs.New()
+	initRepo, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := initRepo.Worktree()
+	require.NoError(t, err)
+	for i, dir := range []string{"a", "b"} {
+		f, err := fs.Create(filepath.Join(dir, "test.txt"))
+		require.NoError(t, err)
+		_, err = f.Write([]byte(dir))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		_, err = w.Add(filepath.Join(dir, "test.txt"))
+		require.NoError(t, err)
+		hash, err := w.Commit(dir, &git.CommitOptions{Author: &object.Signature{Email: "example@example.com"}})
+		require.NoError(t, err)
+		if i == 0 {
+			_, err = initRepo.CreateTag("v1", hash, nil)
+			require.NoError(t, err)
+		}
+	}
+	_, err = initRepo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{repoAddress}})
+	require.NoError(t, err)
+	err = initRepo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	require.NoError(t, err)
+
+	includeTags := false
+	repo, err := CloneWithOptions(ctx, t.TempDir(), repoAddress, CloneOptions{
+		Depth:        1,
+		SingleBranch: true,
+		IncludeTags:  &includeTags,
+		SparsePaths:  []string{"b"},
+	})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(repo.Path(), "b", "test.txt"))
+	require.NoFileExists(t, filepath.Join(repo.Path(), "a", "test.txt"))
+	cloned, err := git.PlainOpen(repo.Path())
+	require.NoError(t, err)
+	shallow, err := cloned.Storer.Shallow()
+	require.NoError(t, err)
+	require.Len(t, shallow, 1)
+	tags, err := cloned.Tags()
+	require.NoError(t, err)
+	require.NoError(t, tags.ForEach(func(ref *plumbing.Reference) error {
+		return fmt.Errorf("unexpected tag %s", ref.Name())
+	}))
+
+	// Shallow clones can only be pushed to git servers that allow shallow updates.
+	repoName, err := transform.GitURLtoRepoName(repoAddress)
+	require.NoError(t, err)
+	bareDir := filepath.Join(cfg.Dir, "zarf", repoName+".git")
+	_, _, err = exec.Cmd("git", "init", "--bare", bareDir)
+	require.NoError(t, err)
+	err = repo.Push(ctx, srv.URL, "zarf", "password")
+	require.ErrorContains(t, err, "the git server must allow shallow updates")
+	_, _, err = exec.Cmd("git", "-C", bareDir, "config", "receive.shallowUpdate", "true")
+	require.NoError(t, err)
+	err = repo.Push(ctx, srv.URL, "zarf", "password")
+	require.NoError(t, err)
+}
@@ -16,7 +16,10 @@ import (
 	"time"
 )
 
-const artifactTokenName = "zarf-artifact-registry-token"
+const (
+	artifactTokenName   = "zarf-artifact-registry-token"
+	scopedPullTokenName = "zarf-scoped-pull-token"
+)
 
 // Client is a client that communicates with the Gitea API.
 type Client struct {
@@ -82,11 +85,37 @@ func (g *Client) DoRequest(ctx context.Context, method string, path string, body
 
 // CreateReadOnlyUser creates a non-admin Zarf user.
 func (g *Client) CreateReadOnlyUser(ctx context.Context, username, password string) error {
+	return g.createReadOnlyUser(ctx, username, password, "zarf-reader@localhost.local")
+}
+
+// CreateScopedUser creates a non-admin user for the pull credentials of a single package, or resets the password of
+// the user if it already exists.
+func (g *Client) CreateScopedUser(ctx context.Context, username, password string) error {
+	err := g.createReadOnlyUser(ctx, username, password, fmt.Sprintf("%s@localhost.local", username))
+	if err != nil {
+		return err
+	}
+	return g.UpdateGitUser(ctx, username, password)
+}
+
+// DeleteUser deletes a user along with the tokens and collaborations of the user.
+func (g *Client) DeleteUser(ctx context.Context, username string) error {
+	_, statusCode, err := g.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/admin/users/%s?purge=true", username), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusNoContent && statusCode != http.StatusNotFound {
+		return fmt.Errorf("unable to delete the user %s: unexpected status code %d", username, statusCode)
+	}
+	return nil
+}
+
+func (g *Client) createReadOnlyUser(ctx context.Context, username, password, email string) error {
 	// Create the read only user
 	createUserData := map[string]interface{}{
 		"username":             username,
 		"password":             password,
-		"email":                "zarf-reader@localhost.local",
+		"email":                email,
 		"must_change_password": false,
 	}
 	body, err := json.Marshal(createUserData)
@@ -137,7 +166,17 @@ func (g *Client) UpdateGitUser(ctx context.Context, username string, password st
 
 // CreatePackageRegistryToken creates or replaces an existing package registry token.
 func (g *Client) CreatePackageRegistryToken(ctx context.Context) (string, error) {
-	// Determine if the package token already exists.
+	return g.createToken(ctx, artifactTokenName, []string{"read:user", "read:package", "write:package"})
+}
+
+// CreateReadRepositoryToken creates or replaces a token of the client user that can only read repositories.
+func (g *Client) CreateReadRepositoryToken(ctx context.Context) (string, error) {
+	return g.createToken(ctx, scopedPullTokenName, []string{"read:repository"})
+}
+
+// createToken creates a token of the client user, replacing an existing token with the same name.
+func (g *Client) createToken(ctx context.Context, name string, scopes []string) (string, error) {
+	// Determine if the token already exists.
 	b, _, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/users/%s/tokens", g.username), nil)
 	if err != nil {
 		return "", err
@@ -147,18 +186,18 @@ func (g *Client) CreatePackageRegistryToken(ctx context.Context) (string, error)
 	if err != nil {
 		return "", err
 	}
-	hasPackageToken := false
+	hasToken := false
 	for _, token := range tokens {
-		if token["name"] != artifactTokenName {
+		if token["name"] != name {
 			continue
 		}
-		hasPackageToken = true
+		hasToken = true
 		break
 	}
 
 	// Delete the token if it already exists.
-	if hasPackageToken {
-		_, _, err := g.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s/tokens/%s", g.username, artifactTokenName), nil)
+	if hasToken {
+		_, _, err := g.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s/tokens/%s", g.username, name), nil)
 		if err != nil {
 			return "", err
 		}
@@ -166,8 +205,8 @@ func (g *Client) CreatePackageRegistryToken(ctx context.Context) (string, error)
 
 	// Create the new token.
 	createTokensData := map[string]interface{}{
-		"name":   artifactTokenName,
-		"scopes": []string{"read:user", "read:package", "write:package"},
+		"name":   name,
+		"scopes": scopes,
 	}
 	body, err := json.Marshal(createTokensData)
 	if err != nil {
@@ -22,11 +22,24 @@ import (
 
 // Run waits for a list of Zarf healthchecks to reach a ready state.
 func Run(ctx context.Context, watcher watcher.StatusWatcher, healthChecks []v1alpha1.NamespacedObjectKindReference) error {
+	objs, err := ReferenceObjMetadata(healthChecks)
+	if err != nil {
+		return err
+	}
+	err = WaitForReady(ctx, watcher, objs)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReferenceObjMetadata returns the object metadata of a list of Zarf healthchecks.
+func ReferenceObjMetadata(healthChecks []v1alpha1.NamespacedObjectKindReference) ([]object.ObjMetadata, error) {
 	objs := []object.ObjMetadata{}
 	for _, hc := range healthChecks {
 		gv, err := schema.ParseGroupVersion(hc.APIVersion)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		obj := object.ObjMetadata{
 			GroupKind: schema.GroupKind{
@@ -38,40 +51,69 @@ func Run(ctx context.Context, watcher watcher.StatusWatcher, healthChecks []v1al
 		}
 		objs = append(objs, obj)
 	}
-	err := WaitForReady(ctx, watcher, objs)
+	return objs, nil
+}
+
+// WaitForReadyRuntime waits for all of the objects to reach a ready state.
+func WaitForReadyRuntime(ctx context.Context, sw watcher.StatusWatcher, robjs []runtime.Object) error {
+	objs, err := RuntimeObjMetadata(robjs)
 	if err != nil {
 		return err
 	}
-	return nil
+	return WaitForReady(ctx, sw, objs)
 }
 
-// WaitForReadyRuntime waits for all of the objects to reach a ready state.
-func WaitForReadyRuntime(ctx context.Context, sw watcher.StatusWatcher, robjs []runtime.Object) error {
+// RuntimeObjMetadata returns the object metadata of a list of runtime objects.
+func RuntimeObjMetadata(robjs []runtime.Object) ([]object.ObjMetadata, error) {
 	objs := []object.ObjMetadata{}
 	for _, robj := range robjs {
 		obj, err := object.RuntimeToObjMeta(robj)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		objs = append(objs, obj)
 	}
-	return WaitForReady(ctx, sw, objs)
+	return objs, nil
 }
 
 // WaitForReady waits for all of the objects to reach a ready state.
 func WaitForReady(ctx context.Context, sw watcher.StatusWatcher, objs []object.ObjMetadata) error {
+	return WaitForReadyOrDeleted(ctx, sw, objs, nil)
+}
+
+// WaitForReadyOrDeleted waits for all of the objects to reach a ready state, where the deletable objects are also done
+// once they no longer exist. This is used for objects that were already ready once, as they may have been removed
+// on purpose since.
+func WaitForReadyOrDeleted(ctx context.Context, sw watcher.StatusWatcher, objs, deletable []object.ObjMetadata) error {
 	cancelCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// Objects that do not exist once the watch is synced are never reported, their status stays unknown.
+	deletableSet := object.ObjMetadataSet(deletable)
+	synced := false
+	isDeleted := func(id object.ObjMetadata, rs *event.ResourceStatus) bool {
+		if !deletableSet.Contains(id) {
+			return false
+		}
+		return rs.Status == status.NotFoundStatus || synced && rs.Status == status.UnknownStatus
+	}
 	eventCh := sw.Watch(cancelCtx, objs, watcher.Options{})
 	statusCollector := collector.NewResourceStatusCollector(objs)
 	done := statusCollector.ListenWithObserver(eventCh, collector.ObserverFunc(
-		func(statusCollector *collector.ResourceStatusCollector, _ event.Event) {
+		func(statusCollector *collector.ResourceStatusCollector, e event.Event) {
+			if e.Type == event.SyncEvent {
+				synced = true
+			}
 			rss := []*event.ResourceStatus{}
-			for _, rs := range statusCollector.ResourceStatuses {
+			for id, rs := range statusCollector.ResourceStatuses {
 				if rs == nil {
 					continue
 				}
+				if isDeleted(id, rs) {
+					deleted := *rs
+					deleted.Status = status.CurrentStatus
+					rs = &deleted
+				}
 				rss = append(rss, rs)
 			}
 			desired := status.CurrentStatus
@@ -92,6 +134,9 @@ func WaitForReady(ctx context.Context, sw watcher.StatusWatcher, objs []object.O
 		errs := []error{}
 		for _, id := range objs {
 			rs := statusCollector.ResourceStatuses[id]
+			if isDeleted(id, rs) {
+				continue
+			}
 			switch rs.Status {
 			case status.CurrentStatus:
 			case status.NotFoundStatus:
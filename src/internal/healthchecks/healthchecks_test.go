@@ -19,6 +19,7 @@ import (
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -98,3 +99,49 @@ func TestRunHealthChecks(t *testing.T) {
 		})
 	}
 }
+
+func TestReferenceObjMetadata(t *testing.T) {
+	t.Parallel()
+
+	objs, err := ReferenceObjMetadata([]v1alpha1.NamespacedObjectKindReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "podinfo", Name: "podinfo"},
+		{APIVersion: "v1", Kind: "Pod", Namespace: "ns", Name: "good-pod"},
+	})
+	require.NoError(t, err)
+	expected := []object.ObjMetadata{
+		{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "podinfo", Name: "podinfo"},
+		{GroupKind: schema.GroupKind{Kind: "Pod"}, Namespace: "ns", Name: "good-pod"},
+	}
+	require.Equal(t, expected, objs)
+
+	_, err = ReferenceObjMetadata([]v1alpha1.NamespacedObjectKindReference{{APIVersion: "a/b/c", Kind: "Pod"}})
+	require.Error(t, err)
+}
+
+func TestWaitForReadyOrDeleted(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+	fakeMapper := testutil.NewFakeRESTMapper(v1.SchemeGroupVersion.WithKind("Pod"))
+	statusWatcher := watcher.NewDefaultStatusWatcher(fakeClient, fakeMapper)
+	m := make(map[string]interface{})
+	err := yaml.Unmarshal([]byte(podCurrentYaml), &m)
+	require.NoError(t, err)
+	pod := &unstructured.Unstructured{Object: m}
+	err = fakeClient.Tracker().Create(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, pod, pod.GetNamespace())
+	require.NoError(t, err)
+
+	ready := object.ObjMetadata{GroupKind: schema.GroupKind{Kind: "Pod"}, Namespace: "ns", Name: "good-pod"}
+	deleted := object.ObjMetadata{GroupKind: schema.GroupKind{Kind: "Pod"}, Namespace: "ns", Name: "deleted-pod"}
+	objs := []object.ObjMetadata{ready, deleted}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err = WaitForReadyOrDeleted(ctx, statusWatcher, objs, []object.ObjMetadata{deleted})
+	require.NoError(t, err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err = WaitForReadyOrDeleted(ctx, statusWatcher, objs, nil)
+	require.EqualError(t, err, errors.Join(errors.New("deleted-pod: Pod not ready"), context.DeadlineExceeded).Error())
+}
@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packagemirror
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// packageLock is the part of a package-lock.json with lockfile version 2 or 3 that pins the packages.
+type packageLock struct {
+	LockfileVersion int `json:"lockfileVersion"`
+	Packages        map[string]struct {
+		Name      string `json:"name"`
+		Version   string `json:"version"`
+		Resolved  string `json:"resolved"`
+		Integrity string `json:"integrity"`
+		Link      bool   `json:"link"`
+	} `json:"packages"`
+}
+
+// lockedPackage is a Node package pinned in a package-lock.json.
+type lockedPackage struct {
+	Name      string
+	Version   string
+	Resolved  string
+	Integrity string
+}
+
+// parsePackageLock returns the registry packages pinned in the package-lock.json. Linked workspace packages and
+// bundled dependencies are skipped as they are not pulled from a registry.
+func parsePackageLock(ctx context.Context, r io.Reader) ([]lockedPackage, error) {
+	lock := packageLock{}
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+	if lock.LockfileVersion < 2 || lock.Packages == nil {
+		return nil, fmt.Errorf("lockfile version %d is not supported, regenerate the lockfile with npm 7 or later", lock.LockfileVersion)
+	}
+	keys := []string{}
+	for key := range lock.Packages {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	pkgs := []lockedPackage{}
+	for _, key := range keys {
+		entry := lock.Packages[key]
+		if key == "" || entry.Link || entry.Resolved == "" {
+			continue
+		}
+		name := entry.Name
+		if name == "" {
+			idx := strings.LastIndex(key, "node_modules/")
+			if idx < 0 {
+				continue
+			}
+			name = key[idx+len("node_modules/"):]
+		}
+		if !strings.HasPrefix(entry.Resolved, "https://") && !strings.HasPrefix(entry.Resolved, "http://") {
+			logger.From(ctx).Warn("skipping package that is not resolved from a registry", "name", name, "resolved", entry.Resolved)
+			continue
+		}
+		// Packages are only pulled when they can be verified against the lockfile.
+		if entry.Integrity == "" {
+			return nil, fmt.Errorf("%s %s has no integrity in the lockfile, regenerate the lockfile so every package has one", name, entry.Version)
+		}
+		if slices.ContainsFunc(pkgs, func(p lockedPackage) bool { return p.Name == name && p.Version == entry.Version }) {
+			continue
+		}
+		pkgs = append(pkgs, lockedPackage{Name: name, Version: entry.Version, Resolved: entry.Resolved, Integrity: entry.Integrity})
+	}
+	return pkgs, nil
+}
+
+func pullNpm(ctx context.Context, lockPath, dir string) ([]Package, error) {
+	f, err := os.Open(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	locked, err := parsePackageLock(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := []Package{}
+	for _, lp := range locked {
+		file := fmt.Sprintf("%s-%s.tgz", strings.ReplaceAll(strings.TrimPrefix(lp.Name, "@"), "/", "-"), lp.Version)
+		dst := filepath.Join(dir, file)
+		if err := download(ctx, lp.Resolved, dst); err != nil {
+			return nil, err
+		}
+		digest, err := verifyIntegrity(dst, lp.Integrity)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", lp.Name, lp.Version, err)
+		}
+		pkgs = append(pkgs, Package{Name: lp.Name, Version: lp.Version, File: file, Digest: digest})
+	}
+	return pkgs, nil
+}
+
+// verifyIntegrity verifies the file against the first supported hash of the subresource integrity string and returns
+// the SHA256 checksum of the file.
+func verifyIntegrity(path, integrity string) (string, error) {
+	for _, sri := range strings.Fields(integrity) {
+		algo, expected, ok := strings.Cut(sri, "-")
+		if !ok {
+			continue
+		}
+		var h hash.Hash
+		switch algo {
+		case "sha512":
+			h = sha512.New()
+		case "sha256":
+			h = sha256.New()
+		case "sha1":
+			h = sha1.New()
+		default:
+			continue
+		}
+		digest := sha256.New()
+		if err := hashFile(path, h, digest); err != nil {
+			return "", err
+		}
+		if actual := base64.StdEncoding.EncodeToString(h.Sum(nil)); actual != expected {
+			return "", fmt.Errorf("integrity mismatch, expected %s-%s but got %s-%s", algo, expected, algo, actual)
+		}
+		return hex.EncodeToString(digest.Sum(nil)), nil
+	}
+	return "", fmt.Errorf("integrity %q does not contain a supported hash", integrity)
+}
+
+// hashFile streams the file into the hashes.
+func hashFile(path string, hashes ...hash.Hash) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writers := make([]io.Writer, 0, len(hashes))
+	for _, h := range hashes {
+		writers = append(writers, h)
+	}
+	_, err = io.Copy(io.MultiWriter(writers...), f)
+	return err
+}
+
+// attachmentDataPlaceholder marks where the base64 encoded tarball is streamed into the publish document.
+const attachmentDataPlaceholder = "zarf-attachment-data-placeholder"
+
+// pushNpm publishes the package tarball with the publish API of the npm registry. The version metadata is read from
+// the package.json within the tarball so that the registry serves the dependencies of the package.
+// The tarball is streamed into the publish document instead of being read into memory.
+func pushNpm(ctx context.Context, tarballPath string, pkg Package, baseURL, username, password string) error {
+	fi, err := os.Stat(tarballPath)
+	if err != nil {
+		return err
+	}
+	meta, err := readPackageJSON(tarballPath)
+	if err != nil {
+		return fmt.Errorf("unable to read package.json: %w", err)
+	}
+	sha512Sum := sha512.New()
+	sha1Sum := sha1.New()
+	if err := hashFile(tarballPath, sha512Sum, sha1Sum); err != nil {
+		return err
+	}
+	meta["name"] = pkg.Name
+	meta["version"] = pkg.Version
+	meta["_id"] = pkg.Name + "@" + pkg.Version
+	meta["dist"] = map[string]any{
+		"integrity": "sha512-" + base64.StdEncoding.EncodeToString(sha512Sum.Sum(nil)),
+		"shasum":    hex.EncodeToString(sha1Sum.Sum(nil)),
+	}
+	doc := map[string]any{
+		"_id":      pkg.Name,
+		"name":     pkg.Name,
+		"versions": map[string]any{pkg.Version: meta},
+		"_attachments": map[string]any{
+			pkg.File: map[string]any{
+				"content_type": "application/octet-stream",
+				"data":         attachmentDataPlaceholder,
+				"length":       fi.Size(),
+			},
+		},
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	prefix, suffix, ok := bytes.Cut(b, []byte(`"`+attachmentDataPlaceholder+`"`))
+	if !ok || bytes.Contains(suffix, []byte(attachmentDataPlaceholder)) {
+		return fmt.Errorf("unable to build the publish document of %s %s", pkg.Name, pkg.Version)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeAttachment(pw, tarballPath, prefix, suffix))
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, baseURL+"/npm/"+pkg.Name, pr)
+	if err != nil {
+		return errors.Join(err, pr.Close())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(req, username, password)
+}
+
+// writeAttachment writes the publish document with the tarball base64 encoded as the attachment data.
+func writeAttachment(w io.Writer, tarballPath string, prefix, suffix []byte) error {
+	f, err := os.Open(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := w.Write(append(prefix, '"')); err != nil {
+		return err
+	}
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	if _, err := io.Copy(enc, f); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(append([]byte{'"'}, suffix...))
+	return err
+}
+
+// readPackageJSON reads the package.json at the root of the package tarball.
+func readPackageJSON(tarballPath string) (map[string]any, error) {
+	f, err := os.Open(tarballPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("package.json not found in package tarball")
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Tarballs contain a single top level directory, which is usually but not always named package.
+		dir, name := path.Split(path.Clean(hdr.Name))
+		if name != "package.json" || strings.Count(dir, "/") != 1 {
+			continue
+		}
+		meta := map[string]any{}
+		if err := json.NewDecoder(tr).Decode(&meta); err != nil {
+			return nil, err
+		}
+		return meta, nil
+	}
+}
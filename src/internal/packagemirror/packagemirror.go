@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packagemirror pulls pinned PyPI and npm packages into a Zarf package and pushes them to the artifact server.
+package packagemirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// TypePyPI mirrors Python packages pinned in a requirements.txt.
+	TypePyPI = "pypi"
+	// TypeNpm mirrors Node packages pinned in a package-lock.json.
+	TypeNpm = "npm"
+)
+
+// IndexFile is the name of the file listing the packages pulled into a mirror directory.
+const IndexFile = "index.json"
+
+// Index lists the packages pulled for a mirror.
+type Index struct {
+	// Type of the mirrored packages.
+	Type string `json:"type"`
+	// Packages that were pulled.
+	Packages []Package `json:"packages"`
+}
+
+// Package is a single package file pulled for a mirror.
+type Package struct {
+	// Name of the package.
+	Name string `json:"name"`
+	// Version of the package.
+	Version string `json:"version"`
+	// File is the name of the package file within the mirror directory.
+	File string `json:"file"`
+	// Digest is the SHA256 checksum of the package file.
+	Digest string `json:"digest"`
+}
+
+// Pull pulls the packages pinned by the mirror source into dir and writes the index of the pulled packages.
+func Pull(ctx context.Context, mirror v1alpha1.ZarfPackageMirror, sourcePath, dir string) error {
+	if err := os.MkdirAll(dir, helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	var (
+		pkgs []Package
+		err  error
+	)
+	switch mirror.Type {
+	case TypePyPI:
+		pkgs, err = pullPyPI(ctx, sourcePath, mirror.Index, dir)
+	case TypeNpm:
+		pkgs, err = pullNpm(ctx, sourcePath, dir)
+	default:
+		return fmt.Errorf("invalid package mirror type %q, valid options are %s and %s", mirror.Type, TypePyPI, TypeNpm)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to pull packages from %s: %w", mirror.Source, err)
+	}
+	b, err := json.Marshal(Index{Type: mirror.Type, Packages: pkgs})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, IndexFile), b, helpers.ReadWriteUser)
+}
+
+// Push pushes the packages pulled into dir to the artifact server at baseURL, the address of the package registry of
+// the push user. Packages that already exist on the artifact server are skipped.
+func Push(ctx context.Context, dir, baseURL, username, password string) error {
+	b, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	if err != nil {
+		return err
+	}
+	var index Index
+	if err := json.Unmarshal(b, &index); err != nil {
+		return err
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	l := logger.From(ctx)
+	for _, pkg := range index.Packages {
+		l.Info("pushing package to artifact server", "type", index.Type, "name", pkg.Name, "version", pkg.Version)
+		path := filepath.Join(dir, pkg.File)
+		var err error
+		switch index.Type {
+		case TypePyPI:
+			err = pushPyPI(ctx, path, pkg, baseURL, username, password)
+		case TypeNpm:
+			err = pushNpm(ctx, path, pkg, baseURL, username, password)
+		default:
+			return fmt.Errorf("invalid package mirror type %q", index.Type)
+		}
+		if errors.Is(err, errPackageExists) {
+			l.Debug("package already exists on artifact server", "name", pkg.Name, "version", pkg.Version)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to push %s %s: %w", pkg.Name, pkg.Version, err)
+		}
+	}
+	return nil
+}
+
+var errPackageExists = errors.New("package already exists")
+
+// do sends the request with basic auth and maps the responses of the artifact server for existing packages to
+// errPackageExists.
+func do(req *http.Request, username, password string) (err error) {
+	req.SetBasicAuth(username, password)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// Gitea returns a conflict for existing PyPI files and a bad request for existing npm versions.
+	if resp.StatusCode == http.StatusConflict || strings.Contains(string(b), "already exists") {
+		return errPackageExists
+	}
+	return fmt.Errorf("bad HTTP status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+}
+
+// download downloads the URL to dst.
+func download(ctx context.Context, url, dst string) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download %s: bad HTTP status %s", url, resp.Status)
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packagemirror
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseRequirements(t *testing.T) {
+	t.Parallel()
+
+	requirements := `# generated by pip-compile
+--index-url https://pypi.org/simple
+
+Flask_Login==0.6.3 \
+    --hash=sha256:aaaa \
+    --hash=sha256:BBBB
+requests[socks] == 2.32.3 ; python_version >= "3.8"  # via -r requirements.in
+`
+	reqs, err := parseRequirements(strings.NewReader(requirements))
+	require.NoError(t, err)
+	expected := []requirement{
+		{Name: "flask-login", Version: "0.6.3", Hashes: []string{"aaaa", "bbbb"}},
+		{Name: "requests", Version: "2.32.3"},
+	}
+	require.Equal(t, expected, reqs)
+
+	_, err = parseRequirements(strings.NewReader("requests>=2.0\n"))
+	require.EqualError(t, err, `requirement "requests>=2.0" is not pinned to a version with ==`)
+
+	_, err = parseRequirements(strings.NewReader("-r other.txt\n"))
+	require.EqualError(t, err, "option -r is not supported, list every requirement pinned with ==")
+}
+
+func TestParsePackageLock(t *testing.T) {
+	t.Parallel()
+
+	lock := `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app"},
+    "node_modules/@types/node": {"version": "22.0.0", "resolved": "https://registry.npmjs.org/@types/node/-/node-22.0.0.tgz", "integrity": "sha512-a"},
+    "node_modules/lodash": {"version": "4.17.21", "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", "integrity": "sha512-b"},
+    "node_modules/a/node_modules/lodash": {"version": "4.17.21", "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", "integrity": "sha512-b"},
+    "node_modules/workspace": {"resolved": "packages/workspace", "link": true},
+    "node_modules/from-git": {"version": "1.0.0", "resolved": "git+ssh://git@github.com/org/from-git.git#abc"}
+  }
+}`
+	pkgs, err := parsePackageLock(testutil.TestContext(t), strings.NewReader(lock))
+	require.NoError(t, err)
+	expected := []lockedPackage{
+		{Name: "@types/node", Version: "22.0.0", Resolved: "https://registry.npmjs.org/@types/node/-/node-22.0.0.tgz", Integrity: "sha512-a"},
+		{Name: "lodash", Version: "4.17.21", Resolved: "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", Integrity: "sha512-b"},
+	}
+	require.Equal(t, expected, pkgs)
+
+	_, err = parsePackageLock(testutil.TestContext(t), strings.NewReader(`{"lockfileVersion": 1, "dependencies": {}}`))
+	require.EqualError(t, err, "lockfile version 1 is not supported, regenerate the lockfile with npm 7 or later")
+
+	_, err = parsePackageLock(testutil.TestContext(t), strings.NewReader(`{"lockfileVersion": 3, "packages": {"node_modules/lodash": {"version": "4.17.21", "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz"}}}`))
+	require.EqualError(t, err, "lodash 4.17.21 has no integrity in the lockfile, regenerate the lockfile so every package has one")
+}
+
+func TestPullAndPushPyPI(t *testing.T) {
+	t.Parallel()
+
+	wheel := []byte("wheel")
+	wheelSum := sha256.Sum256(wheel)
+	sdist := []byte("sdist")
+	sdistSum := sha256.Sum256(sdist)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("GET /pypi/flask-login/0.6.3/json", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"urls": [
+			{"filename": "Flask_Login-0.6.3-py3-none-any.whl", "url": "%[1]s/files/wheel", "digests": {"sha256": "%[2]s"}},
+			{"filename": "Flask-Login-0.6.3.tar.gz", "url": "%[1]s/files/sdist", "digests": {"sha256": "%[3]s"}}
+		]}`, srv.URL, hex.EncodeToString(wheelSum[:]), hex.EncodeToString(sdistSum[:]))
+	})
+	mux.HandleFunc("GET /files/wheel", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write(wheel)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("GET /files/sdist", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write(sdist)
+		require.NoError(t, err)
+	})
+	var mu sync.Mutex
+	uploaded := []string{}
+	mux.HandleFunc("POST /api/packages/zarf-git-user/pypi", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "push" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		file, header, err := r.FormFile("content")
+		require.NoError(t, err)
+		defer file.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, name := range uploaded {
+			if name == header.Filename {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		require.Equal(t, "flask-login", r.FormValue("name"))
+		require.Equal(t, "0.6.3", r.FormValue("version"))
+		uploaded = append(uploaded, header.Filename)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ctx := testutil.TestContext(t)
+	tmpDir := t.TempDir()
+	requirementsPath := filepath.Join(tmpDir, "requirements.txt")
+	err := os.WriteFile(requirementsPath, []byte("flask-login==0.6.3 --hash=sha256:"+hex.EncodeToString(wheelSum[:])+"\n"), 0o600)
+	require.NoError(t, err)
+	dir := filepath.Join(tmpDir, "mirror")
+	mirror := v1alpha1.ZarfPackageMirror{Type: TypePyPI, Source: "requirements.txt", Index: srv.URL}
+	require.NoError(t, Pull(ctx, mirror, requirementsPath, dir))
+
+	b, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	require.NoError(t, err)
+	index := Index{}
+	require.NoError(t, json.Unmarshal(b, &index))
+	expected := Index{
+		Type: TypePyPI,
+		Packages: []Package{
+			{Name: "flask-login", Version: "0.6.3", File: "Flask_Login-0.6.3-py3-none-any.whl", Digest: hex.EncodeToString(wheelSum[:])},
+		},
+	}
+	require.Equal(t, expected, index)
+
+	baseURL := srv.URL + "/api/packages/zarf-git-user"
+	require.NoError(t, Push(ctx, dir, baseURL, "push", "token"))
+	// Pushing again skips the existing files.
+	require.NoError(t, Push(ctx, dir, baseURL, "push", "token"))
+	require.Equal(t, []string{"Flask_Login-0.6.3-py3-none-any.whl"}, uploaded)
+
+	err = Push(ctx, dir, baseURL, "push", "wrong")
+	require.EqualError(t, err, "unable to push flask-login 0.6.3: bad HTTP status 401 Unauthorized: ")
+}
+
+func TestPullAndPushNpm(t *testing.T) {
+	t.Parallel()
+
+	tarball := npmTarball(t, `{"name": "@org/lib", "version": "1.0.0", "dependencies": {"lodash": "^4.17.21"}}`)
+	integrity := sha512.Sum512(tarball)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("GET /@org/lib/-/lib-1.0.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write(tarball)
+		require.NoError(t, err)
+	})
+	var published map[string]any
+	mux.HandleFunc("PUT /api/packages/zarf-git-user/npm/@org/lib", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&published))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ctx := testutil.TestContext(t)
+	tmpDir := t.TempDir()
+	lockPath := filepath.Join(tmpDir, "package-lock.json")
+	lock := fmt.Sprintf(`{"lockfileVersion": 3, "packages": {"node_modules/@org/lib": {"version": "1.0.0", "resolved": "%s/@org/lib/-/lib-1.0.0.tgz", "integrity": "sha512-%s"}}}`,
+		srv.URL, base64.StdEncoding.EncodeToString(integrity[:]))
+	require.NoError(t, os.WriteFile(lockPath, []byte(lock), 0o600))
+	dir := filepath.Join(tmpDir, "mirror")
+	require.NoError(t, Pull(ctx, v1alpha1.ZarfPackageMirror{Type: TypeNpm, Source: "package-lock.json"}, lockPath, dir))
+	require.FileExists(t, filepath.Join(dir, "org-lib-1.0.0.tgz"))
+
+	require.NoError(t, Push(ctx, dir, srv.URL+"/api/packages/zarf-git-user", "push", "token"))
+	require.Equal(t, "@org/lib", published["name"])
+	versions, ok := published["versions"].(map[string]any)
+	require.True(t, ok)
+	meta, ok := versions["1.0.0"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"lodash": "^4.17.21"}, meta["dependencies"])
+	attachments, ok := published["_attachments"].(map[string]any)
+	require.True(t, ok)
+	attachment, ok := attachments["org-lib-1.0.0.tgz"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, base64.StdEncoding.EncodeToString(tarball), attachment["data"])
+	require.InDelta(t, len(tarball), attachment["length"], 0)
+	dist, ok := meta["dist"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "sha512-"+base64.StdEncoding.EncodeToString(integrity[:]), dist["integrity"])
+
+	lock = strings.Replace(lock, base64.StdEncoding.EncodeToString(integrity[:]), "AAAA", 1)
+	require.NoError(t, os.WriteFile(lockPath, []byte(lock), 0o600))
+	err := Pull(ctx, v1alpha1.ZarfPackageMirror{Type: TypeNpm, Source: "package-lock.json"}, lockPath, dir)
+	require.ErrorContains(t, err, "@org/lib 1.0.0: integrity mismatch")
+}
+
+func npmTarball(t *testing.T, packageJSON string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "package/package.json", Mode: 0o644, Size: int64(len(packageJSON))}))
+	_, err := tw.Write([]byte(packageJSON))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
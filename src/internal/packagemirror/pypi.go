@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packagemirror
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+)
+
+// DefaultPyPIIndex is the index Python packages are pulled from when the mirror does not set one.
+const DefaultPyPIIndex = "https://pypi.org"
+
+var (
+	commentRegex     = regexp.MustCompile(`(^|\s+)#.*$`)
+	hashRegex        = regexp.MustCompile(`--hash[=\s]+(\w+):([0-9a-fA-F]+)`)
+	requirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+	pinnedRegex      = regexp.MustCompile(`^==\s*([^\s,=]+)$`)
+	nameNormalizer   = regexp.MustCompile(`[-_.]+`)
+)
+
+// requirement is a Python package pinned to a version in a requirements file.
+type requirement struct {
+	Name    string
+	Version string
+	// Hashes are the allowed SHA256 checksums of the package files, any file of the version is allowed when empty.
+	Hashes []string
+}
+
+// parseRequirements parses a requirements file in which every requirement is pinned with ==, as generated by
+// pip freeze or pip-compile.
+func parseRequirements(r io.Reader) ([]requirement, error) {
+	reqs := []requirement{}
+	scanner := bufio.NewScanner(r)
+	line := ""
+	for scanner.Scan() {
+		line += scanner.Text()
+		if strings.HasSuffix(line, `\`) {
+			line = strings.TrimSuffix(line, `\`) + " "
+			continue
+		}
+		req, ok, err := parseRequirement(line)
+		line = ""
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			reqs = append(reqs, req)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func parseRequirement(line string) (requirement, bool, error) {
+	line = strings.TrimSpace(commentRegex.ReplaceAllString(line, ""))
+	if line == "" {
+		return requirement{}, false, nil
+	}
+	if strings.HasPrefix(line, "-") {
+		for _, opt := range []string{"-r", "--requirement", "-c", "--constraint", "-e", "--editable"} {
+			if line == opt || strings.HasPrefix(line, opt+" ") || strings.HasPrefix(line, opt+"=") {
+				return requirement{}, false, fmt.Errorf("option %s is not supported, list every requirement pinned with ==", opt)
+			}
+		}
+		// Other options such as --index-url do not change which packages are pinned.
+		return requirement{}, false, nil
+	}
+	req := requirement{}
+	for _, match := range hashRegex.FindAllStringSubmatch(line, -1) {
+		if match[1] == "sha256" {
+			req.Hashes = append(req.Hashes, strings.ToLower(match[2]))
+		}
+	}
+	line = strings.TrimSpace(hashRegex.ReplaceAllString(line, ""))
+	line, _, _ = strings.Cut(line, ";")
+	line = strings.TrimSpace(line)
+	match := requirementRegex.FindStringSubmatch(line)
+	if match == nil {
+		return requirement{}, false, fmt.Errorf("invalid requirement %q", line)
+	}
+	pinned := pinnedRegex.FindStringSubmatch(strings.TrimSpace(match[3]))
+	if pinned == nil {
+		return requirement{}, false, fmt.Errorf("requirement %q is not pinned to a version with ==", line)
+	}
+	req.Name = normalizeName(match[1])
+	req.Version = pinned[1]
+	return req, true, nil
+}
+
+// normalizeName normalizes a Python package name as described in PEP 503.
+func normalizeName(name string) string {
+	return strings.ToLower(nameNormalizer.ReplaceAllString(name, "-"))
+}
+
+type pypiRelease struct {
+	URLs []struct {
+		Filename string `json:"filename"`
+		URL      string `json:"url"`
+		Digests  struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digests"`
+	} `json:"urls"`
+}
+
+func pullPyPI(ctx context.Context, requirementsPath, index, dir string) ([]Package, error) {
+	f, err := os.Open(requirementsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reqs, err := parseRequirements(f)
+	if err != nil {
+		return nil, err
+	}
+	if index == "" {
+		index = DefaultPyPIIndex
+	}
+	index = strings.TrimSuffix(index, "/")
+
+	pkgs := []Package{}
+	for _, req := range reqs {
+		release, err := getPyPIRelease(ctx, index, req)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, file := range release.URLs {
+			if len(req.Hashes) > 0 && !slices.Contains(req.Hashes, file.Digests.SHA256) {
+				continue
+			}
+			found = true
+			if slices.ContainsFunc(pkgs, func(p Package) bool { return p.File == file.Filename }) {
+				continue
+			}
+			dst := filepath.Join(dir, file.Filename)
+			if err := download(ctx, file.URL, dst); err != nil {
+				return nil, err
+			}
+			if err := helpers.SHAsMatch(dst, file.Digests.SHA256); err != nil {
+				return nil, fmt.Errorf("%s of %s %s: %w", file.Filename, req.Name, req.Version, err)
+			}
+			pkgs = append(pkgs, Package{Name: req.Name, Version: req.Version, File: file.Filename, Digest: file.Digests.SHA256})
+		}
+		if !found {
+			return nil, fmt.Errorf("no files of %s %s match the pinned hashes", req.Name, req.Version)
+		}
+	}
+	return pkgs, nil
+}
+
+func getPyPIRelease(ctx context.Context, index string, req requirement) (_ pypiRelease, err error) {
+	releaseURL := fmt.Sprintf("%s/pypi/%s/%s/json", index, url.PathEscape(req.Name), url.PathEscape(req.Version))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, releaseURL, nil)
+	if err != nil {
+		return pypiRelease{}, err
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return pypiRelease{}, err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return pypiRelease{}, fmt.Errorf("unable to get %s %s from %s: bad HTTP status %s", req.Name, req.Version, index, resp.Status)
+	}
+	release := pypiRelease{}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return pypiRelease{}, err
+	}
+	return release, nil
+}
+
+// pushPyPI uploads the package file with the upload API of the PyPI registry.
+// The multipart body is streamed from the package file instead of being read into memory.
+func pushPyPI(ctx context.Context, path string, pkg Package, baseURL, username, password string) error {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writePyPIUpload(w, path, pkg))
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/pypi", pr)
+	if err != nil {
+		return errors.Join(err, pr.Close())
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return do(req, username, password)
+}
+
+// writePyPIUpload writes the upload form of the package file.
+func writePyPIUpload(w *multipart.Writer, path string, pkg Package) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fields := [][2]string{
+		{":action", "file_upload"},
+		{"protocol_version", "1"},
+		{"name", pkg.Name},
+		{"version", pkg.Version},
+		{"sha256_digest", pkg.Digest},
+	}
+	for _, field := range fields {
+		if err := w.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	part, err := w.CreateFormFile("content", pkg.File)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/klauspost/compress/zip"
+	"github.com/mholt/archiver/v3"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// ExtractOptions select the members of an archive that are extracted and where they are written.
+type ExtractOptions struct {
+	// Format is the format of the archive, it is determined by the extension of the archive when empty.
+	Format string
+	// StripComponents is the number of leading path elements removed from the name of every member.
+	StripComponents int
+	// Members are glob patterns of the members that are extracted, matching a directory selects everything within it.
+	// All members are extracted when empty.
+	Members []string
+}
+
+// Unpack copies or pulls the archive source of the file and extracts its members into the directory dst.
+// The shasum of the file is verified against the archive before it is extracted.
+func Unpack(ctx context.Context, file v1alpha1.ZarfFile, packagePath, dst, cosignKeyPath string) error {
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var archive string
+	if IsRemote(file.Source) {
+		name, err := archiveName(file.Source)
+		if err != nil {
+			return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err.Error())
+		}
+		archive = filepath.Join(tmpDir, name)
+		if err := pull(ctx, file.Source, archive, cosignKeyPath); err != nil {
+			return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
+		}
+	} else {
+		archive = filepath.Join(packagePath, file.Source)
+	}
+	if file.Shasum != "" {
+		if err := helpers.SHAsMatch(archive, file.Shasum); err != nil {
+			return err
+		}
+	}
+
+	opts := ExtractOptions{
+		Format:          file.ExtractFormat,
+		StripComponents: file.StripComponents,
+		Members:         file.ExtractMembers,
+	}
+	if err := ExtractArchive(archive, dst, opts); err != nil {
+		return fmt.Errorf("unable to extract the archive %s: %w", file.Source, err)
+	}
+	return nil
+}
+
+// ExtractArchive extracts the members of the archive selected by the options into the destination directory.
+// Members that would be written outside of the destination directory, also through the links of the archive or links
+// that already exist in the destination directory, are rejected.
+func ExtractArchive(archive, destinationDir string, opts ExtractOptions) error {
+	format, err := byFormat(archive, opts.Format)
+	if err != nil {
+		return err
+	}
+	walker, ok := format.(archiver.Walker)
+	if !ok {
+		return fmt.Errorf("%s is not an archive that files can be extracted from", archive)
+	}
+	if err := helpers.CreateDirectory(destinationDir, helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	resolvedDir, err := filepath.EvalSymlinks(destinationDir)
+	if err != nil {
+		return err
+	}
+
+	extracted := 0
+	err = walker.Walk(archive, func(f archiver.File) error {
+		name, err := memberName(f)
+		if err != nil {
+			return err
+		}
+		if !matchMember(name, opts.Members) {
+			return nil
+		}
+		rel, ok := stripComponents(name, opts.StripComponents)
+		if !ok {
+			return nil
+		}
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("member %s would be extracted outside of %s", name, destinationDir)
+		}
+		// Links extracted before the member must not redirect it outside of the destination.
+		if resolved, err := resolvePath(resolvedDir, rel); err != nil || !isWithin(resolvedDir, resolved) {
+			return fmt.Errorf("member %s would be extracted outside of %s", name, destinationDir)
+		}
+		target := filepath.Join(destinationDir, rel)
+		extracted++
+
+		switch {
+		case f.IsDir():
+			return helpers.CreateDirectory(target, helpers.ReadWriteExecuteUser)
+		case f.Mode()&os.ModeSymlink != 0:
+			return extractSymlink(f, destinationDir, resolvedDir, rel, target)
+		case f.Mode().IsRegular():
+			return extractFile(f, target)
+		default:
+			// Devices, pipes and other special members are not extracted.
+			extracted--
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if extracted == 0 && len(opts.Members) > 0 {
+		return fmt.Errorf("no members of %s matched %s", archive, strings.Join(opts.Members, ", "))
+	}
+	return nil
+}
+
+func byFormat(archive, format string) (interface{}, error) {
+	if format == "" {
+		return archiver.ByExtension(archive)
+	}
+	return archiver.ByExtension("archive." + strings.TrimPrefix(format, "."))
+}
+
+// memberName returns the full slash separated name of the member within the archive.
+func memberName(f archiver.File) (string, error) {
+	var name string
+	switch h := f.Header.(type) {
+	case *tar.Header:
+		name = h.Name
+	case zip.FileHeader:
+		name = h.Name
+	default:
+		return "", fmt.Errorf("unable to get the name of archive member %s", f.Name())
+	}
+	return path.Clean(strings.TrimPrefix(filepath.ToSlash(name), "./")), nil
+}
+
+// matchMember returns true if the member or one of its parent directories matches a pattern.
+func matchMember(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		pattern = path.Clean(strings.TrimPrefix(pattern, "./"))
+		for candidate := name; candidate != "." && candidate != "/"; candidate = path.Dir(candidate) {
+			if ok, err := path.Match(pattern, candidate); err == nil && ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stripComponents removes the leading path elements of the name, returning false if nothing is left.
+func stripComponents(name string, n int) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	if n >= len(parts) {
+		return "", false
+	}
+	return filepath.FromSlash(path.Join(parts[n:]...)), true
+}
+
+func extractFile(f archiver.File, target string) (err error) {
+	if err := helpers.CreateDirectory(filepath.Dir(target), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm()|0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err2 := out.Close()
+		err = errors.Join(err, err2)
+	}()
+	_, err = io.Copy(out, f)
+	return err
+}
+
+// extractSymlink creates the link of the member at target, rel is the path of the target within the destination directory.
+func extractSymlink(f archiver.File, destinationDir, resolvedDir, rel, target string) error {
+	linkname, err := memberLinkname(f)
+	if err != nil {
+		return err
+	}
+	// Links may only point to other members of the extracted archive, following the links extracted before them.
+	if err := helpers.CreateDirectory(filepath.Dir(target), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	parent, err := resolvePath(resolvedDir, filepath.Dir(rel))
+	if err != nil {
+		return err
+	}
+	resolved, err := resolvePath(parent, linkname)
+	if err != nil || filepath.IsAbs(linkname) || !isWithin(resolvedDir, resolved) {
+		return fmt.Errorf("link %s to %s would point outside of %s", filepath.ToSlash(rel), linkname, destinationDir)
+	}
+	return os.Symlink(linkname, target)
+}
+
+// memberLinkname returns the target of a link member. Zip archives store the target as the content of the member.
+func memberLinkname(f archiver.File) (string, error) {
+	switch h := f.Header.(type) {
+	case *tar.Header:
+		return h.Linkname, nil
+	case zip.FileHeader:
+		b, err := io.ReadAll(io.LimitReader(f, 4096))
+		if err != nil {
+			return "", fmt.Errorf("unable to read the link of archive member %s: %w", h.Name, err)
+		}
+		return string(b), nil
+	default:
+		return "", fmt.Errorf("unable to read the link of archive member %s", f.Name())
+	}
+}
+
+// maxLinkHops is the number of links resolvePath follows before it gives up, as the links may form a loop.
+const maxLinkHops = 255
+
+// resolvePath joins the relative path to the base directory one element at a time and resolves the links on the way,
+// so that '..' after a link refers to the parent of the link target. Elements that do not exist yet are joined as is.
+func resolvePath(base, rel string) (string, error) {
+	hops := 0
+	return resolveLinks(base, rel, &hops)
+}
+
+func resolveLinks(base, rel string, hops *int) (string, error) {
+	current := base
+	for _, elem := range strings.Split(filepath.ToSlash(rel), "/") {
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+			continue
+		}
+		current = filepath.Join(current, elem)
+		info, err := os.Lstat(current)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		*hops++
+		if *hops > maxLinkHops {
+			return "", fmt.Errorf("too many links in %s", filepath.Join(base, rel))
+		}
+		linkname, err := os.Readlink(current)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(linkname) {
+			current, err = resolveLinks(filepath.VolumeName(linkname)+string(filepath.Separator), linkname, hops)
+		} else {
+			current, err = resolveLinks(filepath.Dir(current), linkname, hops)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return current, nil
+}
+
+// isWithin returns true if the path is the directory or within it.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && filepath.IsLocal(rel)
+}
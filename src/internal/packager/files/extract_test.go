@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func writeTarGz(t *testing.T, path string, headers []*tar.Header, contents map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for _, h := range headers {
+		if h.Typeflag == tar.TypeReg {
+			h.Size = int64(len(contents[h.Name]))
+		}
+		require.NoError(t, tw.WriteHeader(h))
+		if h.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(contents[h.Name]))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+}
+
+func TestExtractArchive(t *testing.T) {
+	t.Parallel()
+
+	archive := filepath.Join(t.TempDir(), "release.tar.gz")
+	writeTarGz(t, archive, []*tar.Header{
+		{Name: "release-1.0/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "release-1.0/bin/tool", Typeflag: tar.TypeReg, Mode: 0o755},
+		{Name: "release-1.0/bin/tool-link", Typeflag: tar.TypeSymlink, Linkname: "tool"},
+		{Name: "release-1.0/README.md", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{
+		"release-1.0/bin/tool":  "#!/bin/sh\n",
+		"release-1.0/README.md": "# release\n",
+	})
+
+	tests := []struct {
+		name     string
+		opts     ExtractOptions
+		expected []string
+		missing  []string
+	}{
+		{
+			name:     "all members",
+			expected: []string{"release-1.0/bin/tool", "release-1.0/bin/tool-link", "release-1.0/README.md"},
+		},
+		{
+			name:     "strip components",
+			opts:     ExtractOptions{StripComponents: 1},
+			expected: []string{"bin/tool", "bin/tool-link", "README.md"},
+			missing:  []string{"release-1.0"},
+		},
+		{
+			name:     "select members",
+			opts:     ExtractOptions{StripComponents: 2, Members: []string{"release-1.0/bin"}},
+			expected: []string{"tool", "tool-link"},
+			missing:  []string{"README.md"},
+		},
+		{
+			name:     "select members by pattern",
+			opts:     ExtractOptions{Members: []string{"*/*.md"}},
+			expected: []string{"release-1.0/README.md"},
+			missing:  []string{"release-1.0/bin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dst := t.TempDir()
+			err := ExtractArchive(archive, dst, tt.opts)
+			require.NoError(t, err)
+			for _, name := range tt.expected {
+				require.FileExists(t, filepath.Join(dst, name))
+			}
+			for _, name := range tt.missing {
+				require.NoFileExists(t, filepath.Join(dst, name))
+				require.NoDirExists(t, filepath.Join(dst, name))
+			}
+		})
+	}
+
+	err := ExtractArchive(archive, t.TempDir(), ExtractOptions{Members: []string{"missing"}})
+	require.EqualError(t, err, "no members of "+archive+" matched missing")
+}
+
+func TestExtractArchiveRejectsTraversal(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	archive := filepath.Join(tmpDir, "evil.tgz")
+	writeTarGz(t, archive, []*tar.Header{
+		{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"../evil": "evil"})
+	err := ExtractArchive(archive, filepath.Join(tmpDir, "dst"), ExtractOptions{})
+	require.ErrorContains(t, err, "would be extracted outside of")
+
+	archive = filepath.Join(tmpDir, "link.tgz")
+	writeTarGz(t, archive, []*tar.Header{
+		{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"},
+	}, nil)
+	err = ExtractArchive(archive, filepath.Join(tmpDir, "dst"), ExtractOptions{})
+	require.ErrorContains(t, err, "would point outside of")
+
+	// The second link only points outside of the destination once the first link is followed.
+	archive = filepath.Join(tmpDir, "chained.tgz")
+	writeTarGz(t, archive, []*tar.Header{
+		{Name: "a/b/c/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "a/b/c/l", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+		{Name: "m", Typeflag: tar.TypeSymlink, Linkname: "a/b/c/l/.."},
+		{Name: "m/x", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"m/x": "evil"})
+	dst := filepath.Join(tmpDir, "chained", "dst")
+	err = ExtractArchive(archive, dst, ExtractOptions{})
+	require.ErrorContains(t, err, "link m to a/b/c/l/.. would point outside of")
+	require.NoFileExists(t, filepath.Join(tmpDir, "chained", "x"))
+
+	// Links that already exist in the destination are followed as well.
+	archive = filepath.Join(tmpDir, "existing.tgz")
+	writeTarGz(t, archive, []*tar.Header{
+		{Name: "out/x", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"out/x": "evil"})
+	dst = filepath.Join(tmpDir, "existing", "dst")
+	require.NoError(t, os.MkdirAll(dst, 0o755))
+	require.NoError(t, os.Symlink("..", filepath.Join(dst, "out")))
+	err = ExtractArchive(archive, dst, ExtractOptions{})
+	require.ErrorContains(t, err, "member out/x would be extracted outside of")
+	require.NoFileExists(t, filepath.Join(tmpDir, "existing", "x"))
+}
+
+func TestExtractArchiveZipSymlink(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	archive := filepath.Join(tmpDir, "links.zip")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("bin/tool")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("#!/bin/sh\n"))
+	require.NoError(t, err)
+	h := &zip.FileHeader{Name: "bin/tool-link"}
+	h.SetMode(os.ModeSymlink | 0o777)
+	w, err = zw.CreateHeader(h)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("tool"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	dst := filepath.Join(tmpDir, "dst")
+	err = ExtractArchive(archive, dst, ExtractOptions{})
+	require.NoError(t, err)
+	linkname, err := os.Readlink(filepath.Join(dst, "bin", "tool-link"))
+	require.NoError(t, err)
+	require.Equal(t, "tool", linkname)
+}
+
+func TestUnpack(t *testing.T) {
+	t.Parallel()
+
+	packagePath := t.TempDir()
+	f, err := os.Create(filepath.Join(packagePath, "archive"))
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("dir/hello.txt")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello world\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	file := v1alpha1.ZarfFile{
+		Source:          "archive",
+		Shasum:          "0000000000000000000000000000000000000000000000000000000000000000",
+		Extract:         true,
+		ExtractFormat:   "zip",
+		StripComponents: 1,
+	}
+	dst := filepath.Join(t.TempDir(), "target")
+	err = Unpack(testutil.TestContext(t), file, packagePath, dst, "")
+	require.ErrorContains(t, err, "expected sha256 of")
+
+	file.Shasum = ""
+	err = Unpack(testutil.TestContext(t), file, packagePath, dst, "")
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(dst, "hello.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello world\n", string(b))
+}
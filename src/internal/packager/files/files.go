@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package files contains functions for pulling the remote sources of component files, extracting archives and setting file permissions.
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/git"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+// GitPrefix is the prefix of file sources that are pulled from a git repository.
+const GitPrefix = "git::"
+
+// GitSource is a file or folder within a git repository, written as git::<repository URL>//<path>@<ref>.
+type GitSource struct {
+	// URL is the URL of the repository.
+	URL string
+	// Path is the path of the file or folder within the repository.
+	Path string
+	// Ref is the optional tag, branch or commit the repository is checked out at.
+	Ref string
+}
+
+// IsGit returns true if the source is pulled from a git repository.
+func IsGit(source string) bool {
+	return strings.HasPrefix(source, GitPrefix)
+}
+
+// IsRemote returns true if the source is pulled from a remote location instead of the package directory.
+func IsRemote(source string) bool {
+	return helpers.IsURL(source) || IsGit(source) || IsRelease(source)
+}
+
+// ParseGitSource parses a git file source in the form git::https://host/repo//path@ref.
+func ParseGitSource(source string) (GitSource, error) {
+	trimmed := strings.TrimPrefix(source, GitPrefix)
+	schemeIdx := strings.Index(trimmed, "://")
+	if !IsGit(source) || schemeIdx < 0 {
+		return GitSource{}, fmt.Errorf("%s is not a git source in the form git::https://host/repo//path@ref", source)
+	}
+	rest := trimmed[schemeIdx+len("://"):]
+	pathIdx := strings.Index(rest, "//")
+	if pathIdx < 0 {
+		return GitSource{}, fmt.Errorf("git source %s does not contain a path separated from the repository by //", source)
+	}
+	src := GitSource{
+		URL:  trimmed[:schemeIdx+len("://")+pathIdx],
+		Path: rest[pathIdx+len("//"):],
+	}
+	if refIdx := strings.LastIndex(src.Path, "@"); refIdx >= 0 {
+		src.Ref = src.Path[refIdx+1:]
+		src.Path = src.Path[:refIdx]
+	}
+	if src.Path == "" || !filepath.IsLocal(src.Path) {
+		return GitSource{}, fmt.Errorf("git source %s must reference a path within the repository", source)
+	}
+	return src, nil
+}
+
+// Pull pulls the remote source of the file to dst. When the file has an extract path the source is pulled
+// as an archive and the extract path is extracted into the directory of dst instead.
+func Pull(ctx context.Context, file v1alpha1.ZarfFile, dst, cosignKeyPath string) error {
+	if file.ExtractPath == "" {
+		if err := pull(ctx, file.Source, dst, cosignKeyPath); err != nil {
+			return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
+		}
+		return nil
+	}
+
+	archiveName, err := archiveName(file.Source)
+	if err != nil {
+		return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err.Error())
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	archivePath := filepath.Join(tmpDir, archiveName)
+	if err := pull(ctx, file.Source, archivePath, cosignKeyPath); err != nil {
+		return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
+	}
+	if err := Extract(archivePath, file.ExtractFormat, file.ExtractPath, filepath.Dir(dst)); err != nil {
+		return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, archiveName, err.Error())
+	}
+	return nil
+}
+
+// Extract extracts the path from the archive into the destination directory. The format of the archive is
+// determined by its extension unless a format such as tar.gz or zip is given.
+func Extract(archive, format, path, destinationDir string) error {
+	if format == "" {
+		return archiver.Extract(archive, path, destinationDir)
+	}
+	iface, err := byFormat(archive, format)
+	if err != nil {
+		return err
+	}
+	extractor, ok := iface.(archiver.Extractor)
+	if !ok {
+		return fmt.Errorf("format %s is not an archive that files can be extracted from", format)
+	}
+	return extractor.Extract(archive, path, destinationDir)
+}
+
+func pull(ctx context.Context, source, dst, cosignKeyPath string) error {
+	switch {
+	case IsGit(source):
+		return pullGit(ctx, source, dst)
+	case helpers.IsOCIURL(source):
+		return pullOCI(ctx, source, dst)
+	case IsRelease(source):
+		return pullRelease(ctx, source, dst)
+	default:
+		return utils.DownloadToFile(ctx, source, dst, cosignKeyPath)
+	}
+}
+
+func pullGit(ctx context.Context, source, dst string) error {
+	src, err := ParseGitSource(source)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	address := src.URL
+	if src.Ref != "" {
+		address = fmt.Sprintf("%s@%s", src.URL, src.Ref)
+	}
+	repository, err := git.Clone(ctx, tmpDir, address, false)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(repository.Path(), src.Path)
+	if helpers.InvalidPath(path) {
+		return fmt.Errorf("%s does not exist in %s", src.Path, address)
+	}
+	return helpers.CreatePathAndCopy(path, dst)
+}
+
+func pullOCI(ctx context.Context, source, dst string) error {
+	remote, err := zoci.NewRemote(ctx, source, ocispec.Platform{})
+	if err != nil {
+		return err
+	}
+	return remote.PullBlob(ctx, dst)
+}
+
+// archiveName returns the name an archive pulled from the source is saved as, keeping the extension of the source.
+func archiveName(source string) (string, error) {
+	switch {
+	case IsGit(source):
+		src, err := ParseGitSource(source)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Base(src.Path), nil
+	case helpers.IsOCIURL(source):
+		// Blobs are referenced by digest, so the format is given by the extract format of the file.
+		_, dgst, ok := strings.Cut(source, "@")
+		if !ok {
+			return "", errors.New("OCI sources must reference a blob by digest")
+		}
+		return strings.ReplaceAll(dgst, ":", "-"), nil
+	case IsRelease(source):
+		src, err := ParseReleaseSource(source)
+		if err != nil {
+			return "", err
+		}
+		return src.Asset, nil
+	default:
+		return helpers.ExtractBasePathFromURL(source)
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/mholt/archiver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseGitSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		source      string
+		expected    GitSource
+		expectedErr string
+	}{
+		{
+			name:   "file at tag",
+			source: "git::https://github.com/zarf-dev/zarf//examples/README.md@v0.40.0",
+			expected: GitSource{
+				URL:  "https://github.com/zarf-dev/zarf",
+				Path: "examples/README.md",
+				Ref:  "v0.40.0",
+			},
+		},
+		{
+			name:   "directory without ref",
+			source: "git::https://github.com/zarf-dev/zarf.git//examples",
+			expected: GitSource{
+				URL:  "https://github.com/zarf-dev/zarf.git",
+				Path: "examples",
+			},
+		},
+		{
+			name:        "missing path",
+			source:      "git::https://github.com/zarf-dev/zarf@v0.40.0",
+			expectedErr: "git source git::https://github.com/zarf-dev/zarf@v0.40.0 does not contain a path separated from the repository by //",
+		},
+		{
+			name:        "path outside of repository",
+			source:      "git::https://github.com/zarf-dev/zarf//../etc/passwd@main",
+			expectedErr: "git source git::https://github.com/zarf-dev/zarf//../etc/passwd@main must reference a path within the repository",
+		},
+		{
+			name:        "not a git source",
+			source:      "https://github.com/zarf-dev/zarf//README.md",
+			expectedErr: "https://github.com/zarf-dev/zarf//README.md is not a git source in the form git::https://host/repo//path@ref",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			src, err := ParseGitSource(tt.source)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, src)
+		})
+	}
+}
+
+func TestIsRemote(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsRemote("https://example.com/file.txt"))
+	require.True(t, IsRemote("oci://ghcr.io/zarf-dev/files@sha256:3b1a0e7c5c2e2d5c5d1f1a4e6e3b5f0b8b4a9d1e0d6f6c1d8b6a0c9e8f7a6b5c4"))
+	require.True(t, IsRemote("git::https://github.com/zarf-dev/zarf//README.md@main"))
+	require.False(t, IsRemote("files/README.md"))
+}
+
+func TestPullOCI(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	// Push a tar.gz archive as a single blob.
+	tmpDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(tmpDir, "hello.txt"), []byte("hello world\n"), 0o600)
+	require.NoError(t, err)
+	archivePath := filepath.Join(tmpDir, "archive.tar.gz")
+	err = archiver.Archive([]string{filepath.Join(tmpDir, "hello.txt")}, archivePath)
+	require.NoError(t, err)
+	b, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+	repo, err := remote.NewRepository(u.Host + "/files")
+	require.NoError(t, err)
+	repo.PlainHTTP = true
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayerGzip, b)
+	err = repo.Push(ctx, desc, bytes.NewReader(b))
+	require.NoError(t, err)
+
+	config.CommonOptions.PlainHTTP = true
+	source := "oci://" + u.Host + "/files@" + desc.Digest.String()
+
+	dst := filepath.Join(t.TempDir(), "archive")
+	err = Pull(ctx, v1alpha1.ZarfFile{Source: source}, dst, "")
+	require.NoError(t, err)
+	pulled, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, b, pulled)
+
+	dst = filepath.Join(t.TempDir(), "hello.txt")
+	err = Pull(ctx, v1alpha1.ZarfFile{Source: source, ExtractPath: "hello.txt", ExtractFormat: "tar.gz"}, dst, "")
+	require.NoError(t, err)
+	extracted, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "hello world\n", string(extracted))
+
+	err = Pull(ctx, v1alpha1.ZarfFile{Source: "oci://" + u.Host + "/files:latest"}, dst, "")
+	require.ErrorContains(t, err, "must reference a blob by digest")
+}
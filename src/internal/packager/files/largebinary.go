@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+)
+
+// ChunkSize is the size of the chunks that large binary files are checksummed in.
+const ChunkSize int64 = 64 * 1024 * 1024
+
+// sparseBlockSize is the granularity that runs of zeros are detected at when copying sparse files.
+const sparseBlockSize = 64 * 1024
+
+// CopySparse copies the file at src to dst, streaming it in blocks and seeking over blocks of zeros instead of writing
+// them so that holes in files such as raw VM disk images are preserved on file systems that support them.
+func CopySparse(src, dst string) (err error) {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	fi, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
+	if err := helpers.CreateParentDirectory(dst); err != nil {
+		return err
+	}
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, dstFile.Close())
+	}()
+
+	buf := make([]byte, sparseBlockSize)
+	zeros := make([]byte, sparseBlockSize)
+	for {
+		n, readErr := io.ReadFull(srcFile, buf)
+		if n > 0 {
+			if bytes.Equal(buf[:n], zeros[:n]) {
+				if _, err := dstFile.Seek(int64(n), io.SeekCurrent); err != nil {
+					return err
+				}
+			} else if _, err := dstFile.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+	// Seeking past the end does not extend the file, so trailing holes are added by truncating to the full size.
+	return dstFile.Truncate(fi.Size())
+}
+
+// ChunkChecksums returns the SHA256 checksums of the consecutive chunks of the file.
+func ChunkChecksums(path string, chunkSize int64) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	checksums := []string{}
+	for {
+		h := sha256.New()
+		n, err := io.CopyN(h, f, chunkSize)
+		if n > 0 {
+			checksums = append(checksums, hex.EncodeToString(h.Sum(nil)))
+		}
+		if errors.Is(err, io.EOF) {
+			return checksums, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// VerifyChunks verifies the chunks of the file against their checksums and returns an error naming the byte range of the
+// first chunk that does not match.
+func VerifyChunks(path string, chunkSize int64, expected []string) error {
+	actual, err := ChunkChecksums(path, chunkSize)
+	if err != nil {
+		return err
+	}
+	if len(actual) != len(expected) {
+		return fmt.Errorf("%s has %d chunks but %d were expected", filepath.Base(path), len(actual), len(expected))
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			start := int64(i) * chunkSize
+			return fmt.Errorf("chunk %d (bytes %d-%d) of %s does not match, expected %s but got %s", i, start, start+chunkSize-1, filepath.Base(path), expected[i], actual[i])
+		}
+	}
+	return nil
+}
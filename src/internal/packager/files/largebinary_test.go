@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopySparse(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	data := make([]byte, 3*sparseBlockSize+100)
+	copy(data[sparseBlockSize:], []byte("boot sector"))
+	src := filepath.Join(tmpDir, "disk.img")
+	require.NoError(t, os.WriteFile(src, data, 0o640))
+
+	dst := filepath.Join(tmpDir, "out", "disk.img")
+	require.NoError(t, CopySparse(src, dst))
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(data, b))
+	fi, err := os.Stat(dst)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), fi.Mode().Perm())
+}
+
+func TestVerifyChunks(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "disk.iso")
+	require.NoError(t, os.WriteFile(path, []byte("aaaabbbbcc"), 0o600))
+	checksums, err := ChunkChecksums(path, 4)
+	require.NoError(t, err)
+	require.Len(t, checksums, 3)
+	require.NoError(t, VerifyChunks(path, 4, checksums))
+
+	require.NoError(t, os.WriteFile(path, []byte("aaaaBbbbcc"), 0o600))
+	err = VerifyChunks(path, 4, checksums)
+	require.ErrorContains(t, err, "chunk 1 (bytes 4-7) of disk.iso does not match")
+
+	err = VerifyChunks(path, 4, checksums[:2])
+	require.EqualError(t, err, "disk.iso has 3 chunks but 2 were expected")
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// ParseMode parses an octal file mode such as 0755.
+func ParseMode(mode string) (fs.FileMode, error) {
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || m > 0o777 {
+		return 0, fmt.Errorf("invalid mode %q, it must be an octal permission such as 0755", mode)
+	}
+	return fs.FileMode(m), nil
+}
+
+// ApplyPermissions sets the mode, owner, group and SELinux label of the file on its deployed target,
+// walking every file and directory within the target when it is a directory.
+// On Windows the owner and group are set through ACLs with icacls and only the write bits of the mode are honored.
+func ApplyPermissions(ctx context.Context, file v1alpha1.ZarfFile, target string) error {
+	if file.Mode != "" {
+		mode, err := ParseMode(file.Mode)
+		if err != nil {
+			return err
+		}
+		if err := chmodAll(target, mode); err != nil {
+			return fmt.Errorf("unable to set the mode of %s: %w", target, err)
+		}
+	}
+
+	if file.Owner != "" || file.Group != "" {
+		var err error
+		if runtime.GOOS == "windows" {
+			err = setACLs(ctx, target, file.Owner, file.Group)
+		} else {
+			err = chownAll(target, file.Owner, file.Group)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to set the ownership of %s: %w", target, err)
+		}
+	}
+
+	if file.SELinuxLabel != "" {
+		if runtime.GOOS != "linux" {
+			// TODO(mkcp): Remove message on logger release
+			message.Warnf("Skipping the SELinux label of %s as SELinux is only supported on Linux", target)
+			logger.From(ctx).Warn("skipping SELinux label as SELinux is only supported on Linux", "target", target)
+			return nil
+		}
+		if err := run(ctx, "chcon", "-R", "-h", file.SELinuxLabel, target); err != nil {
+			return fmt.Errorf("unable to set the SELinux label of %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// chmodAll sets the mode of every file within the target, directories are also made searchable where they are readable.
+func chmodAll(target string, mode fs.FileMode) error {
+	return filepath.WalkDir(target, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if d.IsDir() {
+			return os.Chmod(path, mode|(mode&0o444)>>2)
+		}
+		return os.Chmod(path, mode)
+	})
+}
+
+// chownAll sets the owner and group of every file within the target, an empty owner or group is left unchanged.
+func chownAll(target, owner, group string) error {
+	uid, gid := -1, -1
+	if owner != "" {
+		id, err := lookupID(owner, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return err
+		}
+		uid = id
+	}
+	if group != "" {
+		id, err := lookupID(group, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return err
+		}
+		gid = id
+	}
+	return filepath.WalkDir(target, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, gid)
+	})
+}
+
+// lookupID returns the numeric ID of a user or group given by name or ID.
+func lookupID(nameOrID string, lookup func(string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return id, nil
+	}
+	id, err := lookup(nameOrID)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(id)
+}
+
+// setACLs sets the owner of the target and grants the group access to it through Windows ACLs.
+func setACLs(ctx context.Context, target, owner, group string) error {
+	if owner != "" {
+		if err := run(ctx, "icacls", target, "/setowner", owner, "/T", "/C", "/Q"); err != nil {
+			return err
+		}
+	}
+	if group != "" {
+		if err := run(ctx, "icacls", target, "/grant", group+":(RX)", "/T", "/C", "/Q"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(ctx context.Context, command string, args ...string) error {
+	_, stderr, err := exec.CmdWithContext(ctx, exec.Config{}, command, args...)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w: %s", command, err, strings.TrimSpace(stderr))
+	}
+	return nil
+}
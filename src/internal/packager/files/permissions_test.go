@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseMode(t *testing.T) {
+	t.Parallel()
+
+	mode, err := ParseMode("0755")
+	require.NoError(t, err)
+	require.Equal(t, fs.FileMode(0o755), mode)
+	mode, err = ParseMode("640")
+	require.NoError(t, err)
+	require.Equal(t, fs.FileMode(0o640), mode)
+
+	for _, invalid := range []string{"rwxr-xr-x", "0999", "10755", "-1"} {
+		_, err := ParseMode(invalid)
+		require.EqualError(t, err, "invalid mode \""+invalid+"\", it must be an octal permission such as 0755")
+	}
+}
+
+func TestApplyPermissions(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+
+	target := t.TempDir()
+	err := os.MkdirAll(filepath.Join(target, "bin"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(target, "bin", "tool"), []byte("#!/bin/sh\n"), 0o600)
+	require.NoError(t, err)
+
+	// The current user and group can always be set without privileges.
+	file := v1alpha1.ZarfFile{
+		Mode:  "0640",
+		Owner: strconv.Itoa(os.Getuid()),
+		Group: strconv.Itoa(os.Getgid()),
+	}
+	err = ApplyPermissions(testutil.TestContext(t), file, target)
+	require.NoError(t, err)
+
+	fi, err := os.Stat(filepath.Join(target, "bin", "tool"))
+	require.NoError(t, err)
+	require.Equal(t, fs.FileMode(0o640), fi.Mode().Perm())
+	fi, err = os.Stat(filepath.Join(target, "bin"))
+	require.NoError(t, err)
+	require.Equal(t, fs.FileMode(0o750), fi.Mode().Perm())
+
+	err = ApplyPermissions(testutil.TestContext(t), v1alpha1.ZarfFile{Owner: "zarf-user-that-does-not-exist"}, target)
+	require.ErrorContains(t, err, "unable to set the ownership of")
+}
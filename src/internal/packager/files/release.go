@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// GitHubReleasePrefix is the prefix of file sources that are assets of a GitHub release.
+	GitHubReleasePrefix = "github-release://"
+	// GitLabReleasePrefix is the prefix of file sources that are assets of a GitLab release.
+	GitLabReleasePrefix = "gitlab-release://"
+)
+
+// ReleaseSource is an asset of a GitHub or GitLab release, written as github-release://owner/repo@tag#asset or
+// gitlab-release://group/project@tag#asset.
+type ReleaseSource struct {
+	// Prefix is the release source prefix, either GitHubReleasePrefix or GitLabReleasePrefix.
+	Prefix string
+	// Repository is the owner and name of the repository, or the full path of the GitLab project.
+	Repository string
+	// Tag of the release.
+	Tag string
+	// Asset is the name of the release asset.
+	Asset string
+}
+
+// ReleaseAsset is a release asset resolved with the API of its forge.
+type ReleaseAsset struct {
+	// URL the asset is downloaded from.
+	URL string
+	// Header the asset is downloaded with.
+	Header http.Header
+	// Digest is the SHA256 checksum the forge reports for the asset, empty when the forge does not report one.
+	Digest string
+}
+
+// IsRelease returns true if the source is an asset of a GitHub or GitLab release.
+func IsRelease(source string) bool {
+	return strings.HasPrefix(source, GitHubReleasePrefix) || strings.HasPrefix(source, GitLabReleasePrefix)
+}
+
+// ParseReleaseSource parses a release source in the form github-release://owner/repo@tag#asset.
+func ParseReleaseSource(source string) (ReleaseSource, error) {
+	src := ReleaseSource{}
+	rest := ""
+	switch {
+	case strings.HasPrefix(source, GitHubReleasePrefix):
+		src.Prefix = GitHubReleasePrefix
+		rest = strings.TrimPrefix(source, GitHubReleasePrefix)
+	case strings.HasPrefix(source, GitLabReleasePrefix):
+		src.Prefix = GitLabReleasePrefix
+		rest = strings.TrimPrefix(source, GitLabReleasePrefix)
+	default:
+		return ReleaseSource{}, fmt.Errorf("%s is not a release source in the form %sowner/repo@tag#asset", source, GitHubReleasePrefix)
+	}
+	rest, src.Asset, _ = strings.Cut(rest, "#")
+	src.Repository, src.Tag, _ = strings.Cut(rest, "@")
+	if src.Repository == "" || src.Tag == "" || src.Asset == "" {
+		return ReleaseSource{}, fmt.Errorf("release source %s must be in the form %sowner/repo@tag#asset", source, src.Prefix)
+	}
+	if src.Prefix == GitHubReleasePrefix && strings.Count(src.Repository, "/") != 1 {
+		return ReleaseSource{}, fmt.Errorf("release source %s must reference a repository as owner/repo", source)
+	}
+	return src, nil
+}
+
+// ResolveReleaseAsset finds the asset of the release with the API of its forge. The GitHub API is authenticated with
+// GITHUB_TOKEN or GH_TOKEN and the GitLab API with GITLAB_TOKEN when set. GITHUB_API_URL and CI_API_V4_URL point the
+// sources at GitHub Enterprise and self-managed GitLab instances.
+func ResolveReleaseAsset(ctx context.Context, src ReleaseSource) (ReleaseAsset, error) {
+	if src.Prefix == GitLabReleasePrefix {
+		return resolveGitLabAsset(ctx, src)
+	}
+	return resolveGitHubAsset(ctx, src)
+}
+
+func resolveGitHubAsset(ctx context.Context, src ReleaseSource) (ReleaseAsset, error) {
+	apiURL := envOrDefault("GITHUB_API_URL", "https://api.github.com")
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	if token := envOrDefault("GITHUB_TOKEN", os.Getenv("GH_TOKEN")); token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	release := struct {
+		Assets []struct {
+			Name   string `json:"name"`
+			URL    string `json:"url"`
+			Digest string `json:"digest"`
+		} `json:"assets"`
+	}{}
+	releaseURL := fmt.Sprintf("%s/repos/%s/releases/tags/%s", strings.TrimSuffix(apiURL, "/"), src.Repository, url.PathEscape(src.Tag))
+	if err := getJSON(ctx, releaseURL, header, &release); err != nil {
+		return ReleaseAsset{}, fmt.Errorf("unable to get release %s of %s: %w", src.Tag, src.Repository, err)
+	}
+	for _, asset := range release.Assets {
+		if asset.Name != src.Asset {
+			continue
+		}
+		// The API URL of the asset also works for private repositories, it redirects to the download when asked for the content.
+		header.Set("Accept", "application/octet-stream")
+		return ReleaseAsset{URL: asset.URL, Header: header, Digest: strings.TrimPrefix(asset.Digest, "sha256:")}, nil
+	}
+	return ReleaseAsset{}, fmt.Errorf("release %s of %s does not have an asset named %s", src.Tag, src.Repository, src.Asset)
+}
+
+func resolveGitLabAsset(ctx context.Context, src ReleaseSource) (ReleaseAsset, error) {
+	apiURL := envOrDefault("CI_API_V4_URL", "https://gitlab.com/api/v4")
+	header := http.Header{}
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		header.Set("PRIVATE-TOKEN", token)
+	}
+	release := struct {
+		Assets struct {
+			Links []struct {
+				Name           string `json:"name"`
+				URL            string `json:"url"`
+				DirectAssetURL string `json:"direct_asset_url"`
+			} `json:"links"`
+		} `json:"assets"`
+	}{}
+	releaseURL := fmt.Sprintf("%s/projects/%s/releases/%s", strings.TrimSuffix(apiURL, "/"), url.PathEscape(src.Repository), url.PathEscape(src.Tag))
+	if err := getJSON(ctx, releaseURL, header, &release); err != nil {
+		return ReleaseAsset{}, fmt.Errorf("unable to get release %s of %s: %w", src.Tag, src.Repository, err)
+	}
+	for _, link := range release.Assets.Links {
+		if link.Name != src.Asset {
+			continue
+		}
+		assetURL := link.DirectAssetURL
+		if assetURL == "" {
+			assetURL = link.URL
+		}
+		// Release links can point anywhere, the token is only sent to the GitLab instance it belongs to.
+		if !sameHost(assetURL, apiURL) {
+			return ReleaseAsset{URL: assetURL, Header: http.Header{}}, nil
+		}
+		return ReleaseAsset{URL: assetURL, Header: header}, nil
+	}
+	return ReleaseAsset{}, fmt.Errorf("release %s of %s does not have an asset named %s", src.Tag, src.Repository, src.Asset)
+}
+
+// pullRelease downloads the release asset to dst and verifies it against the digest reported by the forge.
+func pullRelease(ctx context.Context, source, dst string) (err error) {
+	src, err := ParseReleaseSource(source)
+	if err != nil {
+		return err
+	}
+	asset, err := ResolveReleaseAsset(ctx, src)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("downloading release asset", "repository", src.Repository, "tag", src.Tag, "asset", src.Asset)
+	resp, err := get(ctx, asset.URL, asset.Header)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	if err := os.MkdirAll(filepath.Dir(dst), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("unable to download release asset %s: %w", src.Asset, err)
+	}
+	if asset.Digest != "" {
+		if err := helpers.SHAsMatch(dst, asset.Digest); err != nil {
+			return fmt.Errorf("release asset %s does not match the digest reported by the release: %w", src.Asset, err)
+		}
+	}
+	return nil
+}
+
+// releaseClient downloads release assets. The Authorization header is dropped by the HTTP client when a download is
+// redirected to another host, the GitLab PRIVATE-TOKEN header has to be dropped the same way.
+var releaseClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if req.URL.Host != via[0].URL.Host {
+			req.Header.Del("PRIVATE-TOKEN")
+		}
+		return nil
+	},
+}
+
+// sameHost returns true when both URLs point at the same host and port.
+func sameHost(a, b string) bool {
+	aURL, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	bURL, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return aURL.Host == bURL.Host
+}
+
+func getJSON(ctx context.Context, url string, header http.Header, v any) (err error) {
+	resp, err := get(ctx, url, header)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header.Clone()
+	resp, err := releaseClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("bad HTTP status: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseReleaseSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		source      string
+		expected    ReleaseSource
+		expectedErr string
+	}{
+		{
+			name:   "github asset",
+			source: "github-release://k3s-io/k3s@v1.29.10+k3s1#k3s",
+			expected: ReleaseSource{
+				Prefix:     GitHubReleasePrefix,
+				Repository: "k3s-io/k3s",
+				Tag:        "v1.29.10+k3s1",
+				Asset:      "k3s",
+			},
+		},
+		{
+			name:   "gitlab asset in subgroup",
+			source: "gitlab-release://group/subgroup/project@v1.0.0#app.tar.gz",
+			expected: ReleaseSource{
+				Prefix:     GitLabReleasePrefix,
+				Repository: "group/subgroup/project",
+				Tag:        "v1.0.0",
+				Asset:      "app.tar.gz",
+			},
+		},
+		{
+			name:        "missing asset",
+			source:      "github-release://k3s-io/k3s@v1.29.10+k3s1",
+			expectedErr: "release source github-release://k3s-io/k3s@v1.29.10+k3s1 must be in the form github-release://owner/repo@tag#asset",
+		},
+		{
+			name:        "github repository without owner",
+			source:      "github-release://k3s@v1.29.10+k3s1#k3s",
+			expectedErr: "release source github-release://k3s@v1.29.10+k3s1#k3s must reference a repository as owner/repo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			src, err := ParseReleaseSource(tt.source)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, src)
+		})
+	}
+}
+
+func TestPullGitHubRelease(t *testing.T) {
+	content := []byte("k3s binary")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("GET /repos/k3s-io/k3s/releases/tags/{tag}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("tag") != "v1.29.10+k3s1" || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		release := map[string]any{
+			"assets": []map[string]string{
+				{"name": "k3s", "url": srv.URL + "/assets/1", "digest": "sha256:" + digest},
+				{"name": "k3s-tampered", "url": srv.URL + "/assets/1", "digest": "sha256:" + digest[1:] + "0"},
+			},
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(release))
+	})
+	mux.HandleFunc("GET /assets/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/octet-stream" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		_, err := w.Write(content)
+		require.NoError(t, err)
+	})
+	t.Setenv("GITHUB_API_URL", srv.URL)
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	ctx := testutil.TestContext(t)
+	src, err := ParseReleaseSource("github-release://k3s-io/k3s@v1.29.10+k3s1#k3s")
+	require.NoError(t, err)
+	asset, err := ResolveReleaseAsset(ctx, src)
+	require.NoError(t, err)
+	require.Equal(t, digest, asset.Digest)
+
+	dst := filepath.Join(t.TempDir(), "k3s")
+	require.NoError(t, pull(ctx, "github-release://k3s-io/k3s@v1.29.10+k3s1#k3s", dst, ""))
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, content, b)
+
+	err = pull(ctx, "github-release://k3s-io/k3s@v1.29.10+k3s1#k3s-tampered", filepath.Join(t.TempDir(), "k3s"), "")
+	require.ErrorContains(t, err, "release asset k3s-tampered does not match the digest reported by the release")
+
+	err = pull(ctx, "github-release://k3s-io/k3s@v1.29.10+k3s1#k3s-missing", filepath.Join(t.TempDir(), "k3s"), "")
+	require.EqualError(t, err, "release v1.29.10+k3s1 of k3s-io/k3s does not have an asset named k3s-missing")
+}
+
+func TestPullGitLabRelease(t *testing.T) {
+	// Assets linked from other hosts must not receive the GitLab token.
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, err := w.Write([]byte("external"))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(external.Close)
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("GET /projects/{project}/releases/{tag}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("project") != "group/project" || r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		release := map[string]any{
+			"assets": map[string]any{
+				"links": []map[string]string{
+					{"name": "app.txt", "url": srv.URL + "/uploads/app.txt"},
+					{"name": "external.txt", "url": external.URL + "/external.txt"},
+					{"name": "redirect.txt", "url": srv.URL + "/redirect"},
+				},
+			},
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(release))
+	})
+	mux.HandleFunc("GET /uploads/app.txt", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte("app"))
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("GET /redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, external.URL+"/external.txt", http.StatusFound)
+	})
+	t.Setenv("CI_API_V4_URL", srv.URL)
+	t.Setenv("GITLAB_TOKEN", "secret")
+
+	ctx := testutil.TestContext(t)
+	dst := filepath.Join(t.TempDir(), "app.txt")
+	require.NoError(t, pull(ctx, "gitlab-release://group/project@v1.0.0#app.txt", dst, ""))
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "app", string(b))
+
+	for _, asset := range []string{"external.txt", "redirect.txt"} {
+		dst := filepath.Join(t.TempDir(), asset)
+		require.NoError(t, pull(ctx, "gitlab-release://group/project@v1.0.0#"+asset, dst, ""))
+		b, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, "external", string(b))
+	}
+
+	name, err := archiveName("gitlab-release://group/project@v1.0.0#app.txt")
+	require.NoError(t, err)
+	require.Equal(t, "app.txt", name)
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+)
+
+// CreateSymlink creates a symlink at link that points to target relative to the directory of the link, so that the
+// link keeps working when the tree containing both is moved. Creating the same symlink again is a no-op and symlinks
+// pointing elsewhere are replaced, while any other existing file at the link is returned as an error.
+func CreateSymlink(target, link string) error {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	link, err = filepath.Abs(link)
+	if err != nil {
+		return err
+	}
+	dest, err := filepath.Rel(filepath.Dir(link), target)
+	if err != nil {
+		// Paths on different volumes cannot be made relative.
+		dest = target
+	}
+
+	fi, err := os.Lstat(link)
+	switch {
+	case err == nil && fi.Mode()&fs.ModeSymlink != 0:
+		current, err := os.Readlink(link)
+		if err != nil {
+			return err
+		}
+		if current == dest || current == target {
+			return nil
+		}
+		if err := os.Remove(link); err != nil {
+			return err
+		}
+	case err == nil:
+		return fmt.Errorf("unable to create symlink %s, a file that is not a symlink already exists there", link)
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	if err := helpers.CreateParentDirectory(link); err != nil {
+		return err
+	}
+	return os.Symlink(dest, link)
+}
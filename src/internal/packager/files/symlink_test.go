@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSymlink(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires privileges on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "opt", "tool")
+	err := os.MkdirAll(filepath.Dir(target), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(target, []byte("tool"), 0o600)
+	require.NoError(t, err)
+	link := filepath.Join(tmpDir, "bin", "tool")
+
+	// Links point to the target relative to their own location.
+	err = CreateSymlink(target, link)
+	require.NoError(t, err)
+	dest, err := os.Readlink(link)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("..", "opt", "tool"), dest)
+	b, err := os.ReadFile(link)
+	require.NoError(t, err)
+	require.Equal(t, "tool", string(b))
+
+	// Creating the same link again is a no-op.
+	err = CreateSymlink(target, link)
+	require.NoError(t, err)
+
+	// Links to another target are replaced.
+	other := filepath.Join(tmpDir, "opt", "other")
+	err = os.WriteFile(other, []byte("other"), 0o600)
+	require.NoError(t, err)
+	err = CreateSymlink(other, link)
+	require.NoError(t, err)
+	dest, err = os.Readlink(link)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("..", "opt", "other"), dest)
+
+	// Existing files are never replaced by a link.
+	err = CreateSymlink(other, target)
+	require.ErrorContains(t, err, "a file that is not a symlink already exists there")
+	b, err = os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "tool", string(b))
+}
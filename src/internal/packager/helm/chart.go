@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -112,6 +113,9 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 		installErr := fmt.Errorf("unable to install chart after %d attempts: %w: %s", h.retries, err, removeMsg)
 
 		releases, _ := histClient.Run(h.chart.ReleaseName)
+		if len(releases) > 0 {
+			reportHooks(ctx, releases[len(releases)-1])
+		}
 		previouslyDeployedVersion := 0
 
 		// Check for previous releases that successfully deployed
@@ -136,6 +140,8 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 		return nil, "", installErr
 	}
 
+	reportHooks(ctx, release)
+
 	resourceList, err := h.actionConfig.KubeClient.Build(bytes.NewBufferString(release.Manifest), true)
 	if err != nil {
 		return nil, "", fmt.Errorf("unable to build the resource list: %w", err)
@@ -146,12 +152,17 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 		runtimeObjs = append(runtimeObjs, resource.Object)
 	}
 	if !h.chart.NoWait {
+		objs, err := healthchecks.RuntimeObjMetadata(runtimeObjs)
+		if err != nil {
+			return nil, "", err
+		}
 		// Ensure we don't go past the timeout by using a context initialized with the helm timeout
 		spinner.Updatef("Running health checks")
 		l.Info("running health checks", "chart", h.chart.Name)
-		if err := healthchecks.WaitForReadyRuntime(helmCtx, h.cluster.Watcher, runtimeObjs); err != nil {
+		if err := healthchecks.WaitForReady(helmCtx, h.cluster.Watcher, objs); err != nil {
 			return nil, "", err
 		}
+		h.readyObjects = objs
 	}
 	spinner.Success()
 	l.Debug("done processing helm chart", "name", h.chart.Name, "duration", time.Since(start))
@@ -220,6 +231,9 @@ func (h *Helm) TemplateChart(ctx context.Context) (manifest string, chartValues
 	manifest = templatedChart.Manifest
 
 	for _, hook := range templatedChart.Hooks {
+		if h.chart.SkipTests && isTestHook(hook) {
+			continue
+		}
 		manifest += fmt.Sprintf("\n---\n%s", hook.Manifest)
 	}
 
@@ -310,6 +324,8 @@ func (h *Helm) installChart(ctx context.Context, postRender *renderer) (*release
 	// We need to include CRDs or operator installations will fail spectacularly.
 	client.SkipCRDs = false
 
+	client.DisableHooks = h.chart.NoHooks
+
 	// Must be unique per-namespace and < 53 characters. @todo: restrict helm loadedChart name to this.
 	client.ReleaseName = h.chart.ReleaseName
 
@@ -321,6 +337,9 @@ func (h *Helm) installChart(ctx context.Context, postRender *renderer) (*release
 	// Post-processing our manifests to apply vars and run zarf helm logic in cluster
 	client.PostRenderer = postRender
 
+	// Label the release with the labels configured for every resource Zarf creates
+	client.Labels = config.CommonOptions.Labels
+
 	loadedChart, chartValues, err := h.loadChartData()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load chart data: %w", err)
@@ -348,6 +367,8 @@ func (h *Helm) upgradeChart(ctx context.Context, lastRelease *release.Release, p
 
 	client.SkipCRDs = true
 
+	client.DisableHooks = h.chart.NoHooks
+
 	client.SkipSchemaValidation = !h.chart.ShouldRunSchemaValidation()
 
 	// Namespace must be specified.
@@ -358,6 +379,9 @@ func (h *Helm) upgradeChart(ctx context.Context, lastRelease *release.Release, p
 
 	client.MaxHistory = maxHelmHistory
 
+	// Label the release with the labels configured for every resource Zarf creates
+	client.Labels = config.CommonOptions.Labels
+
 	loadedChart, chartValues, err := h.loadChartData()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load chart data: %w", err)
@@ -367,6 +391,39 @@ func (h *Helm) upgradeChart(ctx context.Context, lastRelease *release.Release, p
 	return client.RunWithContext(ctx, h.chart.ReleaseName, loadedChart, chartValues)
 }
 
+// isTestHook returns true if the hook only runs on helm test.
+func isTestHook(hook *release.Hook) bool {
+	for _, event := range hook.Events {
+		if event != release.HookTest {
+			return false
+		}
+	}
+	return len(hook.Events) > 0
+}
+
+// reportHooks prints the result of the hooks of the release that ran, as Helm does not report hooks that succeeded and
+// only reports the first hook that failed.
+func reportHooks(ctx context.Context, rel *release.Release) {
+	l := logger.From(ctx)
+	for _, hook := range rel.Hooks {
+		if hook.LastRun.Phase == release.HookPhaseUnknown || hook.LastRun.Phase == "" {
+			continue
+		}
+		events := make([]string, 0, len(hook.Events))
+		for _, event := range hook.Events {
+			events = append(events, event.String())
+		}
+		duration := hook.LastRun.CompletedAt.Sub(hook.LastRun.StartedAt).Round(time.Second)
+		if hook.LastRun.Phase == release.HookPhaseFailed {
+			message.Warnf("Helm hook %s %s (%s) failed", hook.Kind, hook.Name, strings.Join(events, ", "))
+			l.Warn("helm hook failed", "kind", hook.Kind, "name", hook.Name, "events", events, "release", rel.Name)
+			continue
+		}
+		message.Infof("Helm hook %s %s (%s) %s", hook.Kind, hook.Name, strings.Join(events, ", "), strings.ToLower(hook.LastRun.Phase.String()))
+		l.Info("helm hook ran", "kind", hook.Kind, "name", hook.Name, "events", events, "phase", hook.LastRun.Phase.String(), "duration", duration, "release", rel.Name)
+	}
+}
+
 func (h *Helm) rollbackChart(name string, version int) error {
 	client := action.NewRollback(h.actionConfig)
 	client.CleanupOnFail = true
@@ -375,6 +432,7 @@ func (h *Helm) rollbackChart(name string, version int) error {
 	client.Timeout = h.timeout
 	client.Version = version
 	client.MaxHistory = maxHelmHistory
+	client.DisableHooks = h.chart.NoHooks
 	return client.Run(name)
 }
 
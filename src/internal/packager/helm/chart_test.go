@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestIsTestHook(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isTestHook(&release.Hook{Events: []release.HookEvent{release.HookTest}}))
+	require.False(t, isTestHook(&release.Hook{Events: []release.HookEvent{release.HookTest, release.HookPostInstall}}))
+	require.False(t, isTestHook(&release.Hook{Events: []release.HookEvent{release.HookPreInstall}}))
+	require.False(t, isTestHook(&release.Hook{}))
+}
+
+func TestParseChartValuesLayers(t *testing.T) {
+	t.Parallel()
+
+	valuesPath := t.TempDir()
+	chart := v1alpha1.ZarfChart{
+		Name:        "podinfo",
+		Version:     "6.4.0",
+		ValuesFiles: []string{"values.yaml"},
+		ValuesLayers: []v1alpha1.ZarfValuesLayer{
+			{Files: []string{"common.yaml"}},
+			{Profile: "staging", Files: []string{"staging.yaml"}},
+			{Profile: "production", Files: []string{"production.yaml", "production-ha.yaml"}},
+		},
+	}
+	contents := []string{
+		"replicas: 1\nenv: dev\n",
+		"tier: common\n",
+		"env: staging\n",
+		"env: production\n",
+		"replicas: 3\n",
+	}
+	for idx, content := range contents {
+		err := os.WriteFile(StandardValuesName(valuesPath, chart, idx), []byte(content), 0o600)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		profiles []string
+		expected chartutil.Values
+	}{
+		{
+			name:     "no profiles",
+			expected: chartutil.Values{"replicas": float64(1), "env": "dev", "tier": "common"},
+		},
+		{
+			name:     "staging profile",
+			profiles: []string{"staging"},
+			expected: chartutil.Values{"replicas": float64(1), "env": "staging", "tier": "common"},
+		},
+		{
+			name:     "production profile",
+			profiles: []string{"production"},
+			expected: chartutil.Values{"replicas": float64(3), "env": "production", "tier": "common"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &Helm{
+				chart:      chart,
+				valuesPath: valuesPath,
+				cfg:        &types.PackagerConfig{DeployOpts: types.ZarfDeployOptions{ValuesProfiles: tt.profiles}},
+			}
+			values, err := h.parseChartValues()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, values)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/cli"
+	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
 // Helm is a config object for working with helm charts.
@@ -45,6 +46,12 @@ type Helm struct {
 	actionConfig   *action.Configuration
 	variableConfig *variables.VariableConfig
 	state          *types.ZarfState
+
+	skipNamespaceCreation bool
+	namespaceLabels       map[string]string
+	namespaceAnnotations  map[string]string
+	createdNamespace      bool
+	readyObjects          []object.ObjMetadata
 }
 
 // Modifier is a function that modifies the Helm config.
@@ -124,8 +131,11 @@ func NewFromZarfManifest(manifest v1alpha1.ZarfManifest, manifestPath, packageNa
 			Namespace:   manifest.Namespace,
 			NoWait:      manifest.NoWait,
 		},
-		chartOverride: tmpChart,
-		timeout:       config.ZarfDefaultTimeout,
+		chartOverride:         tmpChart,
+		timeout:               config.ZarfDefaultTimeout,
+		skipNamespaceCreation: !manifest.ShouldCreateNamespace(),
+		namespaceLabels:       manifest.NamespaceLabels,
+		namespaceAnnotations:  manifest.NamespaceAnnotations,
 	}
 
 	for _, mod := range mods {
@@ -137,6 +147,17 @@ func NewFromZarfManifest(manifest v1alpha1.ZarfManifest, manifestPath, packageNa
 	return h, nil
 }
 
+// ReadyObjects returns the objects of the installed chart that were waited on to be ready. It is empty when the chart
+// is installed without waiting.
+func (h *Helm) ReadyObjects() []object.ObjMetadata {
+	return h.readyObjects
+}
+
+// CreatedNamespace returns if the namespace of the chart was created when installing it.
+func (h *Helm) CreatedNamespace() bool {
+	return h.createdNamespace
+}
+
 // WithDeployInfo adds the necessary information to deploy a given chart
 func WithDeployInfo(cfg *types.PackagerConfig, variableConfig *variables.VariableConfig, state *types.ZarfState, cluster *cluster.Cluster, valuesOverrides map[string]any, timeout time.Duration, retries int) Modifier {
 	return func(h *Helm) {
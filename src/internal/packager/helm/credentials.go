@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// chartRepoTLSConfig returns the TLS config that verifies the repository of the credential with its CA bundle and
+// presents its client certificate.
+func chartRepoTLSConfig(cred types.ChartRepoCredential) (*tls.Config, error) {
+	//nolint:gosec // Skipping verification is opted into with --insecure-skip-tls-verify.
+	cfg := &tls.Config{
+		InsecureSkipVerify: config.CommonOptions.InsecureSkipTLSVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if cred.CAFile != "" {
+		b, err := os.ReadFile(cred.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA bundle of chart repo credential %s: %w", cred.Name, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("the CA bundle %s of chart repo credential %s contains no certificates", cred.CAFile, cred.Name)
+		}
+		cfg.RootCAs = pool
+	}
+	if cred.CertFile != "" || cred.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cred.CertFile, cred.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate of chart repo credential %s: %w", cred.Name, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// bearerGetter is a helm getter that authenticates the requests to a chart repository with a bearer token, which the
+// helm HTTP getter does not support.
+type bearerGetter struct {
+	cred   types.ChartRepoCredential
+	client *http.Client
+}
+
+// Get downloads the given URL, only sending the token to URLs of the repository of the credential.
+func (g *bearerGetter) Get(u string, _ ...getter.Option) (*bytes.Buffer, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "zarf")
+	if _, ok := types.MatchChartRepoCredential([]types.ChartRepoCredential{g.cred}, u); ok {
+		req.Header.Set("Authorization", "Bearer "+g.cred.Token)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", u, resp.Status)
+	}
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, resp.Body); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// chartRepoGetters returns the helm getters that download charts with the credential. The token of the credential
+// replaces the helm HTTP getter as helm only supports basic auth.
+func chartRepoGetters(settings *cli.EnvSettings, cred types.ChartRepoCredential) (getter.Providers, error) {
+	if cred.Token == "" {
+		return getter.All(settings), nil
+	}
+	tlsCfg, err := chartRepoTLSConfig(cred)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	g := &bearerGetter{cred: cred, client: &http.Client{Transport: transport}}
+	providers := getter.Providers{
+		{
+			Schemes: []string{"http", "https"},
+			New: func(...getter.Option) (getter.Getter, error) {
+				return g, nil
+			},
+		},
+	}
+	for _, p := range getter.All(settings) {
+		if p.Provides("http") || p.Provides("https") {
+			continue
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
+// chartRepoRegistryClient returns a helm registry client that is logged in to the OCI registry of the credential. The
+// login is stored in a credentials file in dir so that the helm registry config of the user is not changed.
+func chartRepoRegistryClient(cred types.ChartRepoCredential, dir string) (*registry.Client, error) {
+	tlsCfg, err := chartRepoTLSConfig(cred)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	opts := []registry.ClientOption{
+		registry.ClientOptEnableCache(true),
+		registry.ClientOptCredentialsFile(filepath.Join(dir, "registry-config.json")),
+		registry.ClientOptHTTPClient(&http.Client{Transport: transport}),
+	}
+	if config.CommonOptions.PlainHTTP {
+		opts = append(opts, registry.ClientOptPlainHTTP())
+	}
+	regClient, err := registry.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the new registry client: %w", err)
+	}
+	password := cred.Password
+	if cred.Token != "" {
+		password = cred.Token
+	}
+	if cred.Username == "" && password == "" {
+		return regClient, nil
+	}
+	u, err := url.Parse(cred.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url of chart repo credential %s: %w", cred.Name, err)
+	}
+	host := u.Host
+	if host == "" {
+		host = strings.SplitN(strings.TrimPrefix(cred.URL, registry.OCIScheme+"://"), "/", 2)[0]
+	}
+	err = regClient.Login(host,
+		registry.LoginOptBasicAuth(cred.Username, password),
+		registry.LoginOptTLSClientConfig(cred.CertFile, cred.KeyFile, cred.CAFile),
+		registry.LoginOptInsecure(config.CommonOptions.PlainHTTP),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to log in to %s with chart repo credential %s: %w", host, cred.Name, err)
+	}
+	return regClient, nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestMatchChartRepoCredential(t *testing.T) {
+	t.Parallel()
+
+	creds := []types.ChartRepoCredential{
+		{Name: "nexus", URL: "https://nexus.example.com/repository"},
+		{Name: "helm", URL: "https://nexus.example.com/repository/helm/"},
+		{Name: "museum", URL: "oci://museum.example.com"},
+	}
+	tests := []struct {
+		name     string
+		chartURL string
+		expected string
+	}{
+		{name: "longest prefix", chartURL: "https://nexus.example.com/repository/helm", expected: "helm"},
+		{name: "nested path", chartURL: "https://nexus.example.com/repository/charts", expected: "nexus"},
+		{name: "oci", chartURL: "oci://museum.example.com/charts/podinfo", expected: "museum"},
+		{name: "partial segment", chartURL: "https://nexus.example.com/repository-other", expected: ""},
+		{name: "unknown host", chartURL: "https://charts.example.com", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cred, ok := types.MatchChartRepoCredential(creds, tt.chartURL)
+			require.Equal(t, tt.expected != "", ok)
+			require.Equal(t, tt.expected, cred.Name)
+		})
+	}
+}
+
+func TestChartRepoGettersToken(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		//nolint:errcheck // Best effort write in test server
+		w.Write([]byte("apiVersion: v1\n"))
+	}))
+	t.Cleanup(srv.Close)
+
+	cred := types.ChartRepoCredential{Name: "private", URL: srv.URL + "/charts", Token: "secret"}
+	getters, err := chartRepoGetters(cli.New(), cred)
+	require.NoError(t, err)
+	g, err := getters.ByScheme("http")
+	require.NoError(t, err)
+
+	buf, err := g.Get(srv.URL + "/charts/index.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: v1\n", buf.String())
+
+	// The token is not sent outside of the repository of the credential.
+	_, err = g.Get(srv.URL + "/other/index.yaml")
+	require.ErrorContains(t, err, "401")
+}
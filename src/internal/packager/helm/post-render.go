@@ -19,6 +19,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/types"
+	"helm.sh/helm/v3/pkg/postrender"
 	"helm.sh/helm/v3/pkg/releaseutil"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/restmapper"
@@ -52,21 +53,44 @@ func (h *Helm) newRenderer(ctx context.Context) (*renderer, error) {
 		return nil, fmt.Errorf("unable to check for existing namespace %q in cluster: %w", h.chart.Namespace, err)
 	}
 	if kerrors.IsNotFound(err) {
-		rend.namespaces[h.chart.Namespace] = cluster.NewZarfManagedNamespace(h.chart.Namespace)
+		if h.skipNamespaceCreation {
+			return nil, fmt.Errorf("namespace %q does not exist and createNamespace is disabled", h.chart.Namespace)
+		}
+		namespace = cluster.NewZarfManagedNamespace(h.chart.Namespace)
+		h.addNamespaceMetadata(namespace)
+		rend.namespaces[h.chart.Namespace] = namespace
 	} else if h.cfg.DeployOpts.AdoptExistingResources {
 		namespace.Labels = cluster.AdoptZarfManagedLabels(namespace.Labels)
+		h.addNamespaceMetadata(namespace)
 		rend.namespaces[h.chart.Namespace] = namespace
 	}
 
 	return rend, nil
 }
 
+// addNamespaceMetadata adds the labels and annotations configured for the namespace of the chart.
+func (h *Helm) addNamespaceMetadata(namespace *corev1.Namespace) {
+	for k, v := range h.namespaceLabels {
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		namespace.Labels[k] = v
+	}
+	for k, v := range h.namespaceAnnotations {
+		if namespace.Annotations == nil {
+			namespace.Annotations = map[string]string{}
+		}
+		namespace.Annotations[k] = v
+	}
+}
+
 func (r *renderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
 	// This is very low cost and consistent for how we replace elsewhere, also good for debugging
 	tempDir, err := utils.MakeTempDir(r.chartPath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create tmpdir:  %w", err)
 	}
+	defer os.RemoveAll(tempDir)
 	path := filepath.Join(tempDir, "chart.yaml")
 
 	if err := os.WriteFile(path, renderedManifests.Bytes(), helpers.ReadWriteUser); err != nil {
@@ -84,8 +108,22 @@ func (r *renderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
 		return nil, fmt.Errorf("error reading temporary post-rendered helm chart: %w", err)
 	}
 
+	// Run the post-renderer given on deploy over the templated manifests, before Zarf processes the resources
+	if r.cfg != nil && r.cfg.DeployOpts.PostRenderer != "" {
+		postRenderer, err := postrender.NewExec(r.cfg.DeployOpts.PostRenderer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the post-renderer: %w", err)
+		}
+		rendered, err := postRenderer.Run(bytes.NewBuffer(buff))
+		if err != nil {
+			return nil, fmt.Errorf("error running the post-renderer: %w", err)
+		}
+		buff = rendered.Bytes()
+	}
+
 	// Use helm to re-split the manifest byte (same call used by helm to pass this data to postRender)
-	_, resources, err := releaseutil.SortManifests(map[string]string{path: string(buff)},
+	// The manifests are named without the temporary directory so that rendering the chart again gives the same output.
+	_, resources, err := releaseutil.SortManifests(map[string]string{filepath.Base(path): string(buff)},
 		r.actionConfig.Capabilities.APIVersions,
 		releaseutil.InstallOrder,
 	)
@@ -124,6 +162,8 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 		return err
 	}
 	for name, namespace := range r.namespaces {
+		cluster.AddCommonMetadata(namespace)
+
 		// Check to see if this namespace already exists
 		var existingNamespace bool
 		for _, serverNamespace := range namespaceList.Items {
@@ -138,6 +178,9 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 			if err != nil {
 				return fmt.Errorf("unable to create the missing namespace %s", name)
 			}
+			if name == r.chart.Namespace {
+				r.createdNamespace = true
+			}
 		} else if r.cfg.DeployOpts.AdoptExistingResources {
 			// Refuse to adopt namespace if it is one of four initial Kubernetes namespaces.
 			// https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/#initial-namespaces
@@ -158,20 +201,10 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 			continue
 		}
 
-		// Create the secret
-		validRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, name, config.ZarfImagePullSecretName, r.state.RegistryInfo)
-		if err != nil {
+		// Create the secrets
+		if err := c.ApplyZarfManagedSecrets(ctx, name, r.state); err != nil {
 			return err
 		}
-		_, err = c.Clientset.CoreV1().Secrets(*validRegistrySecret.Namespace).Apply(ctx, validRegistrySecret, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
-		if err != nil {
-			return fmt.Errorf("problem applying registry secret for the %s namespace: %w", name, err)
-		}
-		gitServerSecret := c.GenerateGitPullCreds(name, config.ZarfGitServerSecretName, r.state.GitServer)
-		_, err = c.Clientset.CoreV1().Secrets(*gitServerSecret.Namespace).Apply(ctx, gitServerSecret, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
-		if err != nil {
-			return fmt.Errorf("problem applying git server secret for the %s namespace: %w", name, err)
-		}
 	}
 	return nil
 }
@@ -284,6 +317,16 @@ func (r *renderer) editHelmResources(ctx context.Context, resources []releaseuti
 				return fmt.Errorf("unable to adopt the resource %s: %w", rawData.GetName(), err)
 			}
 		}
+		// Add the labels and annotations configured for every resource Zarf creates
+		if len(config.CommonOptions.Labels) > 0 || len(config.CommonOptions.Annotations) > 0 {
+			cluster.AddCommonMetadata(rawData)
+			b, err := yaml.Marshal(rawData.Object)
+			if err != nil {
+				return fmt.Errorf("failed to marshal manifest: %w", err)
+			}
+			resource.Content = string(b)
+		}
+
 		// Finally place this back onto the output buffer
 		fmt.Fprintf(finalManifestsOutput, "---\n# Source: %s\n%s\n", resource.Name, resource.Content)
 	}
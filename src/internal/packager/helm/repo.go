@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -33,6 +34,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // PackageChart creates a chart archive from a path to a chart on the host os and builds chart dependencies
@@ -171,6 +173,73 @@ func (h *Helm) DownloadPublishedChart(ctx context.Context, cosignKeyPath string)
 	spinner := message.NewProgressSpinner("Processing helm chart %s:%s from repo %s", h.chart.Name, h.chart.Version, h.chart.URL)
 	defer spinner.Stop()
 
+	// Download the file into a temp directory since we don't control what name helm creates here
+	temp := filepath.Join(h.chartPath, "temp")
+	if err := helpers.CreateDirectory(temp, helpers.ReadWriteExecuteUser); err != nil {
+		return fmt.Errorf("unable to create helm chart temp directory: %w", err)
+	}
+	defer func(l *slog.Logger) {
+		err := os.RemoveAll(temp)
+		if err != nil {
+			l.Error(err.Error())
+		}
+	}(l)
+
+	// Published charts are cached by their repository, name and version.
+	cache, err := utils.DefaultDownloadCache()
+	if err != nil {
+		return err
+	}
+	chartName := h.chart.Name
+	if h.chart.RepoName != "" {
+		chartName = h.chart.RepoName
+	}
+	cacheKey := fmt.Sprintf("%s#%s@%s", h.chart.URL, chartName, h.chart.Version)
+	saved := filepath.Join(temp, fmt.Sprintf("%s-%s.tgz", h.chart.Name, h.chart.Version))
+	hit, err := cache.Get(cacheKey, "", saved)
+	if err != nil {
+		l.Debug("unable to use cached helm chart", "name", h.chart.Name, "error", err.Error())
+	}
+	if hit {
+		// TODO(mkcp): Remove message on logger release
+		message.Debugf("Using the cached helm chart %s:%s", h.chart.Name, h.chart.Version)
+		l.Debug("using cached helm chart", "name", h.chart.Name, "version", h.chart.Version)
+	} else {
+		saved, err = h.pullPublishedChart(ctx, temp, spinner)
+		if err != nil {
+			return err
+		}
+		if err := cache.Put(cacheKey, saved); err != nil {
+			l.Debug("unable to cache helm chart", "name", h.chart.Name, "error", err.Error())
+		}
+	}
+
+	// Validate the chart
+	_, _, err = h.loadAndValidateChart(saved)
+	if err != nil {
+		return err
+	}
+
+	// Finalize the chart
+	err = h.finalizeChartPackage(ctx, saved, cosignKeyPath)
+	if err != nil {
+		return err
+	}
+
+	spinner.Success()
+	l.Debug("done downloading helm chart",
+		"name", h.chart.Name,
+		"version", h.chart.Version,
+		"repo", h.chart.URL,
+		"duration", time.Since(start),
+	)
+	return nil
+}
+
+// pullPublishedChart downloads a specific chart version from a remote repo into dir and returns the path of the chart archive.
+func (h *Helm) pullPublishedChart(ctx context.Context, dir string, out io.Writer) (string, error) {
+	l := logger.From(ctx)
+
 	// Set up the helm pull config
 	pull := action.NewPull()
 	pull.Settings = cli.New()
@@ -195,11 +264,28 @@ func (h *Helm) DownloadPublishedChart(ctx context.Context, cosignKeyPath string)
 	var username string
 	var password string
 
+	// Credentials configured for the chart repository take precedence over the helm repo file
+	cred, hasCred := types.MatchChartRepoCredential(config.CommonOptions.ChartRepoCredentials, h.chart.URL)
+	if hasCred {
+		l.Debug("using chart repo credential", "name", cred.Name, "url", cred.URL)
+	}
+	getters, err := chartRepoGetters(pull.Settings, cred)
+	if err != nil {
+		return "", err
+	}
+	getterOpts := []getter.Option{
+		getter.WithInsecureSkipVerifyTLS(config.CommonOptions.InsecureSkipTLSVerify),
+	}
+
 	// Handle OCI registries
 	if registry.IsOCI(h.chart.URL) {
-		regClient, err = registry.NewClient(registry.ClientOptEnableCache(true))
+		if hasCred {
+			regClient, err = chartRepoRegistryClient(cred, dir)
+		} else {
+			regClient, err = registry.NewClient(registry.ClientOptEnableCache(true))
+		}
 		if err != nil {
-			return fmt.Errorf("unable to create the new registry client: %w", err)
+			return "", fmt.Errorf("unable to create the new registry client: %w", err)
 		}
 		chartURL = h.chart.URL
 		// Explicitly set the pull version for OCI
@@ -221,62 +307,37 @@ func (h *Helm) DownloadPublishedChart(ctx context.Context, cosignKeyPath string)
 			}
 		}
 
-		chartURL, err = repo.FindChartInAuthRepoURL(h.chart.URL, username, password, chartName, h.chart.Version, pull.CertFile, pull.KeyFile, pull.CaFile, getter.All(pull.Settings))
+		certFile, keyFile, caFile := pull.CertFile, pull.KeyFile, pull.CaFile
+		if hasCred {
+			username, password = cred.Username, cred.Password
+			certFile, keyFile, caFile = cred.CertFile, cred.KeyFile, cred.CAFile
+		}
+
+		chartURL, err = repo.FindChartInAuthRepoURL(h.chart.URL, username, password, chartName, h.chart.Version, certFile, keyFile, caFile, getters)
 		if err != nil {
-			return fmt.Errorf("unable to pull the helm chart: %w", err)
+			return "", fmt.Errorf("unable to pull the helm chart: %w", err)
 		}
+		getterOpts = append(getterOpts,
+			getter.WithBasicAuth(username, password),
+			getter.WithTLSClientConfig(certFile, keyFile, caFile),
+		)
 	}
 
 	// Set up the chart chartDownloader
 	chartDownloader := downloader.ChartDownloader{
-		Out:            spinner,
+		Out:            out,
 		RegistryClient: regClient,
 		// TODO: Further research this with regular/OCI charts
 		Verify:  downloader.VerifyNever,
-		Getters: getter.All(pull.Settings),
-		Options: []getter.Option{
-			getter.WithInsecureSkipVerifyTLS(config.CommonOptions.InsecureSkipTLSVerify),
-			getter.WithBasicAuth(username, password),
-		},
-	}
-
-	// Download the file into a temp directory since we don't control what name helm creates here
-	temp := filepath.Join(h.chartPath, "temp")
-	if err = helpers.CreateDirectory(temp, helpers.ReadWriteExecuteUser); err != nil {
-		return fmt.Errorf("unable to create helm chart temp directory: %w", err)
-	}
-	defer func(l *slog.Logger) {
-		err := os.RemoveAll(temp)
-		if err != nil {
-			l.Error(err.Error())
-		}
-	}(l)
-
-	saved, _, err := chartDownloader.DownloadTo(chartURL, pull.Version, temp)
-	if err != nil {
-		return fmt.Errorf("unable to download the helm chart: %w", err)
+		Getters: getters,
+		Options: getterOpts,
 	}
 
-	// Validate the chart
-	_, _, err = h.loadAndValidateChart(saved)
+	saved, _, err := chartDownloader.DownloadTo(chartURL, pull.Version, dir)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("unable to download the helm chart: %w", err)
 	}
-
-	// Finalize the chart
-	err = h.finalizeChartPackage(ctx, saved, cosignKeyPath)
-	if err != nil {
-		return err
-	}
-
-	spinner.Success()
-	l.Debug("done downloading helm chart",
-		"name", h.chart.Name,
-		"version", h.chart.Version,
-		"repo", h.chart.URL,
-		"duration", time.Since(start),
-	)
-	return nil
+	return saved, nil
 }
 
 // DownloadChartFromGitToTemp downloads a chart from git into a temp directory
@@ -308,7 +369,7 @@ func (h *Helm) finalizeChartPackage(ctx context.Context, saved, cosignKeyPath st
 }
 
 func (h *Helm) packageValues(ctx context.Context, cosignKeyPath string) error {
-	for valuesIdx, path := range h.chart.ValuesFiles {
+	for valuesIdx, path := range h.chart.PackagedValuesFiles() {
 		dst := StandardValuesName(h.valuesPath, h.chart, valuesIdx)
 
 		if helpers.IsURL(path) {
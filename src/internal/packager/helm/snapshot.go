@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package helm contains operations for working with helm charts.
+package helm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/variables"
+)
+
+// SnapshotName generates a predictable full path for the rendered manifests snapshot of a helm chart for zarf
+func SnapshotName(destination string, chart v1alpha1.ZarfChart) string {
+	return StandardName(destination, chart) + ".yaml"
+}
+
+// RenderSnapshot renders the packaged chart without a cluster, deploy options or deploy time values so that the
+// manifests only depend on the chart, its packaged values files and the given variable config.
+// Templates of variables that are not set in the variable config are left in the manifests.
+func RenderSnapshot(ctx context.Context, chart v1alpha1.ZarfChart, chartPath, valuesPath string, variableConfig *variables.VariableConfig) (string, error) {
+	helmCfg := New(chart, chartPath, valuesPath, WithVariableConfig(variableConfig))
+	manifest, _, err := helmCfg.TemplateChart(ctx)
+	if err != nil {
+		return "", err
+	}
+	return manifest, nil
+}
+
+// CompareSnapshot returns an error with the first line that differs when the rendered manifests do not match the snapshot.
+func CompareSnapshot(snapshot, manifest string) error {
+	if snapshot == manifest {
+		return nil
+	}
+	snapshotLines := strings.Split(snapshot, "\n")
+	manifestLines := strings.Split(manifest, "\n")
+	for i := range max(len(snapshotLines), len(manifestLines)) {
+		var expected, actual string
+		if i < len(snapshotLines) {
+			expected = snapshotLines[i]
+		}
+		if i < len(manifestLines) {
+			actual = manifestLines[i]
+		}
+		if expected != actual {
+			return fmt.Errorf("line %d differs, expected %q but got %q", i+1, expected, actual)
+		}
+	}
+	return fmt.Errorf("manifests differ")
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		snapshot    string
+		manifest    string
+		expectedErr string
+	}{
+		{
+			name:     "same",
+			snapshot: "---\nkind: ConfigMap\n",
+			manifest: "---\nkind: ConfigMap\n",
+		},
+		{
+			name:        "changed line",
+			snapshot:    "---\nkind: ConfigMap\n",
+			manifest:    "---\nkind: Secret\n",
+			expectedErr: `line 2 differs, expected "kind: ConfigMap" but got "kind: Secret"`,
+		},
+		{
+			name:        "added lines",
+			snapshot:    "---\nkind: ConfigMap",
+			manifest:    "---\nkind: ConfigMap\n---\nkind: Secret",
+			expectedErr: `line 3 differs, expected "" but got "---"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CompareSnapshot(tt.snapshot, tt.manifest)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
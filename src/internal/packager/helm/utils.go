@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -49,6 +50,23 @@ func (h *Helm) parseChartValues() (chartutil.Values, error) {
 		valueOpts.ValueFiles = append(valueOpts.ValueFiles, path)
 	}
 
+	// Values layers are packaged after the values files and only apply when they have no profile or their profile was selected.
+	profiles := []string{}
+	if h.cfg != nil {
+		profiles = h.cfg.DeployOpts.ValuesProfiles
+	}
+	idx := len(h.chart.ValuesFiles)
+	for _, layer := range h.chart.ValuesLayers {
+		selected := layer.Profile == "" || slices.Contains(profiles, layer.Profile)
+		for range layer.Files {
+			if selected {
+				path := StandardValuesName(h.valuesPath, h.chart, idx)
+				valueOpts.ValueFiles = append(valueOpts.ValueFiles, path)
+			}
+			idx++
+		}
+	}
+
 	httpProvider := getter.Provider{
 		Schemes: []string{"http", "https"},
 		New:     getter.NewHTTPGetter,
@@ -68,6 +86,9 @@ func (h *Helm) createActionConfig(ctx context.Context, namespace string, spinner
 	actionConfig := new(action.Configuration)
 	// Set the settings for the helm SDK
 	h.settings = cli.New()
+	if h.cluster != nil {
+		h.settings.KubeContext = h.cluster.KubeContext
+	}
 
 	// Set the namespace for helm
 	h.settings.SetNamespace(namespace)
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package helm contains operations for working with helm charts.
+package helm
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const helmModule = "helm.sh/helm/v3"
+
+// EngineVersion returns the version of the Helm library built into Zarf, it is empty when the version is not known.
+func EngineVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path != helmModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return ""
+}
+
+// ValidateEngineVersion returns an error when the Helm engine version is older than the minimum Helm version.
+// Engine versions that are not known are not validated.
+func ValidateEngineVersion(engineVersion, minimumVersion string) error {
+	if minimumVersion == "" {
+		return nil
+	}
+	minimum, err := semver.NewVersion(minimumVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum helm version %s: %w", minimumVersion, err)
+	}
+	engine, err := semver.NewVersion(engineVersion)
+	if err != nil {
+		return nil
+	}
+	if engine.LessThan(minimum) {
+		return fmt.Errorf("the package requires helm %s or newer but this Zarf is built with helm %s", minimumVersion, engineVersion)
+	}
+	return nil
+}
+
+// EngineVersionDiffers returns true when the Helm engine version has a different major or minor version than the Helm
+// version a package was created with, as charts can render differently across minor versions of Helm.
+// Versions that are not known do not differ.
+func EngineVersionDiffers(engineVersion, createdVersion string) bool {
+	engine, err := semver.NewVersion(engineVersion)
+	if err != nil {
+		return false
+	}
+	created, err := semver.NewVersion(createdVersion)
+	if err != nil {
+		return false
+	}
+	return engine.Major() != created.Major() || engine.Minor() != created.Minor()
+}
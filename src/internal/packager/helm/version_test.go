@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEngineVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		engineVersion  string
+		minimumVersion string
+		expectedErr    string
+	}{
+		{name: "no minimum", engineVersion: "v3.16.2"},
+		{name: "newer engine", engineVersion: "v3.16.2", minimumVersion: "3.15.0"},
+		{name: "same engine", engineVersion: "v3.16.2", minimumVersion: "3.16.2"},
+		{name: "unknown engine", engineVersion: "", minimumVersion: "3.16.0"},
+		{name: "older engine", engineVersion: "v3.14.4", minimumVersion: "3.16.0", expectedErr: "the package requires helm 3.16.0 or newer but this Zarf is built with helm v3.14.4"},
+		{name: "invalid minimum", engineVersion: "v3.16.2", minimumVersion: "latest", expectedErr: "invalid minimum helm version latest: Invalid Semantic Version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateEngineVersion(tt.engineVersion, tt.minimumVersion)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEngineVersionDiffers(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, EngineVersionDiffers("v3.16.2", "v3.16.0"))
+	require.False(t, EngineVersionDiffers("v3.16.2", ""))
+	require.False(t, EngineVersionDiffers("", "v3.16.2"))
+	require.True(t, EngineVersionDiffers("v3.16.2", "v3.15.4"))
+	require.True(t, EngineVersionDiffers("v4.0.0", "v3.16.2"))
+}
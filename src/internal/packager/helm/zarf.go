@@ -35,9 +35,18 @@ func (h *Helm) UpdateZarfRegistryValues(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error generating htpasswd string: %w", err)
 	}
+	htpasswd := fmt.Sprintf("%s\n%s", pushUser, pullUser)
+	// Keep the users of the scoped credentials minted for packages.
+	scopedUsers, err := h.cluster.ScopedRegistryHtpasswd(ctx)
+	if err != nil {
+		return err
+	}
+	for _, scopedUser := range scopedUsers {
+		htpasswd += "\n" + scopedUser
+	}
 	registryValues := map[string]interface{}{
 		"secrets": map[string]interface{}{
-			"htpasswd": fmt.Sprintf("%s\n%s", pushUser, pullUser),
+			"htpasswd": htpasswd,
 		},
 	}
 	h.chart = v1alpha1.ZarfChart{
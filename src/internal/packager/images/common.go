@@ -6,6 +6,7 @@ package images
 
 import (
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/crane"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -28,6 +30,11 @@ type PullConfig struct {
 	RegistryOverrides map[string]string
 
 	CacheDirectory string
+
+	FlattenImages []string
+
+	// RegistryPresets authenticate the pulls of images from their registries.
+	RegistryPresets []types.RegistryPreset
 }
 
 // PushConfig is the configuration for pushing images.
@@ -43,6 +50,13 @@ type PushConfig struct {
 	Arch string
 
 	Retries int
+
+	// Cluster is the cluster of the registry, the cluster of the current kube context is used when it is nil.
+	Cluster *cluster.Cluster
+
+	// Tunnels is the tunnel pool to reach the registry through, which keeps the tunnel open after the push. A tunnel is
+	// opened for the push and closed after it when it is nil.
+	Tunnels *cluster.TunnelPool
 }
 
 // NoopOpt is a no-op option for crane.
@@ -97,6 +111,27 @@ func WithPushAuth(ri types.RegistryInfo) crane.Option {
 	return WithBasicAuth(ri.PushUsername, ri.PushPassword)
 }
 
+// WithRegistryPresetAuth returns an option for crane that sets the auth of a registry preset.
+func WithRegistryPresetAuth(preset types.RegistryPreset) crane.Option {
+	switch preset.Auth {
+	case types.RegistryPresetAuthBasic:
+		return WithBasicAuth(preset.Username, preset.Password)
+	case types.RegistryPresetAuthAnonymous:
+		return crane.WithAuth(authn.Anonymous)
+	default:
+		return crane.WithAuthFromKeychain(authn.DefaultKeychain)
+	}
+}
+
+// withRegistryPreset returns the options with the auth of the registry preset the image reference is from, if any.
+func withRegistryPreset(opts []crane.Option, presets []types.RegistryPreset, ref string) []crane.Option {
+	preset, ok := types.MatchRegistryPreset(presets, ref)
+	if !ok {
+		return opts
+	}
+	return append(slices.Clone(opts), WithRegistryPresetAuth(preset))
+}
+
 func createPushOpts(cfg PushConfig) []crane.Option {
 	opts := CommonOpts(cfg.Arch)
 	opts = append(opts, WithPushAuth(cfg.RegInfo))
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// CopyRegistryConfig is the configuration for copying the images of one registry to another.
+type CopyRegistryConfig struct {
+	// SourceURL is the address the source registry is reached at, which can differ from its address in SourceInfo when it is reached through a tunnel.
+	SourceURL string
+	// SourceInfo holds the pull credentials of the source registry.
+	SourceInfo types.RegistryInfo
+	// DestinationInfo holds the address and push credentials of the destination registry.
+	DestinationInfo types.RegistryInfo
+	// References are untagged images, relative to the registry, that are copied in addition to every tag in the catalog of the source.
+	References []string
+}
+
+// CopyRegistry copies every tagged image in the catalog of the source registry, and the additional references, to the destination registry.
+// Blobs that already exist in the destination are not copied again, so an interrupted copy resumes from the images it completed.
+// It returns the references that were copied.
+func CopyRegistry(ctx context.Context, cfg CopyRegistryConfig) ([]string, error) {
+	l := logger.From(ctx)
+	srcOpts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithUserAgent("zarf"),
+		remote.WithAuth(&authn.Basic{Username: cfg.SourceInfo.PullUsername, Password: cfg.SourceInfo.PullPassword}),
+	}
+	dstOpts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithUserAgent("zarf"),
+		remote.WithAuth(&authn.Basic{Username: cfg.DestinationInfo.PushUsername, Password: cfg.DestinationInfo.PushPassword}),
+	}
+	if config.CommonOptions.InsecureSkipTLSVerify {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // only set when --insecure-skip-tls-verify is used
+		dstOpts = append(dstOpts, remote.WithTransport(transport))
+	}
+	dstNameOpts := []name.Option{}
+	if config.CommonOptions.PlainHTTP {
+		dstNameOpts = append(dstNameOpts, name.Insecure)
+	}
+
+	refs, err := catalogReferences(ctx, cfg.SourceURL, srcOpts)
+	if err != nil {
+		return nil, err
+	}
+	refs = helpers.Unique(append(refs, cfg.References...))
+	slices.Sort(refs)
+
+	spinner := message.NewProgressSpinner("Copying %d images to %s", len(refs), cfg.DestinationInfo.Address)
+	defer spinner.Stop()
+	l.Info("copying images", "count", len(refs), "destination", cfg.DestinationInfo.Address)
+	for i, ref := range refs {
+		spinner.Updatef("Copying image (%d of %d): %s", i+1, len(refs), ref)
+		l.Debug("copying image", "reference", ref)
+		src, err := name.ParseReference(fmt.Sprintf("%s/%s", cfg.SourceURL, ref))
+		if err != nil {
+			return nil, err
+		}
+		dst, err := name.ParseReference(fmt.Sprintf("%s/%s", cfg.DestinationInfo.Address, ref), dstNameOpts...)
+		if err != nil {
+			return nil, err
+		}
+		desc, err := remote.Get(src, srcOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get %s: %w", ref, err)
+		}
+		if desc.MediaType.IsIndex() {
+			idx, err := desc.ImageIndex()
+			if err != nil {
+				return nil, err
+			}
+			err = remote.WriteIndex(dst, idx, dstOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to copy %s: %w", ref, err)
+			}
+			continue
+		}
+		img, err := desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		err = remote.Write(dst, img, dstOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy %s: %w", ref, err)
+		}
+	}
+	spinner.Successf("Copied %d images to %s", len(refs), cfg.DestinationInfo.Address)
+	return refs, nil
+}
+
+// catalogReferences returns every tagged image in the catalog of the registry, relative to the registry.
+func catalogReferences(ctx context.Context, registryURL string, opts []remote.Option) ([]string, error) {
+	reg, err := name.NewRegistry(registryURL)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := remote.Catalog(ctx, reg, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the repositories of %s: %w", registryURL, err)
+	}
+	refs := []string{}
+	for _, repo := range repos {
+		repository, err := name.NewRepository(fmt.Sprintf("%s/%s", registryURL, repo))
+		if err != nil {
+			return nil, err
+		}
+		tags, err := remote.List(repository, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the tags of %s: %w", repo, err)
+		}
+		for _, tag := range tags {
+			refs = append(refs, fmt.Sprintf("%s:%s", repo, tag))
+		}
+	}
+	return refs, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestCopyRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	srcServer := httptest.NewServer(registry.New())
+	t.Cleanup(srcServer.Close)
+	dstServer := httptest.NewServer(registry.New())
+	t.Cleanup(dstServer.Close)
+	srcURL := strings.TrimPrefix(srcServer.URL, "http://")
+	dstURL := strings.TrimPrefix(dstServer.URL, "http://")
+
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	ref, err := name.ParseReference(fmt.Sprintf("%s/library/nginx:1.27", srcURL))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	idx, err := random.Index(512, 1, 2)
+	require.NoError(t, err)
+	ref, err = name.ParseReference(fmt.Sprintf("%s/stefanprodan/podinfo:6.4.0", srcURL))
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(ref, idx))
+	untagged, err := random.Image(512, 1)
+	require.NoError(t, err)
+	untaggedDigest, err := untagged.Digest()
+	require.NoError(t, err)
+	untaggedRef := fmt.Sprintf("library/busybox@%s", untaggedDigest)
+	ref, err = name.ParseReference(fmt.Sprintf("%s/%s", srcURL, untaggedRef))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, untagged))
+
+	cfg := CopyRegistryConfig{
+		SourceURL:       srcURL,
+		DestinationInfo: types.RegistryInfo{Address: dstURL},
+		References:      []string{untaggedRef},
+	}
+	copied, err := CopyRegistry(ctx, cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{untaggedRef, "library/nginx:1.27", "stefanprodan/podinfo:6.4.0"}, copied)
+
+	for _, ref := range copied {
+		srcRef, err := name.ParseReference(fmt.Sprintf("%s/%s", srcURL, ref))
+		require.NoError(t, err)
+		dstRef, err := name.ParseReference(fmt.Sprintf("%s/%s", dstURL, ref))
+		require.NoError(t, err)
+		srcDesc, err := remote.Get(srcRef)
+		require.NoError(t, err)
+		dstDesc, err := remote.Get(dstRef)
+		require.NoError(t, err)
+		require.Equal(t, srcDesc.Digest, dstDesc.Digest)
+	}
+
+	// Copying again succeeds without changing the destination, so an interrupted copy can be resumed.
+	copied, err = CopyRegistry(ctx, cfg)
+	require.NoError(t, err)
+	require.Len(t, copied, 3)
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+// LayerReport summarizes how image layers are shared across the images in a package.
+type LayerReport struct {
+	// The number of image references in the package.
+	Images int
+	// The number of distinct image manifests in the package.
+	UniqueImages int
+	// The number of layers referenced by all images, counting shared layers once per image.
+	Layers int
+	// The number of distinct layers referenced by all images.
+	UniqueLayers int
+	// The size of all layers, counting shared layers once per image.
+	TotalBytes int64
+	// The size of all distinct layers.
+	UniqueBytes int64
+}
+
+// SavedBytes returns the number of bytes saved by storing shared layers only once.
+func (r LayerReport) SavedBytes() int64 {
+	return r.TotalBytes - r.UniqueBytes
+}
+
+// NewLayerReport computes the layer sharing between the given images.
+func NewLayerReport(imgs map[transform.Image]v1.Image) (LayerReport, error) {
+	report := LayerReport{
+		Images: len(imgs),
+	}
+	manifests := map[v1.Hash]bool{}
+	layers := map[v1.Hash]bool{}
+	for info, img := range imgs {
+		digest, err := img.Digest()
+		if err != nil {
+			return LayerReport{}, fmt.Errorf("unable to get digest for %s: %w", info.Reference, err)
+		}
+		manifests[digest] = true
+		imgLayers, err := img.Layers()
+		if err != nil {
+			return LayerReport{}, fmt.Errorf("unable to get layers for %s: %w", info.Reference, err)
+		}
+		for _, layer := range imgLayers {
+			layerDigest, err := layer.Digest()
+			if err != nil {
+				return LayerReport{}, fmt.Errorf("unable to get digest for image layer: %w", err)
+			}
+			size, err := layer.Size()
+			if err != nil {
+				return LayerReport{}, fmt.Errorf("unable to get size for image layer: %w", err)
+			}
+			report.Layers++
+			report.TotalBytes += size
+			if layers[layerDigest] {
+				continue
+			}
+			layers[layerDigest] = true
+			report.UniqueLayers++
+			report.UniqueBytes += size
+		}
+	}
+	report.UniqueImages = len(manifests)
+	return report, nil
+}
+
+// groupByDigest groups image references that resolve to the same manifest digest so that
+// the image content only needs to be processed once. The groups are returned in a stable order.
+func groupByDigest(imgs map[transform.Image]v1.Image) ([][]transform.Image, error) {
+	groups := map[v1.Hash][]transform.Image{}
+	for info, img := range imgs {
+		digest, err := img.Digest()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get digest for %s: %w", info.Reference, err)
+		}
+		groups[digest] = append(groups[digest], info)
+	}
+	out := [][]transform.Image{}
+	for _, infos := range groups {
+		sort.Slice(infos, func(i, j int) bool {
+			return infos[i].Reference < infos[j].Reference
+		})
+		out = append(out, infos)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i][0].Reference < out[j][0].Reference
+	})
+	return out, nil
+}
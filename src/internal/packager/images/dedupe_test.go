@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+func TestLayerReport(t *testing.T) {
+	t.Parallel()
+
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	other, err := random.Image(512, 1)
+	require.NoError(t, err)
+
+	first, err := transform.ParseImageRef("docker.io/library/first:1.0.0")
+	require.NoError(t, err)
+	second, err := transform.ParseImageRef("docker.io/library/second:1.0.0")
+	require.NoError(t, err)
+	third, err := transform.ParseImageRef("docker.io/library/third:1.0.0")
+	require.NoError(t, err)
+
+	imgs := map[transform.Image]v1.Image{
+		first:  img,
+		second: img,
+		third:  other,
+	}
+	report, err := NewLayerReport(imgs)
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Images)
+	require.Equal(t, 2, report.UniqueImages)
+	require.Equal(t, 5, report.Layers)
+	require.Equal(t, 3, report.UniqueLayers)
+	require.Equal(t, report.TotalBytes-report.UniqueBytes, report.SavedBytes())
+	require.Positive(t, report.SavedBytes())
+
+	groups, err := groupByDigest(imgs)
+	require.NoError(t, err)
+	require.Equal(t, [][]transform.Image{{first, second}, {third}}, groups)
+}
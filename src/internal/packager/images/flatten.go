@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// flattenHistory is the history entry recorded on flattened images.
+const flattenHistory = "zarf package create --flatten-image"
+
+// shouldFlatten returns whether the given image was requested to be flattened.
+func shouldFlatten(refInfo transform.Image, flattenImages []string) (bool, error) {
+	for _, src := range flattenImages {
+		flattenRef, err := transform.ParseImageRef(src)
+		if err != nil {
+			return false, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+		}
+		if flattenRef.Reference != refInfo.Reference {
+			continue
+		}
+		if refInfo.Digest != "" {
+			return false, fmt.Errorf("unable to flatten %s as flattening changes the image digest, use a tag reference instead", refInfo.Reference)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// flattenImage squashes all the layers of an image into a single layer and strips its build history.
+// The layer contents are staged in tmpDir which must exist until the image has been written.
+func flattenImage(img v1.Image, tmpDir string) (v1.Image, error) {
+	ok, err := utils.OnlyHasImageLayers(img)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("only container images can be flattened")
+	}
+
+	f, err := os.CreateTemp(tmpDir, "flatten-*.tar")
+	if err != nil {
+		return nil, err
+	}
+	rc := mutate.Extract(img)
+	_, err = io.Copy(f, rc)
+	err = errors.Join(err, rc.Close(), f.Close())
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract the image filesystem: %w", err)
+	}
+
+	mediaType, err := img.MediaType()
+	if err != nil {
+		return nil, err
+	}
+	layerMediaType := types.DockerLayer
+	configMediaType := types.DockerConfigJSON
+	if mediaType == types.OCIManifestSchema1 {
+		layerMediaType = types.OCILayer
+		configMediaType = types.OCIConfigJSON
+	}
+	layer, err := tarball.LayerFromFile(f.Name(), tarball.WithMediaType(layerMediaType))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	cfg = cfg.DeepCopy()
+	cfg.RootFS.DiffIDs = nil
+	cfg.History = nil
+
+	base := mutate.MediaType(empty.Image, mediaType)
+	base = mutate.ConfigMediaType(base, configMediaType)
+	base, err = mutate.ConfigFile(base, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return mutate.Append(base, mutate.Addendum{
+		Layer: layer,
+		History: v1.History{
+			Created:   cfg.Created,
+			CreatedBy: flattenHistory,
+			Comment:   "flattened by zarf",
+		},
+	})
+}
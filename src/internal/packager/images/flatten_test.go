@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"archive/tar"
+	"io"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+func TestShouldFlatten(t *testing.T) {
+	t.Parallel()
+
+	tagRef, err := transform.ParseImageRef("ghcr.io/zarf-dev/zarf/agent:v0.32.6")
+	require.NoError(t, err)
+	digestRef, err := transform.ParseImageRef("ghcr.io/zarf-dev/zarf/agent:v0.32.6@sha256:b3fabdc7d4ecd0f396016ef78da19002c39e3ace352ea0ae4baa2ce9d5958376")
+	require.NoError(t, err)
+
+	ok, err := shouldFlatten(tagRef, nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = shouldFlatten(tagRef, []string{"ghcr.io/zarf-dev/zarf/agent:v0.32.6"})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = shouldFlatten(digestRef, []string{digestRef.Reference})
+	require.ErrorContains(t, err, "flattening changes the image digest")
+}
+
+func TestFlattenImage(t *testing.T) {
+	t.Parallel()
+
+	first, err := crane.Layer(map[string][]byte{"first.txt": []byte("first")})
+	require.NoError(t, err)
+	second, err := crane.Layer(map[string][]byte{"second.txt": []byte("second")})
+	require.NoError(t, err)
+	img, err := mutate.AppendLayers(empty.Image, first, second)
+	require.NoError(t, err)
+
+	flat, err := flattenImage(img, t.TempDir())
+	require.NoError(t, err)
+
+	layers, err := flat.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+
+	cfg, err := flat.ConfigFile()
+	require.NoError(t, err)
+	require.Len(t, cfg.RootFS.DiffIDs, 1)
+	require.Len(t, cfg.History, 1)
+	require.Equal(t, flattenHistory, cfg.History[0].CreatedBy)
+
+	imgDigest, err := img.Digest()
+	require.NoError(t, err)
+	flatDigest, err := flat.Digest()
+	require.NoError(t, err)
+	require.NotEqual(t, imgDigest, flatDigest)
+
+	rc, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+	defer rc.Close()
+	files := []string{}
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files = append(files, hdr.Name)
+	}
+	require.ElementsMatch(t, []string{"first.txt", "second.txt"}, files)
+}
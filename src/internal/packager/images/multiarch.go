@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+)
+
+// MergeArchitectures merges the image layouts pulled for each architecture into a single image layout at dst. The
+// index entries keep their image name annotations and gain the platform of their architecture, so the same reference
+// can be loaded for each architecture. Blobs shared between architectures are stored once.
+func MergeArchitectures(dst string, srcs map[string]string) error {
+	dstPath, err := clayout.Write(dst, empty.Index)
+	if err != nil {
+		return err
+	}
+	for _, arch := range slices.Sorted(maps.Keys(srcs)) {
+		srcPath, err := clayout.FromPath(srcs[arch])
+		if err != nil {
+			return fmt.Errorf("unable to read the %s images: %w", arch, err)
+		}
+		idx, err := srcPath.ImageIndex()
+		if err != nil {
+			return err
+		}
+		idxManifest, err := idx.IndexManifest()
+		if err != nil {
+			return err
+		}
+		for _, desc := range idxManifest.Manifests {
+			img, err := idx.Image(desc.Digest)
+			if err != nil {
+				return fmt.Errorf("unable to load the %s image %s: %w", arch, desc.Digest, err)
+			}
+			if err := dstPath.WriteImage(img); err != nil {
+				return fmt.Errorf("unable to write the %s image %s: %w", arch, desc.Digest, err)
+			}
+			desc.Platform = &v1.Platform{OS: "linux", Architecture: arch}
+			if err := dstPath.AppendDescriptor(desc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
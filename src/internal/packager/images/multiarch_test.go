@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+func TestMergeArchitectures(t *testing.T) {
+	t.Parallel()
+
+	ref, err := transform.ParseImageRef("docker.io/library/nginx:1.27")
+	require.NoError(t, err)
+	shared, err := random.Image(512, 1)
+	require.NoError(t, err)
+	annotations := clayout.WithAnnotations(map[string]string{ocispec.AnnotationBaseImageName: ref.Reference})
+
+	srcs := map[string]string{}
+	digests := map[string]v1.Hash{}
+	for _, arch := range []string{"amd64", "arm64"} {
+		img, err := random.Image(1024, 2)
+		require.NoError(t, err)
+		srcs[arch] = t.TempDir()
+		srcPath, err := clayout.Write(srcs[arch], empty.Index)
+		require.NoError(t, err)
+		require.NoError(t, srcPath.AppendImage(img, annotations))
+		require.NoError(t, srcPath.AppendImage(shared))
+		digests[arch], err = img.Digest()
+		require.NoError(t, err)
+	}
+
+	dst := t.TempDir()
+	err = MergeArchitectures(dst, srcs)
+	require.NoError(t, err)
+
+	dstPath, err := clayout.FromPath(dst)
+	require.NoError(t, err)
+	idx, err := dstPath.ImageIndex()
+	require.NoError(t, err)
+	idxManifest, err := idx.IndexManifest()
+	require.NoError(t, err)
+	require.Len(t, idxManifest.Manifests, 4)
+	for _, desc := range idxManifest.Manifests {
+		require.NotNil(t, desc.Platform)
+		require.Equal(t, "linux", desc.Platform.OS)
+	}
+
+	for arch, digest := range digests {
+		img, err := utils.LoadOCIImageForArch(dst, ref, arch)
+		require.NoError(t, err)
+		actual, err := img.Digest()
+		require.NoError(t, err)
+		require.Equal(t, digest, actual)
+	}
+	_, err = utils.LoadOCIImageForArch(dst, ref, "s390x")
+	require.EqualError(t, err, "unable to find image (docker.io/library/nginx:1.27) for architecture s390x at the path ("+dst+")")
+}
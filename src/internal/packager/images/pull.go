@@ -19,6 +19,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"github.com/avast/retry-go/v4"
@@ -95,6 +96,15 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	logs.Warn.SetOutput(&message.DebugWriter{})
 	logs.Progress.SetOutput(&message.DebugWriter{})
 
+	flattenDir := ""
+	if len(cfg.FlattenImages) > 0 {
+		flattenDir, err = utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(flattenDir)
+	}
+
 	eg, ectx := errgroup.WithContext(ctx)
 	eg.SetLimit(10)
 
@@ -122,12 +132,14 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 				}
 			}
 
+			imgOpts := withRegistryPreset(opts, cfg.RegistryPresets, ref)
+
 			var img v1.Image
 			var desc *remote.Descriptor
 
 			// load from local fs if it's a tarball
 			if strings.HasSuffix(ref, ".tar") || strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz") {
-				img, err = crane.Load(ref, opts...)
+				img, err = crane.Load(ref, imgOpts...)
 				if err != nil {
 					return fmt.Errorf("unable to load %s: %w", refInfo.Reference, err)
 				}
@@ -136,7 +148,7 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 				if err != nil {
 					return fmt.Errorf("failed to parse reference: %w", err)
 				}
-				desc, err = crane.Get(ref, opts...)
+				desc, err = crane.Get(ref, imgOpts...)
 				if err != nil {
 					if strings.Contains(err.Error(), "unexpected status code 429 Too Many Requests") {
 						return fmt.Errorf("rate limited by registry: %w", err)
@@ -173,7 +185,7 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 						return fmt.Errorf("failed to load from docker daemon: %w", err)
 					}
 				} else {
-					img, err = crane.Pull(ref, opts...)
+					img, err = crane.Pull(ref, imgOpts...)
 					if err != nil {
 						return fmt.Errorf("unable to pull image %s: %w", refInfo.Reference, err)
 					}
@@ -192,6 +204,20 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 				img = cache.Image(img, cache.NewFilesystemCache(cfg.CacheDirectory))
 			}
 
+			flatten, err := shouldFlatten(refInfo, cfg.FlattenImages)
+			if err != nil {
+				return err
+			}
+			if flatten {
+				// TODO(mkcp): Remove message on logger release
+				message.Warnf("Flattening %s into a single layer, the image digest will change and layers will no longer be shared with other images", refInfo.Reference)
+				l.Warn("flattening image into a single layer, the image digest will change and layers will no longer be shared with other images", "name", refInfo.Reference)
+				img, err = flattenImage(img, flattenDir)
+				if err != nil {
+					return fmt.Errorf("unable to flatten %s: %w", refInfo.Reference, err)
+				}
+			}
+
 			manifest, err := img.Manifest()
 			if err != nil {
 				return fmt.Errorf("unable to get manifest for %s: %w", refInfo.Reference, err)
@@ -240,6 +266,23 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	spinner.Successf("Fetched info for %d images", imageCount)
 	l.Debug("done fetching info for images", "count", len(cfg.ImageList), "duration", time.Since(imageFetchStart))
 
+	report, err := NewLayerReport(fetched)
+	if err != nil {
+		return nil, err
+	}
+	if report.SavedBytes() > 0 || report.UniqueImages < report.Images {
+		// TODO(mkcp): Remove message on logger release
+		message.Infof("Deduplicated %d images into %d unique manifests and %d unique layers, saving %s",
+			report.Images, report.UniqueImages, report.UniqueLayers, utils.ByteFormat(float64(report.SavedBytes()), 2))
+		l.Info("deduplicated image layers",
+			"images", report.Images,
+			"uniqueImages", report.UniqueImages,
+			"layers", report.Layers,
+			"uniqueLayers", report.UniqueLayers,
+			"savedBytes", report.SavedBytes(),
+		)
+	}
+
 	doneSaving := make(chan error)
 	updateText := fmt.Sprintf("Pulling %d images", imageCount)
 	// TODO(mkcp): Remove progress bar on logger release
@@ -400,11 +443,17 @@ func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]
 
 	var mu sync.Mutex
 
+	// Images that share a manifest digest are written once and then indexed under each reference.
+	groups, err := groupByDigest(m)
+	if err != nil {
+		return saved, err
+	}
+
 	eg, ectx := errgroup.WithContext(ctx)
 	eg.SetLimit(10)
 
-	for info, img := range m {
-		info, img := info, img
+	for _, infos := range groups {
+		info, img := infos[0], m[infos[0]]
 		eg.Go(func() error {
 			select {
 			case <-ectx.Done():
@@ -437,15 +486,16 @@ func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]
 
 				mu.Lock()
 				defer mu.Unlock()
-				annotations := map[string]string{
-					ocispec.AnnotationBaseImageName: info.Reference,
-				}
-				desc.Annotations = annotations
-				if err := cl.AppendDescriptor(*desc); err != nil {
-					return err
+				for _, info := range infos {
+					refDesc := *desc
+					refDesc.Annotations = map[string]string{
+						ocispec.AnnotationBaseImageName: info.Reference,
+					}
+					if err := cl.AppendDescriptor(refDesc); err != nil {
+						return err
+					}
+					saved[info] = m[info]
 				}
-
-				saved[info] = img
 				return nil
 			}
 		})
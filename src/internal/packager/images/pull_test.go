@@ -9,15 +9,23 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 func TestCheckForIndex(t *testing.T) {
@@ -196,3 +204,43 @@ func TestPull(t *testing.T) {
 		require.Equal(t, correctLayerSha, fmt.Sprintf("%x", pulledLayerSha))
 	})
 }
+
+func TestWithRegistryPreset(t *testing.T) {
+	t.Parallel()
+
+	handler := registry.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "zarf" || password != "hardened" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	url := strings.TrimPrefix(srv.URL, "http://")
+
+	img, err := random.Image(512, 1)
+	require.NoError(t, err)
+	imgRef := fmt.Sprintf("%s/ironbank/opensource/nginx/nginx:1.27", url)
+	ref, err := name.ParseReference(imgRef)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img, remote.WithAuth(&authn.Basic{Username: "zarf", Password: "hardened"})))
+
+	presets := []types.RegistryPreset{
+		{Name: "other", URL: "registry1.dso.mil", Auth: types.RegistryPresetAuthAnonymous},
+		{Name: "ironbank", URL: url + "/ironbank", Auth: types.RegistryPresetAuthBasic, Username: "zarf", Password: "hardened"},
+	}
+	opts := []crane.Option{crane.WithUserAgent("zarf")}
+
+	require.Len(t, withRegistryPreset(opts, presets, "docker.io/library/nginx:1.27"), 1)
+	_, err = crane.Get(imgRef, opts...)
+	require.Error(t, err)
+
+	presetOpts := withRegistryPreset(opts, presets, imgRef)
+	require.Len(t, presetOpts, 2)
+	require.Len(t, opts, 1)
+	_, err = crane.Get(imgRef, presetOpts...)
+	require.NoError(t, err)
+}
@@ -26,21 +26,40 @@ func Push(ctx context.Context, cfg PushConfig) error {
 	toPush := map[transform.Image]v1.Image{}
 	// Build an image list from the references
 	for _, refInfo := range cfg.ImageList {
-		img, err := utils.LoadOCIImage(cfg.SourceDirectory, refInfo)
+		img, err := utils.LoadOCIImageForArch(cfg.SourceDirectory, refInfo, cfg.Arch)
 		if err != nil {
 			return err
 		}
 		toPush[refInfo] = img
 	}
 
+	if cfg.Cluster != nil && cfg.RegInfo.IsInternal() {
+		warnRegistrySpace(ctx, cfg.Cluster, toPush)
+	}
+
 	var (
 		err         error
 		tunnel      *cluster.Tunnel
 		registryURL = cfg.RegInfo.Address
 	)
-	err = retry.Do(func() error {
-		c, _ := cluster.NewCluster()
-		if c != nil {
+	err = retry.Do(func() (err error) {
+		c := cfg.Cluster
+		if c == nil && cfg.Tunnels == nil {
+			c, _ = cluster.NewCluster() //nolint:errcheck
+		}
+		switch {
+		case cfg.Tunnels != nil:
+			registryURL, tunnel, err = cfg.Tunnels.ConnectToZarfRegistryEndpoint(ctx, cfg.RegInfo)
+			if err != nil {
+				return err
+			}
+			// The next attempt reconnects the tunnel of the pool when pushing through it fails.
+			defer func() {
+				if err != nil {
+					cfg.Tunnels.Discard(tunnel)
+				}
+			}()
+		case c != nil:
 			registryURL, tunnel, err = c.ConnectToZarfRegistryEndpoint(ctx, cfg.RegInfo)
 			if err != nil {
 				return err
@@ -67,27 +86,14 @@ func Push(ctx context.Context, cfg PushConfig) error {
 		for refInfo, img := range toPush {
 			message.Infof("Pushing %s", refInfo.Reference)
 			l.Info("pushing image", "name", refInfo.Reference)
-			// If this is not a no checksum image push it for use with the Zarf agent
-			if !cfg.NoChecksum {
-				offlineNameCRC, err := transform.ImageTransformHost(registryURL, refInfo.Reference)
-				if err != nil {
-					return err
-				}
-
-				if err = pushImage(img, offlineNameCRC); err != nil {
-					return err
-				}
-			}
-
-			// To allow for other non-zarf workloads to easily see the images upload a non-checksum version
-			// (this may result in collisions but this is acceptable for this use case)
-			offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference)
+			names, err := pushNames(registryURL, refInfo, cfg.NoChecksum)
 			if err != nil {
 				return err
 			}
-
-			if err = pushImage(img, offlineName); err != nil {
-				return err
+			for _, name := range names {
+				if err = pushImage(img, name); err != nil {
+					return err
+				}
 			}
 
 			pushed = append(pushed, refInfo)
@@ -100,3 +106,103 @@ func Push(ctx context.Context, cfg PushConfig) error {
 
 	return nil
 }
+
+// PlannedPush is an image that would be pushed to the registry and the names it would be pushed as.
+type PlannedPush struct {
+	Reference string
+	Digest    string
+	Names     []string
+}
+
+// PlanPush returns the images Push would push with their digests and the names they would be pushed as, without connecting to the registry.
+// The names use the registry address of the registry info, which is the address workloads reference the images with.
+func PlanPush(cfg PushConfig) ([]PlannedPush, error) {
+	planned := []PlannedPush{}
+	for _, refInfo := range cfg.ImageList {
+		img, err := utils.LoadOCIImageForArch(cfg.SourceDirectory, refInfo, cfg.Arch)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := img.Digest()
+		if err != nil {
+			return nil, err
+		}
+		names, err := pushNames(cfg.RegInfo.Address, refInfo, cfg.NoChecksum)
+		if err != nil {
+			return nil, err
+		}
+		planned = append(planned, PlannedPush{
+			Reference: refInfo.Reference,
+			Digest:    digest.String(),
+			Names:     names,
+		})
+	}
+	return planned, nil
+}
+
+// pushNames returns the names an image is pushed as to the registry.
+func pushNames(registryURL string, refInfo transform.Image, noChecksum bool) ([]string, error) {
+	names := []string{}
+	// If this is not a no checksum image push it for use with the Zarf agent
+	if !noChecksum {
+		offlineNameCRC, err := transform.ImageTransformHost(registryURL, refInfo.Reference)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, offlineNameCRC)
+	}
+
+	// To allow for other non-zarf workloads to easily see the images upload a non-checksum version
+	// (this may result in collisions but this is acceptable for this use case)
+	offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference)
+	if err != nil {
+		return nil, err
+	}
+	return append(names, offlineName), nil
+}
+
+// warnRegistrySpace warns when pushing the images likely exceeds the space that is left in the internal registry storage.
+func warnRegistrySpace(ctx context.Context, c *cluster.Cluster, toPush map[transform.Image]v1.Image) {
+	l := logger.From(ctx)
+	usage, err := c.RegistryStorageUsage(ctx)
+	if err != nil {
+		l.Debug("unable to get the registry storage usage", "error", err)
+		return
+	}
+	imgs := []v1.Image{}
+	for _, img := range toPush {
+		imgs = append(imgs, img)
+	}
+	size, err := pushSize(imgs)
+	if err != nil {
+		l.Debug("unable to estimate the size of the images to push", "error", err)
+		return
+	}
+	if uint64(size) <= usage.AvailableBytes() {
+		return
+	}
+	available := utils.ByteFormat(float64(usage.AvailableBytes()), 2)
+	required := utils.ByteFormat(float64(size), 2)
+	message.Warnf("The images to push are up to %s while only %s is left in the registry storage, the push may fail", required, available)
+	l.Warn("images to push may exceed the space left in the registry storage", "size", required, "available", available)
+}
+
+// pushSize returns the size of the unique config and layer blobs of the images, which is what pushing them adds to a registry that has none of the blobs.
+func pushSize(imgs []v1.Image) (int64, error) {
+	seen := map[v1.Hash]bool{}
+	var size int64
+	for _, img := range imgs {
+		manifest, err := img.Manifest()
+		if err != nil {
+			return 0, err
+		}
+		for _, desc := range append([]v1.Descriptor{manifest.Config}, manifest.Layers...) {
+			if seen[desc.Digest] {
+				continue
+			}
+			seen[desc.Digest] = true
+			size += desc.Size
+		}
+	}
+	return size, nil
+}
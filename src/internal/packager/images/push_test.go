@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestPushSize(t *testing.T) {
+	t.Parallel()
+
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	other, err := random.Image(512, 1)
+	require.NoError(t, err)
+
+	expected := int64(0)
+	for _, i := range []v1.Image{img, other} {
+		manifest, err := i.Manifest()
+		require.NoError(t, err)
+		expected += manifest.Config.Size
+		for _, layer := range manifest.Layers {
+			expected += layer.Size
+		}
+	}
+
+	// Blobs shared by images are only pushed once.
+	size, err := pushSize([]v1.Image{img, other, img})
+	require.NoError(t, err)
+	require.Equal(t, expected, size)
+
+	size, err = pushSize(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), size)
+}
+
+func TestPlanPush(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	layoutPath, err := layout.Write(dir, empty.Index)
+	require.NoError(t, err)
+	err = layoutPath.AppendImage(img, layout.WithAnnotations(map[string]string{
+		ocispec.AnnotationBaseImageName: "docker.io/library/nginx:1.27",
+	}))
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	ref, err := transform.ParseImageRef("nginx:1.27")
+	require.NoError(t, err)
+	cfg := PushConfig{
+		SourceDirectory: dir,
+		ImageList:       []transform.Image{ref},
+		RegInfo:         types.RegistryInfo{Address: "127.0.0.1:31999"},
+	}
+	planned, err := PlanPush(cfg)
+	require.NoError(t, err)
+	require.Len(t, planned, 1)
+	require.Equal(t, "docker.io/library/nginx:1.27", planned[0].Reference)
+	require.Equal(t, digest.String(), planned[0].Digest)
+	require.Len(t, planned[0].Names, 2)
+	require.Regexp(t, `^127\.0\.0\.1:31999/library/nginx:1\.27-zarf-\d+$`, planned[0].Names[0])
+	require.Equal(t, "127.0.0.1:31999/library/nginx:1.27", planned[0].Names[1])
+
+	cfg.NoChecksum = true
+	planned, err = PlanPush(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"127.0.0.1:31999/library/nginx:1.27"}, planned[0].Names)
+}
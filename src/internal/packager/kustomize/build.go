@@ -12,10 +12,13 @@ import (
 	"sigs.k8s.io/kustomize/api/krusty"
 	krustytypes "sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 )
 
 // Build reads a kustomization and builds it into a single yaml file.
-func Build(path string, destination string, kustomizeAllowAnyDirectory bool) error {
+// The options match the flags of kustomize build, alpha plugins only run when they are in the allowed plugins.
+func Build(path string, destination string, kustomizeAllowAnyDirectory bool, opts *v1alpha1.ZarfKustomizeOptions) error {
 	// Kustomize has to write to the filesystem on-disk
 	fSys := filesys.MakeFsOnDisk()
 
@@ -26,6 +29,17 @@ func Build(path string, destination string, kustomizeAllowAnyDirectory bool) err
 		buildOptions.LoadRestrictions = krustytypes.LoadRestrictionsNone
 	}
 
+	if opts != nil {
+		if err := applyOptions(buildOptions, opts); err != nil {
+			return err
+		}
+		if opts.EnableAlphaPlugins {
+			if err := checkPlugins(path, opts.AllowedPlugins); err != nil {
+				return err
+			}
+		}
+	}
+
 	kustomizer := krusty.MakeKustomizer(buildOptions)
 
 	// Try to build the kustomization
@@ -42,3 +56,35 @@ func Build(path string, destination string, kustomizeAllowAnyDirectory bool) err
 
 	return os.WriteFile(destination, yaml, helpers.ReadWriteUser)
 }
+
+// applyOptions sets the build options the same way the flags of kustomize build do.
+func applyOptions(buildOptions *krusty.Options, opts *v1alpha1.ZarfKustomizeOptions) error {
+	switch opts.LoadRestrictor {
+	case "":
+	case krustytypes.LoadRestrictionsRootOnly.String():
+		buildOptions.LoadRestrictions = krustytypes.LoadRestrictionsRootOnly
+	case krustytypes.LoadRestrictionsNone.String():
+		buildOptions.LoadRestrictions = krustytypes.LoadRestrictionsNone
+	default:
+		return fmt.Errorf("invalid load restrictor %q, valid options are %s and %s", opts.LoadRestrictor,
+			krustytypes.LoadRestrictionsRootOnly, krustytypes.LoadRestrictionsNone)
+	}
+
+	if opts.EnableAlphaPlugins {
+		pluginConfig := krustytypes.EnabledPluginConfig(krustytypes.BploUseStaticallyLinked)
+		pluginConfig.FnpLoadingOptions.EnableExec = true
+		// Helm is only enabled through enableHelm.
+		pluginConfig.HelmConfig = krustytypes.HelmConfig{}
+		buildOptions.PluginConfig = pluginConfig
+	}
+
+	if opts.EnableHelm {
+		buildOptions.PluginConfig.HelmConfig = krustytypes.HelmConfig{
+			Enabled:     true,
+			Command:     "helm",
+			KubeVersion: opts.HelmKubeVersion,
+			ApiVersions: opts.HelmAPIVersions,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package kustomize
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/konfig"
+	krustytypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/yaml"
+)
+
+// checkPlugins returns an error if the kustomization or any local kustomization it includes configures a plugin that
+// is not allowed. Container functions are identified by their image, exec functions by their path and legacy plugins
+// by their apiVersion/kind. Remote kustomizations cannot be checked and are rejected.
+func checkPlugins(path string, allowed []string) error {
+	return checkKustomization(path, allowed, map[string]bool{})
+}
+
+func checkKustomization(dir string, allowed []string, visited map[string]bool) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if visited[dir] {
+		return nil
+	}
+	visited[dir] = true
+
+	kustomization, err := readKustomization(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range slices.Concat(kustomization.Resources, kustomization.Components) {
+		fi, err := localEntry(dir, entry)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if err := checkKustomization(filepath.Join(dir, entry), allowed, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, entry := range slices.Concat(kustomization.Generators, kustomization.Transformers, kustomization.Validators) {
+		// Plugin configs can be given inline since kustomize v5.
+		if strings.Contains(entry, "\n") {
+			if err := checkPluginConfigs([]byte(entry), allowed); err != nil {
+				return err
+			}
+			continue
+		}
+		fi, err := localEntry(dir, entry)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if err := checkPluginDir(filepath.Join(dir, entry), allowed, visited); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, entry))
+		if err != nil {
+			return err
+		}
+		if err := checkPluginConfigs(b, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPluginDir checks a kustomization whose resources are plugin configs.
+func checkPluginDir(dir string, allowed []string, visited map[string]bool) error {
+	if err := checkKustomization(dir, allowed, visited); err != nil {
+		return err
+	}
+	kustomization, err := readKustomization(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range kustomization.Resources {
+		fi, err := localEntry(dir, entry)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if err := checkPluginDir(filepath.Join(dir, entry), allowed, visited); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, entry))
+		if err != nil {
+			return err
+		}
+		if err := checkPluginConfigs(b, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readKustomization(dir string) (krustytypes.Kustomization, error) {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return krustytypes.Kustomization{}, err
+		}
+		var kustomization krustytypes.Kustomization
+		if err := yaml.Unmarshal(b, &kustomization); err != nil {
+			return krustytypes.Kustomization{}, fmt.Errorf("unable to read the kustomization in %s: %w", dir, err)
+		}
+		return kustomization, nil
+	}
+	return krustytypes.Kustomization{}, fmt.Errorf("unable to find a kustomization in %s", dir)
+}
+
+// localEntry returns the file info of an entry of a kustomization, entries that do not exist locally are remote.
+func localEntry(dir, entry string) (os.FileInfo, error) {
+	fi, err := os.Stat(filepath.Join(dir, entry))
+	if err != nil {
+		return nil, fmt.Errorf("unable to check %s for plugins, remote kustomizations cannot be used with alpha plugins", entry)
+	}
+	return fi, nil
+}
+
+// checkPluginConfigs returns an error if any of the plugin configs in the YAML documents is not allowed.
+func checkPluginConfigs(b []byte, allowed []string) error {
+	nodes, err := kio.FromBytes(b)
+	if err != nil {
+		return fmt.Errorf("unable to read plugin configs: %w", err)
+	}
+	for _, node := range nodes {
+		spec, err := runtimeutil.GetFunctionSpec(node)
+		if err != nil {
+			return err
+		}
+		var plugin string
+		switch {
+		case spec != nil && spec.Container.Image != "":
+			plugin = spec.Container.Image
+		case spec != nil && spec.Exec.Path != "":
+			plugin = spec.Exec.Path
+		case node.GetApiVersion() == konfig.BuiltinPluginApiVersion:
+			continue
+		default:
+			plugin = node.GetApiVersion() + "/" + node.GetKind()
+		}
+		if !slices.Contains(allowed, plugin) {
+			return fmt.Errorf("plugin %s used by %s %s is not in the allowed plugins", plugin, node.GetKind(), node.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPlugins(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"base/kustomization.yaml": `resources:
+- configmap.yaml
+transformers:
+- |-
+  apiVersion: builtin
+  kind: LabelTransformer
+  metadata:
+    name: labels
+  labels:
+    app: test
+  fieldSpecs:
+  - path: metadata/labels
+    create: true
+`,
+		"base/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+`,
+		"overlay/kustomization.yaml": `resources:
+- ../base
+generators:
+- generator.yaml
+`,
+		"overlay/generator.yaml": `apiVersion: example.com/v1
+kind: Generator
+metadata:
+  name: generator
+  annotations:
+    config.kubernetes.io/function: |
+      container:
+        image: ghcr.io/example/generator:v1
+`,
+		"remote/kustomization.yaml": `resources:
+- https://github.com/example/config//base?ref=v1
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	// Builtin plugins are always allowed.
+	err := checkPlugins(filepath.Join(dir, "base"), nil)
+	require.NoError(t, err)
+
+	err = checkPlugins(filepath.Join(dir, "overlay"), []string{"ghcr.io/example/generator:v1"})
+	require.NoError(t, err)
+
+	err = checkPlugins(filepath.Join(dir, "overlay"), []string{"ghcr.io/example/other:v1"})
+	require.EqualError(t, err, "plugin ghcr.io/example/generator:v1 used by Generator generator is not in the allowed plugins")
+
+	err = checkPlugins(filepath.Join(dir, "remote"), []string{"ghcr.io/example/generator:v1"})
+	require.ErrorContains(t, err, "remote kustomizations cannot be used with alpha plugins")
+}
@@ -5,36 +5,88 @@ package packager2
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
 
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/git"
 	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 type CreateOptions struct {
 	Flavor                  string
 	RegistryOverrides       map[string]string
+	FlattenImages           []string
 	SigningKeyPath          string
 	SigningKeyPassword      string
+	SignChecksums           bool
+	TSAURL                  string
+	Keyless                 bool
+	KeylessOptions          utils.KeylessOptions
 	SetVariables            map[string]string
 	MaxPackageSizeMB        int
 	SBOMOut                 string
 	SkipSBOM                bool
+	SkipSBOMViewer          bool
 	Output                  string
 	DifferentialPackagePath string
+	Concurrency             int
+	SnapshotCharts          bool
+	ImagePolicy             lint.ImagePolicy
+	OverrideImagePolicy     bool
+	RegistryPresets         []types.RegistryPreset
+	Architectures           []string
 }
 
 func Create(ctx context.Context, packagePath string, opt CreateOptions) error {
+	if isGitPackageSource(packagePath) {
+		tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+		packagePath, err = cloneGitPackageSource(ctx, tmpDir, packagePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	createOpt := layout2.CreateOptions{
 		Flavor:                  opt.Flavor,
 		RegistryOverrides:       opt.RegistryOverrides,
+		FlattenImages:           opt.FlattenImages,
 		SigningKeyPath:          opt.SigningKeyPath,
 		SigningKeyPassword:      opt.SigningKeyPassword,
+		SignChecksums:           opt.SignChecksums,
+		TSAURL:                  opt.TSAURL,
+		Keyless:                 opt.Keyless,
+		KeylessOptions:          opt.KeylessOptions,
 		SetVariables:            opt.SetVariables,
 		SkipSBOM:                opt.SkipSBOM,
+		SkipSBOMViewer:          opt.SkipSBOMViewer,
 		DifferentialPackagePath: opt.DifferentialPackagePath,
+		Concurrency:             opt.Concurrency,
+		SnapshotCharts:          opt.SnapshotCharts,
+		ImagePolicy:             opt.ImagePolicy,
+		OverrideImagePolicy:     opt.OverrideImagePolicy,
+		RegistryPresets:         opt.RegistryPresets,
+		Architectures:           opt.Architectures,
 	}
 	pkgLayout, err := layout2.CreatePackage(ctx, packagePath, createOpt)
 	if err != nil {
@@ -47,7 +99,12 @@ func Create(ctx context.Context, packagePath string, opt CreateOptions) error {
 		if err != nil {
 			return err
 		}
-		remote, err := layout2.NewRemote(ctx, ref, oci.PlatformForArch(config.GetArch()))
+		arch := config.GetArch()
+		// Packages created for multiple architectures are published under the multi architecture.
+		if pkgLayout.Pkg.Build.Architecture == zoci.MultiArch {
+			arch = zoci.MultiArch
+		}
+		remote, err := layout2.NewRemote(ctx, ref, oci.PlatformForArch(arch))
 		if err != nil {
 			return err
 		}
@@ -70,3 +127,212 @@ func Create(ctx context.Context, packagePath string, opt CreateOptions) error {
 	}
 	return nil
 }
+
+// isGitPackageSource returns true if the package definition is in a remote git repository.
+func isGitPackageSource(source string) bool {
+	u, err := url.Parse(source)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// parseGitPackageSource splits a package definition in a remote git repository, given as
+// https://host/org/repo//path@ref, into the repository URL with its ref and the path of the definition in the repository.
+func parseGitPackageSource(source string) (string, string, error) {
+	scheme, rest, ok := strings.Cut(source, "://")
+	if !ok {
+		return "", "", fmt.Errorf("%s is not a git repository URL", source)
+	}
+	ref := ""
+	// An @ before the first slash belongs to the credentials of the URL and not to the ref.
+	if at := strings.LastIndex(rest, "@"); at > strings.Index(rest, "/") {
+		rest, ref = rest[:at], rest[at+1:]
+		if ref == "" {
+			return "", "", fmt.Errorf("%s has an empty git ref", source)
+		}
+	}
+	repo, subPath, _ := strings.Cut(rest, "//")
+	repo = strings.TrimSuffix(repo, "/")
+	subPath = strings.Trim(subPath, "/")
+	if subPath != "" && !filepath.IsLocal(subPath) {
+		return "", "", fmt.Errorf("the path %s must be within the git repository", subPath)
+	}
+	address := fmt.Sprintf("%s://%s", scheme, repo)
+	if ref != "" {
+		address = fmt.Sprintf("%s@%s", address, ref)
+	}
+	return address, filepath.FromSlash(subPath), nil
+}
+
+// cloneGitPackageSource shallow clones the repository of a package definition into the directory and returns the path
+// of the definition within the clone.
+func cloneGitPackageSource(ctx context.Context, dir, source string) (string, error) {
+	address, subPath, err := parseGitPackageSource(source)
+	if err != nil {
+		return "", err
+	}
+	message.Notef("Cloning the package definition from %s", address)
+	logger.From(ctx).Info("cloning the package definition", "url", address, "path", subPath)
+	repo, err := git.Clone(ctx, dir, address, true)
+	if err != nil {
+		return "", fmt.Errorf("unable to clone the package definition from %s: %w", address, err)
+	}
+	packagePath := filepath.Join(repo.Path(), subPath)
+	if _, err := os.Stat(filepath.Join(packagePath, layout2.ZarfYAML)); err != nil {
+		return "", fmt.Errorf("unable to find %s in %s: %w", filepath.Join(subPath, layout2.ZarfYAML), address, err)
+	}
+	return packagePath, nil
+}
+
+// CreateResult is the result of creating one of the packages of a recursive create.
+type CreateResult struct {
+	// Path is the directory of the package definition.
+	Path string
+	// Name is the name of the package.
+	Name     string
+	Duration time.Duration
+	// Err is the error the package failed to create with.
+	Err error
+	// Skipped is true when the package was not created because a package it imports from failed to create.
+	Skipped bool
+}
+
+// recursivePackage is a package definition found by a recursive create.
+type recursivePackage struct {
+	path    string
+	name    string
+	imports []string
+}
+
+// CreateRecursive creates every package definition in the directory tree. Packages are created after the packages
+// their components import from, and packages importing from a package that failed to create are skipped.
+func CreateRecursive(ctx context.Context, dir string, opt CreateOptions) ([]CreateResult, error) {
+	l := logger.From(ctx)
+	if opt.DifferentialPackagePath != "" {
+		return nil, errors.New("differential packages can not be created recursively")
+	}
+	pkgs, err := findPackages(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("unable to find a %s in %s", layout2.ZarfYAML, dir)
+	}
+	ordered, err := sortPackagesByImports(pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []CreateResult{}
+	failed := map[string]bool{}
+	for _, pkg := range ordered {
+		result := CreateResult{Path: pkg.path, Name: pkg.name}
+		if slices.ContainsFunc(pkg.imports, func(path string) bool { return failed[path] }) {
+			message.Warnf("Skipping %s as a package it imports from failed to create", pkg.path)
+			l.Warn("skipping package as a package it imports from failed to create", "path", pkg.path)
+			result.Skipped = true
+			failed[pkg.path] = true
+			results = append(results, result)
+			continue
+		}
+		message.HeaderInfof("📦 PACKAGE %s", pkg.path)
+		start := time.Now()
+		result.Err = Create(ctx, pkg.path, opt)
+		result.Duration = time.Since(start)
+		if result.Err != nil {
+			message.WarnErrf(result.Err, "Failed to create %s: %s", pkg.path, result.Err.Error())
+			l.Error("failed to create package", "path", pkg.path, "error", result.Err)
+			failed[pkg.path] = true
+		}
+		results = append(results, result)
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%d of %d packages failed to create", len(failed), len(results))
+	}
+	return results, nil
+}
+
+// findPackages returns the package definitions in the directory tree, hidden directories are not searched.
+func findPackages(dir string) ([]recursivePackage, error) {
+	pkgs := []recursivePackage{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != layout2.ZarfYAML {
+			return nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pkg, err := layout2.ParseZarfPackage(b)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		pkgDir := filepath.Dir(path)
+		imports := []string{}
+		for _, component := range pkg.Components {
+			if component.Import.Path == "" {
+				continue
+			}
+			importDir := filepath.Join(pkgDir, component.Import.Path)
+			if importDir != pkgDir && !slices.Contains(imports, importDir) {
+				imports = append(imports, importDir)
+			}
+		}
+		pkgs = append(pkgs, recursivePackage{path: pkgDir, name: pkg.Metadata.Name, imports: imports})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// sortPackagesByImports orders packages so that packages come after the packages they import from. Imports of
+// directories that are not one of the packages are ignored.
+func sortPackagesByImports(pkgs []recursivePackage) ([]recursivePackage, error) {
+	byPath := map[string]recursivePackage{}
+	for _, pkg := range pkgs {
+		byPath[pkg.path] = pkg
+	}
+	ordered := []recursivePackage{}
+	// visiting tracks the packages on the current import chain to detect cycles.
+	visiting := map[string]bool{}
+	visited := map[string]bool{}
+	var visit func(pkg recursivePackage, chain []string) error
+	visit = func(pkg recursivePackage, chain []string) error {
+		if visited[pkg.path] {
+			return nil
+		}
+		chain = append(chain, pkg.path)
+		if visiting[pkg.path] {
+			return fmt.Errorf("packages import from each other in a cycle: %s", strings.Join(chain, " -> "))
+		}
+		visiting[pkg.path] = true
+		for _, path := range pkg.imports {
+			imported, ok := byPath[path]
+			if !ok {
+				continue
+			}
+			if err := visit(imported, chain); err != nil {
+				return err
+			}
+		}
+		visiting[pkg.path] = false
+		visited[pkg.path] = true
+		ordered = append(ordered, pkg)
+		return nil
+	}
+	for _, pkg := range pkgs {
+		if err := visit(pkg, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
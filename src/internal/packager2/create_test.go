@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitPackageSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		source          string
+		expectedAddress string
+		expectedPath    string
+		expectedErr     string
+	}{
+		{
+			name:            "path and ref",
+			source:          "https://github.com/zarf-dev/zarf//examples/dos-games@v0.40.0",
+			expectedAddress: "https://github.com/zarf-dev/zarf@v0.40.0",
+			expectedPath:    "examples/dos-games",
+		},
+		{
+			name:            "repository root",
+			source:          "https://github.com/zarf-dev/zarf.git",
+			expectedAddress: "https://github.com/zarf-dev/zarf.git",
+			expectedPath:    "",
+		},
+		{
+			name:            "branch ref with credentials",
+			source:          "https://user@example.com/org/repo//packages/app/@refs/heads/main",
+			expectedAddress: "https://user@example.com/org/repo@refs/heads/main",
+			expectedPath:    "packages/app",
+		},
+		{
+			name:        "empty ref",
+			source:      "https://github.com/zarf-dev/zarf//examples@",
+			expectedErr: "https://github.com/zarf-dev/zarf//examples@ has an empty git ref",
+		},
+		{
+			name:        "path outside of the repository",
+			source:      "https://github.com/zarf-dev/zarf//../other",
+			expectedErr: "the path ../other must be within the git repository",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			address, path, err := parseGitPackageSource(tt.source)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedAddress, address)
+			require.Equal(t, tt.expectedPath, path)
+		})
+	}
+}
+
+func TestFindPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePackage := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, path), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path, "zarf.yaml"), []byte(content), 0o600))
+	}
+	writePackage("app", `kind: ZarfPackageConfig
+metadata:
+  name: app
+components:
+  - name: base
+    import:
+      path: ../base
+  - name: base-again
+    import:
+      path: ../base/
+      name: other
+`)
+	writePackage("base", "kind: ZarfPackageConfig\nmetadata:\n  name: base\n")
+	writePackage(".github/ci", "kind: ZarfPackageConfig\nmetadata:\n  name: hidden\n")
+
+	pkgs, err := findPackages(dir)
+	require.NoError(t, err)
+	expected := []recursivePackage{
+		{path: filepath.Join(dir, "app"), name: "app", imports: []string{filepath.Join(dir, "base")}},
+		{path: filepath.Join(dir, "base"), name: "base", imports: []string{}},
+	}
+	require.Equal(t, expected, pkgs)
+}
+
+func TestSortPackagesByImports(t *testing.T) {
+	t.Parallel()
+
+	pkgs := []recursivePackage{
+		{path: "app", imports: []string{"platform", "external"}},
+		{path: "base"},
+		{path: "platform", imports: []string{"base"}},
+	}
+	ordered, err := sortPackagesByImports(pkgs)
+	require.NoError(t, err)
+	paths := []string{}
+	for _, pkg := range ordered {
+		paths = append(paths, pkg.path)
+	}
+	require.Equal(t, []string{"base", "platform", "app"}, paths)
+
+	pkgs[1].imports = []string{"app"}
+	_, err = sortPackagesByImports(pkgs)
+	require.EqualError(t, err, "packages import from each other in a cycle: app -> platform -> base -> app")
+}
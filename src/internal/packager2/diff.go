@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager2 contains functions for comparing packages.
+package packager2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	// DiffAdded marks something that is only in the package compared to.
+	DiffAdded = "added"
+	// DiffRemoved marks something that is only in the package compared from.
+	DiffRemoved = "removed"
+	// DiffChanged marks something that is in both packages but differs.
+	DiffChanged = "changed"
+
+	// sanitizedValue replaces the defaults of sensitive variables.
+	sanitizedValue = "**sanitized**"
+)
+
+// DiffOptions are the options for Diff.
+type DiffOptions struct {
+	// From is the source of the package compared from, a deployed package name is looked up in the cluster.
+	From string
+	// To is the source of the package compared to, a deployed package name is looked up in the cluster.
+	To                      string
+	Cluster                 *cluster.Cluster
+	SkipSignatureValidation bool
+	PublicKeyPath           string
+	CertificateIdentity     utils.CertificateIdentity
+	VerificationPolicy      types.VerificationPolicy
+}
+
+// PackageDiff is the difference between two packages.
+type PackageDiff struct {
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Components []ComponentDiff `json:"components,omitempty"`
+	Variables  []ValueDiff     `json:"variables,omitempty"`
+}
+
+// Empty returns true if the packages do not differ.
+func (d PackageDiff) Empty() bool {
+	return len(d.Components) == 0 && len(d.Variables) == 0
+}
+
+// ComponentDiff is the difference of a component between two packages.
+type ComponentDiff struct {
+	Name   string      `json:"name"`
+	Change string      `json:"change"`
+	Images []ValueDiff `json:"images,omitempty"`
+	Charts []ValueDiff `json:"charts,omitempty"`
+	Repos  []ValueDiff `json:"repos,omitempty"`
+	// Modified lists the other parts of a changed component that differ, such as manifests or files.
+	Modified []string `json:"modified,omitempty"`
+}
+
+// ValueDiff is the difference of a named value, like an image or chart version, between two packages.
+// From is empty when the value was added and To is empty when it was removed.
+type ValueDiff struct {
+	Name   string `json:"name"`
+	Change string `json:"change"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// Diff loads two packages and returns the difference between them.
+func Diff(ctx context.Context, opt DiffOptions) (PackageDiff, error) {
+	from, err := packageFromSourceOrCluster(ctx, opt.Cluster, opt.From, opt.SkipSignatureValidation, opt.PublicKeyPath, opt.CertificateIdentity, opt.VerificationPolicy)
+	if err != nil {
+		return PackageDiff{}, fmt.Errorf("unable to load %s: %w", opt.From, err)
+	}
+	to, err := packageFromSourceOrCluster(ctx, opt.Cluster, opt.To, opt.SkipSignatureValidation, opt.PublicKeyPath, opt.CertificateIdentity, opt.VerificationPolicy)
+	if err != nil {
+		return PackageDiff{}, fmt.Errorf("unable to load %s: %w", opt.To, err)
+	}
+	return diffPackages(from, to)
+}
+
+// diffPackages returns the difference between two package definitions.
+func diffPackages(from, to v1alpha1.ZarfPackage) (PackageDiff, error) {
+	diff := PackageDiff{
+		From: packageDiffName(from),
+		To:   packageDiffName(to),
+	}
+
+	fromComponents := map[string]v1alpha1.ZarfComponent{}
+	for _, component := range from.Components {
+		fromComponents[component.Name] = component
+	}
+	toNames := map[string]bool{}
+	for _, component := range to.Components {
+		toNames[component.Name] = true
+		fromComponent, ok := fromComponents[component.Name]
+		if !ok {
+			added, err := diffComponent(v1alpha1.ZarfComponent{}, component)
+			if err != nil {
+				return PackageDiff{}, err
+			}
+			added.Change = DiffAdded
+			added.Modified = nil
+			diff.Components = append(diff.Components, added)
+			continue
+		}
+		changed, err := diffComponent(fromComponent, component)
+		if err != nil {
+			return PackageDiff{}, err
+		}
+		if len(changed.Images) > 0 || len(changed.Charts) > 0 || len(changed.Repos) > 0 || len(changed.Modified) > 0 {
+			diff.Components = append(diff.Components, changed)
+		}
+	}
+	for _, component := range from.Components {
+		if toNames[component.Name] {
+			continue
+		}
+		removed, err := diffComponent(component, v1alpha1.ZarfComponent{})
+		if err != nil {
+			return PackageDiff{}, err
+		}
+		removed.Change = DiffRemoved
+		removed.Modified = nil
+		diff.Components = append(diff.Components, removed)
+	}
+
+	diff.Variables = diffVariables(from.Variables, to.Variables)
+	return diff, nil
+}
+
+// packageDiffName returns the name and version of a package.
+func packageDiffName(pkg v1alpha1.ZarfPackage) string {
+	if pkg.Metadata.Version == "" {
+		return pkg.Metadata.Name
+	}
+	return fmt.Sprintf("%s:%s", pkg.Metadata.Name, pkg.Metadata.Version)
+}
+
+// diffComponent returns the difference between two versions of a component, the name is taken from whichever is set.
+func diffComponent(from, to v1alpha1.ZarfComponent) (ComponentDiff, error) {
+	diff := ComponentDiff{
+		Name:   to.Name,
+		Change: DiffChanged,
+	}
+	if diff.Name == "" {
+		diff.Name = from.Name
+	}
+
+	images, err := diffImages(from.Images, to.Images)
+	if err != nil {
+		return ComponentDiff{}, err
+	}
+	diff.Images = images
+	diff.Charts = diffCharts(from.Charts, to.Charts)
+	diff.Repos = diffLists(from.Repos, to.Repos)
+
+	if !reflect.DeepEqual(from.Manifests, to.Manifests) {
+		diff.Modified = append(diff.Modified, "manifests")
+	}
+	if !reflect.DeepEqual(from.Files, to.Files) {
+		diff.Modified = append(diff.Modified, "files")
+	}
+	if !reflect.DeepEqual(from.DataInjections, to.DataInjections) {
+		diff.Modified = append(diff.Modified, "data injections")
+	}
+	if !reflect.DeepEqual(from.Actions, to.Actions) {
+		diff.Modified = append(diff.Modified, "actions")
+	}
+	// Chart changes other than the version are not reported as version changes.
+	fromCharts, toCharts := slices.Clone(from.Charts), slices.Clone(to.Charts)
+	for i := range fromCharts {
+		fromCharts[i].Version = ""
+	}
+	for i := range toCharts {
+		toCharts[i].Version = ""
+	}
+	if len(diff.Charts) == 0 && !reflect.DeepEqual(fromCharts, toCharts) {
+		diff.Modified = append(diff.Modified, "chart configuration")
+	}
+
+	// Any other change is reported as a whole.
+	from.Images, to.Images = nil, nil
+	from.Charts, to.Charts = nil, nil
+	from.Repos, to.Repos = nil, nil
+	from.Manifests, to.Manifests = nil, nil
+	from.Files, to.Files = nil, nil
+	from.DataInjections, to.DataInjections = nil, nil
+	from.Actions, to.Actions = v1alpha1.ZarfComponentActions{}, v1alpha1.ZarfComponentActions{}
+	if !reflect.DeepEqual(from, to) {
+		diff.Modified = append(diff.Modified, "component configuration")
+	}
+	return diff, nil
+}
+
+// diffImages returns the images added and removed between two lists.
+// An added and a removed image of the same repository are reported as a single changed image.
+func diffImages(from, to []string) ([]ValueDiff, error) {
+	changes := diffLists(from, to)
+	repos := map[string][]int{}
+	for i, change := range changes {
+		ref, err := transform.ParseImageRef(change.Name)
+		if err != nil {
+			return nil, err
+		}
+		repos[ref.Name] = append(repos[ref.Name], i)
+	}
+	diffs := []ValueDiff{}
+	for i, change := range changes {
+		ref, err := transform.ParseImageRef(change.Name)
+		if err != nil {
+			return nil, err
+		}
+		indexes := repos[ref.Name]
+		if len(indexes) != 2 || changes[indexes[0]].Change == changes[indexes[1]].Change {
+			diffs = append(diffs, change)
+			continue
+		}
+		if i != indexes[0] {
+			continue
+		}
+		changed := ValueDiff{Name: ref.Name, Change: DiffChanged}
+		for _, j := range indexes {
+			if changes[j].Change == DiffRemoved {
+				changed.From = changes[j].Name
+			} else {
+				changed.To = changes[j].Name
+			}
+		}
+		diffs = append(diffs, changed)
+	}
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+	return diffs, nil
+}
+
+// diffCharts returns the charts added and removed and the charts whose version changed between two lists.
+func diffCharts(from, to []v1alpha1.ZarfChart) []ValueDiff {
+	diffs := []ValueDiff{}
+	fromVersions := map[string]string{}
+	for _, chart := range from {
+		fromVersions[chart.Name] = chart.Version
+	}
+	toNames := map[string]bool{}
+	for _, chart := range to {
+		toNames[chart.Name] = true
+		version, ok := fromVersions[chart.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, ValueDiff{Name: chart.Name, Change: DiffAdded, To: chart.Version})
+		case version != chart.Version:
+			diffs = append(diffs, ValueDiff{Name: chart.Name, Change: DiffChanged, From: version, To: chart.Version})
+		}
+	}
+	for _, chart := range from {
+		if !toNames[chart.Name] {
+			diffs = append(diffs, ValueDiff{Name: chart.Name, Change: DiffRemoved, From: chart.Version})
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	return diffs
+}
+
+// diffVariables returns the variables added and removed and the variables whose definition changed between two lists.
+// The defaults of sensitive variables are not included.
+func diffVariables(from, to []v1alpha1.InteractiveVariable) []ValueDiff {
+	defaultValue := func(v v1alpha1.InteractiveVariable) string {
+		if v.Sensitive && v.Default != "" {
+			return sanitizedValue
+		}
+		return v.Default
+	}
+	diffs := []ValueDiff{}
+	fromVariables := map[string]v1alpha1.InteractiveVariable{}
+	for _, v := range from {
+		fromVariables[v.Name] = v
+	}
+	toNames := map[string]bool{}
+	for _, v := range to {
+		toNames[v.Name] = true
+		fromVariable, ok := fromVariables[v.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, ValueDiff{Name: v.Name, Change: DiffAdded, To: defaultValue(v)})
+		case !reflect.DeepEqual(fromVariable, v):
+			diffs = append(diffs, ValueDiff{Name: v.Name, Change: DiffChanged, From: defaultValue(fromVariable), To: defaultValue(v)})
+		}
+	}
+	for _, v := range from {
+		if !toNames[v.Name] {
+			diffs = append(diffs, ValueDiff{Name: v.Name, Change: DiffRemoved, From: defaultValue(v)})
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	return diffs
+}
+
+// diffLists returns the values added to and removed from a list.
+func diffLists(from, to []string) []ValueDiff {
+	diffs := []ValueDiff{}
+	for _, value := range to {
+		if !slices.Contains(from, value) {
+			diffs = append(diffs, ValueDiff{Name: value, Change: DiffAdded, To: value})
+		}
+	}
+	for _, value := range from {
+		if !slices.Contains(to, value) {
+			diffs = append(diffs, ValueDiff{Name: value, Change: DiffRemoved, From: value})
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	return diffs
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestDiffPackages(t *testing.T) {
+	t.Parallel()
+
+	from := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "podinfo", Version: "1.0.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:   "podinfo",
+				Images: []string{"ghcr.io/stefanprodan/podinfo:6.3.0", "busybox:1.36"},
+				Charts: []v1alpha1.ZarfChart{{Name: "podinfo", Version: "6.3.0"}},
+				Repos:  []string{"https://github.com/stefanprodan/podinfo.git"},
+			},
+			{
+				Name:      "unchanged",
+				Manifests: []v1alpha1.ZarfManifest{{Name: "config", Files: []string{"config.yaml"}}},
+			},
+			{
+				Name:   "legacy",
+				Images: []string{"nginx:1.25"},
+			},
+		},
+		Variables: []v1alpha1.InteractiveVariable{
+			{Variable: v1alpha1.Variable{Name: "REPLICAS"}, Default: "1"},
+			{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}, Default: "hunter2"},
+			{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Default: "example.com"},
+		},
+	}
+	to := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "podinfo", Version: "1.1.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:     "podinfo",
+				Required: &[]bool{true}[0],
+				Images:   []string{"ghcr.io/stefanprodan/podinfo:6.4.0", "busybox:1.36", "redis:7"},
+				Charts:   []v1alpha1.ZarfChart{{Name: "podinfo", Version: "6.4.0"}},
+			},
+			{
+				Name:      "unchanged",
+				Manifests: []v1alpha1.ZarfManifest{{Name: "config", Files: []string{"config.yaml"}}},
+			},
+			{
+				Name:   "monitoring",
+				Charts: []v1alpha1.ZarfChart{{Name: "prometheus", Version: "25.0.0"}},
+			},
+		},
+		Variables: []v1alpha1.InteractiveVariable{
+			{Variable: v1alpha1.Variable{Name: "REPLICAS"}, Default: "2"},
+			{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}, Default: "correct-horse"},
+			{Variable: v1alpha1.Variable{Name: "NAMESPACE"}, Default: "podinfo"},
+		},
+	}
+
+	diff, err := diffPackages(from, to)
+	require.NoError(t, err)
+	expected := PackageDiff{
+		From: "podinfo:1.0.0",
+		To:   "podinfo:1.1.0",
+		Components: []ComponentDiff{
+			{
+				Name:   "podinfo",
+				Change: DiffChanged,
+				Images: []ValueDiff{
+					{Name: "ghcr.io/stefanprodan/podinfo", Change: DiffChanged, From: "ghcr.io/stefanprodan/podinfo:6.3.0", To: "ghcr.io/stefanprodan/podinfo:6.4.0"},
+					{Name: "redis:7", Change: DiffAdded, To: "redis:7"},
+				},
+				Charts:   []ValueDiff{{Name: "podinfo", Change: DiffChanged, From: "6.3.0", To: "6.4.0"}},
+				Repos:    []ValueDiff{{Name: "https://github.com/stefanprodan/podinfo.git", Change: DiffRemoved, From: "https://github.com/stefanprodan/podinfo.git"}},
+				Modified: []string{"component configuration"},
+			},
+			{
+				Name:   "monitoring",
+				Change: DiffAdded,
+				Charts: []ValueDiff{{Name: "prometheus", Change: DiffAdded, To: "25.0.0"}},
+			},
+			{
+				Name:   "legacy",
+				Change: DiffRemoved,
+				Images: []ValueDiff{{Name: "nginx:1.25", Change: DiffRemoved, From: "nginx:1.25"}},
+			},
+		},
+		Variables: []ValueDiff{
+			{Name: "REPLICAS", Change: DiffChanged, From: "1", To: "2"},
+			{Name: "PASSWORD", Change: DiffChanged, From: sanitizedValue, To: sanitizedValue},
+			{Name: "NAMESPACE", Change: DiffAdded, To: "podinfo"},
+			{Name: "DOMAIN", Change: DiffRemoved, From: "example.com"},
+		},
+	}
+	require.Equal(t, expected, diff)
+	require.False(t, diff.Empty())
+
+	diff, err = diffPackages(from, from)
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+}
@@ -210,3 +210,26 @@ func TestDeployFilter_Apply(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentsFlag(t *testing.T) {
+	t.Parallel()
+
+	components := []v1alpha1.ZarfComponent{
+		{Name: "required", Required: helpers.BoolPtr(true)},
+		{Name: "default", Default: true},
+		{Name: "excluded-default", Default: true},
+		{Name: "optional"},
+		{Name: "unselected"},
+		{Name: "group-default", DeprecatedGroup: "group", Default: true},
+		{Name: "group-choice", DeprecatedGroup: "group"},
+	}
+	selected := []v1alpha1.ZarfComponent{components[0], components[1], components[3], components[6]}
+
+	flag := componentsFlag(components, selected)
+	require.Equal(t, "-excluded-default,optional,group-choice", flag)
+
+	// Deploying with the flag selects the same components without prompting.
+	result, err := ForDeploy(flag, false).Apply(v1alpha1.ZarfPackage{Components: components})
+	require.NoError(t, err)
+	require.Equal(t, selected, result)
+}
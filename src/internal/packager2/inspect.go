@@ -6,16 +6,26 @@ package packager2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/sbom"
+	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // ZarfInspectOptions tracks the user-defined preferences during a package inspection.
@@ -27,6 +37,10 @@ type ZarfInspectOptions struct {
 	ListImages              bool
 	SkipSignatureValidation bool
 	PublicKeyPath           string
+	CertificateIdentity     utils.CertificateIdentity
+	VerificationPolicy      types.VerificationPolicy
+	AttestationKeyPath      string
+	KeylessAttestations     bool
 }
 
 // Inspect list the contents of a package.
@@ -36,6 +50,10 @@ func Inspect(ctx context.Context, opt ZarfInspectOptions) (v1alpha1.ZarfPackage,
 	if err != nil {
 		return pkg, err
 	}
+	err = showLinkedPackage(ctx, opt.Source)
+	if err != nil {
+		return pkg, err
+	}
 
 	if getSBOM(opt.ViewSBOM, opt.SBOMOutputDir) {
 		err = handleSBOMOptions(ctx, opt)
@@ -64,8 +82,171 @@ func InspectList(ctx context.Context, opt ZarfInspectOptions) ([]string, error)
 	return imageList, nil
 }
 
+// InspectAnnotations returns the OCI manifest annotations of a package.
+// Packages in a registry return the annotations they were published with, all other packages return the annotations they would be published with.
+func InspectAnnotations(ctx context.Context, opt ZarfInspectOptions) (map[string]string, error) {
+	srcType, err := identifySource(opt.Source)
+	if err == nil && srcType == "oci" {
+		remote, err := zoci.NewRemote(ctx, opt.Source, oci.PlatformForArch(config.GetArch()))
+		if err != nil {
+			return nil, err
+		}
+		root, err := remote.FetchRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return root.Annotations, nil
+	}
+	pkg, err := getPackageMetadata(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	return zoci.AnnotationsFromMetadata(&pkg.Metadata), nil
+}
+
+// InspectSizes returns the size and content of each component recorded when the package was created.
+func InspectSizes(ctx context.Context, opt ZarfInspectOptions) ([]v1alpha1.ZarfComponentBuildData, error) {
+	pkg, err := getPackageMetadata(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkg.Build.Components) == 0 {
+		return nil, fmt.Errorf("failed listing sizes: package %s was created without component sizes", pkg.Metadata.Name)
+	}
+	return pkg.Build.Components, nil
+}
+
+// InspectSignatures returns the signatures of a package and their timestamps.
+func InspectSignatures(ctx context.Context, opt ZarfInspectOptions) ([]layout.PackageSignature, error) {
+	loadOpt := LoadOptions{
+		Source:                  opt.Source,
+		SkipSignatureValidation: opt.SkipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           opt.PublicKeyPath,
+		CertificateIdentity:     opt.CertificateIdentity,
+		VerificationPolicy:      opt.VerificationPolicy,
+	}
+	pkgLayout, err := LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer pkgLayout.Cleanup()
+	signatures, err := pkgLayout.Signatures()
+	if err != nil {
+		return nil, err
+	}
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("failed listing signatures: package %s is not signed", pkgLayout.Pkg.Metadata.Name)
+	}
+	return signatures, nil
+}
+
+// ChartSnapshot is the rendered manifests of a chart snapshotted when the package was created.
+type ChartSnapshot struct {
+	Component string
+	Chart     string
+	Manifest  string
+}
+
+// InspectChartSnapshots returns the rendered manifests of the charts snapshotted when the package was created.
+func InspectChartSnapshots(ctx context.Context, opt ZarfInspectOptions) ([]ChartSnapshot, error) {
+	loadOpt := LoadOptions{
+		Source:                  opt.Source,
+		SkipSignatureValidation: opt.SkipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           opt.PublicKeyPath,
+		CertificateIdentity:     opt.CertificateIdentity,
+		VerificationPolicy:      opt.VerificationPolicy,
+	}
+	pkgLayout, err := LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer pkgLayout.Cleanup()
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	snapshots := []ChartSnapshot{}
+	for _, component := range pkgLayout.Pkg.Components {
+		if len(component.Charts) == 0 {
+			continue
+		}
+		componentPath := filepath.Join(tmpDir, component.Name)
+		if err := os.MkdirAll(componentPath, helpers.ReadWriteExecuteUser); err != nil {
+			return nil, err
+		}
+		snapshotsPath, err := pkgLayout.GetComponentDir(componentPath, component.Name, layout.SnapshotsComponentDir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, chart := range component.Charts {
+			b, err := os.ReadFile(helm.SnapshotName(snapshotsPath, chart))
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, ChartSnapshot{Component: component.Name, Chart: chart.Name, Manifest: string(b)})
+		}
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("failed listing chart snapshots: package %s was created without chart snapshots", pkgLayout.Pkg.Metadata.Name)
+	}
+	return snapshots, nil
+}
+
+// InspectAttestations verifies the attestations attached to a package in a registry with the attestation key, or keyless
+// against the certificate identity, and returns them.
+func InspectAttestations(ctx context.Context, opt ZarfInspectOptions) ([]zoci.Attestation, error) {
+	srcType, err := identifySource(opt.Source)
+	if err != nil {
+		return nil, err
+	}
+	if srcType != "oci" {
+		return nil, sources.ErrAttestationsNotOCI
+	}
+	remote, err := zoci.NewRemote(ctx, opt.Source, oci.PlatformForArch(config.GetArch()))
+	if err != nil {
+		return nil, err
+	}
+	return sources.ValidatePackageAttestations(ctx, remote, opt.AttestationKeyPath, opt.CertificateIdentity, opt.KeylessAttestations)
+}
+
+// showLinkedPackage shows the skeleton or full package that a package in a registry is linked to.
+func showLinkedPackage(ctx context.Context, source string) error {
+	srcType, err := identifySource(source)
+	if err != nil || srcType != "oci" {
+		return nil
+	}
+	remote, err := zoci.NewRemote(ctx, source, oci.PlatformForArch(config.GetArch()))
+	if err != nil {
+		return err
+	}
+	linked, ok, err := remote.LinkedPackage(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	ref := fmt.Sprintf("%s/%s@%s", remote.Repo().Reference.Registry, remote.Repo().Reference.Repository, linked.Digest)
+	if linked.Platform.Architecture == zoci.SkeletonArch {
+		message.Infof("This package is linked to the skeleton package %s", ref)
+		logger.From(ctx).Info("package is linked to a skeleton package", "reference", ref)
+		return nil
+	}
+	message.Infof("This skeleton package is linked to the %s package %s", linked.Platform.Architecture, ref)
+	logger.From(ctx).Info("skeleton package is linked to a package", "architecture", linked.Platform.Architecture, "reference", ref)
+	return nil
+}
+
 func getPackageMetadata(ctx context.Context, opt ZarfInspectOptions) (v1alpha1.ZarfPackage, error) {
-	pkg, err := packageFromSourceOrCluster(ctx, opt.Cluster, opt.Source, opt.SkipSignatureValidation, opt.PublicKeyPath)
+	pkg, err := packageFromSourceOrCluster(ctx, opt.Cluster, opt.Source, opt.SkipSignatureValidation, opt.PublicKeyPath, opt.CertificateIdentity, opt.VerificationPolicy)
 	if err != nil {
 		return pkg, err
 	}
@@ -79,6 +260,8 @@ func handleSBOMOptions(ctx context.Context, opt ZarfInspectOptions) error {
 		SkipSignatureValidation: opt.SkipSignatureValidation,
 		Filter:                  filters.Empty(),
 		PublicKeyPath:           opt.PublicKeyPath,
+		CertificateIdentity:     opt.CertificateIdentity,
+		VerificationPolicy:      opt.VerificationPolicy,
 	}
 	layout, err := LoadPackage(ctx, loadOpt)
 	if err != nil {
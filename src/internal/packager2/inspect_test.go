@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestInspectAnnotations(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	opt := ZarfInspectOptions{
+		Source: "./testdata/zarf-package-test-amd64-0.0.1.tar.zst",
+	}
+	annotations, err := InspectAnnotations(ctx, opt)
+	require.NoError(t, err)
+	require.Equal(t, "test", annotations[ocispec.AnnotationTitle])
+	require.Equal(t, "0.0.1", annotations[ocispec.AnnotationVersion])
+}
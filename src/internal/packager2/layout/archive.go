@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"archive/tar"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/mholt/archiver/v3"
+)
+
+// byteRange is the half open range [start, end) of bytes within a file.
+type byteRange struct {
+	start int64
+	end   int64
+}
+
+// storedRanges returns the byte ranges of the component files marked as uncompressed within each component tarball,
+// keyed by the name of the component tarball in the package archive.
+func (p *PackageLayout) storedRanges() (map[string][]byteRange, error) {
+	ranges := map[string][]byteRange{}
+	for _, component := range p.Pkg.Components {
+		prefixes := []string{}
+		for fileIdx, file := range component.Files {
+			if file.Uncompressed {
+				prefixes = append(prefixes, fmt.Sprintf("%s/%s/%s/", component.Name, FilesComponentDir, strconv.Itoa(fileIdx)))
+			}
+		}
+		if len(prefixes) == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%s.tar", component.Name)
+		componentRanges, err := tarEntryRanges(filepath.Join(p.dirPath, ComponentsDir, name), prefixes)
+		if err != nil {
+			return nil, err
+		}
+		if len(componentRanges) > 0 {
+			ranges[ComponentsDir+"/"+name] = componentRanges
+		}
+	}
+	return ranges, nil
+}
+
+// tarEntryRanges returns the byte ranges of the contents of the regular files in the tarball with one of the prefixes.
+func tarEntryRanges(tarPath string, prefixes []string) ([]byteRange, error) {
+	f, err := os.Open(tarPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	ranges := []byteRange{}
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return ranges, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Size == 0 {
+			continue
+		}
+		for _, prefix := range prefixes {
+			if !strings.HasPrefix(hdr.Name, prefix) {
+				continue
+			}
+			// The tar reader reads whole blocks without buffering, so the file offset is where the contents start.
+			start, err := f.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, byteRange{start: start, end: start + hdr.Size})
+			break
+		}
+	}
+}
+
+// archiveWithStoredRanges writes the sources to a zstd compressed tarball like archiver.Archive, except that the byte
+// ranges of the sources are written as uncompressed zstd frames instead of being compressed.
+func archiveWithStoredRanges(sources []string, tarballPath string, ranges map[string][]byteRange) (err error) {
+	out, err := os.Create(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, out.Close())
+	}()
+	fw := &zstdFrameWriter{out: out}
+	t := archiver.NewTar()
+	if err := t.Create(fw); err != nil {
+		return err
+	}
+	for _, source := range sources {
+		sourceInfo, err := os.Stat(source)
+		if err != nil {
+			return err
+		}
+		err = filepath.Walk(source, func(fpath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			name, err := archiver.NameInArchive(sourceInfo, source, fpath)
+			if err != nil {
+				return err
+			}
+			file := archiver.File{
+				FileInfo: archiver.FileInfo{FileInfo: info, CustomName: name, SourcePath: fpath},
+			}
+			if info.Mode().IsRegular() {
+				f, err := os.Open(fpath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				file.ReadCloser = struct {
+					io.Reader
+					io.Closer
+				}{&rangeReader{r: f, ranges: ranges[name], fw: fw}, f}
+			}
+			if err := t.Write(file); err != nil {
+				return err
+			}
+			return fw.setStored(false)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := t.Close(); err != nil {
+		return err
+	}
+	return fw.Close()
+}
+
+// rangeReader switches the frame writer to uncompressed frames while the bytes within the ranges are read.
+type rangeReader struct {
+	r      io.Reader
+	off    int64
+	ranges []byteRange
+	fw     *zstdFrameWriter
+}
+
+func (rr *rangeReader) Read(b []byte) (int, error) {
+	stored := false
+	next := int64(-1)
+	for _, r := range rr.ranges {
+		if rr.off >= r.start && rr.off < r.end {
+			stored = true
+			next = r.end
+			break
+		}
+		if r.start > rr.off && (next < 0 || r.start < next) {
+			next = r.start
+		}
+	}
+	if err := rr.fw.setStored(stored); err != nil {
+		return 0, err
+	}
+	// Never read across a range boundary, the bytes read are written before the next read.
+	if next >= 0 && int64(len(b)) > next-rr.off {
+		b = b[:next-rr.off]
+	}
+	n, err := rr.r.Read(b)
+	rr.off += int64(n)
+	return n, err
+}
+
+const (
+	zstdMagic = 0xFD2FB528
+	// zstdMaxBlockSize is the maximum size of a zstd block, which is also the window size of the uncompressed frames.
+	zstdMaxBlockSize = 128 * 1024
+)
+
+// zstdFrameWriter writes a zstd stream as a sequence of frames, either compressed or made of raw blocks. Decoders read
+// concatenated frames as a single stream, so incompressible content is stored without spending time compressing it.
+type zstdFrameWriter struct {
+	out    io.Writer
+	enc    *zstd.Encoder
+	stored bool
+	// storedOpen is true once the header of an uncompressed frame has been written.
+	storedOpen bool
+	// block is the pending raw block, it is held back until more data arrives so the last block can be marked.
+	block []byte
+}
+
+func (w *zstdFrameWriter) Write(b []byte) (int, error) {
+	if !w.stored {
+		if w.enc == nil {
+			enc, err := zstd.NewWriter(w.out)
+			if err != nil {
+				return 0, err
+			}
+			w.enc = enc
+		}
+		return w.enc.Write(b)
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if !w.storedOpen {
+		// Frame header without a content size or checksum, and a window descriptor for a 128KiB window.
+		header := binary.LittleEndian.AppendUint32(nil, zstdMagic)
+		header = append(header, 0x00, 0x38)
+		if _, err := w.out.Write(header); err != nil {
+			return 0, err
+		}
+		w.storedOpen = true
+	}
+	written := 0
+	for len(b) > 0 {
+		if len(w.block) == zstdMaxBlockSize {
+			if err := w.writeBlock(false); err != nil {
+				return written, err
+			}
+		}
+		n := min(zstdMaxBlockSize-len(w.block), len(b))
+		w.block = append(w.block, b[:n]...)
+		b = b[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// writeBlock writes the pending raw block.
+func (w *zstdFrameWriter) writeBlock(last bool) error {
+	header := uint32(len(w.block)) << 3
+	if last {
+		header |= 1
+	}
+	if _, err := w.out.Write([]byte{byte(header), byte(header >> 8), byte(header >> 16)}); err != nil {
+		return err
+	}
+	if _, err := w.out.Write(w.block); err != nil {
+		return err
+	}
+	w.block = w.block[:0]
+	return nil
+}
+
+// setStored ends the current frame when switching between compressed and uncompressed frames.
+func (w *zstdFrameWriter) setStored(stored bool) error {
+	if stored == w.stored {
+		return nil
+	}
+	if err := w.endFrame(); err != nil {
+		return err
+	}
+	w.stored = stored
+	return nil
+}
+
+func (w *zstdFrameWriter) endFrame() error {
+	if w.enc != nil {
+		err := w.enc.Close()
+		w.enc = nil
+		return err
+	}
+	if w.storedOpen {
+		w.storedOpen = false
+		return w.writeBlock(true)
+	}
+	return nil
+}
+
+// Close ends the current frame.
+func (w *zstdFrameWriter) Close() error {
+	return w.endFrame()
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestArchiveWithStoredRanges(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	componentDir := filepath.Join(tmpDir, "vm", string(FilesComponentDir))
+	disk := make([]byte, 300*1024)
+	_, err := rand.Read(disk)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(componentDir, "0"), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(componentDir, "1"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(componentDir, "0", "disk.qcow2"), disk, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(componentDir, "1", "cloud-init.yaml"), bytes.Repeat([]byte("a"), 1024), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(pkgDir, ComponentsDir), 0o700))
+	require.NoError(t, archiver.Archive([]string{filepath.Join(tmpDir, "vm")}, filepath.Join(pkgDir, ComponentsDir, "vm.tar")))
+	require.NoError(t, os.WriteFile(filepath.Join(pkgDir, ZarfYAML), []byte("kind: ZarfPackageConfig"), 0o600))
+
+	pkgLayout := &PackageLayout{
+		dirPath: pkgDir,
+		Pkg: v1alpha1.ZarfPackage{
+			Components: []v1alpha1.ZarfComponent{
+				{
+					Name: "vm",
+					Files: []v1alpha1.ZarfFile{
+						{Source: "disk.qcow2", Uncompressed: true},
+						{Source: "cloud-init.yaml"},
+					},
+				},
+			},
+		},
+	}
+	ranges, err := pkgLayout.storedRanges()
+	require.NoError(t, err)
+	require.Len(t, ranges["components/vm.tar"], 1)
+
+	tarballPath := filepath.Join(tmpDir, "zarf-package-vm.tar.zst")
+	require.NoError(t, archiveWithStoredRanges([]string{filepath.Join(pkgDir, ComponentsDir), filepath.Join(pkgDir, ZarfYAML)}, tarballPath, ranges))
+
+	// The disk image is stored as raw blocks while the rest of the package is compressed.
+	b, err := os.ReadFile(tarballPath)
+	require.NoError(t, err)
+	for i := 0; i < len(disk); i += zstdMaxBlockSize {
+		require.True(t, bytes.Contains(b, disk[i:min(i+zstdMaxBlockSize, len(disk))]))
+	}
+	require.False(t, bytes.Contains(b, bytes.Repeat([]byte("a"), 1024)))
+
+	dec, err := zstd.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	defer dec.Close()
+	_, err = io.Copy(io.Discard, dec)
+	require.NoError(t, err)
+
+	outDir := filepath.Join(tmpDir, "out")
+	require.NoError(t, archiver.Unarchive(tarballPath, outDir))
+	require.FileExists(t, filepath.Join(outDir, ZarfYAML))
+	require.NoError(t, archiver.Unarchive(filepath.Join(outDir, ComponentsDir, "vm.tar"), filepath.Join(outDir, "vm")))
+	got, err := os.ReadFile(filepath.Join(outDir, "vm", "vm", string(FilesComponentDir), "0", "disk.qcow2"))
+	require.NoError(t, err)
+	require.Equal(t, disk, got)
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// parseChecksums returns the checksum of each package path in the content of checksums.txt.
+func parseChecksums(content string) (map[string]string, error) {
+	checksums := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		// If the line is empty (i.e. there is no checksum) simply skip it, this can result from a package with no images/components.
+		if line == "" {
+			continue
+		}
+		split := strings.Split(line, " ")
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid checksum line: %s", line)
+		}
+		sha := split[0]
+		rel := split[1]
+		if sha == "" || rel == "" {
+			return nil, fmt.Errorf("invalid checksum line: %s", line)
+		}
+		checksums[rel] = sha
+	}
+	return checksums, nil
+}
+
+// componentChecksum returns the SHA256 checksum of the sorted checksums.txt lines of the given package paths.
+func componentChecksum(checksums map[string]string, paths []string) (string, error) {
+	lines := []string{}
+	for _, p := range paths {
+		sha, ok := checksums[p]
+		if !ok {
+			return "", fmt.Errorf("%s is not present in the checksums", p)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", sha, p))
+	}
+	slices.Sort(lines)
+	lines = slices.Compact(lines)
+	sha := sha256.Sum256([]byte(strings.Join(lines, "\n") + "\n"))
+	return hex.EncodeToString(sha[:]), nil
+}
+
+// componentTarPath returns the package path of the tarball of the component.
+func componentTarPath(name string) string {
+	return path.Join(ComponentsDir, fmt.Sprintf("%s.tar", name))
+}
+
+// blobPath returns the package path of the image blob with the given digest.
+func blobPath(digest v1.Hash) string {
+	return path.Join(ImagesDir, "blobs", digest.Algorithm, digest.Hex)
+}
+
+// componentPaths returns the package paths of the tarball and image blobs of the component in the package at dirPath.
+// The blobs are found through the image manifests, so they must be present for every image of the component.
+func componentPaths(dirPath string, checksums map[string]string, data v1alpha1.ZarfComponentBuildData) ([]string, error) {
+	paths := []string{}
+	if _, ok := checksums[componentTarPath(data.Name)]; ok {
+		paths = append(paths, componentTarPath(data.Name))
+	}
+	for _, image := range data.Images {
+		digest, err := v1.NewHash(image.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest of image %s: %w", image.Name, err)
+		}
+		b, err := os.ReadFile(filepath.Join(dirPath, filepath.FromSlash(blobPath(digest))))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("image %s of component %s is missing from the package", image.Name, data.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(b, &manifest); err != nil {
+			return nil, fmt.Errorf("unable to read the manifest of image %s: %w", image.Name, err)
+		}
+		paths = append(paths, blobPath(digest))
+		for _, desc := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+			paths = append(paths, path.Join(ImagesDir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded()))
+		}
+	}
+	return paths, nil
+}
+
+// validateComponentChecksums verifies that the tarball and image blobs of each of the given components are present and
+// match the component checksum recorded when the package was created. Components of packages created before component
+// checksums were recorded are skipped.
+func validateComponentChecksums(dirPath string, pkg v1alpha1.ZarfPackage, checksums map[string]string, components []string) ([]string, error) {
+	required := []string{}
+	for _, name := range components {
+		idx := slices.IndexFunc(pkg.Build.Components, func(data v1alpha1.ZarfComponentBuildData) bool {
+			return data.Name == name
+		})
+		if idx == -1 || pkg.Build.Components[idx].Checksum == "" {
+			continue
+		}
+		data := pkg.Build.Components[idx]
+		paths, err := componentPaths(dirPath, checksums, data)
+		if err != nil {
+			return nil, err
+		}
+		sum, err := componentChecksum(checksums, paths)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", name, err)
+		}
+		if sum != data.Checksum {
+			return nil, fmt.Errorf("component %s does not match its checksum, expected %s but got %s", name, data.Checksum, sum)
+		}
+		required = append(required, paths...)
+	}
+	return required, nil
+}
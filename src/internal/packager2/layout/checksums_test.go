@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestValidatePartialPackageIntegrity(t *testing.T) {
+	t.Parallel()
+
+	// newPartialPackage creates a package with a files component and an images component and removes the files of the
+	// components that are not requested.
+	newPartialPackage := func(t *testing.T, requested string) *PackageLayout {
+		t.Helper()
+
+		dirPath := t.TempDir()
+		err := os.MkdirAll(filepath.Join(dirPath, ComponentsDir), 0o700)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dirPath, componentTarPath("files")), []byte("files"), helpers.ReadWriteUser)
+		require.NoError(t, err)
+		img, err := random.Image(512, 2)
+		require.NoError(t, err)
+		imgLayout, err := layout.Write(filepath.Join(dirPath, ImagesDir), empty.Index)
+		require.NoError(t, err)
+		err = imgLayout.AppendImage(img)
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		checksumContent, checksumSha, err := getChecksum(dirPath)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dirPath, Checksums), []byte(checksumContent), helpers.ReadWriteUser)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dirPath, ZarfYAML), []byte{}, helpers.ReadWriteUser)
+		require.NoError(t, err)
+		checksums, err := parseChecksums(checksumContent)
+		require.NoError(t, err)
+
+		pkg := v1alpha1.ZarfPackage{
+			Metadata: v1alpha1.ZarfMetadata{AggregateChecksum: checksumSha},
+			Build: v1alpha1.ZarfBuildData{
+				Components: []v1alpha1.ZarfComponentBuildData{
+					{Name: "files"},
+					{Name: "images", Images: []v1alpha1.ZarfImageBuildData{{Name: "ghcr.io/zarf-dev/test:1.0.0", Digest: digest.String()}}},
+				},
+			},
+		}
+		for i, data := range pkg.Build.Components {
+			paths, err := componentPaths(dirPath, checksums, data)
+			require.NoError(t, err)
+			pkg.Build.Components[i].Checksum, err = componentChecksum(checksums, paths)
+			require.NoError(t, err)
+		}
+
+		switch requested {
+		case "files":
+			err = os.RemoveAll(filepath.Join(dirPath, ImagesDir, "blobs"))
+		case "images":
+			err = os.Remove(filepath.Join(dirPath, componentTarPath("files")))
+		}
+		require.NoError(t, err)
+		return &PackageLayout{dirPath: dirPath, Pkg: pkg}
+	}
+
+	t.Run("requested components are present", func(t *testing.T) {
+		t.Parallel()
+
+		for _, requested := range []string{"files", "images"} {
+			pkgLayout := newPartialPackage(t, requested)
+			err := validatePackageIntegrity(pkgLayout, true, []string{requested})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("requested component is missing", func(t *testing.T) {
+		t.Parallel()
+
+		pkgLayout := newPartialPackage(t, "images")
+		err := validatePackageIntegrity(pkgLayout, true, []string{"files"})
+		require.EqualError(t, err, "file components/files.tar from checksum missing in layout")
+
+		pkgLayout = newPartialPackage(t, "files")
+		err = validatePackageIntegrity(pkgLayout, true, []string{"images"})
+		require.EqualError(t, err, "image ghcr.io/zarf-dev/test:1.0.0 of component images is missing from the package")
+	})
+
+	t.Run("component checksum does not match", func(t *testing.T) {
+		t.Parallel()
+
+		pkgLayout := newPartialPackage(t, "files")
+		pkgLayout.Pkg.Build.Components[0].Checksum = "invalid"
+		err := validatePackageIntegrity(pkgLayout, true, []string{"files"})
+		require.ErrorContains(t, err, "component files does not match its checksum")
+	})
+
+	t.Run("packages without component checksums", func(t *testing.T) {
+		t.Parallel()
+
+		pkgLayout := newPartialPackage(t, "images")
+		pkgLayout.Pkg.Build.Components = nil
+		err := validatePackageIntegrity(pkgLayout, true, []string{"files"})
+		require.NoError(t, err)
+	})
+}
@@ -13,27 +13,35 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
 	goyaml "github.com/goccy/go-yaml"
-	"github.com/mholt/archiver/v3"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/sign"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/git"
+	"github.com/zarf-dev/zarf/src/internal/packagemirror"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	actions2 "github.com/zarf-dev/zarf/src/internal/packager2/actions"
 	"github.com/zarf-dev/zarf/src/internal/packager2/filters"
 	"github.com/zarf-dev/zarf/src/pkg/interactive"
@@ -48,13 +56,37 @@ import (
 
 // CreateOptions are the options for creating a skeleton package.
 type CreateOptions struct {
-	Flavor                  string
-	RegistryOverrides       map[string]string
-	SigningKeyPath          string
-	SigningKeyPassword      string
-	SetVariables            map[string]string
-	SkipSBOM                bool
+	Flavor             string
+	RegistryOverrides  map[string]string
+	FlattenImages      []string
+	SigningKeyPath     string
+	SigningKeyPassword string
+	// SignChecksums also signs checksums.txt so the signature covers all of the package content.
+	SignChecksums bool
+	// TSAURL is the RFC3161 timestamp authority the signatures are timestamped by.
+	TSAURL string
+	// Keyless signs the package with a Fulcio certificate for an OIDC identity instead of a signing key.
+	Keyless bool
+	// KeylessOptions are the Fulcio, Rekor and OIDC options of keyless signing.
+	KeylessOptions utils.KeylessOptions
+	SetVariables   map[string]string
+	SkipSBOM       bool
+	// SkipSBOMViewer leaves the HTML SBOM viewers out of the package, keeping only the SBOM JSON.
+	SkipSBOMViewer          bool
 	DifferentialPackagePath string
+	// Concurrency is the number of components assembled in parallel.
+	Concurrency int
+	// SnapshotCharts stores the rendered manifests of each chart in the package to verify the charts against on deploy.
+	SnapshotCharts bool
+	// ImagePolicy restricts the images the package can contain.
+	ImagePolicy lint.ImagePolicy
+	// OverrideImagePolicy creates the package even when its images violate the image policy, warning about them.
+	OverrideImagePolicy bool
+	// RegistryPresets authenticate image pulls from their registries and set the labels the images must have.
+	RegistryPresets []types.RegistryPreset
+	// Architectures creates a single package for multiple architectures, with the images of each architecture and the
+	// components of all of them.
+	Architectures []string
 }
 
 func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (*PackageLayout, error) {
@@ -66,39 +98,48 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 		return nil, err
 	}
 
-	pkg, err := loadPackage(ctx, packagePath, opt.Flavor, opt.SetVariables)
+	if err := validateArchitectures(opt.Architectures); err != nil {
+		return nil, err
+	}
+	pkg, err := loadPackage(ctx, packagePath, opt.Flavor, opt.Architectures, opt.SetVariables)
 	if err != nil {
 		return nil, err
 	}
+	if err := helm.ValidateEngineVersion(helm.EngineVersion(), pkg.Metadata.MinimumHelmVersion); err != nil {
+		return nil, err
+	}
+
+	if err := checkImagePolicy(ctx, pkg, packagePath, opt.ImagePolicy, opt.OverrideImagePolicy); err != nil {
+		return nil, err
+	}
 
+	var differentialBase map[string]v1alpha1.ZarfComponentBuildData
 	if opt.DifferentialPackagePath != "" {
 		l.Debug("creating differential package", "differential", opt.DifferentialPackagePath)
-		layoutOpt := PackageLayoutOptions{
-			SkipSignatureValidation: true,
+		diffPkg, diffIndex, err := loadDifferentialPackage(ctx, opt.DifferentialPackagePath, pkg.Metadata.Architecture)
+		if err != nil {
+			return nil, err
 		}
-		diffPkgLayout, err := LoadFromTar(ctx, opt.DifferentialPackagePath, layoutOpt)
+		differentialBase = differentialBaseData(diffPkg)
+		allIncludedImagesMap, err := differentialImages(diffPkg, diffIndex, pkg.Components)
 		if err != nil {
 			return nil, err
 		}
-		allIncludedImagesMap := map[string]bool{}
 		allIncludedReposMap := map[string]bool{}
-		for _, component := range diffPkgLayout.Pkg.Components {
-			for _, image := range component.Images {
-				allIncludedImagesMap[image] = true
-			}
+		for _, component := range diffPkg.Components {
 			for _, repo := range component.Repos {
 				allIncludedReposMap[repo] = true
 			}
 		}
 
 		pkg.Build.Differential = true
-		pkg.Build.DifferentialPackageVersion = diffPkgLayout.Pkg.Metadata.Version
+		pkg.Build.DifferentialPackageVersion = diffPkg.Metadata.Version
 
-		versionsMatch := diffPkgLayout.Pkg.Metadata.Version == pkg.Metadata.Version
+		versionsMatch := diffPkg.Metadata.Version == pkg.Metadata.Version
 		if versionsMatch {
 			return nil, errors.New(lang.PkgCreateErrDifferentialSameVersion)
 		}
-		noVersionSet := diffPkgLayout.Pkg.Metadata.Version == "" || pkg.Metadata.Version == ""
+		noVersionSet := diffPkg.Metadata.Version == "" || pkg.Metadata.Version == ""
 		if noVersionSet {
 			return nil, errors.New(lang.PkgCreateErrDifferentialNoVersion)
 		}
@@ -109,44 +150,68 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 		}
 	}
 
-	for _, component := range pkg.Components {
-		err := assemblePackageComponent(ctx, component, packagePath, buildPath)
+	if err := captureReleaseChecksums(ctx, pkg.Components); err != nil {
+		return nil, err
+	}
+
+	contentData, err := assemblePackageComponents(ctx, pkg.Components, pkg.Constants, packagePath, buildPath, opt.Concurrency, opt.SnapshotCharts, differentialBase)
+	if err != nil {
+		return nil, err
+	}
+
+	archs := []string{pkg.Metadata.Architecture}
+	if len(opt.Architectures) > 0 {
+		archs = opt.Architectures
+	}
+	sbomImageList := []transform.Image{}
+	pulled := map[string]map[transform.Image]v1.Image{}
+	// The images of each architecture are pulled into their own layout and merged after all are pulled.
+	archImagePaths := map[string]string{}
+	archImagesDir := ""
+	if len(opt.Architectures) > 0 {
+		archImagesDir, err = utils.MakeTempDir(config.CommonOptions.TempDirectory)
 		if err != nil {
 			return nil, err
 		}
+		defer os.RemoveAll(archImagesDir)
 	}
-
-	componentImages := []transform.Image{}
-	for _, component := range pkg.Components {
-		for _, src := range component.Images {
-			refInfo, err := transform.ParseImageRef(src)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
-			}
-			if slices.Contains(componentImages, refInfo) {
-				continue
-			}
-			componentImages = append(componentImages, refInfo)
+	for _, arch := range archs {
+		componentImages, err := imagesForArch(pkg.Components, arch)
+		if err != nil {
+			return nil, err
+		}
+		if len(componentImages) == 0 {
+			continue
 		}
-	}
-	sbomImageList := []transform.Image{}
-	if len(componentImages) > 0 {
 		cachePath, err := config.GetAbsCachePath()
 		if err != nil {
 			return nil, err
 		}
+		imagesPath := filepath.Join(buildPath, ImagesDir)
+		if archImagesDir != "" {
+			imagesPath = filepath.Join(archImagesDir, arch)
+			archImagePaths[arch] = imagesPath
+		}
 		pullCfg := images.PullConfig{
-			DestinationDirectory: filepath.Join(buildPath, ImagesDir),
+			DestinationDirectory: imagesPath,
 			ImageList:            componentImages,
-			Arch:                 pkg.Metadata.Architecture,
+			Arch:                 arch,
 			RegistryOverrides:    opt.RegistryOverrides,
 			CacheDirectory:       filepath.Join(cachePath, ImagesDir),
+			FlattenImages:        opt.FlattenImages,
+			RegistryPresets:      opt.RegistryPresets,
 		}
-		pulled, err := images.Pull(ctx, pullCfg)
+		pulled[arch], err = images.Pull(ctx, pullCfg)
 		if err != nil {
 			return nil, err
 		}
-		for info, img := range pulled {
+		if err := checkRequiredLabels(ctx, pulled[arch], opt.RegistryPresets, opt.OverrideImagePolicy); err != nil {
+			return nil, err
+		}
+		for info, img := range pulled[arch] {
+			if slices.Contains(sbomImageList, info) {
+				continue
+			}
 			ok, err := utils.OnlyHasImageLayers(img)
 			if err != nil {
 				return nil, fmt.Errorf("failed to validate %s is an image and not an artifact: %w", info, err)
@@ -155,7 +220,14 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 				sbomImageList = append(sbomImageList, info)
 			}
 		}
-
+	}
+	if len(archImagePaths) > 0 {
+		err = images.MergeArchitectures(filepath.Join(buildPath, ImagesDir), archImagePaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(pulled) > 0 {
 		// Sort images index to make build reproducible.
 		err = utils.SortImagesIndex(filepath.Join(buildPath, ImagesDir))
 		if err != nil {
@@ -167,7 +239,7 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 
 	if !opt.SkipSBOM {
 		l.Info("generating SBOM")
-		err = generateSBOM(ctx, pkg, buildPath, sbomImageList)
+		err = generateSBOM(ctx, pkg, buildPath, sbomImageList, opt.SkipSBOMViewer)
 		if err != nil {
 			return nil, err
 		}
@@ -185,6 +257,26 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 	pkg.Metadata.AggregateChecksum = checksumSha
 
 	pkg = recordPackageMetadata(pkg, opt.Flavor, opt.RegistryOverrides)
+	pkg.Build.FlattenedImages = opt.FlattenImages
+	pkg.Build.Architectures = opt.Architectures
+	pkg.Build.Components, err = componentBuildData(buildPath, pkg.Components, pulled, len(opt.Architectures) > 0, contentData)
+	if err != nil {
+		return nil, err
+	}
+	checksums, err := parseChecksums(checksumContent)
+	if err != nil {
+		return nil, err
+	}
+	for i, data := range pkg.Build.Components {
+		paths, err := componentPaths(buildPath, checksums, data)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Build.Components[i].Checksum, err = componentChecksum(checksums, paths)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", data.Name, err)
+		}
+	}
 
 	b, err := goyaml.Marshal(pkg)
 	if err != nil {
@@ -195,7 +287,11 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 		return nil, err
 	}
 
-	err = signPackage(buildPath, opt.SigningKeyPath, opt.SigningKeyPassword)
+	if opt.Keyless {
+		err = signPackageKeyless(buildPath, opt.SignChecksums, opt.KeylessOptions)
+	} else {
+		err = signPackage(buildPath, opt.SigningKeyPath, opt.SigningKeyPassword, opt.SignChecksums, opt.TSAURL)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -210,9 +306,96 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 	return pkgLayout, nil
 }
 
+// imagesForArch returns the images of the components that are compatible with the architecture.
+func imagesForArch(components []v1alpha1.ZarfComponent, arch string) ([]transform.Image, error) {
+	componentImages := []transform.Image{}
+	for _, component := range components {
+		if component.Only.Cluster.Architecture != "" && component.Only.Cluster.Architecture != arch {
+			continue
+		}
+		for _, src := range component.Images {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			if slices.Contains(componentImages, refInfo) {
+				continue
+			}
+			componentImages = append(componentImages, refInfo)
+		}
+	}
+	return componentImages, nil
+}
+
+// validateArchitectures validates the architectures of a multi-architecture package.
+func validateArchitectures(archs []string) error {
+	if len(archs) == 0 {
+		return nil
+	}
+	if len(archs) < 2 {
+		return errors.New("at least two architectures are required to create a multi-architecture package")
+	}
+	for i, arch := range archs {
+		if arch == "" || arch == zoci.MultiArch || arch == zoci.SkeletonArch {
+			return fmt.Errorf("invalid package architecture %q", arch)
+		}
+		if slices.Contains(archs[:i], arch) {
+			return fmt.Errorf("architecture %s is listed more than once", arch)
+		}
+	}
+	return nil
+}
+
+// loadDifferentialPackage returns the package a differential package is created against and its image index.
+// Packages in a registry only have their zarf.yaml and image index fetched instead of being pulled in full.
+func loadDifferentialPackage(ctx context.Context, source, arch string) (v1alpha1.ZarfPackage, *ocispec.Index, error) {
+	if helpers.IsOCIURL(source) {
+		remote, err := zoci.NewRemote(ctx, source, oci.PlatformForArch(arch))
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, nil, err
+		}
+		root, err := remote.FetchRoot(ctx)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, nil, fmt.Errorf("unable to resolve differential package %s: %w", source, err)
+		}
+		pkg, err := remote.FetchZarfYAML(ctx)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, nil, err
+		}
+		// Packages without images have no image index.
+		if oci.IsEmptyDescriptor(root.Locate(filepath.Join(ImagesDir, IndexJSON))) {
+			return pkg, &ocispec.Index{}, nil
+		}
+		index, err := remote.FetchImagesIndex(ctx)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, nil, err
+		}
+		return pkg, index, nil
+	}
+	layoutOpt := PackageLayoutOptions{
+		SkipSignatureValidation: true,
+	}
+	diffPkgLayout, err := LoadFromTar(ctx, source, layoutOpt)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, nil, err
+	}
+	defer diffPkgLayout.Cleanup()
+	index := &ocispec.Index{}
+	b, err := os.ReadFile(filepath.Join(diffPkgLayout.dirPath, ImagesDir, IndexJSON))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return v1alpha1.ZarfPackage{}, nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, index); err != nil {
+			return v1alpha1.ZarfPackage{}, nil, err
+		}
+	}
+	return diffPkgLayout.Pkg, index, nil
+}
+
 // CreateSkeleton creates a skeleton package and returns the path to the created package.
 func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions) (string, error) {
-	pkg, err := loadPackage(ctx, packagePath, opt.Flavor, nil)
+	pkg, err := loadPackage(ctx, packagePath, opt.Flavor, nil, nil)
 	if err != nil {
 		return "", err
 	}
@@ -224,7 +407,7 @@ func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions)
 	}
 
 	for _, component := range pkg.Components {
-		err := assembleSkeletonComponent(component, packagePath, buildPath)
+		err := assembleSkeletonComponent(ctx, component, packagePath, buildPath)
 		if err != nil {
 			return "", err
 		}
@@ -252,7 +435,11 @@ func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions)
 		return "", err
 	}
 
-	err = signPackage(buildPath, opt.SigningKeyPath, opt.SigningKeyPassword)
+	if opt.Keyless {
+		err = signPackageKeyless(buildPath, opt.SignChecksums, opt.KeylessOptions)
+	} else {
+		err = signPackage(buildPath, opt.SigningKeyPath, opt.SigningKeyPassword, opt.SignChecksums, opt.TSAURL)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -260,7 +447,7 @@ func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions)
 	return buildPath, nil
 }
 
-func loadPackage(ctx context.Context, packagePath, flavor string, setVariables map[string]string) (v1alpha1.ZarfPackage, error) {
+func loadPackage(ctx context.Context, packagePath, flavor string, architectures []string, setVariables map[string]string) (v1alpha1.ZarfPackage, error) {
 	b, err := os.ReadFile(filepath.Join(packagePath, ZarfYAML))
 	if err != nil {
 		return v1alpha1.ZarfPackage{}, err
@@ -270,10 +457,18 @@ func loadPackage(ctx context.Context, packagePath, flavor string, setVariables m
 		return v1alpha1.ZarfPackage{}, err
 	}
 	pkg.Metadata.Architecture = config.GetArch(pkg.Metadata.Architecture)
-	pkg, err = resolveImports(ctx, pkg, packagePath, pkg.Metadata.Architecture, flavor, map[string]interface{}{})
+	archs := []string{pkg.Metadata.Architecture}
+	if len(architectures) > 0 {
+		pkg.Metadata.Architecture = zoci.MultiArch
+		archs = architectures
+	}
+	pkg, err = resolveImports(ctx, pkg, packagePath, archs, flavor, map[string]interface{}{})
 	if err != nil {
 		return v1alpha1.ZarfPackage{}, err
 	}
+	for i, component := range pkg.Components {
+		pkg.Components[i] = selectValuesLayers(component, flavor)
+	}
 	if setVariables != nil {
 		pkg, _, err = fillActiveTemplate(ctx, pkg, setVariables)
 		if err != nil {
@@ -306,21 +501,153 @@ func validate(pkg v1alpha1.ZarfPackage, packagePath string, setVariables map[str
 	}
 }
 
-func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath string) error {
+// checkImagePolicy returns the images of the package that violate the image policy as a lint error. When the policy is
+// overridden the violations are warned about instead.
+func checkImagePolicy(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath string, policy lint.ImagePolicy, override bool) error {
+	l := logger.From(ctx)
+	findings := []lint.PackageFinding{}
+	for i, component := range pkg.Components {
+		findings = append(findings, lint.CheckImagePolicy(component, i, policy)...)
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	if !override {
+		return &lint.LintError{
+			BaseDir:     packagePath,
+			PackageName: pkg.Metadata.Name,
+			Findings:    findings,
+		}
+	}
+	for _, finding := range findings {
+		message.Warnf("Overriding the image policy: %s", finding.ItemizedDescription())
+		l.Warn("overriding the image policy", "image", finding.Item, "reason", finding.Description)
+	}
+	return nil
+}
+
+// checkRequiredLabels returns an error for each pulled image from a registry preset that is missing labels the preset
+// requires. When the image policy is overridden the missing labels are warned about instead.
+func checkRequiredLabels(ctx context.Context, pulled map[transform.Image]v1.Image, presets []types.RegistryPreset, override bool) error {
+	l := logger.From(ctx)
+	refInfos := slices.Collect(maps.Keys(pulled))
+	slices.SortFunc(refInfos, func(a, b transform.Image) int {
+		return strings.Compare(a.Reference, b.Reference)
+	})
+	var errs []error
+	for _, refInfo := range refInfos {
+		preset, ok := types.MatchRegistryPreset(presets, refInfo.Reference)
+		if !ok || len(preset.RequiredLabels) == 0 {
+			continue
+		}
+		cfg, err := pulled[refInfo].ConfigFile()
+		if err != nil {
+			return fmt.Errorf("unable to read the config of image %s: %w", refInfo.Reference, err)
+		}
+		missing := []string{}
+		for _, label := range preset.RequiredLabels {
+			if _, ok := cfg.Config.Labels[label]; !ok {
+				missing = append(missing, label)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if override {
+			message.Warnf("Overriding the image policy: image %s is missing the labels %s required by the registry preset %s",
+				refInfo.Reference, strings.Join(missing, ", "), preset.Name)
+			l.Warn("overriding the image policy", "image", refInfo.Reference, "preset", preset.Name, "missingLabels", missing)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("image %s is missing the labels %s required by the registry preset %s",
+			refInfo.Reference, strings.Join(missing, ", "), preset.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// captureReleaseChecksums sets the shasum of release asset files without one to the digest reported by the release, so
+// that the asset is verified when the package is created and deployed.
+func captureReleaseChecksums(ctx context.Context, components []v1alpha1.ZarfComponent) error {
+	for i, component := range components {
+		for j, file := range component.Files {
+			if !files.IsRelease(file.Source) || file.Shasum != "" || file.Extract || file.ExtractPath != "" {
+				continue
+			}
+			src, err := files.ParseReleaseSource(file.Source)
+			if err != nil {
+				return err
+			}
+			asset, err := files.ResolveReleaseAsset(ctx, src)
+			if err != nil {
+				return err
+			}
+			if asset.Digest == "" {
+				logger.From(ctx).Warn("release does not report a digest for the asset, set a shasum for the file to verify it", "source", file.Source)
+				continue
+			}
+			logger.From(ctx).Debug("captured checksum of release asset", "source", file.Source, "shasum", asset.Digest)
+			components[i].Files[j].Shasum = asset.Digest
+		}
+	}
+	return nil
+}
+
+// assemblePackageComponents assembles the components of a package with up to concurrency components assembled in parallel
+// and returns the chart and file checksums of each component. Charts and files that did not change from the base
+// components of a differential package are left out.
+// Each component logs with its name so that the output of components assembled in parallel can be told apart.
+// The constants of the package are available to the create actions of the components.
+func assemblePackageComponents(ctx context.Context, components []v1alpha1.ZarfComponent, constants []v1alpha1.Constant, packagePath, buildPath string, concurrency int, snapshotCharts bool, base map[string]v1alpha1.ZarfComponentBuildData) (map[string]v1alpha1.ZarfComponentBuildData, error) {
+	l := logger.From(ctx)
+	var mu sync.Mutex
+	buildData := map[string]v1alpha1.ZarfComponentBuildData{}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(concurrency, 1))
+	for _, component := range components {
+		g.Go(func() error {
+			if err := gCtx.Err(); err != nil {
+				return err
+			}
+			componentCtx := logger.WithContext(gCtx, l.With("component", component.Name))
+			logger.From(componentCtx).Info("assembling component")
+			var componentBase *v1alpha1.ZarfComponentBuildData
+			if b, ok := base[component.Name]; ok {
+				componentBase = &b
+			}
+			data, err := assemblePackageComponent(componentCtx, component, constants, packagePath, buildPath, snapshotCharts, componentBase)
+			if err != nil {
+				return fmt.Errorf("unable to assemble component %s: %w", component.Name, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			buildData[component.Name] = data
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return buildData, nil
+}
+
+func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfComponent, constants []v1alpha1.Constant, packagePath, buildPath string, snapshotCharts bool, base *v1alpha1.ZarfComponentBuildData) (v1alpha1.ZarfComponentBuildData, error) {
 	tmpBuildPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
-		return err
+		return v1alpha1.ZarfComponentBuildData{}, err
 	}
 	defer os.RemoveAll(tmpBuildPath)
 	compBuildPath := filepath.Join(tmpBuildPath, component.Name)
 	err = os.MkdirAll(compBuildPath, 0o700)
 	if err != nil {
-		return err
+		return v1alpha1.ZarfComponentBuildData{}, err
 	}
 
+	// Each component gets its own variable config as variables set by actions must not leak between components assembled in parallel.
+	variableConfig := template.GetZarfVariableConfig(ctx)
+	variableConfig.SetConstants(constants)
 	onCreate := component.Actions.OnCreate
-	if err := actions2.Run(ctx, packagePath, onCreate.Defaults, onCreate.Before, nil); err != nil {
-		return fmt.Errorf("unable to run component before action: %w", err)
+	if err := actions2.Run(ctx, packagePath, onCreate.Defaults, onCreate.Before, variableConfig); err != nil {
+		return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to run component before action: %w", err)
 	}
 
 	// If any helm charts are defined, process them.
@@ -329,17 +656,33 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 		if chart.LocalPath != "" {
 			chart.LocalPath = filepath.Join(packagePath, chart.LocalPath)
 		}
-		oldValuesFiles := chart.ValuesFiles
 		valuesFiles := []string{}
 		for _, v := range chart.ValuesFiles {
 			valuesFiles = append(valuesFiles, filepath.Join(packagePath, v))
 		}
 		chart.ValuesFiles = valuesFiles
+		valuesLayers := []v1alpha1.ZarfValuesLayer{}
+		for _, layer := range chart.ValuesLayers {
+			layerFiles := []string{}
+			for _, v := range layer.Files {
+				if !helpers.IsURL(v) {
+					v = filepath.Join(packagePath, v)
+				}
+				layerFiles = append(layerFiles, v)
+			}
+			layer.Files = layerFiles
+			valuesLayers = append(valuesLayers, layer)
+		}
+		chart.ValuesLayers = valuesLayers
 		helmCfg := helm.New(chart, filepath.Join(compBuildPath, string(ChartsComponentDir)), filepath.Join(compBuildPath, string(ValuesComponentDir)))
 		if err := helmCfg.PackageChart(ctx, filepath.Join(compBuildPath, string(ChartsComponentDir))); err != nil {
-			return err
+			return v1alpha1.ZarfComponentBuildData{}, err
+		}
+	}
+	if snapshotCharts {
+		if err := snapshotComponentCharts(ctx, component, constants, compBuildPath); err != nil {
+			return v1alpha1.ZarfComponentBuildData{}, err
 		}
-		chart.ValuesFiles = oldValuesFiles
 	}
 
 	for filesIdx, file := range component.Files {
@@ -347,41 +690,26 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 		dst := filepath.Join(compBuildPath, rel)
 		destinationDir := filepath.Dir(dst)
 
-		if helpers.IsURL(file.Source) {
-			if file.ExtractPath != "" {
-				// get the compressedFileName from the source
-				compressedFileName, err := helpers.ExtractBasePathFromURL(file.Source)
-				if err != nil {
-					return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err.Error())
-				}
-				tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
-				if err != nil {
-					return err
-				}
-				defer os.RemoveAll(tmpDir)
-				compressedFile := filepath.Join(tmpDir, compressedFileName)
-
-				// If the file is an archive, download it to the componentPath.Temp
-				if err := utils.DownloadToFile(ctx, file.Source, compressedFile, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
-				}
-				err = archiver.Extract(compressedFile, file.ExtractPath, destinationDir)
-				if err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, compressedFileName, err.Error())
-				}
-			} else {
-				if err := utils.DownloadToFile(ctx, file.Source, dst, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
-				}
+		if file.Extract {
+			if err := files.Unpack(ctx, file, packagePath, dst, component.DeprecatedCosignKeyPath); err != nil {
+				return v1alpha1.ZarfComponentBuildData{}, err
+			}
+		} else if files.IsRemote(file.Source) {
+			if err := files.Pull(ctx, file, dst, component.DeprecatedCosignKeyPath); err != nil {
+				return v1alpha1.ZarfComponentBuildData{}, err
 			}
 		} else {
 			if file.ExtractPath != "" {
-				if err := archiver.Extract(filepath.Join(packagePath, file.Source), file.ExtractPath, destinationDir); err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
+				if err := files.Extract(filepath.Join(packagePath, file.Source), file.ExtractFormat, file.ExtractPath, destinationDir); err != nil {
+					return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
+				}
+			} else if file.LargeBinary && !helpers.IsDir(filepath.Join(packagePath, file.Source)) {
+				if err := files.CopySparse(filepath.Join(packagePath, file.Source), dst); err != nil {
+					return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to copy file %s: %w", file.Source, err)
 				}
 			} else {
 				if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, file.Source), dst); err != nil {
-					return fmt.Errorf("unable to copy file %s: %w", file.Source, err)
+					return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to copy file %s: %w", file.Source, err)
 				}
 			}
 		}
@@ -391,27 +719,27 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 			updatedExtractedFileOrDir := filepath.Join(destinationDir, file.ExtractPath)
 			if updatedExtractedFileOrDir != dst {
 				if err := os.Rename(updatedExtractedFileOrDir, dst); err != nil {
-					return fmt.Errorf(lang.ErrWritingFile, dst, err)
+					return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf(lang.ErrWritingFile, dst, err)
 				}
 			}
 		}
 
-		// Abort packaging on invalid shasum (if one is specified).
-		if file.Shasum != "" {
+		// Abort packaging on invalid shasum (if one is specified), extracted archives are verified before they are extracted.
+		if file.Shasum != "" && !file.Extract {
 			if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
-				return err
+				return v1alpha1.ZarfComponentBuildData{}, err
 			}
 		}
 
 		if file.Executable || helpers.IsDir(dst) {
 			err := os.Chmod(dst, helpers.ReadWriteExecuteUser)
 			if err != nil {
-				return err
+				return v1alpha1.ZarfComponentBuildData{}, err
 			}
 		} else {
 			err := os.Chmod(dst, helpers.ReadWriteUser)
 			if err != nil {
-				return err
+				return v1alpha1.ZarfComponentBuildData{}, err
 			}
 		}
 	}
@@ -422,11 +750,11 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 
 		if helpers.IsURL(data.Source) {
 			if err := utils.DownloadToFile(ctx, data.Source, dst, component.DeprecatedCosignKeyPath); err != nil {
-				return fmt.Errorf(lang.ErrDownloading, data.Source, err.Error())
+				return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf(lang.ErrDownloading, data.Source, err.Error())
 			}
 		} else {
 			if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, data.Source), dst); err != nil {
-				return fmt.Errorf("unable to copy data injection %s: %s", data.Source, err.Error())
+				return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to copy data injection %s: %s", data.Source, err.Error())
 			}
 		}
 	}
@@ -435,7 +763,7 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 	if len(component.Manifests) > 0 {
 		err := os.MkdirAll(filepath.Join(compBuildPath, string(ManifestsComponentDir)), 0o700)
 		if err != nil {
-			return err
+			return v1alpha1.ZarfComponentBuildData{}, err
 		}
 	}
 	for _, manifest := range component.Manifests {
@@ -446,11 +774,11 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 			// Copy manifests without any processing.
 			if helpers.IsURL(path) {
 				if err := utils.DownloadToFile(ctx, path, dst, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, path, err.Error())
+					return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf(lang.ErrDownloading, path, err.Error())
 				}
 			} else {
 				if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, path), dst); err != nil {
-					return fmt.Errorf("unable to copy manifest %s: %w", path, err)
+					return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to copy manifest %s: %w", path, err)
 				}
 			}
 		}
@@ -464,8 +792,8 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 			if !helpers.IsURL(path) {
 				path = filepath.Join(packagePath, path)
 			}
-			if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory); err != nil {
-				return fmt.Errorf("unable to build kustomization %s: %w", path, err)
+			if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory, manifest.KustomizeOptions); err != nil {
+				return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to build kustomization %s: %w", path, err)
 			}
 		}
 	}
@@ -473,37 +801,54 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 	// Load all specified git repos.
 	for _, url := range component.Repos {
 		// Pull all the references if there is no `@` in the string.
-		_, err := git.Clone(ctx, filepath.Join(compBuildPath, string(RepoComponentDir)), url, false)
+		_, err := git.CloneWithOptions(ctx, filepath.Join(compBuildPath, string(RepoComponentDir)), url, git.NewCloneOptions(component.GetRepoOptions(url)))
 		if err != nil {
-			return fmt.Errorf("unable to pull git repo %s: %w", url, err)
+			return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to pull git repo %s: %w", url, err)
 		}
 	}
 
-	if err := actions2.Run(ctx, packagePath, onCreate.Defaults, onCreate.After, nil); err != nil {
-		return fmt.Errorf("unable to run component after action: %w", err)
+	for mirrorIdx, mirror := range component.PackageMirrors {
+		dst := filepath.Join(compBuildPath, string(MirrorsComponentDir), strconv.Itoa(mirrorIdx))
+		if err := packagemirror.Pull(ctx, mirror, filepath.Join(packagePath, mirror.Source), dst); err != nil {
+			return v1alpha1.ZarfComponentBuildData{}, err
+		}
+	}
+
+	if err := actions2.Run(ctx, packagePath, onCreate.Defaults, onCreate.After, variableConfig); err != nil {
+		return v1alpha1.ZarfComponentBuildData{}, fmt.Errorf("unable to run component after action: %w", err)
+	}
+
+	buildData := v1alpha1.ZarfComponentBuildData{Name: component.Name}
+	buildData.Charts, buildData.Files, err = contentBuildData(compBuildPath, component, base)
+	if err != nil {
+		return v1alpha1.ZarfComponentBuildData{}, err
+	}
+	buildData.Resources, err = componentResourceEstimate(ctx, component, compBuildPath, variableConfig)
+	if err != nil {
+		return v1alpha1.ZarfComponentBuildData{}, err
 	}
 
 	// Write the tar component.
 	entries, err := os.ReadDir(compBuildPath)
 	if err != nil {
-		return err
+		return v1alpha1.ZarfComponentBuildData{}, err
 	}
 	if len(entries) == 0 {
-		return nil
+		return buildData, nil
 	}
 	tarPath := filepath.Join(buildPath, "components", fmt.Sprintf("%s.tar", component.Name))
 	err = os.MkdirAll(filepath.Join(buildPath, "components"), 0o700)
 	if err != nil {
-		return err
+		return v1alpha1.ZarfComponentBuildData{}, err
 	}
 	err = createReproducibleTarballFromDir(compBuildPath, component.Name, tarPath, false)
 	if err != nil {
-		return err
+		return v1alpha1.ZarfComponentBuildData{}, err
 	}
-	return nil
+	return buildData, nil
 }
 
-func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, buildPath string) error {
+func assembleSkeletonComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath string) error {
 	tmpBuildPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
 		return err
@@ -540,10 +885,27 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 				return fmt.Errorf("unable to copy chart values file %s: %w", path, err)
 			}
 		}
+
+		// Values layer files are stored after the values files.
+		valuesIdx := len(chart.ValuesFiles)
+		for layerIdx, layer := range chart.ValuesLayers {
+			for fileIdx, path := range layer.Files {
+				rel := fmt.Sprintf("%s-%d", helm.StandardName(string(ValuesComponentDir), chart), valuesIdx)
+				valuesIdx++
+				if helpers.IsURL(path) {
+					continue
+				}
+				component.Charts[chartIdx].ValuesLayers[layerIdx].Files[fileIdx] = rel
+
+				if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, path), filepath.Join(compBuildPath, rel)); err != nil {
+					return fmt.Errorf("unable to copy chart values file %s: %w", path, err)
+				}
+			}
+		}
 	}
 
 	for filesIdx, file := range component.Files {
-		if helpers.IsURL(file.Source) {
+		if files.IsRemote(file.Source) {
 			continue
 		}
 
@@ -551,8 +913,12 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 		dst := filepath.Join(compBuildPath, rel)
 		destinationDir := filepath.Dir(dst)
 
-		if file.ExtractPath != "" {
-			if err := archiver.Extract(filepath.Join(packagePath, file.Source), file.ExtractPath, destinationDir); err != nil {
+		if file.Extract {
+			if err := files.Unpack(ctx, file, packagePath, dst, ""); err != nil {
+				return err
+			}
+		} else if file.ExtractPath != "" {
+			if err := files.Extract(filepath.Join(packagePath, file.Source), file.ExtractFormat, file.ExtractPath, destinationDir); err != nil {
 				return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
 			}
 
@@ -572,11 +938,19 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 		// Change the source to the new relative source directory (any remote files will have been skipped above)
 		component.Files[filesIdx].Source = rel
 
-		// Remove the extractPath from a skeleton since it will already extract it
+		// Remove the extract options from a skeleton since it will already extract it
 		component.Files[filesIdx].ExtractPath = ""
+		component.Files[filesIdx].ExtractFormat = ""
+		if file.Extract {
+			// The shasum of an extracted archive no longer applies to the extracted directory.
+			component.Files[filesIdx].Extract = false
+			component.Files[filesIdx].StripComponents = 0
+			component.Files[filesIdx].ExtractMembers = nil
+			component.Files[filesIdx].Shasum = ""
+		}
 
-		// Abort packaging on invalid shasum (if one is specified).
-		if file.Shasum != "" {
+		// Abort packaging on invalid shasum (if one is specified), extracted archives are verified before they are extracted.
+		if file.Shasum != "" && !file.Extract {
 			if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
 				return err
 			}
@@ -606,6 +980,17 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 		component.DataInjections[dataIdx].Source = rel
 	}
 
+	for mirrorIdx, mirror := range component.PackageMirrors {
+		rel := filepath.Join(string(MirrorsComponentDir), strconv.Itoa(mirrorIdx), filepath.Base(mirror.Source))
+		dst := filepath.Join(compBuildPath, rel)
+
+		if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, mirror.Source), dst); err != nil {
+			return fmt.Errorf("unable to copy package mirror source %s: %w", mirror.Source, err)
+		}
+
+		component.PackageMirrors[mirrorIdx].Source = rel
+	}
+
 	// Iterate over all manifests.
 	for manifestIdx, manifest := range component.Manifests {
 		for fileIdx, path := range manifest.Files {
@@ -626,13 +1011,14 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 			rel := filepath.Join(string(ManifestsComponentDir), kname)
 			dst := filepath.Join(compBuildPath, rel)
 
-			if err := kustomize.Build(filepath.Join(packagePath, path), dst, manifest.KustomizeAllowAnyDirectory); err != nil {
+			if err := kustomize.Build(filepath.Join(packagePath, path), dst, manifest.KustomizeAllowAnyDirectory, manifest.KustomizeOptions); err != nil {
 				return fmt.Errorf("unable to build kustomization %s: %w", path, err)
 			}
 		}
 
 		// remove kustomizations
 		component.Manifests[manifestIdx].Kustomizations = nil
+		component.Manifests[manifestIdx].KustomizeOptions = nil
 	}
 
 	// Write the tar component.
@@ -684,6 +1070,9 @@ func recordPackageMetadata(pkg v1alpha1.ZarfPackage, flavor string, registryOver
 	// Record the Zarf Version the CLI was built with.
 	pkg.Build.Version = config.CLIVersion
 
+	// Record the Helm version the CLI was built with as charts can render differently across Helm versions.
+	pkg.Build.HelmVersion = helm.EngineVersion()
+
 	// Record the time of package creation.
 	pkg.Build.Timestamp = now.Format(time.RFC1123Z)
 
@@ -695,6 +1084,91 @@ func recordPackageMetadata(pkg v1alpha1.ZarfPackage, flavor string, registryOver
 	return pkg
 }
 
+// componentBuildData returns the size of each component tarball along with the digest and compressed size of its images
+// and the chart and file checksums recorded when the component was assembled. The images of a multi-architecture package
+// are recorded once for each architecture they were pulled for.
+func componentBuildData(buildPath string, components []v1alpha1.ZarfComponent, pulled map[string]map[transform.Image]v1.Image, multiArch bool, contentData map[string]v1alpha1.ZarfComponentBuildData) ([]v1alpha1.ZarfComponentBuildData, error) {
+	archs := slices.Sorted(maps.Keys(pulled))
+	buildData := []v1alpha1.ZarfComponentBuildData{}
+	for _, component := range components {
+		data := v1alpha1.ZarfComponentBuildData{
+			Name:       component.Name,
+			ImageCount: len(component.Images),
+			Charts:     contentData[component.Name].Charts,
+			Files:      contentData[component.Name].Files,
+			Resources:  contentData[component.Name].Resources,
+		}
+		// Components without any files, charts, manifests or data injections do not have a tarball.
+		fi, err := os.Stat(filepath.Join(buildPath, ComponentsDir, fmt.Sprintf("%s.tar", component.Name)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil {
+			data.Size = fi.Size()
+		}
+
+		blobs := map[string]int64{}
+		for _, src := range component.Images {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			found := false
+			for _, arch := range archs {
+				img, ok := pulled[arch][refInfo]
+				if !ok {
+					continue
+				}
+				imageData, err := imageBuildData(src, img, blobs)
+				if err != nil {
+					return nil, err
+				}
+				if multiArch {
+					imageData.Architecture = arch
+				}
+				data.Images = append(data.Images, imageData)
+				found = true
+			}
+			if !found {
+				return nil, fmt.Errorf("image %s was not pulled", src)
+			}
+		}
+		for _, size := range blobs {
+			data.ImagesSize += size
+		}
+		buildData = append(buildData, data)
+	}
+	return buildData, nil
+}
+
+// imageBuildData returns the digest and compressed size of the image and adds its blobs to the given map.
+func imageBuildData(name string, img v1.Image, blobs map[string]int64) (v1alpha1.ZarfImageBuildData, error) {
+	digest, err := img.Digest()
+	if err != nil {
+		return v1alpha1.ZarfImageBuildData{}, fmt.Errorf("unable to get digest for %s: %w", name, err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return v1alpha1.ZarfImageBuildData{}, fmt.Errorf("unable to get manifest for %s: %w", name, err)
+	}
+	manifestSize, err := img.Size()
+	if err != nil {
+		return v1alpha1.ZarfImageBuildData{}, fmt.Errorf("unable to get size for %s: %w", name, err)
+	}
+	size := manifestSize + manifest.Config.Size
+	blobs[digest.String()] = manifestSize
+	blobs[manifest.Config.Digest.String()] = manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+		blobs[layer.Digest.String()] = layer.Size
+	}
+	return v1alpha1.ZarfImageBuildData{
+		Name:   name,
+		Digest: digest.String(),
+		Size:   size,
+	}, nil
+}
+
 func getChecksum(dirPath string) (string, string, error) {
 	checksumData := []string{}
 	err := filepath.Walk(dirPath, func(path string, info fs.FileInfo, err error) error {
@@ -728,10 +1202,15 @@ func getChecksum(dirPath string) (string, string, error) {
 	return checksumContent, hex.EncodeToString(sha[:]), nil
 }
 
-func signPackage(dirPath, signingKeyPath, signingKeyPassword string) error {
+// signPackage signs the zarf.yaml and, when signChecksums is set, the checksums.txt of the package. When tsaURL is set
+// the signatures are timestamped by the RFC3161 timestamp authority.
+func signPackage(dirPath, signingKeyPath, signingKeyPassword string, signChecksums bool, tsaURL string) error {
 	if signingKeyPath == "" {
 		return nil
 	}
+	if err := utils.CheckCosignKeyRef(signingKeyPath); err != nil {
+		return err
+	}
 	passFunc := func(_ bool) ([]byte, error) {
 		return []byte(signingKeyPassword), nil
 	}
@@ -743,16 +1222,45 @@ func signPackage(dirPath, signingKeyPath, signingKeyPassword string) error {
 		Verbose: false,
 		Timeout: options.DefaultTimeout,
 	}
-	_, err := sign.SignBlobCmd(
-		rootOpts,
-		keyOpts,
-		filepath.Join(dirPath, ZarfYAML),
-		true,
-		filepath.Join(dirPath, Signature),
-		"",
-		false)
-	if err != nil {
-		return err
+	blobs := map[string]string{ZarfYAML: Signature}
+	if signChecksums {
+		blobs[Checksums] = ChecksumsSignature
+	}
+	timestamps := map[string]string{Signature: SignatureTimestamp, ChecksumsSignature: ChecksumsSignatureTimestamp}
+	for blob, signature := range blobs {
+		keyOpts := keyOpts
+		if tsaURL != "" {
+			keyOpts.TSAServerURL = tsaURL
+			keyOpts.RFC3161TimestampPath = filepath.Join(dirPath, timestamps[signature])
+		}
+		_, err := sign.SignBlobCmd(
+			rootOpts,
+			keyOpts,
+			filepath.Join(dirPath, blob),
+			true,
+			filepath.Join(dirPath, signature),
+			"",
+			false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signPackageKeyless signs the zarf.yaml and, when signChecksums is set, the checksums.txt of the package with a Fulcio
+// certificate. The bundles with the certificates and Rekor transparency log entries are written next to the signatures.
+func signPackageKeyless(dirPath string, signChecksums bool, opts utils.KeylessOptions) error {
+	blobs := map[string]string{ZarfYAML: Signature}
+	if signChecksums {
+		blobs[Checksums] = ChecksumsSignature
+	}
+	bundles := map[string]string{Signature: SignatureBundle, ChecksumsSignature: ChecksumsSignatureBundle}
+	for blob, signature := range blobs {
+		err := utils.CosignSignBlobKeyless(filepath.Join(dirPath, blob), filepath.Join(dirPath, signature), filepath.Join(dirPath, bundles[signature]), opts)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -949,46 +1457,18 @@ func splitFile(srcPath string, chunkSize int) (err error) {
 
 	hash := sha256.New()
 	fileCount := 0
-	// TODO(mkcp): The inside of this loop should be wrapped in a closure so we can close the destination file each
-	//   iteration as soon as we're done writing.
 	for {
 		path := fmt.Sprintf("%s.part%03d", srcPath, fileCount+1)
-		dstFile, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, helpers.ReadAllWriteUser)
+		written, err := writeSplitPart(path, srcFile, hash, int64(chunkSize))
 		if err != nil {
 			return err
 		}
-		defer func(dstFile *os.File) {
-			err2 := dstFile.Close()
-			// Ignore if file is already closed
-			if !errors.Is(err2, os.ErrClosed) {
-				err = errors.Join(err, err2)
-			}
-		}(dstFile)
-
-		written, copyErr := io.CopyN(dstFile, srcFile, int64(chunkSize))
-		if copyErr != nil && !errors.Is(copyErr, io.EOF) {
-			return err
-		}
 		progressBar.Add(int(written))
 		title := fmt.Sprintf("[%d/%d] MB bytes written", progressBar.GetCurrent()/1000/1000, fi.Size()/1000/1000)
 		progressBar.Updatef(title)
 
-		_, err = dstFile.Seek(0, io.SeekStart)
-		if err != nil {
-			return err
-		}
-		_, err = io.Copy(hash, dstFile)
-		if err != nil {
-			return err
-		}
-
 		// EOF error could be returned on 0 bytes written.
 		if written == 0 {
-			// NOTE(mkcp): We have to close the file before removing it or windows will break with a file-in-use err.
-			err = dstFile.Close()
-			if err != nil {
-				return err
-			}
 			err = os.Remove(path)
 			if err != nil {
 				return err
@@ -997,7 +1477,7 @@ func splitFile(srcPath string, chunkSize int) (err error) {
 		}
 
 		fileCount++
-		if errors.Is(copyErr, io.EOF) {
+		if written < int64(chunkSize) {
 			break
 		}
 	}
@@ -1031,3 +1511,21 @@ func splitFile(srcPath string, chunkSize int) (err error) {
 
 	return nil
 }
+
+// writeSplitPart streams up to chunkSize bytes from the source into the part file and the hash, closing the part file
+// before returning so that only a single part is open at a time however large the package is.
+func writeSplitPart(path string, src io.Reader, hash io.Writer, chunkSize int64) (written int64, err error) {
+	dstFile, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, helpers.ReadAllWriteUser)
+	if err != nil {
+		return 0, err
+	}
+	// NOTE(mkcp): We have to close the file before removing it or windows will break with a file-in-use err.
+	defer func() {
+		err = errors.Join(err, dstFile.Close())
+	}()
+	written, err = io.CopyN(io.MultiWriter(dstFile, hash), src, chunkSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return written, err
+	}
+	return written, nil
+}
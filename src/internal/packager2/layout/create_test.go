@@ -4,16 +4,37 @@
 package layout
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/digitorus/timestamp"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/timestamp-authority/pkg/signer"
 	"github.com/stretchr/testify/require"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 func TestCreateSkeleton(t *testing.T) {
@@ -41,6 +62,161 @@ fb7ebee94a4479bacddd71195030a483b0b0b96d4f73f7fcd2c2c8e0fce0c5c6 components/helm
 	require.Equal(t, expectedChecksum, string(b))
 }
 
+func TestCreatePackageConcurrency(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../../zarf.schema.json")
+
+	checksums := []string{}
+	for _, concurrency := range []int{1, 4} {
+		pkgLayout, err := CreatePackage(ctx, "./testdata/zarf-package", CreateOptions{SkipSBOM: true, Concurrency: concurrency})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, pkgLayout.Cleanup())
+		})
+		b, err := os.ReadFile(filepath.Join(pkgLayout.dirPath, Checksums))
+		require.NoError(t, err)
+		// Helm writes the current time into packaged charts, so the chart component is not reproducible.
+		lines := slices.DeleteFunc(strings.Split(strings.TrimSpace(string(b)), "\n"), func(line string) bool {
+			return strings.HasSuffix(line, "components/helm-charts.tar")
+		})
+		checksums = append(checksums, strings.Join(lines, "\n"))
+	}
+	require.Len(t, strings.Split(checksums[0], "\n"), 3)
+	require.Equal(t, checksums[0], checksums[1])
+}
+
+func TestComponentBuildData(t *testing.T) {
+	t.Parallel()
+
+	buildPath := t.TempDir()
+	err := os.MkdirAll(filepath.Join(buildPath, ComponentsDir), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(buildPath, ComponentsDir, "files.tar"), make([]byte, 1024), helpers.ReadWriteUser)
+	require.NoError(t, err)
+
+	img, err := random.Image(512, 2)
+	require.NoError(t, err)
+	refInfo, err := transform.ParseImageRef("ghcr.io/zarf-dev/test:1.0.0")
+	require.NoError(t, err)
+	pulled := map[string]map[transform.Image]v1.Image{"amd64": {refInfo: img}}
+
+	components := []v1alpha1.ZarfComponent{
+		{Name: "files"},
+		{Name: "images", Images: []string{"ghcr.io/zarf-dev/test:1.0.0", "ghcr.io/zarf-dev/test:1.0.0"}},
+	}
+	buildData, err := componentBuildData(buildPath, components, pulled, false, nil)
+	require.NoError(t, err)
+	require.Len(t, buildData, 2)
+	require.Equal(t, v1alpha1.ZarfComponentBuildData{Name: "files", Size: 1024}, buildData[0])
+
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	manifestSize, err := img.Size()
+	require.NoError(t, err)
+	manifest, err := img.Manifest()
+	require.NoError(t, err)
+	imageSize := manifestSize + manifest.Config.Size + manifest.Layers[0].Size + manifest.Layers[1].Size
+	require.Equal(t, "images", buildData[1].Name)
+	require.Equal(t, int64(0), buildData[1].Size)
+	require.Equal(t, 2, buildData[1].ImageCount)
+	// Blobs shared between the images are only counted once.
+	require.Equal(t, imageSize, buildData[1].ImagesSize)
+	expectedImage := v1alpha1.ZarfImageBuildData{Name: "ghcr.io/zarf-dev/test:1.0.0", Digest: digest.String(), Size: imageSize}
+	require.Equal(t, []v1alpha1.ZarfImageBuildData{expectedImage, expectedImage}, buildData[1].Images)
+
+	_, err = componentBuildData(buildPath, []v1alpha1.ZarfComponent{{Name: "missing", Images: []string{"ghcr.io/zarf-dev/missing:1.0.0"}}}, pulled, false, nil)
+	require.EqualError(t, err, "image ghcr.io/zarf-dev/missing:1.0.0 was not pulled")
+
+	// The images of a multi-architecture package are recorded for each architecture they were pulled for.
+	armImg, err := random.Image(512, 1)
+	require.NoError(t, err)
+	pulled["arm64"] = map[transform.Image]v1.Image{refInfo: armImg}
+	components = []v1alpha1.ZarfComponent{{Name: "images", Images: []string{"ghcr.io/zarf-dev/test:1.0.0"}}}
+	buildData, err = componentBuildData(buildPath, components, pulled, true, nil)
+	require.NoError(t, err)
+	require.Len(t, buildData[0].Images, 2)
+	require.Equal(t, "amd64", buildData[0].Images[0].Architecture)
+	require.Equal(t, digest.String(), buildData[0].Images[0].Digest)
+	require.Equal(t, "arm64", buildData[0].Images[1].Architecture)
+	armDigest, err := armImg.Digest()
+	require.NoError(t, err)
+	require.Equal(t, armDigest.String(), buildData[0].Images[1].Digest)
+}
+
+func TestValidateArchitectures(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateArchitectures(nil))
+	require.NoError(t, validateArchitectures([]string{"amd64", "arm64"}))
+	require.EqualError(t, validateArchitectures([]string{"amd64"}), "at least two architectures are required to create a multi-architecture package")
+	require.EqualError(t, validateArchitectures([]string{"amd64", "multi"}), `invalid package architecture "multi"`)
+	require.EqualError(t, validateArchitectures([]string{"amd64", "arm64", "amd64"}), "architecture amd64 is listed more than once")
+}
+
+func TestCheckImagePolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "policy"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "approved", Images: []string{"registry1.dso.mil/ironbank/opensource/nginx/nginx:1.27"}},
+			{Name: "hub", Images: []string{"nginx:1.27"}},
+		},
+	}
+	policy, err := lint.ParseImagePolicy([]string{`^registry1\.dso\.mil/`}, []string{`^docker\.io/`})
+	require.NoError(t, err)
+
+	err = checkImagePolicy(ctx, pkg, "testdata", policy, false)
+	var lintErr *lint.LintError
+	require.ErrorAs(t, err, &lintErr)
+	require.Equal(t, "policy", lintErr.PackageName)
+	require.Len(t, lintErr.Findings, 1)
+	require.Equal(t, ".components.[1].images.[0]", lintErr.Findings[0].YqPath)
+
+	require.NoError(t, checkImagePolicy(ctx, pkg, "testdata", policy, true))
+	require.NoError(t, checkImagePolicy(ctx, pkg, "testdata", lint.ImagePolicy{}, false))
+}
+
+func TestCheckRequiredLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	labeledImg, err := random.Image(512, 1)
+	require.NoError(t, err)
+	labeledImg, err = mutate.Config(labeledImg, v1.Config{Labels: map[string]string{"mil.dso.ironbank.image.type": "opensource"}})
+	require.NoError(t, err)
+	unlabeledImg, err := random.Image(512, 1)
+	require.NoError(t, err)
+
+	labeled, err := transform.ParseImageRef("registry1.dso.mil/ironbank/opensource/nginx/nginx:1.27")
+	require.NoError(t, err)
+	unlabeled, err := transform.ParseImageRef("registry1.dso.mil/ironbank/opensource/redis/redis:7.4")
+	require.NoError(t, err)
+	other, err := transform.ParseImageRef("ghcr.io/zarf-dev/zarf/agent:v0.40.0")
+	require.NoError(t, err)
+	pulled := map[transform.Image]v1.Image{
+		labeled:   labeledImg,
+		unlabeled: unlabeledImg,
+		other:     unlabeledImg,
+	}
+	presets := []types.RegistryPreset{
+		{
+			Name:           "ironbank",
+			URL:            "registry1.dso.mil/ironbank",
+			RequiredLabels: []string{"mil.dso.ironbank.image.type"},
+		},
+	}
+
+	err = checkRequiredLabels(ctx, pulled, presets, false)
+	require.EqualError(t, err, "image registry1.dso.mil/ironbank/opensource/redis/redis:7.4 is missing the labels mil.dso.ironbank.image.type required by the registry preset ironbank")
+	require.NoError(t, checkRequiredLabels(ctx, pulled, presets, true))
+	require.NoError(t, checkRequiredLabels(ctx, pulled, nil, false))
+}
+
 func TestGetChecksum(t *testing.T) {
 	t.Parallel()
 
@@ -80,16 +256,183 @@ func TestSignPackage(t *testing.T) {
 	err := os.WriteFile(yamlPath, []byte("foobar"), 0o644)
 	require.NoError(t, err)
 
-	err = signPackage(tmpDir, "", "")
+	err = signPackage(tmpDir, "", "", false, "")
 	require.NoError(t, err)
 	require.NoFileExists(t, signedPath)
 
-	err = signPackage(tmpDir, "./testdata/cosign.key", "wrongpassword")
+	err = signPackage(tmpDir, "./testdata/cosign.key", "wrongpassword", false, "")
 	require.EqualError(t, err, "reading key: decrypt: encrypted: decryption failed")
 
-	err = signPackage(tmpDir, "./testdata/cosign.key", "test")
+	err = signPackage(tmpDir, "./testdata/cosign.key", "test", false, "")
 	require.NoError(t, err)
 	require.FileExists(t, signedPath)
+	require.NoFileExists(t, filepath.Join(tmpDir, ChecksumsSignature))
+}
+
+func TestSignPackageChecksums(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, ZarfYAML), []byte("foobar"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpDir, Checksums), []byte("abc foo"), 0o644)
+	require.NoError(t, err)
+	pkgLayout := &PackageLayout{dirPath: tmpDir}
+
+	// Packages signed before checksums signatures existed are still verified.
+	err = signPackage(tmpDir, "./testdata/cosign.key", "test", false, "")
+	require.NoError(t, err)
+	err = validatePackageSignature(ctx, pkgLayout, "./testdata/cosign.pub", false)
+	require.NoError(t, err)
+
+	err = signPackage(tmpDir, "./testdata/cosign.key", "test", true, "")
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(tmpDir, ChecksumsSignature))
+	err = validatePackageSignature(ctx, pkgLayout, "./testdata/cosign.pub", false)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(tmpDir, Checksums), []byte("def foo"), 0o644)
+	require.NoError(t, err)
+	err = validatePackageSignature(ctx, pkgLayout, "./testdata/cosign.pub", false)
+	require.ErrorContains(t, err, "package checksums signature did not match the provided key")
+
+	err = os.Remove(filepath.Join(tmpDir, Signature))
+	require.NoError(t, err)
+	err = validatePackageSignature(ctx, pkgLayout, "./testdata/cosign.pub", false)
+	require.EqualError(t, err, "package contains checksums.txt.sig but not zarf.yaml.sig")
+}
+
+func TestValidateTrustedSignature(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, ZarfYAML), []byte("foobar"), 0o644)
+	require.NoError(t, err)
+	pkgLayout := &PackageLayout{dirPath: tmpDir, Pkg: v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}}}
+	pub, err := os.ReadFile("./testdata/cosign.pub")
+	require.NoError(t, err)
+	policy := types.VerificationPolicy{Rules: []types.VerificationRule{{Packages: []string{"te*"}, Keys: []string{string(pub)}}}}
+	other := types.VerificationPolicy{Rules: []types.VerificationRule{{Packages: []string{"other"}, Keys: []string{string(pub)}}}}
+
+	// Packages matching the policy must be signed and their signature validation can not be skipped.
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: policy})
+	require.ErrorIs(t, err, sources.ErrPkgPolicyButNoSig)
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: policy, SkipSignatureValidation: true})
+	require.ErrorIs(t, err, sources.ErrPkgPolicyButSkipped)
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: other, SkipSignatureValidation: true})
+	require.NoError(t, err)
+
+	err = signPackage(tmpDir, "./testdata/cosign.key", "test", false, "")
+	require.NoError(t, err)
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: policy})
+	require.NoError(t, err)
+
+	// Packages that do not match the policy need a key as before.
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: other})
+	require.EqualError(t, err, "package is signed but no key was provided")
+
+	// Packages matching the policy are verified with the keys of the policy even when a key is provided.
+	untrusted := types.VerificationPolicy{Rules: []types.VerificationRule{{Repositories: []string{"ghcr.io/my-org/*"}, Keys: []string{"-----BEGIN PUBLIC KEY-----\n-----END PUBLIC KEY-----\n"}}}}
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: untrusted, Source: "oci://ghcr.io/my-org/test:1.0.0"})
+	require.ErrorContains(t, err, "package test is not signed by a trusted publisher of the verification policy")
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: untrusted, Source: "oci://ghcr.io/my-org/test:1.0.0", PublicKeyPath: "./testdata/cosign.pub"})
+	require.ErrorContains(t, err, "package test is not signed by a trusted publisher of the verification policy")
+}
+
+// startTestTSA starts an RFC3161 timestamp authority that timestamps at signedAt and returns its URL and certificate chain.
+func startTestTSA(t *testing.T, signedAt time.Time) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	chain, err := signer.NewTimestampingCertWithChain(key)
+	require.NoError(t, err)
+	chainPEM, err := cryptoutils.MarshalCertificatesToPEM(chain)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req, err := timestamp.ParseRequest(b)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ts := timestamp.Timestamp{
+			HashAlgorithm:     req.HashAlgorithm,
+			HashedMessage:     req.HashedMessage,
+			Time:              signedAt,
+			Nonce:             req.Nonce,
+			Policy:            asn1.ObjectIdentifier{1, 2, 3, 4, 1},
+			AddTSACertificate: req.Certificates,
+		}
+		resp, err := ts.CreateResponseWithOpts(chain[0], key, crypto.SHA256)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/timestamp-reply")
+		w.Write(resp) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, string(chainPEM)
+}
+
+func TestSignPackageTimestamp(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, ZarfYAML), []byte("foobar"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpDir, Checksums), []byte("abc foo"), 0o644)
+	require.NoError(t, err)
+	pkgLayout := &PackageLayout{dirPath: tmpDir, Pkg: v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}}}
+
+	signedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tsaURL, tsaChain := startTestTSA(t, signedAt)
+	err = signPackage(tmpDir, "./testdata/cosign.key", "test", true, tsaURL)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(tmpDir, SignatureTimestamp))
+	require.FileExists(t, filepath.Join(tmpDir, ChecksumsSignatureTimestamp))
+
+	signatures, err := pkgLayout.Signatures()
+	require.NoError(t, err)
+	require.Len(t, signatures, 2)
+	require.Equal(t, ZarfYAML, signatures[0].Blob)
+	require.Equal(t, Checksums, signatures[1].Blob)
+	require.NotNil(t, signatures[0].Timestamp)
+	require.True(t, signedAt.Equal(signatures[0].Timestamp.Time))
+
+	// The keys of a rotated out rule remain trusted for signatures timestamped before the rule expired.
+	pub, err := os.ReadFile("./testdata/cosign.pub")
+	require.NoError(t, err)
+	notAfter := signedAt.Add(24 * time.Hour)
+	policy := types.VerificationPolicy{
+		Rules:                []types.VerificationRule{{Packages: []string{"test"}, Keys: []string{string(pub)}, NotAfter: &notAfter}},
+		TimestampAuthorities: []string{tsaChain},
+	}
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: policy})
+	require.NoError(t, err)
+
+	// Without a trusted timestamp authority the signing time is unknown and the expired keys are not trusted.
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{VerificationPolicy: types.VerificationPolicy{Rules: policy.Rules}})
+	require.ErrorContains(t, err, "keys of rule 0 are not trusted after")
+
+	_, otherChain := startTestTSA(t, signedAt)
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{PublicKeyPath: "./testdata/cosign.pub", VerificationPolicy: types.VerificationPolicy{TimestampAuthorities: []string{otherChain}}})
+	require.ErrorContains(t, err, "is not signed by a trusted timestamp authority")
+
+	// Timestamps must cover the signature they are stored with.
+	err = signPackage(tmpDir, "./testdata/cosign.key", "test", false, "")
+	require.NoError(t, err)
+	err = validateTrustedSignature(ctx, pkgLayout, PackageLayoutOptions{PublicKeyPath: "./testdata/cosign.pub", VerificationPolicy: policy})
+	require.ErrorContains(t, err, "is not signed by a trusted timestamp authority")
 }
 
 func TestCreateReproducibleTarballFromDir(t *testing.T) {
@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+// templateMarker is the prefix of the markers that are replaced when a package is deployed, such as ###ZARF_VAR_*###.
+const templateMarker = "###ZARF_"
+
+// contentBuildData returns the checksums of the charts and files assembled into compBuildPath. Charts and files with the
+// same checksum as in the base component are removed from compBuildPath and marked as differential. Charts and files
+// that are templated when the package is deployed are always kept, as the values they are templated with can change
+// between deploys.
+func contentBuildData(compBuildPath string, component v1alpha1.ZarfComponent, base *v1alpha1.ZarfComponentBuildData) ([]v1alpha1.ZarfContentBuildData, []v1alpha1.ZarfContentBuildData, error) {
+	chartsPath := filepath.Join(compBuildPath, string(ChartsComponentDir))
+	valuesPath := filepath.Join(compBuildPath, string(ValuesComponentDir))
+	charts := []v1alpha1.ZarfContentBuildData{}
+	for _, chart := range component.Charts {
+		checksum, templated, err := chartChecksum(chartsPath, valuesPath, chart)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get checksum for chart %s: %w", chart.Name, err)
+		}
+		content := v1alpha1.ZarfContentBuildData{Name: chart.Name, Checksum: checksum}
+		// Charts with variables are overridden with the values of the variables on deploy.
+		templated = templated || len(chart.Variables) > 0
+		if base != nil && !templated && containsContent(base.Charts, content) {
+			content.Differential = true
+			paths := []string{helm.StandardName(chartsPath, chart) + ".tgz"}
+			for idx := range chart.PackagedValuesFiles() {
+				paths = append(paths, helm.StandardValuesName(valuesPath, chart, idx))
+			}
+			for _, path := range paths {
+				if err := os.Remove(path); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		charts = append(charts, content)
+	}
+	if err := removeEmptyDir(chartsPath); err != nil {
+		return nil, nil, err
+	}
+	if err := removeEmptyDir(valuesPath); err != nil {
+		return nil, nil, err
+	}
+
+	filesPath := filepath.Join(compBuildPath, string(FilesComponentDir))
+	files := []v1alpha1.ZarfContentBuildData{}
+	for fileIdx, file := range component.Files {
+		fileDir := filepath.Join(filesPath, strconv.Itoa(fileIdx))
+		checksum, templated, err := fileChecksum(fileDir, file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get checksum for file %s: %w", file.Target, err)
+		}
+		content := v1alpha1.ZarfContentBuildData{Name: file.Target, Checksum: checksum}
+		if file.LargeBinary {
+			content.ChunkSize, content.Chunks, err = largeBinaryChunks(filepath.Join(fileDir, filepath.Base(file.Target)))
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to get chunk checksums for file %s: %w", file.Target, err)
+			}
+		}
+		templated = templated && (file.Templated == nil || *file.Templated)
+		// Files in the deploy temp directory never exist before the package is deployed.
+		if base != nil && !templated && !strings.Contains(file.Target, "###ZARF_TEMP###") && containsContent(base.Files, content) {
+			content.Differential = true
+			if err := os.RemoveAll(fileDir); err != nil {
+				return nil, nil, err
+			}
+		}
+		files = append(files, content)
+	}
+	if err := removeEmptyDir(filesPath); err != nil {
+		return nil, nil, err
+	}
+	return charts, files, nil
+}
+
+// largeBinaryChunks returns the chunk checksums of a large binary file, no chunks are returned for folders.
+func largeBinaryChunks(path string) (int64, []string, error) {
+	fi, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return 0, nil, nil
+	}
+	chunks, err := files.ChunkChecksums(path, files.ChunkSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	return files.ChunkSize, chunks, nil
+}
+
+// containsContent returns if the contents have a chart or file with the same name and checksum.
+func containsContent(contents []v1alpha1.ZarfContentBuildData, content v1alpha1.ZarfContentBuildData) bool {
+	for _, c := range contents {
+		if c.Name == content.Name && c.Checksum == content.Checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// chartChecksum returns the checksum of the chart definition, archive contents and values files, and if any of them
+// contain template markers. The archive itself is not hashed as Helm records the time the chart was packaged in it.
+func chartChecksum(chartsPath, valuesPath string, chart v1alpha1.ZarfChart) (string, bool, error) {
+	h := sha256.New()
+	if err := writeDefinition(h, chart); err != nil {
+		return "", false, err
+	}
+	f, err := os.Open(helm.StandardName(chartsPath, chart) + ".tgz")
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", false, err
+	}
+	mf := &markerFinder{marker: []byte(templateMarker)}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := writeContent(h, hdr.Name, tr, mf); err != nil {
+			return "", false, err
+		}
+	}
+	for idx := range chart.PackagedValuesFiles() {
+		path := helm.StandardValuesName(valuesPath, chart, idx)
+		vf, err := os.Open(path)
+		if err != nil {
+			return "", false, err
+		}
+		err = writeContent(h, filepath.Base(path), vf, mf)
+		vf.Close()
+		if err != nil {
+			return "", false, err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), mf.found, nil
+}
+
+// fileChecksum returns the checksum of the file definition and the names and contents of the files within its
+// directory, and if any of the files contain template markers.
+func fileChecksum(dirPath string, file v1alpha1.ZarfFile) (string, bool, error) {
+	h := sha256.New()
+	if err := writeDefinition(h, file); err != nil {
+		return "", false, err
+	}
+	mf := &markerFinder{marker: []byte(templateMarker)}
+	err := filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writeContent(h, filepath.ToSlash(rel), f, mf)
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), mf.found, nil
+}
+
+// markerFinder is a writer that records if the marker was written to it, also when the marker is split across writes.
+// The tail of the previous write is kept so that markers split across writes are found.
+type markerFinder struct {
+	marker []byte
+	tail   []byte
+	found  bool
+}
+
+func (mf *markerFinder) Write(p []byte) (int, error) {
+	if mf.found {
+		return len(p), nil
+	}
+	buf := append(mf.tail, p...)
+	if bytes.Contains(buf, mf.marker) {
+		mf.found = true
+		return len(p), nil
+	}
+	keep := min(len(buf), len(mf.marker)-1)
+	mf.tail = append([]byte{}, buf[len(buf)-keep:]...)
+	return len(p), nil
+}
+
+// reset forgets the tail of the previous content so that markers are not found across the end of one file and the start of the next.
+func (mf *markerFinder) reset() {
+	mf.tail = nil
+}
+
+// writeDefinition writes the definition to the hash so that changes to how content is deployed change the checksum.
+func writeDefinition(h hash.Hash, definition any) error {
+	b, err := json.Marshal(definition)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(h, "%s\n", b)
+	return err
+}
+
+// writeContent writes the name and the checksum of the content to the hash so that renamed files change the checksum.
+// The content is also written to the marker finder.
+func writeContent(h hash.Hash, name string, r io.Reader, mf *markerFinder) error {
+	ch := sha256.New()
+	mf.reset()
+	if _, err := io.Copy(io.MultiWriter(ch, mf), r); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(h, "%s %s\n", hex.EncodeToString(ch.Sum(nil)), name)
+	return err
+}
+
+// removeEmptyDir removes the directory if it exists and is empty.
+func removeEmptyDir(path string) error {
+	entries, err := os.ReadDir(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// differentialBaseData returns the build data of each component in the package a differential package is created against.
+func differentialBaseData(pkg v1alpha1.ZarfPackage) map[string]v1alpha1.ZarfComponentBuildData {
+	base := map[string]v1alpha1.ZarfComponentBuildData{}
+	for _, component := range pkg.Build.Components {
+		base[component.Name] = component
+	}
+	return base
+}
+
+// differentialImages returns the images included in the package a differential package is created against. Images of
+// the new components pinned to a digest are included as well when the image index of the base package holds the same
+// digest for the same repository, even when the base package references them with a different tag.
+func differentialImages(base v1alpha1.ZarfPackage, index *ocispec.Index, components []v1alpha1.ZarfComponent) (map[string]bool, error) {
+	images := map[string]bool{}
+	for _, component := range base.Components {
+		for _, image := range component.Images {
+			images[image] = true
+		}
+	}
+	// The repositories are keyed by their digest, the reference of each manifest is recorded in its base name annotation.
+	repositories := map[string][]string{}
+	for _, manifest := range index.Manifests {
+		name, ok := manifest.Annotations[ocispec.AnnotationBaseImageName]
+		if !ok {
+			continue
+		}
+		ref, err := transform.ParseImageRef(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse image ref %s: %w", name, err)
+		}
+		digest := manifest.Digest.String()
+		repositories[digest] = append(repositories[digest], ref.Host+"/"+ref.Path)
+	}
+	for _, component := range components {
+		for _, image := range component.Images {
+			ref, err := transform.ParseImageRef(image)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse image ref %s: %w", image, err)
+			}
+			if ref.Digest != "" && slices.Contains(repositories[ref.Digest], ref.Host+"/"+ref.Path) {
+				images[image] = true
+			}
+		}
+	}
+	return images, nil
+}
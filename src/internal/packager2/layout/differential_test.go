@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func writeChartArchive(t *testing.T, path string, modTime time.Time, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	err = tw.WriteHeader(&tar.Header{Name: "podinfo/Chart.yaml", Mode: 0o600, Size: int64(len(content)), ModTime: modTime, Typeflag: tar.TypeReg})
+	require.NoError(t, err)
+	_, err = tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+}
+
+func writeComponentContent(t *testing.T, compBuildPath string, modTime time.Time, chartContent, fileContent string) {
+	t.Helper()
+
+	writeChartArchive(t, filepath.Join(compBuildPath, string(ChartsComponentDir), "podinfo-6.4.0.tgz"), modTime, chartContent)
+	require.NoError(t, os.MkdirAll(filepath.Join(compBuildPath, string(ValuesComponentDir)), 0o700))
+	err := os.WriteFile(filepath.Join(compBuildPath, string(ValuesComponentDir), "podinfo-6.4.0-0"), []byte("replicaCount: 1\n"), 0o600)
+	require.NoError(t, err)
+	for idx, content := range []string{fileContent, "unchanged"} {
+		dir := filepath.Join(compBuildPath, string(FilesComponentDir), strconv.Itoa(idx))
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), []byte(content), 0o600))
+	}
+}
+
+func TestContentBuildData(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{
+		Name:   "test",
+		Charts: []v1alpha1.ZarfChart{{Name: "podinfo", Version: "6.4.0", Namespace: "podinfo", ValuesFiles: []string{"values.yaml"}}},
+		Files:  []v1alpha1.ZarfFile{{Source: "a", Target: "/tmp/a"}, {Source: "b", Target: "/tmp/b"}},
+	}
+
+	basePath := filepath.Join(t.TempDir(), "test")
+	writeComponentContent(t, basePath, time.Unix(0, 0), "name: podinfo", "v1")
+	charts, files, err := contentBuildData(basePath, component, nil)
+	require.NoError(t, err)
+	require.Len(t, charts, 1)
+	require.Len(t, files, 2)
+	require.Equal(t, "podinfo", charts[0].Name)
+	require.Equal(t, "/tmp/a", files[0].Name)
+	require.False(t, charts[0].Differential)
+	require.FileExists(t, filepath.Join(basePath, string(ChartsComponentDir), "podinfo-6.4.0.tgz"))
+	base := &v1alpha1.ZarfComponentBuildData{Name: "test", Charts: charts, Files: files}
+
+	// Charts repackaged at a different time with the same content are unchanged.
+	compBuildPath := filepath.Join(t.TempDir(), "test")
+	writeComponentContent(t, compBuildPath, time.Now(), "name: podinfo", "v2")
+	charts, files, err = contentBuildData(compBuildPath, component, base)
+	require.NoError(t, err)
+	require.True(t, charts[0].Differential)
+	require.Equal(t, base.Charts[0].Checksum, charts[0].Checksum)
+	require.False(t, files[0].Differential)
+	require.NotEqual(t, base.Files[0].Checksum, files[0].Checksum)
+	require.True(t, files[1].Differential)
+	require.NoDirExists(t, filepath.Join(compBuildPath, string(ChartsComponentDir)))
+	require.NoDirExists(t, filepath.Join(compBuildPath, string(ValuesComponentDir)))
+	require.FileExists(t, filepath.Join(compBuildPath, string(FilesComponentDir), "0", "file"))
+	require.NoDirExists(t, filepath.Join(compBuildPath, string(FilesComponentDir), "1"))
+
+	// Changes to how a chart is deployed change the checksum.
+	component.Charts[0].Namespace = "other"
+	compBuildPath = filepath.Join(t.TempDir(), "test")
+	writeComponentContent(t, compBuildPath, time.Unix(0, 0), "name: podinfo", "v1")
+	charts, _, err = contentBuildData(compBuildPath, component, base)
+	require.NoError(t, err)
+	require.False(t, charts[0].Differential)
+	require.FileExists(t, filepath.Join(compBuildPath, string(ChartsComponentDir), "podinfo-6.4.0.tgz"))
+
+}
+
+func TestContentBuildDataTemplated(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{
+		Name:   "test",
+		Charts: []v1alpha1.ZarfChart{{Name: "podinfo", Version: "6.4.0", Namespace: "podinfo", ValuesFiles: []string{"values.yaml"}}},
+		Files:  []v1alpha1.ZarfFile{{Source: "a", Target: "/tmp/a"}, {Source: "b", Target: "/tmp/b"}},
+	}
+	build := func(t *testing.T, component v1alpha1.ZarfComponent, chartContent, fileContent string, base *v1alpha1.ZarfComponentBuildData) ([]v1alpha1.ZarfContentBuildData, []v1alpha1.ZarfContentBuildData) {
+		t.Helper()
+		compBuildPath := filepath.Join(t.TempDir(), "test")
+		writeComponentContent(t, compBuildPath, time.Unix(0, 0), chartContent, fileContent)
+		charts, files, err := contentBuildData(compBuildPath, component, base)
+		require.NoError(t, err)
+		return charts, files
+	}
+
+	// Charts and files templated on deploy are kept even when they are unchanged, as the variables can change.
+	charts, files := build(t, component, "name: ###ZARF_VAR_NAME###", "host: ###ZARF_VAR_HOST###", nil)
+	base := &v1alpha1.ZarfComponentBuildData{Name: "test", Charts: charts, Files: files}
+	charts, files = build(t, component, "name: ###ZARF_VAR_NAME###", "host: ###ZARF_VAR_HOST###", base)
+	require.False(t, charts[0].Differential)
+	require.False(t, files[0].Differential)
+	require.True(t, files[1].Differential)
+
+	// Charts overridden with variables are kept.
+	withVariables := component
+	withVariables.Charts = []v1alpha1.ZarfChart{component.Charts[0]}
+	withVariables.Charts[0].Variables = []v1alpha1.ZarfChartVariable{{Name: "REPLICAS", Path: "replicaCount"}}
+	charts, _ = build(t, withVariables, "name: podinfo", "v1", nil)
+	base = &v1alpha1.ZarfComponentBuildData{Name: "test", Charts: charts}
+	charts, _ = build(t, withVariables, "name: podinfo", "v1", base)
+	require.False(t, charts[0].Differential)
+
+	// Files with templating turned off are not templated on deploy.
+	notTemplated := component
+	notTemplated.Files = []v1alpha1.ZarfFile{{Source: "a", Target: "/tmp/a", Templated: helpers.BoolPtr(false)}, component.Files[1]}
+	_, files = build(t, notTemplated, "name: podinfo", "host: ###ZARF_VAR_HOST###", nil)
+	base = &v1alpha1.ZarfComponentBuildData{Name: "test", Files: files}
+	_, files = build(t, notTemplated, "name: podinfo", "host: ###ZARF_VAR_HOST###", base)
+	require.True(t, files[0].Differential)
+}
+
+func TestMarkerFinder(t *testing.T) {
+	t.Parallel()
+
+	mf := &markerFinder{marker: []byte(templateMarker)}
+	for _, chunk := range []string{"value: ###ZA", "RF_VAR_NAME###"} {
+		_, err := mf.Write([]byte(chunk))
+		require.NoError(t, err)
+	}
+	require.True(t, mf.found)
+
+	mf = &markerFinder{marker: []byte(templateMarker)}
+	_, err := mf.Write([]byte("value: ###ZA"))
+	require.NoError(t, err)
+	mf.reset()
+	_, err = mf.Write([]byte("RF_VAR_NAME###"))
+	require.NoError(t, err)
+	require.False(t, mf.found)
+}
+
+func TestDifferentialImages(t *testing.T) {
+	t.Parallel()
+
+	pinned := digest.FromString("nginx")
+	base := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{{Name: "app", Images: []string{"docker.io/library/nginx:1.27"}}},
+	}
+	shared := digest.FromString("app")
+	index := &ocispec.Index{Manifests: []ocispec.Descriptor{
+		{Digest: pinned, Annotations: map[string]string{ocispec.AnnotationBaseImageName: "docker.io/library/nginx:1.27"}},
+		{Digest: shared, Annotations: map[string]string{ocispec.AnnotationBaseImageName: "docker.io/bar/app:1"}},
+		{Digest: digest.FromString("unnamed")},
+	}}
+	components := []v1alpha1.ZarfComponent{
+		{
+			Name: "app",
+			Images: []string{
+				"docker.io/library/nginx:1.27@" + pinned.String(),
+				"docker.io/library/redis@" + digest.FromString("redis").String(),
+				"docker.io/library/busybox:1.36",
+				// The same digest in another repository is not in the base package.
+				"ghcr.io/foo/app@" + shared.String(),
+				"docker.io/library/unnamed@" + digest.FromString("unnamed").String(),
+			},
+		},
+	}
+	images, err := differentialImages(base, index, components)
+	require.NoError(t, err)
+	expected := map[string]bool{
+		"docker.io/library/nginx:1.27":                    true,
+		"docker.io/library/nginx:1.27@" + pinned.String(): true,
+	}
+	require.Equal(t, expected, images)
+}
@@ -20,17 +20,20 @@ import (
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 )
 
-func resolveImports(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath, arch, flavor string, seenImports map[string]interface{}) (v1alpha1.ZarfPackage, error) {
+// resolveImports composes the imported components of the package that are compatible with any of the architectures and
+// the flavor.
+func resolveImports(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath string, archs []string, flavor string, seenImports map[string]interface{}) (v1alpha1.ZarfPackage, error) {
 	variables := pkg.Variables
 	constants := pkg.Constants
 	components := []v1alpha1.ZarfComponent{}
 
 	for _, component := range pkg.Components {
-		if !compatibleComponent(component, arch, flavor) {
+		if !compatibleWithArchs(component, archs, flavor) {
 			continue
 		}
 
@@ -44,6 +47,11 @@ func resolveImports(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath,
 			return v1alpha1.ZarfPackage{}, fmt.Errorf("invalid imported definition for %s: %w", component.Name, err)
 		}
 
+		// A component for a single architecture of a multi-architecture package only imports components for that architecture.
+		importArchs := archs
+		if component.Only.Cluster.Architecture != "" {
+			importArchs = []string{component.Only.Cluster.Architecture}
+		}
 		var importedPkg v1alpha1.ZarfPackage
 		if component.Import.Path != "" {
 			importPath := filepath.Join(packagePath, component.Import.Path)
@@ -61,7 +69,7 @@ func resolveImports(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath,
 			if err != nil {
 				return v1alpha1.ZarfPackage{}, err
 			}
-			importedPkg, err = resolveImports(ctx, importedPkg, importPath, arch, flavor, seenImports)
+			importedPkg, err = resolveImports(ctx, importedPkg, importPath, importArchs, flavor, seenImports)
 			if err != nil {
 				return v1alpha1.ZarfPackage{}, err
 			}
@@ -86,7 +94,7 @@ func resolveImports(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath,
 		}
 		found := []v1alpha1.ZarfComponent{}
 		for _, component := range importedPkg.Components {
-			if component.Name == name && compatibleComponent(component, arch, flavor) {
+			if component.Name == name && compatibleWithArchs(component, importArchs, flavor) {
 				found = append(found, component)
 			}
 		}
@@ -154,6 +162,31 @@ func compatibleComponent(c v1alpha1.ZarfComponent, arch, flavor string) bool {
 	return satisfiesArch && satisfiesFlavor
 }
 
+func compatibleWithArchs(c v1alpha1.ZarfComponent, archs []string, flavor string) bool {
+	for _, arch := range archs {
+		if compatibleComponent(c, arch, flavor) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectValuesLayers leaves out the values layers of the component charts that are for another flavor.
+func selectValuesLayers(c v1alpha1.ZarfComponent, flavor string) v1alpha1.ZarfComponent {
+	for chartIdx, chart := range c.Charts {
+		layers := []v1alpha1.ZarfValuesLayer{}
+		for _, layer := range chart.ValuesLayers {
+			if layer.Flavor != "" && layer.Flavor != flavor {
+				continue
+			}
+			layer.Flavor = ""
+			layers = append(layers, layer)
+		}
+		c.Charts[chartIdx].ValuesLayers = layers
+	}
+	return c
+}
+
 // TODO (phillebaba): Refactor package structure so that pullOCI can be used instead.
 func fetchOCISkeleton(ctx context.Context, component v1alpha1.ZarfComponent, packagePath string) (string, error) {
 	if component.Import.URL == "" {
@@ -242,6 +275,16 @@ func overrideMetadata(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompone
 		comp.Description = override.Description
 	}
 
+	// Override the cluster if it was provided.
+	if override.Cluster != "" {
+		comp.Cluster = override.Cluster
+	}
+
+	// Override notes if they were provided.
+	if override.Notes != "" {
+		comp.Notes = override.Notes
+	}
+
 	if override.Only.LocalOS != "" {
 		if comp.Only.LocalOS != "" {
 			return v1alpha1.ZarfComponent{}, fmt.Errorf("component %q: \"only.localOS\" %q cannot be redefined as %q during compose", comp.Name, comp.Only.LocalOS, override.Only.LocalOS)
@@ -301,6 +344,8 @@ func overrideResources(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompon
 	comp.Files = append(comp.Files, override.Files...)
 	comp.Images = append(comp.Images, override.Images...)
 	comp.Repos = append(comp.Repos, override.Repos...)
+	comp.RepoOptions = overrideRepoOptions(comp.RepoOptions, override.RepoOptions)
+	comp.PackageMirrors = append(comp.PackageMirrors, override.PackageMirrors...)
 
 	// Merge charts with the same name to keep them unique
 	for _, overrideChart := range override.Charts {
@@ -314,6 +359,7 @@ func overrideResources(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompon
 					comp.Charts[idx].ReleaseName = overrideChart.ReleaseName
 				}
 				comp.Charts[idx].ValuesFiles = append(comp.Charts[idx].ValuesFiles, overrideChart.ValuesFiles...)
+				comp.Charts[idx].ValuesLayers = append(comp.Charts[idx].ValuesLayers, overrideChart.ValuesLayers...)
 				comp.Charts[idx].Variables = append(comp.Charts[idx].Variables, overrideChart.Variables...)
 				existing = true
 			}
@@ -350,7 +396,7 @@ func overrideResources(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompon
 }
 
 func makePathRelativeTo(path, relativeTo string) string {
-	if helpers.IsURL(path) {
+	if helpers.IsURL(path) || files.IsGit(path) {
 		return path
 	}
 	return filepath.Join(relativeTo, path)
@@ -367,6 +413,12 @@ func fixPaths(child v1alpha1.ZarfComponent, relativeToHead, packagePath string)
 			composed := makePathRelativeTo(valuesFile, relativeToHead)
 			child.Charts[chartIdx].ValuesFiles[valuesIdx] = composed
 		}
+		for layerIdx, layer := range chart.ValuesLayers {
+			for valuesIdx, valuesFile := range layer.Files {
+				composed := makePathRelativeTo(valuesFile, relativeToHead)
+				child.Charts[chartIdx].ValuesLayers[layerIdx].Files[valuesIdx] = composed
+			}
+		}
 		if child.Charts[chartIdx].LocalPath != "" {
 			composed := makePathRelativeTo(chart.LocalPath, relativeToHead)
 			child.Charts[chartIdx].LocalPath = composed
@@ -393,6 +445,11 @@ func fixPaths(child v1alpha1.ZarfComponent, relativeToHead, packagePath string)
 		child.DataInjections[dataInjectionsIdx].Source = composed
 	}
 
+	for mirrorIdx, mirror := range child.PackageMirrors {
+		composed := makePathRelativeTo(mirror.Source, relativeToHead)
+		child.PackageMirrors[mirrorIdx].Source = composed
+	}
+
 	defaultDir := child.Actions.OnCreate.Defaults.Dir
 	child.Actions.OnCreate.Before = fixActionPaths(child.Actions.OnCreate.Before, defaultDir, relativeToHead)
 	child.Actions.OnCreate.After = fixActionPaths(child.Actions.OnCreate.After, defaultDir, relativeToHead)
@@ -421,3 +478,15 @@ func fixActionPaths(actions []v1alpha1.ZarfComponentAction, defaultDir, relative
 	}
 	return actions
 }
+
+// overrideRepoOptions replaces the repo options of the imported component with the options for the same url of the
+// importing component.
+func overrideRepoOptions(opts []v1alpha1.ZarfRepoOptions, overrides []v1alpha1.ZarfRepoOptions) []v1alpha1.ZarfRepoOptions {
+	var merged []v1alpha1.ZarfRepoOptions
+	for _, o := range opts {
+		if !slices.ContainsFunc(overrides, func(override v1alpha1.ZarfRepoOptions) bool { return override.URL == o.URL }) {
+			merged = append(merged, o)
+		}
+	}
+	return append(merged, overrides...)
+}
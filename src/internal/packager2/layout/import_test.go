@@ -28,7 +28,7 @@ func TestResolveImportsCircular(t *testing.T) {
 	pkg, err := ParseZarfPackage(b)
 	require.NoError(t, err)
 
-	_, err = resolveImports(ctx, pkg, "./testdata/import/first", "", "", map[string]interface{}{})
+	_, err = resolveImports(ctx, pkg, "./testdata/import/first", []string{""}, "", map[string]interface{}{})
 	require.EqualError(t, err, "package testdata/import/second imported in cycle by testdata/import/third in component component")
 }
 
@@ -217,3 +217,46 @@ func TestCompatibleComponent(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectValuesLayers(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{
+		Charts: []v1alpha1.ZarfChart{
+			{
+				Name: "podinfo",
+				ValuesLayers: []v1alpha1.ZarfValuesLayer{
+					{Files: []string{"common.yaml"}},
+					{Flavor: "upstream", Files: []string{"upstream.yaml"}},
+					{Flavor: "registry1", Profile: "production", Files: []string{"registry1.yaml"}},
+				},
+			},
+		},
+	}
+	selected := selectValuesLayers(component, "registry1")
+	expected := []v1alpha1.ZarfValuesLayer{
+		{Files: []string{"common.yaml"}},
+		{Profile: "production", Files: []string{"registry1.yaml"}},
+	}
+	require.Equal(t, expected, selected.Charts[0].ValuesLayers)
+}
+
+func TestOverrideRepoOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := []v1alpha1.ZarfRepoOptions{
+		{URL: "https://github.com/example/app.git@v1.0.0", Depth: 1},
+		{URL: "https://github.com/example/docs.git", SingleBranch: true},
+	}
+	overrides := []v1alpha1.ZarfRepoOptions{
+		{URL: "https://github.com/example/app.git@v1.0.0", Depth: 10},
+	}
+	merged := overrideRepoOptions(opts, overrides)
+	require.Equal(t, []v1alpha1.ZarfRepoOptions{
+		{URL: "https://github.com/example/docs.git", SingleBranch: true},
+		{URL: "https://github.com/example/app.git@v1.0.0", Depth: 10},
+	}, merged)
+
+	comp := v1alpha1.ZarfComponent{RepoOptions: merged}
+	require.Equal(t, 10, comp.GetRepoOptions("https://github.com/example/app.git@v1.0.0").Depth)
+}
@@ -14,7 +14,16 @@ import (
 const (
 	ZarfYAML  = "zarf.yaml"
 	Signature = "zarf.yaml.sig"
-	Checksums = "checksums.txt"
+	// ChecksumsSignature is the detached signature of checksums.txt, covering all of the package content.
+	ChecksumsSignature = "checksums.txt.sig"
+	// SignatureTimestamp and ChecksumsSignatureTimestamp are the RFC3161 timestamps of the signatures.
+	SignatureTimestamp          = "zarf.yaml.sig.timestamp"
+	ChecksumsSignatureTimestamp = "checksums.txt.sig.timestamp"
+	Checksums                   = "checksums.txt"
+
+	// SignatureBundle and ChecksumsSignatureBundle hold the certificate and transparency log entry of keyless signatures.
+	SignatureBundle          = "zarf.yaml.sig.bundle"
+	ChecksumsSignatureBundle = "checksums.txt.sig.bundle"
 
 	ImagesDir     = "images"
 	ComponentsDir = "components"
@@ -37,6 +46,8 @@ const (
 	ManifestsComponentDir ComponentDir = "manifests"
 	DataComponentDir      ComponentDir = "data"
 	ValuesComponentDir    ComponentDir = "values"
+	MirrorsComponentDir   ComponentDir = "mirrors"
+	SnapshotsComponentDir ComponentDir = "snapshots"
 )
 
 // ParseZarfPackage parses the yaml passed as a byte slice and applies potential schema migrations.
@@ -16,7 +16,7 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	registryv1 "github.com/google/go-containerregistry/pkg/v1"
-	"github.com/google/go-containerregistry/pkg/v1/layout"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/mholt/archiver/v3"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
@@ -24,11 +24,13 @@ import (
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // PackageLayout manages the layout for a package.
@@ -42,6 +44,15 @@ type PackageLayoutOptions struct {
 	PublicKeyPath           string
 	SkipSignatureValidation bool
 	IsPartial               bool
+	// Components requested from a partial package, their tarballs and image blobs must be present and match the
+	// component checksums.
+	Components []string
+	// VerificationPolicy is enforced when no public key is provided.
+	VerificationPolicy types.VerificationPolicy
+	// Source the package was loaded from, used to match repositories of the verification policy.
+	Source string
+	// CertificateIdentity the certificate of a keyless signature must be issued to.
+	CertificateIdentity utils.CertificateIdentity
 }
 
 // LoadFromTar unpacks the give compressed package and loads it.
@@ -101,11 +112,11 @@ func LoadFromDir(ctx context.Context, dirPath string, opt PackageLayoutOptions)
 		dirPath: dirPath,
 		Pkg:     pkg,
 	}
-	err = validatePackageIntegrity(pkgLayout, opt.IsPartial)
+	err = validatePackageIntegrity(pkgLayout, opt.IsPartial, opt.Components)
 	if err != nil {
 		return nil, err
 	}
-	err = validatePackageSignature(ctx, pkgLayout, opt.PublicKeyPath, opt.SkipSignatureValidation)
+	err = validateTrustedSignature(ctx, pkgLayout, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -121,13 +132,53 @@ func (p *PackageLayout) Cleanup() error {
 	return nil
 }
 
-// GetSBOM outputs the SBOM data from the package to the give destination path.
+// PackageSignature is a signature of the package.
+type PackageSignature struct {
+	// Signature is the name of the signature file.
+	Signature string
+	// Blob is the name of the file the signature covers.
+	Blob string
+	// Timestamp of the signature, nil when the signature is not timestamped.
+	Timestamp *utils.SignatureTimestamp
+}
+
+// Signatures returns the signatures of the package. Timestamps are read without being verified.
+func (p *PackageLayout) Signatures() ([]PackageSignature, error) {
+	timestamps := map[string]string{Signature: SignatureTimestamp, ChecksumsSignature: ChecksumsSignatureTimestamp}
+	signatures := []PackageSignature{}
+	for _, sig := range []PackageSignature{{Signature: Signature, Blob: ZarfYAML}, {Signature: ChecksumsSignature, Blob: Checksums}} {
+		if helpers.InvalidPath(filepath.Join(p.dirPath, sig.Signature)) {
+			continue
+		}
+		timestampPath := filepath.Join(p.dirPath, timestamps[sig.Signature])
+		if !helpers.InvalidPath(timestampPath) {
+			ts, err := utils.ReadSignatureTimestamp(timestampPath)
+			if err != nil {
+				return nil, err
+			}
+			sig.Timestamp = &ts
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, nil
+}
+
+// GetSBOM outputs the SBOM data from the package to the give destination path and returns the directory of the SBOMs.
+// The destination path may be a template of the package metadata, see sbomOutputPath. SBOM viewers are created for
+// packages that were created without them.
 func (p *PackageLayout) GetSBOM(destPath string) (string, error) {
-	path := filepath.Join(destPath, p.Pkg.Metadata.Name)
-	err := archiver.Extract(filepath.Join(p.dirPath, SBOMTar), "", path)
+	path, err := sbomOutputPath(destPath, p.Pkg)
+	if err != nil {
+		return "", err
+	}
+	err = archiver.Extract(filepath.Join(p.dirPath, SBOMTar), "", path)
 	if err != nil {
 		return "", err
 	}
+	err = createMissingSBOMViewers(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to create the SBOM viewers: %w", err)
+	}
 	return path, nil
 }
 
@@ -168,10 +219,11 @@ func (p *PackageLayout) GetComponentDir(destPath, componentName string, ct Compo
 	return outPath, nil
 }
 
-// GetImage returns the image with the given reference in the package layout.
-func (p *PackageLayout) GetImage(ref transform.Image) (registryv1.Image, error) {
+// GetImage returns the image with the given reference in the package layout. The architecture selects the image of a
+// package created for multiple architectures, it is ignored for packages created for a single architecture.
+func (p *PackageLayout) GetImage(ref transform.Image, arch string) (registryv1.Image, error) {
 	// Use the manifest within the index.json to load the specific image we want
-	layoutPath := layout.Path(filepath.Join(p.dirPath, ImagesDir))
+	layoutPath := clayout.Path(filepath.Join(p.dirPath, ImagesDir))
 	imgIdx, err := layoutPath.ImageIndex()
 	if err != nil {
 		return nil, err
@@ -182,6 +234,9 @@ func (p *PackageLayout) GetImage(ref transform.Image) (registryv1.Image, error)
 	}
 	// Search through all the manifests within this package until we find the annotation that matches our ref
 	for _, manifest := range idxManifest.Manifests {
+		if arch != "" && manifest.Platform != nil && manifest.Platform.Architecture != arch {
+			continue
+		}
 		if manifest.Annotations[ocispec.AnnotationBaseImageName] == ref.Reference ||
 			// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
 			(manifest.Annotations[ocispec.AnnotationBaseImageName] == ref.Path+ref.TagOrDigest && ref.Host == "docker.io") {
@@ -209,7 +264,18 @@ func (p *PackageLayout) Archive(ctx context.Context, dirPath string, maxPackageS
 	for _, file := range files {
 		filePaths = append(filePaths, filepath.Join(p.dirPath, file.Name()))
 	}
-	err = archiver.Archive(filePaths, tarballPath)
+	storedRanges := map[string][]byteRange{}
+	if !p.Pkg.Metadata.Uncompressed {
+		storedRanges, err = p.storedRanges()
+		if err != nil {
+			return err
+		}
+	}
+	if len(storedRanges) > 0 {
+		err = archiveWithStoredRanges(filePaths, tarballPath, storedRanges)
+	} else {
+		err = archiver.Archive(filePaths, tarballPath)
+	}
 	if err != nil {
 		return fmt.Errorf("unable to create package: %w", err)
 	}
@@ -253,7 +319,7 @@ func (p *PackageLayout) Files() (map[string]string, error) {
 	return files, nil
 }
 
-func validatePackageIntegrity(pkgLayout *PackageLayout, isPartial bool) error {
+func validatePackageIntegrity(pkgLayout *PackageLayout, isPartial bool, components []string) error {
 	_, err := os.Stat(filepath.Join(pkgLayout.dirPath, ZarfYAML))
 	if err != nil {
 		return err
@@ -275,32 +341,37 @@ func validatePackageIntegrity(pkgLayout *PackageLayout, isPartial bool) error {
 	delete(packageFiles, filepath.Join(pkgLayout.dirPath, ZarfYAML))
 	delete(packageFiles, filepath.Join(pkgLayout.dirPath, Checksums))
 	delete(packageFiles, filepath.Join(pkgLayout.dirPath, Signature))
+	delete(packageFiles, filepath.Join(pkgLayout.dirPath, ChecksumsSignature))
+	delete(packageFiles, filepath.Join(pkgLayout.dirPath, SignatureTimestamp))
+	delete(packageFiles, filepath.Join(pkgLayout.dirPath, ChecksumsSignatureTimestamp))
+	delete(packageFiles, filepath.Join(pkgLayout.dirPath, SignatureBundle))
+	delete(packageFiles, filepath.Join(pkgLayout.dirPath, ChecksumsSignatureBundle))
 
 	b, err := os.ReadFile(filepath.Join(pkgLayout.dirPath, Checksums))
 	if err != nil {
 		return err
 	}
-	lines := strings.Split(string(b), "\n")
-	for _, line := range lines {
-		// If the line is empty (i.e. there is no checksum) simply skip it, this can result from a package with no images/components.
-		if line == "" {
-			continue
-		}
+	checksums, err := parseChecksums(string(b))
+	if err != nil {
+		return err
+	}
 
-		split := strings.Split(line, " ")
-		if len(split) != 2 {
-			return fmt.Errorf("invalid checksum line: %s", line)
+	// Only the layers of the requested components are required in a partial package.
+	required := map[string]bool{}
+	if isPartial {
+		paths, err := validateComponentChecksums(pkgLayout.dirPath, pkgLayout.Pkg, checksums, components)
+		if err != nil {
+			return err
 		}
-		sha := split[0]
-		rel := split[1]
-		if sha == "" || rel == "" {
-			return fmt.Errorf("invalid checksum line: %s", line)
+		for _, p := range paths {
+			required[p] = true
 		}
+	}
 
+	for rel, sha := range checksums {
 		path := filepath.Join(pkgLayout.dirPath, rel)
 		_, ok := packageFiles[path]
-		if !ok && isPartial {
-			delete(packageFiles, path)
+		if !ok && isPartial && !required[rel] {
 			continue
 		}
 		if !ok {
@@ -325,16 +396,71 @@ func validatePackageIntegrity(pkgLayout *PackageLayout, isPartial bool) error {
 	return nil
 }
 
+// validateTrustedSignature validates the package signature with the keys the verification policy trusts for the
+// package or, when the policy does not trust publishers for it, with the public key. Signature validation can not be
+// skipped for packages the policy trusts publishers for. Signature timestamps are verified with the timestamp
+// authorities of the verification policy.
+func validateTrustedSignature(ctx context.Context, pkgLayout *PackageLayout, opt PackageLayoutOptions) error {
+	rules := sources.TrustedRules(opt.VerificationPolicy, pkgLayout.Pkg.Metadata.Name, opt.Source)
+	if len(rules) == 0 && opt.SkipSignatureValidation {
+		return nil
+	}
+	if len(rules) > 0 && opt.SkipSignatureValidation {
+		return sources.ErrPkgPolicyButSkipped
+	}
+	timestamps := map[string]string{}
+	for timestamp, signature := range map[string]string{SignatureTimestamp: Signature, ChecksumsSignatureTimestamp: ChecksumsSignature} {
+		if !helpers.InvalidPath(filepath.Join(pkgLayout.dirPath, timestamp)) {
+			timestamps[filepath.Join(pkgLayout.dirPath, timestamp)] = filepath.Join(pkgLayout.dirPath, signature)
+		}
+	}
+	signedAt, err := sources.VerifySignatureTimestamps(ctx, opt.VerificationPolicy, timestamps)
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		if helpers.InvalidPath(filepath.Join(pkgLayout.dirPath, Signature)) {
+			return sources.ErrPkgPolicyButNoSig
+		}
+		return sources.VerifyWithTrustedKeys(ctx, pkgLayout.Pkg.Metadata.Name, rules, signedAt, func(keyRef string) error {
+			return validatePackageSignature(ctx, pkgLayout, keyRef, false)
+		})
+	}
+	if !helpers.InvalidPath(filepath.Join(pkgLayout.dirPath, SignatureBundle)) || !opt.CertificateIdentity.IsEmpty() {
+		return sources.ValidateKeylessSignature(ctx, pkgLayout.signaturePaths(), opt.CertificateIdentity)
+	}
+	return validatePackageSignature(ctx, pkgLayout, opt.PublicKeyPath, false)
+}
+
+// signaturePaths returns the paths of the signed files, signatures and signature bundles that exist in the package.
+func (p *PackageLayout) signaturePaths() *layout.PackagePaths {
+	paths := &layout.PackagePaths{Base: p.dirPath}
+	for name, path := range map[string]*string{
+		ZarfYAML:                 &paths.ZarfYAML,
+		Checksums:                &paths.Checksums,
+		Signature:                &paths.Signature,
+		ChecksumsSignature:       &paths.ChecksumsSignature,
+		SignatureBundle:          &paths.SignatureBundle,
+		ChecksumsSignatureBundle: &paths.ChecksumsSignatureBundle,
+	} {
+		if !helpers.InvalidPath(filepath.Join(p.dirPath, name)) {
+			*path = filepath.Join(p.dirPath, name)
+		}
+	}
+	return paths
+}
+
 func validatePackageSignature(ctx context.Context, pkgLayout *PackageLayout, publicKeyPath string, skipSignatureValidation bool) error {
 	if skipSignatureValidation {
 		return nil
 	}
 
 	signaturePath := filepath.Join(pkgLayout.dirPath, Signature)
-	sigExist := true
-	_, err := os.Stat(signaturePath)
-	if err != nil {
-		sigExist = false
+	checksumsSignaturePath := filepath.Join(pkgLayout.dirPath, ChecksumsSignature)
+	sigExist := !helpers.InvalidPath(signaturePath)
+	checksumsSigExist := !helpers.InvalidPath(checksumsSignaturePath)
+	if checksumsSigExist && !sigExist {
+		return fmt.Errorf("package contains %s but not %s", ChecksumsSignature, Signature)
 	}
 	if !sigExist && publicKeyPath == "" {
 		// Nobody was expecting a signature, so we can just return
@@ -345,6 +471,28 @@ func validatePackageSignature(ctx context.Context, pkgLayout *PackageLayout, pub
 		return errors.New("a key was provided but the package is not signed")
 	}
 
+	// The checksums signature covers all of the package content, packages created before it existed only sign the zarf.yaml.
+	if checksumsSigExist {
+		err := verifyBlob(ctx, filepath.Join(pkgLayout.dirPath, Checksums), checksumsSignaturePath, publicKeyPath)
+		if err != nil {
+			return fmt.Errorf("package checksums signature did not match the provided key: %w", err)
+		}
+	} else {
+		// TODO(mkcp): Remove message on logger release
+		message.Warnf("The package does not contain a signature of %s, only the %s is verified against the provided key", Checksums, ZarfYAML)
+		logger.From(ctx).Warn("package does not contain a checksums signature, only the zarf.yaml is verified against the provided key")
+	}
+	err := verifyBlob(ctx, filepath.Join(pkgLayout.dirPath, ZarfYAML), signaturePath, publicKeyPath)
+	if err != nil {
+		return fmt.Errorf("package signature did not match the provided key: %w", err)
+	}
+	return nil
+}
+
+func verifyBlob(ctx context.Context, blobPath, signaturePath, publicKeyPath string) error {
+	if err := utils.CheckCosignKeyRef(publicKeyPath); err != nil {
+		return err
+	}
 	keyOptions := options.KeyOpts{KeyRef: publicKeyPath}
 	cmd := &verify.VerifyBlobCmd{
 		KeyOpts:    keyOptions,
@@ -353,9 +501,5 @@ func validatePackageSignature(ctx context.Context, pkgLayout *PackageLayout, pub
 		Offline:    true,
 		IgnoreTlog: true,
 	}
-	err = cmd.Exec(ctx, filepath.Join(pkgLayout.dirPath, ZarfYAML))
-	if err != nil {
-		return fmt.Errorf("package signature did not match the provided key: %w", err)
-	}
-	return nil
+	return cmd.Exec(ctx, blobPath)
 }
@@ -47,7 +47,7 @@ func TestPackageLayout(t *testing.T) {
 
 	ref, err := transform.ParseImageRef("docker.io/library/alpine:3.20")
 	require.NoError(t, err)
-	img, err := pkgLayout.GetImage(ref)
+	img, err := pkgLayout.GetImage(ref, pkgLayout.Pkg.Build.Architecture)
 	require.NoError(t, err)
 	dgst, err := img.Digest()
 	require.NoError(t, err)
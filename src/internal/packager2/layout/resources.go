@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/variables"
+)
+
+// componentResourceEstimate renders the charts and reads the manifests assembled for the component to estimate the
+// compute resources requested by its workloads. Charts that cannot be rendered without deploy time values are skipped.
+func componentResourceEstimate(ctx context.Context, component v1alpha1.ZarfComponent, compBuildPath string, variableConfig *variables.VariableConfig) (*v1alpha1.ZarfResourceEstimate, error) {
+	l := logger.From(ctx)
+	resources := []*unstructured.Unstructured{}
+	for _, chart := range component.Charts {
+		helmCfg := helm.New(
+			chart,
+			filepath.Join(compBuildPath, string(ChartsComponentDir)),
+			filepath.Join(compBuildPath, string(ValuesComponentDir)),
+			helm.WithVariableConfig(variableConfig),
+		)
+		manifest, _, err := helmCfg.TemplateChart(ctx)
+		if err != nil {
+			l.Debug("unable to render chart to estimate its resource requests", "name", chart.Name, "error", err)
+			continue
+		}
+		yamls, err := utils.SplitYAML([]byte(manifest))
+		if err != nil {
+			l.Debug("unable to read chart to estimate its resource requests", "name", chart.Name, "error", err)
+			continue
+		}
+		resources = append(resources, yamls...)
+	}
+
+	manifestsDir := filepath.Join(compBuildPath, string(ManifestsComponentDir))
+	entries, err := os.ReadDir(manifestsDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		b, err := os.ReadFile(filepath.Join(manifestsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		yamls, err := utils.SplitYAML(b)
+		if err != nil {
+			l.Debug("unable to read manifest to estimate its resource requests", "name", entry.Name(), "error", err)
+			continue
+		}
+		resources = append(resources, yamls...)
+	}
+	return estimateResources(ctx, resources), nil
+}
+
+// estimateResources sums the CPU and memory requests of the pods created by the workloads multiplied by their replicas.
+// Daemon sets are counted once as the number of nodes is not known until deploy time.
+// It returns nil when none of the workloads request resources.
+func estimateResources(ctx context.Context, resources []*unstructured.Unstructured) *v1alpha1.ZarfResourceEstimate {
+	l := logger.From(ctx)
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	for _, res := range resources {
+		podSpec, replicas, ok, err := workloadPodSpec(res)
+		if err != nil {
+			// Requests templated with deploy time variables are not valid quantities until they are deployed.
+			l.Debug("unable to read the pod spec of workload to estimate its resource requests", "kind", res.GetKind(), "name", res.GetName(), "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		requests := podRequests(podSpec)
+		podCPU := requests[corev1.ResourceCPU]
+		cpu.Add(*resource.NewMilliQuantity(podCPU.MilliValue()*replicas, resource.DecimalSI))
+		podMemory := requests[corev1.ResourceMemory]
+		memory.Add(*resource.NewQuantity(podMemory.Value()*replicas, resource.BinarySI))
+	}
+	if cpu.IsZero() && memory.IsZero() {
+		return nil
+	}
+	estimate := &v1alpha1.ZarfResourceEstimate{}
+	if !cpu.IsZero() {
+		estimate.CPURequests = cpu.String()
+	}
+	if !memory.IsZero() {
+		estimate.MemoryRequests = memory.String()
+	}
+	return estimate
+}
+
+// workloadPodSpec returns the pod spec and number of pods of a workload, it returns false when the resource is not a workload.
+func workloadPodSpec(res *unstructured.Unstructured) (corev1.PodSpec, int64, bool, error) {
+	var path []string
+	replicasField := []string{"spec", "replicas"}
+	switch res.GetKind() {
+	case "Pod":
+		path = []string{"spec"}
+		replicasField = nil
+	case "Deployment", "StatefulSet", "ReplicaSet", "ReplicationController":
+		path = []string{"spec", "template", "spec"}
+	case "DaemonSet":
+		path = []string{"spec", "template", "spec"}
+		replicasField = nil
+	case "Job":
+		path = []string{"spec", "template", "spec"}
+		replicasField = []string{"spec", "parallelism"}
+	case "CronJob":
+		path = []string{"spec", "jobTemplate", "spec", "template", "spec"}
+		replicasField = []string{"spec", "jobTemplate", "spec", "parallelism"}
+	default:
+		return corev1.PodSpec{}, 0, false, nil
+	}
+
+	replicas := int64(1)
+	if replicasField != nil {
+		value, found, err := unstructured.NestedInt64(res.Object, replicasField...)
+		if err != nil {
+			return corev1.PodSpec{}, 0, false, err
+		}
+		if found {
+			replicas = value
+		}
+	}
+	obj, found, err := unstructured.NestedMap(res.Object, path...)
+	if err != nil {
+		return corev1.PodSpec{}, 0, false, err
+	}
+	if !found {
+		return corev1.PodSpec{}, 0, false, nil
+	}
+	podSpec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &podSpec); err != nil {
+		return corev1.PodSpec{}, 0, false, fmt.Errorf("invalid pod spec: %w", err)
+	}
+	return podSpec, replicas, true, nil
+}
+
+// podRequests returns the resources requested by a pod, which is the larger of the sum of the containers and the largest
+// init container, as init containers run before the containers start.
+func podRequests(podSpec corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range podSpec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	for _, container := range podSpec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if quantity.Cmp(requests[name]) > 0 {
+				requests[name] = quantity
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestEstimateResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	manifests := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+spec:
+  replicas: 3
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          resources:
+            requests:
+              memory: 1Gi
+      containers:
+        - name: podinfo
+          resources:
+            requests:
+              cpu: 100m
+              memory: 64Mi
+        - name: sidecar
+          resources:
+            requests:
+              cpu: 50m
+              memory: 32Mi
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      containers:
+        - name: agent
+          resources:
+            requests:
+              cpu: "1"
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      parallelism: 2
+      template:
+        spec:
+          containers:
+            - name: backup
+              resources:
+                requests:
+                  cpu: "###ZARF_VAR_BACKUP_CPU###"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+spec:
+  ports:
+    - port: 80
+`
+	resources, err := utils.SplitYAML([]byte(manifests))
+	require.NoError(t, err)
+	estimate := estimateResources(ctx, resources)
+	// The init container requests more memory than the containers and the templated request of the cron job is skipped.
+	require.Equal(t, &v1alpha1.ZarfResourceEstimate{CPURequests: "1450m", MemoryRequests: "3Gi"}, estimate)
+
+	service := `apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+`
+	resources, err = utils.SplitYAML([]byte(service))
+	require.NoError(t, err)
+	require.Nil(t, estimateResources(ctx, resources))
+}
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
+	texttemplate "text/template"
 
 	"github.com/anchore/stereoscope/pkg/file"
 	"github.com/anchore/stereoscope/pkg/image"
@@ -41,7 +43,7 @@ const componentPrefix = "zarf-component-"
 var viewerAssets embed.FS
 var transformRegex = regexp.MustCompile(`(?m)[^a-zA-Z0-9\.\-]`)
 
-func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath string, images []transform.Image) error {
+func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath string, images []transform.Image, skipViewer bool) error {
 	outputPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
 		return err
@@ -73,6 +75,9 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 		if err != nil {
 			return err
 		}
+		if skipViewer {
+			continue
+		}
 		err = createSBOMViewerAsset(outputPath, refInfo.Reference, b, jsonList)
 		if err != nil {
 			return err
@@ -88,6 +93,9 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 		if err != nil {
 			return err
 		}
+		if skipViewer {
+			continue
+		}
 		err = createSBOMViewerAsset(outputPath, fmt.Sprintf("%s%s", componentPrefix, comp.Name), jsonData, jsonList)
 		if err != nil {
 			return err
@@ -95,9 +103,11 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 	}
 
 	// Include the compare tool if there are any image SBOMs OR component SBOMs
-	err = createSBOMCompareAsset(outputPath)
-	if err != nil {
-		return err
+	if !skipViewer {
+		err = createSBOMCompareAsset(outputPath)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = createReproducibleTarballFromDir(outputPath, "", filepath.Join(buildPath, "sboms.tar"), false)
@@ -257,6 +267,72 @@ func createFileSBOM(ctx context.Context, component v1alpha1.ZarfComponent, outpu
 	return jsonData, nil
 }
 
+// createMissingSBOMViewers creates the SBOM viewers and the compare tool for the SBOMs in the directory, when the
+// package was created without them.
+func createMissingSBOMViewers(dir string) error {
+	jsonPaths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(jsonPaths) == 0 {
+		return nil
+	}
+	identifiers := []string{}
+	for _, jsonPath := range jsonPaths {
+		identifiers = append(identifiers, strings.TrimSuffix(filepath.Base(jsonPath), ".json"))
+	}
+	jsonList, err := json.Marshal(identifiers)
+	if err != nil {
+		return err
+	}
+	for i, identifier := range identifiers {
+		if !helpers.InvalidPath(filepath.Join(dir, fmt.Sprintf("sbom-viewer-%s.html", identifier))) {
+			continue
+		}
+		jsonData, err := os.ReadFile(jsonPaths[i])
+		if err != nil {
+			return err
+		}
+		err = createSBOMViewerAsset(dir, identifier, jsonData, jsonList)
+		if err != nil {
+			return err
+		}
+	}
+	if helpers.InvalidPath(filepath.Join(dir, "compare.html")) {
+		return createSBOMCompareAsset(dir)
+	}
+	return nil
+}
+
+// sbomOutputPath returns the directory the SBOMs of the package are written to. The destination path is a template of
+// the package name, version, architecture and flavor, such as sboms/{{.Name}}-{{.Version}}. Destination paths that are
+// not templates are the parent directory of a directory named after the package.
+func sbomOutputPath(destPath string, pkg v1alpha1.ZarfPackage) (string, error) {
+	if !strings.Contains(destPath, "{{") {
+		return filepath.Join(destPath, pkg.Metadata.Name), nil
+	}
+	tpl, err := texttemplate.New("sbom-out").Option("missingkey=error").Parse(destPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid SBOM output path template %s: %w", destPath, err)
+	}
+	data := struct {
+		Name         string
+		Version      string
+		Architecture string
+		Flavor       string
+	}{
+		Name:         pkg.Metadata.Name,
+		Version:      pkg.Metadata.Version,
+		Architecture: pkg.Build.Architecture,
+		Flavor:       pkg.Build.Flavor,
+	}
+	var b strings.Builder
+	if err := tpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid SBOM output path template %s: %w", destPath, err)
+	}
+	return filepath.Clean(b.String()), nil
+}
+
 func createSBOMViewerAsset(outputDir, identifier string, jsonData, jsonList []byte) error {
 	filename := fmt.Sprintf("sbom-viewer-%s.html", getNormalizedFileName(identifier))
 	return createSBOMHTML(outputDir, filename, "viewer/template.gohtml", jsonData, jsonList)
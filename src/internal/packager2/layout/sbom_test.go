@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/test/testutil"
 )
 
@@ -25,3 +26,41 @@ func TestCreateImageSBOM(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, fileContent, b)
 }
+
+func TestSBOMOutputPath(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Build:    v1alpha1.ZarfBuildData{Architecture: "amd64"},
+	}
+	path, err := sbomOutputPath("sboms", pkg)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("sboms", "test"), path)
+
+	path, err = sbomOutputPath("sboms/{{.Name}}-{{.Version}}-{{.Architecture}}", pkg)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("sboms", "test-1.0.0-amd64"), path)
+
+	_, err = sbomOutputPath("sboms/{{.Unknown}}", pkg)
+	require.Error(t, err)
+}
+
+func TestCreateMissingSBOMViewers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker.io_foo_bar_latest.json"), []byte("{}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zarf-component-files.json"), []byte("{}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sbom-viewer-zarf-component-files.html"), []byte("existing"), 0o600))
+
+	err := createMissingSBOMViewers(dir)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(dir, "sbom-viewer-docker.io_foo_bar_latest.html"))
+	require.FileExists(t, filepath.Join(dir, "compare.html"))
+	b, err := os.ReadFile(filepath.Join(dir, "sbom-viewer-zarf-component-files.html"))
+	require.NoError(t, err)
+	require.Equal(t, "existing", string(b))
+
+	require.NoError(t, createMissingSBOMViewers(t.TempDir()))
+}
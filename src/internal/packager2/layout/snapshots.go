@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
+)
+
+// snapshotComponentCharts renders the packaged charts of the component and stores the manifests next to the charts so that
+// deploy can verify the charts render the same and inspect can show them without helm.
+// Only the constants of the package are templated, variables are left as templates as they are set at deploy time.
+// Charts are rendered twice as charts that do not render the same each time cannot be verified.
+func snapshotComponentCharts(ctx context.Context, component v1alpha1.ZarfComponent, constants []v1alpha1.Constant, compBuildPath string) error {
+	chartsPath := filepath.Join(compBuildPath, string(ChartsComponentDir))
+	valuesPath := filepath.Join(compBuildPath, string(ValuesComponentDir))
+	snapshotsPath := filepath.Join(compBuildPath, string(SnapshotsComponentDir))
+	for _, chart := range component.Charts {
+		variableConfig := template.GetZarfVariableConfig(ctx)
+		variableConfig.SetConstants(constants)
+		manifest, err := helm.RenderSnapshot(ctx, chart, chartsPath, valuesPath, variableConfig)
+		if err != nil {
+			return fmt.Errorf("unable to render chart %s for its snapshot: %w", chart.Name, err)
+		}
+		again, err := helm.RenderSnapshot(ctx, chart, chartsPath, valuesPath, variableConfig)
+		if err != nil {
+			return fmt.Errorf("unable to render chart %s for its snapshot: %w", chart.Name, err)
+		}
+		if err := helm.CompareSnapshot(manifest, again); err != nil {
+			return fmt.Errorf("chart %s does not render the same each time and cannot be snapshotted: %w", chart.Name, err)
+		}
+		if err := helpers.CreateDirectory(snapshotsPath, helpers.ReadWriteExecuteUser); err != nil {
+			return err
+		}
+		if err := os.WriteFile(helm.SnapshotName(snapshotsPath, chart), []byte(manifest), helpers.ReadWriteUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
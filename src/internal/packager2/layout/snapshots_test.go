@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestSnapshotComponentCharts(t *testing.T) {
+	t.Parallel()
+
+	// newComponent packages a chart with the template and returns a component with the chart.
+	newComponent := func(t *testing.T, tmpl string) (v1alpha1.ZarfComponent, string) {
+		t.Helper()
+
+		chartPath := filepath.Join(t.TempDir(), "chart")
+		err := os.MkdirAll(filepath.Join(chartPath, "templates"), helpers.ReadWriteExecuteUser)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(chartPath, "Chart.yaml"), []byte("apiVersion: v2\nname: test\nversion: 1.0.0\n"), helpers.ReadWriteUser)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte(tmpl), helpers.ReadWriteUser)
+		require.NoError(t, err)
+
+		component := v1alpha1.ZarfComponent{
+			Name:   "charts",
+			Charts: []v1alpha1.ZarfChart{{Name: "test", Version: "1.0.0", Namespace: "test", LocalPath: chartPath}},
+		}
+		compBuildPath := t.TempDir()
+		chartsPath := filepath.Join(compBuildPath, string(ChartsComponentDir))
+		helmCfg := helm.New(component.Charts[0], chartsPath, filepath.Join(compBuildPath, string(ValuesComponentDir)))
+		err = helmCfg.PackageChart(testutil.TestContext(t), chartsPath)
+		require.NoError(t, err)
+		return component, compBuildPath
+	}
+
+	t.Run("constants are templated and variables are left as templates", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.TestContext(t)
+		tmpl := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  constant: "###ZARF_CONST_MESSAGE###"
+  variable: "###ZARF_VAR_MESSAGE###"
+`
+		component, compBuildPath := newComponent(t, tmpl)
+		constants := []v1alpha1.Constant{{Name: "MESSAGE", Value: "hello"}}
+		err := snapshotComponentCharts(ctx, component, constants, compBuildPath)
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(helm.SnapshotName(filepath.Join(compBuildPath, string(SnapshotsComponentDir)), component.Charts[0]))
+		require.NoError(t, err)
+		require.Contains(t, string(b), `constant: "hello"`)
+		require.Contains(t, string(b), `variable: "###ZARF_VAR_MESSAGE###"`)
+	})
+
+	t.Run("charts that do not render the same each time", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.TestContext(t)
+		tmpl := `apiVersion: v1
+kind: Secret
+metadata:
+  name: test
+stringData:
+  password: {{ randAlphaNum 16 | quote }}
+`
+		component, compBuildPath := newComponent(t, tmpl)
+		err := snapshotComponentCharts(ctx, component, nil, compBuildPath)
+		require.ErrorContains(t, err, "chart test does not render the same each time and cannot be snapshotted")
+	})
+}
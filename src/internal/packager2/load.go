@@ -31,7 +31,10 @@ type LoadOptions struct {
 	Shasum                  string
 	PublicKeyPath           string
 	SkipSignatureValidation bool
+	VerificationPolicy      types.VerificationPolicy
 	Filter                  filters.ComponentFilterStrategy
+	// CertificateIdentity the certificate of a keyless signature must be issued to.
+	CertificateIdentity utils.CertificateIdentity
 }
 
 // LoadPackage optionally fetches and loads the package from the given source.
@@ -49,9 +52,10 @@ func LoadPackage(ctx context.Context, opt LoadOptions) (*layout.PackageLayout, e
 	tarPath := filepath.Join(tmpDir, "data.tar.zst")
 
 	isPartial := false
+	var components []string
 	switch srcType {
 	case "oci":
-		isPartial, err = pullOCI(ctx, opt.Source, tarPath, opt.Shasum, opt.Filter)
+		isPartial, components, err = pullOCI(ctx, opt.Source, tarPath, opt.Shasum, opt.Filter)
 		if err != nil {
 			return nil, err
 		}
@@ -60,6 +64,11 @@ func LoadPackage(ctx context.Context, opt LoadOptions) (*layout.PackageLayout, e
 		if err != nil {
 			return nil, err
 		}
+	case utils.S3URLScheme:
+		err = pullS3(ctx, opt.Source, tarPath, opt.Shasum)
+		if err != nil {
+			return nil, err
+		}
 	case "split":
 		err = assembleSplitTar(opt.Source, tarPath)
 		if err != nil {
@@ -79,8 +88,12 @@ func LoadPackage(ctx context.Context, opt LoadOptions) (*layout.PackageLayout, e
 
 	layoutOpt := layout.PackageLayoutOptions{
 		PublicKeyPath:           opt.PublicKeyPath,
+		CertificateIdentity:     opt.CertificateIdentity,
 		SkipSignatureValidation: opt.SkipSignatureValidation,
 		IsPartial:               isPartial,
+		Components:              components,
+		VerificationPolicy:      opt.VerificationPolicy,
+		Source:                  opt.Source,
 	}
 	pkgLayout, err := layout.LoadFromTar(ctx, tarPath, layoutOpt)
 	if err != nil {
@@ -152,7 +165,7 @@ func assembleSplitTar(src, tarPath string) error {
 	return nil
 }
 
-func packageFromSourceOrCluster(ctx context.Context, cluster *cluster.Cluster, src string, skipSignatureValidation bool, publicKeyPath string) (v1alpha1.ZarfPackage, error) {
+func packageFromSourceOrCluster(ctx context.Context, cluster *cluster.Cluster, src string, skipSignatureValidation bool, publicKeyPath string, identity utils.CertificateIdentity, policy types.VerificationPolicy) (v1alpha1.ZarfPackage, error) {
 	_, err := identifySource(src)
 	if err != nil {
 		if cluster == nil {
@@ -170,6 +183,8 @@ func packageFromSourceOrCluster(ctx context.Context, cluster *cluster.Cluster, s
 		SkipSignatureValidation: skipSignatureValidation,
 		Filter:                  filters.Empty(),
 		PublicKeyPath:           publicKeyPath,
+		CertificateIdentity:     identity,
+		VerificationPolicy:      policy,
 	}
 	p, err := LoadPackage(ctx, loadOpt)
 	if err != nil {
@@ -12,7 +12,9 @@ import (
 
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 func TestLoadPackage(t *testing.T) {
@@ -102,6 +104,11 @@ func TestIdentifySource(t *testing.T) {
 			src:             "http://github.com/zarf-dev/zarf/releases/download/v1.0.0/zarf-init-amd64-v1.0.0.tar.zst",
 			expectedSrcType: "http",
 		},
+		{
+			name:            "s3",
+			src:             "s3://zarf-packages/releases/zarf-init-amd64-v1.0.0.tar.zst",
+			expectedSrcType: "s3",
+		},
 		{
 			name:            "local tar init zst",
 			src:             "zarf-init-amd64-v1.0.0.tar.zst",
@@ -140,19 +147,19 @@ func TestPackageFromSourceOrCluster(t *testing.T) {
 
 	ctx := testutil.TestContext(t)
 
-	_, err := packageFromSourceOrCluster(ctx, nil, "test", false, "")
+	_, err := packageFromSourceOrCluster(ctx, nil, "test", false, "", utils.CertificateIdentity{}, types.VerificationPolicy{})
 	require.EqualError(t, err, "cannot get Zarf package from Kubernetes without configuration")
 
-	pkg, err := packageFromSourceOrCluster(ctx, nil, "./testdata/zarf-package-test-amd64-0.0.1.tar.zst", false, "")
+	pkg, err := packageFromSourceOrCluster(ctx, nil, "./testdata/zarf-package-test-amd64-0.0.1.tar.zst", false, "", utils.CertificateIdentity{}, types.VerificationPolicy{})
 	require.NoError(t, err)
 	require.Equal(t, "test", pkg.Metadata.Name)
 
 	c := &cluster.Cluster{
 		Clientset: fake.NewClientset(),
 	}
-	_, err = c.RecordPackageDeployment(ctx, pkg, nil)
+	_, err = c.RecordPackageDeployment(ctx, pkg, nil, nil)
 	require.NoError(t, err)
-	pkg, err = packageFromSourceOrCluster(ctx, c, "test", false, "")
+	pkg, err = packageFromSourceOrCluster(ctx, c, "test", false, "", utils.CertificateIdentity{}, types.VerificationPolicy{})
 	require.NoError(t, err)
 	require.Equal(t, "test", pkg.Metadata.Name)
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"runtime"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -28,6 +29,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -44,44 +46,82 @@ type MirrorOptions struct {
 
 // Mirror mirrors the package contents to the given registry and git server.
 func Mirror(ctx context.Context, opt MirrorOptions) error {
-	err := pushImagesToRegistry(ctx, opt.Cluster, opt.PkgLayout, opt.Filter, opt.RegistryInfo, opt.NoImageChecksum, opt.Retries)
+	start := time.Now()
+	arch := opt.PkgLayout.Pkg.Build.Architecture
+	filter := opt.Filter
+	// Only the images and components of one architecture of a multi-architecture package are mirrored.
+	if arch == zoci.MultiArch {
+		requested := config.CLIArch
+		if requested == zoci.MultiArch {
+			requested = ""
+		}
+		var err error
+		arch, err = filters.SelectArchitecture(opt.PkgLayout.Pkg.Build.Architectures, requested, nil, runtime.GOARCH)
+		if err != nil {
+			return err
+		}
+		filter = filters.Combine(filters.ByArchitecture(arch), opt.Filter)
+	}
+	imageCount, err := pushImagesToRegistry(ctx, opt.Cluster, opt.PkgLayout, filter, arch, opt.RegistryInfo, opt.NoImageChecksum, opt.Retries)
 	if err != nil {
 		return err
 	}
-	err = pushReposToRepository(ctx, opt.Cluster, opt.PkgLayout, opt.Filter, opt.GitInfo, opt.Retries)
+	repoCount, err := pushReposToRepository(ctx, opt.Cluster, opt.PkgLayout, filter, opt.GitInfo, opt.Retries)
 	if err != nil {
 		return err
 	}
+	message.Successf("Mirrored %d images and %d repositories of package %s in %s", imageCount, repoCount, opt.PkgLayout.Pkg.Metadata.Name, time.Since(start).Round(time.Second))
+	logger.From(ctx).Info("mirrored package resources", "name", opt.PkgLayout.Pkg.Metadata.Name, "images", imageCount, "repos", repoCount, "duration", time.Since(start))
 	return nil
 }
 
-func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, regInfo types.RegistryInfo, noImgChecksum bool, retries int) error {
+// mirrorRetryOptions returns the options to retry pushing a resource with, failed attempts are reported before they
+// are retried with an increasing delay.
+func mirrorRetryOptions(ctx context.Context, resource string, retries int) []retry.Option {
+	l := logger.From(ctx)
+	return []retry.Option{
+		retry.Context(ctx),
+		retry.Attempts(uint(retries)),
+		retry.Delay(500 * time.Millisecond),
+		retry.DelayType(retry.BackOffDelay),
+		retry.MaxDelay(30 * time.Second),
+		retry.OnRetry(func(n uint, err error) {
+			message.Warnf("Retrying push of %s (attempt %d of %d): %s", resource, n+2, retries, err.Error())
+			l.Warn("retrying push", "resource", resource, "attempt", n+2, "attempts", retries, "error", err)
+		}),
+	}
+}
+
+func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, arch string, regInfo types.RegistryInfo, noImgChecksum bool, retries int) (int, error) {
 	l := logger.From(ctx)
 
 	components, err := filter.Apply(pkgLayout.Pkg)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	images := map[transform.Image]v1.Image{}
+	// Images are pushed in the order they are listed so that progress is reported consistently.
+	refs := []transform.Image{}
 	for _, component := range components {
 		for _, img := range component.Images {
 			ref, err := transform.ParseImageRef(img)
 			if err != nil {
-				return fmt.Errorf("failed to create ref for image %s: %w", img, err)
+				return 0, fmt.Errorf("failed to create ref for image %s: %w", img, err)
 			}
 			if _, ok := images[ref]; ok {
 				continue
 			}
-			img, err := pkgLayout.GetImage(ref)
+			img, err := pkgLayout.GetImage(ref, arch)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			images[ref] = img
+			refs = append(refs, ref)
 		}
 	}
 	if len(images) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
@@ -91,7 +131,7 @@ func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *la
 	transport := helpers.NewTransport(defaultTransport, nil)
 
 	pushOptions := []crane.Option{
-		crane.WithPlatform(&v1.Platform{OS: "linux", Architecture: pkgLayout.Pkg.Build.Architecture}),
+		crane.WithPlatform(&v1.Platform{OS: "linux", Architecture: arch}),
 		crane.WithTransport(transport),
 		crane.WithAuth(authn.FromConfig(authn.AuthConfig{
 			Username: regInfo.PushUsername,
@@ -105,7 +145,10 @@ func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *la
 		pushOptions = append(pushOptions, crane.Insecure)
 	}
 
-	for refInfo, img := range images {
+	for i, refInfo := range refs {
+		img := images[refInfo]
+		message.Notef("Mirroring image %d of %d: %s", i+1, len(refs), refInfo.Reference)
+		l.Info("mirroring image", "index", i+1, "total", len(refs), "name", refInfo.Reference)
 		err = retry.Do(func() error {
 			pushImage := func(registryUrl string) error {
 				names := []string{}
@@ -159,34 +202,42 @@ func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *la
 				return err
 			}
 			return nil
-		}, retry.Context(ctx), retry.Attempts(uint(retries)), retry.Delay(500*time.Millisecond))
+		}, mirrorRetryOptions(ctx, refInfo.Reference, retries)...)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
-	return nil
+	return len(refs), nil
 }
 
-func pushReposToRepository(ctx context.Context, c *cluster.Cluster, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, gitInfo types.GitServerInfo, retries int) error {
+func pushReposToRepository(ctx context.Context, c *cluster.Cluster, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, gitInfo types.GitServerInfo, retries int) (int, error) {
 	l := logger.From(ctx)
 	components, err := filter.Apply(pkgLayout.Pkg)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	total := 0
+	for _, component := range components {
+		total += len(component.Repos)
 	}
+	pushed := 0
 	for _, component := range components {
 		for _, repoURL := range component.Repos {
+			pushed++
+			message.Notef("Mirroring repository %d of %d: %s", pushed, total, repoURL)
+			l.Info("mirroring repository", "index", pushed, "total", total, "repo", repoURL)
 			tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			defer os.RemoveAll(tmpDir)
 			reposPath, err := pkgLayout.GetComponentDir(tmpDir, component.Name, layout.RepoComponentDir)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			repository, err := git.Open(reposPath, repoURL)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			err = retry.Do(func() error {
 				if !dns.IsServiceURL(gitInfo.Address) {
@@ -238,11 +289,11 @@ func pushReposToRepository(ctx context.Context, c *cluster.Cluster, pkgLayout *l
 					}
 					return nil
 				})
-			}, retry.Context(ctx), retry.Attempts(uint(retries)), retry.Delay(500*time.Millisecond))
+			}, mirrorRetryOptions(ctx, repoURL, retries)...)
 			if err != nil {
-				return fmt.Errorf("unable to push repo %s to the Git Server: %w", repoURL, err)
+				return 0, fmt.Errorf("unable to push repo %s to the Git Server: %w", repoURL, err)
 			}
 		}
 	}
-	return nil
+	return total, nil
 }
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// PlanDeployOptions are the options for PlanDeploy.
+type PlanDeployOptions struct {
+	Source                  string
+	Shasum                  string
+	OptionalComponents      string
+	PublicKeyPath           string
+	CertificateIdentity     utils.CertificateIdentity
+	SkipSignatureValidation bool
+	VerificationPolicy      types.VerificationPolicy
+	Cluster                 *cluster.Cluster
+}
+
+// PlanDeploy returns the changes deploying the package makes to the package deployed in the cluster.
+func PlanDeploy(ctx context.Context, opt PlanDeployOptions) (types.DeployPlan, error) {
+	loadOpt := LoadOptions{
+		Source:                  opt.Source,
+		Shasum:                  opt.Shasum,
+		PublicKeyPath:           opt.PublicKeyPath,
+		CertificateIdentity:     opt.CertificateIdentity,
+		SkipSignatureValidation: opt.SkipSignatureValidation,
+		VerificationPolicy:      opt.VerificationPolicy,
+		Filter:                  filters.Empty(),
+	}
+	pkgLayout, err := LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return types.DeployPlan{}, err
+	}
+	//nolint: errcheck // ignore
+	defer pkgLayout.Cleanup()
+
+	filter := filters.Combine(
+		filters.ByLocalOS(runtime.GOOS),
+		filters.ForDeploy(opt.OptionalComponents, false),
+	)
+	components, err := filter.Apply(pkgLayout.Pkg)
+	if err != nil {
+		return types.DeployPlan{}, err
+	}
+
+	var deployed *types.DeployedPackage
+	if opt.Cluster != nil {
+		deployed, err = opt.Cluster.GetDeployedPackage(ctx, pkgLayout.Pkg.Metadata.Name)
+		if kerrors.IsNotFound(err) {
+			deployed, err = nil, nil
+		}
+		if err != nil {
+			return types.DeployPlan{}, fmt.Errorf("unable to get the deployed package %s: %w", pkgLayout.Pkg.Metadata.Name, err)
+		}
+	}
+	return planDeploy(pkgLayout.Pkg, components, deployed), nil
+}
+
+// planDeploy compares the selected components of a package with the deployed package.
+// Components are only considered unchanged when the deployed package was deployed from the same build.
+func planDeploy(pkg v1alpha1.ZarfPackage, components []v1alpha1.ZarfComponent, deployed *types.DeployedPackage) types.DeployPlan {
+	plan := types.DeployPlan{
+		Package:    pkg.Metadata.Name,
+		Version:    pkg.Metadata.Version,
+		Components: []types.ComponentPlan{},
+	}
+	deployedNames := []string{}
+	sameBuild := false
+	if deployed != nil {
+		plan.DeployedVersion = deployed.Data.Metadata.Version
+		sameBuild = deployed.Data.Metadata.Version == pkg.Metadata.Version && deployed.Data.Build.Timestamp == pkg.Build.Timestamp
+		for _, c := range deployed.DeployedComponents {
+			deployedNames = append(deployedNames, c.Name)
+		}
+	}
+
+	selectedNames := []string{}
+	for _, c := range components {
+		selectedNames = append(selectedNames, c.Name)
+		action := types.ComponentActionCreate
+		if slices.Contains(deployedNames, c.Name) {
+			action = types.ComponentActionUpdate
+			if sameBuild {
+				action = types.ComponentActionNoOp
+			}
+		}
+		plan.Components = append(plan.Components, types.ComponentPlan{Name: c.Name, Action: action})
+	}
+	for _, name := range deployedNames {
+		if !slices.Contains(selectedNames, name) {
+			plan.Components = append(plan.Components, types.ComponentPlan{Name: name, Action: types.ComponentActionUntrack})
+		}
+	}
+	return plan
+}
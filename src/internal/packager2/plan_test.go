@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestPlanDeploy(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Build:    v1alpha1.ZarfBuildData{Timestamp: "Mon, 01 Sep 2025 00:00:00 +0000"},
+	}
+	components := []v1alpha1.ZarfComponent{{Name: "a"}, {Name: "b"}}
+
+	plan := planDeploy(pkg, components, nil)
+	require.Equal(t, types.DeployPlan{
+		Package: "test",
+		Version: "1.0.0",
+		Components: []types.ComponentPlan{
+			{Name: "a", Action: types.ComponentActionCreate},
+			{Name: "b", Action: types.ComponentActionCreate},
+		},
+	}, plan)
+	require.True(t, plan.HasChanges())
+
+	deployed := &types.DeployedPackage{
+		Name:               "test",
+		Data:               pkg,
+		DeployedComponents: []types.DeployedComponent{{Name: "a"}, {Name: "b"}},
+	}
+	plan = planDeploy(pkg, components, deployed)
+	require.Equal(t, "1.0.0", plan.DeployedVersion)
+	require.Equal(t, []types.ComponentPlan{
+		{Name: "a", Action: types.ComponentActionNoOp},
+		{Name: "b", Action: types.ComponentActionNoOp},
+	}, plan.Components)
+	require.False(t, plan.HasChanges())
+
+	plan = planDeploy(pkg, components[:1], deployed)
+	require.Equal(t, []types.ComponentPlan{
+		{Name: "a", Action: types.ComponentActionNoOp},
+		{Name: "b", Action: types.ComponentActionUntrack},
+	}, plan.Components)
+	require.True(t, plan.HasChanges())
+
+	// A rebuild of the same version updates every deployed component.
+	rebuilt := pkg
+	rebuilt.Build.Timestamp = "Tue, 02 Sep 2025 00:00:00 +0000"
+	plan = planDeploy(rebuilt, append(components, v1alpha1.ZarfComponent{Name: "c"}), deployed)
+	require.Equal(t, []types.ComponentPlan{
+		{Name: "a", Action: types.ComponentActionUpdate},
+		{Name: "b", Action: types.ComponentActionUpdate},
+		{Name: "c", Action: types.ComponentActionCreate},
+	}, plan.Components)
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// PruneOptions are the options for Prune.
+type PruneOptions struct {
+	Cluster     *cluster.Cluster
+	PackageName string
+	// Keep is the number of versions to keep including the currently deployed version.
+	Keep int
+}
+
+// Prune removes the superseded version records of a deployed package beyond the versions to keep,
+// the images that were only referenced by the removed versions, and old Helm release revisions of the package charts.
+func Prune(ctx context.Context, opt PruneOptions) error {
+	l := logger.From(ctx)
+	if opt.Keep < 1 {
+		return errors.New("at least one version must be kept")
+	}
+
+	current, err := opt.Cluster.GetDeployedPackage(ctx, opt.PackageName)
+	if err != nil {
+		return fmt.Errorf("unable to get the deployed package %s: %w", opt.PackageName, err)
+	}
+	history, err := opt.Cluster.GetDeployedPackageHistory(ctx, opt.PackageName)
+	if err != nil {
+		return err
+	}
+	kept, pruned := splitPackageHistory(history, opt.Keep)
+
+	if len(pruned) > 0 {
+		referenced := []types.DeployedPackage{*current}
+		referenced = append(referenced, kept...)
+		deployedPackages, err := opt.Cluster.GetDeployedZarfPackages(ctx)
+		if err != nil {
+			return err
+		}
+		for _, depPkg := range deployedPackages {
+			if depPkg.Name == opt.PackageName {
+				continue
+			}
+			referenced = append(referenced, depPkg)
+			otherHistory, err := opt.Cluster.GetDeployedPackageHistory(ctx, depPkg.Name)
+			if err != nil {
+				return err
+			}
+			referenced = append(referenced, otherHistory...)
+		}
+		unusedImages := uniqueImages(pruned, referenced)
+		if len(unusedImages) > 0 {
+			err := pruneRegistryImages(ctx, opt.Cluster, unusedImages, deployedImages(referenced))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, depPkg := range pruned {
+		message.Infof("Removing the record of %s version %s", depPkg.Name, depPkg.Data.Metadata.Version)
+		l.Info("removing package version record", "name", depPkg.Name, "version", depPkg.Data.Metadata.Version)
+		err := opt.Cluster.DeleteDeployedPackageHistory(ctx, depPkg.Name, depPkg.Generation)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, component := range current.DeployedComponents {
+		for _, chart := range component.InstalledCharts {
+			removed, err := opt.Cluster.PruneHelmReleaseHistory(ctx, chart.Namespace, chart.ChartName, opt.Keep)
+			if err != nil {
+				return fmt.Errorf("unable to prune the history of helm chart %s in the namespace %s: %w", chart.ChartName, chart.Namespace, err)
+			}
+			for _, name := range removed {
+				message.Infof("Removed the stale helm release secret %s/%s", chart.Namespace, name)
+				l.Info("removed stale helm release secret", "name", name, "namespace", chart.Namespace)
+			}
+		}
+	}
+	return nil
+}
+
+// splitPackageHistory splits the history of a package, newest first, into the records to keep and to prune.
+// The currently deployed version counts towards the number of versions to keep.
+func splitPackageHistory(history []types.DeployedPackage, keep int) ([]types.DeployedPackage, []types.DeployedPackage) {
+	keepHistory := min(max(keep-1, 0), len(history))
+	return history[:keepHistory], history[keepHistory:]
+}
+
+// deployedImages returns the images of the deployed components of the given packages.
+func deployedImages(depPkgs []types.DeployedPackage) []string {
+	imgs := []string{}
+	for _, depPkg := range depPkgs {
+		deployedComponents := map[string]bool{}
+		for _, depComponent := range depPkg.DeployedComponents {
+			deployedComponents[depComponent.Name] = true
+		}
+		for _, component := range depPkg.Data.Components {
+			if !deployedComponents[component.Name] {
+				continue
+			}
+			imgs = append(imgs, component.Images...)
+		}
+	}
+	slices.Sort(imgs)
+	return slices.Compact(imgs)
+}
+
+// uniqueImages returns the images deployed by the pruned packages that are not deployed by any of the referenced packages.
+func uniqueImages(pruned, referenced []types.DeployedPackage) []string {
+	referencedImages := deployedImages(referenced)
+	unused := []string{}
+	for _, img := range deployedImages(pruned) {
+		if slices.Contains(referencedImages, img) {
+			continue
+		}
+		unused = append(unused, img)
+	}
+	return unused
+}
+
+// pruneRegistryImages deletes the given images from the Zarf registry unless they share a digest with a referenced image.
+func pruneRegistryImages(ctx context.Context, c *cluster.Cluster, unusedImages, referencedImages []string) error {
+	l := logger.From(ctx)
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	registryEndpoint, tunnel, err := c.ConnectToZarfRegistryEndpoint(ctx, state.RegistryInfo)
+	if err != nil {
+		return err
+	}
+	doPrune := func() error {
+		authOption := images.WithPushAuth(state.RegistryInfo)
+		// The no checksum image always exists and shares its digest with the checksum tag.
+		digestForImage := func(img string) (string, error) {
+			ref, err := transform.ImageTransformHostWithoutChecksum(registryEndpoint, img)
+			if err != nil {
+				return "", err
+			}
+			return crane.Digest(ref, authOption)
+		}
+		referencedDigests := map[string]bool{}
+		for _, img := range referencedImages {
+			digest, err := digestForImage(img)
+			if err != nil {
+				// Images that can not be resolved are not in the registry and can not share a digest.
+				continue
+			}
+			referencedDigests[digest] = true
+		}
+		deletedDigests := map[string]bool{}
+		for _, img := range unusedImages {
+			digest, err := digestForImage(img)
+			if err != nil {
+				l.Debug("skipping image that is not in the registry", "name", img, "error", err)
+				continue
+			}
+			if referencedDigests[digest] || deletedDigests[digest] {
+				continue
+			}
+			ref, err := transform.ImageTransformHostWithoutChecksum(registryEndpoint, img)
+			if err != nil {
+				return err
+			}
+			refInfo, err := transform.ParseImageRef(ref)
+			if err != nil {
+				return err
+			}
+			digestRef := fmt.Sprintf("%s@%s", refInfo.Name, digest)
+			if err := crane.Delete(digestRef, authOption); err != nil {
+				return fmt.Errorf("unable to delete image %s: %w", img, err)
+			}
+			deletedDigests[digest] = true
+			message.Infof("Removed the unused image %s", img)
+			l.Info("removed unused image", "name", img)
+		}
+		return nil
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+		return tunnel.Wrap(doPrune)
+	}
+	return doPrune()
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestSplitPackageHistory(t *testing.T) {
+	t.Parallel()
+
+	history := []types.DeployedPackage{{Generation: 3}, {Generation: 2}, {Generation: 1}, {Generation: 0}}
+
+	kept, pruned := splitPackageHistory(history, 3)
+	require.Equal(t, history[:2], kept)
+	require.Equal(t, history[2:], pruned)
+
+	kept, pruned = splitPackageHistory(history, 1)
+	require.Empty(t, kept)
+	require.Equal(t, history, pruned)
+
+	kept, pruned = splitPackageHistory(history, 10)
+	require.Equal(t, history, kept)
+	require.Empty(t, pruned)
+}
+
+func TestUniqueImages(t *testing.T) {
+	t.Parallel()
+
+	newDeployedPackage := func(imgs ...string) types.DeployedPackage {
+		return types.DeployedPackage{
+			Data: v1alpha1.ZarfPackage{
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "deployed", Images: imgs},
+					{Name: "skipped", Images: []string{"ghcr.io/zarf-dev/skipped:1.0.0"}},
+				},
+			},
+			DeployedComponents: []types.DeployedComponent{{Name: "deployed"}},
+		}
+	}
+
+	pruned := []types.DeployedPackage{
+		newDeployedPackage("ghcr.io/zarf-dev/app:1.0.0", "ghcr.io/zarf-dev/shared:1.0.0"),
+		newDeployedPackage("ghcr.io/zarf-dev/app:1.1.0", "ghcr.io/zarf-dev/shared:1.0.0"),
+	}
+	referenced := []types.DeployedPackage{
+		newDeployedPackage("ghcr.io/zarf-dev/app:1.2.0", "ghcr.io/zarf-dev/shared:1.0.0"),
+	}
+	unused := uniqueImages(pruned, referenced)
+	require.Equal(t, []string{"ghcr.io/zarf-dev/app:1.0.0", "ghcr.io/zarf-dev/app:1.1.0"}, unused)
+}
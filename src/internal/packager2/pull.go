@@ -26,10 +26,11 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // Pull fetches the Zarf package from the given sources.
-func Pull(ctx context.Context, src, dir, shasum string, filter filters.ComponentFilterStrategy, publicKeyPath string, skipSignatureValidation bool) error {
+func Pull(ctx context.Context, src, dir, shasum string, filter filters.ComponentFilterStrategy, publicKeyPath string, identity utils.CertificateIdentity, skipSignatureValidation bool, policy types.VerificationPolicy) error {
 	u, err := url.Parse(src)
 	if err != nil {
 		return err
@@ -49,9 +50,10 @@ func Pull(ctx context.Context, src, dir, shasum string, filter filters.Component
 	tmpPath := filepath.Join(tmpDir, "data.tar.zst")
 
 	isPartial := false
+	var components []string
 	switch u.Scheme {
 	case "oci":
-		isPartial, err = pullOCI(ctx, src, tmpPath, shasum, filter)
+		isPartial, components, err = pullOCI(ctx, src, tmpPath, shasum, filter)
 		if err != nil {
 			return err
 		}
@@ -60,6 +62,11 @@ func Pull(ctx context.Context, src, dir, shasum string, filter filters.Component
 		if err != nil {
 			return err
 		}
+	case utils.S3URLScheme:
+		err := pullS3(ctx, src, tmpPath, shasum)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown scheme %s", u.Scheme)
 	}
@@ -67,8 +74,12 @@ func Pull(ctx context.Context, src, dir, shasum string, filter filters.Component
 	// This loadFromTar is done so that validatePackageIntegrtiy and validatePackageSignature are called
 	layoutOpt := layout.PackageLayoutOptions{
 		PublicKeyPath:           publicKeyPath,
+		CertificateIdentity:     identity,
 		SkipSignatureValidation: skipSignatureValidation,
 		IsPartial:               isPartial,
+		Components:              components,
+		VerificationPolicy:      policy,
+		Source:                  src,
 	}
 	_, err = layout.LoadFromTar(ctx, tmpPath, layoutOpt)
 	if err != nil {
@@ -101,10 +112,12 @@ func Pull(ctx context.Context, src, dir, shasum string, filter filters.Component
 	return nil
 }
 
-func pullOCI(ctx context.Context, src, tarPath, shasum string, filter filters.ComponentFilterStrategy) (bool, error) {
+// pullOCI pulls the package layers of the components selected by the filter into tarPath. It returns whether only some of
+// the package layers were pulled along with the names of the pulled components.
+func pullOCI(ctx context.Context, src, tarPath, shasum string, filter filters.ComponentFilterStrategy) (bool, []string, error) {
 	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	defer os.Remove(tmpDir)
 	if shasum != "" {
@@ -113,48 +126,52 @@ func pullOCI(ctx context.Context, src, tarPath, shasum string, filter filters.Co
 	arch := config.GetArch()
 	remote, err := zoci.NewRemote(ctx, src, oci.PlatformForArch(arch))
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	desc, err := remote.ResolveRoot(ctx)
 	if err != nil {
-		return false, fmt.Errorf("could not fetch images index: %w", err)
+		return false, nil, fmt.Errorf("could not fetch images index: %w", err)
 	}
 	layersToPull := []ocispec.Descriptor{}
 	isPartial := false
+	components := []string{}
 	if supportsFiltering(desc.Platform) {
 		root, err := remote.FetchRoot(ctx)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		if len(root.Layers) != len(layersToPull) {
 			isPartial = true
 		}
 		pkg, err := remote.FetchZarfYAML(ctx)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		pkg.Components, err = filter.Apply(pkg)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		layersToPull, err = remote.LayersFromRequestedComponents(ctx, pkg.Components)
 		if err != nil {
-			return false, err
+			return false, nil, err
+		}
+		for _, component := range pkg.Components {
+			components = append(components, component.Name)
 		}
 	}
 	_, err = remote.PullPackage(ctx, tmpDir, config.CommonOptions.OCIConcurrency, layersToPull...)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	allTheLayers, err := filepath.Glob(filepath.Join(tmpDir, "*"))
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	err = archiver.Archive(allTheLayers, tarPath)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
-	return isPartial, nil
+	return isPartial, components, nil
 }
 
 func pullHTTP(ctx context.Context, src, tarPath, shasum string) error {
@@ -196,6 +213,14 @@ func pullHTTP(ctx context.Context, src, tarPath, shasum string) error {
 	return nil
 }
 
+// pullS3 downloads the package from S3 compatible object storage, validating the shasum if one is provided.
+func pullS3(ctx context.Context, src, tarPath, shasum string) error {
+	if shasum != "" {
+		src = fmt.Sprintf("%s@%s", src, shasum)
+	}
+	return utils.DownloadToFile(ctx, src, tarPath, "")
+}
+
 func nameFromMetadata(path string) (string, error) {
 	var pkg v1alpha1.ZarfPackage
 	err := archiver.Walk(path, func(f archiver.File) error {
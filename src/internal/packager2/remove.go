@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"maps"
 	"slices"
 
 	"helm.sh/helm/v3/pkg/action"
@@ -16,10 +17,12 @@ import (
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/actions"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -30,12 +33,17 @@ type RemoveOptions struct {
 	Filter                  filters.ComponentFilterStrategy
 	SkipSignatureValidation bool
 	PublicKeyPath           string
+	CertificateIdentity     utils.CertificateIdentity
+	VerificationPolicy      types.VerificationPolicy
+	// ClusterContexts maps the cluster aliases of components to kube contexts.
+	ClusterContexts map[string]string
+	// SetVariables are the package variables used to template the remove actions, as they were set on deploy.
+	SetVariables map[string]string
 }
 
 // Remove removes a package that was already deployed onto a cluster, uninstalling all installed helm charts.
 func Remove(ctx context.Context, opt RemoveOptions) error {
-	l := logger.From(ctx)
-	pkg, err := packageFromSourceOrCluster(ctx, opt.Cluster, opt.Source, opt.SkipSignatureValidation, opt.PublicKeyPath)
+	pkg, err := packageFromSourceOrCluster(ctx, opt.Cluster, opt.Source, opt.SkipSignatureValidation, opt.PublicKeyPath, opt.CertificateIdentity, opt.VerificationPolicy)
 	if err != nil {
 		return err
 	}
@@ -45,13 +53,46 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 	if err != nil {
 		return err
 	}
+
+	// Components are removed from each cluster they were deployed to, starting with the last cluster deployed to.
+	aliases := []string{}
+	targetComponents := map[string][]v1alpha1.ZarfComponent{}
+	for _, component := range components {
+		if _, ok := targetComponents[component.Cluster]; !ok {
+			aliases = append(aliases, component.Cluster)
+		}
+		targetComponents[component.Cluster] = append(targetComponents[component.Cluster], component)
+	}
+	slices.Reverse(aliases)
+	for _, alias := range aliases {
+		c := opt.Cluster
+		if alias != "" {
+			c = nil
+			if slices.ContainsFunc(targetComponents[alias], v1alpha1.ZarfComponent.RequiresCluster) {
+				c, err = cluster.NewClusterForContext(cluster.TargetKubeContext(alias, opt.ClusterContexts))
+				if err != nil {
+					return err
+				}
+			}
+		}
+		err := removeFromCluster(ctx, c, pkg, targetComponents[alias], opt.SetVariables)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFromCluster removes the components of the package that were deployed to the cluster.
+func removeFromCluster(ctx context.Context, c *cluster.Cluster, pkg v1alpha1.ZarfPackage, components []v1alpha1.ZarfComponent, setVariables map[string]string) (err error) {
+	l := logger.From(ctx)
 	// Check that cluster is configured if required.
 	requiresCluster := false
 	componentIdx := map[string]v1alpha1.ZarfComponent{}
 	for _, component := range components {
 		componentIdx[component.Name] = component
 		if component.RequiresCluster() {
-			if opt.Cluster == nil {
+			if c == nil {
 				return fmt.Errorf("component %s requires cluster access but none was configured", component.Name)
 			}
 			requiresCluster = true
@@ -61,10 +102,19 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 	// Get or build the secret for the deployed package
 	depPkg := &types.DeployedPackage{}
 	if requiresCluster {
-		depPkg, err = opt.Cluster.GetDeployedPackage(ctx, pkg.Metadata.Name)
+		depPkg, err = c.GetDeployedPackage(ctx, pkg.Metadata.Name)
 		if err != nil {
 			return fmt.Errorf("unable to load the secret for the package we are attempting to remove: %s", err.Error())
 		}
+		namespaces := cluster.PackageNamespaces(components)
+		c.RecordPackageEvent(ctx, pkg, cluster.PackageRemove, cluster.PackageEventStarted, namespaces, nil)
+		defer func() {
+			phase := cluster.PackageEventSucceeded
+			if err != nil {
+				phase = cluster.PackageEventFailed
+			}
+			c.RecordPackageEvent(ctx, pkg, cluster.PackageRemove, phase, namespaces, err)
+		}()
 	} else {
 		// If we do not need the cluster, create a deployed components object based on the info we have
 		depPkg.Name = pkg.Metadata.Name
@@ -72,74 +122,67 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 		for _, component := range components {
 			depPkg.DeployedComponents = append(depPkg.DeployedComponents, types.DeployedComponent{Name: component.Name})
 		}
+		// The variables the package was deployed with are still used for the remove actions when the cluster is available.
+		if c != nil {
+			if existing, err := c.GetDeployedPackage(ctx, pkg.Metadata.Name); err == nil {
+				depPkg.Variables = existing.Variables
+			}
+		}
 	}
 
-	reverseDepComps := slices.Clone(depPkg.DeployedComponents)
-	slices.Reverse(reverseDepComps)
-	for _, depComp := range reverseDepComps {
-		// Only remove the component if it was requested or if we are removing the whole package.
-		comp, ok := componentIdx[depComp.Name]
-		if !ok {
-			continue
+	// Remove actions are templated with the same variables as deploy actions.
+	variableConfig := template.GetZarfVariableConfig(ctx)
+	variableConfig.SetConstants(pkg.Constants)
+	err = variableConfig.PopulateVariables(pkg.Variables, removeVariables(*depPkg, setVariables))
+	if err != nil {
+		return err
+	}
+	var state *types.ZarfState
+	if c != nil {
+		state, err = c.LoadZarfState(ctx)
+		if err != nil {
+			// The state is only used for templating, packages can be removed from clusters that were never initialized.
+			l.Debug("unable to load the zarf state, state variables will not be templated", "error", err)
+			state = nil
 		}
+	}
 
+	for _, name := range removalOrder(*depPkg, componentIdx) {
+		comp := componentIdx[name]
 		err := func() error {
-			err := actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.Before, nil)
+			applicationTemplates, err := template.GetZarfTemplates(ctx, comp.Name, state)
+			if err != nil {
+				return err
+			}
+			variableConfig.SetApplicationTemplates(applicationTemplates)
+
+			err = actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.Before, variableConfig)
 			if err != nil {
 				return fmt.Errorf("unable to run the before action: %w", err)
 			}
 
-			reverseInstalledCharts := slices.Clone(depComp.InstalledCharts)
-			slices.Reverse(reverseInstalledCharts)
-			if opt.Cluster != nil {
-				for _, chart := range reverseInstalledCharts {
-					settings := cli.New()
-					settings.SetNamespace(chart.Namespace)
-					actionConfig := &action.Configuration{}
-					// TODO (phillebaba): Get credentials from cluster instead of reading again.
-					err := actionConfig.Init(settings.RESTClientGetter(), chart.Namespace, "", func(string, ...interface{}) {})
-					if err != nil {
-						return err
-					}
-					client := action.NewUninstall(actionConfig)
-					client.KeepHistory = false
-					client.Wait = true
-					client.Timeout = config.ZarfDefaultTimeout
-					_, err = client.Run(chart.ChartName)
-					if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
-						return fmt.Errorf("unable to uninstall the helm chart %s in the namespace %s: %w", chart.ChartName, chart.Namespace, err)
-					}
-					if errors.Is(err, driver.ErrReleaseNotFound) {
-						message.Warnf("Helm release for helm chart '%s' in the namespace '%s' was not found.  Was it already removed?", chart.ChartName, chart.Namespace)
-						l.Warn("helm release was not found. was it already removed?", "name", chart.ChartName, "namespace", chart.Namespace)
-					}
-
-					// Pop the removed helm chart from the installed charts slice.
-					installedCharts := depPkg.DeployedComponents[len(depPkg.DeployedComponents)-1].InstalledCharts
-					installedCharts = installedCharts[:len(installedCharts)-1]
-					depPkg.DeployedComponents[len(depPkg.DeployedComponents)-1].InstalledCharts = installedCharts
-					err = opt.Cluster.UpdateDeployedPackage(ctx, *depPkg)
-					if err != nil {
-						// We warn and ignore errors because we may have removed the cluster that this package was inside of
-						message.Warnf("Unable to update the secret for package %s, this may be normal if the cluster was removed: %s", depPkg.Name, err.Error())
-						l.Warn("unable to update secret for package, this may be normal if the cluster was removed", "pkgName", depPkg.Name, "error", err.Error())
-					}
+			if c != nil {
+				err = removeCharts(ctx, c, depPkg, comp.Name)
+				if err != nil {
+					return err
 				}
 			}
 
-			err = actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.After, nil)
+			err = actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.After, variableConfig)
 			if err != nil {
 				return fmt.Errorf("unable to run the after action: %w", err)
 			}
-			err = actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.OnSuccess, nil)
+			err = actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.OnSuccess, variableConfig)
 			if err != nil {
 				return fmt.Errorf("unable to run the success action: %w", err)
 			}
 
-			// Pop the removed component from deploy components slice.
-			if opt.Cluster != nil {
-				depPkg.DeployedComponents = depPkg.DeployedComponents[:len(depPkg.DeployedComponents)-1]
-				err = opt.Cluster.UpdateDeployedPackage(ctx, *depPkg)
+			// Drop the removed component from the deployed components.
+			depPkg.DeployedComponents = slices.DeleteFunc(depPkg.DeployedComponents, func(depComp types.DeployedComponent) bool {
+				return depComp.Name == comp.Name
+			})
+			if c != nil {
+				err = c.UpdateDeployedPackage(ctx, *depPkg)
 				if err != nil {
 					// We warn and ignore errors because we may have removed the cluster that this package was inside of
 					message.Warnf("Unable to update the secret for package %s, this may be normal if the cluster was removed: %s", depPkg.Name, err.Error())
@@ -149,7 +192,7 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 			return nil
 		}()
 		if err != nil {
-			removeErr := actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.OnFailure, nil)
+			removeErr := actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.OnFailure, variableConfig)
 			if removeErr != nil {
 				return errors.Join(fmt.Errorf("unable to run the failure action: %w", err), removeErr)
 			}
@@ -158,13 +201,117 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 	}
 
 	// All the installed components were deleted, therefore this package is no longer actually deployed
-	if opt.Cluster != nil && len(depPkg.DeployedComponents) == 0 {
-		err := opt.Cluster.DeleteDeployedPackage(ctx, depPkg.Name)
+	if c != nil && len(depPkg.DeployedComponents) == 0 {
+		if depPkg.ScopedCredentials != nil {
+			err := revokeScopedCredentials(ctx, c, *depPkg.ScopedCredentials)
+			if err != nil {
+				message.Warnf("Unable to revoke the scoped credentials of package %s: %s", depPkg.Name, err.Error())
+				l.Warn("unable to revoke the scoped credentials of package", "pkgName", depPkg.Name, "error", err.Error())
+			}
+		}
+		err := c.DeleteDeployedPackage(ctx, depPkg.Name)
 		if err != nil {
 			message.Warnf("Unable to delete the secret for package %s, this may be normal if the cluster was removed: %s", depPkg.Name, err.Error())
 			l.Warn("unable to delete secret for package, this may be normal if the cluster was removed", "pkgName", depPkg.Name, "error", err.Error())
 		}
+		if err := c.DeletePackageExports(ctx, depPkg.Name); err != nil {
+			message.Warnf("Unable to delete the exports of package %s: %s", depPkg.Name, err.Error())
+			l.Warn("unable to delete the exports of package", "pkgName", depPkg.Name, "error", err.Error())
+		}
 	}
 
 	return nil
 }
+
+// removalOrder returns the names of the requested components that are deployed, in the reverse of the order they were
+// deployed in so that components are removed before the components they were deployed after.
+func removalOrder(depPkg types.DeployedPackage, componentIdx map[string]v1alpha1.ZarfComponent) []string {
+	names := []string{}
+	for _, depComp := range slices.Backward(depPkg.DeployedComponents) {
+		// Only remove the component if it was requested or if we are removing the whole package.
+		if _, ok := componentIdx[depComp.Name]; !ok {
+			continue
+		}
+		names = append(names, depComp.Name)
+	}
+	return names
+}
+
+// removeCharts uninstalls the charts of a deployed component in the reverse of the order they were installed in. The
+// deployed package is updated after each chart so that an interrupted removal can be resumed. Namespaces created for
+// the charts are deleted once no chart is installed in them, waiting for them to terminate.
+func removeCharts(ctx context.Context, c *cluster.Cluster, depPkg *types.DeployedPackage, componentName string) error {
+	l := logger.From(ctx)
+	idx := slices.IndexFunc(depPkg.DeployedComponents, func(depComp types.DeployedComponent) bool {
+		return depComp.Name == componentName
+	})
+	if idx == -1 {
+		return nil
+	}
+	for _, chart := range slices.Backward(slices.Clone(depPkg.DeployedComponents[idx].InstalledCharts)) {
+		settings := cli.New()
+		settings.SetNamespace(chart.Namespace)
+		settings.KubeContext = c.KubeContext
+		actionConfig := &action.Configuration{}
+		// TODO (phillebaba): Get credentials from cluster instead of reading again.
+		err := actionConfig.Init(settings.RESTClientGetter(), chart.Namespace, "", func(string, ...interface{}) {})
+		if err != nil {
+			return err
+		}
+		client := action.NewUninstall(actionConfig)
+		client.KeepHistory = false
+		client.Wait = true
+		client.Timeout = config.ZarfDefaultTimeout
+		_, err = client.Run(chart.ChartName)
+		if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+			return fmt.Errorf("unable to uninstall the helm chart %s in the namespace %s: %w", chart.ChartName, chart.Namespace, err)
+		}
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			message.Warnf("Helm release for helm chart '%s' in the namespace '%s' was not found.  Was it already removed?", chart.ChartName, chart.Namespace)
+			l.Warn("helm release was not found. was it already removed?", "name", chart.ChartName, "namespace", chart.Namespace)
+		}
+
+		// Drop the removed helm chart from the installed charts of the component.
+		depPkg.DeployedComponents[idx].InstalledCharts = slices.DeleteFunc(depPkg.DeployedComponents[idx].InstalledCharts, func(installed types.InstalledChart) bool {
+			return installed.Namespace == chart.Namespace && installed.ChartName == chart.ChartName
+		})
+		err = c.UpdateDeployedPackage(ctx, *depPkg)
+		if err != nil {
+			// We warn and ignore errors because we may have removed the cluster that this package was inside of
+			message.Warnf("Unable to update the secret for package %s, this may be normal if the cluster was removed: %s", depPkg.Name, err.Error())
+			l.Warn("unable to update secret for package, this may be normal if the cluster was removed", "pkgName", depPkg.Name, "error", err.Error())
+		}
+
+		if chart.CreatedNamespace {
+			deleteCtx, cancel := context.WithTimeout(ctx, config.ZarfDefaultTimeout)
+			deleted, err := c.DeleteUnusedNamespace(deleteCtx, chart.Namespace, *depPkg)
+			cancel()
+			if err != nil {
+				// We warn and ignore errors because we may have removed the cluster that this package was inside of
+				message.Warnf("Unable to delete the namespace %s created for helm chart '%s': %s", chart.Namespace, chart.ChartName, err.Error())
+				l.Warn("unable to delete namespace created for helm chart", "name", chart.ChartName, "namespace", chart.Namespace, "error", err.Error())
+			}
+			if deleted {
+				l.Debug("deleted namespace created for helm chart", "name", chart.ChartName, "namespace", chart.Namespace)
+			}
+		}
+	}
+	return nil
+}
+
+// removeVariables returns the variables remove actions are templated with, the non-sensitive values the package was
+// deployed with overridden by the variables set on remove. Sensitive variables are not recorded so they have to be set again.
+func removeVariables(depPkg types.DeployedPackage, setVariables map[string]string) map[string]string {
+	variables := map[string]string{}
+	maps.Copy(variables, depPkg.Variables)
+	maps.Copy(variables, setVariables)
+	return variables
+}
+
+func revokeScopedCredentials(ctx context.Context, c *cluster.Cluster, creds types.ScopedCredentials) error {
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	return c.RevokeScopedCredentials(ctx, state, creds)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRemovalOrder(t *testing.T) {
+	t.Parallel()
+
+	depPkg := types.DeployedPackage{
+		DeployedComponents: []types.DeployedComponent{
+			{Name: "crds"},
+			{Name: "operator"},
+			{Name: "app"},
+		},
+	}
+	componentIdx := map[string]v1alpha1.ZarfComponent{
+		"app":  {Name: "app"},
+		"crds": {Name: "crds"},
+		// Requested components that were never deployed are not removed.
+		"docs": {Name: "docs"},
+	}
+	require.Equal(t, []string{"app", "crds"}, removalOrder(depPkg, componentIdx))
+}
+
+func TestRemoveActions(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	out := filepath.Join(t.TempDir(), "out.txt")
+	component := func(name string) v1alpha1.ZarfComponent {
+		return v1alpha1.ZarfComponent{
+			Name: name,
+			Actions: v1alpha1.ZarfComponentActions{
+				OnRemove: v1alpha1.ZarfComponentActionSet{
+					Before: []v1alpha1.ZarfComponentAction{{Cmd: fmt.Sprintf("echo before-%s-${ZARF_VAR_ENV}-${ZARF_CONST_REGION} >> %s", name, out)}},
+					After:  []v1alpha1.ZarfComponentAction{{Cmd: fmt.Sprintf("echo after-%s >> %s", name, out)}},
+				},
+			},
+		}
+	}
+	pkg := v1alpha1.ZarfPackage{
+		Metadata:   v1alpha1.ZarfMetadata{Name: "actions"},
+		Components: []v1alpha1.ZarfComponent{component("first"), component("second")},
+		Variables:  []v1alpha1.InteractiveVariable{{Variable: v1alpha1.Variable{Name: "ENV"}, Default: "dev"}},
+		Constants:  []v1alpha1.Constant{{Name: "REGION", Value: "east"}},
+	}
+
+	err := removeFromCluster(ctx, nil, pkg, pkg.Components, map[string]string{"ENV": "prod"})
+	require.NoError(t, err)
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "before-second-prod-east\nafter-second\nbefore-first-prod-east\nafter-first\n", string(b))
+}
+
+func TestRemoveVariables(t *testing.T) {
+	t.Parallel()
+
+	depPkg := types.DeployedPackage{Variables: map[string]string{"ENV": "staging", "REGION": "east"}}
+	require.Equal(t, map[string]string{"ENV": "prod", "REGION": "east", "TOKEN": "secret"}, removeVariables(depPkg, map[string]string{"ENV": "prod", "TOKEN": "secret"}))
+	require.Equal(t, map[string]string{"ENV": "prod"}, removeVariables(types.DeployedPackage{}, map[string]string{"ENV": "prod"}))
+}
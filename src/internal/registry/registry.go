@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package registry runs throwaway OCI registries for local development.
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/distribution/distribution/v3/configuration"
+	distribution "github.com/distribution/distribution/v3/registry"
+	_ "github.com/distribution/distribution/v3/registry/storage/driver/filesystem" // used for the persistent registry
+	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory"   // used for the throwaway registry
+	"github.com/sirupsen/logrus"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	zarfexec "github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// Runtime is what runs the registry.
+type Runtime string
+
+const (
+	// RuntimeAuto uses the first container runtime found on the PATH and falls back to the in-process registry.
+	RuntimeAuto Runtime = "auto"
+	// RuntimeGo runs the registry within the Zarf process, no container runtime is needed.
+	RuntimeGo Runtime = "go"
+	// RuntimeDocker runs the registry as a docker container.
+	RuntimeDocker Runtime = "docker"
+	// RuntimePodman runs the registry as a podman container.
+	RuntimePodman Runtime = "podman"
+	// RuntimeNerdctl runs the registry as a nerdctl container.
+	RuntimeNerdctl Runtime = "nerdctl"
+)
+
+// containerRuntimes are the container runtimes in the order they are detected.
+var containerRuntimes = []Runtime{RuntimeDocker, RuntimePodman, RuntimeNerdctl}
+
+// DefaultImage is the registry image run by container runtimes.
+const DefaultImage = "docker.io/library/registry:2"
+
+// containerName is the name of the registry container.
+const containerName = "zarf-dev-registry"
+
+// Options are the options for Run.
+type Options struct {
+	// Address the registry listens on, e.g. 127.0.0.1:5000.
+	Address string
+	// Runtime that runs the registry.
+	Runtime Runtime
+	// StorageDir persists the registry contents, the contents are kept in memory when empty.
+	StorageDir string
+	// Image run by container runtimes.
+	Image string
+}
+
+// ParseRuntime returns the runtime with the given name.
+func ParseRuntime(runtime string) (Runtime, error) {
+	switch r := Runtime(runtime); r {
+	case RuntimeAuto, RuntimeGo, RuntimeDocker, RuntimePodman, RuntimeNerdctl:
+		return r, nil
+	default:
+		return "", fmt.Errorf("invalid registry runtime %q, valid options are %s, %s, %s, %s and %s", runtime, RuntimeAuto, RuntimeGo, RuntimeDocker, RuntimePodman, RuntimeNerdctl)
+	}
+}
+
+// DetectRuntime returns the first container runtime found with lookPath, or the in-process registry when there is none.
+func DetectRuntime(lookPath func(string) (string, error)) Runtime {
+	for _, runtime := range containerRuntimes {
+		if _, err := lookPath(string(runtime)); err == nil {
+			return runtime
+		}
+	}
+	return RuntimeGo
+}
+
+// Run runs the registry until the context is canceled.
+func Run(ctx context.Context, opt Options) error {
+	if opt.Runtime == RuntimeAuto || opt.Runtime == "" {
+		opt.Runtime = DetectRuntime(exec.LookPath)
+	}
+	if opt.Image == "" {
+		opt.Image = DefaultImage
+	}
+	logger.From(ctx).Info("starting registry", "address", opt.Address, "runtime", opt.Runtime)
+	if opt.Runtime == RuntimeGo {
+		return runInProcess(ctx, opt)
+	}
+	return runContainer(ctx, opt)
+}
+
+// runInProcess runs a distribution registry within the Zarf process.
+func runInProcess(ctx context.Context, opt Options) error {
+	config := &configuration.Configuration{}
+	config.HTTP.Addr = opt.Address
+	config.Log.AccessLog.Disabled = true
+	config.Log.Level = "error"
+	logrus.SetOutput(io.Discard)
+	config.Storage = map[string]configuration.Parameters{"inmemory": map[string]interface{}{}}
+	if opt.StorageDir != "" {
+		config.Storage = map[string]configuration.Parameters{"filesystem": map[string]interface{}{"rootdirectory": opt.StorageDir}}
+	}
+	reg, err := distribution.NewRegistry(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- reg.ListenAndServe()
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := reg.Shutdown(shutdownCtx)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// runContainer runs the registry image with a container runtime and removes the container when the context is canceled.
+func runContainer(ctx context.Context, opt Options) error {
+	args, err := containerRunArgs(opt)
+	if err != nil {
+		return err
+	}
+	l := logger.From(ctx)
+	l.Debug("running registry container", "runtime", opt.Runtime, "args", args)
+	_, stderr, err := zarfexec.CmdWithContext(ctx, zarfexec.Config{}, string(opt.Runtime), args...)
+	if err != nil {
+		return fmt.Errorf("unable to run the registry with %s: %s: %w", opt.Runtime, stderr, err)
+	}
+
+	<-ctx.Done()
+	l.Info("removing registry container", "name", containerName)
+	// The context is done so the container is removed with a fresh one.
+	_, stderr, err = zarfexec.CmdWithContext(context.Background(), zarfexec.Config{}, string(opt.Runtime), "rm", "--force", containerName)
+	if err != nil {
+		return fmt.Errorf("unable to remove the registry container: %s: %w", stderr, err)
+	}
+	return nil
+}
+
+// containerRunArgs returns the arguments to run the registry image, docker, podman and nerdctl share the same flags.
+func containerRunArgs(opt Options) ([]string, error) {
+	host, port, err := net.SplitHostPort(opt.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry address %q: %w", opt.Address, err)
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		return nil, fmt.Errorf("invalid registry port %q", port)
+	}
+	publish := port + ":5000"
+	if host != "" {
+		publish = net.JoinHostPort(host, port) + ":5000"
+	}
+	args := []string{"run", "--detach", "--rm", "--name", containerName, "--publish", publish}
+	if opt.StorageDir != "" {
+		// Bind mounts need an absolute path.
+		storageDir, err := filepath.Abs(opt.StorageDir)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--volume", storageDir+":/var/lib/registry")
+	}
+	return append(args, opt.Image), nil
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestDetectRuntime(t *testing.T) {
+	t.Parallel()
+
+	lookPath := func(available ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, a := range available {
+				if a == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		}
+	}
+	require.Equal(t, RuntimeDocker, DetectRuntime(lookPath("nerdctl", "podman", "docker")))
+	require.Equal(t, RuntimePodman, DetectRuntime(lookPath("nerdctl", "podman")))
+	require.Equal(t, RuntimeNerdctl, DetectRuntime(lookPath("nerdctl")))
+	require.Equal(t, RuntimeGo, DetectRuntime(lookPath()))
+}
+
+func TestParseRuntime(t *testing.T) {
+	t.Parallel()
+
+	runtime, err := ParseRuntime("podman")
+	require.NoError(t, err)
+	require.Equal(t, RuntimePodman, runtime)
+
+	_, err = ParseRuntime("containerd")
+	require.EqualError(t, err, `invalid registry runtime "containerd", valid options are auto, go, docker, podman and nerdctl`)
+}
+
+func TestContainerRunArgs(t *testing.T) {
+	t.Parallel()
+
+	args, err := containerRunArgs(Options{Address: "127.0.0.1:5000", Image: DefaultImage})
+	require.NoError(t, err)
+	require.Equal(t, []string{"run", "--detach", "--rm", "--name", containerName, "--publish", "127.0.0.1:5000:5000", DefaultImage}, args)
+
+	storageDir := t.TempDir()
+	args, err = containerRunArgs(Options{Address: ":5001", StorageDir: storageDir, Image: DefaultImage})
+	require.NoError(t, err)
+	require.Equal(t, []string{"run", "--detach", "--rm", "--name", containerName, "--publish", "5001:5000", "--volume", filepath.Clean(storageDir) + ":/var/lib/registry", DefaultImage}, args)
+
+	_, err = containerRunArgs(Options{Address: "localhost"})
+	require.ErrorContains(t, err, `invalid registry address "localhost"`)
+}
+
+func TestRunInProcess(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	ctx, cancel := context.WithCancel(testutil.TestContext(t))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(ctx, Options{Address: address, Runtime: RuntimeGo, StorageDir: t.TempDir()})
+	}()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(fmt.Sprintf("http://%s/v2/", address))
+		if err != nil {
+			return false
+		}
+		//nolint: errcheck // ignore
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.True(t, err == nil || errors.Is(err, http.ErrServerClosed), err)
+	case <-time.After(15 * time.Second):
+		t.Fatal("registry did not stop")
+	}
+}
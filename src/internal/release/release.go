@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package release bumps package versions and writes changelogs from the changes between two package definitions.
+package release
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// Bump is the part of a semantic version that is incremented.
+type Bump int
+
+const (
+	// BumpNone is used when nothing changed.
+	BumpNone Bump = iota
+	// BumpPatch is used when images or the contents of existing components changed.
+	BumpPatch
+	// BumpMinor is used when components were added.
+	BumpMinor
+	// BumpMajor is used when components were removed.
+	BumpMajor
+)
+
+// String returns the name of the bump.
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// ComponentChange describes what changed in a component that is in both package definitions.
+type ComponentChange struct {
+	Name    string
+	Details []string
+}
+
+// Changes are the changes between two package definitions.
+type Changes struct {
+	Added   []string
+	Removed []string
+	Changed []ComponentChange
+}
+
+// Bump returns the bump for the changes.
+func (c Changes) Bump() Bump {
+	switch {
+	case len(c.Removed) > 0:
+		return BumpMajor
+	case len(c.Added) > 0:
+		return BumpMinor
+	case len(c.Changed) > 0:
+		return BumpPatch
+	default:
+		return BumpNone
+	}
+}
+
+// Compare returns the components added, removed and changed from previous to current.
+func Compare(previous, current v1alpha1.ZarfPackage) Changes {
+	changes := Changes{}
+	previousComponents := map[string]v1alpha1.ZarfComponent{}
+	for _, component := range previous.Components {
+		previousComponents[component.Name] = component
+	}
+	currentNames := map[string]bool{}
+	for _, component := range current.Components {
+		currentNames[component.Name] = true
+		previousComponent, ok := previousComponents[component.Name]
+		if !ok {
+			changes.Added = append(changes.Added, component.Name)
+			continue
+		}
+		details := compareComponent(previousComponent, component)
+		if len(details) > 0 {
+			changes.Changed = append(changes.Changed, ComponentChange{Name: component.Name, Details: details})
+		}
+	}
+	for _, component := range previous.Components {
+		if !currentNames[component.Name] {
+			changes.Removed = append(changes.Removed, component.Name)
+		}
+	}
+	return changes
+}
+
+// compareComponent returns the changes between two versions of a component.
+func compareComponent(previous, current v1alpha1.ZarfComponent) []string {
+	details := []string{}
+	for _, image := range current.Images {
+		if !slices.Contains(previous.Images, image) {
+			details = append(details, fmt.Sprintf("Added image %s", image))
+		}
+	}
+	for _, image := range previous.Images {
+		if !slices.Contains(current.Images, image) {
+			details = append(details, fmt.Sprintf("Removed image %s", image))
+		}
+	}
+	for _, repo := range current.Repos {
+		if !slices.Contains(previous.Repos, repo) {
+			details = append(details, fmt.Sprintf("Added repository %s", repo))
+		}
+	}
+	for _, repo := range previous.Repos {
+		if !slices.Contains(current.Repos, repo) {
+			details = append(details, fmt.Sprintf("Removed repository %s", repo))
+		}
+	}
+	if !reflect.DeepEqual(previous.Charts, current.Charts) {
+		details = append(details, "Changed charts")
+	}
+	if !reflect.DeepEqual(previous.Manifests, current.Manifests) {
+		details = append(details, "Changed manifests")
+	}
+	if !reflect.DeepEqual(previous.Files, current.Files) {
+		details = append(details, "Changed files")
+	}
+
+	// Any other change is reported as a whole.
+	previous.Images, current.Images = nil, nil
+	previous.Repos, current.Repos = nil, nil
+	previous.Charts, current.Charts = nil, nil
+	previous.Manifests, current.Manifests = nil, nil
+	previous.Files, current.Files = nil, nil
+	if !reflect.DeepEqual(previous, current) {
+		details = append(details, "Changed component configuration")
+	}
+	return details
+}
+
+// NextVersion returns the version with the bump applied, a leading v is kept.
+func NextVersion(version string, bump Bump) (string, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("version %q is not a semantic version: %w", version, err)
+	}
+	var next semver.Version
+	switch bump {
+	case BumpPatch:
+		next = v.IncPatch()
+	case BumpMinor:
+		next = v.IncMinor()
+	case BumpMajor:
+		next = v.IncMajor()
+	default:
+		return version, nil
+	}
+	if strings.HasPrefix(version, "v") {
+		return "v" + next.String(), nil
+	}
+	return next.String(), nil
+}
+
+// Changelog returns the changelog section for the version.
+func Changelog(version string, date time.Time, changes Changes) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (%s)\n", version, date.Format(time.DateOnly))
+	if len(changes.Added) > 0 {
+		sb.WriteString("\n### Added components\n\n")
+		for _, name := range changes.Added {
+			fmt.Fprintf(&sb, "- %s\n", name)
+		}
+	}
+	if len(changes.Removed) > 0 {
+		sb.WriteString("\n### Removed components\n\n")
+		for _, name := range changes.Removed {
+			fmt.Fprintf(&sb, "- %s\n", name)
+		}
+	}
+	if len(changes.Changed) > 0 {
+		sb.WriteString("\n### Changed components\n\n")
+		for _, change := range changes.Changed {
+			fmt.Fprintf(&sb, "- %s\n", change.Name)
+			for _, detail := range change.Details {
+				fmt.Fprintf(&sb, "  - %s\n", detail)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// changelogHeader is the header of new changelogs.
+const changelogHeader = "# Changelog\n"
+
+// WriteChangelog adds the section to the top of the changelog at path, the changelog is created when it does not exist.
+func WriteChangelog(path, section string) error {
+	b, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	content := string(b)
+	if content == "" {
+		content = changelogHeader
+	}
+	// Sections go after the header when there is one.
+	header, rest := "", content
+	if strings.HasPrefix(content, "# ") {
+		header, rest, _ = strings.Cut(content, "\n")
+		header += "\n"
+	}
+	rest = strings.TrimLeft(rest, "\n")
+	content = header + "\n" + section
+	if rest != "" {
+		content += "\n" + rest
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// SetVersion returns the package definition with metadata.version set to version, comments and formatting are kept.
+func SetVersion(b []byte, version string) ([]byte, error) {
+	file, err := parser.ParseBytes(b, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	path, err := yaml.PathString("$.metadata.version")
+	if err != nil {
+		return nil, err
+	}
+	previous, err := path.FilterFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set metadata.version: %w", err)
+	}
+	node, err := yaml.ValueToNode(version)
+	if err != nil {
+		return nil, err
+	}
+	if err := node.SetComment(previous.GetComment()); err != nil {
+		return nil, err
+	}
+	if err := path.ReplaceWithNode(file, node); err != nil {
+		return nil, fmt.Errorf("unable to set metadata.version: %w", err)
+	}
+	out := file.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	return []byte(out), nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	previous := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "app", Images: []string{"ghcr.io/app:1.0.0"}},
+			{Name: "unchanged", Images: []string{"ghcr.io/unchanged:1.0.0"}},
+			{Name: "removed"},
+		},
+	}
+	current := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "app", Images: []string{"ghcr.io/app:1.0.1"}, Required: helpers.BoolPtr(true)},
+			{Name: "unchanged", Images: []string{"ghcr.io/unchanged:1.0.0"}},
+			{Name: "added"},
+		},
+	}
+
+	changes := Compare(previous, current)
+	expected := Changes{
+		Added:   []string{"added"},
+		Removed: []string{"removed"},
+		Changed: []ComponentChange{
+			{
+				Name:    "app",
+				Details: []string{"Added image ghcr.io/app:1.0.1", "Removed image ghcr.io/app:1.0.0", "Changed component configuration"},
+			},
+		},
+	}
+	require.Equal(t, expected, changes)
+	require.Equal(t, BumpMajor, changes.Bump())
+	require.Equal(t, BumpMinor, Changes{Added: []string{"added"}}.Bump())
+	require.Equal(t, BumpPatch, Changes{Changed: expected.Changed}.Bump())
+	require.Equal(t, BumpNone, Compare(previous, previous).Bump())
+}
+
+func TestNextVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version  string
+		bump     Bump
+		expected string
+	}{
+		{version: "1.2.3", bump: BumpPatch, expected: "1.2.4"},
+		{version: "v1.2.3", bump: BumpMinor, expected: "v1.3.0"},
+		{version: "v1.2.3", bump: BumpMajor, expected: "v2.0.0"},
+		{version: "1.2.3-rc.1", bump: BumpPatch, expected: "1.2.3"},
+		{version: "1.2.3", bump: BumpNone, expected: "1.2.3"},
+	}
+	for _, tt := range tests {
+		version, err := NextVersion(tt.version, tt.bump)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, version)
+	}
+
+	_, err := NextVersion("latest", BumpPatch)
+	require.ErrorContains(t, err, `version "latest" is not a semantic version`)
+}
+
+func TestChangelog(t *testing.T) {
+	t.Parallel()
+
+	changes := Changes{
+		Added:   []string{"added"},
+		Changed: []ComponentChange{{Name: "app", Details: []string{"Added image ghcr.io/app:1.0.1"}}},
+	}
+	section := Changelog("v1.1.0", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), changes)
+	expected := `## v1.1.0 (2024-05-01)
+
+### Added components
+
+- added
+
+### Changed components
+
+- app
+  - Added image ghcr.io/app:1.0.1
+`
+	require.Equal(t, expected, section)
+
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	require.NoError(t, WriteChangelog(path, "## v1.0.0\n"))
+	require.NoError(t, WriteChangelog(path, "## v1.1.0\n"))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "# Changelog\n\n## v1.1.0\n\n## v1.0.0\n", string(b))
+}
+
+func TestSetVersion(t *testing.T) {
+	t.Parallel()
+
+	definition := `# The package
+kind: ZarfPackageConfig
+metadata:
+  name: test
+  version: v1.0.0 # bumped by zarf dev release
+components:
+  - name: app
+`
+	b, err := SetVersion([]byte(definition), "v1.1.0")
+	require.NoError(t, err)
+	require.Contains(t, string(b), "# The package\n")
+	require.Contains(t, string(b), "version: v1.1.0 # bumped by zarf dev release\n")
+	require.Contains(t, string(b), "  - name: app\n")
+
+	_, err = SetVersion([]byte("kind: ZarfPackageConfig\nmetadata:\n  name: test\n"), "v1.1.0")
+	require.ErrorContains(t, err, "unable to set metadata.version")
+}
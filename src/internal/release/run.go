@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// Options are the options for Run.
+type Options struct {
+	// Path to the directory with the package definition, it must be within a git repository.
+	Path string
+	// BaseRef is the git ref with the previous package definition, the latest tag is used when empty.
+	BaseRef string
+	// ChangelogPath is the changelog the release is added to, relative paths are relative to Path.
+	ChangelogPath string
+	// Tag commits the package definition and changelog and tags the commit with the new version.
+	Tag bool
+}
+
+// Result is the outcome of a release.
+type Result struct {
+	PreviousVersion string
+	Version         string
+	Bump            Bump
+	Changes         Changes
+}
+
+// Run compares the package definition in Path to the one at the base ref, bumps metadata.version and adds the changes
+// to the changelog.
+func Run(ctx context.Context, opt Options) (Result, error) {
+	l := logger.From(ctx)
+	baseRef := opt.BaseRef
+	if baseRef == "" {
+		out, err := git(ctx, opt.Path, "describe", "--tags", "--abbrev=0")
+		if err != nil {
+			return Result{}, fmt.Errorf("unable to find the latest tag, set a base ref: %w", err)
+		}
+		baseRef = strings.TrimSpace(out)
+	}
+	l.Debug("comparing package definition", "path", opt.Path, "base", baseRef)
+
+	previousB, err := git(ctx, opt.Path, "show", fmt.Sprintf("%s:./%s", baseRef, layout.ZarfYAML))
+	if err != nil {
+		return Result{}, fmt.Errorf("unable to read %s at %s: %w", layout.ZarfYAML, baseRef, err)
+	}
+	previous, err := layout.ParseZarfPackage([]byte(previousB))
+	if err != nil {
+		return Result{}, err
+	}
+	definitionPath := filepath.Join(opt.Path, layout.ZarfYAML)
+	currentB, err := os.ReadFile(definitionPath)
+	if err != nil {
+		return Result{}, err
+	}
+	current, err := layout.ParseZarfPackage(currentB)
+	if err != nil {
+		return Result{}, err
+	}
+
+	changes := Compare(previous, current)
+	bump := changes.Bump()
+	if bump == BumpNone {
+		return Result{}, fmt.Errorf("no component changes since %s", baseRef)
+	}
+	version, err := NextVersion(previous.Metadata.Version, bump)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{
+		PreviousVersion: previous.Metadata.Version,
+		Version:         version,
+		Bump:            bump,
+		Changes:         changes,
+	}
+
+	b, err := SetVersion(currentB, version)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := os.WriteFile(definitionPath, b, 0o644); err != nil {
+		return Result{}, err
+	}
+	changelogPath := opt.ChangelogPath
+	if !filepath.IsAbs(changelogPath) {
+		changelogPath = filepath.Join(opt.Path, changelogPath)
+	}
+	if err := WriteChangelog(changelogPath, Changelog(version, time.Now(), changes)); err != nil {
+		return Result{}, err
+	}
+	l.Info("released package", "previous", result.PreviousVersion, "version", version, "bump", bump)
+
+	if !opt.Tag {
+		return result, nil
+	}
+	if _, err := git(ctx, opt.Path, "add", definitionPath, changelogPath); err != nil {
+		return Result{}, err
+	}
+	if _, err := git(ctx, opt.Path, "commit", "--message", fmt.Sprintf("Release %s %s", current.Metadata.Name, version)); err != nil {
+		return Result{}, err
+	}
+	if _, err := git(ctx, opt.Path, "tag", version); err != nil {
+		return Result{}, err
+	}
+	l.Info("tagged release", "tag", version)
+	return result, nil
+}
+
+// git runs git in dir and returns stdout.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	stdout, stderr, err := exec.CmdWithContext(ctx, exec.Config{Dir: dir}, "git", args...)
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(stderr), err)
+	}
+	return stdout, nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	dir := t.TempDir()
+	runGit := func(args ...string) string {
+		t.Helper()
+		out, err := git(ctx, dir, append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		require.NoError(t, err)
+		return out
+	}
+	runGit("init", "--quiet")
+	definition := `kind: ZarfPackageConfig
+metadata:
+  name: test
+  version: v1.0.0
+components:
+  - name: app
+    images:
+      - ghcr.io/app:1.0.0
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zarf.yaml"), []byte(definition), 0o644))
+	runGit("add", "zarf.yaml")
+	runGit("commit", "--quiet", "--message", "initial")
+	runGit("tag", "v1.0.0")
+
+	_, err := Run(ctx, Options{Path: dir, ChangelogPath: "CHANGELOG.md"})
+	require.EqualError(t, err, "no component changes since v1.0.0")
+
+	definition = strings.Replace(definition, "ghcr.io/app:1.0.0", "ghcr.io/app:1.0.1", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zarf.yaml"), []byte(definition), 0o644))
+	result, err := Run(ctx, Options{Path: dir, ChangelogPath: "CHANGELOG.md"})
+	require.NoError(t, err)
+	require.Equal(t, "v1.0.0", result.PreviousVersion)
+	require.Equal(t, "v1.0.1", result.Version)
+	require.Equal(t, BumpPatch, result.Bump)
+
+	b, err := os.ReadFile(filepath.Join(dir, "zarf.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "version: v1.0.1")
+	b, err = os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "## v1.0.1 (")
+	require.Contains(t, string(b), "  - Added image ghcr.io/app:1.0.1\n")
+}
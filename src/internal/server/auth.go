@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// APIGroup is the API group of the resources that Kubernetes RBAC rules grant access to the Zarf API with.
+const APIGroup = "zarf.dev"
+
+// TokenAudience is the audience Kubernetes tokens must be issued for to be accepted by the Zarf API, so that tokens
+// issued for the Kubernetes API server or other services can not be replayed against it.
+const TokenAudience = "zarf.dev"
+
+var (
+	errUnauthenticated = errors.New("a valid bearer token is required")
+	errForbidden       = errors.New("forbidden")
+)
+
+// Authorizer authenticates the bearer token of a request and decides whether it may perform the verb on the resource.
+type Authorizer interface {
+	// Authorize returns the name of the user the token belongs to. It returns errUnauthenticated when the token is not
+	// valid and errForbidden when the user may not perform the verb on the resource.
+	Authorize(ctx context.Context, token, verb, resource string) (string, error)
+}
+
+// TokenAuthorizer allows every operation to requests that send the token.
+type TokenAuthorizer struct {
+	token string
+}
+
+// NewTokenAuthorizer returns an authorizer that allows every operation to requests that send the token.
+func NewTokenAuthorizer(token string) (*TokenAuthorizer, error) {
+	if token == "" {
+		return nil, errors.New("a token is required to start the server")
+	}
+	return &TokenAuthorizer{token: token}, nil
+}
+
+// Authorize allows every operation when the token matches.
+func (a *TokenAuthorizer) Authorize(_ context.Context, token, _, _ string) (string, error) {
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		return "", errUnauthenticated
+	}
+	return "token", nil
+}
+
+// TokenReviewAuthorizer authenticates Kubernetes tokens with a TokenReview and authorizes the user of the token with a
+// SubjectAccessReview, so that access to the API is granted with RBAC rules for the resources of APIGroup.
+type TokenReviewAuthorizer struct {
+	client kubernetes.Interface
+}
+
+// NewTokenReviewAuthorizer returns an authorizer that reviews tokens and access with the Kubernetes API server.
+func NewTokenReviewAuthorizer(client kubernetes.Interface) *TokenReviewAuthorizer {
+	return &TokenReviewAuthorizer{client: client}
+}
+
+// Authorize reviews the token and whether its user may perform the verb on the resource of APIGroup.
+func (a *TokenReviewAuthorizer) Authorize(ctx context.Context, token, verb, resource string) (string, error) {
+	if token == "" {
+		return "", errUnauthenticated
+	}
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{TokenAudience},
+		},
+	}
+	tokenReview, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, tokenReview, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to review the token: %w", err)
+	}
+	if !tokenReview.Status.Authenticated || !slices.Contains(tokenReview.Status.Audiences, TokenAudience) {
+		return "", errUnauthenticated
+	}
+	user := tokenReview.Status.User
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    APIGroup,
+				Resource: resource,
+				Verb:     verb,
+			},
+		},
+	}
+	accessReview, err = a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, accessReview, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to review the access of user %s: %w", user.Username, err)
+	}
+	if !accessReview.Status.Allowed {
+		return "", fmt.Errorf("%w: user %s cannot %s %s.%s", errForbidden, user.Username, verb, resource, APIGroup)
+	}
+	return user.Username, nil
+}
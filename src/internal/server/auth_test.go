@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestTokenReviewAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	// The operator token may list and deploy packages, the viewer token may only list them.
+	cs := fake.NewClientset()
+	cs.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		// Tokens are only authenticated for the audiences they were issued for.
+		if !slices.Contains(review.Spec.Audiences, TokenAudience) {
+			return true, review, nil
+		}
+		switch review.Spec.Token {
+		case "operator":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "operator", Groups: []string{"operators"}}, Audiences: []string{TokenAudience}}
+		case "viewer":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "viewer"}, Audiences: []string{TokenAudience}}
+		case "api-server":
+			// A token of another audience that the API server authenticates without an audience.
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "api-server"}, Audiences: []string{"https://kubernetes.default.svc"}}
+		}
+		return true, review, nil
+	})
+	cs.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Group == APIGroup && attrs.Resource == "packages" &&
+			(attrs.Verb == "list" || slices.Contains(review.Spec.Groups, "operators"))
+		return true, review, nil
+	})
+
+	s, err := New(testutil.TestContext(t), NewTokenReviewAuthorizer(cs), Options{})
+	require.NoError(t, err)
+	s.inventory = newTestInventory
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		body           string
+		expectedStatus int
+	}{
+		{name: "no token", method: http.MethodGet, path: "/api/v1/packages", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown token", method: http.MethodGet, path: "/api/v1/packages", token: "unknown", expectedStatus: http.StatusUnauthorized},
+		{name: "token of another audience", method: http.MethodGet, path: "/api/v1/packages", token: "api-server", expectedStatus: http.StatusUnauthorized},
+		{name: "viewer lists packages", method: http.MethodGet, path: "/api/v1/packages", token: "viewer", expectedStatus: http.StatusOK},
+		{name: "viewer deploys package", method: http.MethodPost, path: "/api/v1/packages/deploy", token: "viewer", body: `{"source":"zarf.tar.zst"}`, expectedStatus: http.StatusForbidden},
+		{name: "operator lists jobs", method: http.MethodGet, path: "/api/v1/jobs", token: "operator", expectedStatus: http.StatusForbidden},
+		{name: "operator deploys package", method: http.MethodPost, path: "/api/v1/packages/deploy", token: "operator", body: `{}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := doRequest(t, tt.method, ts.URL+tt.path, tt.token, tt.body)
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
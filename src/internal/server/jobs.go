@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobStatus is the state of a package operation run by the server.
+type JobStatus string
+
+// The states of a job.
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// Event is a progress event logged by a package operation.
+type Event struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// Job is a package operation run by the server.
+type Job struct {
+	ID          string     `json:"id"`
+	Operation   string     `json:"operation"`
+	User        string     `json:"user,omitempty"`
+	Status      JobStatus  `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// job tracks the state and events of a package operation.
+type job struct {
+	mu      sync.Mutex
+	info    Job
+	events  []Event
+	changed chan struct{}
+}
+
+func newJob(id, operation, user string) *job {
+	return &job{
+		info: Job{
+			ID:        id,
+			Operation: operation,
+			User:      user,
+			Status:    JobPending,
+			CreatedAt: time.Now(),
+		},
+		changed: make(chan struct{}),
+	}
+}
+
+// notify wakes up every event stream waiting for changes, it must be called with the lock held.
+func (j *job) notify() {
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+func (j *job) snapshot() Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.info
+}
+
+func (j *job) setStatus(status JobStatus, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.info.Status = status
+	if err != nil {
+		j.info.Error = err.Error()
+	}
+	if status == JobSucceeded || status == JobFailed {
+		now := time.Now()
+		j.info.CompletedAt = &now
+	}
+	j.notify()
+}
+
+func (j *job) addEvent(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.events = append(j.events, e)
+	j.notify()
+}
+
+// eventsSince returns the events after the given offset, whether the job is done and a channel closed on the next change.
+func (j *job) eventsSince(offset int) ([]Event, bool, <-chan struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	events := append([]Event{}, j.events[min(offset, len(j.events)):]...)
+	done := j.info.Status == JobSucceeded || j.info.Status == JobFailed
+	return events, done, j.changed
+}
+
+// eventHandler records log records of a job as events and passes them on to the next handler.
+type eventHandler struct {
+	job    *job
+	next   slog.Handler
+	level  slog.Leveler
+	attrs  []slog.Attr
+	prefix string
+}
+
+func (h *eventHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() || h.next.Enabled(ctx, level)
+}
+
+func (h *eventHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= h.level.Level() {
+		attrs := map[string]any{}
+		for _, a := range h.attrs {
+			attrs[a.Key] = attrValue(a.Value)
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			attrs[h.prefix+a.Key] = attrValue(a.Value)
+			return true
+		})
+		e := Event{
+			Time:    r.Time,
+			Level:   strings.ToLower(r.Level.String()),
+			Message: r.Message,
+		}
+		if len(attrs) > 0 {
+			e.Attrs = attrs
+		}
+		h.job.addEvent(e)
+	}
+	if h.next.Enabled(ctx, r.Level) {
+		return h.next.Handle(ctx, r)
+	}
+	return nil
+}
+
+func (h *eventHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefixed := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	prefixed = append(prefixed, h.attrs...)
+	for _, a := range attrs {
+		prefixed = append(prefixed, slog.Attr{Key: h.prefix + a.Key, Value: a.Value})
+	}
+	return &eventHandler{job: h.job, next: h.next.WithAttrs(attrs), level: h.level, attrs: prefixed, prefix: h.prefix}
+}
+
+func (h *eventHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &eventHandler{job: h.job, next: h.next.WithGroup(name), level: h.level, attrs: h.attrs, prefix: h.prefix + name + "."}
+}
+
+// attrValue returns the value of an attribute that can be encoded as JSON.
+func attrValue(v slog.Value) any {
+	a := v.Resolve().Any()
+	if err, ok := a.(error); ok {
+		return err.Error()
+	}
+	return a
+}
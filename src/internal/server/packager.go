@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func createPackage(ctx context.Context, req CreateRequest) error {
+	opt := packager2.CreateOptions{
+		Flavor:             req.Flavor,
+		Output:             req.Output,
+		SetVariables:       helpers.TransformMapKeys(req.SetVariables, strings.ToUpper),
+		SigningKeyPath:     req.SigningKeyPath,
+		SigningKeyPassword: req.SigningKeyPassword,
+		SkipSBOM:           req.SkipSBOM,
+		MaxPackageSizeMB:   req.MaxPackageSizeMB,
+		Concurrency:        1,
+	}
+	if opt.Output == "" {
+		opt.Output = "."
+	}
+	if err := packager2.Create(ctx, req.Path, opt); err != nil {
+		return fmt.Errorf("failed to create package: %w", err)
+	}
+	return nil
+}
+
+func deployPackage(ctx context.Context, req DeployRequest) error {
+	cfg := types.PackagerConfig{
+		PkgOpts: types.ZarfPackageOptions{
+			PackageSource:           req.Source,
+			OptionalComponents:      req.Components,
+			SetVariables:            helpers.TransformMapKeys(req.SetVariables, strings.ToUpper),
+			Shasum:                  req.Shasum,
+			Retries:                 req.Retries,
+			SkipSignatureValidation: req.SkipSignatureValidation,
+		},
+		DeployOpts: types.ZarfDeployOptions{
+			AdoptExistingResources: req.AdoptExistingResources,
+			Timeout:                config.ZarfDefaultTimeout,
+		},
+	}
+	if cfg.PkgOpts.Retries == 0 {
+		cfg.PkgOpts.Retries = config.ZarfDefaultRetries
+	}
+	if req.Timeout != "" {
+		timeout, err := time.ParseDuration(req.Timeout)
+		if err != nil {
+			return err
+		}
+		cfg.DeployOpts.Timeout = timeout
+	}
+
+	pkgClient, err := packager.New(&cfg, packager.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer pkgClient.ClearTempPaths()
+	if err := pkgClient.Deploy(ctx); err != nil {
+		return fmt.Errorf("failed to deploy package: %w", err)
+	}
+	return nil
+}
+
+func removePackage(ctx context.Context, req RemoveRequest) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+	opt := packager2.RemoveOptions{
+		Source:  req.Source,
+		Cluster: c,
+		Filter: filters.Combine(
+			filters.ByLocalOS(runtime.GOOS),
+			filters.BySelectState(req.Components),
+		),
+		SkipSignatureValidation: req.SkipSignatureValidation,
+		SetVariables:            helpers.TransformMapKeys(req.SetVariables, strings.ToUpper),
+	}
+	if err := packager2.Remove(ctx, opt); err != nil {
+		return fmt.Errorf("failed to remove package: %w", err)
+	}
+	return nil
+}
+
+func inspectPackage(ctx context.Context, req InspectRequest) (v1alpha1.ZarfPackage, error) {
+	// Packages are only inspected in the cluster when the source is the name of a deployed package.
+	c, _ := cluster.NewCluster() //nolint:errcheck
+	opt := packager2.ZarfInspectOptions{
+		Source:                  req.Source,
+		Cluster:                 c,
+		SkipSignatureValidation: req.SkipSignatureValidation,
+	}
+	pkg, err := packager2.Inspect(ctx, opt)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, fmt.Errorf("failed to inspect package: %w", err)
+	}
+	return pkg, nil
+}
+
+func deployedPackages(ctx context.Context) ([]types.DeployedPackage, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil && len(pkgs) == 0 {
+		return nil, fmt.Errorf("unable to get the packages deployed to the cluster: %w", err)
+	}
+	return pkgs, nil
+}
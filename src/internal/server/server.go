@@ -0,0 +1,509 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package server exposes package operations over an HTTP API.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// CreateRequest is the body of a request to create a package.
+type CreateRequest struct {
+	// Path to the directory containing the zarf.yaml
+	Path string `json:"path"`
+	// Flavor of the package to create
+	Flavor string `json:"flavor,omitempty"`
+	// Directory or OCI repository the package is written to
+	Output string `json:"output,omitempty"`
+	// Package template variables to set
+	SetVariables map[string]string `json:"setVariables,omitempty"`
+	// Path to the private key used to sign the package
+	SigningKeyPath string `json:"signingKeyPath,omitempty"`
+	// Password of the private key used to sign the package
+	SigningKeyPassword string `json:"signingKeyPassword,omitempty"`
+	// Skip generating SBOMs for the package
+	SkipSBOM bool `json:"skipSBOM,omitempty"`
+	// Size in MB to split the package into chunks
+	MaxPackageSizeMB int `json:"maxPackageSizeMB,omitempty"`
+}
+
+// DeployRequest is the body of a request to deploy a package.
+type DeployRequest struct {
+	// Path, URL or OCI reference of the package
+	Source string `json:"source"`
+	// Comma separated list of optional components to deploy
+	Components string `json:"components,omitempty"`
+	// Deploy time variables to set
+	SetVariables map[string]string `json:"setVariables,omitempty"`
+	// SHA256 checksum of the package
+	Shasum string `json:"shasum,omitempty"`
+	// Number of retries for operations like image pushes or Helm installs
+	Retries int `json:"retries,omitempty"`
+	// Timeout of Helm operations such as "15m"
+	Timeout string `json:"timeout,omitempty"`
+	// Adopt pre-existing resources into the Helm charts managed by Zarf
+	AdoptExistingResources bool `json:"adoptExistingResources,omitempty"`
+	// Skip validating the signature of the package, set from the options of the server and never from the request
+	SkipSignatureValidation bool `json:"-"`
+}
+
+// RemoveRequest is the body of a request to remove a package.
+type RemoveRequest struct {
+	// Name of the deployed package or path, URL or OCI reference of the package
+	Source string `json:"source"`
+	// Comma separated list of components to remove
+	Components string `json:"components,omitempty"`
+	// Variables used to template the remove actions, as they were set on deploy
+	SetVariables map[string]string `json:"setVariables,omitempty"`
+	// Skip validating the signature of the package, set from the options of the server and never from the request
+	SkipSignatureValidation bool `json:"-"`
+}
+
+// InspectRequest is the body of a request to inspect a package.
+type InspectRequest struct {
+	// Name of the deployed package or path, URL or OCI reference of the package
+	Source string `json:"source"`
+	// Skip validating the signature of the package, set from the options of the server and never from the request
+	SkipSignatureValidation bool `json:"-"`
+}
+
+// Options configures what requests to the server may do.
+type Options struct {
+	// Root is the directory that the local paths of requests must be within, it defaults to the working directory.
+	Root string
+	// SkipSignatureValidation skips validating the signatures of the packages of every request.
+	SkipSignatureValidation bool
+}
+
+// Server runs package operations requested over HTTP as jobs.
+// Package operations share global configuration so only one job runs at a time.
+type Server struct {
+	ctx                     context.Context
+	auth                    Authorizer
+	root                    string
+	skipSignatureValidation bool
+
+	create    func(context.Context, CreateRequest) error
+	deploy    func(context.Context, DeployRequest) error
+	remove    func(context.Context, RemoveRequest) error
+	inspect   func(context.Context, InspectRequest) (v1alpha1.ZarfPackage, error)
+	inventory func(context.Context) ([]types.DeployedPackage, error)
+
+	opMu sync.Mutex
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// New returns a server that authorizes every request with the authorizer.
+// Jobs run with the logger of the context and stop when it is canceled.
+func New(ctx context.Context, auth Authorizer, opts Options) (*Server, error) {
+	if auth == nil {
+		return nil, errors.New("an authorizer is required to start the server")
+	}
+	root := opts.Root
+	if root == "" {
+		root = "."
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	// The root is compared to paths with symlinks resolved.
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access the root directory: %w", err)
+	}
+	return &Server{
+		ctx:                     ctx,
+		auth:                    auth,
+		root:                    root,
+		skipSignatureValidation: opts.SkipSignatureValidation,
+		create:                  createPackage,
+		deploy:                  deployPackage,
+		remove:                  removePackage,
+		inspect:                 inspectPackage,
+		inventory:               deployedPackages,
+		jobs:                    map[string]*job{},
+	}, nil
+}
+
+// GenerateToken returns a random token to authenticate requests.
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Handler returns the HTTP handler of the API.
+func (s *Server) Handler() http.Handler {
+	// Each route is authorized as a verb on a resource of APIGroup so that access can be granted with Kubernetes RBAC.
+	mux := http.NewServeMux()
+	mux.Handle("POST /api/v1/packages/create", s.authorize("create", "packages", s.handleCreate))
+	mux.Handle("POST /api/v1/packages/deploy", s.authorize("deploy", "packages", s.handleDeploy))
+	mux.Handle("POST /api/v1/packages/remove", s.authorize("remove", "packages", s.handleRemove))
+	mux.Handle("POST /api/v1/packages/inspect", s.authorize("get", "packages", s.handleInspect))
+	mux.Handle("GET /api/v1/packages", s.authorize("list", "packages", s.handleInventory))
+	mux.Handle("GET /api/v1/jobs", s.authorize("list", "jobs", s.handleListJobs))
+	mux.Handle("GET /api/v1/jobs/{id}", s.authorize("get", "jobs", s.handleGetJob))
+	mux.Handle("GET /api/v1/jobs/{id}/events", s.authorize("get", "jobs", s.handleEvents))
+	return mux
+}
+
+// ListenAndServe serves the API on the address until the context is canceled.
+// The API is served over TLS when a certificate and key file are given.
+func (s *Server) ListenAndServe(ctx context.Context, address, certFile, keyFile string) error {
+	srv := &http.Server{
+		Addr:              address,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if certFile != "" {
+			errCh <- srv.ListenAndServeTLS(certFile, keyFile)
+			return
+		}
+		errCh <- srv.ListenAndServe()
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+type userKey struct{}
+
+// authorize only passes requests whose bearer token may perform the verb on the resource to the handler.
+func (s *Server) authorize(verb, resource string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		user, err := s.auth.Authorize(r.Context(), token, verb, resource)
+		switch {
+		case errors.Is(err, errUnauthenticated):
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		case errors.Is(err, errForbidden):
+			writeError(w, http.StatusForbidden, err)
+			return
+		case err != nil:
+			logger.From(s.ctx).Error("unable to authorize request", "path", r.URL.Path, "error", err)
+			writeError(w, http.StatusInternalServerError, errors.New("unable to authorize request"))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey{}, user)))
+	})
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Path == "" {
+		writeError(w, http.StatusBadRequest, errors.New("path is required"))
+		return
+	}
+	var err error
+	if req.Path, err = s.resolvePath(req.Path); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Output == "" {
+		req.Output = "."
+	}
+	if !helpers.IsOCIURL(req.Output) {
+		if req.Output, err = s.resolvePath(req.Output); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if req.SigningKeyPath != "" {
+		if req.SigningKeyPath, err = s.resolvePath(req.SigningKeyPath); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	s.startJob(w, r, "create", func(ctx context.Context) error {
+		return s.create(ctx, req)
+	})
+}
+
+func (s *Server) handleDeploy(w http.ResponseWriter, r *http.Request) {
+	var req DeployRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Source == "" {
+		writeError(w, http.StatusBadRequest, errors.New("source is required"))
+		return
+	}
+	if req.Timeout != "" {
+		if _, err := time.ParseDuration(req.Timeout); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid timeout: %w", err))
+			return
+		}
+	}
+	var err error
+	if req.Source, err = s.resolveSource(req.Source); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.SkipSignatureValidation = s.skipSignatureValidation
+	s.startJob(w, r, "deploy", func(ctx context.Context) error {
+		return s.deploy(ctx, req)
+	})
+}
+
+func (s *Server) handleRemove(w http.ResponseWriter, r *http.Request) {
+	var req RemoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Source == "" {
+		writeError(w, http.StatusBadRequest, errors.New("source is required"))
+		return
+	}
+	var err error
+	if req.Source, err = s.resolveSource(req.Source); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.SkipSignatureValidation = s.skipSignatureValidation
+	s.startJob(w, r, "remove", func(ctx context.Context) error {
+		return s.remove(ctx, req)
+	})
+}
+
+// handleInspect responds with the definition of the package, it does not run as a job as it does not change the cluster.
+// It still waits for the running job as package operations share global configuration.
+func (s *Server) handleInspect(w http.ResponseWriter, r *http.Request) {
+	var req InspectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Source == "" {
+		writeError(w, http.StatusBadRequest, errors.New("source is required"))
+		return
+	}
+	var err error
+	if req.Source, err = s.resolveSource(req.Source); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.SkipSignatureValidation = s.skipSignatureValidation
+	s.opMu.Lock()
+	pkg, err := s.inspect(r.Context(), req)
+	s.opMu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pkg)
+}
+
+func (s *Server) handleInventory(w http.ResponseWriter, r *http.Request) {
+	pkgs, err := s.inventory(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if pkgs == nil {
+		pkgs = []types.DeployedPackage{}
+	}
+	// The scoped pull credentials of the packages are secrets, listing packages must not expose them.
+	for i := range pkgs {
+		pkgs[i].ScopedCredentials = nil
+	}
+	writeJSON(w, http.StatusOK, pkgs)
+}
+
+func (s *Server) handleListJobs(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j.snapshot())
+	}
+	s.mu.Unlock()
+	slices.SortFunc(jobs, func(a, b Job) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.getJob(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, j.snapshot())
+}
+
+// handleEvents streams the events of a job as server-sent events until the job completes.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.getJob(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not found", r.PathValue("id")))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	offset := 0
+	for {
+		events, done, changed := j.eventsSince(offset)
+		for _, e := range events {
+			if err := writeEvent(w, "log", e); err != nil {
+				return
+			}
+		}
+		offset += len(events)
+		if done {
+			_ = writeEvent(w, "status", j.snapshot())
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// resolveSource resolves the source of a request with resolvePath unless it is a URL or the name of a deployed package.
+func (s *Server) resolveSource(src string) (string, error) {
+	if parsed, err := url.Parse(src); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		return src, nil
+	}
+	if lint.IsLowercaseNumberHyphenNoStartHyphen(src) {
+		return src, nil
+	}
+	return s.resolvePath(src)
+}
+
+// resolvePath returns the absolute path of a local path of a request, relative paths are relative to the root of the
+// server. Paths outside of the root are rejected so that requests can not read or write arbitrary files.
+func (s *Server) resolvePath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.root, path)
+	}
+	path = filepath.Clean(path)
+	// Symlinks are resolved for the part of the path that exists, as the path may be created by the request.
+	resolved := path
+	for dir, rest := path, ""; ; {
+		if evaluated, err := filepath.EvalSymlinks(dir); err == nil {
+			resolved = filepath.Join(evaluated, rest)
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = parent
+	}
+	rel, err := filepath.Rel(s.root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of the root directory of the server", path)
+	}
+	return resolved, nil
+}
+
+func (s *Server) getJob(id string) (*job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	return j, ok
+}
+
+// startJob runs the operation in the background and responds with the job that tracks it.
+func (s *Server) startJob(w http.ResponseWriter, r *http.Request, operation string, fn func(context.Context) error) {
+	id, err := GenerateToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	id = id[:16]
+	user, _ := r.Context().Value(userKey{}).(string)
+	j := newJob(id, operation, user)
+	s.mu.Lock()
+	s.jobs[id] = j
+	s.mu.Unlock()
+
+	base := logger.From(s.ctx)
+	l := slog.New(&eventHandler{job: j, next: base.Handler(), level: slog.LevelInfo}).With("job", id)
+	ctx := logger.WithContext(s.ctx, l)
+	go func() {
+		s.opMu.Lock()
+		defer s.opMu.Unlock()
+		j.setStatus(JobRunning, nil)
+		l.Info("job started", "operation", operation, "user", user)
+		if err := fn(ctx); err != nil {
+			l.Error("job failed", "operation", operation, "error", err)
+			j.setStatus(JobFailed, err)
+			return
+		}
+		l.Info("job succeeded", "operation", operation)
+		j.setStatus(JobSucceeded, nil)
+	}()
+	writeJSON(w, http.StatusAccepted, j.snapshot())
+}
+
+func writeEvent(w http.ResponseWriter, name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, b)
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// The client is gone if the response cannot be written.
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
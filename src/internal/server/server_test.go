@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func newTestInventory(_ context.Context) ([]types.DeployedPackage, error) {
+	return []types.DeployedPackage{
+		{
+			Name: "test",
+			Data: v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}},
+			ScopedCredentials: &types.ScopedCredentials{
+				Username:         "zarf-pkg-test",
+				RegistryPassword: "registry-password",
+				GitToken:         "git-token",
+			},
+		},
+	}, nil
+}
+
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	auth, err := NewTokenAuthorizer("secret")
+	require.NoError(t, err)
+	s, err := New(testutil.TestContext(t), auth, Options{Root: t.TempDir()})
+	require.NoError(t, err)
+	s.create = func(ctx context.Context, req CreateRequest) error {
+		logger.From(ctx).Info("creating package", "path", req.Path)
+		return nil
+	}
+	s.deploy = func(ctx context.Context, req DeployRequest) error {
+		logger.From(ctx).Info("deploying package", "source", req.Source)
+		return errors.New("cluster unreachable")
+	}
+	s.remove = func(ctx context.Context, req RemoveRequest) error {
+		logger.From(ctx).Info("removing package", "source", req.Source)
+		return nil
+	}
+	s.inspect = func(_ context.Context, req InspectRequest) (v1alpha1.ZarfPackage, error) {
+		return v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: req.Source}}, nil
+	}
+	s.inventory = newTestInventory
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func doRequest(t *testing.T, method, url, token, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(testutil.TestContext(t), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		resp.Body.Close()
+	})
+	return resp
+}
+
+// streamEvents reads the event stream of a job until it completes.
+func streamEvents(t *testing.T, url string) ([]Event, Job) {
+	t.Helper()
+	resp := doRequest(t, http.MethodGet, url, "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := []Event{}
+	var status Job
+	name := ""
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data := []byte(strings.TrimPrefix(line, "data: "))
+			if name == "status" {
+				require.NoError(t, json.Unmarshal(data, &status))
+				continue
+			}
+			var e Event
+			require.NoError(t, json.Unmarshal(data, &e))
+			events = append(events, e)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return events, status
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTokenAuthorizer("")
+	require.EqualError(t, err, "a token is required to start the server")
+	_, err = New(testutil.TestContext(t), nil, Options{})
+	require.EqualError(t, err, "an authorizer is required to start the server")
+
+	_, ts := newTestServer(t)
+	for _, token := range []string{"", "wrong"} {
+		resp := doRequest(t, http.MethodGet, ts.URL+"/api/v1/packages", token, "")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+	resp := doRequest(t, http.MethodGet, ts.URL+"/api/v1/packages", "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var pkgs []types.DeployedPackage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pkgs))
+	require.Len(t, pkgs, 1)
+	require.Equal(t, "test", pkgs[0].Name)
+}
+
+func TestInventoryCredentials(t *testing.T) {
+	t.Parallel()
+
+	_, ts := newTestServer(t)
+	resp := doRequest(t, http.MethodGet, ts.URL+"/api/v1/packages", "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "scopedCredentials")
+	require.NotContains(t, string(b), "registry-password")
+	require.NotContains(t, string(b), "git-token")
+}
+
+func TestJobs(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/create", "secret", `{}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/deploy", "secret", `{"source":"zarf.tar.zst","timeout":"soon"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = doRequest(t, http.MethodGet, ts.URL+"/api/v1/jobs/missing", "secret", "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/create", "secret", `{"path":"examples/dos-games"}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var job Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+	require.Equal(t, "create", job.Operation)
+
+	events, status := streamEvents(t, ts.URL+"/api/v1/jobs/"+job.ID+"/events")
+	require.Equal(t, JobSucceeded, status.Status)
+	require.NotNil(t, status.CompletedAt)
+	messages := []string{}
+	for _, e := range events {
+		messages = append(messages, e.Message)
+	}
+	require.Equal(t, []string{"job started", "creating package", "job succeeded"}, messages)
+	require.Equal(t, filepath.Join(s.root, "examples/dos-games"), events[1].Attrs["path"])
+	require.Equal(t, job.ID, events[1].Attrs["job"])
+
+	resp = doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/deploy", "secret", `{"source":"zarf.tar.zst"}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+	_, status = streamEvents(t, ts.URL+"/api/v1/jobs/"+job.ID+"/events")
+	require.Equal(t, JobFailed, status.Status)
+	require.Equal(t, "cluster unreachable", status.Error)
+
+	resp = doRequest(t, http.MethodGet, ts.URL+"/api/v1/jobs/"+job.ID, "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	require.Equal(t, JobFailed, status.Status)
+
+	resp = doRequest(t, http.MethodGet, ts.URL+"/api/v1/jobs", "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var jobs []Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jobs))
+	require.Len(t, jobs, 2)
+	require.Equal(t, "create", jobs[0].Operation)
+	require.Equal(t, "deploy", jobs[1].Operation)
+	require.Equal(t, "token", jobs[0].User)
+}
+
+func TestRemoveAndInspect(t *testing.T) {
+	t.Parallel()
+
+	_, ts := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/remove", "secret", `{}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/inspect", "secret", `{}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/remove", "secret", `{"source":"test"}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var job Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+	require.Equal(t, "remove", job.Operation)
+	events, status := streamEvents(t, ts.URL+"/api/v1/jobs/"+job.ID+"/events")
+	require.Equal(t, JobSucceeded, status.Status)
+	require.Equal(t, "removing package", events[1].Message)
+
+	resp = doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/inspect", "secret", `{"source":"test"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var pkg v1alpha1.ZarfPackage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pkg))
+	require.Equal(t, "test", pkg.Metadata.Name)
+}
+
+func TestRequestPaths(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(s.root, "link")))
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "relative path", path: "create", body: `{"path":"examples/dos-games"}`, expectedStatus: http.StatusAccepted},
+		{name: "path outside of root", path: "create", body: `{"path":"../examples/dos-games"}`, expectedStatus: http.StatusBadRequest},
+		{name: "absolute path outside of root", path: "create", body: `{"path":"/etc"}`, expectedStatus: http.StatusBadRequest},
+		{name: "symlink outside of root", path: "create", body: `{"path":"link/dos-games"}`, expectedStatus: http.StatusBadRequest},
+		{name: "signing key outside of root", path: "create", body: `{"path":".","signingKeyPath":"/etc/cosign.key"}`, expectedStatus: http.StatusBadRequest},
+		{name: "output outside of root", path: "create", body: `{"path":".","output":"/tmp"}`, expectedStatus: http.StatusBadRequest},
+		{name: "OCI output", path: "create", body: `{"path":".","output":"oci://ghcr.io/zarf-dev/packages"}`, expectedStatus: http.StatusAccepted},
+		{name: "OCI source", path: "deploy", body: `{"source":"oci://ghcr.io/zarf-dev/packages/dos-games:1.0.0"}`, expectedStatus: http.StatusAccepted},
+		{name: "source outside of root", path: "deploy", body: `{"source":"../zarf-package-dos-games-amd64.tar.zst"}`, expectedStatus: http.StatusBadRequest},
+		{name: "deployed package", path: "remove", body: `{"source":"dos-games"}`, expectedStatus: http.StatusAccepted},
+		{name: "inspected source outside of root", path: "inspect", body: `{"source":"/tmp/zarf-package-dos-games-amd64.tar.zst"}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/"+tt.path, "secret", tt.body)
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestSkipSignatureValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, skip := range []bool{false, true} {
+		auth, err := NewTokenAuthorizer("secret")
+		require.NoError(t, err)
+		s, err := New(testutil.TestContext(t), auth, Options{Root: t.TempDir(), SkipSignatureValidation: skip})
+		require.NoError(t, err)
+		var inspected InspectRequest
+		s.inspect = func(_ context.Context, req InspectRequest) (v1alpha1.ZarfPackage, error) {
+			inspected = req
+			return v1alpha1.ZarfPackage{}, nil
+		}
+		ts := httptest.NewServer(s.Handler())
+		t.Cleanup(ts.Close)
+
+		// Requests can not skip signature validation, only the server can.
+		resp := doRequest(t, http.MethodPost, ts.URL+"/api/v1/packages/inspect", "secret", `{"source":"test","skipSignatureValidation":true}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, skip, inspected.SkipSignatureValidation)
+	}
+}
@@ -30,6 +30,8 @@ const (
 	DefaultTimeout = 30 * time.Second
 	// AgentLabel is used to give instructions to the Zarf agent
 	AgentLabel = "zarf.dev/agent"
+	// SecretSyncLabel is used to opt namespaces in or out of the Zarf secrets kept by the Zarf agent
+	SecretSyncLabel = "zarf.dev/secret-sync"
 	// FieldManagerName is the field manager used during server side apply
 	FieldManagerName = "zarf"
 )
@@ -39,17 +41,25 @@ type Cluster struct {
 	Clientset  kubernetes.Interface
 	RestConfig *rest.Config
 	Watcher    watcher.StatusWatcher
+	// KubeContext is the kube context the cluster was connected with, empty for the current context.
+	KubeContext string
 }
 
 // NewClusterWithWait creates a new Cluster instance and waits for the given timeout for the cluster to be ready.
 func NewClusterWithWait(ctx context.Context) (*Cluster, error) {
+	return NewClusterForContextWithWait(ctx, "")
+}
+
+// NewClusterForContextWithWait creates a new Cluster instance for the kube context and waits for the given timeout for
+// the cluster to be ready. The current context is used when the kube context is empty.
+func NewClusterForContextWithWait(ctx context.Context, kubeContext string) (*Cluster, error) {
 	start := time.Now()
 	l := logger.From(ctx)
 	spinner := message.NewProgressSpinner("Waiting for cluster connection")
 	defer spinner.Stop()
-	l.Info("waiting for cluster connection")
+	l.Info("waiting for cluster connection", "context", kubeContext)
 
-	c, err := NewCluster()
+	c, err := NewClusterForContext(kubeContext)
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +89,24 @@ func NewClusterWithWait(ctx context.Context) (*Cluster, error) {
 	spinner.Success()
 	l.Debug("done waiting for cluster, connected", "duration", time.Since(start))
 
+	c.CheckCredentialExpiry(ctx)
+
 	return c, nil
 }
 
 // NewCluster creates a new Cluster instance and validates connection to the cluster by fetching the Kubernetes version.
 func NewCluster() (*Cluster, error) {
+	return NewClusterForContext("")
+}
+
+// NewClusterForContext creates a new Cluster instance for the kube context and validates connection to the cluster by
+// fetching the Kubernetes version. The current context is used when the kube context is empty.
+func NewClusterForContext(kubeContext string) (*Cluster, error) {
 	clusterErr := errors.New("unable to connect to the cluster")
-	clientset, config, err := ClientAndConfig()
+	if kubeContext != "" {
+		clusterErr = fmt.Errorf("unable to connect to the cluster of kube context %s", kubeContext)
+	}
+	clientset, config, err := ClientAndConfigForContext(kubeContext)
 	if err != nil {
 		return nil, errors.Join(clusterErr, err)
 	}
@@ -94,9 +115,10 @@ func NewCluster() (*Cluster, error) {
 		return nil, errors.Join(clusterErr, err)
 	}
 	c := &Cluster{
-		Clientset:  clientset,
-		RestConfig: config,
-		Watcher:    watcher,
+		Clientset:   clientset,
+		RestConfig:  config,
+		Watcher:     watcher,
+		KubeContext: kubeContext,
 	}
 	// Dogsled the version output. We just want to ensure no errors were returned to validate cluster connection.
 	_, err = c.Clientset.Discovery().ServerVersion()
@@ -106,10 +128,26 @@ func NewCluster() (*Cluster, error) {
 	return c, nil
 }
 
+// TargetKubeContext returns the kube context of a component cluster alias, which is the context the alias is mapped to
+// or the alias itself when it is not mapped.
+func TargetKubeContext(alias string, contexts map[string]string) string {
+	if kubeContext, ok := contexts[alias]; ok {
+		return kubeContext
+	}
+	return alias
+}
+
 // ClientAndConfig returns a Kubernetes client and the rest config used to configure the client.
 func ClientAndConfig() (kubernetes.Interface, *rest.Config, error) {
+	return ClientAndConfigForContext("")
+}
+
+// ClientAndConfigForContext returns a Kubernetes client and the rest config for the kube context, the current context is
+// used when the kube context is empty.
+func ClientAndConfigForContext(kubeContext string) (kubernetes.Interface, *rest.Config, error) {
 	loader := clientcmd.NewDefaultClientConfigLoadingRules()
-	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, nil)
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
 	cfg, err := clientCfg.ClientConfig()
 	if err != nil {
 		return nil, nil, err
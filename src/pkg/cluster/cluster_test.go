@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetKubeContext(t *testing.T) {
+	t.Parallel()
+
+	contexts := map[string]string{"management": "kind-mgmt"}
+	require.Equal(t, "kind-mgmt", TargetKubeContext("management", contexts))
+	require.Equal(t, "workload", TargetKubeContext("workload", contexts))
+	require.Equal(t, "", TargetKubeContext("", nil))
+}
+
+func TestClientAndConfigForContext(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: management
+  cluster:
+    server: https://management.example.com
+- name: workload
+  cluster:
+    server: https://workload.example.com
+contexts:
+- name: management
+  context:
+    cluster: management
+    user: user
+- name: workload
+  context:
+    cluster: workload
+    user: user
+current-context: management
+users:
+- name: user
+  user:
+    token: token
+`
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+	t.Setenv("KUBECONFIG", path)
+
+	_, cfg, err := ClientAndConfigForContext("")
+	require.NoError(t, err)
+	require.Equal(t, "https://management.example.com", cfg.Host)
+
+	_, cfg, err = ClientAndConfigForContext("workload")
+	require.NoError(t, err)
+	require.Equal(t, "https://workload.example.com", cfg.Host)
+
+	_, _, err = ClientAndConfigForContext("missing")
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// credentialServices are the services with Zarf managed credentials in the order they are reported.
+var credentialServices = []string{message.RegistryKey, message.GitKey, message.ArtifactKey, message.AgentKey}
+
+// ExpiringCredential is a set of Zarf managed credentials that is expired or close to expiring.
+type ExpiringCredential struct {
+	Service   string
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the credential has expired at the given time.
+func (e ExpiringCredential) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// SetCredentialTimestamps records the credentials of the given services as created at now.
+// The agent expiry is read from its certificate, all other credentials expire after the default credential lifetime.
+func SetCredentialTimestamps(state *types.ZarfState, services []string, now time.Time) error {
+	if state.CredentialTimestamps == nil {
+		state.CredentialTimestamps = map[string]types.CredentialTimestamps{}
+	}
+	for _, service := range services {
+		expiresAt := now.Add(types.ZarfCredentialLifetime)
+		if service == message.AgentKey {
+			notAfter, err := certificateExpiry(state.AgentTLS.Cert)
+			if err != nil {
+				return fmt.Errorf("unable to read the agent certificate expiry: %w", err)
+			}
+			expiresAt = notAfter
+		}
+		state.CredentialTimestamps[service] = types.CredentialTimestamps{
+			CreatedAt: now,
+			ExpiresAt: expiresAt,
+		}
+	}
+	return nil
+}
+
+// ExpiringCredentials returns the credentials in the state that are expired or expire within the warning window after now.
+// States created before timestamps were tracked only report the agent certificate which carries its own expiry.
+func ExpiringCredentials(state *types.ZarfState, now time.Time) []ExpiringCredential {
+	expiring := []ExpiringCredential{}
+	for _, service := range credentialServices {
+		timestamps, ok := state.CredentialTimestamps[service]
+		if !ok && service == message.AgentKey {
+			notAfter, err := certificateExpiry(state.AgentTLS.Cert)
+			if err != nil {
+				continue
+			}
+			timestamps.ExpiresAt = notAfter
+			ok = true
+		}
+		if !ok || timestamps.ExpiresAt.IsZero() {
+			continue
+		}
+		if now.Add(types.ZarfCredentialExpiryWarning).Before(timestamps.ExpiresAt) {
+			continue
+		}
+		expiring = append(expiring, ExpiringCredential{Service: service, ExpiresAt: timestamps.ExpiresAt})
+	}
+	return expiring
+}
+
+// CheckCredentialExpiry warns about Zarf managed credentials that are expired or near expiry. Clusters without a Zarf
+// state are skipped.
+func (c *Cluster) CheckCredentialExpiry(ctx context.Context) {
+	l := logger.From(ctx)
+	expiring := c.expiringCredentials(ctx)
+	if len(expiring) == 0 {
+		return
+	}
+	now := time.Now()
+	for _, cred := range expiring {
+		expiresAt := cred.ExpiresAt.Format(time.DateOnly)
+		if cred.Expired(now) {
+			message.Warnf("The Zarf %s credentials expired on %s", cred.Service, expiresAt)
+			l.Warn("Zarf managed credentials have expired", "service", cred.Service, "expiresAt", expiresAt)
+			continue
+		}
+		message.Warnf("The Zarf %s credentials expire on %s", cred.Service, expiresAt)
+		l.Warn("Zarf managed credentials are near expiry", "service", cred.Service, "expiresAt", expiresAt)
+	}
+	rotateCmd := rotateCredentialsCommand(expiring)
+	message.Notef("To rotate the credentials run `%s`", rotateCmd)
+	l.Info("to rotate the credentials run `" + rotateCmd + "`")
+}
+
+// ValidateCredentialExpiry returns an error when Zarf managed credentials are expired or near expiry. It is used by
+// deploys that must not run with credentials that are about to stop working, while commands that rotate the
+// credentials only warn through CheckCredentialExpiry. Clusters without a Zarf state are skipped.
+func (c *Cluster) ValidateCredentialExpiry(ctx context.Context) error {
+	expiring := c.expiringCredentials(ctx)
+	if len(expiring) == 0 {
+		return nil
+	}
+	services := []string{}
+	for _, cred := range expiring {
+		services = append(services, cred.Service)
+	}
+	return fmt.Errorf("credentials for %s are expired or near expiry, rotate them with `%s`", strings.Join(services, ", "), rotateCredentialsCommand(expiring))
+}
+
+// expiringCredentials returns the credentials in the Zarf state of the cluster that are expired or near expiry.
+func (c *Cluster) expiringCredentials(ctx context.Context) []ExpiringCredential {
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			logger.From(ctx).Debug("unable to load the Zarf state to check credential expiry", "error", err)
+		}
+		return nil
+	}
+	return ExpiringCredentials(state, time.Now())
+}
+
+// rotateCredentialsCommand returns the command that rotates the expiring credentials.
+func rotateCredentialsCommand(expiring []ExpiringCredential) string {
+	if len(expiring) > 1 {
+		return "zarf tools update-creds"
+	}
+	return fmt.Sprintf("zarf tools update-creds %s", expiring[0].Service)
+}
+
+// certificateExpiry returns the expiry of the first certificate in the PEM data.
+func certificateExpiry(certPEM []byte) (time.Time, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, errors.New("no PEM data found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestExpiringCredentials(t *testing.T) {
+	t.Parallel()
+
+	agentTLS, err := pki.GeneratePKI("example.com")
+	require.NoError(t, err)
+	agentExpiry, err := certificateExpiry(agentTLS.Cert)
+	require.NoError(t, err)
+
+	created := time.Now()
+	state := &types.ZarfState{AgentTLS: agentTLS}
+	err = SetCredentialTimestamps(state, credentialServices, created)
+	require.NoError(t, err)
+	require.Equal(t, created.Add(types.ZarfCredentialLifetime), state.CredentialTimestamps[message.RegistryKey].ExpiresAt)
+	require.Equal(t, agentExpiry, state.CredentialTimestamps[message.AgentKey].ExpiresAt)
+
+	require.Empty(t, ExpiringCredentials(state, created))
+
+	nearExpiry := created.Add(types.ZarfCredentialLifetime - types.ZarfCredentialExpiryWarning + 24*time.Hour)
+	expiring := ExpiringCredentials(state, nearExpiry)
+	services := []string{}
+	for _, cred := range expiring {
+		require.False(t, cred.Expired(nearExpiry))
+		services = append(services, cred.Service)
+	}
+	require.Equal(t, []string{message.RegistryKey, message.GitKey, message.ArtifactKey}, services)
+
+	// Rotating a single service only resets its timestamps.
+	err = SetCredentialTimestamps(state, []string{message.GitKey}, nearExpiry)
+	require.NoError(t, err)
+	expiring = ExpiringCredentials(state, nearExpiry)
+	require.Len(t, expiring, 2)
+
+	expiredAt := created.Add(types.ZarfCredentialLifetime)
+	expired := ExpiringCredentials(state, expiredAt)
+	require.Len(t, expired, 3)
+	for _, cred := range expired {
+		require.Equal(t, cred.Service != message.AgentKey, cred.Expired(expiredAt))
+	}
+
+	// States created before timestamps were tracked still report the agent certificate.
+	legacy := &types.ZarfState{AgentTLS: agentTLS}
+	expiring = ExpiringCredentials(legacy, agentExpiry)
+	require.Equal(t, []ExpiringCredential{{Service: message.AgentKey, ExpiresAt: agentExpiry}}, expiring)
+}
+
+func TestValidateCredentialExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	// No state yet so there is nothing to check.
+	err := c.ValidateCredentialExpiry(ctx)
+	require.NoError(t, err)
+
+	state := &types.ZarfState{
+		CredentialTimestamps: map[string]types.CredentialTimestamps{
+			message.RegistryKey: {ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+	b, err := json.Marshal(state)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespaceName},
+		Data:       map[string][]byte{ZarfStateDataKey: b},
+	}
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = c.ValidateCredentialExpiry(ctx)
+	require.EqualError(t, err, "credentials for registry are expired or near expiry, rotate them with `zarf tools update-creds registry`")
+}
@@ -5,9 +5,14 @@
 package cluster
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -18,6 +23,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/defenseunicorns/pkg/helpers/v2"
@@ -27,12 +34,9 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
-	"github.com/zarf-dev/zarf/src/pkg/utils"
-	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
 )
 
 // HandleDataInjection waits for the target pod(s) to come up and inject the data into them
-// todo:  this currently requires kubectl but we should have enough k8s work to make this native now.
 func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDataInjection, componentPath *layout.ComponentPaths, dataIdx int) error {
 	l := logger.From(ctx)
 	injectionCompletionMarker := filepath.Join(componentPath.DataInjections, config.GetDataInjectionMarker())
@@ -40,11 +44,6 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 		return fmt.Errorf("unable to create the data injection completion marker: %w", err)
 	}
 
-	tarCompressFlag := ""
-	if data.Compress {
-		tarCompressFlag = "-z"
-	}
-
 	// Pod filter to ensure we only use the current deployment's pods
 	podFilterByInitContainer := func(pod corev1.Pod) bool {
 		b, err := json.Marshal(pod)
@@ -55,13 +54,6 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 		return strings.Contains(string(b), config.GetDataInjectionMarker())
 	}
 
-	// Get the OS shell to execute commands in
-	shell, shellArgs := exec.GetOSShell(v1alpha1.Shell{Windows: "cmd"})
-
-	if _, _, err := exec.Cmd(shell, append(shellArgs, "tar --version")...); err != nil {
-		return fmt.Errorf("unable to execute tar, ensure it is installed in the $PATH: %w", err)
-	}
-
 	message.Debugf("Attempting to inject data into %s", data.Target)
 	l.Debug("performing data injection", "target", data.Target)
 
@@ -89,49 +81,27 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 
 	// Inject into all the pods
 	for _, pod := range pods {
-		// Try to use the embedded kubectl if we can
-		zarfCommand, err := utils.GetFinalExecutableCommand()
-		kubectlBinPath := "kubectl"
-		if err != nil {
-			message.Warnf("Unable to get the zarf executable path, falling back to host kubectl: %s", err)
-			l.Warn("unable to get the zarf executable path, falling back to host kubectl", "error", err)
-		} else {
-			kubectlBinPath = fmt.Sprintf("%s tools kubectl", zarfCommand)
-		}
-		kubectlCmd := fmt.Sprintf("%s exec -i -n %s %s -c %s ", kubectlBinPath, data.Target.Namespace, pod.Name, data.Target.Container)
-
-		// Note that each command flag is separated to provide the widest cross-platform tar support
-		tarCmd := fmt.Sprintf("tar -c %s -f -", tarCompressFlag)
-		untarCmd := fmt.Sprintf("tar -x %s -v -f - -C %s", tarCompressFlag, data.Target.Path)
-
 		// Must create the target directory before trying to change to it for untar
-		mkdirCmd := fmt.Sprintf("%s -- mkdir -p %s", kubectlCmd, data.Target.Path)
-		if err := exec.CmdWithPrint(shell, append(shellArgs, mkdirCmd)...); err != nil {
+		mkdirCmd := []string{"mkdir", "-p", data.Target.Path}
+		if err := c.execInPod(ctx, pod, data.Target.Container, mkdirCmd, nil); err != nil {
 			return fmt.Errorf("unable to create the data injection target directory %s in pod %s: %w", data.Target.Path, pod.Name, err)
 		}
 
-		cpPodCmd := fmt.Sprintf("%s -C %s . | %s -- %s",
-			tarCmd,
-			source,
-			kubectlCmd,
-			untarCmd,
-		)
-
 		// Do the actual data injection
-		if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
+		count := 0
+		progress := func(name string) {
+			count++
+			message.Debugf("Injecting %s into pod %s", name, pod.Name)
+			l.Debug("injecting file", "name", name, "pod", pod.Name)
+		}
+		if err := c.injectTar(ctx, pod, data, source, ".", progress); err != nil {
 			return fmt.Errorf("could not copy data into the pod %s: %w", pod.Name, err)
 		}
+		message.Debugf("Injected %d files into pod %s", count, pod.Name)
+		l.Debug("injected data into pod", "files", count, "pod", pod.Name, "path", data.Target.Path)
 
 		// Leave a marker in the target container for pods to track the sync action
-		cpPodCmd = fmt.Sprintf("%s -C %s %s | %s -- %s",
-			tarCmd,
-			componentPath.DataInjections,
-			config.GetDataInjectionMarker(),
-			kubectlCmd,
-			untarCmd,
-		)
-
-		if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
+		if err := c.injectTar(ctx, pod, data, componentPath.DataInjections, config.GetDataInjectionMarker(), nil); err != nil {
 			return fmt.Errorf("could not save the Zarf sync completion file after injection into pod %s: %w", pod.Name, err)
 		}
 	}
@@ -160,6 +130,128 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 	return nil
 }
 
+// injectTar streams the path within the root directory as a tarball into the target container of the data injection
+// and extracts it in the target path. progress is called for each file that is streamed, it may be nil.
+func (c *Cluster) injectTar(ctx context.Context, pod corev1.Pod, data v1alpha1.ZarfDataInjection, root, path string, progress func(name string)) error {
+	// Note that each command flag is separated to provide the widest cross-platform tar support
+	untarCmd := []string{"tar", "-x"}
+	if data.Compress {
+		untarCmd = append(untarCmd, "-z")
+	}
+	untarCmd = append(untarCmd, "-f", "-", "-C", data.Target.Path)
+
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := writeTar(ctx, pw, root, path, data.Compress, progress)
+		pw.CloseWithError(err)
+		errCh <- err
+	}()
+	err := c.execInPod(ctx, pod, data.Target.Container, untarCmd, pr)
+	// Unblock the tar writer if the command exited before reading all of it.
+	pr.CloseWithError(io.ErrClosedPipe)
+	if writeErr := <-errCh; writeErr != nil && !errors.Is(writeErr, io.ErrClosedPipe) {
+		return writeErr
+	}
+	return err
+}
+
+// execInPod runs the command in the container of the pod with the given stdin, which may be nil.
+func (c *Cluster) execInPod(ctx context.Context, pod corev1.Pod, container string, cmd []string, stdin io.Reader) error {
+	req := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   cmd,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(c.RestConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("unable to create the pod executor: %w", err)
+	}
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if stdout.Len() > 0 {
+		message.Debug(stdout.String())
+		logger.From(ctx).Debug("pod exec output", "pod", pod.Name, "output", stdout.String())
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// writeTar writes the path within the root directory to w as a tarball with names relative to the root directory,
+// compressed with gzip when compress is true. progress is called for each file that is written, it may be nil.
+func writeTar(ctx context.Context, w io.Writer, root, path string, compress bool, progress func(name string)) (err error) {
+	if compress {
+		gw := gzip.NewWriter(w)
+		defer func() {
+			err = errors.Join(err, gw.Close())
+		}()
+		w = gw
+	}
+	tw := tar.NewWriter(w)
+	defer func() {
+		err = errors.Join(err, tw.Close())
+	}()
+	return filepath.Walk(filepath.Join(root, path), func(fpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, fpath)
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(fpath)
+			if err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if progress != nil {
+			progress(hdr.Name)
+		}
+		f, err := os.Open(fpath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
 // podLookup is a struct for specifying a pod to target for data injection or lookups.
 type podLookup struct {
 	Namespace string
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTar(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "data", "nested"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "data", "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "data", "nested", "b.txt"), []byte("b"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".marker"), []byte("🦄"), 0o600))
+
+	tests := []struct {
+		name     string
+		root     string
+		path     string
+		compress bool
+		expected map[string]string
+	}{
+		{
+			name:     "directory",
+			root:     filepath.Join(root, "data"),
+			path:     ".",
+			expected: map[string]string{"./": "", "a.txt": "a", "nested/": "", "nested/b.txt": "b"},
+		},
+		{
+			name:     "compressed directory",
+			root:     filepath.Join(root, "data"),
+			path:     ".",
+			compress: true,
+			expected: map[string]string{"./": "", "a.txt": "a", "nested/": "", "nested/b.txt": "b"},
+		},
+		{
+			name:     "file",
+			root:     root,
+			path:     ".marker",
+			expected: map[string]string{".marker": "🦄"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			progress := []string{}
+			err := writeTar(context.Background(), &buf, tt.root, tt.path, tt.compress, func(name string) {
+				progress = append(progress, name)
+			})
+			require.NoError(t, err)
+
+			var r io.Reader = &buf
+			if tt.compress {
+				gr, err := gzip.NewReader(&buf)
+				require.NoError(t, err)
+				r = gr
+			}
+			entries := map[string]string{}
+			files := []string{}
+			tr := tar.NewReader(r)
+			for {
+				hdr, err := tr.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				b, err := io.ReadAll(tr)
+				require.NoError(t, err)
+				entries[hdr.Name] = string(b)
+				if hdr.Typeflag == tar.TypeReg {
+					files = append(files, hdr.Name)
+				}
+			}
+			require.Equal(t, tt.expected, entries)
+			require.Equal(t, files, progress)
+		})
+	}
+}
+
+func TestWriteTarCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := writeTar(ctx, io.Discard, t.TempDir(), ".", false, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
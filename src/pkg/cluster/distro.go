@@ -5,9 +5,12 @@
 package cluster
 
 import (
+	"context"
 	"regexp"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // List of supported distros via distro detection.
@@ -99,3 +102,16 @@ func detectDistro(node corev1.Node, namespaces []corev1.Namespace) string {
 
 	return DistroIsUnknown
 }
+
+// isIPv6Only returns true if the cluster only assigns IPv6 addresses to services, which is detected from the IP families
+// of the kubernetes API service.
+func (c *Cluster) isIPv6Only(ctx context.Context) (bool, error) {
+	svc, err := c.Clientset.CoreV1().Services(metav1.NamespaceDefault).Get(ctx, "kubernetes", metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(svc.Spec.IPFamilies) == 1 && svc.Spec.IPFamilies[0] == corev1.IPv6Protocol, nil
+}
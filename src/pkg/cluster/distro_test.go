@@ -10,6 +10,9 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
 )
 
 func TestDetectDistro(t *testing.T) {
@@ -179,3 +182,49 @@ func TestDetectDistro(t *testing.T) {
 		})
 	}
 }
+
+func TestIsIPv6Only(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ipFamilies []corev1.IPFamily
+		expected   bool
+	}{
+		{
+			name:       "IPv4",
+			ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+			expected:   false,
+		},
+		{
+			name:       "dual-stack",
+			ipFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			expected:   false,
+		},
+		{
+			name:       "IPv6-only",
+			ipFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+			expected:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.TestContext(t)
+			c := &Cluster{Clientset: fake.NewClientset(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: metav1.NamespaceDefault},
+				Spec:       corev1.ServiceSpec{IPFamilies: tt.ipFamilies},
+			})}
+			ipv6Only, err := c.isIPv6Only(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ipv6Only)
+		})
+	}
+
+	ctx := testutil.TestContext(t)
+	c := &Cluster{Clientset: fake.NewClientset()}
+	ipv6Only, err := c.isIPv6Only(ctx)
+	require.NoError(t, err)
+	require.False(t, ipv6Only)
+}
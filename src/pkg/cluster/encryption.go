@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ZarfStateRecipientsDataKey is the key of the Zarf state secret that holds the age recipients the Zarf state and
+// deployed package secrets are encrypted to.
+const ZarfStateRecipientsDataKey = "recipients"
+
+// ZarfAgentStateSecretName is the name of the secret that holds the parts of an encrypted Zarf state the agent needs.
+const ZarfAgentStateSecretName = "zarf-agent-state"
+
+// errNoStateKey is returned when encrypted data is read without an age key.
+var errNoStateKey = errors.New("the data is encrypted, provide the age key with --state-key, SOPS_AGE_KEY_FILE or SOPS_AGE_KEY")
+
+// stateIdentities returns the age identities used to decrypt the Zarf state and deployed package secrets. Keys are
+// found the same way as sops finds them, so that a key already set up for sops is used without any extra flags.
+func stateIdentities() ([]age.Identity, error) {
+	keyPath := config.CommonOptions.StateKeyPath
+	if keyPath == "" {
+		if key := os.Getenv("SOPS_AGE_KEY"); key != "" {
+			return age.ParseIdentities(strings.NewReader(key))
+		}
+		keyPath = os.Getenv("SOPS_AGE_KEY_FILE")
+	}
+	if keyPath == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, nil
+		}
+		keyPath = filepath.Join(configDir, "sops", "age", "keys.txt")
+		if _, err := os.Stat(keyPath); err != nil {
+			return nil, nil
+		}
+	}
+	f, err := os.Open(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the age key: %w", err)
+	}
+	defer f.Close()
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the age key %s: %w", keyPath, err)
+	}
+	return identities, nil
+}
+
+// parseRecipients parses age X25519 recipients such as the ones printed by age-keygen.
+func parseRecipients(recipients []string) ([]age.Recipient, error) {
+	parsed := []age.Recipient{}
+	for _, recipient := range recipients {
+		r, err := age.ParseX25519Recipient(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid state recipient %q: %w", recipient, err)
+		}
+		parsed = append(parsed, r)
+	}
+	return parsed, nil
+}
+
+// checkStateKey returns an error unless the state key decrypts data encrypted to the recipients, so that the Zarf state
+// is not encrypted to recipients no one running the CLI can read it with.
+func checkStateKey(recipients []string) error {
+	data, err := encryptData([]byte("zarf"), recipients)
+	if err != nil {
+		return err
+	}
+	if _, err := decryptData(data); err != nil {
+		return fmt.Errorf("the state key must match one of the state recipients: %w", err)
+	}
+	return nil
+}
+
+// isEncrypted returns true if the data is armored age ciphertext.
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte(armor.Header))
+}
+
+// encryptData encrypts the data to the recipients as armored age ciphertext, the data is returned as is when there
+// are no recipients.
+func encryptData(data []byte, recipients []string) ([]byte, error) {
+	if len(recipients) == 0 {
+		return data, nil
+	}
+	parsed, err := parseRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	aw := armor.NewWriter(buf)
+	w, err := age.Encrypt(aw, parsed...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decryptData decrypts armored age ciphertext with the state key, data that is not encrypted is returned as is.
+func decryptData(data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return data, nil
+	}
+	identities, err := stateIdentities()
+	if err != nil {
+		return nil, err
+	}
+	if len(identities) == 0 {
+		return nil, errNoStateKey
+	}
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(bytes.TrimSpace(data))), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the data with the age key: %w", err)
+	}
+	return io.ReadAll(r)
+}
+
+// stateRecipients returns the age recipients stored in the Zarf state secret, which are empty when the Zarf state is
+// not encrypted.
+func (c *Cluster) stateRecipients(ctx context.Context) ([]string, error) {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(secret.Data[ZarfStateRecipientsDataKey])), nil
+}
+
+// encryptPackageData encrypts deployed package data to the recipients of the Zarf state.
+func (c *Cluster) encryptPackageData(ctx context.Context, data []byte) ([]byte, error) {
+	recipients, err := c.stateRecipients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return encryptData(data, recipients)
+}
+
+// agentState returns the parts of the state the agent needs to mutate resources, which are the addresses, the usernames
+// and the pull credentials of the services. Push credentials and the agent TLS key are left out.
+func agentState(state *types.ZarfState) *types.ZarfState {
+	return &types.ZarfState{
+		ZarfAppliance: state.ZarfAppliance,
+		Distro:        state.Distro,
+		Architecture:  state.Architecture,
+		StorageClass:  state.StorageClass,
+		GitServer: types.GitServerInfo{
+			Address:      state.GitServer.Address,
+			PushUsername: state.GitServer.PushUsername,
+			PullUsername: state.GitServer.PullUsername,
+			PullPassword: state.GitServer.PullPassword,
+		},
+		RegistryInfo: types.RegistryInfo{
+			Address:      state.RegistryInfo.Address,
+			NodePort:     state.RegistryInfo.NodePort,
+			PushUsername: state.RegistryInfo.PushUsername,
+			PullUsername: state.RegistryInfo.PullUsername,
+			PullPassword: state.RegistryInfo.PullPassword,
+		},
+		ArtifactServer: types.ArtifactServerInfo{
+			Address:      state.ArtifactServer.Address,
+			PushUsername: state.ArtifactServer.PushUsername,
+		},
+	}
+}
+
+// saveAgentState stores the agent view of an encrypted state, which the agent reads instead of the state so that the
+// age key never has to be given to the cluster. The secret is removed when the state is not encrypted.
+func (c *Cluster) saveAgentState(ctx context.Context, state *types.ZarfState, encrypted bool) error {
+	if !encrypted {
+		err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Delete(ctx, ZarfAgentStateSecretName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete the zarf agent state secret: %w", err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(agentState(state))
+	if err != nil {
+		return err
+	}
+	secret := v1ac.Secret(ZarfAgentStateSecretName, ZarfNamespaceName).
+		WithLabels(CommonLabels(map[string]string{
+			ZarfManagedByLabel: "zarf",
+		})).
+		WithAnnotations(CommonAnnotations(nil)).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(map[string][]byte{ZarfStateDataKey: data})
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to apply the zarf agent state secret: %w", err)
+	}
+	return nil
+}
+
+// LoadAgentState returns the state the agent works with. It is the agent view of the state when the state is
+// encrypted, and the state itself otherwise.
+func (c *Cluster) LoadAgentState(ctx context.Context) (*types.ZarfState, error) {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfAgentStateSecretName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return c.LoadZarfState(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the zarf agent state secret: %w", err)
+	}
+	state := &types.ZarfState{}
+	if err := json.Unmarshal(secret.Data[ZarfStateDataKey], state); err != nil {
+		return nil, fmt.Errorf("unable to parse the zarf agent state: %w", err)
+	}
+	return state, nil
+}
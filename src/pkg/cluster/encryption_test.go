@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestEncryptedState(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	t.Setenv("SOPS_AGE_KEY", identity.String())
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+	state := &types.ZarfState{Distro: DistroIsK3s, RegistryInfo: types.RegistryInfo{PushPassword: "hunter2", PullPassword: "pull"}}
+	require.NoError(t, c.saveZarfState(ctx, state, []string{identity.Recipient().String()}))
+
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, isEncrypted(secret.Data[ZarfStateDataKey]))
+	require.NotContains(t, string(secret.Data[ZarfStateDataKey]), "hunter2")
+	require.Equal(t, identity.Recipient().String(), string(secret.Data[ZarfStateRecipientsDataKey]))
+
+	loaded, err := c.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", loaded.RegistryInfo.PushPassword)
+
+	// The agent reads the pull credentials without the key but never the push credentials.
+	secret, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfAgentStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, string(secret.Data[ZarfStateDataKey]), "hunter2")
+	agent, err := c.LoadAgentState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, DistroIsK3s, agent.Distro)
+	require.Equal(t, "pull", agent.RegistryInfo.PullPassword)
+	require.Empty(t, agent.RegistryInfo.PushPassword)
+
+	// Saving keeps the state encrypted to the same recipients.
+	loaded.RegistryInfo.PushPassword = "hunter3"
+	require.NoError(t, c.SaveZarfState(ctx, loaded))
+	secret, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, isEncrypted(secret.Data[ZarfStateDataKey]))
+
+	// Deployed packages are encrypted to the recipients of the state.
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"}}
+	_, err = c.RecordPackageDeployment(ctx, pkg, nil, nil)
+	require.NoError(t, err)
+	secret, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, "zarf-package-test", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, isEncrypted(secret.Data["data"]))
+	depPkg, err := c.GetDeployedPackage(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", depPkg.Data.Metadata.Version)
+
+	// Without the key the state cannot be read.
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	_, err = c.LoadZarfState(ctx)
+	require.ErrorIs(t, err, errNoStateKey)
+	_, err = c.GetDeployedZarfPackages(ctx)
+	require.ErrorIs(t, err, errNoStateKey)
+
+	_, err = c.LoadAgentState(ctx)
+	require.NoError(t, err)
+	require.ErrorIs(t, checkStateKey([]string{identity.Recipient().String()}), errNoStateKey)
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	t.Setenv("SOPS_AGE_KEY", other.String())
+	_, err = c.LoadZarfState(ctx)
+	require.ErrorContains(t, err, "unable to decrypt the data with the age key")
+	require.ErrorContains(t, checkStateKey([]string{identity.Recipient().String()}), "the state key must match one of the state recipients")
+	require.NoError(t, checkStateKey([]string{identity.Recipient().String(), other.Recipient().String()}))
+
+	// The agent view is removed once the state is no longer encrypted.
+	require.NoError(t, c.saveZarfState(ctx, state, nil))
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfAgentStateSecretName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+	agent, err = c.LoadAgentState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", agent.RegistryInfo.PushPassword)
+}
+
+func TestEncryptData(t *testing.T) {
+	t.Parallel()
+
+	data, err := encryptData([]byte("plain"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("plain"), data)
+
+	_, err = encryptData([]byte("plain"), []string{"not-a-recipient"})
+	require.ErrorContains(t, err, `invalid state recipient "not-a-recipient"`)
+}
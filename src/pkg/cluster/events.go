@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// PackageOperation is a lifecycle operation on a package that events are recorded for.
+type PackageOperation string
+
+// The lifecycle operations on a package.
+const (
+	PackageDeploy  PackageOperation = "Deploy"
+	PackageUpgrade PackageOperation = "Upgrade"
+	PackageRemove  PackageOperation = "Remove"
+)
+
+// PackageEventPhase is the phase of a package operation that an event is recorded for.
+type PackageEventPhase string
+
+// The phases of a package operation.
+const (
+	PackageEventStarted   PackageEventPhase = "Started"
+	PackageEventSucceeded PackageEventPhase = "Succeeded"
+	PackageEventFailed    PackageEventPhase = "Failed"
+)
+
+// eventSourceComponent is the component that Zarf events are reported from.
+const eventSourceComponent = "zarf"
+
+// RecordPackageEvent records an event for an operation on a package in the Zarf namespace, where it refers to the
+// secret of the deployed package, and in each of the namespaces the package deploys to, where it refers to the namespace.
+// The error of a failed operation is included in the message of the event.
+// Recording events is best effort, events that cannot be created are logged instead of failing the operation.
+func (c *Cluster) RecordPackageEvent(ctx context.Context, pkg v1alpha1.ZarfPackage, op PackageOperation, phase PackageEventPhase, namespaces []string, opErr error) {
+	l := logger.From(ctx)
+	eventType := corev1.EventTypeNormal
+	if phase == PackageEventFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	reason := fmt.Sprintf("Package%s%s", op, phase)
+	msg := fmt.Sprintf("%s of package %s", op, pkg.Metadata.Name)
+	if pkg.Metadata.Version != "" {
+		msg = fmt.Sprintf("%s version %s", msg, pkg.Metadata.Version)
+	}
+	switch phase {
+	case PackageEventStarted:
+		msg = fmt.Sprintf("%s started", msg)
+	case PackageEventSucceeded:
+		msg = fmt.Sprintf("%s succeeded", msg)
+	case PackageEventFailed:
+		msg = fmt.Sprintf("%s failed: %v", msg, opErr)
+	}
+
+	objects := []corev1.ObjectReference{
+		{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  ZarfNamespaceName,
+			Name:       config.ZarfPackagePrefix + pkg.Metadata.Name,
+		},
+	}
+	for _, namespace := range namespaces {
+		if namespace == "" || namespace == ZarfNamespaceName {
+			continue
+		}
+		// Events must be in the namespace of the object they refer to, so the namespace refers to itself.
+		objects = append(objects, corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Namespace:  namespace,
+			Name:       namespace,
+		})
+	}
+	now := metav1.NewTime(time.Now())
+	for _, obj := range objects {
+		event := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				// Events are named like the events of the Kubernetes event recorder.
+				Name:      fmt.Sprintf("%s.%x", obj.Name, now.UnixNano()),
+				Namespace: obj.Namespace,
+				Labels: map[string]string{
+					ZarfManagedByLabel:   "zarf",
+					ZarfPackageInfoLabel: pkg.Metadata.Name,
+				},
+			},
+			InvolvedObject:      obj,
+			Reason:              reason,
+			Message:             msg,
+			Type:                eventType,
+			Source:              corev1.EventSource{Component: eventSourceComponent},
+			ReportingController: eventSourceComponent,
+			FirstTimestamp:      now,
+			LastTimestamp:       now,
+			Count:               1,
+		}
+		_, err := c.Clientset.CoreV1().Events(obj.Namespace).Create(ctx, event, metav1.CreateOptions{})
+		if err != nil {
+			l.Debug("unable to record package event", "namespace", obj.Namespace, "reason", reason, "error", err)
+		}
+	}
+}
+
+// PackageNamespaces returns the namespaces the charts and manifests of the components deploy to.
+func PackageNamespaces(components []v1alpha1.ZarfComponent) []string {
+	namespaces := []string{}
+	for _, component := range components {
+		for _, chart := range component.Charts {
+			namespaces = append(namespaces, chart.Namespace)
+		}
+		for _, manifest := range component.Manifests {
+			namespaces = append(namespaces, manifest.Namespace)
+		}
+	}
+	namespaces = slices.DeleteFunc(namespaces, func(namespace string) bool {
+		return namespace == ""
+	})
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
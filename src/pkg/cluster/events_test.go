@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestRecordPackageEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	c := &Cluster{Clientset: fake.NewClientset()}
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "podinfo", Version: "1.0.0"}}
+
+	c.RecordPackageEvent(ctx, pkg, PackageUpgrade, PackageEventStarted, []string{"podinfo", ZarfNamespaceName}, nil)
+	c.RecordPackageEvent(ctx, pkg, PackageUpgrade, PackageEventFailed, []string{"podinfo"}, errors.New("timed out"))
+
+	events, err := c.Clientset.CoreV1().Events(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 2)
+	for _, event := range events.Items {
+		require.Equal(t, corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: ZarfNamespaceName, Name: "zarf-package-podinfo"}, event.InvolvedObject)
+		require.Equal(t, "podinfo", event.Labels[ZarfPackageInfoLabel])
+		require.Equal(t, "zarf", event.Source.Component)
+	}
+
+	events, err = c.Clientset.CoreV1().Events("podinfo").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 2)
+	reasons := map[string]corev1.Event{}
+	for _, event := range events.Items {
+		require.Equal(t, corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Namespace: "podinfo", Name: "podinfo"}, event.InvolvedObject)
+		reasons[event.Reason] = event
+	}
+	started := reasons["PackageUpgradeStarted"]
+	require.Equal(t, corev1.EventTypeNormal, started.Type)
+	require.Equal(t, "Upgrade of package podinfo version 1.0.0 started", started.Message)
+	failed := reasons["PackageUpgradeFailed"]
+	require.Equal(t, corev1.EventTypeWarning, failed.Type)
+	require.Equal(t, "Upgrade of package podinfo version 1.0.0 failed: timed out", failed.Message)
+}
+
+func TestPackageNamespaces(t *testing.T) {
+	t.Parallel()
+
+	components := []v1alpha1.ZarfComponent{
+		{
+			Name:   "charts",
+			Charts: []v1alpha1.ZarfChart{{Name: "podinfo", Namespace: "podinfo"}, {Name: "default"}},
+		},
+		{
+			Name:      "manifests",
+			Manifests: []v1alpha1.ZarfManifest{{Name: "httpd", Namespace: "httpd"}, {Name: "podinfo", Namespace: "podinfo"}},
+		},
+	}
+	require.Equal(t, []string{"httpd", "podinfo"}, PackageNamespaces(components))
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PackageExportsConfigMapName is the name of the configmap holding the variables exported by deployed packages.
+const PackageExportsConfigMapName = "zarf-package-exports"
+
+// packageExportKey returns the key of an exported variable within the package exports configmap.
+func packageExportKey(packageName, name string) string {
+	return fmt.Sprintf("%s.%s", packageName, name)
+}
+
+// GetPackageExports returns the variables exported by deployed packages keyed by "<package>.<VARIABLE>".
+func (c *Cluster) GetPackageExports(ctx context.Context) (map[string]string, error) {
+	cm, err := c.Clientset.CoreV1().ConfigMaps(ZarfNamespaceName).Get(ctx, PackageExportsConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cm.Data == nil {
+		return map[string]string{}, nil
+	}
+	return cm.Data, nil
+}
+
+// GetPackageExport returns the value of a variable exported by a deployed package.
+func (c *Cluster) GetPackageExport(ctx context.Context, packageName, name string) (string, bool, error) {
+	exports, err := c.GetPackageExports(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	value, ok := exports[packageExportKey(packageName, name)]
+	return value, ok, nil
+}
+
+// RecordPackageExports replaces the variables exported by a package with the given values.
+func (c *Cluster) RecordPackageExports(ctx context.Context, packageName string, values map[string]string) error {
+	return c.updatePackageExports(ctx, packageName, values)
+}
+
+// DeletePackageExports removes the variables exported by a package.
+func (c *Cluster) DeletePackageExports(ctx context.Context, packageName string) error {
+	return c.updatePackageExports(ctx, packageName, nil)
+}
+
+func (c *Cluster) updatePackageExports(ctx context.Context, packageName string, values map[string]string) error {
+	cm, err := c.Clientset.CoreV1().ConfigMaps(ZarfNamespaceName).Get(ctx, PackageExportsConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		if len(values) == 0 {
+			return nil
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      PackageExportsConfigMapName,
+				Namespace: ZarfNamespaceName,
+				Labels:    map[string]string{ZarfManagedByLabel: "zarf"},
+			},
+		}
+		AddCommonMetadata(cm)
+		cm.Data = exportData(cm.Data, packageName, values)
+		_, err = c.Clientset.CoreV1().ConfigMaps(ZarfNamespaceName).Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to record the exports of package %s: %w", packageName, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cm.Data = exportData(cm.Data, packageName, values)
+	_, err = c.Clientset.CoreV1().ConfigMaps(ZarfNamespaceName).Update(ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to record the exports of package %s: %w", packageName, err)
+	}
+	return nil
+}
+
+// exportData returns the data with the keys of the package replaced by the given values.
+func exportData(data map[string]string, packageName string, values map[string]string) map[string]string {
+	updated := map[string]string{}
+	for k, v := range data {
+		if !strings.HasPrefix(k, packageName+".") {
+			updated[k] = v
+		}
+	}
+	for name, value := range values {
+		updated[packageExportKey(packageName, name)] = value
+	}
+	return updated
+}
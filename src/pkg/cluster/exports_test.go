@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPackageExports(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	exports, err := c.GetPackageExports(ctx)
+	require.NoError(t, err)
+	require.Empty(t, exports)
+
+	err = c.RecordPackageExports(ctx, "database", map[string]string{"HOST": "db.svc", "PORT": "5432"})
+	require.NoError(t, err)
+	err = c.RecordPackageExports(ctx, "database-proxy", map[string]string{"HOST": "proxy.svc"})
+	require.NoError(t, err)
+
+	// Recording the exports again replaces the previous exports of the package only.
+	err = c.RecordPackageExports(ctx, "database", map[string]string{"HOST": "db.other.svc"})
+	require.NoError(t, err)
+	exports, err = c.GetPackageExports(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"database.HOST": "db.other.svc", "database-proxy.HOST": "proxy.svc"}, exports)
+
+	value, ok, err := c.GetPackageExport(ctx, "database", "HOST")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "db.other.svc", value)
+	_, ok, err = c.GetPackageExport(ctx, "database", "PORT")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	err = c.DeletePackageExports(ctx, "database")
+	require.NoError(t, err)
+	exports, err = c.GetPackageExports(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"database-proxy.HOST": "proxy.svc"}, exports)
+}
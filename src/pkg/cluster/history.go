@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// packageHistorySecretName returns the name of the secret holding a superseded package version record.
+// History records use their own prefix so they never collide with the secret of a deployed package.
+func packageHistorySecretName(packageName string, generation int) string {
+	return fmt.Sprintf("%s%s-%d", config.ZarfPackageHistoryPrefix, packageName, generation)
+}
+
+// recordPackageHistory saves a superseded deployed package record so that it can be inspected or pruned later.
+// History records are labeled separately from deployed packages so they are not listed as deployed.
+func (c *Cluster) recordPackageHistory(ctx context.Context, depPkg types.DeployedPackage) error {
+	data, err := json.Marshal(depPkg)
+	if err != nil {
+		return err
+	}
+	data, err = c.encryptPackageData(ctx, data)
+	if err != nil {
+		return err
+	}
+	secret := v1ac.Secret(packageHistorySecretName(depPkg.Name, depPkg.Generation), ZarfNamespaceName).
+		WithLabels(CommonLabels(map[string]string{
+			ZarfManagedByLabel:      "zarf",
+			ZarfPackageHistoryLabel: depPkg.Name,
+		})).WithAnnotations(CommonAnnotations(nil)).WithType(corev1.SecretTypeOpaque).
+		WithData(map[string][]byte{
+			"data": data,
+		})
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to record the history of package %s: %w", depPkg.Name, err)
+	}
+	return nil
+}
+
+// GetDeployedPackageHistory returns the superseded version records of a deployed package, newest first.
+func (c *Cluster) GetDeployedPackageHistory(ctx context.Context, packageName string) ([]types.DeployedPackage, error) {
+	selector := labels.Set{ZarfPackageHistoryLabel: packageName}.String()
+	secrets, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	history := []types.DeployedPackage{}
+	for _, secret := range secrets.Items {
+		data, err := decryptData(secret.Data["data"])
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		var depPkg types.DeployedPackage
+		if err := json.Unmarshal(data, &depPkg); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		history = append(history, depPkg)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Generation > history[j].Generation
+	})
+	return history, nil
+}
+
+// DeleteDeployedPackageHistory removes a single superseded version record of a deployed package.
+func (c *Cluster) DeleteDeployedPackageHistory(ctx context.Context, packageName string, generation int) error {
+	return c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Delete(ctx, packageHistorySecretName(packageName, generation), metav1.DeleteOptions{})
+}
+
+// PruneHelmReleaseHistory removes all but the newest keep revisions of a Helm release.
+// The currently deployed revision is never removed. Returns the names of the removed release secrets.
+func (c *Cluster) PruneHelmReleaseHistory(ctx context.Context, namespace, releaseName string, keep int) ([]string, error) {
+	selector := labels.Set{"owner": "helm", "name": releaseName}.String()
+	secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	releases := secrets.Items
+	revision := func(secret corev1.Secret) int {
+		// Secrets with an unparsable version are treated as the oldest revision
+		v, err := strconv.Atoi(secret.Labels["version"])
+		if err != nil {
+			return 0
+		}
+		return v
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return revision(releases[i]) > revision(releases[j])
+	})
+	if len(releases) <= keep {
+		return nil, nil
+	}
+	removed := []string{}
+	for _, secret := range releases[keep:] {
+		if secret.Labels["status"] == "deployed" {
+			continue
+		}
+		if err := c.Clientset.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil {
+			return removed, err
+		}
+		removed = append(removed, secret.Name)
+	}
+	return removed, nil
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestPackageHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	for _, version := range []string{"1.0.0", "1.0.0", "1.1.0", "1.2.0"} {
+		pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: version}}
+		_, err := c.RecordPackageDeployment(ctx, pkg, nil, nil)
+		require.NoError(t, err)
+	}
+
+	current, err := c.GetDeployedPackage(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", current.Data.Metadata.Version)
+	require.Equal(t, 2, current.Generation)
+
+	// History records are not listed as deployed packages.
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	require.NoError(t, err)
+	require.Len(t, deployedPackages, 1)
+
+	history, err := c.GetDeployedPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "1.1.0", history[0].Data.Metadata.Version)
+	require.Equal(t, 1, history[0].Generation)
+	require.Equal(t, "1.0.0", history[1].Data.Metadata.Version)
+	require.Equal(t, 0, history[1].Generation)
+
+	// A package named like a history record does not overwrite or list the history of another package.
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "history-test-1", Version: "9.9.9"}}
+	_, err = c.RecordPackageDeployment(ctx, pkg, nil, nil)
+	require.NoError(t, err)
+	history, err = c.GetDeployedPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "1.1.0", history[0].Data.Metadata.Version)
+	err = c.DeleteDeployedPackage(ctx, "history-test-1")
+	require.NoError(t, err)
+
+	err = c.DeleteDeployedPackageHistory(ctx, "test", 0)
+	require.NoError(t, err)
+	history, err = c.GetDeployedPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	err = c.DeleteDeployedPackage(ctx, "test")
+	require.NoError(t, err)
+	history, err = c.GetDeployedPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func TestPruneHelmReleaseHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	for i := 1; i <= 5; i++ {
+		status := "superseded"
+		if i == 5 {
+			status = "deployed"
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sh.helm.release.v1.podinfo.v" + strconv.Itoa(i),
+				Namespace: "podinfo",
+				Labels: map[string]string{
+					"owner":   "helm",
+					"name":    "podinfo",
+					"version": strconv.Itoa(i),
+					"status":  status,
+				},
+			},
+		}
+		_, err := c.Clientset.CoreV1().Secrets("podinfo").Create(ctx, secret, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	removed, err := c.PruneHelmReleaseHistory(ctx, "podinfo", "podinfo", 2)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"sh.helm.release.v1.podinfo.v1", "sh.helm.release.v1.podinfo.v2", "sh.helm.release.v1.podinfo.v3"}, removed)
+
+	secrets, err := c.Clientset.CoreV1().Secrets("podinfo").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, secrets.Items, 2)
+
+	removed, err = c.PruneHelmReleaseHistory(ctx, "podinfo", "podinfo", 2)
+	require.NoError(t, err)
+	require.Empty(t, removed)
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/mholt/archiver/v3"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,11 +32,15 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
 	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
+// maxInjectorPayloadChunks is the number of payload chunks that can be named zarf-payload-%03d.
+const maxInjectorPayloadChunks = 1000
+
 // StartInjection initializes a Zarf injection into the cluster.
-func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string, injectorSeedSrcs []string) error {
+func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string, injectorSeedSrcs []string, opts types.InjectorOptions) error {
 	l := logger.From(ctx)
 	start := time.Now()
 	// Stop any previous running injection before starting.
@@ -48,30 +53,45 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 	defer spinner.Stop()
 	l.Info("creating Zarf injector resources")
 
-	resReq := v1ac.ResourceRequirements().
-		WithRequests(corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse(".5"),
-			corev1.ResourceMemory: resource.MustParse("64Mi"),
-		}).
-		WithLimits(corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse("1"),
-			corev1.ResourceMemory: resource.MustParse("256Mi"),
-		})
-	injectorImage, injectorNodeName, err := c.getInjectorImageAndNode(ctx, resReq)
+	resReq, err := injectorResources(opts)
+	if err != nil {
+		return err
+	}
+	if opts.SeedImagePath != "" && len(injectorSeedSrcs) != 1 {
+		return fmt.Errorf("the seed image can only be overridden for a single seed image, the init package has %d", len(injectorSeedSrcs))
+	}
+	injectorBinaryPath := filepath.Join(tmpDir, "zarf-injector")
+	if opts.BinaryPath != "" {
+		if opts.BinaryShasum == "" {
+			return fmt.Errorf("the injector binary override %s requires a shasum to verify it against", opts.BinaryPath)
+		}
+		if err := helpers.SHAsMatch(opts.BinaryPath, opts.BinaryShasum); err != nil {
+			return fmt.Errorf("unable to verify the injector binary override %s: %w", opts.BinaryPath, err)
+		}
+		l.Info("using injector binary override", "path", opts.BinaryPath)
+		injectorBinaryPath = opts.BinaryPath
+	}
+	injectorImage, injectorNodeName, err := c.getInjectorImageAndNode(ctx, resReq, opts.Image)
 	if err != nil {
 		return err
 	}
 
-	payloadCmNames, shasum, err := c.createPayloadConfigMaps(ctx, spinner, tmpDir, imagesDir, injectorSeedSrcs)
+	payloadChunkSize, err := injectorPayloadChunkSize(opts.PayloadChunkSize)
+	if err != nil {
+		return err
+	}
+	payloadCmNames, shasum, err := c.createPayloadConfigMaps(ctx, spinner, tmpDir, imagesDir, injectorSeedSrcs, opts.SeedImagePath, payloadChunkSize)
 	if err != nil {
 		return fmt.Errorf("unable to generate the injector payload configmaps: %w", err)
 	}
 
-	b, err := os.ReadFile(filepath.Join(tmpDir, "zarf-injector"))
+	b, err := os.ReadFile(injectorBinaryPath)
 	if err != nil {
 		return err
 	}
 	cm := v1ac.ConfigMap("rust-binary", ZarfNamespaceName).
+		WithLabels(CommonLabels(nil)).
+		WithAnnotations(CommonAnnotations(nil)).
 		WithBinaryData(map[string][]byte{
 			"zarf-injector": b,
 		})
@@ -81,8 +101,11 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 	}
 
 	svcAc := v1ac.Service("zarf-injector", ZarfNamespaceName).
+		WithLabels(CommonLabels(nil)).
+		WithAnnotations(CommonAnnotations(nil)).
 		WithSpec(v1ac.ServiceSpec().
 			WithType(corev1.ServiceTypeNodePort).
+			WithIPFamilyPolicy(corev1.IPFamilyPolicyPreferDualStack).
 			WithPorts(
 				v1ac.ServicePort().WithPort(int32(5000)),
 			).WithSelector(map[string]string{
@@ -101,7 +124,11 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 		return fmt.Errorf("error creating pod in cluster: %w", err)
 	}
 
-	waitCtx, waitCancel := context.WithTimeout(ctx, 60*time.Second)
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = types.DefaultInjectorTimeout
+	}
+	waitCtx, waitCancel := context.WithTimeout(ctx, timeout)
 	defer waitCancel()
 	podRef := v1alpha1.NamespacedObjectKindReference{
 		APIVersion: *pod.APIVersion,
@@ -111,6 +138,10 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 	}
 	err = healthchecks.Run(waitCtx, c.Watcher, []v1alpha1.NamespacedObjectKindReference{podRef})
 	if err != nil {
+		err = fmt.Errorf("injector pod did not become ready within %s: %w", timeout, err)
+		if diagnostics := c.injectorDiagnostics(ctx, *pod.Namespace, *pod.Name); diagnostics != "" {
+			return fmt.Errorf("%w\n%s", err, diagnostics)
+		}
 		return err
 	}
 
@@ -183,7 +214,7 @@ func (c *Cluster) StopInjection(ctx context.Context) error {
 	return nil
 }
 
-func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.Spinner, tmpDir, imagesDir string, injectorSeedSrcs []string) ([]string, string, error) {
+func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.Spinner, tmpDir, imagesDir string, injectorSeedSrcs []string, seedImagePath string, payloadChunkSize int) ([]string, string, error) {
 	l := logger.From(ctx)
 	tarPath := filepath.Join(tmpDir, "payload.tar.gz")
 	seedImagesDir := filepath.Join(tmpDir, "seed-images")
@@ -197,7 +228,7 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to create ref for image %s: %w", src, err)
 		}
-		img, err := utils.LoadOCIImage(imagesDir, ref)
+		img, err := loadSeedImage(imagesDir, ref, seedImagePath)
 		if err != nil {
 			return nil, "", err
 		}
@@ -214,7 +245,6 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 		return nil, "", fmt.Errorf("unable to format OCI layout: %w", err)
 	}
 
-	// Chunk size has to accommodate base64 encoding & etcd 1MB limit
 	tarFileList, err := filepath.Glob(filepath.Join(seedImagesDir, "*"))
 	if err != nil {
 		return nil, "", err
@@ -222,11 +252,13 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 	if err := archiver.Archive(tarFileList, tarPath); err != nil {
 		return nil, "", err
 	}
-	payloadChunkSize := 1024 * 768
 	chunks, shasum, err := helpers.ReadFileByChunks(tarPath, payloadChunkSize)
 	if err != nil {
 		return nil, "", err
 	}
+	if err := checkInjectorPayloadChunks(len(chunks), payloadChunkSize); err != nil {
+		return nil, "", err
+	}
 
 	cmNames := []string{}
 	l.Info("adding archived binary configmaps of registry image to the cluster")
@@ -236,9 +268,10 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 		spinner.Updatef("Adding archive binary configmap %d of %d to the cluster", i+1, len(chunks))
 
 		cm := v1ac.ConfigMap(fileName, ZarfNamespaceName).
-			WithLabels(map[string]string{
+			WithLabels(CommonLabels(map[string]string{
 				"zarf-injector": "payload",
-			}).
+			})).
+			WithAnnotations(CommonAnnotations(nil)).
 			WithBinaryData(map[string][]byte{
 				fileName: data,
 			})
@@ -254,8 +287,22 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 	return cmNames, shasum, nil
 }
 
+// loadSeedImage loads the seed image from the init package, or from the image tarball at seedImagePath if one is given.
+// The override is served under the reference of the seed image so the seed registry is deployed from it unchanged.
+func loadSeedImage(imagesDir string, ref transform.Image, seedImagePath string) (v1.Image, error) {
+	if seedImagePath == "" {
+		return utils.LoadOCIImage(imagesDir, ref)
+	}
+	img, err := crane.Load(seedImagePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the seed image override %s: %w", seedImagePath, err)
+	}
+	return img, nil
+}
+
 // getImagesAndNodesForInjection checks for images on schedulable nodes within a cluster.
-func (c *Cluster) getInjectorImageAndNode(ctx context.Context, resReq *v1ac.ResourceRequirementsApplyConfiguration) (string, string, error) {
+// If an image override is given only nodes that are running that image are considered.
+func (c *Cluster) getInjectorImageAndNode(ctx context.Context, resReq *v1ac.ResourceRequirementsApplyConfiguration, imageOverride string) (string, string, error) {
 	// Regex for Zarf seed image
 	zarfImageRegex, err := regexp.Compile(`(?m)^127\.0\.0\.1:`)
 	if err != nil {
@@ -281,27 +328,161 @@ func (c *Cluster) getInjectorImageAndNode(ctx context.Context, resReq *v1ac.Reso
 			continue
 		}
 		for _, container := range pod.Spec.Containers {
-			if zarfImageRegex.MatchString(container.Image) {
+			if !isInjectorImageCandidate(zarfImageRegex, container.Image, imageOverride) {
 				continue
 			}
 			return container.Image, pod.Spec.NodeName, nil
 		}
 		for _, container := range pod.Spec.InitContainers {
-			if zarfImageRegex.MatchString(container.Image) {
+			if !isInjectorImageCandidate(zarfImageRegex, container.Image, imageOverride) {
 				continue
 			}
 			return container.Image, pod.Spec.NodeName, nil
 		}
 		for _, container := range pod.Spec.EphemeralContainers {
-			if zarfImageRegex.MatchString(container.Image) {
+			if !isInjectorImageCandidate(zarfImageRegex, container.Image, imageOverride) {
 				continue
 			}
 			return container.Image, pod.Spec.NodeName, nil
 		}
 	}
+	if imageOverride != "" {
+		return "", "", fmt.Errorf("no schedulable node is running the injector image %s", imageOverride)
+	}
 	return "", "", fmt.Errorf("no suitable injector image or node exists")
 }
 
+func isInjectorImageCandidate(zarfImageRegex *regexp.Regexp, image, imageOverride string) bool {
+	if imageOverride != "" {
+		return image == imageOverride
+	}
+	return !zarfImageRegex.MatchString(image)
+}
+
+// injectorResources builds the injector pod resource requirements, falling back to the defaults for unset values.
+func injectorResources(opts types.InjectorOptions) (*v1ac.ResourceRequirementsApplyConfiguration, error) {
+	parse := func(name, value, fallback string) (resource.Quantity, error) {
+		if value == "" {
+			value = fallback
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf("invalid injector %s %q: %w", name, value, err)
+		}
+		return q, nil
+	}
+	cpuReq, err := parse("cpu request", opts.CPURequest, types.DefaultInjectorCPURequest)
+	if err != nil {
+		return nil, err
+	}
+	memReq, err := parse("memory request", opts.MemoryRequest, types.DefaultInjectorMemoryRequest)
+	if err != nil {
+		return nil, err
+	}
+	cpuLim, err := parse("cpu limit", opts.CPULimit, types.DefaultInjectorCPULimit)
+	if err != nil {
+		return nil, err
+	}
+	memLim, err := parse("memory limit", opts.MemoryLimit, types.DefaultInjectorMemoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	if cpuReq.Cmp(cpuLim) > 0 {
+		return nil, fmt.Errorf("injector cpu request %s must not exceed the cpu limit %s", cpuReq.String(), cpuLim.String())
+	}
+	if memReq.Cmp(memLim) > 0 {
+		return nil, fmt.Errorf("injector memory request %s must not exceed the memory limit %s", memReq.String(), memLim.String())
+	}
+	resReq := v1ac.ResourceRequirements().
+		WithRequests(corev1.ResourceList{
+			corev1.ResourceCPU:    cpuReq,
+			corev1.ResourceMemory: memReq,
+		}).
+		WithLimits(corev1.ResourceList{
+			corev1.ResourceCPU:    cpuLim,
+			corev1.ResourceMemory: memLim,
+		})
+	return resReq, nil
+}
+
+// injectorPayloadChunkSize returns the size in bytes of the payload chunks, falling back to the default for an unset value.
+// Chunk size has to accommodate base64 encoding & etcd 1MB limit so it can not exceed the default.
+func injectorPayloadChunkSize(value string) (int, error) {
+	if value == "" {
+		value = types.DefaultInjectorPayloadChunkSize
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid injector payload chunk size %q: %w", value, err)
+	}
+	minSize := resource.MustParse(types.MinInjectorPayloadChunkSize)
+	maxSize := resource.MustParse(types.DefaultInjectorPayloadChunkSize)
+	if q.Cmp(minSize) < 0 || q.Cmp(maxSize) > 0 {
+		return 0, fmt.Errorf("injector payload chunk size %s must be between %s and %s", q.String(), minSize.String(), maxSize.String())
+	}
+	return int(q.Value()), nil
+}
+
+// checkInjectorPayloadChunks returns an error if the payload is split into more chunks than the injector can put back
+// together. The injector sorts the chunks by name, so their three digit index must not overflow.
+func checkInjectorPayloadChunks(count, chunkSize int) error {
+	if count >= maxInjectorPayloadChunks {
+		return fmt.Errorf("the seed image is split into %d payload chunks of %d bytes, the injector supports less than %d, use a larger injector payload chunk size", count, chunkSize, maxInjectorPayloadChunks)
+	}
+	return nil
+}
+
+// injectorDiagnostics collects the status, events and logs of the injector pod to explain why it did not become ready.
+// Failing to collect any of them is not an error as the diagnostics only add context to the readiness error.
+func (c *Cluster) injectorDiagnostics(ctx context.Context, namespace, name string) string {
+	lines := []string{}
+	pod, err := c.Clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	lines = append(lines, fmt.Sprintf("injector pod %s/%s is %s on node %q", namespace, name, pod.Status.Phase, pod.Spec.NodeName))
+	for _, cond := range pod.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  condition %s is %s: %s %s", cond.Type, cond.Status, cond.Reason, cond.Message))
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		switch {
+		case cs.State.Waiting != nil:
+			lines = append(lines, fmt.Sprintf("  container %s is waiting: %s %s", cs.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message))
+		case cs.State.Terminated != nil:
+			lines = append(lines, fmt.Sprintf("  container %s terminated with exit code %d: %s %s", cs.Name, cs.State.Terminated.ExitCode, cs.State.Terminated.Reason, cs.State.Terminated.Message))
+		}
+		if cs.LastTerminationState.Terminated != nil {
+			lines = append(lines, fmt.Sprintf("  container %s previously terminated with exit code %d: %s", cs.Name, cs.LastTerminationState.Terminated.ExitCode, cs.LastTerminationState.Terminated.Reason))
+		}
+	}
+
+	events, err := c.Clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", name),
+	})
+	if err == nil && len(events.Items) > 0 {
+		lines = append(lines, "events:")
+		for _, event := range events.Items {
+			lines = append(lines, fmt.Sprintf("  %s %s: %s", event.Type, event.Reason, event.Message))
+		}
+	}
+
+	tailLines := int64(20)
+	for _, container := range pod.Spec.Containers {
+		b, err := c.Clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{Container: container.Name, TailLines: &tailLines}).DoRaw(ctx)
+		if err != nil || len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("logs of container %s:", container.Name))
+		for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func hasBlockingTaints(taints []corev1.Taint) bool {
 	for _, taint := range taints {
 		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
@@ -352,10 +533,11 @@ func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum s
 	}
 
 	pod := v1ac.Pod("injector", ZarfNamespaceName).
-		WithLabels(map[string]string{
+		WithLabels(CommonLabels(map[string]string{
 			"app":      "zarf-injector",
 			AgentLabel: "ignore",
-		}).
+		})).
+		WithAnnotations(CommonAnnotations(nil)).
 		WithSpec(
 			v1ac.PodSpec().
 				WithNodeName(nodeName).
@@ -12,10 +12,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/internal/healthchecks"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -106,7 +110,7 @@ func TestInjector(t *testing.T) {
 		_, err = layout.Write(filepath.Join(tmpDir, "seed-images"), idx)
 		require.NoError(t, err)
 
-		err = c.StartInjection(ctx, tmpDir, t.TempDir(), nil)
+		err = c.StartInjection(ctx, tmpDir, t.TempDir(), nil, types.InjectorOptions{})
 		require.NoError(t, err)
 
 		podList, err := cs.CoreV1().Pods(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
@@ -149,6 +153,46 @@ func TestInjector(t *testing.T) {
 	require.Empty(t, cmList.Items)
 }
 
+func TestStartInjectionOverrides(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+	binaryPath := filepath.Join(t.TempDir(), "zarf-injector")
+	err := os.WriteFile(binaryPath, []byte("foobar"), 0o644)
+	require.NoError(t, err)
+
+	err = c.StartInjection(ctx, t.TempDir(), t.TempDir(), nil, types.InjectorOptions{BinaryPath: binaryPath})
+	require.EqualError(t, err, fmt.Sprintf("the injector binary override %s requires a shasum to verify it against", binaryPath))
+	err = c.StartInjection(ctx, t.TempDir(), t.TempDir(), nil, types.InjectorOptions{BinaryPath: binaryPath, BinaryShasum: "abc"})
+	require.ErrorContains(t, err, fmt.Sprintf("unable to verify the injector binary override %s", binaryPath))
+	err = c.StartInjection(ctx, t.TempDir(), t.TempDir(), []string{"registry:2", "registry:3"}, types.InjectorOptions{SeedImagePath: "seed.tar"})
+	require.EqualError(t, err, "the seed image can only be overridden for a single seed image, the init package has 2")
+}
+
+func TestLoadSeedImage(t *testing.T) {
+	t.Parallel()
+
+	img, err := random.Image(1, 1)
+	require.NoError(t, err)
+	seedImagePath := filepath.Join(t.TempDir(), "seed.tar")
+	err = tarball.WriteToFile(seedImagePath, name.MustParseReference("hardened/registry:2"), img)
+	require.NoError(t, err)
+	ref, err := transform.ParseImageRef("library/registry:2.8.3")
+	require.NoError(t, err)
+
+	seedImg, err := loadSeedImage(t.TempDir(), ref, seedImagePath)
+	require.NoError(t, err)
+	expected, err := img.Digest()
+	require.NoError(t, err)
+	actual, err := seedImg.Digest()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = loadSeedImage(t.TempDir(), ref, filepath.Join(t.TempDir(), "missing.tar"))
+	require.ErrorContains(t, err, "unable to load the seed image override")
+}
+
 func TestBuildInjectionPod(t *testing.T) {
 	t.Parallel()
 
@@ -172,6 +216,109 @@ func TestBuildInjectionPod(t *testing.T) {
 	require.Equal(t, strings.TrimSpace(string(expected)), string(b))
 }
 
+func TestInjectorResources(t *testing.T) {
+	t.Parallel()
+
+	resReq, err := injectorResources(types.InjectorOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "500m", resReq.Requests.Cpu().String())
+	require.Equal(t, "64Mi", resReq.Requests.Memory().String())
+	require.Equal(t, "1", resReq.Limits.Cpu().String())
+	require.Equal(t, "256Mi", resReq.Limits.Memory().String())
+
+	resReq, err = injectorResources(types.InjectorOptions{CPURequest: "100m", MemoryLimit: "1Gi"})
+	require.NoError(t, err)
+	require.Equal(t, "100m", resReq.Requests.Cpu().String())
+	require.Equal(t, "1Gi", resReq.Limits.Memory().String())
+
+	_, err = injectorResources(types.InjectorOptions{CPURequest: "lots"})
+	require.ErrorContains(t, err, "invalid injector cpu request \"lots\"")
+
+	_, err = injectorResources(types.InjectorOptions{MemoryRequest: "1Gi"})
+	require.EqualError(t, err, "injector memory request 1Gi must not exceed the memory limit 256Mi")
+}
+
+func TestInjectorPayloadChunkSize(t *testing.T) {
+	t.Parallel()
+
+	size, err := injectorPayloadChunkSize("")
+	require.NoError(t, err)
+	require.Equal(t, 768*1024, size)
+	size, err = injectorPayloadChunkSize("256Ki")
+	require.NoError(t, err)
+	require.Equal(t, 256*1024, size)
+	_, err = injectorPayloadChunkSize("big")
+	require.ErrorContains(t, err, "invalid injector payload chunk size \"big\"")
+	size, err = injectorPayloadChunkSize("64Ki")
+	require.NoError(t, err)
+	require.Equal(t, 64*1024, size)
+	_, err = injectorPayloadChunkSize("1Mi")
+	require.EqualError(t, err, "injector payload chunk size 1Mi must be between 64Ki and 768Ki")
+	_, err = injectorPayloadChunkSize("16Ki")
+	require.EqualError(t, err, "injector payload chunk size 16Ki must be between 64Ki and 768Ki")
+	_, err = injectorPayloadChunkSize("0")
+	require.EqualError(t, err, "injector payload chunk size 0 must be between 64Ki and 768Ki")
+}
+
+func TestCheckInjectorPayloadChunks(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkInjectorPayloadChunks(999, 64*1024))
+	require.EqualError(t, checkInjectorPayloadChunks(1000, 64*1024), "the seed image is split into 1000 payload chunks of 65536 bytes, the injector supports less than 1000, use a larger injector payload chunk size")
+}
+
+func TestInjectorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cs := fake.NewClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "injector", Namespace: ZarfNamespaceName},
+			Spec: corev1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []corev1.Container{{Name: "injector"}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+					{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady", Message: "containers with unready status: [injector]"},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:                 "injector",
+						State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off restarting failed container"}},
+						LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+					},
+				},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "injector.1", Namespace: ZarfNamespaceName},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "injector", Namespace: ZarfNamespaceName},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+		},
+	)
+	c := &Cluster{Clientset: cs}
+
+	diagnostics := c.injectorDiagnostics(ctx, ZarfNamespaceName, "injector")
+	expected := []string{
+		`injector pod zarf/injector is Pending on node "node-1"`,
+		"  condition Ready is False: ContainersNotReady containers with unready status: [injector]",
+		"  container injector is waiting: CrashLoopBackOff back-off restarting failed container",
+		"  container injector previously terminated with exit code 137: OOMKilled",
+		"events:",
+		"  Warning BackOff: Back-off restarting failed container",
+		"logs of container injector:",
+		"  fake logs",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), diagnostics)
+
+	require.Empty(t, c.injectorDiagnostics(ctx, ZarfNamespaceName, "missing"))
+}
+
 func TestGetInjectorImageAndNode(t *testing.T) {
 	t.Parallel()
 
@@ -286,8 +433,16 @@ func TestGetInjectorImageAndNode(t *testing.T) {
 				corev1.ResourceCPU:    resource.MustParse("1"),
 				corev1.ResourceMemory: resource.MustParse("256Mi"),
 			})
-	image, node, err := c.getInjectorImageAndNode(ctx, resReq)
+	image, node, err := c.getInjectorImageAndNode(ctx, resReq, "")
 	require.NoError(t, err)
 	require.Equal(t, "pod-2-container", image)
 	require.Equal(t, "good", node)
+
+	image, node, err = c.getInjectorImageAndNode(ctx, resReq, "pod-2-ephemeral")
+	require.NoError(t, err)
+	require.Equal(t, "pod-2-ephemeral", image)
+	require.Equal(t, "good", node)
+
+	_, _, err = c.getInjectorImageAndNode(ctx, resReq, "pod-1-container")
+	require.EqualError(t, err, "no schedulable node is running the injector image pod-1-container")
 }
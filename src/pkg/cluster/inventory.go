@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// PackageInventory describes a package that is deployed to the cluster.
+type PackageInventory struct {
+	Name        string
+	Version     string
+	Description string
+	// CLIVersion is the version of Zarf that last deployed the package
+	CLIVersion string
+	// Generation is incremented every time a different version of the package is deployed
+	Generation int
+	// FirstDeployedAt is when the package was first deployed to the cluster
+	FirstDeployedAt time.Time
+	// DeployedAt is when the package was last deployed, packages deployed by older versions of Zarf report FirstDeployedAt
+	DeployedAt time.Time
+	Components []ComponentInventory
+	// ConnectStrings are the connect strings of every chart in the package
+	ConnectStrings types.ConnectStrings
+}
+
+// ComponentInventory describes a deployed component of a package.
+type ComponentInventory struct {
+	Name   string
+	Charts []ChartInventory
+}
+
+// ChartInventory describes a Helm release installed by a component.
+type ChartInventory struct {
+	// ReleaseName is the name of the Helm release
+	ReleaseName string
+	Namespace   string
+	// Version is the version of the chart as defined in the package, it is empty when the chart is not found in the package
+	Version        string
+	ConnectStrings types.ConnectStrings
+}
+
+// GetDeployedPackages returns the inventory of every package deployed to the cluster sorted by name.
+// Use GetDeployedZarfPackages to get the full deployment records instead.
+func (c *Cluster) GetDeployedPackages(ctx context.Context) ([]PackageInventory, error) {
+	listOpts := metav1.ListOptions{LabelSelector: ZarfPackageInfoLabel}
+	secrets, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	errs := []error{}
+	inventory := []PackageInventory{}
+	for _, secret := range secrets.Items {
+		if !strings.HasPrefix(secret.Name, config.ZarfPackagePrefix) {
+			continue
+		}
+		pkgInventory, err := packageInventoryFromSecret(&secret)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to read the secret %s/%s: %w", secret.Namespace, secret.Name, err))
+			continue
+		}
+		inventory = append(inventory, pkgInventory)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(inventory, func(a, b PackageInventory) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return inventory, nil
+}
+
+// GetDeployedPackageInventory returns the inventory of the deployed package with the given name.
+// Use GetDeployedPackage to get the full deployment record instead.
+func (c *Cluster) GetDeployedPackageInventory(ctx context.Context, packageName string) (*PackageInventory, error) {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, config.ZarfPackagePrefix+packageName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pkgInventory, err := packageInventoryFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &pkgInventory, nil
+}
+
+func packageInventoryFromSecret(secret *corev1.Secret) (PackageInventory, error) {
+	data, err := decryptData(secret.Data["data"])
+	if err != nil {
+		return PackageInventory{}, err
+	}
+	var deployedPackage types.DeployedPackage
+	if err := json.Unmarshal(data, &deployedPackage); err != nil {
+		return PackageInventory{}, err
+	}
+	// The secret is created on the first deployment and removed with the package
+	firstDeployedAt := secret.CreationTimestamp.UTC()
+	return newPackageInventory(deployedPackage, firstDeployedAt), nil
+}
+
+func newPackageInventory(deployedPackage types.DeployedPackage, firstDeployedAt time.Time) PackageInventory {
+	deployedAt := firstDeployedAt
+	if deployedPackage.DeployedAt != nil {
+		deployedAt = *deployedPackage.DeployedAt
+	}
+	pkgInventory := PackageInventory{
+		Name:            deployedPackage.Name,
+		Version:         deployedPackage.Data.Metadata.Version,
+		Description:     deployedPackage.Data.Metadata.Description,
+		CLIVersion:      deployedPackage.CLIVersion,
+		Generation:      deployedPackage.Generation,
+		FirstDeployedAt: firstDeployedAt,
+		DeployedAt:      deployedAt,
+		Components:      []ComponentInventory{},
+		ConnectStrings:  deployedPackage.ConnectStrings,
+	}
+	// Chart versions are not recorded with the installed charts so they are looked up in the package definition
+	chartVersions := map[string]map[string]string{}
+	for _, component := range deployedPackage.Data.Components {
+		chartVersions[component.Name] = map[string]string{}
+		for _, chart := range component.Charts {
+			releaseName := chart.ReleaseName
+			if releaseName == "" {
+				releaseName = chart.Name
+			}
+			chartVersions[component.Name][releaseName] = chart.Version
+		}
+	}
+	for _, deployedComponent := range deployedPackage.DeployedComponents {
+		componentInventory := ComponentInventory{
+			Name:   deployedComponent.Name,
+			Charts: []ChartInventory{},
+		}
+		for _, installedChart := range deployedComponent.InstalledCharts {
+			componentInventory.Charts = append(componentInventory.Charts, ChartInventory{
+				ReleaseName:    installedChart.ChartName,
+				Namespace:      installedChart.Namespace,
+				Version:        chartVersions[deployedComponent.Name][installedChart.ChartName],
+				ConnectStrings: installedChart.ConnectStrings,
+			})
+		}
+		pkgInventory.Components = append(pkgInventory.Components, componentInventory)
+	}
+	return pkgInventory
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestGetDeployedPackages(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "podinfo", Version: "1.0.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name: "podinfo",
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "podinfo", Version: "6.4.0", ReleaseName: "my-podinfo"},
+				},
+			},
+		},
+	}
+	components := []types.DeployedComponent{
+		{
+			Name: "podinfo",
+			InstalledCharts: []types.InstalledChart{
+				{
+					Namespace:      "podinfo",
+					ChartName:      "my-podinfo",
+					ConnectStrings: types.ConnectStrings{"podinfo": {URL: "/"}},
+				},
+			},
+		},
+	}
+	_, err := c.RecordPackageDeployment(ctx, pkg, components, nil)
+	require.NoError(t, err)
+
+	// Packages deployed by older versions of Zarf do not record when they were deployed
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b, err := json.Marshal(types.DeployedPackage{Name: "init", Data: v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "init"}}})
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              config.ZarfPackagePrefix + "init",
+			Namespace:         ZarfNamespaceName,
+			Labels:            map[string]string{ZarfPackageInfoLabel: "init"},
+			CreationTimestamp: metav1.NewTime(createdAt),
+		},
+		Data: map[string][]byte{"data": b},
+	}
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	inventory, err := c.GetDeployedPackages(ctx)
+	require.NoError(t, err)
+	require.Len(t, inventory, 2)
+	require.Equal(t, "init", inventory[0].Name)
+	require.Equal(t, createdAt, inventory[0].FirstDeployedAt)
+	require.Equal(t, createdAt, inventory[0].DeployedAt)
+	require.Empty(t, inventory[0].Components)
+
+	podinfo := inventory[1]
+	require.Equal(t, "podinfo", podinfo.Name)
+	require.Equal(t, "1.0.0", podinfo.Version)
+	require.Equal(t, config.CLIVersion, podinfo.CLIVersion)
+	require.False(t, podinfo.DeployedAt.IsZero())
+	expectedComponents := []ComponentInventory{
+		{
+			Name: "podinfo",
+			Charts: []ChartInventory{
+				{
+					ReleaseName:    "my-podinfo",
+					Namespace:      "podinfo",
+					Version:        "6.4.0",
+					ConnectStrings: types.ConnectStrings{"podinfo": {URL: "/"}},
+				},
+			},
+		},
+	}
+	require.Equal(t, expectedComponents, podinfo.Components)
+	require.Equal(t, types.ConnectStrings{"podinfo": {URL: "/"}}, podinfo.ConnectStrings)
+
+	actual, err := c.GetDeployedPackageInventory(ctx, "podinfo")
+	require.NoError(t, err)
+	require.Equal(t, podinfo, *actual)
+
+	_, err = c.GetDeployedPackageInventory(ctx, "missing")
+	require.True(t, kerrors.IsNotFound(err))
+}
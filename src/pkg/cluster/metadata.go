@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"maps"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/zarf-dev/zarf/src/config"
+)
+
+// CommonLabels returns the given labels merged over the labels configured for every resource Zarf creates.
+func CommonLabels(labels map[string]string) map[string]string {
+	return mergeCommonMetadata(config.CommonOptions.Labels, labels)
+}
+
+// CommonAnnotations returns the given annotations merged over the annotations configured for every resource Zarf creates.
+func CommonAnnotations(annotations map[string]string) map[string]string {
+	return mergeCommonMetadata(config.CommonOptions.Annotations, annotations)
+}
+
+// AddCommonMetadata adds the labels and annotations configured for every resource Zarf creates to the object, keeping
+// the labels and annotations it already has.
+func AddCommonMetadata(obj metav1.Object) {
+	if len(config.CommonOptions.Labels) > 0 {
+		obj.SetLabels(CommonLabels(obj.GetLabels()))
+	}
+	if len(config.CommonOptions.Annotations) > 0 {
+		obj.SetAnnotations(CommonAnnotations(obj.GetAnnotations()))
+	}
+}
+
+// ValidateCommonMetadata validates the labels and annotations configured for every resource Zarf creates.
+func ValidateCommonMetadata(labels, annotations map[string]string) error {
+	errs := metav1validation.ValidateLabels(labels, field.NewPath("labels"))
+	errs = append(errs, apivalidation.ValidateAnnotations(annotations, field.NewPath("annotations"))...)
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
+
+func mergeCommonMetadata(common, m map[string]string) map[string]string {
+	merged := map[string]string{}
+	maps.Copy(merged, common)
+	maps.Copy(merged, m)
+	return merged
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+)
+
+func TestCommonMetadata(t *testing.T) {
+	labels, annotations := config.CommonOptions.Labels, config.CommonOptions.Annotations
+	t.Cleanup(func() {
+		config.CommonOptions.Labels, config.CommonOptions.Annotations = labels, annotations
+	})
+
+	config.CommonOptions.Labels = nil
+	config.CommonOptions.Annotations = nil
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	AddCommonMetadata(ns)
+	require.Nil(t, ns.Labels)
+	require.Nil(t, ns.Annotations)
+
+	config.CommonOptions.Labels = map[string]string{"team": "platform", ZarfManagedByLabel: "someone"}
+	config.CommonOptions.Annotations = map[string]string{"owner": "ops"}
+	require.Equal(t, map[string]string{"team": "platform", ZarfManagedByLabel: "zarf"}, CommonLabels(map[string]string{ZarfManagedByLabel: "zarf"}))
+	require.Equal(t, map[string]string{"owner": "ops"}, CommonAnnotations(nil))
+
+	ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"app": "test"}}}
+	AddCommonMetadata(ns)
+	require.Equal(t, map[string]string{"app": "test", "team": "platform", ZarfManagedByLabel: "someone"}, ns.Labels)
+	require.Equal(t, map[string]string{"owner": "ops"}, ns.Annotations)
+}
+
+func TestValidateCommonMetadata(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateCommonMetadata(map[string]string{"example.com/team": "platform"}, map[string]string{"owner": "any value"}))
+	require.Error(t, ValidateCommonMetadata(map[string]string{"team": "not a valid value"}, nil))
+	require.Error(t, ValidateCommonMetadata(nil, map[string]string{"not a valid key": "ops"}))
+}
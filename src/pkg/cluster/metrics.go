@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// packageMetric is a metric of deployed packages in the Prometheus text exposition format.
+type packageMetric struct {
+	name       string
+	help       string
+	metricType string
+	// value returns the value of the metric for a package, metrics without a value for a package are omitted.
+	value func(pkg types.DeployedPackage) (float64, bool)
+}
+
+var packageMetrics = []packageMetric{
+	{
+		name:       "zarf_package_info",
+		help:       "Information about a deployed Zarf package.",
+		metricType: "gauge",
+	},
+	{
+		name:       "zarf_package_generation",
+		help:       "Number of times a different version of the package was deployed.",
+		metricType: "gauge",
+		value: func(pkg types.DeployedPackage) (float64, bool) {
+			return float64(pkg.Generation), true
+		},
+	},
+	{
+		name:       "zarf_package_deployed_components",
+		help:       "Number of deployed components of the package.",
+		metricType: "gauge",
+		value: func(pkg types.DeployedPackage) (float64, bool) {
+			return float64(len(pkg.DeployedComponents)), true
+		},
+	},
+	{
+		name:       "zarf_package_last_success_timestamp_seconds",
+		help:       "Unix time the last deployment of the package succeeded.",
+		metricType: "gauge",
+		value: func(pkg types.DeployedPackage) (float64, bool) {
+			if pkg.SucceededAt == nil {
+				return 0, false
+			}
+			return float64(pkg.SucceededAt.Unix()), true
+		},
+	},
+	{
+		name:       "zarf_package_deploy_duration_seconds",
+		help:       "Duration of the last successful deployment of the package.",
+		metricType: "gauge",
+		value: func(pkg types.DeployedPackage) (float64, bool) {
+			if pkg.SucceededAt == nil {
+				return 0, false
+			}
+			return pkg.DeployDuration.Seconds(), true
+		},
+	},
+	{
+		name:       "zarf_package_deploy_failures_total",
+		help:       "Number of failed deployments of the package.",
+		metricType: "counter",
+		value: func(pkg types.DeployedPackage) (float64, bool) {
+			return float64(pkg.FailedDeployments), true
+		},
+	},
+}
+
+// labelValueReplacer escapes label values as required by the Prometheus text exposition format.
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WritePackageMetrics writes the deploy metrics of the packages in the Prometheus text exposition format.
+func WritePackageMetrics(w io.Writer, pkgs []types.DeployedPackage) error {
+	var b strings.Builder
+	for _, metric := range packageMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", metric.name, metric.metricType)
+		for _, pkg := range pkgs {
+			name := labelValueReplacer.Replace(pkg.Name)
+			if metric.value == nil {
+				version := labelValueReplacer.Replace(pkg.Data.Metadata.Version)
+				cliVersion := labelValueReplacer.Replace(pkg.CLIVersion)
+				fmt.Fprintf(&b, "%s{package=\"%s\",version=\"%s\",cli_version=\"%s\"} 1\n", metric.name, name, version, cliVersion)
+				continue
+			}
+			value, ok := metric.value(pkg)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "%s{package=\"%s\"} %g\n", metric.name, name, value)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
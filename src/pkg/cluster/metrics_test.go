@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRecordPackageDeployOutcome(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	// Packages that were never recorded have no outcome.
+	require.NoError(t, c.RecordPackageDeployOutcome(ctx, "test", time.Minute, errors.New("failed")))
+
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"}}
+	_, err := c.RecordPackageDeployment(ctx, pkg, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.RecordPackageDeployOutcome(ctx, "test", time.Minute, nil))
+	require.NoError(t, c.RecordPackageDeployOutcome(ctx, "test", 2*time.Minute, errors.New("failed")))
+
+	// Outcomes are kept when the package is recorded again.
+	pkg.Metadata.Version = "1.1.0"
+	_, err = c.RecordPackageDeployment(ctx, pkg, nil, nil)
+	require.NoError(t, err)
+
+	depPkg, err := c.GetDeployedPackage(ctx, "test")
+	require.NoError(t, err)
+	require.NotNil(t, depPkg.SucceededAt)
+	require.Equal(t, time.Minute, depPkg.DeployDuration)
+	require.Equal(t, 1, depPkg.FailedDeployments)
+}
+
+func TestWritePackageMetrics(t *testing.T) {
+	t.Parallel()
+
+	succeededAt := time.Unix(1700000000, 0)
+	pkgs := []types.DeployedPackage{
+		{
+			Name:               "podinfo",
+			CLIVersion:         "v0.40.0",
+			Data:               v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Version: "1.0.0"}},
+			DeployedComponents: []types.DeployedComponent{{Name: "podinfo"}, {Name: "ingress"}},
+			Generation:         1,
+			SucceededAt:        &succeededAt,
+			DeployDuration:     90 * time.Second,
+			FailedDeployments:  2,
+		},
+		{
+			Name: "legacy",
+			Data: v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Version: `1.0.0"beta`}},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WritePackageMetrics(&buf, pkgs))
+	expected := `# HELP zarf_package_info Information about a deployed Zarf package.
+# TYPE zarf_package_info gauge
+zarf_package_info{package="podinfo",version="1.0.0",cli_version="v0.40.0"} 1
+zarf_package_info{package="legacy",version="1.0.0\"beta",cli_version=""} 1
+# HELP zarf_package_generation Number of times a different version of the package was deployed.
+# TYPE zarf_package_generation gauge
+zarf_package_generation{package="podinfo"} 1
+zarf_package_generation{package="legacy"} 0
+# HELP zarf_package_deployed_components Number of deployed components of the package.
+# TYPE zarf_package_deployed_components gauge
+zarf_package_deployed_components{package="podinfo"} 2
+zarf_package_deployed_components{package="legacy"} 0
+# HELP zarf_package_last_success_timestamp_seconds Unix time the last deployment of the package succeeded.
+# TYPE zarf_package_last_success_timestamp_seconds gauge
+zarf_package_last_success_timestamp_seconds{package="podinfo"} 1.7e+09
+# HELP zarf_package_deploy_duration_seconds Duration of the last successful deployment of the package.
+# TYPE zarf_package_deploy_duration_seconds gauge
+zarf_package_deploy_duration_seconds{package="podinfo"} 90
+# HELP zarf_package_deploy_failures_total Number of failed deployments of the package.
+# TYPE zarf_package_deploy_failures_total counter
+zarf_package_deploy_failures_total{package="podinfo"} 2
+zarf_package_deploy_failures_total{package="legacy"} 0
+`
+	require.Equal(t, expected, buf.String())
+}
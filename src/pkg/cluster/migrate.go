@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// MigrateRegistry points the cluster at an external registry that already holds the images of the internal registry.
+// The registry is saved in the state, the image pull secrets of every Zarf managed namespace are updated, workloads that
+// reference images of deployed packages at the internal registry address are rewritten and the agent is restarted.
+// The internal registry is left running so that it can be removed once the migration is verified.
+func (c *Cluster) MigrateRegistry(ctx context.Context, oldState *types.ZarfState, registry types.RegistryInfo) (*types.ZarfState, []RemappedImage, error) {
+	l := logger.From(ctx)
+	if !oldState.RegistryInfo.IsInternal() {
+		return nil, nil, fmt.Errorf("the cluster already uses the external registry %s", oldState.RegistryInfo.Address)
+	}
+	if registry.Address == "" {
+		return nil, nil, errors.New("the address of the external registry is required")
+	}
+	if registry.PushUsername == "" || registry.PushPassword == "" {
+		return nil, nil, errors.New("the push credentials of the external registry are required")
+	}
+	if err := registry.FillInEmptyValues(); err != nil {
+		return nil, nil, err
+	}
+	if registry.IsInternal() {
+		return nil, nil, fmt.Errorf("%s is the address of the internal registry", registry.Address)
+	}
+
+	newState := *oldState
+	newState.RegistryInfo = registry
+	// Copy the timestamps so the old state is left untouched
+	newState.CredentialTimestamps = maps.Clone(oldState.CredentialTimestamps)
+	if err := SetCredentialTimestamps(&newState, []string{message.RegistryKey}, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	if err := c.SaveZarfState(ctx, &newState); err != nil {
+		return nil, nil, fmt.Errorf("failed to save the Zarf State to the cluster: %w", err)
+	}
+	l.Info("saved the external registry in the Zarf state", "address", registry.Address)
+
+	if err := c.UpdateZarfManagedImageSecrets(ctx, &newState); err != nil {
+		return nil, nil, err
+	}
+	if err := c.unscopeRegistrySecrets(ctx, &newState); err != nil {
+		return nil, nil, err
+	}
+	remapped, err := c.RemapImages(ctx, oldState.RegistryInfo.Address, registry.Address, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.restartAgent(ctx); err != nil {
+		return nil, nil, err
+	}
+	return &newState, remapped, nil
+}
+
+// unscopeRegistrySecrets replaces the image pull secrets that hold the scoped registry credentials of a package with
+// the shared pull credentials of the state and drops the registry credentials from the deployed package, as scoped
+// credentials are only supported by the internal registry.
+func (c *Cluster) unscopeRegistrySecrets(ctx context.Context, state *types.ZarfState) error {
+	l := logger.From(ctx)
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return err
+	}
+	for _, deployedPackage := range deployedPackages {
+		if deployedPackage.ScopedCredentials == nil || deployedPackage.ScopedCredentials.RegistryPassword == "" {
+			continue
+		}
+		deployedPackage.ScopedCredentials.RegistryPassword = ""
+		if err := c.UpdateDeployedPackage(ctx, deployedPackage); err != nil {
+			return err
+		}
+	}
+
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaceList.Items {
+		username, err := c.pullSecretUsername(ctx, namespace.Name, config.ZarfImagePullSecretName)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(username, ScopedUserPrefix) {
+			continue
+		}
+		secret, err := c.GenerateRegistryPullCreds(ctx, namespace.Name, config.ZarfImagePullSecretName, state.RegistryInfo)
+		if err != nil {
+			return err
+		}
+		l.Info("replacing scoped registry secret for namespace", "name", namespace.Name, "username", username)
+		_, err = c.Clientset.CoreV1().Secrets(namespace.Name).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restartAgent triggers a rolling update of the agent so that it serves with the current state.
+func (c *Cluster) restartAgent(ctx context.Context) error {
+	deployment, err := c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[restartedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Update(ctx, deployment, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to restart the agent: %w", err)
+	}
+	logger.From(ctx).Info("restarted the Zarf agent")
+	return nil
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestMigrateRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	c := &Cluster{Clientset: fake.NewClientset()}
+	for _, name := range []string{ZarfNamespaceName, "podinfo", "other"} {
+		_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	agent := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: agentDeploymentName, Namespace: ZarfNamespaceName}}
+	_, err := c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Create(ctx, agent, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	state := &types.ZarfState{
+		Distro: "k3s",
+		RegistryInfo: types.RegistryInfo{
+			Address:      "127.0.0.1:31999",
+			NodePort:     31999,
+			PushUsername: "zarf-push",
+			PushPassword: "push",
+			PullUsername: "zarf-pull",
+			PullPassword: "pull",
+		},
+	}
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "podinfo"}}
+	deployedPackage, err := c.RecordPackageDeployment(ctx, pkg, nil, nil)
+	require.NoError(t, err)
+	creds := &types.ScopedCredentials{Username: "zarf-pull-podinfo", RegistryPassword: "scoped", GitToken: "token"}
+	deployedPackage.ScopedCredentials = creds
+	require.NoError(t, c.UpdateDeployedPackage(ctx, *deployedPackage))
+	require.NoError(t, c.ApplyZarfManagedSecrets(ctx, "podinfo", ScopedState(state, creds)))
+	require.NoError(t, c.ApplyZarfManagedSecrets(ctx, "other", state))
+
+	_, _, err = c.MigrateRegistry(ctx, state, types.RegistryInfo{Address: "registry.example.com"})
+	require.EqualError(t, err, "the push credentials of the external registry are required")
+
+	external := types.RegistryInfo{Address: "registry.example.com", PushUsername: "push-user", PushPassword: "push-password"}
+	newState, _, err := c.MigrateRegistry(ctx, state, external)
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com", newState.RegistryInfo.Address)
+	require.False(t, newState.RegistryInfo.IsInternal())
+	// The pull credentials fall back to the push credentials.
+	require.Equal(t, "push-user", newState.RegistryInfo.PullUsername)
+	require.Equal(t, "127.0.0.1:31999", state.RegistryInfo.Address)
+
+	savedState, err := c.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, newState.RegistryInfo, savedState.RegistryInfo)
+	for _, namespace := range []string{"podinfo", "other"} {
+		username, err := c.pullSecretUsername(ctx, namespace, config.ZarfImagePullSecretName)
+		require.NoError(t, err)
+		require.Equal(t, "push-user", username)
+	}
+	deployedPackage, err = c.GetDeployedPackage(ctx, "podinfo")
+	require.NoError(t, err)
+	require.Equal(t, &types.ScopedCredentials{Username: "zarf-pull-podinfo", GitToken: "token"}, deployedPackage.ScopedCredentials)
+	agent, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, agent.Spec.Template.Annotations["zarf.dev/restartedAt"])
+
+	_, _, err = c.MigrateRegistry(ctx, newState, external)
+	require.EqualError(t, err, "the cluster already uses the external registry registry.example.com")
+}
@@ -7,6 +7,8 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
 	corev1 "k8s.io/api/core/v1"
 	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 )
@@ -34,27 +37,174 @@ func (c *Cluster) DeleteZarfNamespace(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	err = retry.Do(func() error {
-		_, err := c.Clientset.CoreV1().Namespaces().Get(ctx, ZarfNamespaceName, metav1.GetOptions{})
+	err = c.waitForNamespaceDeletion(ctx, ZarfNamespaceName)
+	if err != nil {
+		return err
+	}
+
+	l.Debug("done deleting the zarf namespace from this cluster", "duration", time.Since(start))
+	return nil
+}
+
+// DeleteUnusedNamespace deletes a namespace created by Zarf when no chart of the given deployed package or any other
+// deployed package is installed in it and no resources that were not deployed by Zarf are left in it, and waits for it to terminate.
+// It returns true when the namespace was deleted.
+func (c *Cluster) DeleteUnusedNamespace(ctx context.Context, name string, depPkg types.DeployedPackage) (bool, error) {
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return false, err
+	}
+	// The given deployed package is checked instead of its secret as the secret may not reflect the removed charts yet.
+	deployedPackages = slices.DeleteFunc(deployedPackages, func(deployedPackage types.DeployedPackage) bool {
+		return deployedPackage.Name == depPkg.Name
+	})
+	deployedPackages = append(deployedPackages, depPkg)
+	for _, deployedPackage := range deployedPackages {
+		for _, component := range deployedPackage.DeployedComponents {
+			for _, chart := range component.InstalledCharts {
+				if chart.Namespace == name {
+					return false, nil
+				}
+			}
+		}
+	}
+
+	namespace, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// Only delete namespaces that are still managed by Zarf.
+	if namespace.Labels[ZarfManagedByLabel] != "zarf" {
+		return false, nil
+	}
+	// Resources that were not deployed by Zarf, such as workloads of other tools or operators, keep the namespace around.
+	remaining, err := c.unmanagedNamespaceResources(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if len(remaining) > 0 {
+		message.Warnf("Keeping the namespace %s as it still contains resources not deployed by Zarf: %s", name, strings.Join(remaining, ", "))
+		logger.From(ctx).Warn("keeping namespace as it still contains resources not deployed by Zarf", "namespace", name, "resources", remaining)
+		return false, nil
+	}
+	err = c.Clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// Wait for the namespace to terminate so that the package can be deployed again right away.
+	err = c.waitForNamespaceDeletion(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// unmanagedNamespaceResources returns the resources left in a namespace once the Zarf charts in it are removed.
+// The secrets Zarf creates, service account tokens and the root CA configmap Kubernetes creates in every namespace are not included.
+func (c *Cluster) unmanagedNamespaceResources(ctx context.Context, name string) ([]string, error) {
+	remaining := []string{}
+	pods, err := c.Clientset.CoreV1().Pods(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		remaining = append(remaining, "pod/"+pod.Name)
+	}
+	deployments, err := c.Clientset.AppsV1().Deployments(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		remaining = append(remaining, "deployment/"+deployment.Name)
+	}
+	statefulSets, err := c.Clientset.AppsV1().StatefulSets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		remaining = append(remaining, "statefulset/"+statefulSet.Name)
+	}
+	daemonSets, err := c.Clientset.AppsV1().DaemonSets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, daemonSet := range daemonSets.Items {
+		remaining = append(remaining, "daemonset/"+daemonSet.Name)
+	}
+	jobs, err := c.Clientset.BatchV1().Jobs(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs.Items {
+		remaining = append(remaining, "job/"+job.Name)
+	}
+	cronJobs, err := c.Clientset.BatchV1().CronJobs(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cronJob := range cronJobs.Items {
+		remaining = append(remaining, "cronjob/"+cronJob.Name)
+	}
+	services, err := c.Clientset.CoreV1().Services(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services.Items {
+		remaining = append(remaining, "service/"+service.Name)
+	}
+	pvcs, err := c.Clientset.CoreV1().PersistentVolumeClaims(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs.Items {
+		remaining = append(remaining, "persistentvolumeclaim/"+pvc.Name)
+	}
+	configMaps, err := c.Clientset.CoreV1().ConfigMaps(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, configMap := range configMaps.Items {
+		if configMap.Name == "kube-root-ca.crt" || configMap.Labels[ZarfManagedByLabel] == "zarf" {
+			continue
+		}
+		remaining = append(remaining, "configmap/"+configMap.Name)
+	}
+	secrets, err := c.Clientset.CoreV1().Secrets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets.Items {
+		if secret.Type == corev1.SecretTypeServiceAccountToken || secret.Labels[ZarfManagedByLabel] == "zarf" {
+			continue
+		}
+		remaining = append(remaining, "secret/"+secret.Name)
+	}
+	return remaining, nil
+}
+
+// waitForNamespaceDeletion waits until the namespace no longer exists or the context is done.
+func (c *Cluster) waitForNamespaceDeletion(ctx context.Context, name string) error {
+	return retry.Do(func() error {
+		_, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 		if kerrors.IsNotFound(err) {
 			return nil
 		}
 		if err != nil {
 			return err
 		}
-		return fmt.Errorf("namespace still exists")
+		return fmt.Errorf("namespace %s still exists", name)
 	}, retry.Context(ctx), retry.Attempts(0), retry.DelayType(retry.FixedDelay), retry.Delay(time.Second))
-	if err != nil {
-		return err
-	}
-
-	l.Debug("done deleting the zarf namespace from this cluster", "duration", time.Since(start))
-	return nil
 }
 
 // NewZarfManagedApplyNamespace returns a v1ac.NamespaceApplyConfiguration with Zarf-managed labels
 func NewZarfManagedApplyNamespace(name string) *v1ac.NamespaceApplyConfiguration {
-	return v1ac.Namespace(name).WithLabels(AdoptZarfManagedLabels(nil))
+	return v1ac.Namespace(name).WithLabels(CommonLabels(AdoptZarfManagedLabels(nil))).WithAnnotations(CommonAnnotations(nil))
 }
 
 // NewZarfManagedNamespace returns a corev1.Namespace with Zarf-managed labels
@@ -69,6 +219,7 @@ func NewZarfManagedNamespace(name string) *corev1.Namespace {
 		},
 	}
 	namespace.Labels = AdoptZarfManagedLabels(namespace.Labels)
+	AddCommonMetadata(namespace)
 	return namespace
 }
 
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestDeleteUnusedNamespace(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+	c := &Cluster{
+		Clientset: fake.NewClientset(),
+	}
+
+	for _, ns := range []*corev1.Namespace{
+		NewZarfManagedNamespace("shared"),
+		NewZarfManagedNamespace("unused"),
+		NewZarfManagedNamespace("workloads"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}},
+	} {
+		_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	other := types.DeployedPackage{
+		Name: "other",
+		DeployedComponents: []types.DeployedComponent{
+			{Name: "component", InstalledCharts: []types.InstalledChart{{Namespace: "shared", ChartName: "chart"}}},
+		},
+	}
+	b, err := json.Marshal(other)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.ZarfPackagePrefix + other.Name,
+			Namespace: ZarfNamespaceName,
+			Labels: map[string]string{
+				ZarfPackageInfoLabel: other.Name,
+			},
+		},
+		Data: map[string][]byte{
+			"data": b,
+		},
+	}
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	depPkg := types.DeployedPackage{Name: "test"}
+
+	deleted, err := c.DeleteUnusedNamespace(ctx, "shared", depPkg)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = c.DeleteUnusedNamespace(ctx, "unmanaged", depPkg)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = c.DeleteUnusedNamespace(ctx, "missing", depPkg)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	inUse := types.DeployedPackage{
+		Name: "test",
+		DeployedComponents: []types.DeployedComponent{
+			{Name: "component", InstalledCharts: []types.InstalledChart{{Namespace: "unused", ChartName: "chart"}}},
+		},
+	}
+	deleted, err = c.DeleteUnusedNamespace(ctx, "unused", inUse)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	// Resources that were not deployed by Zarf keep the namespace.
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "operator-workload", Namespace: "workloads"}}
+	_, err = c.Clientset.AppsV1().Deployments("workloads").Create(ctx, deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+	deleted, err = c.DeleteUnusedNamespace(ctx, "workloads", depPkg)
+	require.NoError(t, err)
+	require.False(t, deleted)
+	_, err = c.Clientset.CoreV1().Namespaces().Get(ctx, "workloads", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// The resources Zarf and Kubernetes create in every namespace do not keep the namespace.
+	for _, obj := range []*corev1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Name: config.ZarfImagePullSecretName, Namespace: "unused", Labels: map[string]string{ZarfManagedByLabel: "zarf"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "default-token", Namespace: "unused"}, Type: corev1.SecretTypeServiceAccountToken},
+	} {
+		_, err = c.Clientset.CoreV1().Secrets("unused").Create(ctx, obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	_, err = c.Clientset.CoreV1().ConfigMaps("unused").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "unused"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	deleted, err = c.DeleteUnusedNamespace(ctx, "unused", depPkg)
+	require.NoError(t, err)
+	require.True(t, deleted)
+	_, err = c.Clientset.CoreV1().Namespaces().Get(ctx, "unused", metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+}
@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// The actions RemapImages takes for a workload that references an image at the previous registry address.
+const (
+	// RemapActionRewritten is the action for workloads whose pod template was rewritten to the new address.
+	RemapActionRewritten = "rewritten"
+	// RemapActionRestarted is the action for workloads whose running pods were mutated to the previous address by the
+	// Zarf agent, they are restarted for the agent to mutate them to the registry address in the Zarf state.
+	RemapActionRestarted = "restarted"
+	// RemapActionRecreate is the action for jobs and pods without a controller, their pod templates can not be changed
+	// so they must be recreated.
+	RemapActionRecreate = "recreate"
+)
+
+// restartedAtAnnotation is set on the pod template of a workload to restart its pods.
+const restartedAtAnnotation = "zarf.dev/restartedAt"
+
+// RemappedImage is a container image of a workload that referenced an image of a deployed package at a previous registry address.
+type RemappedImage struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Action    string `json:"action"`
+}
+
+// RemapImages rewrites the deployments, statefulsets, daemonsets, replicasets and cronjobs that reference images of deployed packages at the old registry address to the new address.
+// Workloads whose running pods still reference the old address are restarted, and jobs and pods without a controller that do are reported to be recreated.
+// Only images recorded in the deployed packages are rewritten, when dryRun is true the workloads are not updated.
+func (c *Cluster) RemapImages(ctx context.Context, oldAddress, newAddress string, dryRun bool) ([]RemappedImage, error) {
+	l := logger.From(ctx)
+	if oldAddress == newAddress {
+		return nil, fmt.Errorf("the old and new registry address are both %s", oldAddress)
+	}
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remap, err := imageRemap(deployedPackages, oldAddress, newAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	remapped := []RemappedImage{}
+	// handled holds the workloads that were rewritten or restarted, keyed by kind, namespace and name.
+	handled := map[string]bool{}
+	record := func(kind, action string, meta metav1.ObjectMeta, changes []RemappedImage) {
+		handled[workloadKey(kind, meta.Namespace, meta.Name)] = true
+		for _, change := range changes {
+			change.Kind = kind
+			change.Namespace = meta.Namespace
+			change.Name = meta.Name
+			change.Action = action
+			remapped = append(remapped, change)
+			l.Info("remapping image", "kind", kind, "namespace", meta.Namespace, "name", meta.Name, "container", change.Container, "from", change.From, "to", change.To, "action", action)
+		}
+	}
+	rewrite := func(kind string, meta metav1.ObjectMeta, spec *corev1.PodSpec) bool {
+		changes := remapPodSpec(spec, remap)
+		if len(changes) == 0 {
+			return false
+		}
+		record(kind, RemapActionRewritten, meta, changes)
+		return !dryRun
+	}
+
+	deployments, err := c.Clientset.AppsV1().Deployments(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		if !rewrite("Deployment", deployment.ObjectMeta, &deployment.Spec.Template.Spec) {
+			continue
+		}
+		if _, err := c.Clientset.AppsV1().Deployments(deployment.Namespace).Update(ctx, &deployment, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("unable to update deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+		}
+	}
+	statefulSets, err := c.Clientset.AppsV1().StatefulSets(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if !rewrite("StatefulSet", statefulSet.ObjectMeta, &statefulSet.Spec.Template.Spec) {
+			continue
+		}
+		if _, err := c.Clientset.AppsV1().StatefulSets(statefulSet.Namespace).Update(ctx, &statefulSet, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("unable to update statefulset %s/%s: %w", statefulSet.Namespace, statefulSet.Name, err)
+		}
+	}
+	daemonSets, err := c.Clientset.AppsV1().DaemonSets(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, daemonSet := range daemonSets.Items {
+		if !rewrite("DaemonSet", daemonSet.ObjectMeta, &daemonSet.Spec.Template.Spec) {
+			continue
+		}
+		if _, err := c.Clientset.AppsV1().DaemonSets(daemonSet.Namespace).Update(ctx, &daemonSet, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("unable to update daemonset %s/%s: %w", daemonSet.Namespace, daemonSet.Name, err)
+		}
+	}
+	replicaSets, err := c.Clientset.AppsV1().ReplicaSets(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, replicaSet := range replicaSets.Items {
+		// The replicasets of a deployment are rolled by the deployment.
+		if metav1.GetControllerOf(&replicaSet) != nil {
+			continue
+		}
+		if !rewrite("ReplicaSet", replicaSet.ObjectMeta, &replicaSet.Spec.Template.Spec) {
+			continue
+		}
+		if _, err := c.Clientset.AppsV1().ReplicaSets(replicaSet.Namespace).Update(ctx, &replicaSet, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("unable to update replicaset %s/%s: %w", replicaSet.Namespace, replicaSet.Name, err)
+		}
+	}
+	cronJobs, err := c.Clientset.BatchV1().CronJobs(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cronJob := range cronJobs.Items {
+		if !rewrite("CronJob", cronJob.ObjectMeta, &cronJob.Spec.JobTemplate.Spec.Template.Spec) {
+			continue
+		}
+		if _, err := c.Clientset.BatchV1().CronJobs(cronJob.Namespace).Update(ctx, &cronJob, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("unable to update cronjob %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+		}
+	}
+
+	// Pods mutated by the Zarf agent reference the old address while their workload does not, and a replicaset does not
+	// roll its pods when its template changes.
+	pods, err := c.Clientset.CoreV1().Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		changes := remapPodSpec(pod.Spec.DeepCopy(), remap)
+		if len(changes) == 0 {
+			continue
+		}
+		kind, name, err := c.podWorkload(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		key := workloadKey(kind, pod.Namespace, name)
+		meta := metav1.ObjectMeta{Namespace: pod.Namespace, Name: name}
+		switch kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			if handled[key] {
+				continue
+			}
+			record(kind, RemapActionRestarted, meta, changes)
+			if dryRun {
+				continue
+			}
+			if err := c.restartWorkload(ctx, kind, pod.Namespace, name); err != nil {
+				return nil, err
+			}
+		case "ReplicaSet":
+			if !handled[key] {
+				record(kind, RemapActionRestarted, meta, changes)
+			}
+			if dryRun {
+				continue
+			}
+			// The replicaset replaces the pod, which is then created from the rewritten template or mutated by the agent.
+			err := c.Clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to delete pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		default:
+			if handled[key] {
+				continue
+			}
+			record(kind, RemapActionRecreate, meta, changes)
+		}
+	}
+	return remapped, nil
+}
+
+// podWorkload returns the kind and name of the workload that manages the pod, which is the pod itself when it has no
+// controller. The pods of a deployment are managed by the deployment rather than its replicaset.
+func (c *Cluster) podWorkload(ctx context.Context, pod corev1.Pod) (string, string, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name, nil
+	}
+	if owner.Kind != "ReplicaSet" {
+		return owner.Kind, owner.Name, nil
+	}
+	replicaSet, err := c.Clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return owner.Kind, owner.Name, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if deploymentOwner := metav1.GetControllerOf(replicaSet); deploymentOwner != nil && deploymentOwner.Kind == "Deployment" {
+		return deploymentOwner.Kind, deploymentOwner.Name, nil
+	}
+	return owner.Kind, owner.Name, nil
+}
+
+// restartWorkload restarts the pods of a deployment, statefulset or daemonset the same way kubectl rollout restart does.
+func (c *Cluster) restartWorkload(ctx context.Context, kind, namespace, name string) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, time.Now().UTC().Format(time.RFC3339)))
+	var err error
+	switch kind {
+	case "Deployment":
+		_, err = c.Clientset.AppsV1().Deployments(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = c.Clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = c.Clientset.AppsV1().DaemonSets(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("unable to restart %s %s/%s", kind, namespace, name)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to restart %s %s/%s: %w", strings.ToLower(kind), namespace, name, err)
+	}
+	return nil
+}
+
+// workloadKey returns the key of a workload in the workloads handled by RemapImages.
+func workloadKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// imageRemap returns the names the images of the deployed packages were pushed as at the old registry address mapped to their names at the new address.
+func imageRemap(deployedPackages []types.DeployedPackage, oldAddress, newAddress string) (map[string]string, error) {
+	remap := map[string]string{}
+	for _, deployedPackage := range deployedPackages {
+		for _, component := range deployedPackage.Data.Components {
+			for _, image := range component.Images {
+				oldName, err := transform.ImageTransformHost(oldAddress, image)
+				if err != nil {
+					return nil, err
+				}
+				newName, err := transform.ImageTransformHost(newAddress, image)
+				if err != nil {
+					return nil, err
+				}
+				remap[oldName] = newName
+				oldName, err = transform.ImageTransformHostWithoutChecksum(oldAddress, image)
+				if err != nil {
+					return nil, err
+				}
+				newName, err = transform.ImageTransformHostWithoutChecksum(newAddress, image)
+				if err != nil {
+					return nil, err
+				}
+				remap[oldName] = newName
+			}
+		}
+	}
+	return remap, nil
+}
+
+// remapPodSpec rewrites the images of the containers in the pod spec that are in the remap and returns the changes.
+func remapPodSpec(spec *corev1.PodSpec, remap map[string]string) []RemappedImage {
+	changes := []RemappedImage{}
+	remapContainers := func(containers []corev1.Container) {
+		for i, container := range containers {
+			newName, ok := remap[container.Image]
+			if !ok {
+				continue
+			}
+			changes = append(changes, RemappedImage{Container: container.Name, From: container.Image, To: newName})
+			containers[i].Image = newName
+		}
+	}
+	remapContainers(spec.InitContainers)
+	remapContainers(spec.Containers)
+	return changes
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestRemapImages(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	c := &Cluster{Clientset: fake.NewClientset()}
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "podinfo"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "podinfo", Images: []string{"ghcr.io/stefanprodan/podinfo:6.4.0", "busybox:1.36"}},
+		},
+	}
+	_, err := c.RecordPackageDeployment(ctx, pkg, nil, nil)
+	require.NoError(t, err)
+
+	oldName, err := transform.ImageTransformHost("127.0.0.1:31999", "ghcr.io/stefanprodan/podinfo:6.4.0")
+	require.NoError(t, err)
+	newName, err := transform.ImageTransformHost("registry.example.com", "ghcr.io/stefanprodan/podinfo:6.4.0")
+	require.NoError(t, err)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "podinfo"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init", Image: "127.0.0.1:31999/library/busybox:1.36"}},
+					Containers: []corev1.Container{
+						{Name: "podinfo", Image: oldName},
+						// Images that are not recorded in a deployed package are left as is.
+						{Name: "other", Image: "127.0.0.1:31999/library/nginx:1.27"},
+						{Name: "original", Image: "ghcr.io/stefanprodan/podinfo:6.4.0"},
+					},
+				},
+			},
+		},
+	}
+	_, err = c.Clientset.AppsV1().Deployments("podinfo").Create(ctx, deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "untouched", Namespace: "podinfo"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.27"}}},
+			},
+		},
+	}
+	_, err = c.Clientset.AppsV1().StatefulSets("podinfo").Create(ctx, statefulSet, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	oldPodSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "podinfo", Image: oldName}}}
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "podinfo"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: oldPodSpec}}},
+		},
+	}
+	_, err = c.Clientset.BatchV1().CronJobs("podinfo").Create(ctx, cronJob, metav1.CreateOptions{})
+	require.NoError(t, err)
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "podinfo"},
+		Spec:       appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: oldPodSpec}},
+	}
+	_, err = c.Clientset.AppsV1().ReplicaSets("podinfo").Create(ctx, replicaSet, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// The pods of the mutated deployment reference the old address while its template references the original image.
+	mutated := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mutated", Namespace: "podinfo"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "podinfo", Image: "ghcr.io/stefanprodan/podinfo:6.4.0"}}},
+			},
+		},
+	}
+	_, err = c.Clientset.AppsV1().Deployments("podinfo").Create(ctx, mutated, metav1.CreateOptions{})
+	require.NoError(t, err)
+	mutatedReplicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "mutated-abc", Namespace: "podinfo", OwnerReferences: []metav1.OwnerReference{controllerRef("Deployment", "mutated")}},
+		Spec:       appsv1.ReplicaSetSpec{Template: mutated.Spec.Template},
+	}
+	_, err = c.Clientset.AppsV1().ReplicaSets("podinfo").Create(ctx, mutatedReplicaSet, metav1.CreateOptions{})
+	require.NoError(t, err)
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "mutated-abc-1", Namespace: "podinfo", OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", "mutated-abc")}}, Spec: oldPodSpec},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mutated-abc-2", Namespace: "podinfo", OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", "mutated-abc")}}, Spec: oldPodSpec},
+		{ObjectMeta: metav1.ObjectMeta{Name: "standalone-1", Namespace: "podinfo", OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", "standalone")}}, Spec: oldPodSpec},
+		{ObjectMeta: metav1.ObjectMeta{Name: "migrate-1", Namespace: "podinfo", OwnerReferences: []metav1.OwnerReference{controllerRef("Job", "migrate")}}, Spec: oldPodSpec},
+	}
+	for _, pod := range pods {
+		_, err = c.Clientset.CoreV1().Pods("podinfo").Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	expected := []RemappedImage{
+		{Kind: "Deployment", Namespace: "podinfo", Name: "podinfo", Container: "init", From: "127.0.0.1:31999/library/busybox:1.36", To: "registry.example.com/library/busybox:1.36", Action: RemapActionRewritten},
+		{Kind: "Deployment", Namespace: "podinfo", Name: "podinfo", Container: "podinfo", From: oldName, To: newName, Action: RemapActionRewritten},
+		{Kind: "ReplicaSet", Namespace: "podinfo", Name: "standalone", Container: "podinfo", From: oldName, To: newName, Action: RemapActionRewritten},
+		{Kind: "CronJob", Namespace: "podinfo", Name: "report", Container: "podinfo", From: oldName, To: newName, Action: RemapActionRewritten},
+		{Kind: "Deployment", Namespace: "podinfo", Name: "mutated", Container: "podinfo", From: oldName, To: newName, Action: RemapActionRestarted},
+		{Kind: "Job", Namespace: "podinfo", Name: "migrate", Container: "podinfo", From: oldName, To: newName, Action: RemapActionRecreate},
+	}
+
+	// A dry run does not update the workloads.
+	remapped, err := c.RemapImages(ctx, "127.0.0.1:31999", "registry.example.com", true)
+	require.NoError(t, err)
+	require.ElementsMatch(t, expected, remapped)
+	got, err := c.Clientset.AppsV1().Deployments("podinfo").Get(ctx, "podinfo", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, oldName, got.Spec.Template.Spec.Containers[0].Image)
+	_, err = c.Clientset.CoreV1().Pods("podinfo").Get(ctx, "standalone-1", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	remapped, err = c.RemapImages(ctx, "127.0.0.1:31999", "registry.example.com", false)
+	require.NoError(t, err)
+	require.ElementsMatch(t, expected, remapped)
+	got, err = c.Clientset.AppsV1().Deployments("podinfo").Get(ctx, "podinfo", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com/library/busybox:1.36", got.Spec.Template.Spec.InitContainers[0].Image)
+	require.Equal(t, newName, got.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, "127.0.0.1:31999/library/nginx:1.27", got.Spec.Template.Spec.Containers[1].Image)
+	require.Equal(t, "ghcr.io/stefanprodan/podinfo:6.4.0", got.Spec.Template.Spec.Containers[2].Image)
+	require.NotContains(t, got.Spec.Template.Annotations, restartedAtAnnotation)
+	gotCronJob, err := c.Clientset.BatchV1().CronJobs("podinfo").Get(ctx, "report", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, newName, gotCronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image)
+	gotReplicaSet, err := c.Clientset.AppsV1().ReplicaSets("podinfo").Get(ctx, "standalone", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, newName, gotReplicaSet.Spec.Template.Spec.Containers[0].Image)
+	_, err = c.Clientset.CoreV1().Pods("podinfo").Get(ctx, "standalone-1", metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+	gotMutated, err := c.Clientset.AppsV1().Deployments("podinfo").Get(ctx, "mutated", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, gotMutated.Spec.Template.Annotations, restartedAtAnnotation)
+	require.Equal(t, "ghcr.io/stefanprodan/podinfo:6.4.0", gotMutated.Spec.Template.Spec.Containers[0].Image)
+
+	// Remapping again only finds the pods that are left until the restarts complete.
+	for _, name := range []string{"mutated-abc-1", "mutated-abc-2", "migrate-1"} {
+		err = c.Clientset.CoreV1().Pods("podinfo").Delete(ctx, name, metav1.DeleteOptions{})
+		require.NoError(t, err)
+	}
+	remapped, err = c.RemapImages(ctx, "127.0.0.1:31999", "registry.example.com", false)
+	require.NoError(t, err)
+	require.Empty(t, remapped)
+
+	_, err = c.RemapImages(ctx, "registry.example.com", "registry.example.com", false)
+	require.EqualError(t, err, "the old and new registry address are both registry.example.com")
+}
+
+// controllerRef returns an owner reference to the controller of an object.
+func controllerRef(kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{Kind: kind, Name: name, Controller: helpers.BoolPtr(true)}
+}
@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	// ScopedUserPrefix prefixes the names of the users minted for the pull credentials of a single package.
+	ScopedUserPrefix = "zarf-pull-"
+	// ZarfRegistrySecretName is the secret holding the configuration and htpasswd file of the Zarf registry.
+	ZarfRegistrySecretName = "zarf-docker-registry-secret"
+	registryHtpasswdKey    = "htpasswd"
+	// maxScopedUsernameLength is the maximum length of a Gitea username.
+	maxScopedUsernameLength = 40
+)
+
+// ScopedUsername returns the name of the registry and git server users minted for a package.
+func ScopedUsername(packageName string) string {
+	username := ScopedUserPrefix + packageName
+	if len(username) <= maxScopedUsernameLength {
+		return username
+	}
+	// Keep long names unique by replacing the end of the name with its checksum.
+	suffix := fmt.Sprintf("-%08x", crc32.ChecksumIEEE([]byte(packageName)))
+	return username[:maxScopedUsernameLength-len(suffix)] + suffix
+}
+
+// ScopedState returns a copy of the state that uses the scoped credentials as the registry and git server pull
+// credentials, so that the pull secrets and templates of a package are created with them.
+func ScopedState(state *types.ZarfState, creds *types.ScopedCredentials) *types.ZarfState {
+	scoped := *state
+	if creds.RegistryPassword != "" {
+		scoped.RegistryInfo.PullUsername = creds.Username
+		scoped.RegistryInfo.PullPassword = creds.RegistryPassword
+	}
+	if creds.GitToken != "" {
+		scoped.GitServer.PullUsername = creds.Username
+		scoped.GitServer.PullPassword = creds.GitToken
+	}
+	return &scoped
+}
+
+// MintScopedCredentials creates the read-only registry and git server users of a package and records their
+// credentials with the deployed package so they can be revoked when the package is removed. Credentials that were
+// already minted for the package are reused. Only the internal registry and git server are supported.
+func (c *Cluster) MintScopedCredentials(ctx context.Context, state *types.ZarfState, pkg v1alpha1.ZarfPackage) (*types.ScopedCredentials, error) {
+	existing, err := c.GetDeployedPackage(ctx, pkg.Metadata.Name)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	creds := types.ScopedCredentials{Username: ScopedUsername(pkg.Metadata.Name)}
+	if existing != nil && existing.ScopedCredentials != nil {
+		creds = *existing.ScopedCredentials
+	}
+
+	if state.RegistryInfo.IsInternal() {
+		if creds.RegistryPassword == "" {
+			creds.RegistryPassword, err = helpers.RandomString(types.ZarfGeneratedPasswordLen)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// The user is always set as a re-init of the registry replaces the htpasswd file.
+		line, err := utils.GetHtpasswdString(creds.Username, creds.RegistryPassword)
+		if err != nil {
+			return nil, fmt.Errorf("error generating htpasswd string: %w", err)
+		}
+		if err := c.setRegistryUser(ctx, creds.Username, line); err != nil {
+			return nil, err
+		}
+	}
+
+	if state.GitServer.IsInternal() && creds.GitToken == "" {
+		exists, err := c.InternalGitServerExists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			creds.GitToken, err = c.createScopedGitToken(ctx, state.GitServer, creds.Username)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create the git server user %s: %w", creds.Username, err)
+			}
+		}
+	}
+
+	// Record the credentials right away so they are revoked on removal even if the deployment fails.
+	if existing == nil {
+		existing = &types.DeployedPackage{
+			Name:       pkg.Metadata.Name,
+			CLIVersion: config.CLIVersion,
+			Data:       pkg,
+		}
+	}
+	existing.ScopedCredentials = &creds
+	if err := c.UpdateDeployedPackage(ctx, *existing); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// RevokeScopedCredentials deletes the registry and git server users of a package and resets the pull secrets that
+// still hold its credentials to the shared pull credentials of the state.
+func (c *Cluster) RevokeScopedCredentials(ctx context.Context, state *types.ZarfState, creds types.ScopedCredentials) error {
+	l := logger.From(ctx)
+	if creds.RegistryPassword != "" {
+		if err := c.setRegistryUser(ctx, creds.Username, ""); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	if creds.GitToken != "" {
+		exists, err := c.InternalGitServerExists(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := c.deleteGitUser(ctx, state.GitServer, creds.Username); err != nil {
+				return fmt.Errorf("unable to delete the git server user %s: %w", creds.Username, err)
+			}
+		}
+	}
+
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaceList.Items {
+		registryUsername, err := c.pullSecretUsername(ctx, namespace.Name, config.ZarfImagePullSecretName)
+		if err != nil {
+			return err
+		}
+		gitUsername, err := c.pullSecretUsername(ctx, namespace.Name, config.ZarfGitServerSecretName)
+		if err != nil {
+			return err
+		}
+		if registryUsername != creds.Username && gitUsername != creds.Username {
+			continue
+		}
+		l.Info("resetting scoped pull secrets for namespace", "name", namespace.Name, "username", creds.Username)
+		if err := c.ApplyZarfManagedSecrets(ctx, namespace.Name, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScopedRegistryHtpasswd returns the htpasswd entries of the scoped registry users, so that they are kept when the
+// htpasswd file of the registry is regenerated.
+func (c *Cluster) ScopedRegistryHtpasswd(ctx context.Context) ([]string, error) {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfRegistrySecretName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries := []string{}
+	for _, line := range strings.Split(string(secret.Data[registryHtpasswdKey]), "\n") {
+		if strings.HasPrefix(line, ScopedUserPrefix) {
+			entries = append(entries, line)
+		}
+	}
+	return entries, nil
+}
+
+// setRegistryUser replaces the htpasswd entry of the user in the registry secret, the user is removed when the entry
+// is empty. The registry reloads the htpasswd file when the mounted secret changes.
+func (c *Cluster) setRegistryUser(ctx context.Context, username, entry string) error {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfRegistrySecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	lines := []string{}
+	for _, line := range strings.Split(string(secret.Data[registryHtpasswdKey]), "\n") {
+		if line == "" || strings.HasPrefix(line, username+":") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if entry != "" {
+		lines = append(lines, entry)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[registryHtpasswdKey] = []byte(strings.Join(lines, "\n"))
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Update(ctx, secret, metav1.UpdateOptions{FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to update the registry users: %w", err)
+	}
+	return nil
+}
+
+// pullSecretUsername returns the username of the Zarf pull secret in the namespace, or an empty string if there is no
+// such secret.
+func (c *Cluster) pullSecretUsername(ctx context.Context, namespace, name string) (string, error) {
+	secret, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return secretUsername(secret.Data), nil
+}
+
+// secretUsername returns the username of a Zarf registry or git server pull secret.
+func secretUsername(data map[string][]byte) string {
+	if username, ok := data["username"]; ok {
+		return string(username)
+	}
+	dockerConfig := DockerConfig{}
+	if err := json.Unmarshal(data[".dockerconfigjson"], &dockerConfig); err != nil {
+		return ""
+	}
+	for _, entry := range dockerConfig.Auths {
+		auth, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			continue
+		}
+		username, _, _ := strings.Cut(string(auth), ":")
+		return username
+	}
+	return ""
+}
+
+// createScopedGitToken creates the git server user and returns a token of the user that can only read repositories.
+func (c *Cluster) createScopedGitToken(ctx context.Context, gitServer types.GitServerInfo, username string) (string, error) {
+	tunnel, err := c.NewTunnel(ZarfNamespaceName, SvcResource, ZarfGitServerName, "", 0, ZarfGitServerPort)
+	if err != nil {
+		return "", err
+	}
+	_, err = tunnel.Connect(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tunnel.Close()
+	tunnelURL := tunnel.HTTPEndpoint()
+	giteaClient, err := gitea.NewClient(tunnelURL, gitServer.PushUsername, gitServer.PushPassword)
+	if err != nil {
+		return "", err
+	}
+	var token string
+	err = tunnel.Wrap(func() error {
+		// The password is only used to create the token, workloads authenticate with the token.
+		password, err := helpers.RandomString(types.ZarfGeneratedPasswordLen)
+		if err != nil {
+			return err
+		}
+		err = giteaClient.CreateScopedUser(ctx, username, password)
+		if err != nil {
+			return err
+		}
+		userClient, err := gitea.NewClient(tunnelURL, username, password)
+		if err != nil {
+			return err
+		}
+		token, err = userClient.CreateReadRepositoryToken(ctx)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// deleteGitUser deletes the git server user, which revokes its tokens.
+func (c *Cluster) deleteGitUser(ctx context.Context, gitServer types.GitServerInfo, username string) error {
+	tunnel, err := c.NewTunnel(ZarfNamespaceName, SvcResource, ZarfGitServerName, "", 0, ZarfGitServerPort)
+	if err != nil {
+		return err
+	}
+	_, err = tunnel.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+	giteaClient, err := gitea.NewClient(tunnel.HTTPEndpoint(), gitServer.PushUsername, gitServer.PushPassword)
+	if err != nil {
+		return err
+	}
+	return tunnel.Wrap(func() error {
+		return giteaClient.DeleteUser(ctx, username)
+	})
+}
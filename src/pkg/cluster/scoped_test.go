@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestScopedUsername(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "zarf-pull-podinfo", ScopedUsername("podinfo"))
+
+	long := ScopedUsername("a-package-with-a-very-long-name-that-does-not-fit")
+	require.Len(t, long, maxScopedUsernameLength)
+	require.True(t, strings.HasPrefix(long, ScopedUserPrefix))
+	require.NotEqual(t, long, ScopedUsername("a-package-with-a-very-long-name-that-does-not-fit-either"))
+}
+
+func TestScopedState(t *testing.T) {
+	t.Parallel()
+
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{PullUsername: "zarf-pull", PullPassword: "registry"},
+		GitServer:    types.GitServerInfo{PullUsername: "zarf-git-read-user", PullPassword: "git"},
+	}
+	scoped := ScopedState(state, &types.ScopedCredentials{Username: "zarf-pull-podinfo", RegistryPassword: "scoped"})
+	require.Equal(t, "zarf-pull-podinfo", scoped.RegistryInfo.PullUsername)
+	require.Equal(t, "scoped", scoped.RegistryInfo.PullPassword)
+	// Credentials that were not minted are left as is.
+	require.Equal(t, "zarf-git-read-user", scoped.GitServer.PullUsername)
+	require.Equal(t, "git", scoped.GitServer.PullPassword)
+	// The state itself is not modified.
+	require.Equal(t, "zarf-pull", state.RegistryInfo.PullUsername)
+}
+
+func TestMintAndRevokeScopedCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	c := &Cluster{Clientset: fake.NewClientset()}
+	registrySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ZarfRegistrySecretName,
+			Namespace: ZarfNamespaceName,
+		},
+		Data: map[string][]byte{
+			registryHtpasswdKey: []byte("zarf-push:push\nzarf-pull:pull"),
+		},
+	}
+	_, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, registrySecret, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = c.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "podinfo"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{
+			Address:      "127.0.0.1:31999",
+			NodePort:     31999,
+			PullUsername: "zarf-pull",
+			PullPassword: "pull",
+		},
+		GitServer: types.GitServerInfo{
+			Address:      "https://git.example.com",
+			PullUsername: "git-user",
+			PullPassword: "git-password",
+		},
+	}
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "podinfo"}}
+	creds, err := c.MintScopedCredentials(ctx, state, pkg)
+	require.NoError(t, err)
+	require.Equal(t, "zarf-pull-podinfo", creds.Username)
+	require.NotEmpty(t, creds.RegistryPassword)
+	require.Empty(t, creds.GitToken)
+
+	deployedPackage, err := c.GetDeployedPackage(ctx, "podinfo")
+	require.NoError(t, err)
+	require.Equal(t, creds, deployedPackage.ScopedCredentials)
+	entries, err := c.ScopedRegistryHtpasswd(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.True(t, strings.HasPrefix(entries[0], "zarf-pull-podinfo:"))
+
+	// Minting again reuses the recorded credentials.
+	again, err := c.MintScopedCredentials(ctx, state, pkg)
+	require.NoError(t, err)
+	require.Equal(t, creds, again)
+	entries, err = c.ScopedRegistryHtpasswd(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	err = c.ApplyZarfManagedSecrets(ctx, "podinfo", ScopedState(state, creds))
+	require.NoError(t, err)
+	username, err := c.pullSecretUsername(ctx, "podinfo", config.ZarfImagePullSecretName)
+	require.NoError(t, err)
+	require.Equal(t, "zarf-pull-podinfo", username)
+
+	err = c.RevokeScopedCredentials(ctx, state, *creds)
+	require.NoError(t, err)
+	entries, err = c.ScopedRegistryHtpasswd(ctx)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfRegistrySecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "zarf-push:push\nzarf-pull:pull", string(secret.Data[registryHtpasswdKey]))
+	username, err = c.pullSecretUsername(ctx, "podinfo", config.ZarfImagePullSecretName)
+	require.NoError(t, err)
+	require.Equal(t, "zarf-pull", username)
+}
@@ -9,6 +9,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -56,7 +59,7 @@ func (c *Cluster) GenerateRegistryPullCreds(ctx context.Context, namespace, name
 	// Build zarf-docker-registry service address string
 	svc, port, err := serviceInfoFromNodePortURL(serviceList.Items, registryInfo.Address)
 	if err == nil {
-		kubeDNSRegistryURL := fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, port)
+		kubeDNSRegistryURL := net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(port))
 		dockerConfigJSON.Auths[kubeDNSRegistryURL] = DockerConfigEntryWithAuth{
 			Auth: authEncodedValue,
 		}
@@ -69,9 +72,10 @@ func (c *Cluster) GenerateRegistryPullCreds(ctx context.Context, namespace, name
 	}
 
 	secretDockerConfig := v1ac.Secret(name, namespace).
-		WithLabels(map[string]string{
+		WithLabels(CommonLabels(map[string]string{
 			ZarfManagedByLabel: "zarf",
-		}).
+		})).
+		WithAnnotations(CommonAnnotations(nil)).
 		WithType(corev1.SecretTypeDockerConfigJson).
 		WithData(map[string][]byte{
 			".dockerconfigjson": dockerConfigData,
@@ -83,15 +87,33 @@ func (c *Cluster) GenerateRegistryPullCreds(ctx context.Context, namespace, name
 // GenerateGitPullCreds generates a secret containing the git credentials.
 func (c *Cluster) GenerateGitPullCreds(namespace, name string, gitServerInfo types.GitServerInfo) *v1ac.SecretApplyConfiguration {
 	return v1ac.Secret(name, namespace).
-		WithLabels(map[string]string{
+		WithLabels(CommonLabels(map[string]string{
 			ZarfManagedByLabel: "zarf",
-		}).WithType(corev1.SecretTypeOpaque).
+		})).WithAnnotations(CommonAnnotations(nil)).WithType(corev1.SecretTypeOpaque).
 		WithStringData(map[string]string{
 			"username": gitServerInfo.PullUsername,
 			"password": gitServerInfo.PullPassword,
 		})
 }
 
+// ApplyZarfManagedSecrets applies the registry and git server secrets generated from state to a namespace.
+func (c *Cluster) ApplyZarfManagedSecrets(ctx context.Context, namespace string, state *types.ZarfState) error {
+	validRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, namespace, config.ZarfImagePullSecretName, state.RegistryInfo)
+	if err != nil {
+		return err
+	}
+	_, err = c.Clientset.CoreV1().Secrets(*validRegistrySecret.Namespace).Apply(ctx, validRegistrySecret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("problem applying registry secret for the %s namespace: %w", namespace, err)
+	}
+	gitServerSecret := c.GenerateGitPullCreds(namespace, config.ZarfGitServerSecretName, state.GitServer)
+	_, err = c.Clientset.CoreV1().Secrets(*gitServerSecret.Namespace).Apply(ctx, gitServerSecret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("problem applying git server secret for the %s namespace: %w", namespace, err)
+	}
+	return nil
+}
+
 // UpdateZarfManagedImageSecrets updates all Zarf-managed image secrets in all namespaces based on state
 func (c *Cluster) UpdateZarfManagedImageSecrets(ctx context.Context, state *types.ZarfState) error {
 	l := logger.From(ctx)
@@ -115,6 +137,10 @@ func (c *Cluster) UpdateZarfManagedImageSecrets(ctx context.Context, state *type
 		if currentRegistrySecret.Labels[ZarfManagedByLabel] != "zarf" && (namespace.Labels[AgentLabel] == "skip" || namespace.Labels[AgentLabel] == "ignore") {
 			continue
 		}
+		// Skip secrets holding the scoped credentials of a package, they are not derived from the state.
+		if strings.HasPrefix(secretUsername(currentRegistrySecret.Data), ScopedUserPrefix) {
+			continue
+		}
 		newRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, namespace.Name, config.ZarfImagePullSecretName, state.RegistryInfo)
 		if err != nil {
 			return err
@@ -153,6 +179,10 @@ func (c *Cluster) UpdateZarfManagedGitSecrets(ctx context.Context, state *types.
 		if currentGitSecret.Labels[ZarfManagedByLabel] != "zarf" && (namespace.Labels[AgentLabel] == "skip" || namespace.Labels[AgentLabel] == "ignore") {
 			continue
 		}
+		// Skip secrets holding the scoped credentials of a package, they are not derived from the state.
+		if strings.HasPrefix(secretUsername(currentGitSecret.Data), ScopedUserPrefix) {
+			continue
+		}
 		newGitSecret := c.GenerateGitPullCreds(namespace.Name, config.ZarfGitServerSecretName, state.GitServer)
 		spinner.Updatef("Updating existing Zarf-managed git secret for namespace: %s", namespace.Name)
 		l.Info("applying Zarf managed git secret for namespace", "name", namespace.Name)
@@ -181,5 +211,5 @@ func (c *Cluster) GetServiceInfoFromRegistryAddress(ctx context.Context, stateRe
 		return stateRegistryAddress, nil
 	}
 
-	return fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, port), nil
+	return net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(port)), nil
 }
@@ -9,7 +9,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -34,6 +36,8 @@ const (
 	ZarfStateSecretName  = "zarf-state"
 	ZarfStateDataKey     = "state"
 	ZarfPackageInfoLabel = "package-deploy-info"
+	// ZarfPackageHistoryLabel marks secrets holding superseded versions of a deployed package
+	ZarfPackageHistoryLabel = "zarf.dev/package-history"
 )
 
 // InitZarfState initializes the Zarf state with the given temporary directory and init configs.
@@ -42,6 +46,12 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 	spinner := message.NewProgressSpinner("Gathering cluster state information")
 	defer spinner.Stop()
 
+	if len(initOptions.StateRecipients) > 0 {
+		if err := checkStateKey(initOptions.StateRecipients); err != nil {
+			return err
+		}
+	}
+
 	// Attempt to load an existing state prior to init.
 	// NOTE: We are ignoring the error here because we don't really expect a state to exist yet.
 	spinner.Updatef("Checking cluster for existing Zarf deployment")
@@ -142,6 +152,17 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 			return err
 		}
 		state.GitServer = initOptions.GitServer
+		// The internal registry is reached on a node port on the loopback address of the nodes, which is not routed for
+		// IPv6 as the node port traffic can not be forwarded from ::1.
+		if initOptions.RegistryInfo.Address == "" {
+			ipv6Only, err := c.isIPv6Only(ctx)
+			if err != nil {
+				return err
+			}
+			if ipv6Only {
+				return errors.New("the internal registry can not be reached by the nodes of an IPv6-only cluster, provide an external registry with --registry-url")
+			}
+		}
 		err = initOptions.RegistryInfo.FillInEmptyValues()
 		if err != nil {
 			return err
@@ -149,6 +170,11 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		state.RegistryInfo = initOptions.RegistryInfo
 		initOptions.ArtifactServer.FillInEmptyValues()
 		state.ArtifactServer = initOptions.ArtifactServer
+
+		err = SetCredentialTimestamps(state, credentialServices, time.Now())
+		if err != nil {
+			return err
+		}
 	} else {
 		// TODO (@austinabro321) validate immediately in `zarf init` if these are set and not equal and error out if so
 		if helpers.IsNotZeroAndNotEqual(initOptions.GitServer, state.GitServer) {
@@ -183,10 +209,24 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		state.StorageClass = initOptions.StorageClass
 	}
 
+	// The verification policy can be replaced on a re-init.
+	if len(initOptions.VerificationPolicy.Rules) > 0 {
+		state.VerificationPolicy = initOptions.VerificationPolicy
+	}
+
 	spinner.Success()
 
+	// The state stays encrypted to its existing recipients unless new recipients are given on a re-init.
+	recipients := initOptions.StateRecipients
+	if len(recipients) == 0 {
+		recipients, err = c.stateRecipients(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Save the state back to K8s
-	if err := c.SaveZarfState(ctx, state); err != nil {
+	if err := c.saveZarfState(ctx, state, recipients); err != nil {
 		return fmt.Errorf("unable to save the Zarf state: %w", err)
 	}
 
@@ -201,8 +241,12 @@ func (c *Cluster) LoadZarfState(ctx context.Context) (*types.ZarfState, error) {
 		return nil, fmt.Errorf("%w: %w", stateErr, err)
 	}
 
+	data, err := decryptData(secret.Data[ZarfStateDataKey])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", stateErr, err)
+	}
 	state := &types.ZarfState{}
-	err = json.Unmarshal(secret.Data[ZarfStateDataKey], &state)
+	err = json.Unmarshal(data, &state)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", stateErr, err)
 	}
@@ -247,27 +291,45 @@ func (c *Cluster) debugPrintZarfState(ctx context.Context, state *types.ZarfStat
 }
 
 // SaveZarfState takes a given state and persists it to the Zarf/zarf-state secret.
+// The state is encrypted when the existing state was encrypted.
 func (c *Cluster) SaveZarfState(ctx context.Context, state *types.ZarfState) error {
+	recipients, err := c.stateRecipients(ctx)
+	if err != nil {
+		return err
+	}
+	return c.saveZarfState(ctx, state, recipients)
+}
+
+func (c *Cluster) saveZarfState(ctx context.Context, state *types.ZarfState, recipients []string) error {
 	c.debugPrintZarfState(ctx, state)
 
 	data, err := json.Marshal(&state)
 	if err != nil {
 		return err
 	}
+	data, err = encryptData(data, recipients)
+	if err != nil {
+		return err
+	}
+	secretData := map[string][]byte{
+		ZarfStateDataKey: data,
+	}
+	if len(recipients) > 0 {
+		secretData[ZarfStateRecipientsDataKey] = []byte(strings.Join(recipients, "\n"))
+	}
 	secret := v1ac.Secret(ZarfStateSecretName, ZarfNamespaceName).
-		WithLabels(map[string]string{
+		WithLabels(CommonLabels(map[string]string{
 			ZarfManagedByLabel: "zarf",
-		}).
+		})).
+		WithAnnotations(CommonAnnotations(nil)).
 		WithType(corev1.SecretTypeOpaque).
-		WithData(map[string][]byte{
-			ZarfStateDataKey: data,
-		})
+		WithData(secretData)
 
 	_, err = c.Clientset.CoreV1().Secrets(*secret.Namespace).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
 	if err != nil {
 		return fmt.Errorf("unable to apply the zarf state secret: %w", err)
 	}
-	return nil
+	return c.saveAgentState(ctx, state, len(recipients) > 0)
 }
 
 // MergeZarfState merges init options for provided services into the provided state to create a new state struct
@@ -323,5 +385,12 @@ func MergeZarfState(oldState *types.ZarfState, initOptions types.ZarfInitOptions
 		newState.AgentTLS = agentTLS
 	}
 
+	// Copy the timestamps so the old state is left untouched
+	newState.CredentialTimestamps = maps.Clone(oldState.CredentialTimestamps)
+	err = SetCredentialTimestamps(&newState, services, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	return &newState, nil
 }
@@ -42,12 +42,21 @@ func TestInitZarfState(t *testing.T) {
 	existingStateData, err := json.Marshal(existingState)
 	require.NoError(t, err)
 
+	ipv6KubernetesService := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "kubernetes",
+		},
+		Spec: corev1.ServiceSpec{IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}},
+	}
+
 	tests := []struct {
 		name        string
 		initOpts    types.ZarfInitOptions
 		nodes       []corev1.Node
 		namespaces  []corev1.Namespace
 		secrets     []corev1.Secret
+		services    []corev1.Service
 		expectedErr string
 	}{
 		{
@@ -160,12 +169,42 @@ func TestInitZarfState(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv6-only cluster without a registry",
+			nodes: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node",
+					},
+				},
+			},
+			services:    []corev1.Service{ipv6KubernetesService},
+			expectedErr: "the internal registry can not be reached by the nodes of an IPv6-only cluster, provide an external registry with --registry-url",
+		},
+		{
+			name: "IPv6-only cluster with an external registry",
+			initOpts: types.ZarfInitOptions{
+				RegistryInfo: types.RegistryInfo{Address: "[fd00::10]:5000"},
+			},
+			nodes: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node",
+					},
+				},
+			},
+			services: []corev1.Service{ipv6KubernetesService},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 			cs := fake.NewClientset()
+			for _, svc := range tt.services {
+				_, err := cs.CoreV1().Services(svc.ObjectMeta.Namespace).Create(ctx, &svc, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
 			for _, node := range tt.nodes {
 				_, err := cs.CoreV1().Nodes().Create(ctx, &node, metav1.CreateOptions{})
 				require.NoError(t, err)
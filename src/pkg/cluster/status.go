@@ -0,0 +1,501 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Names of the Zarf infrastructure health checks.
+const (
+	HealthCheckState          = "state"
+	HealthCheckAgent          = "agent"
+	HealthCheckRegistry       = "registry"
+	HealthCheckGitServer      = "git-server"
+	HealthCheckArtifactServer = "artifact-server"
+)
+
+const (
+	agentDeploymentName = "agent-hook"
+	agentWebhookName    = "zarf"
+	registryDataVolume  = "data"
+	statusHTTPTimeout   = 10 * time.Second
+)
+
+// RegistryUsageWarnRatio is the share of the registry storage capacity above which Zarf warns that the registry is running out of space.
+const RegistryUsageWarnRatio = 0.85
+
+// manifestAcceptHeader lists the manifest media types that are read from the registry to count blobs.
+const manifestAcceptHeader = "application/vnd.oci.image.index.v1+json, application/vnd.oci.image.manifest.v1+json, " +
+	"application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.docker.distribution.manifest.v2+json"
+
+// HealthCheck is the result of checking a single piece of Zarf infrastructure.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// Skipped is true when the checked service is not configured for this cluster.
+	Skipped bool   `json:"skipped,omitempty"`
+	Message string `json:"message"`
+}
+
+// VolumeUsage is the disk usage of a volume mounted into a pod.
+type VolumeUsage struct {
+	UsedBytes     uint64 `json:"usedBytes"`
+	CapacityBytes uint64 `json:"capacityBytes"`
+}
+
+// AvailableBytes returns the number of bytes that are not used.
+func (u VolumeUsage) AvailableBytes() uint64 {
+	if u.UsedBytes >= u.CapacityBytes {
+		return 0
+	}
+	return u.CapacityBytes - u.UsedBytes
+}
+
+// NearlyFull returns true when the used share of the volume is above RegistryUsageWarnRatio.
+func (u VolumeUsage) NearlyFull() bool {
+	if u.CapacityBytes == 0 {
+		return false
+	}
+	return float64(u.UsedBytes)/float64(u.CapacityBytes) >= RegistryUsageWarnRatio
+}
+
+// Status is the health of the Zarf infrastructure in the cluster.
+type Status struct {
+	// Healthy is true when none of the checks failed.
+	Healthy          bool          `json:"healthy"`
+	Checks           []HealthCheck `json:"checks"`
+	DeployedPackages int           `json:"deployedPackages"`
+	// RegistryUsage is the disk usage of the internal registry storage if it could be determined.
+	RegistryUsage *VolumeUsage `json:"registryUsage,omitempty"`
+	// RegistryBlobs is the number of unique blobs referenced by the images in the internal registry if they could be counted.
+	RegistryBlobs *int `json:"registryBlobs,omitempty"`
+}
+
+// GetStatus checks the health of the Zarf infrastructure deployed to the cluster.
+func (c *Cluster) GetStatus(ctx context.Context) (Status, error) {
+	status := Status{
+		Healthy: true,
+	}
+
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	status.DeployedPackages = len(deployedPackages)
+
+	state, stateCheck := c.checkState(ctx)
+	checks := []HealthCheck{stateCheck}
+	if state == nil {
+		for _, name := range []string{HealthCheckAgent, HealthCheckRegistry, HealthCheckGitServer, HealthCheckArtifactServer} {
+			checks = append(checks, HealthCheck{Name: name, Message: "unable to check without a valid Zarf state"})
+		}
+	} else {
+		checks = append(checks, c.checkAgent(ctx))
+		registryCheck, storage := c.checkRegistry(ctx, state.RegistryInfo)
+		status.RegistryUsage = storage.usage
+		status.RegistryBlobs = storage.blobs
+		checks = append(checks, registryCheck)
+		checks = append(checks, c.checkGitServer(ctx, state.GitServer))
+		checks = append(checks, c.checkArtifactServer(ctx, state.ArtifactServer))
+	}
+
+	for _, check := range checks {
+		if !check.Healthy && !check.Skipped {
+			status.Healthy = false
+		}
+	}
+	status.Checks = checks
+	return status, nil
+}
+
+// checkState verifies that the Zarf state can be loaded and has the values Zarf depends on.
+func (c *Cluster) checkState(ctx context.Context) (*types.ZarfState, HealthCheck) {
+	check := HealthCheck{Name: HealthCheckState}
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return nil, check
+	}
+	var errs []error
+	if state.Distro == "" {
+		errs = append(errs, errors.New("distro is not set"))
+	}
+	if state.RegistryInfo.Address == "" {
+		errs = append(errs, errors.New("registry address is not set"))
+	}
+	if _, err := tls.X509KeyPair(state.AgentTLS.Cert, state.AgentTLS.Key); err != nil {
+		errs = append(errs, fmt.Errorf("agent TLS certificate is invalid: %w", err))
+	}
+	if err := errors.Join(errs...); err != nil {
+		check.Message = err.Error()
+		return nil, check
+	}
+	check.Healthy = true
+	check.Message = "valid"
+	return state, check
+}
+
+// checkAgent verifies that the agent webhook is registered and the agent deployment is ready.
+func (c *Cluster) checkAgent(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: HealthCheckAgent}
+	_, err := c.Clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, agentWebhookName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		check.Message = "mutating webhook is not registered"
+		return check
+	}
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	check.Healthy, check.Message = c.deploymentReady(ctx, agentDeploymentName)
+	return check
+}
+
+// registryStorage is the storage usage of the internal registry.
+type registryStorage struct {
+	usage *VolumeUsage
+	blobs *int
+}
+
+// checkRegistry verifies that the registry catalog can be read with the pull credentials.
+func (c *Cluster) checkRegistry(ctx context.Context, registryInfo types.RegistryInfo) (HealthCheck, registryStorage) {
+	check := HealthCheck{Name: HealthCheckRegistry}
+	storage := registryStorage{}
+	if registryInfo.IsInternal() {
+		ready, msg := c.deploymentReady(ctx, ZarfRegistryName)
+		if !ready {
+			check.Message = msg
+			return check, storage
+		}
+		// Disk usage is informational so a failure to get it does not fail the check.
+		storage.usage, _ = c.RegistryStorageUsage(ctx)
+	}
+
+	endpoint, tunnel, err := c.ConnectToZarfRegistryEndpoint(ctx, registryInfo)
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+	if err != nil {
+		check.Message = err.Error()
+		return check, storage
+	}
+	scheme := "https"
+	if tunnel != nil {
+		scheme = "http"
+	}
+	catalogURL := fmt.Sprintf("%s://%s/v2/_catalog", scheme, endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, catalogURL, nil)
+	if err != nil {
+		check.Message = err.Error()
+		return check, storage
+	}
+	req.SetBasicAuth(registryInfo.PullUsername, registryInfo.PullPassword)
+	b, statusCode, err := doStatusRequest(req)
+	if err != nil {
+		check.Message = err.Error()
+		return check, storage
+	}
+	if statusCode != http.StatusOK {
+		check.Message = fmt.Sprintf("catalog request returned status %d", statusCode)
+		return check, storage
+	}
+	catalog := struct {
+		Repositories []string `json:"repositories"`
+	}{}
+	if err := json.Unmarshal(b, &catalog); err != nil {
+		check.Message = fmt.Sprintf("unable to parse catalog: %s", err)
+		return check, storage
+	}
+	check.Healthy = true
+	check.Message = fmt.Sprintf("catalog reachable with %d repositories", len(catalog.Repositories))
+	if registryInfo.IsInternal() {
+		// Like the disk usage the blob count is informational.
+		blobs, err := countRegistryBlobs(ctx, fmt.Sprintf("%s://%s", scheme, endpoint), registryInfo, catalog.Repositories)
+		if err == nil {
+			storage.blobs = &blobs
+		}
+	}
+	return check, storage
+}
+
+// countRegistryBlobs counts the unique config and layer blobs referenced by the tagged manifests of the given repositories.
+func countRegistryBlobs(ctx context.Context, baseURL string, registryInfo types.RegistryInfo, repositories []string) (int, error) {
+	blobs := map[string]bool{}
+	for _, repo := range repositories {
+		b, err := registryGet(ctx, fmt.Sprintf("%s/v2/%s/tags/list", baseURL, repo), registryInfo, "")
+		if err != nil {
+			return 0, err
+		}
+		tags := struct {
+			Tags []string `json:"tags"`
+		}{}
+		if err := json.Unmarshal(b, &tags); err != nil {
+			return 0, fmt.Errorf("unable to parse the tags of %s: %w", repo, err)
+		}
+		for _, tag := range tags.Tags {
+			if err := addManifestBlobs(ctx, baseURL, registryInfo, repo, tag, blobs); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(blobs), nil
+}
+
+// addManifestBlobs adds the blobs of a manifest, or of the manifests of an index, to the set of blobs.
+func addManifestBlobs(ctx context.Context, baseURL string, registryInfo types.RegistryInfo, repo, reference string, blobs map[string]bool) error {
+	b, err := registryGet(ctx, fmt.Sprintf("%s/v2/%s/manifests/%s", baseURL, repo, reference), registryInfo, manifestAcceptHeader)
+	if err != nil {
+		return err
+	}
+	type descriptor struct {
+		Digest string `json:"digest"`
+	}
+	manifest := struct {
+		Config    *descriptor  `json:"config"`
+		Layers    []descriptor `json:"layers"`
+		Manifests []descriptor `json:"manifests"`
+	}{}
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return fmt.Errorf("unable to parse the manifest %s of %s: %w", reference, repo, err)
+	}
+	if manifest.Config != nil {
+		blobs[manifest.Config.Digest] = true
+	}
+	for _, layer := range manifest.Layers {
+		blobs[layer.Digest] = true
+	}
+	for _, child := range manifest.Manifests {
+		if err := addManifestBlobs(ctx, baseURL, registryInfo, repo, child.Digest, blobs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registryGet reads a registry API endpoint with the pull credentials.
+func registryGet(ctx context.Context, url string, registryInfo types.RegistryInfo, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(registryInfo.PullUsername, registryInfo.PullPassword)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	b, statusCode, err := doStatusRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", url, statusCode)
+	}
+	return b, nil
+}
+
+// RegistryStorageUsage reads the usage of the internal registry storage from the kubelet stats of its node.
+func (c *Cluster) RegistryStorageUsage(ctx context.Context) (*VolumeUsage, error) {
+	podList, err := c.Clientset.CoreV1().Pods(ZarfNamespaceName).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=docker-registry",
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		b, err := c.Clientset.CoreV1().RESTClient().Get().
+			AbsPath("/api/v1/nodes", pod.Spec.NodeName, "proxy/stats/summary").
+			DoRaw(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return volumeUsageFromSummary(b, pod.Namespace, pod.Name, registryDataVolume)
+	}
+	return nil, errors.New("no scheduled registry pods found")
+}
+
+// volumeUsageFromSummary extracts the usage of a pod volume from a kubelet stats summary.
+func volumeUsageFromSummary(b []byte, namespace, podName, volumeName string) (*VolumeUsage, error) {
+	summary := struct {
+		Pods []struct {
+			PodRef struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"podRef"`
+			Volumes []struct {
+				Name          string  `json:"name"`
+				UsedBytes     *uint64 `json:"usedBytes"`
+				CapacityBytes *uint64 `json:"capacityBytes"`
+			} `json:"volume"`
+		} `json:"pods"`
+	}{}
+	if err := json.Unmarshal(b, &summary); err != nil {
+		return nil, err
+	}
+	for _, pod := range summary.Pods {
+		if pod.PodRef.Namespace != namespace || pod.PodRef.Name != podName {
+			continue
+		}
+		for _, volume := range pod.Volumes {
+			if volume.Name != volumeName || volume.UsedBytes == nil || volume.CapacityBytes == nil {
+				continue
+			}
+			return &VolumeUsage{UsedBytes: *volume.UsedBytes, CapacityBytes: *volume.CapacityBytes}, nil
+		}
+	}
+	return nil, fmt.Errorf("no stats found for volume %s of pod %s/%s", volumeName, namespace, podName)
+}
+
+// checkGitServer verifies that the git server responds.
+func (c *Cluster) checkGitServer(ctx context.Context, gitServer types.GitServerInfo) HealthCheck {
+	check := HealthCheck{Name: HealthCheckGitServer}
+	if gitServer.Address == "" {
+		check.Skipped = true
+		check.Message = "not configured"
+		return check
+	}
+	if !gitServer.IsInternal() {
+		return checkEndpointReachable(ctx, check, gitServer.Address)
+	}
+	return c.checkInternalGitServer(ctx, check, gitServer)
+}
+
+// checkArtifactServer verifies that the artifact server responds.
+func (c *Cluster) checkArtifactServer(ctx context.Context, artifactServer types.ArtifactServerInfo) HealthCheck {
+	check := HealthCheck{Name: HealthCheckArtifactServer}
+	if artifactServer.Address == "" {
+		check.Skipped = true
+		check.Message = "not configured"
+		return check
+	}
+	if !artifactServer.IsInternal() {
+		return checkEndpointReachable(ctx, check, artifactServer.Address)
+	}
+	exists, err := c.InternalGitServerExists(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	if !exists {
+		check.Skipped = true
+		check.Message = "internal git server is not deployed"
+		return check
+	}
+	check.Healthy = true
+	check.Message = "served by the internal git server"
+	return check
+}
+
+func (c *Cluster) checkInternalGitServer(ctx context.Context, check HealthCheck, gitServer types.GitServerInfo) HealthCheck {
+	exists, err := c.InternalGitServerExists(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	if !exists {
+		check.Skipped = true
+		check.Message = "internal git server is not deployed"
+		return check
+	}
+	tunnel, err := c.NewTunnel(ZarfNamespaceName, SvcResource, ZarfGitServerName, "", 0, ZarfGitServerPort)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	_, err = tunnel.Connect(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	defer tunnel.Close()
+	giteaClient, err := gitea.NewClient(tunnel.HTTPEndpoint(), gitServer.PullUsername, gitServer.PullPassword)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	err = tunnel.Wrap(func() error {
+		_, statusCode, err := giteaClient.DoRequest(ctx, http.MethodGet, "/api/healthz", nil)
+		if err != nil {
+			return err
+		}
+		if statusCode != http.StatusOK {
+			return fmt.Errorf("health check returned status %d", statusCode)
+		}
+		return nil
+	})
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	check.Healthy = true
+	check.Message = "healthy"
+	return check
+}
+
+// checkEndpointReachable verifies that an external server responds without a server error.
+func checkEndpointReachable(ctx context.Context, check HealthCheck, address string) HealthCheck {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	_, statusCode, err := doStatusRequest(req)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	if statusCode >= http.StatusInternalServerError {
+		check.Message = fmt.Sprintf("%s returned status %d", address, statusCode)
+		return check
+	}
+	check.Healthy = true
+	check.Message = fmt.Sprintf("%s is reachable", address)
+	return check
+}
+
+// deploymentReady returns whether all replicas of a deployment in the Zarf namespace are ready.
+func (c *Cluster) deploymentReady(ctx context.Context, name string) (bool, string) {
+	deployment, err := c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err.Error()
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	msg := fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, desired)
+	return deployment.Status.ReadyReplicas >= desired && desired > 0, msg
+}
+
+func doStatusRequest(req *http.Request) (_ []byte, _ int, err error) {
+	client := &http.Client{Timeout: statusHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return b, resp.StatusCode, nil
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestCheckState(t *testing.T) {
+	t.Parallel()
+
+	agentTLS, err := pki.GeneratePKI("example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		state         *types.ZarfState
+		expectHealthy bool
+		expectMessage string
+	}{
+		{
+			name:          "missing state",
+			expectMessage: "has Zarf been initiated?",
+		},
+		{
+			name: "valid state",
+			state: &types.ZarfState{
+				Distro:       "k3s",
+				AgentTLS:     agentTLS,
+				RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"},
+			},
+			expectHealthy: true,
+			expectMessage: "valid",
+		},
+		{
+			name: "invalid state",
+			state: &types.ZarfState{
+				AgentTLS: types.GeneratedPKI{Cert: []byte("cert"), Key: []byte("key")},
+			},
+			expectMessage: "registry address is not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			c := &Cluster{Clientset: fake.NewClientset()}
+			if tt.state != nil {
+				b, err := json.Marshal(tt.state)
+				require.NoError(t, err)
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespaceName},
+					Data:       map[string][]byte{ZarfStateDataKey: b},
+				}
+				_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			state, check := c.checkState(ctx)
+			require.Equal(t, HealthCheckState, check.Name)
+			require.Equal(t, tt.expectHealthy, check.Healthy)
+			require.Contains(t, check.Message, tt.expectMessage)
+			require.Equal(t, tt.expectHealthy, state != nil)
+		})
+	}
+}
+
+func TestCheckAgent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	check := c.checkAgent(ctx)
+	require.False(t, check.Healthy)
+	require.Equal(t, "mutating webhook is not registered", check.Message)
+
+	webhook := &admissionv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: agentWebhookName}}
+	_, err := c.Clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Create(ctx, webhook, metav1.CreateOptions{})
+	require.NoError(t, err)
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: agentDeploymentName, Namespace: ZarfNamespaceName},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Create(ctx, deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	check = c.checkAgent(ctx)
+	require.False(t, check.Healthy)
+	require.Equal(t, "1/2 replicas ready", check.Message)
+
+	deployment.Status.ReadyReplicas = 2
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).UpdateStatus(ctx, deployment, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	check = c.checkAgent(ctx)
+	require.True(t, check.Healthy)
+	require.Equal(t, "2/2 replicas ready", check.Message)
+}
+
+func TestGetStatusWithoutState(t *testing.T) {
+	t.Parallel()
+
+	c := &Cluster{Clientset: fake.NewClientset()}
+	status, err := c.GetStatus(context.Background())
+	require.NoError(t, err)
+	require.False(t, status.Healthy)
+	require.Equal(t, 0, status.DeployedPackages)
+	require.Len(t, status.Checks, 5)
+	for _, check := range status.Checks {
+		require.False(t, check.Healthy)
+	}
+}
+
+func TestVolumeUsageFromSummary(t *testing.T) {
+	t.Parallel()
+
+	summary := `{
+  "pods": [
+    {
+      "podRef": {"name": "zarf-docker-registry-abc", "namespace": "zarf"},
+      "volume": [
+        {"name": "config", "usedBytes": 10, "capacityBytes": 100},
+        {"name": "data", "usedBytes": 2048, "capacityBytes": 4096}
+      ]
+    }
+  ]
+}`
+	usage, err := volumeUsageFromSummary([]byte(summary), "zarf", "zarf-docker-registry-abc", "data")
+	require.NoError(t, err)
+	require.Equal(t, VolumeUsage{UsedBytes: 2048, CapacityBytes: 4096}, *usage)
+
+	_, err = volumeUsageFromSummary([]byte(summary), "zarf", "other", "data")
+	require.EqualError(t, err, "no stats found for volume data of pod zarf/other")
+}
+
+func TestVolumeUsageAvailable(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, uint64(2048), VolumeUsage{UsedBytes: 2048, CapacityBytes: 4096}.AvailableBytes())
+	require.Equal(t, uint64(0), VolumeUsage{UsedBytes: 5000, CapacityBytes: 4096}.AvailableBytes())
+	require.False(t, VolumeUsage{UsedBytes: 2048, CapacityBytes: 4096}.NearlyFull())
+	require.True(t, VolumeUsage{UsedBytes: 3900, CapacityBytes: 4096}.NearlyFull())
+	require.False(t, VolumeUsage{}.NearlyFull())
+}
+
+func TestCountRegistryBlobs(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"/v2/library/nginx/tags/list":                 `{"tags": ["1.0.0", "1.0.0-zarf-1234"]}`,
+		"/v2/library/nginx/manifests/1.0.0":           `{"manifests": [{"digest": "sha256:amd64"}, {"digest": "sha256:arm64"}]}`,
+		"/v2/library/nginx/manifests/1.0.0-zarf-1234": `{"manifests": [{"digest": "sha256:amd64"}, {"digest": "sha256:arm64"}]}`,
+		"/v2/library/nginx/manifests/sha256:amd64":    `{"config": {"digest": "sha256:config-amd64"}, "layers": [{"digest": "sha256:base"}, {"digest": "sha256:app-amd64"}]}`,
+		"/v2/library/nginx/manifests/sha256:arm64":    `{"config": {"digest": "sha256:config-arm64"}, "layers": [{"digest": "sha256:base"}, {"digest": "sha256:app-arm64"}]}`,
+		"/v2/library/redis/tags/list":                 `{"tags": ["7"]}`,
+		"/v2/library/redis/manifests/7":               `{"config": {"digest": "sha256:config-redis"}, "layers": [{"digest": "sha256:base"}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "zarf-pull" || password != "pull" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		//nolint: errcheck // ignore
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	registryInfo := types.RegistryInfo{PullUsername: "zarf-pull", PullPassword: "pull"}
+	blobs, err := countRegistryBlobs(context.Background(), srv.URL, registryInfo, []string{"library/nginx", "library/redis"})
+	require.NoError(t, err)
+	require.Equal(t, 6, blobs)
+
+	_, err = countRegistryBlobs(context.Background(), srv.URL, registryInfo, []string{"library/missing"})
+	require.Error(t, err)
+}
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SupportBundleLogTailLines is the number of log lines collected from each container for a support bundle.
+	SupportBundleLogTailLines = 1000
+	// SupportBundleWarningWindow is how far back cluster warning events are collected for a support bundle.
+	SupportBundleWarningWindow = time.Hour
+)
+
+// supportBundlePackage is the summary of a deployed package that is included in a support bundle.
+// The full package definition is omitted as it may contain sensitive variable defaults.
+type supportBundlePackage struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version,omitempty"`
+	CLIVersion string   `json:"cliVersion"`
+	Components []string `json:"components"`
+}
+
+// CollectSupportBundle gathers diagnostic information about the Zarf deployment in the cluster into dir.
+// Collection is best effort, every item that can not be collected is recorded in collection-errors.txt
+// instead of aborting the collection of the remaining items.
+func (c *Cluster) CollectSupportBundle(ctx context.Context, dir string) error {
+	if err := helpers.CreateDirectory(dir, helpers.ReadExecuteAllWriteUser); err != nil {
+		return err
+	}
+
+	collectors := []struct {
+		name string
+		fn   func(context.Context, string) error
+	}{
+		{"zarf state", c.collectSupportState},
+		{"deployed packages", c.collectSupportPackages},
+		{"zarf pods", c.collectSupportPods},
+		{"zarf events", c.collectSupportZarfEvents},
+		{"cluster warnings", c.collectSupportWarnings},
+	}
+	var errs []string
+	for _, collector := range collectors {
+		if err := collector.fn(ctx, dir); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", collector.name, err))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return os.WriteFile(filepath.Join(dir, "collection-errors.txt"), []byte(strings.Join(errs, "\n")+"\n"), helpers.ReadWriteUser)
+}
+
+func (c *Cluster) collectSupportState(ctx context.Context, dir string) error {
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(c.sanitizeZarfState(state), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "zarf-state.json"), b, helpers.ReadWriteUser)
+}
+
+func (c *Cluster) collectSupportPackages(ctx context.Context, dir string) error {
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return err
+	}
+	summaries := []supportBundlePackage{}
+	for _, depPkg := range deployedPackages {
+		summary := supportBundlePackage{
+			Name:       depPkg.Name,
+			Version:    depPkg.Data.Metadata.Version,
+			CLIVersion: depPkg.CLIVersion,
+			Components: []string{},
+		}
+		for _, component := range depPkg.DeployedComponents {
+			summary.Components = append(summary.Components, component.Name)
+		}
+		summaries = append(summaries, summary)
+	}
+	b, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "deployed-packages.json"), b, helpers.ReadWriteUser)
+}
+
+// collectSupportPods records the status and logs of every pod in the Zarf namespace.
+// This covers the agent, registry, gitea and injector pods.
+func (c *Cluster) collectSupportPods(ctx context.Context, dir string) error {
+	podList, err := c.Clientset.CoreV1().Pods(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range podList.Items {
+		podList.Items[i].ManagedFields = nil
+	}
+	b, err := yaml.Marshal(podList.Items)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "zarf-pods.yaml"), b, helpers.ReadWriteUser); err != nil {
+		return err
+	}
+
+	logDir := filepath.Join(dir, "logs")
+	if err := helpers.CreateDirectory(logDir, helpers.ReadExecuteAllWriteUser); err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range podList.Items {
+		for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+			logPath := filepath.Join(logDir, fmt.Sprintf("%s_%s.log", pod.Name, container.Name))
+			if err := c.writeContainerLogs(ctx, pod.Name, container.Name, logPath); err != nil {
+				errs = append(errs, fmt.Errorf("unable to get logs for %s/%s: %w", pod.Name, container.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Cluster) writeContainerLogs(ctx context.Context, podName, containerName, path string) error {
+	tailLines := int64(SupportBundleLogTailLines)
+	req := c.Clientset.CoreV1().Pods(ZarfNamespaceName).GetLogs(podName, &corev1.PodLogOptions{
+		Container: containerName,
+		TailLines: &tailLines,
+	})
+	rc, err := req.Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, rc)
+	return errors.Join(err, f.Close())
+}
+
+func (c *Cluster) collectSupportZarfEvents(ctx context.Context, dir string) error {
+	eventList, err := c.Clientset.CoreV1().Events(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	return writeSupportEvents(filepath.Join(dir, "zarf-events.txt"), eventList.Items, time.Time{})
+}
+
+func (c *Cluster) collectSupportWarnings(ctx context.Context, dir string) error {
+	eventList, err := c.Clientset.CoreV1().Events(corev1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + corev1.EventTypeWarning,
+	})
+	if err != nil {
+		return err
+	}
+	since := time.Now().Add(-SupportBundleWarningWindow)
+	return writeSupportEvents(filepath.Join(dir, "cluster-warnings.txt"), eventList.Items, since)
+}
+
+// writeSupportEvents writes the events that were last seen after since as a table sorted from oldest to newest.
+func writeSupportEvents(path string, events []corev1.Event, since time.Time) error {
+	filtered := []corev1.Event{}
+	for _, event := range events {
+		if eventTime(event).Before(since) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return eventTime(filtered[i]).Before(eventTime(filtered[j]))
+	})
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(f, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for _, event := range filtered {
+		object := fmt.Sprintf("%s/%s/%s", event.InvolvedObject.Namespace, strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", eventTime(event).UTC().Format(time.RFC3339), event.Type, event.Reason, object, strings.TrimSpace(event.Message))
+	}
+	return errors.Join(w.Flush(), f.Close())
+}
+
+// eventTime returns the most recent time an event was observed.
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestCollectSupportBundle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	state := &types.ZarfState{
+		Distro: "k3s",
+		RegistryInfo: types.RegistryInfo{
+			PushPassword: "push-secret",
+			Secret:       "registry-secret",
+		},
+	}
+	b, err := json.Marshal(state)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespaceName},
+		Data:       map[string][]byte{ZarfStateDataKey: b},
+	}
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "injector", Namespace: ZarfNamespaceName},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "injector"}},
+		},
+	}
+	_, err = c.Clientset.CoreV1().Pods(ZarfNamespaceName).Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "injector.1", Namespace: ZarfNamespaceName},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "injector", Namespace: ZarfNamespaceName},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedScheduling",
+		Message:        "0/1 nodes are available",
+		LastTimestamp:  metav1.NewTime(time.Now()),
+	}
+	_, err = c.Clientset.CoreV1().Events(ZarfNamespaceName).Create(ctx, event, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "bundle")
+	err = c.CollectSupportBundle(ctx, dir)
+	require.NoError(t, err)
+
+	b, err = os.ReadFile(filepath.Join(dir, "zarf-state.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "k3s")
+	require.NotContains(t, string(b), "push-secret")
+	require.NotContains(t, string(b), "registry-secret")
+
+	b, err = os.ReadFile(filepath.Join(dir, "zarf-events.txt"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "FailedScheduling")
+
+	require.FileExists(t, filepath.Join(dir, "zarf-pods.yaml"))
+	require.FileExists(t, filepath.Join(dir, "logs", "injector_injector.log"))
+	require.FileExists(t, filepath.Join(dir, "deployed-packages.json"))
+	require.NoFileExists(t, filepath.Join(dir, "collection-errors.txt"))
+}
@@ -6,13 +6,17 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -144,39 +148,42 @@ func (c *Cluster) ConnectTunnelInfo(ctx context.Context, zt TunnelInfo) (*Tunnel
 
 // ConnectToZarfRegistryEndpoint determines if a registry endpoint is in cluster, and if so opens a tunnel to connect to it
 func (c *Cluster) ConnectToZarfRegistryEndpoint(ctx context.Context, registryInfo types.RegistryInfo) (string, *Tunnel, error) {
-	registryEndpoint := registryInfo.Address
-
-	var err error
-	var tunnel *Tunnel
-	if registryInfo.IsInternal() {
-		// Establish a registry tunnel to send the images to the zarf registry
-		if tunnel, err = c.NewTunnel(ZarfNamespaceName, SvcResource, ZarfRegistryName, "", 0, ZarfRegistryPort); err != nil {
-			return "", tunnel, err
-		}
-	} else {
-		serviceList, err := c.Clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
-		if err != nil {
-			return "", nil, err
-		}
-		svc, port, err := serviceInfoFromNodePortURL(serviceList.Items, registryInfo.Address)
-
-		// If this is a service (no error getting svcInfo), create a port-forward tunnel to that resource
-		if err == nil {
-			if tunnel, err = c.NewTunnel(svc.Namespace, SvcResource, svc.Name, "", 0, port); err != nil {
-				return "", tunnel, err
-			}
-		}
+	namespace, name, port, err := c.registryTunnelTarget(ctx, registryInfo)
+	if err != nil {
+		return "", nil, err
+	}
+	if name == "" {
+		return registryInfo.Address, nil, nil
 	}
 
-	if tunnel != nil {
-		_, err = tunnel.Connect(ctx)
-		if err != nil {
-			return "", tunnel, err
-		}
-		registryEndpoint = tunnel.Endpoint()
+	// Establish a registry tunnel to send the images to the zarf registry
+	tunnel, err := c.NewTunnel(namespace, SvcResource, name, "", 0, port)
+	if err != nil {
+		return "", tunnel, err
 	}
+	_, err = tunnel.Connect(ctx)
+	if err != nil {
+		return "", tunnel, err
+	}
+	return tunnel.Endpoint(), tunnel, nil
+}
 
-	return registryEndpoint, tunnel, nil
+// registryTunnelTarget returns the service and port to open a tunnel to for the registry, or an empty name when the
+// registry is reached at its address.
+func (c *Cluster) registryTunnelTarget(ctx context.Context, registryInfo types.RegistryInfo) (string, string, int, error) {
+	if registryInfo.IsInternal() {
+		return ZarfNamespaceName, ZarfRegistryName, ZarfRegistryPort, nil
+	}
+	serviceList, err := c.Clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", "", 0, err
+	}
+	// If this is a service (no error getting svcInfo), create a port-forward tunnel to that resource
+	svc, port, err := serviceInfoFromNodePortURL(serviceList.Items, registryInfo.Address)
+	if err != nil {
+		return "", "", 0, nil
+	}
+	return svc.Namespace, svc.Name, port, nil
 }
 
 // checkForZarfConnectLabel looks in the cluster for a connect name that matches the target
@@ -266,9 +273,9 @@ func serviceInfoFromNodePortURL(services []corev1.Service, nodePortURL string) (
 		}
 	}
 
-	// Match hostname against localhost ip/hostnames
+	// Match hostname against localhost ip/hostnames, the IPv6 loopback address is used in IPv6-only clusters
 	hostname := parsedURL.Hostname()
-	if hostname != helpers.IPV4Localhost && hostname != "localhost" {
+	if ip := net.ParseIP(hostname); hostname != "localhost" && (ip == nil || !ip.IsLoopback()) {
 		return corev1.Service{}, 0, fmt.Errorf("node port services should be on localhost")
 	}
 
@@ -303,11 +310,24 @@ const (
 	SvcResource = "svc"
 )
 
+const (
+	// tunnelPortRangeStart is the first local port of the range that tunnels without a requested local port prefer.
+	tunnelPortRangeStart = 40000
+	// tunnelPortRangeSize is the number of local ports in the preferred range.
+	tunnelPortRangeSize = 10000
+	// tunnelPortCandidates is the number of preferred local ports tried before the host system selects an open port.
+	tunnelPortCandidates = 10
+)
+
+// ErrLocalPortInUse is returned when the local port a tunnel listens on is already in use.
+var ErrLocalPortInUse = errors.New("local port is already in use")
+
 // Tunnel is the main struct that configures and manages port forwarding tunnels to Kubernetes resources.
 type Tunnel struct {
 	clientset    kubernetes.Interface
 	restConfig   *rest.Config
 	out          io.Writer
+	localAddress string
 	localPort    int
 	remotePort   int
 	namespace    string
@@ -317,6 +337,9 @@ type Tunnel struct {
 	stopChan     chan struct{}
 	readyChan    chan struct{}
 	errChan      chan error
+	// done is closed when the port forward of the tunnel stops.
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // NewTunnel will create a new Tunnel struct.
@@ -355,15 +378,20 @@ func (tunnel *Tunnel) Wrap(function func() error) error {
 	}
 }
 
-// Connect will establish a tunnel to the specified target.
+// Connect will establish a tunnel to the specified target. Attempts that fail because the local port is in use are
+// retried, with another local port when none was requested.
 func (tunnel *Tunnel) Connect(ctx context.Context) (string, error) {
+	l := logger.From(ctx)
 	url, err := retry.DoWithData(func() (string, error) {
 		url, err := tunnel.establish(ctx)
 		if err != nil {
 			return "", err
 		}
 		return url, nil
-	}, retry.Context(ctx), retry.Attempts(6))
+	}, retry.Context(ctx), retry.Attempts(6), retry.LastErrorOnly(true), retry.OnRetry(func(n uint, err error) {
+		message.Debugf("Retrying tunnel to %s/%s (attempt %d): %s", tunnel.resourceType, tunnel.resourceName, n+2, err.Error())
+		l.Debug("retrying tunnel", "resourceType", tunnel.resourceType, "resourceName", tunnel.resourceName, "attempt", n+2, "error", err)
+	}))
 	if err != nil {
 		return "", err
 	}
@@ -372,7 +400,11 @@ func (tunnel *Tunnel) Connect(ctx context.Context) (string, error) {
 
 // Endpoint returns the tunnel ip address and port (i.e. for docker registries)
 func (tunnel *Tunnel) Endpoint() string {
-	return fmt.Sprintf("%s:%d", helpers.IPV4Localhost, tunnel.localPort)
+	address := tunnel.localAddress
+	if address == "" {
+		address = helpers.IPV4Localhost
+	}
+	return net.JoinHostPort(address, strconv.Itoa(tunnel.localPort))
 }
 
 // ErrChan returns the tunnel's error channel
@@ -390,9 +422,38 @@ func (tunnel *Tunnel) FullURL() string {
 	return fmt.Sprintf("%s%s", tunnel.HTTPEndpoint(), tunnel.urlSuffix)
 }
 
-// Close disconnects a tunnel connection by closing the StopChan, thereby stopping the goroutine.
+// FullURLWithCredentials returns the full URL of the tunnel with the username and password embedded for basic
+// authentication.
+func (tunnel *Tunnel) FullURLWithCredentials(username, password string) string {
+	return fmt.Sprintf("http://%s@%s%s", url.UserPassword(username, password).String(), tunnel.Endpoint(), tunnel.urlSuffix)
+}
+
+// Close disconnects a tunnel connection by closing the StopChan, thereby stopping the goroutine. Closing a tunnel more
+// than once has no effect.
 func (tunnel *Tunnel) Close() {
-	close(tunnel.stopChan)
+	tunnel.closeOnce.Do(func() {
+		close(tunnel.stopChan)
+	})
+}
+
+// Alive returns whether the tunnel is connected, its port forward has not stopped and its local endpoint accepts
+// connections.
+func (tunnel *Tunnel) Alive() bool {
+	if tunnel.done == nil {
+		return false
+	}
+	select {
+	case <-tunnel.done:
+		return false
+	default:
+	}
+	conn, err := net.DialTimeout("tcp", tunnel.Endpoint(), time.Second)
+	if err != nil {
+		return false
+	}
+	// Ignore the error as the connection was only used to check the endpoint.
+	_ = conn.Close()
+	return true
 }
 
 // establish opens a tunnel to a kubernetes resource, as specified by the provided tunnel struct.
@@ -400,26 +461,32 @@ func (tunnel *Tunnel) establish(ctx context.Context) (string, error) {
 	var err error
 	l := logger.From(ctx)
 
+	// The tunnel listens on a single loopback address so that the rendered endpoint is the one that is listening, also on
+	// IPv6-only hosts.
+	localAddress := localhostAddress()
+
 	// Track this locally as we may need to retry if the tunnel fails.
 	localPort := tunnel.localPort
 
-	// If the local-port is 0, get an available port before continuing. We do this here instead of relying on the
+	// If the local-port is 0, select an available port before continuing. We do this here instead of relying on the
 	// underlying port-forwarder library, because the port-forwarder library does not expose the selected local port in a
 	// machine-readable manner.
 	// Synchronize on the global lock to avoid race conditions with concurrently selecting the same available port,
-	// since there is a brief moment between `GetAvailablePort` and `forwarder.ForwardPorts` where the selected port
+	// since there is a brief moment between selecting the port and `forwarder.ForwardPorts` where the selected port
 	// is available for selection again.
 	if localPort == 0 {
 		message.Debugf("Requested local port is 0. Selecting an open port on host system")
 		l.Debug("requested local port is 0. Selecting an open port on host system")
-		localPort, err = helpers.GetAvailablePort()
+		globalMutex.Lock()
+		defer globalMutex.Unlock()
+		localPort, err = tunnel.selectLocalPort(localAddress)
 		if err != nil {
 			return "", fmt.Errorf("unable to find an available port: %w", err)
 		}
 		message.Debugf("Selected port %d", localPort)
 		l.Debug("selected port", "port", localPort)
-		globalMutex.Lock()
-		defer globalMutex.Unlock()
+	} else if !portAvailable(localAddress, localPort) {
+		return "", fmt.Errorf("%w: %d", ErrLocalPortInUse, localPort)
 	}
 
 	msg := fmt.Sprintf("Opening tunnel %d -> %d for %s/%s in namespace %s",
@@ -469,29 +536,38 @@ func (tunnel *Tunnel) establish(ctx context.Context) (string, error) {
 
 	// Construct a new PortForwarder struct that manages the instructed port forward tunnel.
 	ports := []string{fmt.Sprintf("%d:%d", localPort, tunnel.remotePort)}
-	portforwarder, err := portforward.New(dialer, ports, tunnel.stopChan, tunnel.readyChan, tunnel.out, tunnel.out)
+	portforwarder, err := portforward.NewOnAddresses(dialer, []string{localAddress}, ports, tunnel.stopChan, tunnel.readyChan, tunnel.out, tunnel.out)
 	if err != nil {
 		return "", fmt.Errorf("unable to create the port forward: %w", err)
 	}
 
 	// Open the tunnel in a goroutine so that it is available in the background. Report errors to the main goroutine via
 	// a new channel.
-	errChan := make(chan error)
+	errChan := make(chan error, 1)
+	done := make(chan struct{})
 	go func() {
 		errChan <- portforwarder.ForwardPorts()
+		close(done)
 	}()
 
 	// Wait for an error or the tunnel to be ready.
 	select {
 	case err = <-errChan:
+		// Another process can take the port between selecting it and listening on it, the next attempt selects another
+		// port when none was requested.
+		if isPortConflict(err) {
+			return "", fmt.Errorf("%w: %d", ErrLocalPortInUse, localPort)
+		}
 		return "", fmt.Errorf("unable to start the tunnel: %w", err)
 	case <-portforwarder.Ready:
 		// Store for endpoint output
+		tunnel.localAddress = localAddress
 		tunnel.localPort = localPort
 		url := tunnel.FullURL()
 
 		// Store the error channel to listen for errors
 		tunnel.errChan = errChan
+		tunnel.done = done
 
 		message.Debugf("Creating port forwarding tunnel at %s", url)
 		l.Debug("creating port forwarding tunnel", "url", url)
@@ -499,6 +575,59 @@ func (tunnel *Tunnel) establish(ctx context.Context) (string, error) {
 	}
 }
 
+// selectLocalPort returns the first preferred local port of the tunnel that is available at the address, or an open port
+// selected by the host system when none of them are.
+func (tunnel *Tunnel) selectLocalPort(address string) (int, error) {
+	for _, port := range preferredLocalPorts(tunnel.namespace, tunnel.resourceType, tunnel.resourceName, tunnel.remotePort) {
+		if portAvailable(address, port) {
+			return port, nil
+		}
+	}
+	return helpers.GetAvailablePort()
+}
+
+// preferredLocalPorts returns the local ports that a tunnel to the resource prefers in order. The ports are derived from
+// the resource so that tunnels to the same resource listen on the same local port across runs when it is available.
+func preferredLocalPorts(namespace, resourceType, resourceName string, remotePort int) []int {
+	h := fnv.New32a()
+	// Writes to a hash never return an error.
+	_, _ = fmt.Fprintf(h, "%s/%s/%s:%d", namespace, resourceType, resourceName, remotePort)
+	first := int(h.Sum32() % tunnelPortRangeSize)
+	ports := []int{}
+	for i := range tunnelPortCandidates {
+		ports = append(ports, tunnelPortRangeStart+(first+i)%tunnelPortRangeSize)
+	}
+	return ports
+}
+
+// portAvailable returns whether the port can be listened on at the address.
+func portAvailable(address string, port int) bool {
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	// Ignore the error as the listener was only used to check the port.
+	_ = listener.Close()
+	return true
+}
+
+// isPortConflict returns whether the port forward failed because it could not listen on the local port.
+func isPortConflict(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "unable to listen on any of the requested ports")
+}
+
+// localhostAddress returns the IPv4 loopback address when the host can listen on it and otherwise the IPv6 loopback
+// address.
+func localhostAddress() string {
+	listener, err := net.Listen("tcp4", net.JoinHostPort(helpers.IPV4Localhost, "0"))
+	if err != nil {
+		return types.IPV6Localhost
+	}
+	// Ignore the error as the listener was only used to check the address.
+	_ = listener.Close()
+	return helpers.IPV4Localhost
+}
+
 // getAttachablePodForResource will find a pod that can be port forwarded to the provided resource type and return
 // the name.
 func (tunnel *Tunnel) getAttachablePodForResource(ctx context.Context) (string, error) {
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// TunnelPool keeps one tunnel open per target so that the pushes of a deploy reuse tunnels instead of each opening
+// their own. A cached tunnel that is no longer alive is reconnected when it is requested again.
+type TunnelPool struct {
+	cluster *Cluster
+	// newTunnel opens a tunnel to the target, it is replaced in tests.
+	newTunnel func(ctx context.Context, namespace, resourceType, resourceName string, remotePort int) (*Tunnel, error)
+	mu        sync.Mutex
+	tunnels   map[string]*Tunnel
+}
+
+// NewTunnelPool returns an empty tunnel pool for the cluster. The tunnels of the pool stay open until the pool is closed.
+func (c *Cluster) NewTunnelPool() *TunnelPool {
+	return &TunnelPool{
+		cluster: c,
+		newTunnel: func(ctx context.Context, namespace, resourceType, resourceName string, remotePort int) (*Tunnel, error) {
+			tunnel, err := c.NewTunnel(namespace, resourceType, resourceName, "", 0, remotePort)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := tunnel.Connect(ctx); err != nil {
+				return nil, err
+			}
+			return tunnel, nil
+		},
+		tunnels: map[string]*Tunnel{},
+	}
+}
+
+// Connect returns the cached tunnel to the remote port of the resource if it is alive, and otherwise opens a new one.
+// The tunnel is owned by the pool and must not be closed by the caller, use Discard when it stopped working.
+func (p *TunnelPool) Connect(ctx context.Context, namespace, resourceType, resourceName string, remotePort int) (*Tunnel, error) {
+	l := logger.From(ctx)
+	key := fmt.Sprintf("%s/%s/%s:%d", namespace, resourceType, resourceName, remotePort)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tunnel, ok := p.tunnels[key]; ok {
+		if tunnel.Alive() {
+			l.Debug("reusing tunnel", "target", key, "endpoint", tunnel.Endpoint())
+			return tunnel, nil
+		}
+		message.Debugf("Reconnecting tunnel to %s", key)
+		l.Debug("reconnecting tunnel", "target", key)
+		tunnel.Close()
+		delete(p.tunnels, key)
+	}
+
+	tunnel, err := p.newTunnel(ctx, namespace, resourceType, resourceName, remotePort)
+	if err != nil {
+		return nil, err
+	}
+	p.tunnels[key] = tunnel
+	return tunnel, nil
+}
+
+// ConnectToZarfRegistryEndpoint returns the endpoint of the registry and, when the registry is in the cluster, the tunnel
+// of the pool to it.
+func (p *TunnelPool) ConnectToZarfRegistryEndpoint(ctx context.Context, registryInfo types.RegistryInfo) (string, *Tunnel, error) {
+	namespace, name, port, err := p.cluster.registryTunnelTarget(ctx, registryInfo)
+	if err != nil {
+		return "", nil, err
+	}
+	if name == "" {
+		return registryInfo.Address, nil, nil
+	}
+	tunnel, err := p.Connect(ctx, namespace, SvcResource, name, port)
+	if err != nil {
+		return "", nil, err
+	}
+	return tunnel.Endpoint(), tunnel, nil
+}
+
+// Discard closes the tunnel and removes it from the pool, so that the next request for its target opens a new tunnel.
+// Tunnels are discarded when an operation through them fails, as a tunnel can accept connections while the pod it
+// forwards to is gone.
+func (p *TunnelPool) Discard(tunnel *Tunnel) {
+	if tunnel == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, t := range p.tunnels {
+		if t == tunnel {
+			delete(p.tunnels, key)
+		}
+	}
+	tunnel.Close()
+}
+
+// Close closes all tunnels of the pool.
+func (p *TunnelPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, tunnel := range p.tunnels {
+		tunnel.Close()
+		delete(p.tunnels, key)
+	}
+}
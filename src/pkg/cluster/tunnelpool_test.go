@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// listeningTunnel returns a tunnel whose local endpoint is served by a listener, as if its port forward was running.
+func listeningTunnel(t *testing.T) *Tunnel {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(helpers.IPV4Localhost, "0"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		// Ignore the error as the listener only serves the test.
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	return &Tunnel{
+		localAddress: helpers.IPV4Localhost,
+		localPort:    listener.Addr().(*net.TCPAddr).Port,
+		stopChan:     make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+}
+
+func TestTunnelPool(t *testing.T) {
+	t.Parallel()
+
+	opened := []*Tunnel{}
+	p := (&Cluster{}).NewTunnelPool()
+	p.newTunnel = func(_ context.Context, _, _, _ string, _ int) (*Tunnel, error) {
+		tunnel := listeningTunnel(t)
+		opened = append(opened, tunnel)
+		return tunnel, nil
+	}
+	ctx := context.Background()
+
+	first, err := p.Connect(ctx, ZarfNamespaceName, SvcResource, ZarfRegistryName, ZarfRegistryPort)
+	require.NoError(t, err)
+	reused, err := p.Connect(ctx, ZarfNamespaceName, SvcResource, ZarfRegistryName, ZarfRegistryPort)
+	require.NoError(t, err)
+	require.Same(t, first, reused)
+	other, err := p.Connect(ctx, ZarfNamespaceName, SvcResource, "zarf-gitea-http", 3000)
+	require.NoError(t, err)
+	require.NotSame(t, first, other)
+	require.Len(t, opened, 2)
+
+	// A tunnel whose port forward stopped is reconnected.
+	close(first.done)
+	reconnected, err := p.Connect(ctx, ZarfNamespaceName, SvcResource, ZarfRegistryName, ZarfRegistryPort)
+	require.NoError(t, err)
+	require.NotSame(t, first, reconnected)
+	require.Len(t, opened, 3)
+
+	// A discarded tunnel is closed and replaced on the next request.
+	p.Discard(reconnected)
+	_, ok := <-reconnected.stopChan
+	require.False(t, ok)
+	_, err = p.Connect(ctx, ZarfNamespaceName, SvcResource, ZarfRegistryName, ZarfRegistryPort)
+	require.NoError(t, err)
+	require.Len(t, opened, 4)
+
+	p.Close()
+	require.Empty(t, p.tunnels)
+	for _, tunnel := range opened {
+		_, ok := <-tunnel.stopChan
+		require.False(t, ok)
+	}
+}
+
+func TestTunnelAlive(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, (&Tunnel{}).Alive())
+
+	tunnel := listeningTunnel(t)
+	require.True(t, tunnel.Alive())
+	close(tunnel.done)
+	require.False(t, tunnel.Alive())
+}
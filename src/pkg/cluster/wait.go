@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// WaitForResource waits for resources of any API version and kind, including custom resources, to exist and meet the
+// condition. The name may be a label selector and the condition is a status condition type such as Ready, a
+// condition type and status such as Ready=False, or a JSONPath and value such as {.status.phase}=Ready.
+func (c *Cluster) WaitForResource(ctx context.Context, apiVersion, kind, name, namespace, condition string) error {
+	dynamicClient, err := dynamic.NewForConfig(c.RestConfig)
+	if err != nil {
+		return err
+	}
+	httpClient, err := rest.HTTPClientFor(c.RestConfig)
+	if err != nil {
+		return err
+	}
+	// The dynamic mapper rediscovers the API when a kind is not found, so custom resources can be waited for while
+	// the operator that defines them is still being installed.
+	restMapper, err := apiutil.NewDynamicRESTMapper(c.RestConfig, httpClient)
+	if err != nil {
+		return err
+	}
+	return waitForResource(ctx, dynamicClient, restMapper, apiVersion, kind, name, namespace, condition)
+}
+
+func waitForResource(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, apiVersion, kind, name, namespace, condition string) error {
+	l := logger.From(ctx)
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("invalid api version %s: %w", apiVersion, err)
+	}
+	matches, err := conditionMatcher(condition)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	err = wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		objs, err := getResources(ctx, client, mapper, gv, kind, name, namespace)
+		if err != nil {
+			lastErr = err
+			l.Debug("waiting for resource", "apiVersion", apiVersion, "kind", kind, "name", name, "error", err)
+			return false, nil
+		}
+		for _, obj := range objs {
+			if !matches(obj) {
+				lastErr = fmt.Errorf("%s %s does not meet the condition %s", kind, obj.GetName(), condition)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil && lastErr != nil && ctx.Err() != nil {
+		return fmt.Errorf("wait timed out: %w", lastErr)
+	}
+	return err
+}
+
+// getResources returns the resources with the name or label selector, it errors when none exist.
+func getResources(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, gv schema.GroupVersion, kind, name, namespace string) ([]unstructured.Unstructured, error) {
+	gvr, namespaced, err := resourceFor(mapper, gv, kind)
+	if err != nil {
+		return nil, err
+	}
+	var resourceClient dynamic.ResourceInterface = client.Resource(gvr)
+	if namespaced {
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		resourceClient = client.Resource(gvr).Namespace(namespace)
+	}
+	if name != "" && !strings.ContainsRune(name, '=') {
+		obj, err := resourceClient.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []unstructured.Unstructured{*obj}, nil
+	}
+	list, err := resourceClient.List(ctx, metav1.ListOptions{LabelSelector: name})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no %s resources found", gvr.Resource)
+	}
+	return list.Items, nil
+}
+
+// resourceFor returns the resource of the kind and whether it is namespaced, the kind may also be the resource name.
+func resourceFor(mapper meta.RESTMapper, gv schema.GroupVersion, kind string) (schema.GroupVersionResource, bool, error) {
+	mapping, err := mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if meta.IsNoMatchError(err) {
+		gvk, resourceErr := mapper.KindFor(gv.WithResource(strings.ToLower(kind)))
+		if resourceErr != nil {
+			return schema.GroupVersionResource{}, false, err
+		}
+		mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
+	}
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// conditionMatcher returns a function that reports whether a resource meets the condition.
+func conditionMatcher(condition string) (func(unstructured.Unstructured) bool, error) {
+	switch condition {
+	case "", "exist", "exists":
+		return func(unstructured.Unstructured) bool { return true }, nil
+	}
+
+	// JSONPath conditions are written as {.status.phase}=Ready or .status.phase=Ready.
+	if strings.HasPrefix(condition, "{") || strings.HasPrefix(condition, ".") {
+		idx := strings.LastIndex(condition, "=")
+		if idx < 1 {
+			return nil, fmt.Errorf("jsonpath condition %s must be in the form {.path}=value", condition)
+		}
+		path, expected := condition[:idx], condition[idx+1:]
+		if !strings.HasPrefix(path, "{") {
+			path = fmt.Sprintf("{%s}", path)
+		}
+		j := jsonpath.New("condition").AllowMissingKeys(true)
+		if err := j.Parse(path); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath condition %s: %w", condition, err)
+		}
+		return func(obj unstructured.Unstructured) bool {
+			results, err := j.FindResults(obj.Object)
+			if err != nil || len(results) != 1 || len(results[0]) != 1 {
+				return false
+			}
+			return fmt.Sprint(results[0][0].Interface()) == expected
+		}, nil
+	}
+
+	// Status conditions are written as Ready or Ready=False.
+	conditionType, status, ok := strings.Cut(condition, "=")
+	if !ok {
+		status = string(metav1.ConditionTrue)
+	}
+	return func(obj unstructured.Unstructured) bool {
+		conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		if err != nil {
+			return false
+		}
+		for _, c := range conditions {
+			c, ok := c.(map[string]interface{})
+			if !ok || !strings.EqualFold(fmt.Sprint(c["type"]), conditionType) {
+				continue
+			}
+			return strings.EqualFold(fmt.Sprint(c["status"]), status)
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestConditionMatcher(t *testing.T) {
+	t.Parallel()
+
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":         "Ready",
+			"readyReplicas": int64(3),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Degraded", "status": "False"},
+			},
+		},
+	}}
+	tests := []struct {
+		name      string
+		condition string
+		expected  bool
+	}{
+		{name: "exists", condition: "", expected: true},
+		{name: "condition", condition: "ready", expected: true},
+		{name: "condition status", condition: "Degraded=False", expected: true},
+		{name: "condition not true", condition: "Degraded", expected: false},
+		{name: "missing condition", condition: "Available", expected: false},
+		{name: "jsonpath", condition: ".status.phase=Ready", expected: true},
+		{name: "braced jsonpath", condition: "{.status.readyReplicas}=3", expected: true},
+		{name: "jsonpath filter", condition: `{.status.conditions[?(@.type=="Ready")].status}=True`, expected: true},
+		{name: "jsonpath mismatch", condition: ".status.phase=Pending", expected: false},
+		{name: "missing jsonpath", condition: ".status.missing=Ready", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matches, err := conditionMatcher(tt.condition)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, matches(obj))
+		})
+	}
+
+	_, err := conditionMatcher(".status.phase")
+	require.EqualError(t, err, "jsonpath condition .status.phase must be in the form {.path}=value")
+}
+
+func TestWaitForResource(t *testing.T) {
+	t.Parallel()
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Database"}
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "databases"}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+
+	db := &unstructured.Unstructured{}
+	db.SetGroupVersionKind(gvk)
+	db.SetName("db")
+	db.SetNamespace("app")
+	db.SetLabels(map[string]string{"app": "db"})
+	require.NoError(t, unstructured.SetNestedField(db.Object, "Ready", "status", "phase"))
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: "DatabaseList"}, db)
+
+	tests := []struct {
+		name        string
+		kind        string
+		id          string
+		condition   string
+		expectedErr string
+	}{
+		{name: "kind and name", kind: "Database", id: "db", condition: ".status.phase=Ready"},
+		{name: "resource and selector", kind: "databases", id: "app=db", condition: "{.status.phase}=Ready"},
+		{name: "condition not met", kind: "Database", id: "db", condition: ".status.phase=Failed", expectedErr: "wait timed out: Database db does not meet the condition .status.phase=Failed"},
+		{name: "not found", kind: "Database", id: "other", expectedErr: `wait timed out: databases.example.com "other" not found`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(testutil.TestContext(t), 100*time.Millisecond)
+			defer cancel()
+			err := waitForResource(ctx, client, mapper, "example.com/v1", tt.kind, tt.id, "app", tt.condition)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
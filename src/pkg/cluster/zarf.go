@@ -43,9 +43,14 @@ func (c *Cluster) GetDeployedZarfPackages(ctx context.Context) ([]types.Deployed
 		if !strings.HasPrefix(secret.Name, config.ZarfPackagePrefix) {
 			continue
 		}
+		data, err := decryptData(secret.Data["data"])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to read the secret %s/%s: %w", secret.Namespace, secret.Name, err))
+			continue
+		}
 		var deployedPackage types.DeployedPackage
 		// Process the k8s secret into our internal structs
-		err := json.Unmarshal(secret.Data["data"], &deployedPackage)
+		err = json.Unmarshal(data, &deployedPackage)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("unable to unmarshal the secret %s/%s", secret.Namespace, secret.Name))
 			continue
@@ -67,8 +72,12 @@ func (c *Cluster) GetDeployedPackage(ctx context.Context, packageName string) (*
 	if err != nil {
 		return nil, err
 	}
+	data, err := decryptData(secret.Data["data"])
+	if err != nil {
+		return nil, err
+	}
 	deployedPackage := &types.DeployedPackage{}
-	err = json.Unmarshal(secret.Data["data"], deployedPackage)
+	err = json.Unmarshal(data, deployedPackage)
 	if err != nil {
 		return nil, err
 	}
@@ -82,11 +91,15 @@ func (c *Cluster) UpdateDeployedPackage(ctx context.Context, depPkg types.Deploy
 	if err != nil {
 		return err
 	}
+	packageSecretData, err = c.encryptPackageData(ctx, packageSecretData)
+	if err != nil {
+		return err
+	}
 	packageSecret := v1ac.Secret(secretName, ZarfNamespaceName).
-		WithLabels(map[string]string{
+		WithLabels(CommonLabels(map[string]string{
 			ZarfManagedByLabel:   "zarf",
 			ZarfPackageInfoLabel: depPkg.Name,
-		}).WithData(map[string][]byte{
+		})).WithAnnotations(CommonAnnotations(nil)).WithData(map[string][]byte{
 		"data": packageSecretData,
 	}).WithType(corev1.SecretTypeOpaque)
 	_, err = c.Clientset.CoreV1().Secrets(*packageSecret.Namespace).Apply(ctx, packageSecret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
@@ -103,6 +116,16 @@ func (c *Cluster) DeleteDeployedPackage(ctx context.Context, packageName string)
 	if err != nil {
 		return err
 	}
+	history, err := c.GetDeployedPackageHistory(ctx, packageName)
+	if err != nil {
+		return err
+	}
+	for _, depPkg := range history {
+		err := c.DeleteDeployedPackageHistory(ctx, packageName, depPkg.Generation)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -156,7 +179,8 @@ func (c *Cluster) StripZarfLabelsAndSecretsFromNamespaces(ctx context.Context) {
 }
 
 // RecordPackageDeployment saves metadata about a package that has been deployed to the cluster.
-func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.ZarfPackage, components []types.DeployedComponent) (*types.DeployedPackage, error) {
+// The variables are the non-sensitive variable values of the deployment, sensitive values must not be passed as they are persisted.
+func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.ZarfPackage, components []types.DeployedComponent, variables map[string]string) (*types.DeployedPackage, error) {
 	packageName := pkg.Metadata.Name
 
 	// TODO: This is done for backwards compatibility and could be removed in the future.
@@ -169,25 +193,62 @@ func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.Zarf
 		}
 	}
 
+	// Keep the record of the previous version when a different version of the package is deployed
+	generation := 0
+	var scopedCredentials *types.ScopedCredentials
+	var succeededAt *time.Time
+	var deployDuration time.Duration
+	failedDeployments := 0
+	existing, err := c.GetDeployedPackage(ctx, packageName)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	if existing != nil {
+		generation = existing.Generation
+		scopedCredentials = existing.ScopedCredentials
+		// Outcomes are kept across versions so that failures of an upgrade are counted.
+		succeededAt = existing.SucceededAt
+		deployDuration = existing.DeployDuration
+		failedDeployments = existing.FailedDeployments
+		if existing.Data.Metadata.Version != pkg.Metadata.Version {
+			if err := c.recordPackageHistory(ctx, *existing); err != nil {
+				return nil, err
+			}
+			generation++
+		}
+	}
+
+	deployedAt := time.Now().UTC()
 	deployedPackage := &types.DeployedPackage{
 		Name:               packageName,
 		CLIVersion:         config.CLIVersion,
 		Data:               pkg,
 		DeployedComponents: components,
 		ConnectStrings:     connectStrings,
+		Variables:          variables,
+		Generation:         generation,
+		ScopedCredentials:  scopedCredentials,
+		DeployedAt:         &deployedAt,
+		SucceededAt:        succeededAt,
+		DeployDuration:     deployDuration,
+		FailedDeployments:  failedDeployments,
 	}
 
 	packageData, err := json.Marshal(deployedPackage)
 	if err != nil {
 		return nil, err
 	}
+	packageData, err = c.encryptPackageData(ctx, packageData)
+	if err != nil {
+		return nil, err
+	}
 
 	packageSecretName := fmt.Sprintf("%s%s", config.ZarfPackagePrefix, packageName)
 	deployedPackageSecret := v1ac.Secret(packageSecretName, ZarfNamespaceName).
-		WithLabels(map[string]string{
+		WithLabels(CommonLabels(map[string]string{
 			ZarfManagedByLabel:   "zarf",
 			ZarfPackageInfoLabel: packageName,
-		}).WithType(corev1.SecretTypeOpaque).
+		})).WithAnnotations(CommonAnnotations(nil)).WithType(corev1.SecretTypeOpaque).
 		WithData(map[string][]byte{
 			"data": packageData,
 		})
@@ -195,12 +256,37 @@ func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.Zarf
 	if err != nil {
 		return nil, fmt.Errorf("failed to record package deployment in secret '%s': %w", *deployedPackageSecret.Name, err)
 	}
-	if err := json.Unmarshal(updatedSecret.Data["data"], &deployedPackage); err != nil {
+	updatedData, err := decryptData(updatedSecret.Data["data"])
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(updatedData, &deployedPackage); err != nil {
 		return nil, err
 	}
 	return deployedPackage, nil
 }
 
+// RecordPackageDeployOutcome records the outcome of a deployment of a package that took the given duration. A success
+// records when it completed and how long it took, a failure increments the failed deployments of the package.
+// Nothing is recorded for a package that has not been recorded as deployed.
+func (c *Cluster) RecordPackageDeployOutcome(ctx context.Context, packageName string, duration time.Duration, deployErr error) error {
+	depPkg, err := c.GetDeployedPackage(ctx, packageName)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if deployErr != nil {
+		depPkg.FailedDeployments++
+	} else {
+		succeededAt := time.Now().UTC()
+		depPkg.SucceededAt = &succeededAt
+		depPkg.DeployDuration = duration
+	}
+	return c.UpdateDeployedPackage(ctx, *depPkg)
+}
+
 // EnableRegHPAScaleDown enables the HPA scale down for the Zarf Registry.
 func (c *Cluster) EnableRegHPAScaleDown(ctx context.Context) error {
 	hpa, err := c.Clientset.AutoscalingV2().HorizontalPodAutoscalers(ZarfNamespaceName).Get(ctx, "zarf-docker-registry", metav1.GetOptions{})
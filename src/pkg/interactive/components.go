@@ -11,35 +11,42 @@ import (
 	"github.com/pterm/pterm"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/message"
-	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
-// SelectOptionalComponent prompts to confirm optional components
-func SelectOptionalComponent(component v1alpha1.ZarfComponent) (bool, error) {
+// SelectOptionalComponents prompts to select the optional components to deploy with a checkbox list, the default
+// components are selected to begin with. It returns the names of the selected components.
+func SelectOptionalComponents(components []v1alpha1.ZarfComponent) ([]string, error) {
 	message.HorizontalRule()
 
-	displayComponent := component
-	displayComponent.Description = ""
-	err := utils.ColorPrintYAML(displayComponent, nil, false)
-	if err != nil {
-		return false, err
-	}
-	if component.Description != "" {
-		// TODO (@austinabro321) once we move interactiveness to CLI level we should replace this with logger.Info
-		message.Question(component.Description)
+	options := []string{}
+	defaults := []string{}
+	descriptions := map[string]string{}
+	for _, component := range components {
+		options = append(options, component.Name)
+		descriptions[component.Name] = component.Description
+		if component.Default {
+			defaults = append(defaults, component.Name)
+		}
 	}
 
-	prompt := &survey.Confirm{
-		Message: fmt.Sprintf("Deploy the %s component?", component.Name),
-		Default: component.Default,
+	prompt := &survey.MultiSelect{
+		Message:  "Select the optional components to deploy:",
+		Options:  options,
+		Default:  defaults,
+		PageSize: 15,
+		Description: func(value string, _ int) string {
+			return descriptions[value]
+		},
 	}
 
-	var confirm bool
-	err = survey.AskOne(prompt, &confirm)
+	pterm.Println()
+
+	selected := []string{}
+	err := survey.AskOne(prompt, &selected)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return confirm, nil
+	return selected, nil
 }
 
 // SelectChoiceGroup prompts to select component groups
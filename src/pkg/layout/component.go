@@ -10,6 +10,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/mholt/archiver/v3"
@@ -28,6 +29,8 @@ type ComponentPaths struct {
 	Repos          string
 	Manifests      string
 	DataInjections string
+	Mirrors        string
+	Snapshots      string
 }
 
 // Components contains paths for components.
@@ -35,6 +38,8 @@ type Components struct {
 	Base     string
 	Dirs     map[string]*ComponentPaths
 	Tarballs map[string]string
+	// mu guards Dirs and Tarballs, components are created concurrently during package create.
+	mu sync.Mutex
 }
 
 // ErrNotLoaded is returned when a path is not loaded.
@@ -44,16 +49,19 @@ var ErrNotLoaded = fmt.Errorf("not loaded")
 func (c *Components) Archive(ctx context.Context, component v1alpha1.ZarfComponent, cleanupTemp bool) error {
 	l := logger.From(ctx)
 	name := component.Name
-	if _, ok := c.Dirs[name]; !ok {
+	c.mu.Lock()
+	dirs, ok := c.Dirs[name]
+	c.mu.Unlock()
+	if !ok {
 		return &fs.PathError{
 			Op:   "check dir map for",
 			Path: name,
 			Err:  ErrNotLoaded,
 		}
 	}
-	base := c.Dirs[name].Base
+	base := dirs.Base
 	if cleanupTemp {
-		err := os.RemoveAll(c.Dirs[name].Temp)
+		err := os.RemoveAll(dirs.Temp)
 		if err != nil {
 			return err
 		}
@@ -70,24 +78,30 @@ func (c *Components) Archive(ctx context.Context, component v1alpha1.ZarfCompone
 		if err := helpers.CreateReproducibleTarballFromDir(base, name, tb); err != nil {
 			return err
 		}
+		c.mu.Lock()
 		if c.Tarballs == nil {
 			c.Tarballs = make(map[string]string)
 		}
 		c.Tarballs[name] = tb
+		c.mu.Unlock()
 	} else {
 		// TODO(mkcp): Remove message on logger release
 		message.Debugf("Component %q is empty, skipping archiving", name)
 		l.Debug("component is empty, skipping archiving", "name", name)
 	}
 
+	c.mu.Lock()
 	delete(c.Dirs, name)
+	c.mu.Unlock()
 	return os.RemoveAll(base)
 }
 
 // Unarchive unarchives a component.
 func (c *Components) Unarchive(component v1alpha1.ZarfComponent) error {
 	name := component.Name
+	c.mu.Lock()
 	tb, ok := c.Tarballs[name]
+	c.mu.Unlock()
 	if !ok {
 		return &fs.PathError{
 			Op:   "check tarball map for",
@@ -112,8 +126,9 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) error {
 	}
 	if len(component.Charts) > 0 {
 		cs.Charts = filepath.Join(cs.Base, ChartsDir)
+		cs.Snapshots = filepath.Join(cs.Base, SnapshotsDir)
 		for _, chart := range component.Charts {
-			if len(chart.ValuesFiles) > 0 {
+			if len(chart.PackagedValuesFiles()) > 0 {
 				cs.Values = filepath.Join(cs.Base, ValuesDir)
 				break
 			}
@@ -128,11 +143,16 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) error {
 	if len(component.DataInjections) > 0 {
 		cs.DataInjections = filepath.Join(cs.Base, DataInjectionsDir)
 	}
+	if len(component.PackageMirrors) > 0 {
+		cs.Mirrors = filepath.Join(cs.Base, MirrorsDir)
+	}
+	c.mu.Lock()
 	if c.Dirs == nil {
 		c.Dirs = make(map[string]*ComponentPaths)
 	}
 	c.Dirs[name] = cs
 	delete(c.Tarballs, name)
+	c.mu.Unlock()
 
 	// if the component is already unarchived, skip
 	if !helpers.InvalidPath(cs.Base) {
@@ -151,7 +171,9 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) error {
 func (c *Components) Create(component v1alpha1.ZarfComponent) (*ComponentPaths, error) {
 	name := component.Name
 
+	c.mu.Lock()
 	_, ok := c.Tarballs[name]
+	c.mu.Unlock()
 	if ok {
 		return nil, &fs.PathError{
 			Op:   "create component paths",
@@ -193,7 +215,7 @@ func (c *Components) Create(component v1alpha1.ZarfComponent) (*ComponentPaths,
 		}
 		for _, chart := range component.Charts {
 			cp.Values = filepath.Join(base, ValuesDir)
-			if len(chart.ValuesFiles) > 0 {
+			if len(chart.PackagedValuesFiles()) > 0 {
 				if err := helpers.CreateDirectory(cp.Values, helpers.ReadWriteExecuteUser); err != nil {
 					return nil, err
 				}
@@ -223,6 +245,15 @@ func (c *Components) Create(component v1alpha1.ZarfComponent) (*ComponentPaths,
 		}
 	}
 
+	if len(component.PackageMirrors) > 0 {
+		cp.Mirrors = filepath.Join(base, MirrorsDir)
+		if err := helpers.CreateDirectory(cp.Mirrors, helpers.ReadWriteExecuteUser); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.Dirs == nil {
 		c.Dirs = make(map[string]*ComponentPaths)
 	}
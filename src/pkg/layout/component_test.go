@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestComponentsCreateConcurrently(t *testing.T) {
+	t.Parallel()
+
+	c := &Components{Base: t.TempDir()}
+	g := errgroup.Group{}
+	for i := range 50 {
+		g.Go(func() error {
+			component := v1alpha1.ZarfComponent{
+				Name:  fmt.Sprintf("component-%d", i),
+				Files: []v1alpha1.ZarfFile{{Source: "file.txt", Target: "file.txt"}},
+			}
+			if _, err := c.Create(component); err != nil {
+				return err
+			}
+			// Creating the paths of a component again, as the SBOM of a component does, replaces them.
+			_, err := c.Create(component)
+			return err
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.Len(t, c.Dirs, 50)
+}
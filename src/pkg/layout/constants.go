@@ -15,10 +15,21 @@ const (
 	ManifestsDir      = "manifests"
 	DataInjectionsDir = "data"
 	ValuesDir         = "values"
+	MirrorsDir        = "mirrors"
+	SnapshotsDir      = "snapshots"
 
 	ZarfYAML  = "zarf.yaml"
 	Signature = "zarf.yaml.sig"
-	Checksums = "checksums.txt"
+	// ChecksumsSignature is the detached signature of checksums.txt, covering all of the package content.
+	ChecksumsSignature = "checksums.txt.sig"
+	// SignatureTimestamp and ChecksumsSignatureTimestamp are the RFC3161 timestamps of the signatures.
+	SignatureTimestamp          = "zarf.yaml.sig.timestamp"
+	ChecksumsSignatureTimestamp = "checksums.txt.sig.timestamp"
+	Checksums                   = "checksums.txt"
+
+	// SignatureBundle and ChecksumsSignatureBundle hold the certificate and transparency log entry of keyless signatures.
+	SignatureBundle          = "zarf.yaml.sig.bundle"
+	ChecksumsSignatureBundle = "checksums.txt.sig.bundle"
 
 	ImagesDir     = "images"
 	ComponentsDir = "components"
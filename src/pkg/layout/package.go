@@ -32,7 +32,14 @@ type PackagePaths struct {
 	ZarfYAML  string
 	Checksums string
 
-	Signature string
+	Signature          string
+	ChecksumsSignature string
+
+	SignatureTimestamp          string
+	ChecksumsSignatureTimestamp string
+
+	SignatureBundle          string
+	ChecksumsSignatureBundle string
 
 	Components Components
 	SBOMs      SBOMs
@@ -171,14 +178,24 @@ func (pp *PackagePaths) IsLegacyLayout() bool {
 	return pp.isLegacyLayout
 }
 
-// SignPackage signs the zarf.yaml in a Zarf package.
-func (pp *PackagePaths) SignPackage(signingKeyPath, signingKeyPassword string, isInteractive bool) error {
+// SignPackage signs the zarf.yaml in a Zarf package, and the checksums.txt if the package already has a checksums signature.
+// When tsaURL is set the signatures are timestamped by the RFC3161 timestamp authority.
+func (pp *PackagePaths) SignPackage(signingKeyPath, signingKeyPassword, tsaURL string, isInteractive bool) error {
 	if signingKeyPath == "" {
 		return nil
 	}
 
 	pp.Signature = filepath.Join(pp.Base, Signature)
 
+	if err := pp.removeSignatureMetadata(); err != nil {
+		return err
+	}
+	signatureTimestamp, checksumsSignatureTimestamp := "", ""
+	if tsaURL != "" {
+		signatureTimestamp = filepath.Join(pp.Base, SignatureTimestamp)
+		checksumsSignatureTimestamp = filepath.Join(pp.Base, ChecksumsSignatureTimestamp)
+	}
+
 	passwordFunc := func(_ bool) ([]byte, error) {
 		if signingKeyPassword != "" {
 			return []byte(signingKeyPassword), nil
@@ -188,11 +205,56 @@ func (pp *PackagePaths) SignPackage(signingKeyPath, signingKeyPassword string, i
 		}
 		return interactive.PromptSigPassword()
 	}
-	_, err := utils.CosignSignBlob(pp.ZarfYAML, pp.Signature, signingKeyPath, passwordFunc)
+	_, err := utils.CosignSignBlob(pp.ZarfYAML, pp.Signature, signingKeyPath, passwordFunc, tsaURL, signatureTimestamp)
 	if err != nil {
 		return fmt.Errorf("unable to sign the package: %w", err)
 	}
+	pp.SignatureTimestamp = signatureTimestamp
+	if pp.ChecksumsSignature != "" {
+		_, err := utils.CosignSignBlob(pp.Checksums, pp.ChecksumsSignature, signingKeyPath, passwordFunc, tsaURL, checksumsSignatureTimestamp)
+		if err != nil {
+			return fmt.Errorf("unable to sign the package checksums: %w", err)
+		}
+		pp.ChecksumsSignatureTimestamp = checksumsSignatureTimestamp
+	}
+
+	return nil
+}
+
+// SignPackageKeyless signs the zarf.yaml in a Zarf package, and the checksums.txt if the package already has a checksums
+// signature, with a Fulcio certificate for an OIDC identity. The certificates and Rekor transparency log entries are
+// stored in bundles next to the signatures so that the package can be verified without access to Rekor.
+func (pp *PackagePaths) SignPackageKeyless(opts utils.KeylessOptions) error {
+	pp.Signature = filepath.Join(pp.Base, Signature)
+	if err := pp.removeSignatureMetadata(); err != nil {
+		return err
+	}
+
+	signatureBundle := filepath.Join(pp.Base, SignatureBundle)
+	if err := utils.CosignSignBlobKeyless(pp.ZarfYAML, pp.Signature, signatureBundle, opts); err != nil {
+		return fmt.Errorf("unable to sign the package keyless: %w", err)
+	}
+	pp.SignatureBundle = signatureBundle
+	if pp.ChecksumsSignature != "" {
+		checksumsSignatureBundle := filepath.Join(pp.Base, ChecksumsSignatureBundle)
+		if err := utils.CosignSignBlobKeyless(pp.Checksums, pp.ChecksumsSignature, checksumsSignatureBundle, opts); err != nil {
+			return fmt.Errorf("unable to sign the package checksums keyless: %w", err)
+		}
+		pp.ChecksumsSignatureBundle = checksumsSignatureBundle
+	}
+	return nil
+}
 
+// removeSignatureMetadata removes the timestamps and bundles of previous signatures as they no longer match new signatures.
+func (pp *PackagePaths) removeSignatureMetadata() error {
+	for _, path := range []*string{&pp.SignatureTimestamp, &pp.ChecksumsSignatureTimestamp, &pp.SignatureBundle, &pp.ChecksumsSignatureBundle} {
+		if *path != "" {
+			if err := os.Remove(*path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			*path = ""
+		}
+	}
 	return nil
 }
 
@@ -205,7 +267,7 @@ func (pp *PackagePaths) GenerateChecksums() (string, error) {
 	var checksumsData = []string{}
 
 	for rel, abs := range pp.Files() {
-		if rel == ZarfYAML || rel == Checksums {
+		if rel == ZarfYAML || rel == Checksums || rel == ChecksumsSignature || rel == SignatureTimestamp || rel == ChecksumsSignatureTimestamp || rel == SignatureBundle || rel == ChecksumsSignatureBundle {
 			continue
 		}
 
@@ -305,6 +367,16 @@ func (pp *PackagePaths) SetFromPaths(paths []string) {
 			pp.ZarfYAML = filepath.Join(pp.Base, path)
 		case path == Signature:
 			pp.Signature = filepath.Join(pp.Base, path)
+		case path == ChecksumsSignature:
+			pp.ChecksumsSignature = filepath.Join(pp.Base, path)
+		case path == SignatureTimestamp:
+			pp.SignatureTimestamp = filepath.Join(pp.Base, path)
+		case path == ChecksumsSignatureTimestamp:
+			pp.ChecksumsSignatureTimestamp = filepath.Join(pp.Base, path)
+		case path == SignatureBundle:
+			pp.SignatureBundle = filepath.Join(pp.Base, path)
+		case path == ChecksumsSignatureBundle:
+			pp.ChecksumsSignatureBundle = filepath.Join(pp.Base, path)
 		case path == Checksums:
 			pp.Checksums = filepath.Join(pp.Base, path)
 		case path == SBOMTar:
@@ -355,6 +427,11 @@ func (pp *PackagePaths) Files() map[string]string {
 
 	add(pp.ZarfYAML)
 	add(pp.Signature)
+	add(pp.ChecksumsSignature)
+	add(pp.SignatureTimestamp)
+	add(pp.ChecksumsSignatureTimestamp)
+	add(pp.SignatureBundle)
+	add(pp.ChecksumsSignatureBundle)
 	add(pp.Checksums)
 
 	add(pp.Images.OCILayout)
@@ -12,6 +12,8 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/mholt/archiver/v3"
+
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
 // ComponentSBOM contains paths for a component's SBOM.
@@ -86,6 +88,7 @@ func (s *SBOMs) StageSBOMViewFiles() ([]string, []string, error) {
 		}
 		sbomViewFiles = files
 
+		utils.TrackTempPath(SBOMDir)
 		if _, err := s.OutputSBOMFiles(SBOMDir, ""); err != nil {
 			// Don't stop the deployment, let the user decide if they want to continue the deployment
 			warning := fmt.Sprintf("Unable to process the SBOM files for this package: %s", err.Error())
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	goyaml "github.com/goccy/go-yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/composer"
+)
+
+// ImportChains returns the import chains of the components of the package in baseDir that are compatible with the
+// flavor. When a component name is given only the import chain of that component is returned.
+func ImportChains(ctx context.Context, baseDir, flavor, componentName string) ([]*composer.ImportChain, error) {
+	err := os.Chdir(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access directory %q: %w", baseDir, err)
+	}
+	b, err := os.ReadFile(layout.ZarfYAML)
+	if err != nil {
+		return nil, err
+	}
+	var pkg v1alpha1.ZarfPackage
+	err = goyaml.Unmarshal(b, &pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	arch := config.GetArch(pkg.Metadata.Architecture)
+	chains := []*composer.ImportChain{}
+	for i, component := range pkg.Components {
+		if componentName != "" && component.Name != componentName {
+			continue
+		}
+		if !composer.CompatibleComponent(component, arch, flavor) {
+			continue
+		}
+		chain, err := composer.NewImportChain(ctx, component, i, pkg.Metadata.Name, arch, flavor)
+		if err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain)
+	}
+	if componentName != "" && len(chains) == 0 {
+		return nil, fmt.Errorf("component %q not found in %s for architecture %s and flavor %q", componentName, layout.ZarfYAML, arch, flavor)
+	}
+	return chains, nil
+}
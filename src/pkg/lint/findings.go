@@ -32,6 +32,8 @@ type PackageFinding struct {
 	PackagePathOverride string
 	// Severity of finding.
 	Severity Severity
+	// Rule is the name of the security rule that produced the finding, it is empty for all other findings.
+	Rule string
 }
 
 // ItemizedDescription returns a string with the description and item if finding contains one.
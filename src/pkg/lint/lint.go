@@ -42,8 +42,9 @@ func (e *LintError) OnlyWarnings() bool {
 	return true
 }
 
-// Validate lints the given Zarf package
-func Validate(ctx context.Context, baseDir, flavor string, setVariables map[string]string) error {
+// Validate lints the given Zarf package. The resources rendered from its charts and manifests are checked against the
+// security rules with the given severities, see ParseSecuritySeverities, and its images against the image policy.
+func Validate(ctx context.Context, baseDir, flavor string, setVariables map[string]string, securitySeverities map[string]Severity, imagePolicy ImagePolicy) error {
 	err := os.Chdir(baseDir)
 	if err != nil {
 		return fmt.Errorf("unable to access directory %q: %w", baseDir, err)
@@ -59,7 +60,7 @@ func Validate(ctx context.Context, baseDir, flavor string, setVariables map[stri
 	}
 
 	findings := []PackageFinding{}
-	compFindings, err := lintComponents(ctx, pkg, flavor, setVariables)
+	compFindings, err := lintComponents(ctx, pkg, flavor, setVariables, securitySeverities, imagePolicy)
 	if err != nil {
 		return err
 	}
@@ -79,7 +80,7 @@ func Validate(ctx context.Context, baseDir, flavor string, setVariables map[stri
 	}
 }
 
-func lintComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor string, setVariables map[string]string) ([]PackageFinding, error) {
+func lintComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor string, setVariables map[string]string, securitySeverities map[string]Severity, imagePolicy ImagePolicy) ([]PackageFinding, error) {
 	findings := []PackageFinding{}
 	for i, component := range pkg.Components {
 		arch := config.GetArch(pkg.Metadata.Architecture)
@@ -98,6 +99,7 @@ func lintComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor string
 				return nil, err
 			}
 			compFindings = append(compFindings, CheckComponentValues(component, node.Index())...)
+			compFindings = append(compFindings, CheckImagePolicy(component, node.Index(), imagePolicy)...)
 			for i := range compFindings {
 				compFindings[i].PackagePathOverride = node.ImportLocation()
 				compFindings[i].PackageNameOverride = node.OriginalPackageName()
@@ -105,6 +107,23 @@ func lintComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor string
 			findings = append(findings, compFindings...)
 			node = node.Next()
 		}
+
+		if len(securitySeverities) == 0 {
+			continue
+		}
+		composed, err := chain.Compose(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Template findings were already reported for each component in the import chain.
+		if _, err := templateZarfObj(composed, setVariables); err != nil {
+			return nil, err
+		}
+		securityFindings, err := checkComponentSecurity(ctx, *composed, i, securitySeverities)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, securityFindings...)
 	}
 	return findings, nil
 }
@@ -55,7 +55,7 @@ func TestLintComponents(t *testing.T) {
 			Metadata: v1alpha1.ZarfMetadata{Name: "test-zarf-package"},
 		}
 
-		_, err := lintComponents(context.Background(), zarfPackage, "", nil)
+		_, err := lintComponents(context.Background(), zarfPackage, "", nil, nil, ImagePolicy{})
 		require.Error(t, err)
 	})
 }
@@ -154,7 +154,7 @@ func TestLintPackageWithImports(t *testing.T) {
 	defer func() {
 		require.NoError(t, os.Chdir(cwd))
 	}()
-	err = Validate(ctx, "testdata/lint-with-imports", "good-flavor", setVariables)
+	err = Validate(ctx, "testdata/lint-with-imports", "good-flavor", setVariables, nil, ImagePolicy{})
 	var lintErr *LintError
 	require.ErrorAs(t, err, &lintErr)
 	require.ElementsMatch(t, findings, lintErr.Findings)
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ImagePolicy restricts the images a package can contain by their fully qualified references, such as
+// docker.io/library/nginx:1.27.
+type ImagePolicy struct {
+	// Allow are the patterns of the approved images, every image must match one of them when any are set.
+	Allow []*regexp.Regexp
+	// Deny are the patterns of the disallowed images, no image may match any of them.
+	Deny []*regexp.Regexp
+}
+
+// ParseImagePolicy returns the image policy of the given allow and deny regular expressions.
+func ParseImagePolicy(allow, deny []string) (ImagePolicy, error) {
+	policy := ImagePolicy{}
+	for _, expr := range allow {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return ImagePolicy{}, fmt.Errorf("invalid image allow pattern %q: %w", expr, err)
+		}
+		policy.Allow = append(policy.Allow, re)
+	}
+	for _, expr := range deny {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return ImagePolicy{}, fmt.Errorf("invalid image deny pattern %q: %w", expr, err)
+		}
+		policy.Deny = append(policy.Deny, re)
+	}
+	return policy, nil
+}
+
+// AllowRegistryPresets returns the policy with the registries of the presets added to the approved images, so that
+// every image of the package must come from one of the registries.
+func (p ImagePolicy) AllowRegistryPresets(presets []types.RegistryPreset) ImagePolicy {
+	for _, preset := range presets {
+		p.Allow = append(p.Allow, regexp.MustCompile("^"+regexp.QuoteMeta(strings.TrimSuffix(preset.URL, "/")+"/")))
+	}
+	return p
+}
+
+// IsEmpty returns true if the policy doesn't restrict any images.
+func (p ImagePolicy) IsEmpty() bool {
+	return len(p.Allow) == 0 && len(p.Deny) == 0
+}
+
+// CheckImagePolicy returns a finding for each image of the component that the policy doesn't allow. Images that can't
+// be parsed, such as images set by package templates that are not set, are checked by the other lint rules instead.
+func CheckImagePolicy(c v1alpha1.ZarfComponent, i int, policy ImagePolicy) []PackageFinding {
+	var findings []PackageFinding
+	if policy.IsEmpty() {
+		return findings
+	}
+	for j, image := range c.Images {
+		refInfo, err := transform.ParseImageRef(image)
+		if err != nil {
+			continue
+		}
+		if description := policy.violation(refInfo.Reference); description != "" {
+			findings = append(findings, PackageFinding{
+				YqPath:      fmt.Sprintf(".components.[%d].images.[%d]", i, j),
+				Description: description,
+				Item:        image,
+				Severity:    SevErr,
+			})
+		}
+	}
+	return findings
+}
+
+// violation returns why the policy doesn't allow the image reference, or an empty string if it is allowed.
+func (p ImagePolicy) violation(ref string) string {
+	for _, re := range p.Deny {
+		if re.MatchString(ref) {
+			return fmt.Sprintf("Image is denied by the image policy (%s)", re.String())
+		}
+	}
+	if len(p.Allow) == 0 {
+		return ""
+	}
+	for _, re := range p.Allow {
+		if re.MatchString(ref) {
+			return ""
+		}
+	}
+	return "Image is not allowed by the image policy"
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestParseImagePolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := ParseImagePolicy(nil, nil)
+	require.NoError(t, err)
+	require.True(t, policy.IsEmpty())
+
+	policy, err = ParseImagePolicy([]string{`^registry1\.dso\.mil/`}, []string{`^docker\.io/`})
+	require.NoError(t, err)
+	require.False(t, policy.IsEmpty())
+
+	_, err = ParseImagePolicy([]string{"("}, nil)
+	require.ErrorContains(t, err, `invalid image allow pattern "("`)
+	_, err = ParseImagePolicy(nil, []string{"["})
+	require.ErrorContains(t, err, `invalid image deny pattern "["`)
+}
+
+func TestAllowRegistryPresets(t *testing.T) {
+	t.Parallel()
+
+	policy, err := ParseImagePolicy(nil, nil)
+	require.NoError(t, err)
+	policy = policy.AllowRegistryPresets([]types.RegistryPreset{{Name: "ironbank", URL: "registry1.dso.mil/ironbank/"}})
+	require.False(t, policy.IsEmpty())
+
+	component := v1alpha1.ZarfComponent{
+		Images: []string{
+			"registry1.dso.mil/ironbank/opensource/nginx/nginx:1.27",
+			"registry1.dso.mil/other/nginx:1.27",
+		},
+	}
+	expected := []PackageFinding{
+		{
+			YqPath:      ".components.[0].images.[1]",
+			Description: "Image is not allowed by the image policy",
+			Item:        "registry1.dso.mil/other/nginx:1.27",
+			Severity:    SevErr,
+		},
+	}
+	require.Equal(t, expected, CheckImagePolicy(component, 0, policy))
+}
+
+func TestCheckImagePolicy(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{
+		Name: "images",
+		Images: []string{
+			"registry1.dso.mil/ironbank/opensource/nginx/nginx:1.27",
+			"nginx:1.27",
+			"ghcr.io/zarf-dev/zarf/agent:v0.40.0",
+			"###ZARF_PKG_TMPL_IMAGE###",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		allow    []string
+		deny     []string
+		expected []PackageFinding
+	}{
+		{
+			name:     "empty policy",
+			expected: nil,
+		},
+		{
+			name: "deny",
+			deny: []string{`^docker\.io/`},
+			expected: []PackageFinding{
+				{
+					YqPath:      ".components.[3].images.[1]",
+					Description: `Image is denied by the image policy (^docker\.io/)`,
+					Item:        "nginx:1.27",
+					Severity:    SevErr,
+				},
+			},
+		},
+		{
+			name:  "allow",
+			allow: []string{`^registry1\.dso\.mil/`, `^ghcr\.io/zarf-dev/`},
+			expected: []PackageFinding{
+				{
+					YqPath:      ".components.[3].images.[1]",
+					Description: "Image is not allowed by the image policy",
+					Item:        "nginx:1.27",
+					Severity:    SevErr,
+				},
+			},
+		},
+		{
+			name:  "deny takes precedence over allow",
+			allow: []string{`.*`},
+			deny:  []string{`^ghcr\.io/`},
+			expected: []PackageFinding{
+				{
+					YqPath:      ".components.[3].images.[2]",
+					Description: `Image is denied by the image policy (^ghcr\.io/)`,
+					Item:        "ghcr.io/zarf-dev/zarf/agent:v0.40.0",
+					Severity:    SevErr,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := ParseImagePolicy(tt.allow, tt.deny)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, CheckImagePolicy(component, 3, policy))
+		})
+	}
+}
@@ -95,7 +95,8 @@ func checkForUnpinnedFiles(c v1alpha1.ZarfComponent, i int) []PackageFinding {
 	var findings []PackageFinding
 	for j, file := range c.Files {
 		fileYqPath := fmt.Sprintf(".components.[%d].files.[%d]", i, j)
-		if file.Shasum == "" && helpers.IsURL(file.Source) {
+		// OCI blobs are pinned by the digest in their source.
+		if file.Shasum == "" && helpers.IsURL(file.Source) && !helpers.IsOCIURL(file.Source) {
 			findings = append(findings, PackageFinding{
 				YqPath:      fileYqPath,
 				Description: "No shasum for remote file",
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// Security rules checked against the resources rendered from the charts and manifests of a package.
+const (
+	// RulePrivileged flags containers that run privileged.
+	RulePrivileged = "privileged"
+	// RuleHostNetwork flags pods that use the network namespace of the host.
+	RuleHostNetwork = "host-network"
+	// RuleHostPath flags pods that mount a path on the host.
+	RuleHostPath = "host-path"
+	// RuleWildcardRBAC flags roles that grant every verb or every resource.
+	RuleWildcardRBAC = "wildcard-rbac"
+)
+
+// sevOff disables a security rule.
+const sevOff Severity = "Off"
+
+// SecurityRules returns the security rules with their default severity.
+func SecurityRules() map[string]Severity {
+	return map[string]Severity{
+		RulePrivileged:   SevWarn,
+		RuleHostNetwork:  SevWarn,
+		RuleHostPath:     SevWarn,
+		RuleWildcardRBAC: SevWarn,
+	}
+}
+
+// ParseSecuritySeverities returns the severity of each security rule, overriding the defaults with the severities of the
+// given rules. A severity is one of error, warning or off, where off disables the rule.
+func ParseSecuritySeverities(overrides map[string]string) (map[string]Severity, error) {
+	severities := SecurityRules()
+	for rule, value := range overrides {
+		if _, ok := severities[rule]; !ok {
+			return nil, fmt.Errorf("unknown security rule %q, valid rules are %s", rule, strings.Join(slices.Sorted(maps.Keys(SecurityRules())), ", "))
+		}
+		switch strings.ToLower(value) {
+		case "error":
+			severities[rule] = SevErr
+		case "warning":
+			severities[rule] = SevWarn
+		case "off":
+			severities[rule] = sevOff
+		default:
+			return nil, fmt.Errorf("invalid severity %q for security rule %q, valid severities are error, warning and off", value, rule)
+		}
+	}
+	return severities, nil
+}
+
+// checkComponentSecurity renders the charts and manifests of a composed component and checks the resources against the
+// security rules. Charts and manifests that cannot be rendered are reported as warnings instead of failing the lint.
+func checkComponentSecurity(ctx context.Context, c v1alpha1.ZarfComponent, i int, severities map[string]Severity) ([]PackageFinding, error) {
+	if len(c.Charts) == 0 && len(c.Manifests) == 0 {
+		return nil, nil
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	findings := []PackageFinding{}
+	for j, chart := range c.Charts {
+		yqPath := fmt.Sprintf(".components.[%d].charts.[%d]", i, j)
+		resources, err := renderChart(ctx, chart, filepath.Join(tmpDir, "charts", chart.Name))
+		if err != nil {
+			findings = append(findings, PackageFinding{
+				YqPath:      yqPath,
+				Description: fmt.Sprintf("Unable to render chart, security rules were not checked: %s", err),
+				Item:        chart.Name,
+				Severity:    SevWarn,
+			})
+			continue
+		}
+		findings = append(findings, checkResourceSecurity(yqPath, resources, severities)...)
+	}
+	for j, manifest := range c.Manifests {
+		yqPath := fmt.Sprintf(".components.[%d].manifests.[%d]", i, j)
+		resources, err := renderManifest(ctx, manifest, filepath.Join(tmpDir, "manifests", manifest.Name))
+		if err != nil {
+			findings = append(findings, PackageFinding{
+				YqPath:      yqPath,
+				Description: fmt.Sprintf("Unable to render manifest, security rules were not checked: %s", err),
+				Item:        manifest.Name,
+				Severity:    SevWarn,
+			})
+			continue
+		}
+		findings = append(findings, checkResourceSecurity(yqPath, resources, severities)...)
+	}
+	return findings, nil
+}
+
+// renderChart packages a chart with its values files and renders it.
+func renderChart(ctx context.Context, chart v1alpha1.ZarfChart, dir string) ([]*unstructured.Unstructured, error) {
+	chartsPath := filepath.Join(dir, "charts")
+	valuesPath := filepath.Join(dir, "values")
+	if err := helm.New(chart, chartsPath, valuesPath).PackageChart(ctx, chartsPath); err != nil {
+		return nil, err
+	}
+	helmCfg := helm.New(chart, chartsPath, valuesPath, helm.WithVariableConfig(template.GetZarfVariableConfig(ctx)))
+	manifest, _, err := helmCfg.TemplateChart(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SplitYAML([]byte(manifest))
+}
+
+// renderManifest reads the files of a manifest and builds its kustomizations.
+func renderManifest(ctx context.Context, manifest v1alpha1.ZarfManifest, dir string) ([]*unstructured.Unstructured, error) {
+	resources := []*unstructured.Unstructured{}
+	for f, path := range manifest.Files {
+		if helpers.IsURL(path) {
+			dst := filepath.Join(dir, fmt.Sprintf("file-%d.yaml", f))
+			if err := utils.DownloadToFile(ctx, path, dst, ""); err != nil {
+				return nil, fmt.Errorf("unable to download manifest %s: %w", path, err)
+			}
+			path = dst
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		yamls, err := utils.SplitYAML(b)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, yamls...)
+	}
+	for k, path := range manifest.Kustomizations {
+		dst := filepath.Join(dir, fmt.Sprintf("kustomization-%d.yaml", k))
+		if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory, manifest.KustomizeOptions); err != nil {
+			return nil, fmt.Errorf("unable to build kustomization %s: %w", path, err)
+		}
+		b, err := os.ReadFile(dst)
+		if err != nil {
+			return nil, err
+		}
+		yamls, err := utils.SplitYAML(b)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, yamls...)
+	}
+	return resources, nil
+}
+
+// checkResourceSecurity checks rendered resources against the security rules.
+func checkResourceSecurity(yqPath string, resources []*unstructured.Unstructured, severities map[string]Severity) []PackageFinding {
+	findings := []PackageFinding{}
+	add := func(rule string, res *unstructured.Unstructured, description string) {
+		severity, ok := severities[rule]
+		if !ok || severity == sevOff {
+			return
+		}
+		item := fmt.Sprintf("%s/%s", res.GetKind(), res.GetName())
+		if res.GetNamespace() != "" {
+			item = fmt.Sprintf("%s/%s/%s", res.GetKind(), res.GetNamespace(), res.GetName())
+		}
+		findings = append(findings, PackageFinding{
+			YqPath:      yqPath,
+			Description: description,
+			Item:        item,
+			Severity:    severity,
+			Rule:        rule,
+		})
+	}
+
+	for _, res := range resources {
+		switch res.GetKind() {
+		case "Role", "ClusterRole":
+			role := rbacv1.ClusterRole{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, &role); err != nil {
+				continue
+			}
+			for _, rule := range role.Rules {
+				if slices.Contains(rule.Verbs, rbacv1.VerbAll) || slices.Contains(rule.Resources, rbacv1.ResourceAll) {
+					add(RuleWildcardRBAC, res, "Role grants wildcard permissions")
+					break
+				}
+			}
+			continue
+		}
+
+		podSpec, ok := securityPodSpec(res)
+		if !ok {
+			continue
+		}
+		containers := append(slices.Clone(podSpec.InitContainers), podSpec.Containers...)
+		for _, container := range containers {
+			if container.SecurityContext != nil && container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
+				add(RulePrivileged, res, fmt.Sprintf("Container %s runs privileged", container.Name))
+			}
+		}
+		if podSpec.HostNetwork {
+			add(RuleHostNetwork, res, "Pod uses the host network")
+		}
+		for _, volume := range podSpec.Volumes {
+			if volume.HostPath != nil {
+				add(RuleHostPath, res, fmt.Sprintf("Volume %s mounts the host path %s", volume.Name, volume.HostPath.Path))
+			}
+		}
+	}
+	return findings
+}
+
+// securityPodSpec returns the pod spec of a workload, it returns false when the resource is not a workload or its
+// pod spec cannot be read.
+func securityPodSpec(res *unstructured.Unstructured) (corev1.PodSpec, bool) {
+	var path []string
+	switch res.GetKind() {
+	case "Pod":
+		path = []string{"spec"}
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController", "Job":
+		path = []string{"spec", "template", "spec"}
+	case "CronJob":
+		path = []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	default:
+		return corev1.PodSpec{}, false
+	}
+	obj, found, err := unstructured.NestedMap(res.Object, path...)
+	if err != nil || !found {
+		return corev1.PodSpec{}, false
+	}
+	podSpec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &podSpec); err != nil {
+		return corev1.PodSpec{}, false
+	}
+	return podSpec, true
+}
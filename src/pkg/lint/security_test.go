@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseSecuritySeverities(t *testing.T) {
+	t.Parallel()
+
+	severities, err := ParseSecuritySeverities(nil)
+	require.NoError(t, err)
+	require.Equal(t, SecurityRules(), severities)
+
+	severities, err = ParseSecuritySeverities(map[string]string{RulePrivileged: "Error", RuleHostPath: "off"})
+	require.NoError(t, err)
+	require.Equal(t, Severity(SevErr), severities[RulePrivileged])
+	require.Equal(t, sevOff, severities[RuleHostPath])
+	require.Equal(t, Severity(SevWarn), severities[RuleHostNetwork])
+
+	_, err = ParseSecuritySeverities(map[string]string{"host-pid": "error"})
+	require.EqualError(t, err, `unknown security rule "host-pid", valid rules are host-network, host-path, privileged, wildcard-rbac`)
+	_, err = ParseSecuritySeverities(map[string]string{RulePrivileged: "fatal"})
+	require.EqualError(t, err, `invalid severity "fatal" for security rule "privileged", valid severities are error, warning and off`)
+}
+
+func TestCheckComponentSecurity(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	component := v1alpha1.ZarfComponent{
+		Name: "security",
+		Manifests: []v1alpha1.ZarfManifest{
+			{
+				Name:  "workloads",
+				Files: []string{"testdata/security/workloads.yaml"},
+			},
+		},
+	}
+	severities, err := ParseSecuritySeverities(map[string]string{RulePrivileged: "error", RuleHostNetwork: "off"})
+	require.NoError(t, err)
+
+	findings, err := checkComponentSecurity(ctx, component, 2, severities)
+	require.NoError(t, err)
+	expected := []PackageFinding{
+		{
+			YqPath:      ".components.[2].manifests.[0]",
+			Description: "Container setup runs privileged",
+			Item:        "DaemonSet/monitoring/node-agent",
+			Severity:    SevErr,
+			Rule:        RulePrivileged,
+		},
+		{
+			YqPath:      ".components.[2].manifests.[0]",
+			Description: "Volume logs mounts the host path /var/log",
+			Item:        "DaemonSet/monitoring/node-agent",
+			Severity:    SevWarn,
+			Rule:        RuleHostPath,
+		},
+		{
+			YqPath:      ".components.[2].manifests.[0]",
+			Description: "Role grants wildcard permissions",
+			Item:        "ClusterRole/admin-all",
+			Severity:    SevWarn,
+			Rule:        RuleWildcardRBAC,
+		},
+	}
+	require.Equal(t, expected, findings)
+
+	component.Manifests[0].Files = []string{"testdata/security/missing.yaml"}
+	findings, err = checkComponentSecurity(ctx, component, 0, severities)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	require.Equal(t, Severity(SevWarn), findings[0].Severity)
+	require.Empty(t, findings[0].Rule)
+	require.Contains(t, findings[0].Description, "Unable to render manifest, security rules were not checked")
+
+	component = v1alpha1.ZarfComponent{
+		Name: "chart",
+		Charts: []v1alpha1.ZarfChart{
+			{
+				Name:        "agent",
+				Version:     "0.1.0",
+				Namespace:   "agent",
+				LocalPath:   "testdata/security/chart",
+				ValuesFiles: []string{"testdata/security/values.yaml"},
+			},
+		},
+	}
+	findings, err = checkComponentSecurity(ctx, component, 1, SecurityRules())
+	require.NoError(t, err)
+	expected = []PackageFinding{
+		{
+			YqPath:      ".components.[1].charts.[0]",
+			Description: "Pod uses the host network",
+			Item:        "Deployment/agent/agent",
+			Severity:    SevWarn,
+			Rule:        RuleHostNetwork,
+		},
+	}
+	require.Equal(t, expected, findings)
+}
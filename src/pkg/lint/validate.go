@@ -7,10 +7,15 @@ package lint
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packagemirror"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -41,9 +46,11 @@ const (
 // Package errors found during validation.
 const (
 	PkgValidateErrInitNoYOLO              = "sorry, you can't YOLO an init package"
+	PkgValidateErrInitNoCluster           = "component %q of an init package cannot set a cluster"
 	PkgValidateErrConstant                = "invalid package constant: %w"
 	PkgValidateErrYOLONoOCI               = "OCI images not allowed in YOLO"
 	PkgValidateErrYOLONoGit               = "git repos not allowed in YOLO"
+	PkgValidateErrYOLONoPackageMirrors    = "package mirrors not allowed in YOLO"
 	PkgValidateErrYOLONoArch              = "cluster architecture not allowed in YOLO"
 	PkgValidateErrYOLONoDistro            = "cluster distros not allowed in YOLO"
 	PkgValidateErrComponentNameNotUnique  = "component name %q is not unique"
@@ -58,13 +65,44 @@ const (
 	PkgValidateErrAction                  = "invalid action: %w"
 	PkgValidateErrActionCmdWait           = "action %q cannot be both a command and wait action"
 	PkgValidateErrActionClusterNetwork    = "a single wait action must contain only one of cluster or network"
+	PkgValidateErrActionKinds             = "action can only contain one of cmd, wait, kubectl, helmUpgrade, httpGet or copyFile, found %s"
+	PkgValidateErrActionKubectl           = "kubectl action must include args"
+	PkgValidateErrActionHelmUpgrade       = "helmUpgrade action must include a releaseName and chart"
+	PkgValidateErrActionHTTPGet           = "httpGet action must include a url"
+	PkgValidateErrActionHTTPGetCode       = "httpGet action for %q has an unknown status code %d"
+	PkgValidateErrActionCopyFile          = "copyFile action must include a source and target"
 	PkgValidateErrChartName               = "chart %q exceed the maximum length of %d characters"
 	PkgValidateErrChartNamespaceMissing   = "chart %q must include a namespace"
 	PkgValidateErrChartURLOrPath          = "chart %q must have either a url or localPath"
 	PkgValidateErrChartVersion            = "chart %q must include a chart version"
 	PkgValidateErrManifestFileOrKustomize = "manifest %q must have at least one file or kustomization"
 	PkgValidateErrManifestNameLength      = "manifest %q exceed the maximum length of %d characters"
+	PkgValidateErrKustomizeOptions        = "manifest %q must have kustomizations to use kustomizeOptions"
+	PkgValidateErrKustomizeLoadRestrictor = "manifest %q has an invalid loadRestrictor %q, valid options are LoadRestrictionsRootOnly and LoadRestrictionsNone"
+	PkgValidateErrKustomizeAnyDirectory   = "manifest %q cannot use both kustomizeAllowAnyDirectory and the LoadRestrictionsRootOnly loadRestrictor"
+	PkgValidateErrKustomizeHelm           = "manifest %q must set enableHelm to use helmKubeVersion or helmAPIVersions"
+	PkgValidateErrKustomizeNoPlugins      = "manifest %q must list the allowedPlugins to use enableAlphaPlugins"
+	PkgValidateErrKustomizePlugins        = "manifest %q must set enableAlphaPlugins to use allowedPlugins"
+	PkgValidateErrManifestNamespace       = "manifest %q must set a namespace to use createNamespace, namespaceLabels or namespaceAnnotations"
+	PkgValidateErrWaitForKind             = "waitFor of %q must include a kind"
+	PkgValidateErrWaitForNetwork          = "waitFor of %q cannot wait for %s endpoints, use a wait action instead"
+	PkgValidateErrWaitForMaxTotalSeconds  = "waitFor of %q cannot have a negative maxTotalSeconds"
 	PkgValidateErrVariable                = "invalid package variable: %w"
+	PkgValidateErrFile                    = "invalid file definition: %w"
+	PkgValidateErrFileExtractPath         = "file %q cannot use both extract and extractPath"
+	PkgValidateErrFileExtractOptions      = "file %q must set extract to use stripComponents or extractMembers"
+	PkgValidateErrFileStripComponents     = "file %q cannot strip a negative number of components"
+	PkgValidateErrFileMode                = "file %q: %w"
+	PkgValidateErrFileLargeBinaryTemplate = "file %q cannot be templated as it is a large binary"
+	PkgValidateErrPackageMirrorType       = "package mirror %q has an invalid type %q, valid options are pypi and npm"
+	PkgValidateErrPackageMirrorSource     = "package mirror %q must be a local requirements.txt or package-lock.json"
+	PkgValidateErrRepoOptionsURL          = "repo options of %q must match a repo of the component"
+	PkgValidateErrRepoOptionsDepth        = "repo options of %q cannot have a negative depth"
+	PkgValidateErrExportUndefined         = "exported variable %q must be a package variable, an imported variable or set by a deploy action"
+	PkgValidateErrExportSensitive         = "exported variable %q is sensitive and cannot be exported"
+	PkgValidateErrImportPackage           = "imported variable %q must name the package that exports it"
+	PkgValidateErrImportSelf              = "imported variable %q cannot be imported from the package itself"
+	PkgValidateErrMinimumHelmVersion      = "minimum helm version %q must be a semantic version"
 )
 
 // ValidatePackage runs all validation checks on the package.
@@ -73,6 +111,11 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 	if pkg.Kind == v1alpha1.ZarfInitConfig && pkg.Metadata.YOLO {
 		err = errors.Join(err, errors.New(PkgValidateErrInitNoYOLO))
 	}
+	if pkg.Metadata.MinimumHelmVersion != "" {
+		if _, semverErr := semver.NewVersion(pkg.Metadata.MinimumHelmVersion); semverErr != nil {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrMinimumHelmVersion, pkg.Metadata.MinimumHelmVersion))
+		}
+	}
 	for _, constant := range pkg.Constants {
 		if varErr := constant.Validate(); varErr != nil {
 			err = errors.Join(err, fmt.Errorf(PkgValidateErrConstant, varErr))
@@ -89,6 +132,9 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 			if len(component.Repos) > 0 {
 				err = errors.Join(err, errors.New(PkgValidateErrYOLONoGit))
 			}
+			if len(component.PackageMirrors) > 0 {
+				err = errors.Join(err, errors.New(PkgValidateErrYOLONoPackageMirrors))
+			}
 			if component.Only.Cluster.Architecture != "" {
 				err = errors.Join(err, errors.New(PkgValidateErrYOLONoArch))
 			}
@@ -103,6 +149,9 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 			err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentNameNotUnique, component.Name))
 		}
 		uniqueComponentNames[component.Name] = true
+		if pkg.Kind == v1alpha1.ZarfInitConfig && component.Cluster != "" {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrInitNoCluster, component.Name))
+		}
 		if component.IsRequired() {
 			if component.Default {
 				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentReqDefault, component.Name))
@@ -133,6 +182,17 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 				err = errors.Join(err, fmt.Errorf(PkgValidateErrManifest, manifestErr))
 			}
 		}
+		for _, file := range component.Files {
+			if fileErr := validateFile(file); fileErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrFile, fileErr))
+			}
+		}
+		for _, mirror := range component.PackageMirrors {
+			err = errors.Join(err, validatePackageMirror(mirror))
+		}
+		for _, opts := range component.RepoOptions {
+			err = errors.Join(err, validateRepoOptions(component, opts))
+		}
 		if actionsErr := validateActions(component.Actions); actionsErr != nil {
 			err = errors.Join(err, fmt.Errorf("%q: %w", component.Name, actionsErr))
 		}
@@ -152,6 +212,47 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 			err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupOneComponent, groupKey, componentNames[0]))
 		}
 	}
+	err = errors.Join(err, validateExports(pkg))
+	return err
+}
+
+// validateExports validates the variables a package exports and imports.
+func validateExports(pkg v1alpha1.ZarfPackage) error {
+	var err error
+	// Variables that can be exported by name, mapped to whether they are sensitive.
+	variables := map[string]bool{}
+	for _, variable := range pkg.Variables {
+		variables[variable.Name] = variable.Sensitive
+	}
+	for _, imp := range pkg.Imports {
+		variables[imp.Name] = false
+		if imp.Package == "" {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrImportPackage, imp.Name))
+		}
+		if imp.Package == pkg.Metadata.Name {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrImportSelf, imp.Name))
+		}
+	}
+	for _, component := range pkg.Components {
+		as := component.Actions.OnDeploy
+		for _, actions := range [][]v1alpha1.ZarfComponentAction{as.Before, as.After, as.OnSuccess, as.OnFailure} {
+			for _, action := range actions {
+				for _, variable := range action.SetVariables {
+					variables[variable.Name] = variables[variable.Name] || variable.Sensitive
+				}
+			}
+		}
+	}
+	for _, export := range pkg.Exports {
+		sensitive, ok := variables[export.Name]
+		if !ok {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrExportUndefined, export.Name))
+			continue
+		}
+		if sensitive {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrExportSensitive, export.Name))
+		}
+	}
 	return err
 }
 
@@ -229,6 +330,46 @@ func validateAction(action v1alpha1.ZarfComponentAction) error {
 		}
 	}
 
+	kinds := []string{}
+	if action.Cmd != "" {
+		kinds = append(kinds, "cmd")
+	}
+	if action.Wait != nil {
+		kinds = append(kinds, "wait")
+	}
+	cmdOrWait := len(kinds)
+	if action.Kubectl != nil {
+		kinds = append(kinds, "kubectl")
+		if len(action.Kubectl.Args) == 0 {
+			err = errors.Join(err, errors.New(PkgValidateErrActionKubectl))
+		}
+	}
+	if action.HelmUpgrade != nil {
+		kinds = append(kinds, "helmUpgrade")
+		if action.HelmUpgrade.ReleaseName == "" || action.HelmUpgrade.Chart == "" {
+			err = errors.Join(err, errors.New(PkgValidateErrActionHelmUpgrade))
+		}
+	}
+	if action.HTTPGet != nil {
+		kinds = append(kinds, "httpGet")
+		if action.HTTPGet.URL == "" {
+			err = errors.Join(err, errors.New(PkgValidateErrActionHTTPGet))
+		}
+		if action.HTTPGet.Code != 0 && http.StatusText(action.HTTPGet.Code) == "" {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrActionHTTPGetCode, action.HTTPGet.URL, action.HTTPGet.Code))
+		}
+	}
+	if action.CopyFile != nil {
+		kinds = append(kinds, "copyFile")
+		if action.CopyFile.Source == "" || action.CopyFile.Target == "" {
+			err = errors.Join(err, errors.New(PkgValidateErrActionCopyFile))
+		}
+	}
+	// Actions with both a cmd and a wait are already reported above.
+	if len(kinds) > 1 && len(kinds) > cmdOrWait {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrActionKinds, strings.Join(kinds, ", ")))
+	}
+
 	return err
 }
 
@@ -283,6 +424,25 @@ func validateChart(chart v1alpha1.ZarfChart) error {
 		err = errors.Join(err, nameErr)
 	}
 
+	err = errors.Join(err, validateWaitFor(chart.Name, chart.WaitFor))
+
+	return err
+}
+
+// validateWaitFor runs all validation checks on the waitFor rules of a chart or manifest.
+func validateWaitFor(name string, waitFor []v1alpha1.ZarfWaitFor) error {
+	var err error
+	for _, wait := range waitFor {
+		switch wait.Kind {
+		case "":
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrWaitForKind, name))
+		case "http", "https", "tcp":
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrWaitForNetwork, name, wait.Kind))
+		}
+		if wait.MaxTotalSeconds < 0 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrWaitForMaxTotalSeconds, name))
+		}
+	}
 	return err
 }
 
@@ -298,5 +458,92 @@ func validateManifest(manifest v1alpha1.ZarfManifest) error {
 		err = errors.Join(err, fmt.Errorf(PkgValidateErrManifestFileOrKustomize, manifest.Name))
 	}
 
+	if opts := manifest.KustomizeOptions; opts != nil {
+		if len(manifest.Kustomizations) < 1 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrKustomizeOptions, manifest.Name))
+		}
+		switch opts.LoadRestrictor {
+		case "", "LoadRestrictionsNone":
+		case "LoadRestrictionsRootOnly":
+			if manifest.KustomizeAllowAnyDirectory {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrKustomizeAnyDirectory, manifest.Name))
+			}
+		default:
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrKustomizeLoadRestrictor, manifest.Name, opts.LoadRestrictor))
+		}
+		if !opts.EnableHelm && (opts.HelmKubeVersion != "" || len(opts.HelmAPIVersions) > 0) {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrKustomizeHelm, manifest.Name))
+		}
+		if opts.EnableAlphaPlugins && len(opts.AllowedPlugins) < 1 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrKustomizeNoPlugins, manifest.Name))
+		}
+		if !opts.EnableAlphaPlugins && len(opts.AllowedPlugins) > 0 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrKustomizePlugins, manifest.Name))
+		}
+	}
+
+	if manifest.Namespace == "" && (manifest.CreateNamespace != nil || len(manifest.NamespaceLabels) > 0 || len(manifest.NamespaceAnnotations) > 0) {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrManifestNamespace, manifest.Name))
+	}
+
+	err = errors.Join(err, validateWaitFor(manifest.Name, manifest.WaitFor))
+
+	return err
+}
+
+// validateFile runs all validation checks on a file.
+func validateFile(file v1alpha1.ZarfFile) error {
+	var err error
+
+	if file.Extract && file.ExtractPath != "" {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrFileExtractPath, file.Source))
+	}
+
+	if !file.Extract && (file.StripComponents != 0 || len(file.ExtractMembers) > 0) {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrFileExtractOptions, file.Source))
+	}
+
+	if file.StripComponents < 0 {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrFileStripComponents, file.Source))
+	}
+
+	if file.Mode != "" {
+		if _, modeErr := files.ParseMode(file.Mode); modeErr != nil {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrFileMode, file.Source, modeErr))
+		}
+	}
+
+	if file.LargeBinary && file.Templated != nil && *file.Templated {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrFileLargeBinaryTemplate, file.Source))
+	}
+
+	return err
+}
+
+func validatePackageMirror(mirror v1alpha1.ZarfPackageMirror) error {
+	var err error
+
+	if mirror.Type != packagemirror.TypePyPI && mirror.Type != packagemirror.TypeNpm {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrPackageMirrorType, mirror.Source, mirror.Type))
+	}
+
+	if mirror.Source == "" || files.IsRemote(mirror.Source) {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrPackageMirrorSource, mirror.Source))
+	}
+
+	return err
+}
+
+func validateRepoOptions(component v1alpha1.ZarfComponent, opts v1alpha1.ZarfRepoOptions) error {
+	var err error
+
+	if !slices.Contains(component.Repos, opts.URL) {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrRepoOptionsURL, opts.URL))
+	}
+
+	if opts.Depth < 0 {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrRepoOptionsDepth, opts.URL))
+	}
+
 	return err
 }
@@ -98,6 +98,40 @@ func TestZarfPackageValidate(t *testing.T) {
 				fmt.Sprintf(PkgValidateErrGroupMultipleDefaults, "multi-default", "multi-default", "multi-default-2"),
 			},
 		},
+		{
+			name: "valid minimum helm version",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name:               "valid-helm-version",
+					MinimumHelmVersion: "3.16.0",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "component1",
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{
+			name: "invalid minimum helm version",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name:               "invalid-helm-version",
+					MinimumHelmVersion: "latest",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "component1",
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrMinimumHelmVersion, "latest"),
+			},
+		},
 		{
 			name: "invalid yolo",
 			pkg: v1alpha1.ZarfPackage{
@@ -111,6 +145,9 @@ func TestZarfPackageValidate(t *testing.T) {
 						Name:   "yolo",
 						Images: []string{"an-image"},
 						Repos:  []string{"a-repo"},
+						PackageMirrors: []v1alpha1.ZarfPackageMirror{
+							{Type: "pypi", Source: "requirements.txt"},
+						},
 						Only: v1alpha1.ZarfComponentOnlyTarget{
 							Cluster: v1alpha1.ZarfComponentOnlyCluster{
 								Architecture: "not-empty",
@@ -124,10 +161,29 @@ func TestZarfPackageValidate(t *testing.T) {
 				PkgValidateErrInitNoYOLO,
 				PkgValidateErrYOLONoOCI,
 				PkgValidateErrYOLONoGit,
+				PkgValidateErrYOLONoPackageMirrors,
 				PkgValidateErrYOLONoArch,
 				PkgValidateErrYOLONoDistro,
 			},
 		},
+		{
+			name: "init component with cluster",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfInitConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "init",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name:    "agent",
+						Cluster: "workload",
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrInitNoCluster, "agent"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,6 +224,74 @@ func TestValidateManifest(t *testing.T) {
 			manifest:     v1alpha1.ZarfManifest{Name: "nothing-there"},
 			expectedErrs: []string{fmt.Sprintf(PkgValidateErrManifestFileOrKustomize, "nothing-there")},
 		},
+		{
+			name: "valid kustomize options",
+			manifest: v1alpha1.ZarfManifest{
+				Name:           "kustomize",
+				Kustomizations: []string{"a-kustomization"},
+				KustomizeOptions: &v1alpha1.ZarfKustomizeOptions{
+					EnableHelm:         true,
+					HelmKubeVersion:    "1.31.0",
+					LoadRestrictor:     "LoadRestrictionsNone",
+					EnableAlphaPlugins: true,
+					AllowedPlugins:     []string{"ghcr.io/example/fn:v1"},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{
+			name: "invalid kustomize options",
+			manifest: v1alpha1.ZarfManifest{
+				Name:                       "kustomize",
+				Files:                      []string{"a-file"},
+				KustomizeAllowAnyDirectory: true,
+				KustomizeOptions: &v1alpha1.ZarfKustomizeOptions{
+					HelmAPIVersions:    []string{"v1"},
+					LoadRestrictor:     "LoadRestrictionsRootOnly",
+					EnableAlphaPlugins: true,
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrKustomizeOptions, "kustomize"),
+				fmt.Sprintf(PkgValidateErrKustomizeAnyDirectory, "kustomize"),
+				fmt.Sprintf(PkgValidateErrKustomizeHelm, "kustomize"),
+				fmt.Sprintf(PkgValidateErrKustomizeNoPlugins, "kustomize"),
+			},
+		},
+		{
+			name: "unknown load restrictor",
+			manifest: v1alpha1.ZarfManifest{
+				Name:             "kustomize",
+				Kustomizations:   []string{"a-kustomization"},
+				KustomizeOptions: &v1alpha1.ZarfKustomizeOptions{LoadRestrictor: "none", AllowedPlugins: []string{"fn"}},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrKustomizeLoadRestrictor, "kustomize", "none"),
+				fmt.Sprintf(PkgValidateErrKustomizePlugins, "kustomize"),
+			},
+		},
+		{
+			name: "namespace options",
+			manifest: v1alpha1.ZarfManifest{
+				Name:            "namespace",
+				Namespace:       "test",
+				Files:           []string{"a-file"},
+				CreateNamespace: helpers.BoolPtr(false),
+				NamespaceLabels: map[string]string{"istio-injection": "enabled"},
+			},
+			expectedErrs: nil,
+		},
+		{
+			name: "namespace options without namespace",
+			manifest: v1alpha1.ZarfManifest{
+				Name:                 "namespace",
+				Files:                []string{"a-file"},
+				NamespaceAnnotations: map[string]string{"owner": "test"},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrManifestNamespace, "namespace"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -184,6 +308,57 @@ func TestValidateManifest(t *testing.T) {
 	}
 }
 
+func TestValidateFile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		file         v1alpha1.ZarfFile
+		expectedErrs []string
+		name         string
+	}{
+		{
+			name:         "valid",
+			file:         v1alpha1.ZarfFile{Source: "archive.tar.gz", Target: "dir", Extract: true, StripComponents: 1, ExtractMembers: []string{"bin"}},
+			expectedErrs: nil,
+		},
+		{
+			name: "extract and extract path",
+			file: v1alpha1.ZarfFile{Source: "archive.tar.gz", Target: "dir", Extract: true, ExtractPath: "bin", StripComponents: -1},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrFileExtractPath, "archive.tar.gz"),
+				fmt.Sprintf(PkgValidateErrFileStripComponents, "archive.tar.gz"),
+			},
+		},
+		{
+			name:         "invalid mode",
+			file:         v1alpha1.ZarfFile{Source: "tool", Target: "/usr/local/bin/tool", Mode: "0999"},
+			expectedErrs: []string{`file "tool": invalid mode "0999", it must be an octal permission such as 0755`},
+		},
+		{
+			name:         "extract options without extract",
+			file:         v1alpha1.ZarfFile{Source: "archive.tar.gz", Target: "dir", ExtractMembers: []string{"bin"}},
+			expectedErrs: []string{fmt.Sprintf(PkgValidateErrFileExtractOptions, "archive.tar.gz")},
+		},
+		{
+			name:         "templated large binary",
+			file:         v1alpha1.ZarfFile{Source: "disk.qcow2", Target: "disk.qcow2", LargeBinary: true, Templated: helpers.BoolPtr(true)},
+			expectedErrs: []string{fmt.Sprintf(PkgValidateErrFileLargeBinaryTemplate, "disk.qcow2")},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateFile(tt.file)
+			if tt.expectedErrs == nil {
+				require.NoError(t, err)
+				return
+			}
+			errs := strings.Split(err.Error(), "\n")
+			require.ElementsMatch(t, errs, tt.expectedErrs)
+		})
+	}
+}
+
 func TestValidateReleaseName(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -301,6 +476,25 @@ func TestValidateChart(t *testing.T) {
 			chart:        v1alpha1.ZarfChart{Namespace: "namespace", URL: "http://whatever", Version: "v1.0.0"},
 			expectedErrs: []string{errChartReleaseNameEmpty},
 		},
+		{
+			name: "valid waitFor",
+			chart: v1alpha1.ZarfChart{Name: "chart4", Namespace: "namespace", URL: "http://whatever", Version: "v1.0.0", WaitFor: []v1alpha1.ZarfWaitFor{
+				{Kind: "Deployment", Name: "podinfo", Condition: "Available", MaxTotalSeconds: 60},
+			}},
+			expectedErrs: nil,
+		},
+		{
+			name: "invalid waitFor",
+			chart: v1alpha1.ZarfChart{Name: "chart5", Namespace: "namespace", URL: "http://whatever", Version: "v1.0.0", WaitFor: []v1alpha1.ZarfWaitFor{
+				{Name: "podinfo"},
+				{Kind: "http", Name: "localhost:8080", MaxTotalSeconds: -1},
+			}},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrWaitForKind, "chart5"),
+				fmt.Sprintf(PkgValidateErrWaitForNetwork, "chart5", "http"),
+				fmt.Sprintf(PkgValidateErrWaitForMaxTotalSeconds, "chart5"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -453,6 +647,37 @@ func TestValidateComponentAction(t *testing.T) {
 			},
 			expectedErrs: []string{PkgValidateErrActionClusterNetwork},
 		},
+		{
+			name: "valid typed actions",
+			action: v1alpha1.ZarfComponentAction{
+				Kubectl: &v1alpha1.ZarfComponentActionKubectl{Args: []string{"apply", "-f", "manifest.yaml"}},
+			},
+		},
+		{
+			name: "cmd and typed action both set",
+			action: v1alpha1.ZarfComponentAction{
+				Cmd:      "ls",
+				CopyFile: &v1alpha1.ZarfComponentActionCopyFile{Source: "a", Target: "b"},
+			},
+			expectedErrs: []string{fmt.Sprintf(PkgValidateErrActionKinds, "cmd, copyFile")},
+		},
+		{
+			name: "incomplete typed actions",
+			action: v1alpha1.ZarfComponentAction{
+				Kubectl:     &v1alpha1.ZarfComponentActionKubectl{},
+				HelmUpgrade: &v1alpha1.ZarfComponentActionHelmUpgrade{ReleaseName: "podinfo"},
+				HTTPGet:     &v1alpha1.ZarfComponentActionHTTPGet{Code: 999},
+				CopyFile:    &v1alpha1.ZarfComponentActionCopyFile{Source: "a"},
+			},
+			expectedErrs: []string{
+				PkgValidateErrActionKubectl,
+				PkgValidateErrActionHelmUpgrade,
+				PkgValidateErrActionHTTPGet,
+				fmt.Sprintf(PkgValidateErrActionHTTPGetCode, "", 999),
+				PkgValidateErrActionCopyFile,
+				fmt.Sprintf(PkgValidateErrActionKinds, "kubectl, helmUpgrade, httpGet, copyFile"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -469,3 +694,62 @@ func TestValidateComponentAction(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePackageMirror(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validatePackageMirror(v1alpha1.ZarfPackageMirror{Type: "npm", Source: "package-lock.json"}))
+
+	err := validatePackageMirror(v1alpha1.ZarfPackageMirror{Type: "maven", Source: "https://example.com/pom.xml"})
+	errs := strings.Split(err.Error(), "\n")
+	expectedErrs := []string{
+		fmt.Sprintf(PkgValidateErrPackageMirrorType, "https://example.com/pom.xml", "maven"),
+		fmt.Sprintf(PkgValidateErrPackageMirrorSource, "https://example.com/pom.xml"),
+	}
+	require.ElementsMatch(t, expectedErrs, errs)
+}
+
+func TestValidateRepoOptions(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{Repos: []string{"https://github.com/zarf-dev/zarf.git@v0.40.0"}}
+	require.NoError(t, validateRepoOptions(component, v1alpha1.ZarfRepoOptions{URL: "https://github.com/zarf-dev/zarf.git@v0.40.0", Depth: 1}))
+
+	err := validateRepoOptions(component, v1alpha1.ZarfRepoOptions{URL: "https://github.com/zarf-dev/zarf.git", Depth: -1})
+	errs := strings.Split(err.Error(), "\n")
+	expectedErrs := []string{
+		fmt.Sprintf(PkgValidateErrRepoOptionsURL, "https://github.com/zarf-dev/zarf.git"),
+		fmt.Sprintf(PkgValidateErrRepoOptionsDepth, "https://github.com/zarf-dev/zarf.git"),
+	}
+	require.ElementsMatch(t, expectedErrs, errs)
+}
+
+func TestValidateExports(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata:  v1alpha1.ZarfMetadata{Name: "app"},
+		Variables: []v1alpha1.InteractiveVariable{{Variable: v1alpha1.Variable{Name: "DOMAIN"}}, {Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}}},
+		Imports:   []v1alpha1.PackageImport{{Package: "init", Name: "REGION"}, {Name: "ZONE"}, {Package: "app", Name: "CLUSTER"}},
+		Exports:   []v1alpha1.PackageExport{{Name: "DOMAIN"}, {Name: "REGION"}, {Name: "ENDPOINT"}, {Name: "PASSWORD"}, {Name: "MISSING"}},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name: "component",
+				Actions: v1alpha1.ZarfComponentActions{
+					OnDeploy: v1alpha1.ZarfComponentActionSet{
+						After: []v1alpha1.ZarfComponentAction{{Cmd: "echo", SetVariables: []v1alpha1.Variable{{Name: "ENDPOINT"}}}},
+					},
+				},
+			},
+		},
+	}
+	err := validateExports(pkg)
+	errs := strings.Split(err.Error(), "\n")
+	expectedErrs := []string{
+		fmt.Sprintf(PkgValidateErrImportPackage, "ZONE"),
+		fmt.Sprintf(PkgValidateErrImportSelf, "CLUSTER"),
+		fmt.Sprintf(PkgValidateErrExportSensitive, "PASSWORD"),
+		fmt.Sprintf(PkgValidateErrExportUndefined, "MISSING"),
+	}
+	require.ElementsMatch(t, expectedErrs, errs)
+}
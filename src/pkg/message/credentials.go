@@ -24,8 +24,13 @@ const (
 
 // PrintCredentialTable displays credentials in a table
 func PrintCredentialTable(state *types.ZarfState, componentsToDeploy []types.DeployedComponent) {
-	if len(componentsToDeploy) == 0 {
-		componentsToDeploy = []types.DeployedComponent{{Name: "git-server"}}
+	PrintCredentials(Credentials(state, componentsToDeploy))
+}
+
+// PrintCredentials displays the given credentials in a table
+func PrintCredentials(credentials []types.Credential) {
+	if len(credentials) == 0 {
+		return
 	}
 
 	// Pause the logfile's output to avoid credentials being printed to the log file
@@ -35,27 +40,66 @@ func PrintCredentialTable(state *types.ZarfState, componentsToDeploy []types.Dep
 	}
 
 	loginData := [][]string{}
+	for _, c := range credentials {
+		loginData = append(loginData, []string{c.Application, c.Username, c.Password, c.Connect, c.Key})
+	}
+	header := []string{"Application", "Username", "Password", "Connect", "Get-Creds Key"}
+	TableWithWriter(OutputWriter, header, loginData)
+}
+
+// Credentials returns the credentials of the Zarf services deployed by the components
+func Credentials(state *types.ZarfState, componentsToDeploy []types.DeployedComponent) []types.Credential {
+	if len(componentsToDeploy) == 0 {
+		componentsToDeploy = []types.DeployedComponent{{Name: "git-server"}}
+	}
+
+	keys := []string{}
 	if state.RegistryInfo.IsInternal() {
-		loginData = append(loginData,
-			[]string{"Registry", state.RegistryInfo.PushUsername, state.RegistryInfo.PushPassword, "zarf connect registry", RegistryKey},
-			[]string{"Registry (read-only)", state.RegistryInfo.PullUsername, state.RegistryInfo.PullPassword, "zarf connect registry", RegistryReadKey},
-		)
+		keys = append(keys, RegistryKey, RegistryReadKey)
 	}
 
 	for _, component := range componentsToDeploy {
 		// Show message if including git-server
 		if component.Name == "git-server" {
-			loginData = append(loginData,
-				[]string{"Git", state.GitServer.PushUsername, state.GitServer.PushPassword, "zarf connect git", GitKey},
-				[]string{"Git (read-only)", state.GitServer.PullUsername, state.GitServer.PullPassword, "zarf connect git", GitReadKey},
-				[]string{"Artifact Token", state.ArtifactServer.PushUsername, state.ArtifactServer.PushToken, "zarf connect git", ArtifactKey},
-			)
+			keys = append(keys, GitKey, GitReadKey, ArtifactKey)
 		}
 	}
 
-	if len(loginData) > 0 {
-		header := []string{"Application", "Username", "Password", "Connect", "Get-Creds Key"}
-		TableWithWriter(OutputWriter, header, loginData)
+	credentials := []types.Credential{}
+	for _, key := range keys {
+		credential, _ := ComponentCredential(state, key)
+		credentials = append(credentials, credential)
+	}
+	return credentials
+}
+
+// ComponentCredential returns the credential of a single component by its get-creds key
+func ComponentCredential(state *types.ZarfState, key string) (types.Credential, bool) {
+	switch strings.ToLower(key) {
+	case RegistryKey:
+		return types.Credential{Application: "Registry", Username: state.RegistryInfo.PushUsername, Password: state.RegistryInfo.PushPassword, Connect: "zarf connect registry", Key: RegistryKey}, true
+	case RegistryReadKey:
+		return types.Credential{Application: "Registry (read-only)", Username: state.RegistryInfo.PullUsername, Password: state.RegistryInfo.PullPassword, Connect: "zarf connect registry", Key: RegistryReadKey}, true
+	case GitKey:
+		return types.Credential{Application: "Git", Username: state.GitServer.PushUsername, Password: state.GitServer.PushPassword, Connect: "zarf connect git", Key: GitKey}, true
+	case GitReadKey:
+		return types.Credential{Application: "Git (read-only)", Username: state.GitServer.PullUsername, Password: state.GitServer.PullPassword, Connect: "zarf connect git", Key: GitReadKey}, true
+	case ArtifactKey:
+		return types.Credential{Application: "Artifact Token", Username: state.ArtifactServer.PushUsername, Password: state.ArtifactServer.PushToken, Connect: "zarf connect git", Key: ArtifactKey}, true
+	default:
+		return types.Credential{}, false
+	}
+}
+
+// ConnectCredential returns the credential to log in to the service of a zarf connect target
+func ConnectCredential(state *types.ZarfState, target string) (types.Credential, bool) {
+	switch strings.ToLower(target) {
+	case RegistryKey:
+		return ComponentCredential(state, RegistryKey)
+	case GitKey:
+		return ComponentCredential(state, GitKey)
+	default:
+		return types.Credential{}, false
 	}
 }
 
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestConnectCredential(t *testing.T) {
+	t.Parallel()
+
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{PushUsername: "zarf-push", PushPassword: "push-password", PullUsername: "zarf-pull", PullPassword: "pull-password"},
+		GitServer:    types.GitServerInfo{PushUsername: "zarf-git-user", PushPassword: "git-password"},
+	}
+
+	credential, ok := ConnectCredential(state, "REGISTRY")
+	require.True(t, ok)
+	require.Equal(t, types.Credential{Application: "Registry", Username: "zarf-push", Password: "push-password", Connect: "zarf connect registry", Key: RegistryKey}, credential)
+
+	credential, ok = ConnectCredential(state, "git")
+	require.True(t, ok)
+	require.Equal(t, "zarf-git-user", credential.Username)
+	require.Equal(t, "git-password", credential.Password)
+
+	_, ok = ConnectCredential(state, "injector")
+	require.False(t, ok)
+	_, ok = ComponentCredential(state, "agent")
+	require.False(t, ok)
+}
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package message provides a rich set of functions for displaying messages to the user.
+package message
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+)
+
+// PrintNotes prints the rendered notes of a package or component under a title.
+func PrintNotes(title, notes string) {
+	if notes == "" {
+		return
+	}
+	fmt.Fprintln(OutputWriter)
+	fmt.Fprintln(OutputWriter, pterm.Bold.Sprint(title))
+	fmt.Fprintln(OutputWriter, notes)
+}
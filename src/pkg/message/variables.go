@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package message provides a rich set of functions for displaying messages to the user.
+package message
+
+import (
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// PrintVariableTable prints a table of the variables that are templated during a deployment.
+func PrintVariableTable(summaries []types.VariableSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+	variableData := [][]string{}
+	for _, summary := range summaries {
+		value := summary.Value
+		if summary.Source == types.VariableSourcePrompt && value == "" {
+			value = "(prompted)"
+		}
+		changed := ""
+		if summary.Changed {
+			changed = "yes"
+		}
+		variableData = append(variableData, []string{summary.Name, string(summary.Source), value, summary.Default, changed})
+	}
+	header := []string{"Variable", "Source", "Value", "Default", "Changed"}
+	TableWithWriter(OutputWriter, header, variableData)
+}
@@ -74,6 +74,12 @@ func runAction(ctx context.Context, defaultCfg v1alpha1.ZarfComponentActionDefau
 		action.SetVariables = []v1alpha1.Variable{}
 	}
 
+	// Typed actions run natively in Go, so they are described by what they do instead of a command.
+	native, nativeDescription := Native(action)
+	if native != nil {
+		cmd = nativeDescription
+	}
+
 	if action.Description != "" {
 		cmdEscaped = action.Description
 	} else {
@@ -88,9 +94,11 @@ func runAction(ctx context.Context, defaultCfg v1alpha1.ZarfComponentActionDefau
 
 	actionDefaults := actionGetCfg(ctx, defaultCfg, action, variableConfig.GetAllTemplates())
 
-	if cmd, err = actionCmdMutation(ctx, cmd, actionDefaults.Shell); err != nil {
-		spinner.Errorf(err, "Error mutating command: %s", cmdEscaped)
-		l.Error("error mutating command", "cmd", cmdEscaped, "err", err.Error())
+	if native == nil {
+		if cmd, err = actionCmdMutation(ctx, cmd, actionDefaults.Shell); err != nil {
+			spinner.Errorf(err, "Error mutating command: %s", cmdEscaped)
+			l.Error("error mutating command", "cmd", cmdEscaped, "err", err.Error())
+		}
 	}
 
 	duration := time.Duration(actionDefaults.MaxTotalSeconds) * time.Second
@@ -103,7 +111,13 @@ retryCmd:
 		// Perform the action run.
 		tryCmd := func(ctx context.Context) error {
 			// Try running the command and continue the retry loop if it fails.
-			stdout, stderr, err := actionRun(ctx, actionDefaults, cmd, spinner)
+			var stdout, stderr string
+			var err error
+			if native != nil {
+				stdout, stderr, err = native(ctx, actionDefaults, spinner)
+			} else {
+				stdout, stderr, err = actionRun(ctx, actionDefaults, cmd, spinner)
+			}
 			if err != nil {
 				if !actionDefaults.Mute {
 					l.Warn("action failed", "cmd", cmdEscaped, "stdout", stdout, "stderr", stderr)
@@ -195,6 +209,9 @@ func convertWaitToCmd(_ context.Context, wait v1alpha1.ZarfComponentActionWait,
 		if ns != "" {
 			ns = fmt.Sprintf("-n %s", ns)
 		}
+		if cluster.APIVersion != "" {
+			ns = fmt.Sprintf("%s --api-version %s", ns, cluster.APIVersion)
+		}
 
 		// Build a call to the zarf tools wait-for command.
 		return fmt.Sprintf("./zarf tools wait-for %s %s %s %s %s",
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// NativeRunner runs a typed action in Go instead of in a shell and returns its stdout and stderr. Output is written to
+// out as it is produced unless the action is muted.
+type NativeRunner func(ctx context.Context, cfg v1alpha1.ZarfComponentActionDefaults, out io.Writer) (string, string, error)
+
+// Native returns the runner and a description of the typed action kind of the action, or a nil runner when the action
+// is a cmd or wait action.
+func Native(action v1alpha1.ZarfComponentAction) (NativeRunner, string) {
+	switch {
+	case action.Kubectl != nil:
+		a := *action.Kubectl
+		return func(ctx context.Context, cfg v1alpha1.ZarfComponentActionDefaults, out io.Writer) (string, string, error) {
+			return runKubectl(ctx, a, cfg, out)
+		}, "kubectl " + strings.Join(a.Args, " ")
+	case action.HelmUpgrade != nil:
+		a := *action.HelmUpgrade
+		return func(ctx context.Context, cfg v1alpha1.ZarfComponentActionDefaults, _ io.Writer) (string, string, error) {
+			return runHelmUpgrade(ctx, a, cfg)
+		}, fmt.Sprintf("helm upgrade --install %s %s", a.ReleaseName, a.Chart)
+	case action.HTTPGet != nil:
+		a := *action.HTTPGet
+		return func(ctx context.Context, cfg v1alpha1.ZarfComponentActionDefaults, _ io.Writer) (string, string, error) {
+			return runHTTPGet(ctx, a, cfg)
+		}, "GET " + a.URL
+	case action.CopyFile != nil:
+		a := *action.CopyFile
+		return func(_ context.Context, cfg v1alpha1.ZarfComponentActionDefaults, _ io.Writer) (string, string, error) {
+			return runCopyFile(a, cfg)
+		}, fmt.Sprintf("copy %s to %s", a.Source, a.Target)
+	}
+	return nil, ""
+}
+
+// runKubectl runs the kubectl built into the Zarf binary directly, so the arguments are not interpreted by a shell.
+func runKubectl(ctx context.Context, a v1alpha1.ZarfComponentActionKubectl, cfg v1alpha1.ZarfComponentActionDefaults, out io.Writer) (string, string, error) {
+	executable, err := utils.GetFinalExecutablePath()
+	if err != nil {
+		return "", "", err
+	}
+	zarfCommand, args := zarfCommandArgs(executable, config.ActionsCommandZarfPrefix, config.ActionsUseSystemZarf)
+	args = append(args, "tools", "kubectl")
+	for _, arg := range a.Args {
+		args = append(args, expandEnv(arg, cfg.Env))
+	}
+
+	// TODO(mkcp): Remove message on logger release
+	message.Debugf("Running kubectl %s", strings.Join(args, " "))
+	logger.From(ctx).Debug("running kubectl", "args", args)
+
+	execCfg := exec.Config{
+		Env: cfg.Env,
+		Dir: cfg.Dir,
+	}
+	if !cfg.Mute {
+		execCfg.Stdout = out
+		execCfg.Stderr = out
+	}
+	return exec.CmdWithContext(ctx, execCfg, zarfCommand, args...)
+}
+
+// zarfCommandArgs returns the Zarf command and the arguments of its library prefix. The prefix only applies to the
+// current binary, Zarf from the system path is always run without it.
+func zarfCommandArgs(executable, prefix string, useSystemZarf bool) (string, []string) {
+	if useSystemZarf {
+		return "zarf", nil
+	}
+	return executable, strings.Fields(prefix)
+}
+
+// runHelmUpgrade installs the chart or upgrades the release if it already exists, and returns the notes of the release.
+func runHelmUpgrade(ctx context.Context, a v1alpha1.ZarfComponentActionHelmUpgrade, cfg v1alpha1.ZarfComponentActionDefaults) (string, string, error) {
+	l := logger.From(ctx)
+	releaseName := expandEnv(a.ReleaseName, cfg.Env)
+	namespace := expandEnv(a.Namespace, cfg.Env)
+
+	chartPath := actionPath(cfg.Dir, expandEnv(a.Chart, cfg.Env))
+	loadedChart, err := loader.Load(chartPath)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to load helm chart %s: %w", chartPath, err)
+	}
+	valuesOpts := values.Options{}
+	for _, f := range a.ValuesFiles {
+		valuesOpts.ValueFiles = append(valuesOpts.ValueFiles, actionPath(cfg.Dir, expandEnv(f, cfg.Env)))
+	}
+	chartValues, err := valuesOpts.MergeValues(getter.Providers{})
+	if err != nil {
+		return "", "", fmt.Errorf("unable to read values files: %w", err)
+	}
+
+	settings := cli.New()
+	if namespace != "" {
+		settings.SetNamespace(namespace)
+	}
+	actionConfig := new(action.Configuration)
+	helmLogger := slog.NewLogLogger(l.Handler(), slog.LevelDebug).Printf
+	if err := actionConfig.Init(settings.RESTClientGetter(), settings.Namespace(), "", helmLogger); err != nil {
+		return "", "", fmt.Errorf("unable to initialize the K8s client: %w", err)
+	}
+
+	// Match the default timeout of the helm CLI unless the action has a shorter one.
+	timeout := 5 * time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	histClient := action.NewHistory(actionConfig)
+	histClient.Max = 1
+	var rel *release.Release
+	_, err = histClient.Run(releaseName)
+	switch {
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		l.Info("performing Helm install", "release", releaseName, "chart", chartPath)
+		client := action.NewInstall(actionConfig)
+		client.ReleaseName = releaseName
+		client.Namespace = settings.Namespace()
+		client.CreateNamespace = true
+		client.Wait = a.Wait
+		client.Timeout = timeout
+		client.Labels = config.CommonOptions.Labels
+		rel, err = client.RunWithContext(ctx, loadedChart, chartValues)
+	case err == nil:
+		l.Info("performing Helm upgrade", "release", releaseName, "chart", chartPath)
+		client := action.NewUpgrade(actionConfig)
+		client.Namespace = settings.Namespace()
+		client.Wait = a.Wait
+		client.Timeout = timeout
+		client.Labels = config.CommonOptions.Labels
+		rel, err = client.RunWithContext(ctx, releaseName, loadedChart, chartValues)
+	default:
+		return "", "", fmt.Errorf("unable to verify the installation status of release %s: %w", releaseName, err)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("unable to install or upgrade release %s: %w", releaseName, err)
+	}
+	return rel.Info.Notes, "", nil
+}
+
+// runHTTPGet sends a GET request to the URL and returns the body of the response.
+func runHTTPGet(ctx context.Context, a v1alpha1.ZarfComponentActionHTTPGet, cfg v1alpha1.ZarfComponentActionDefaults) (string, string, error) {
+	url := expandEnv(a.URL, cfg.Env)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		// The body has been read by the time the error could matter.
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	if a.Code == 0 && (resp.StatusCode < 200 || resp.StatusCode > 299) || a.Code != 0 && resp.StatusCode != a.Code {
+		return string(body), "", fmt.Errorf("GET %s returned status %s", url, resp.Status)
+	}
+	return string(body), "", nil
+}
+
+// runCopyFile copies the source file or directory to the target.
+func runCopyFile(a v1alpha1.ZarfComponentActionCopyFile, cfg v1alpha1.ZarfComponentActionDefaults) (string, string, error) {
+	src := actionPath(cfg.Dir, expandEnv(a.Source, cfg.Env))
+	dst := actionPath(cfg.Dir, expandEnv(a.Target, cfg.Env))
+	if err := helpers.CreatePathAndCopy(src, dst); err != nil {
+		return "", "", fmt.Errorf("unable to copy %s to %s: %w", src, dst, err)
+	}
+	return "", "", nil
+}
+
+// actionPath resolves a path of a typed action relative to the working directory of the action.
+func actionPath(dir, path string) string {
+	if dir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// expandEnv replaces ${VAR} and $VAR references in s with the values set in env, falling back to the environment of
+// Zarf, the same way a shell would for a cmd action.
+func expandEnv(s string, env []string) string {
+	vars := map[string]string{}
+	for _, kv := range env {
+		k, v, ok := strings.Cut(kv, "=")
+		if ok {
+			vars[k] = v
+		}
+	}
+	return os.Expand(s, func(k string) string {
+		if v, ok := vars[k]; ok {
+			return v
+		}
+		return os.Getenv(k)
+	})
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestNative(t *testing.T) {
+	t.Parallel()
+
+	run, description := Native(v1alpha1.ZarfComponentAction{Cmd: "ls"})
+	require.Nil(t, run)
+	require.Empty(t, description)
+
+	run, description = Native(v1alpha1.ZarfComponentAction{Kubectl: &v1alpha1.ZarfComponentActionKubectl{Args: []string{"get", "pods"}}})
+	require.NotNil(t, run)
+	require.Equal(t, "kubectl get pods", description)
+}
+
+func TestZarfCommandArgs(t *testing.T) {
+	t.Parallel()
+
+	command, args := zarfCommandArgs("/usr/bin/app", "", false)
+	require.Equal(t, "/usr/bin/app", command)
+	require.Empty(t, args)
+
+	command, args = zarfCommandArgs("/usr/bin/app", "tools zarf", false)
+	require.Equal(t, "/usr/bin/app", command)
+	require.Equal(t, []string{"tools", "zarf"}, args)
+
+	command, args = zarfCommandArgs("/usr/bin/app", "tools zarf", true)
+	require.Equal(t, "zarf", command)
+	require.Empty(t, args)
+}
+
+func TestRunHTTPGet(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name        string
+		action      v1alpha1.ZarfComponentActionHTTPGet
+		expectedErr string
+	}{
+		{
+			name:   "success",
+			action: v1alpha1.ZarfComponentActionHTTPGet{URL: "${SERVER}/ok"},
+		},
+		{
+			name:        "unexpected status",
+			action:      v1alpha1.ZarfComponentActionHTTPGet{URL: "${SERVER}/missing"},
+			expectedErr: "returned status 404 Not Found",
+		},
+		{
+			name:   "expected status",
+			action: v1alpha1.ZarfComponentActionHTTPGet{URL: "${SERVER}/missing", Code: http.StatusNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			run, _ := Native(v1alpha1.ZarfComponentAction{HTTPGet: &tt.action})
+			cfg := v1alpha1.ZarfComponentActionDefaults{Env: []string{"SERVER=" + srv.URL}}
+			stdout, _, err := run(context.Background(), cfg, io.Discard)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "hello", stdout)
+		})
+	}
+}
+
+func TestRunCopyFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "source.txt"), []byte("data"), 0o644)
+	require.NoError(t, err)
+
+	run, _ := Native(v1alpha1.ZarfComponentAction{CopyFile: &v1alpha1.ZarfComponentActionCopyFile{Source: "source.txt", Target: "nested/${NAME}.txt"}})
+	cfg := v1alpha1.ZarfComponentActionDefaults{Dir: dir, Env: []string{"NAME=target"}}
+	_, _, err = run(context.Background(), cfg, io.Discard)
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(dir, "nested", "target.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(b))
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Parallel()
+
+	env := []string{"ZARF_VAR_NAME=first", "ZARF_VAR_NAME=second", "OTHER=value"}
+	require.Equal(t, "second-value", expandEnv("${ZARF_VAR_NAME}-$OTHER", env))
+	require.Equal(t, "plain", expandEnv("plain", env))
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/sigstore/cosign/v2/pkg/signature"
+	sigsig "github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+const (
+	// AttestationSBOM attaches the SBOMs of the images in the package as attestations.
+	AttestationSBOM = "sbom"
+	// AttestationProvenance attaches the SLSA provenance of the package as an attestation.
+	AttestationProvenance = "provenance"
+)
+
+// provenanceBuildType is the SLSA build type of the provenance of Zarf packages.
+const provenanceBuildType = "https://zarf.dev/attestations/package-create/v1"
+
+// ValidateAttestations validates the attestations requested for a publish.
+func ValidateAttestations(attestations []string, signingKeyPath string, keyless bool) error {
+	for _, attestation := range attestations {
+		if attestation != AttestationSBOM && attestation != AttestationProvenance {
+			return fmt.Errorf("invalid attestation %q, must be %s or %s", attestation, AttestationSBOM, AttestationProvenance)
+		}
+	}
+	if len(attestations) > 0 && signingKeyPath == "" && !keyless {
+		return errors.New("attestations are signed with the signing key or keyless, a signing key or --keyless must be provided to attach attestations")
+	}
+	return nil
+}
+
+// attachAttestations signs the requested attestations with the signing key, or keyless, and attaches them to the published package.
+func (p *Packager) attachAttestations(ctx context.Context, remote *zoci.Remote) error {
+	l := logger.From(ctx)
+	predicates := []zoci.Predicate{}
+	if slices.Contains(p.cfg.PublishOpts.Attestations, AttestationSBOM) {
+		sboms, err := sbomPredicates(p.layout.SBOMs)
+		if err != nil {
+			return err
+		}
+		if len(sboms) == 0 {
+			return fmt.Errorf("unable to attach the SBOM attestation, package %s has no SBOMs", p.cfg.Pkg.Metadata.Name)
+		}
+		predicates = append(predicates, sboms...)
+	}
+	if slices.Contains(p.cfg.PublishOpts.Attestations, AttestationProvenance) {
+		provenance, err := provenancePredicate(p.cfg.Pkg)
+		if err != nil {
+			return err
+		}
+		predicates = append(predicates, provenance)
+	}
+
+	// The signer is loaded before the spinner starts, as it can prompt for the key password or the OIDC identity.
+	var attach func() ([]zoci.Attestation, error)
+	if p.cfg.PublishOpts.Keyless {
+		signer, err := utils.NewKeylessSigner(ctx, utils.KeylessOptions{IdentityToken: p.cfg.PublishOpts.IdentityToken})
+		if err != nil {
+			return err
+		}
+		defer signer.Close()
+		attach = func() ([]zoci.Attestation, error) {
+			return remote.AttachKeylessAttestations(ctx, signer, predicates)
+		}
+	} else {
+		signer, err := p.attestationSigner(ctx)
+		if err != nil {
+			return err
+		}
+		attach = func() ([]zoci.Attestation, error) {
+			return remote.AttachAttestations(ctx, signer, predicates)
+		}
+	}
+
+	spinner := message.NewProgressSpinner("Attaching %d attestations", len(predicates))
+	defer spinner.Stop()
+	attestations, err := attach()
+	if err != nil {
+		return err
+	}
+	for _, attestation := range attestations {
+		l.Debug("attached attestation", "predicateType", attestation.PredicateType, "name", attestation.Name, "digest", attestation.Digest)
+	}
+	spinner.Successf("Attached %d attestations", len(attestations))
+	l.Info("attached attestations", "count", len(attestations))
+	return nil
+}
+
+// attestationSigner loads the signing key to sign attestations with.
+func (p *Packager) attestationSigner(ctx context.Context) (sigsig.Signer, error) {
+	passwordFunc := func(_ bool) ([]byte, error) {
+		if p.cfg.PublishOpts.SigningKeyPassword != "" {
+			return []byte(p.cfg.PublishOpts.SigningKeyPassword), nil
+		}
+		if config.CommonOptions.Confirm {
+			return nil, nil
+		}
+		return interactive.PromptSigPassword()
+	}
+	if err := utils.CheckCosignKeyRef(p.cfg.PublishOpts.SigningKeyPath); err != nil {
+		return nil, err
+	}
+	signer, err := signature.SignerFromKeyRef(ctx, p.cfg.PublishOpts.SigningKeyPath, passwordFunc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the signing key: %w", err)
+	}
+	return signer, nil
+}
+
+// sbomPredicates returns a predicate for each of the Syft SBOMs of the package.
+func sbomPredicates(sboms layout.SBOMs) ([]zoci.Predicate, error) {
+	if sboms.Path == "" {
+		return nil, nil
+	}
+	predicates := []zoci.Predicate{}
+	add := func(name string, r io.Reader) error {
+		if filepath.Ext(name) != ".json" {
+			return nil
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if !json.Valid(b) {
+			return fmt.Errorf("SBOM %s is not valid JSON", name)
+		}
+		predicates = append(predicates, zoci.Predicate{Type: zoci.SBOMPredicateType, Name: name, Content: b})
+		return nil
+	}
+
+	if !sboms.IsTarball() {
+		entries, err := os.ReadDir(sboms.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			f, err := os.Open(filepath.Join(sboms.Path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			err = add(entry.Name(), f)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return predicates, nil
+	}
+
+	f, err := os.Open(sboms.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := add(filepath.Base(hdr.Name), tr); err != nil {
+			return nil, err
+		}
+	}
+	return predicates, nil
+}
+
+// slsaResourceDescriptor is a SLSA v1 resource descriptor.
+type slsaResourceDescriptor struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// provenancePredicate returns the SLSA v1 provenance predicate of the package, built from the build data recorded when
+// the package was created.
+func provenancePredicate(pkg v1alpha1.ZarfPackage) (zoci.Predicate, error) {
+	dependencies := []slsaResourceDescriptor{}
+	for _, component := range pkg.Build.Components {
+		for _, image := range component.Images {
+			dependency := slsaResourceDescriptor{URI: "docker://" + image.Name}
+			if d, err := digest.Parse(image.Digest); err == nil {
+				dependency.Digest = map[string]string{d.Algorithm().String(): d.Encoded()}
+			}
+			dependencies = append(dependencies, dependency)
+		}
+		for _, chart := range component.Charts {
+			dependencies = append(dependencies, slsaResourceDescriptor{
+				URI:    "chart://" + chart.Name,
+				Digest: map[string]string{"sha256": chart.Checksum},
+			})
+		}
+	}
+
+	metadata := map[string]any{}
+	if startedOn, err := time.Parse(time.RFC1123Z, pkg.Build.Timestamp); err == nil {
+		metadata["startedOn"] = startedOn.UTC().Format(time.RFC3339)
+	}
+	predicate := map[string]any{
+		"buildDefinition": map[string]any{
+			"buildType": provenanceBuildType,
+			"externalParameters": map[string]any{
+				"name":         pkg.Metadata.Name,
+				"version":      pkg.Metadata.Version,
+				"flavor":       pkg.Build.Flavor,
+				"architecture": pkg.Build.Architecture,
+			},
+			"internalParameters": map[string]any{
+				"registryOverrides": pkg.Build.RegistryOverrides,
+				"differential":      pkg.Build.Differential,
+			},
+			"resolvedDependencies": dependencies,
+		},
+		"runDetails": map[string]any{
+			"builder": map[string]any{
+				"id":      "https://zarf.dev/cli",
+				"version": map[string]string{"zarf": pkg.Build.Version},
+			},
+			"metadata": metadata,
+		},
+	}
+	b, err := json.Marshal(predicate)
+	if err != nil {
+		return zoci.Predicate{}, err
+	}
+	return zoci.Predicate{Type: zoci.ProvenancePredicateType, Content: b}, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+func TestValidateAttestations(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateAttestations(nil, "", false))
+	require.NoError(t, ValidateAttestations([]string{AttestationSBOM, AttestationProvenance}, "cosign.key", false))
+	require.NoError(t, ValidateAttestations([]string{AttestationSBOM, AttestationProvenance}, "", true))
+	require.EqualError(t, ValidateAttestations([]string{"vex"}, "cosign.key", false), `invalid attestation "vex", must be sbom or provenance`)
+	require.ErrorContains(t, ValidateAttestations([]string{AttestationSBOM}, "", false), "a signing key or --keyless must be provided")
+}
+
+func TestProvenancePredicate(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "podinfo", Version: "1.0.0"},
+		Build: v1alpha1.ZarfBuildData{
+			Architecture: "amd64",
+			Timestamp:    "Tue, 02 Sep 2025 00:00:00 +0000",
+			Version:      "v0.50.0",
+			Components: []v1alpha1.ZarfComponentBuildData{
+				{
+					Name:   "podinfo",
+					Images: []v1alpha1.ZarfImageBuildData{{Name: "ghcr.io/stefanprodan/podinfo:6.4.0", Digest: "sha256:57a654ace69ec02ba8973093b6a786faa15640575fbf0dbb603db55aca2ccec8"}},
+					Charts: []v1alpha1.ZarfContentBuildData{{Name: "podinfo", Checksum: "abcd"}},
+				},
+			},
+		},
+	}
+	predicate, err := provenancePredicate(pkg)
+	require.NoError(t, err)
+	require.Equal(t, zoci.ProvenancePredicateType, predicate.Type)
+
+	var provenance struct {
+		BuildDefinition struct {
+			BuildType            string            `json:"buildType"`
+			ExternalParameters   map[string]string `json:"externalParameters"`
+			ResolvedDependencies []struct {
+				URI    string            `json:"uri"`
+				Digest map[string]string `json:"digest"`
+			} `json:"resolvedDependencies"`
+		} `json:"buildDefinition"`
+		RunDetails struct {
+			Metadata map[string]string `json:"metadata"`
+		} `json:"runDetails"`
+	}
+	require.NoError(t, json.Unmarshal(predicate.Content, &provenance))
+	require.Equal(t, provenanceBuildType, provenance.BuildDefinition.BuildType)
+	require.Equal(t, "podinfo", provenance.BuildDefinition.ExternalParameters["name"])
+	require.Equal(t, "amd64", provenance.BuildDefinition.ExternalParameters["architecture"])
+	require.Len(t, provenance.BuildDefinition.ResolvedDependencies, 2)
+	require.Equal(t, "docker://ghcr.io/stefanprodan/podinfo:6.4.0", provenance.BuildDefinition.ResolvedDependencies[0].URI)
+	require.Equal(t, "57a654ace69ec02ba8973093b6a786faa15640575fbf0dbb603db55aca2ccec8", provenance.BuildDefinition.ResolvedDependencies[0].Digest["sha256"])
+	require.Equal(t, "2025-09-02T00:00:00Z", provenance.RunDetails.Metadata["startedOn"])
+}
+
+func TestSBOMPredicates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "podinfo.json"), []byte(`{"artifacts":[]}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sbom-viewer-podinfo.html"), []byte("<html></html>"), 0o644))
+
+	predicates, err := sbomPredicates(layout.SBOMs{Path: dir})
+	require.NoError(t, err)
+	require.Equal(t, []zoci.Predicate{{Type: zoci.SBOMPredicateType, Name: "podinfo.json", Content: []byte(`{"artifacts":[]}`)}}, predicates)
+
+	predicates, err = sbomPredicates(layout.SBOMs{})
+	require.NoError(t, err)
+	require.Empty(t, predicates)
+}
@@ -15,10 +15,13 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/strvals"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
@@ -41,6 +44,26 @@ type Packager struct {
 	layout         *layout.PackagePaths
 	hpaModified    bool
 	source         sources.PackageSource
+	// pushedImages tracks the images pushed during this deployment and whether they were pushed without a checksum.
+	pushedImages map[string]bool
+	// scopedCredentials are the pull credentials minted for the package when deploying with scoped credentials.
+	scopedCredentials *types.ScopedCredentials
+	// target is the cluster alias of the component being deployed, empty for the current kube context.
+	target string
+	// targets holds the connections and state of the other clusters the package is deployed to, keyed by cluster alias.
+	targets map[string]*clusterTarget
+	// deployResult describes the last successful deployment.
+	deployResult types.DeployResult
+	// tunnelPools hold the tunnels opened during a deploy by cluster, so that pushes to the same service reuse them.
+	tunnelPools map[*cluster.Cluster]*cluster.TunnelPool
+}
+
+// clusterTarget is the connection and state of a cluster that components of the package are deployed to.
+type clusterTarget struct {
+	cluster           *cluster.Cluster
+	state             *types.ZarfState
+	scopedCredentials *types.ScopedCredentials
+	hpaModified       bool
 }
 
 // Modifier is a function that modifies the packager.
@@ -152,21 +175,57 @@ func (p *Packager) GetVariableConfig() *variables.VariableConfig {
 	return p.variableConfig
 }
 
+// GetDeployResult returns the result of the last successful deployment of the packager.
+func (p *Packager) GetDeployResult() types.DeployResult {
+	return p.deployResult
+}
+
 // connectToCluster attempts to connect to a cluster if a connection is not already established
 func (p *Packager) connectToCluster(ctx context.Context) error {
 	if p.isConnectedToCluster() {
 		return nil
 	}
 
-	cluster, err := cluster.NewClusterWithWait(ctx)
+	cluster, err := cluster.NewClusterForContextWithWait(ctx, cluster.TargetKubeContext(p.target, p.cfg.DeployOpts.ClusterContexts))
 	if err != nil {
 		return err
 	}
 	p.cluster = cluster
 
+	if p.cfg.DeployOpts.StrictCredentialExpiry {
+		if err := p.cluster.ValidateCredentialExpiry(ctx); err != nil {
+			return err
+		}
+	}
 	return p.attemptClusterChecks(ctx)
 }
 
+// useTarget switches the cluster connection and state of the packager to the cluster with the alias, the connections
+// and state of the other clusters are kept so that switching back does not connect again.
+func (p *Packager) useTarget(alias string) {
+	if alias == p.target {
+		return
+	}
+	if p.targets == nil {
+		p.targets = map[string]*clusterTarget{}
+	}
+	p.targets[p.target] = &clusterTarget{
+		cluster:           p.cluster,
+		state:             p.state,
+		scopedCredentials: p.scopedCredentials,
+		hpaModified:       p.hpaModified,
+	}
+	next, ok := p.targets[alias]
+	if !ok {
+		next = &clusterTarget{}
+	}
+	p.cluster = next.cluster
+	p.state = next.state
+	p.scopedCredentials = next.scopedCredentials
+	p.hpaModified = next.hpaModified
+	p.target = alias
+}
+
 // isConnectedToCluster returns whether the current packager instance is connected to a cluster
 func (p *Packager) isConnectedToCluster() bool {
 	return p.cluster != nil
@@ -209,22 +268,13 @@ func (p *Packager) validatePackageArchitecture(ctx context.Context) error {
 		return nil
 	}
 
-	// Get node architectures
-	nodeList, err := p.cluster.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	architectures, err := nodeArchitectures(ctx, p.cluster)
 	if err != nil {
 		return lang.ErrUnableToCheckArch
 	}
-	if len(nodeList.Items) == 0 {
+	if len(architectures) == 0 {
 		return lang.ErrUnableToCheckArch
 	}
-	archMap := map[string]bool{}
-	for _, node := range nodeList.Items {
-		archMap[node.Status.NodeInfo.Architecture] = true
-	}
-	architectures := []string{}
-	for arch := range archMap {
-		architectures = append(architectures, arch)
-	}
 
 	// Check if the package architecture and the cluster architecture are the same.
 	if !slices.Contains(architectures, p.cfg.Pkg.Metadata.Architecture) {
@@ -234,6 +284,21 @@ func (p *Packager) validatePackageArchitecture(ctx context.Context) error {
 	return nil
 }
 
+// nodeArchitectures returns the distinct architectures of the nodes of the cluster.
+func nodeArchitectures(ctx context.Context, c *cluster.Cluster) ([]string, error) {
+	nodeList, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	architectures := []string{}
+	for _, node := range nodeList.Items {
+		if !slices.Contains(architectures, node.Status.NodeInfo.Architecture) {
+			architectures = append(architectures, node.Status.NodeInfo.Architecture)
+		}
+	}
+	return architectures, nil
+}
+
 // validateLastNonBreakingVersion validates the Zarf CLI version against a package's LastNonBreakingVersion.
 func validateLastNonBreakingVersion(cliVersion, lastNonBreakingVersion string) ([]string, error) {
 	if lastNonBreakingVersion == "" {
@@ -258,3 +323,84 @@ func validateLastNonBreakingVersion(cliVersion, lastNonBreakingVersion string) (
 	}
 	return nil, nil
 }
+
+// validateHelmVersion validates the Helm engine version against the minimum Helm version of a package and warns when
+// it differs from the Helm version the package was created with.
+func validateHelmVersion(engineVersion string, pkg v1alpha1.ZarfPackage) ([]string, error) {
+	if err := helm.ValidateEngineVersion(engineVersion, pkg.Metadata.MinimumHelmVersion); err != nil {
+		return nil, err
+	}
+	if helm.EngineVersionDiffers(engineVersion, pkg.Build.HelmVersion) {
+		return []string{fmt.Sprintf(lang.CmdPackageDeployHelmVersionWarn, pkg.Build.HelmVersion, engineVersion)}, nil
+	}
+	return nil, nil
+}
+
+// validateValuesProfiles validates that every requested values profile is used by a values layer of the package.
+func validateValuesProfiles(pkg v1alpha1.ZarfPackage, profiles []string) error {
+	defined := map[string]bool{}
+	for _, component := range pkg.Components {
+		for _, chart := range component.Charts {
+			for _, layer := range chart.ValuesLayers {
+				defined[layer.Profile] = true
+			}
+		}
+	}
+	for _, profile := range profiles {
+		if !defined[profile] {
+			return fmt.Errorf("values profile %s is not used by any chart values layer in the package", profile)
+		}
+	}
+	return nil
+}
+
+// validateSetHelmValues validates that every Helm value set on deploy is formatted as CHART_NAME.KEY=VALUE and sets a
+// value of a chart in the package.
+func validateSetHelmValues(pkg v1alpha1.ZarfPackage, sets []string) error {
+	charts := map[string]bool{}
+	for _, component := range pkg.Components {
+		for _, chart := range component.Charts {
+			charts[chart.Name] = true
+		}
+	}
+	for _, set := range sets {
+		chartName, _, err := splitSetHelmValue(set)
+		if err != nil {
+			return err
+		}
+		if !charts[chartName] {
+			return fmt.Errorf("chart %s of the Helm value %s is not in the package", chartName, set)
+		}
+		if _, err := chartSetHelmValues([]string{set}, chartName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitSetHelmValue splits a Helm value set on deploy into the name of the chart and the value in the format of helm --set.
+func splitSetHelmValue(set string) (string, string, error) {
+	chartName, value, ok := strings.Cut(set, ".")
+	if !ok || chartName == "" || !strings.Contains(value, "=") {
+		return "", "", fmt.Errorf("invalid Helm value %s, expected CHART_NAME.KEY=VALUE", set)
+	}
+	return chartName, value, nil
+}
+
+// chartSetHelmValues returns the values of the chart set on deploy, later values take precedence over earlier ones.
+func chartSetHelmValues(sets []string, chartName string) (map[string]any, error) {
+	values := map[string]any{}
+	for _, set := range sets {
+		name, value, err := splitSetHelmValue(set)
+		if err != nil {
+			return nil, err
+		}
+		if name != chartName {
+			continue
+		}
+		if err := strvals.ParseInto(value, values); err != nil {
+			return nil, fmt.Errorf("invalid Helm value %s: %w", set, err)
+		}
+	}
+	return values, nil
+}
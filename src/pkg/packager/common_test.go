@@ -183,3 +183,84 @@ func TestValidateLastNonBreakingVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestUseTarget(t *testing.T) {
+	t.Parallel()
+
+	current := &cluster.Cluster{Clientset: fake.NewClientset()}
+	currentState := &types.ZarfState{Distro: "k3s"}
+	p := &Packager{cluster: current, state: currentState, hpaModified: true}
+
+	p.useTarget("workload")
+	require.Equal(t, "workload", p.target)
+	require.Nil(t, p.cluster)
+	require.Nil(t, p.state)
+	require.False(t, p.hpaModified)
+
+	workload := &cluster.Cluster{Clientset: fake.NewClientset()}
+	p.cluster = workload
+	p.state = &types.ZarfState{Distro: "eks"}
+
+	p.useTarget("")
+	require.Same(t, current, p.cluster)
+	require.Same(t, currentState, p.state)
+	require.True(t, p.hpaModified)
+
+	p.useTarget("workload")
+	require.Same(t, workload, p.cluster)
+	require.Equal(t, "eks", p.state.Distro)
+}
+
+func TestValidateValuesProfiles(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Charts: []v1alpha1.ZarfChart{
+					{
+						ValuesLayers: []v1alpha1.ZarfValuesLayer{
+							{Files: []string{"common.yaml"}},
+							{Profile: "production", Files: []string{"production.yaml"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, validateValuesProfiles(pkg, nil))
+	require.NoError(t, validateValuesProfiles(pkg, []string{"production"}))
+	require.EqualError(t, validateValuesProfiles(pkg, []string{"staging"}), "values profile staging is not used by any chart values layer in the package")
+}
+
+func TestValidateHelmVersion(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{MinimumHelmVersion: "3.15.0"},
+		Build:    v1alpha1.ZarfBuildData{HelmVersion: "v3.16.2"},
+	}
+	warnings, err := validateHelmVersion("v3.16.4", pkg)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	warnings, err = validateHelmVersion("v3.17.0", pkg)
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf(lang.CmdPackageDeployHelmVersionWarn, "v3.16.2", "v3.17.0")}, warnings)
+	_, err = validateHelmVersion("v3.14.0", pkg)
+	require.EqualError(t, err, "the package requires helm 3.15.0 or newer but this Zarf is built with helm v3.14.0")
+}
+
+func TestValidateSetHelmValues(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Charts: []v1alpha1.ZarfChart{{Name: "podinfo"}}},
+		},
+	}
+	require.NoError(t, validateSetHelmValues(pkg, nil))
+	require.NoError(t, validateSetHelmValues(pkg, []string{"podinfo.nodeSelector.disk=ssd", "podinfo.tolerations[0].key=gpu"}))
+	require.EqualError(t, validateSetHelmValues(pkg, []string{"nginx.replicaCount=2"}), "chart nginx of the Helm value nginx.replicaCount=2 is not in the package")
+	require.EqualError(t, validateSetHelmValues(pkg, []string{"podinfo=2"}), "invalid Helm value podinfo=2, expected CHART_NAME.KEY=VALUE")
+	require.EqualError(t, validateSetHelmValues(pkg, []string{"podinfo.replicaCount"}), "invalid Helm value podinfo.replicaCount, expected CHART_NAME.KEY=VALUE")
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package composer contains functions for composing components within Zarf packages.
+package composer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// Override is a field that a component in the import chain sets over the value of a component it imports.
+type Override struct {
+	// Field is the path of the overridden field in the component.
+	Field string
+	// Value is the value set by the importing component.
+	Value string
+	// Previous is the value that was overridden.
+	Previous string
+	// PreviousLocation is the import location of the component that set the overridden value.
+	PreviousLocation string
+}
+
+type componentField struct {
+	field string
+	value string
+}
+
+// Overrides returns the fields that each node in the import chain overrides when the chain is composed.
+func (ic *ImportChain) Overrides() map[*Node][]Override {
+	type origin struct {
+		value    string
+		location string
+	}
+	overrides := map[*Node][]Override{}
+	origins := map[string]origin{}
+	// Compose overrides from the tail to the head, so the values of each node are compared to the values of the nodes
+	// it imports.
+	for node := ic.tail; node != nil; node = node.prev {
+		for _, f := range overridableFields(node.ZarfComponent) {
+			prev, ok := origins[f.field]
+			if ok && prev.value != f.value {
+				overrides[node] = append(overrides[node], Override{
+					Field:            f.field,
+					Value:            f.value,
+					Previous:         prev.value,
+					PreviousLocation: prev.location,
+				})
+			}
+			origins[f.field] = origin{value: f.value, location: node.ImportLocation()}
+		}
+	}
+	return overrides
+}
+
+// overridableFields returns the fields of the component that replace the values of the components it imports, following
+// the rules of overrideMetadata, overrideActions and overrideResources.
+func overridableFields(c v1alpha1.ZarfComponent) []componentField {
+	fields := []componentField{
+		{"name", formatValue(c.Name)},
+	}
+	if c.Description != "" {
+		fields = append(fields, componentField{"description", formatValue(c.Description)})
+	}
+	if c.Cluster != "" {
+		fields = append(fields, componentField{"cluster", formatValue(c.Cluster)})
+	}
+	if c.Notes != "" {
+		fields = append(fields, componentField{"notes", formatValue(c.Notes)})
+	}
+	fields = append(fields,
+		componentField{"default", formatValue(c.Default)},
+		componentField{"required", formatValue(c.Required)},
+		componentField{"actions.onCreate.defaults", formatValue(c.Actions.OnCreate.Defaults)},
+		componentField{"actions.onDeploy.defaults", formatValue(c.Actions.OnDeploy.Defaults)},
+		componentField{"actions.onRemove.defaults", formatValue(c.Actions.OnRemove.Defaults)},
+	)
+	for _, chart := range c.Charts {
+		if chart.Namespace != "" {
+			fields = append(fields, componentField{fmt.Sprintf("charts[%s].namespace", chart.Name), formatValue(chart.Namespace)})
+		}
+		if chart.ReleaseName != "" {
+			fields = append(fields, componentField{fmt.Sprintf("charts[%s].releaseName", chart.Name), formatValue(chart.ReleaseName)})
+		}
+	}
+	for _, manifest := range c.Manifests {
+		if manifest.Namespace != "" {
+			fields = append(fields, componentField{fmt.Sprintf("manifests[%s].namespace", manifest.Name), formatValue(manifest.Namespace)})
+		}
+	}
+	return fields
+}
+
+func formatValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// describeNode returns where the component of the node was loaded from.
+func describeNode(n *Node) string {
+	source := fmt.Sprintf("package %s, component %d", n.originalPackageName, n.index)
+	if n.prev == nil {
+		return source
+	}
+	if n.prev.Import.URL != "" {
+		return fmt.Sprintf("OCI skeleton %s, %s", n.ImportLocation(), source)
+	}
+	return fmt.Sprintf("local path %s, %s", n.ImportLocation(), source)
+}
+
+// Tree returns the import chain as a tree with the source of each component and the fields it overrides.
+func (ic *ImportChain) Tree() string {
+	overrides := ic.Overrides()
+	s := strings.Builder{}
+	depth := 0
+	for node := ic.head; node != nil; node = node.next {
+		prefix := ""
+		if depth > 0 {
+			prefix = strings.Repeat("    ", depth-1) + "└── "
+		}
+		fmt.Fprintf(&s, "%s%s (%s)\n", prefix, node.Name, describeNode(node))
+
+		overridePrefix := strings.Repeat("    ", depth) + "    "
+		if node.next != nil {
+			overridePrefix = strings.Repeat("    ", depth) + "│   "
+		}
+		for _, o := range overrides[node] {
+			fmt.Fprintf(&s, "%s%s: %s overrides %s from %s\n", overridePrefix, o.Field, o.Value, o.Previous, o.PreviousLocation)
+		}
+		depth++
+	}
+	return s.String()
+}
+
+// ImportChainsDOT returns the import chains as a graph in the DOT language, with the source of each component and the
+// fields it overrides in the label of its node.
+func ImportChainsDOT(chains []*ImportChain) string {
+	s := strings.Builder{}
+	s.WriteString("digraph imports {\n")
+	s.WriteString("  node [shape=box];\n")
+	for i, ic := range chains {
+		overrides := ic.Overrides()
+		depth := 0
+		for node := ic.head; node != nil; node = node.next {
+			lines := []string{node.Name, describeNode(node)}
+			for _, o := range overrides[node] {
+				lines = append(lines, fmt.Sprintf("%s: %s overrides %s from %s", o.Field, o.Value, o.Previous, o.PreviousLocation))
+			}
+			id := fmt.Sprintf("%d.%d", i, depth)
+			fmt.Fprintf(&s, "  %q [label=%q];\n", id, strings.Join(lines, "\n"))
+			if node.prev != nil {
+				fmt.Fprintf(&s, "  %q -> %q;\n", fmt.Sprintf("%d.%d", i, depth-1), id)
+			}
+			depth++
+		}
+	}
+	s.WriteString("}\n")
+	return s.String()
+}
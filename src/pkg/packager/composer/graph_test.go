@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package composer contains functions for composing components within Zarf packages.
+package composer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestImportChainOverrides(t *testing.T) {
+	t.Parallel()
+
+	ic := createChainFromSlice(t, []v1alpha1.ZarfComponent{
+		{
+			Name:   "web",
+			Import: v1alpha1.ZarfComponentImport{Path: "base"},
+			Charts: []v1alpha1.ZarfChart{{Name: "podinfo", Namespace: "web"}},
+		},
+		{
+			Name:        "web",
+			Description: "base web",
+			Import:      v1alpha1.ZarfComponentImport{Path: "podinfo"},
+		},
+		{
+			Name:        "podinfo",
+			Description: "podinfo",
+			Charts:      []v1alpha1.ZarfChart{{Name: "podinfo", Namespace: "podinfo"}},
+		},
+	})
+
+	overrides := ic.Overrides()
+	require.Equal(t, []Override{
+		{Field: "charts[podinfo].namespace", Value: `"web"`, Previous: `"podinfo"`, PreviousLocation: "base/podinfo"},
+	}, overrides[ic.Head()])
+	require.Equal(t, []Override{
+		{Field: "name", Value: `"web"`, Previous: `"podinfo"`, PreviousLocation: "base/podinfo"},
+		{Field: "description", Value: `"base web"`, Previous: `"podinfo"`, PreviousLocation: "base/podinfo"},
+	}, overrides[ic.Head().Next()])
+	require.Empty(t, overrides[ic.Tail()])
+
+	expectedTree := `web (package test-package, component 0)
+│   charts[podinfo].namespace: "web" overrides "podinfo" from base/podinfo
+└── web (local path base, package test-package, component 1)
+    │   name: "web" overrides "podinfo" from base/podinfo
+    │   description: "base web" overrides "podinfo" from base/podinfo
+    └── podinfo (local path base/podinfo, package test-package, component 2)
+`
+	require.Equal(t, expectedTree, ic.Tree())
+
+	expectedDOT := `digraph imports {
+  node [shape=box];
+  "0.0" [label="web\npackage test-package, component 0\ncharts[podinfo].namespace: \"web\" overrides \"podinfo\" from base/podinfo"];
+  "0.1" [label="web\nlocal path base, package test-package, component 1\nname: \"web\" overrides \"podinfo\" from base/podinfo\ndescription: \"base web\" overrides \"podinfo\" from base/podinfo"];
+  "0.0" -> "0.1";
+  "0.2" [label="podinfo\nlocal path base/podinfo, package test-package, component 2"];
+  "0.1" -> "0.2";
+}
+`
+	require.Equal(t, expectedDOT, ImportChainsDOT([]*ImportChain{ic}))
+}
@@ -384,3 +384,19 @@ func CompatibleComponent(c v1alpha1.ZarfComponent, arch, flavor string) bool {
 	satisfiesFlavor := c.Only.Flavor == "" || c.Only.Flavor == flavor
 	return satisfiesArch && satisfiesFlavor
 }
+
+// SelectValuesLayers leaves out the values layers of the component charts that are for another flavor.
+func SelectValuesLayers(c v1alpha1.ZarfComponent, flavor string) v1alpha1.ZarfComponent {
+	for chartIdx, chart := range c.Charts {
+		layers := []v1alpha1.ZarfValuesLayer{}
+		for _, layer := range chart.ValuesLayers {
+			if layer.Flavor != "" && layer.Flavor != flavor {
+				continue
+			}
+			layer.Flavor = ""
+			layers = append(layers, layer)
+		}
+		c.Charts[chartIdx].ValuesLayers = layers
+	}
+	return c
+}
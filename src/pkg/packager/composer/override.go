@@ -6,6 +6,7 @@ package composer
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 )
@@ -20,6 +21,16 @@ func overrideMetadata(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent
 		c.Description = override.Description
 	}
 
+	// Override the cluster if it was provided.
+	if override.Cluster != "" {
+		c.Cluster = override.Cluster
+	}
+
+	// Override notes if they were provided.
+	if override.Notes != "" {
+		c.Notes = override.Notes
+	}
+
 	if override.Only.LocalOS != "" {
 		if c.Only.LocalOS != "" {
 			return fmt.Errorf("component %q: \"only.localOS\" %q cannot be redefined as %q during compose", c.Name, c.Only.LocalOS, override.Only.LocalOS)
@@ -82,6 +93,8 @@ func overrideResources(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponen
 	c.Files = append(c.Files, override.Files...)
 	c.Images = append(c.Images, override.Images...)
 	c.Repos = append(c.Repos, override.Repos...)
+	c.RepoOptions = overrideRepoOptions(c.RepoOptions, override.RepoOptions)
+	c.PackageMirrors = append(c.PackageMirrors, override.PackageMirrors...)
 
 	// Merge charts with the same name to keep them unique
 	for _, overrideChart := range override.Charts {
@@ -95,6 +108,7 @@ func overrideResources(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponen
 					c.Charts[idx].ReleaseName = overrideChart.ReleaseName
 				}
 				c.Charts[idx].ValuesFiles = append(c.Charts[idx].ValuesFiles, overrideChart.ValuesFiles...)
+				c.Charts[idx].ValuesLayers = append(c.Charts[idx].ValuesLayers, overrideChart.ValuesLayers...)
 				c.Charts[idx].Variables = append(c.Charts[idx].Variables, overrideChart.Variables...)
 				existing = true
 			}
@@ -125,3 +139,15 @@ func overrideResources(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponen
 		}
 	}
 }
+
+// overrideRepoOptions replaces the repo options of the imported component with the options for the same url of the
+// importing component.
+func overrideRepoOptions(opts []v1alpha1.ZarfRepoOptions, overrides []v1alpha1.ZarfRepoOptions) []v1alpha1.ZarfRepoOptions {
+	var merged []v1alpha1.ZarfRepoOptions
+	for _, o := range opts {
+		if !slices.ContainsFunc(overrides, func(override v1alpha1.ZarfRepoOptions) bool { return override.URL == o.URL }) {
+			merged = append(merged, o)
+		}
+	}
+	return append(merged, overrides...)
+}
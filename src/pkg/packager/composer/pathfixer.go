@@ -9,10 +9,11 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 )
 
 func makePathRelativeTo(path, relativeTo string) string {
-	if helpers.IsURL(path) {
+	if helpers.IsURL(path) || files.IsGit(path) {
 		return path
 	}
 
@@ -30,6 +31,12 @@ func fixPaths(child *v1alpha1.ZarfComponent, relativeToHead string) {
 			composed := makePathRelativeTo(valuesFile, relativeToHead)
 			child.Charts[chartIdx].ValuesFiles[valuesIdx] = composed
 		}
+		for layerIdx, layer := range chart.ValuesLayers {
+			for valuesIdx, valuesFile := range layer.Files {
+				composed := makePathRelativeTo(valuesFile, relativeToHead)
+				child.Charts[chartIdx].ValuesLayers[layerIdx].Files[valuesIdx] = composed
+			}
+		}
 		if child.Charts[chartIdx].LocalPath != "" {
 			composed := makePathRelativeTo(chart.LocalPath, relativeToHead)
 			child.Charts[chartIdx].LocalPath = composed
@@ -57,6 +64,11 @@ func fixPaths(child *v1alpha1.ZarfComponent, relativeToHead string) {
 		child.DataInjections[dataInjectionsIdx].Source = composed
 	}
 
+	for mirrorIdx, mirror := range child.PackageMirrors {
+		composed := makePathRelativeTo(mirror.Source, relativeToHead)
+		child.PackageMirrors[mirrorIdx].Source = composed
+	}
+
 	defaultDir := child.Actions.OnCreate.Defaults.Dir
 	child.Actions.OnCreate.Before = fixActionPaths(child.Actions.OnCreate.Before, defaultDir, relativeToHead)
 	child.Actions.OnCreate.After = fixActionPaths(child.Actions.OnCreate.After, defaultDir, relativeToHead)
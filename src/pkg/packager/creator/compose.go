@@ -46,7 +46,7 @@ func ComposeComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor str
 		if err != nil {
 			return v1alpha1.ZarfPackage{}, nil, err
 		}
-		components = append(components, *composed)
+		components = append(components, composer.SelectValuesLayers(*composed, flavor))
 
 		// merge variables and constants
 		pkgVars = chain.MergeVariables(pkgVars)
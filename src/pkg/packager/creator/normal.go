@@ -17,11 +17,12 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
-	"github.com/mholt/archiver/v3"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/git"
+	"github.com/zarf-dev/zarf/src/internal/packagemirror"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
@@ -36,6 +37,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -130,39 +132,33 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 	var imageList []transform.Image
 	l := logger.From(ctx)
 
-	skipSBOMFlagUsed := pc.createOpts.SkipSBOM
 	componentSBOMs := map[string]*layout.ComponentSBOM{}
 
-	for _, component := range components {
-		onCreate := component.Actions.OnCreate
-
-		onFailure := func() {
-			if err := actions.Run(ctx, onCreate.Defaults, onCreate.OnFailure, nil); err != nil {
-				// TODO(mkcp): Remove message on logger release
-				message.Debugf("unable to run component failure action: %s", err.Error())
-				l.Debug("unable to run component failure action", "error", err.Error())
+	// Components are assembled in parallel up to the create concurrency, each with its own log attributes.
+	assembledSBOMs := make([]*layout.ComponentSBOM, len(components))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(pc.createOpts.CreateConcurrency, 1))
+	for i, component := range components {
+		g.Go(func() error {
+			if err := gCtx.Err(); err != nil {
+				return err
 			}
-		}
-
-		if err := pc.addComponent(ctx, component, dst); err != nil {
-			onFailure()
-			return fmt.Errorf("unable to add component %q: %w", component.Name, err)
-		}
-
-		// TODO(mkcp): Migrate to logger
-		if err := actions.Run(ctx, onCreate.Defaults, onCreate.OnSuccess, nil); err != nil {
-			onFailure()
-			return fmt.Errorf("unable to run component success action: %w", err)
-		}
-
-		if !skipSBOMFlagUsed {
-			componentSBOM, err := pc.getFilesToSBOM(component, dst)
+			componentCtx := logger.WithContext(gCtx, l.With("component", component.Name))
+			componentSBOM, err := pc.assembleComponent(componentCtx, component, dst)
 			if err != nil {
-				return fmt.Errorf("unable to create component SBOM: %w", err)
-			}
-			if componentSBOM != nil && len(componentSBOM.Files) > 0 {
-				componentSBOMs[component.Name] = componentSBOM
+				return err
 			}
+			assembledSBOMs[i] = componentSBOM
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	for i, component := range components {
+		if componentSBOM := assembledSBOMs[i]; componentSBOM != nil && len(componentSBOM.Files) > 0 {
+			componentSBOMs[component.Name] = componentSBOM
 		}
 
 		// Combine all component images into a single entry for efficient layer reuse.
@@ -197,6 +193,7 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 			Arch:                 arch,
 			RegistryOverrides:    pc.createOpts.RegistryOverrides,
 			CacheDirectory:       filepath.Join(cachePath, layout.ImagesDir),
+			FlattenImages:        pc.createOpts.FlattenImages,
 		}
 
 		pulled, err := images.Pull(ctx, pullCfg)
@@ -225,7 +222,7 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 	}
 
 	// Ignore SBOM creation if the flag is set.
-	if skipSBOMFlagUsed {
+	if pc.createOpts.SkipSBOM {
 		// TODO(mkcp): Remove message on logger release
 		message.Debug("Skipping image SBOM processing per --skip-sbom flag")
 		l.Debug("skipping image SBOM processing per --skip-sbom flag")
@@ -281,7 +278,14 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 	}
 
 	// Sign the package if a key has been provided
-	if err := dst.SignPackage(pc.createOpts.SigningKeyPath, pc.createOpts.SigningKeyPassword, !config.CommonOptions.Confirm); err != nil {
+	if pc.createOpts.SignChecksums && (pc.createOpts.SigningKeyPath != "" || pc.createOpts.Keyless) {
+		dst.ChecksumsSignature = filepath.Join(dst.Base, layout.ChecksumsSignature)
+	}
+	if pc.createOpts.Keyless {
+		if err := dst.SignPackageKeyless(utils.KeylessOptions{IdentityToken: pc.createOpts.IdentityToken}); err != nil {
+			return err
+		}
+	} else if err := dst.SignPackage(pc.createOpts.SigningKeyPath, pc.createOpts.SigningKeyPassword, pc.createOpts.TSAURL, !config.CommonOptions.Confirm); err != nil {
 		return err
 	}
 
@@ -296,7 +300,12 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 		if err != nil {
 			return err
 		}
-		err = remote.PublishPackage(ctx, pkg, dst, config.CommonOptions.OCIConcurrency)
+		publishOpts := zoci.PublishOptions{
+			Concurrency: config.CommonOptions.OCIConcurrency,
+			Retries:     config.ZarfDefaultRetries,
+			RetryDelay:  zoci.PublishRetryDelay,
+		}
+		err = remote.PublishPackage(ctx, pkg, dst, publishOpts)
 		if err != nil {
 			return fmt.Errorf("unable to publish package: %w", err)
 		}
@@ -359,6 +368,41 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 	return nil
 }
 
+// assembleComponent adds the component to the package, running its success or failure actions,
+// and returns the files of the component to include in the SBOM.
+func (pc *PackageCreator) assembleComponent(ctx context.Context, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) (*layout.ComponentSBOM, error) {
+	l := logger.From(ctx)
+	onCreate := component.Actions.OnCreate
+
+	onFailure := func() {
+		if err := actions.Run(ctx, onCreate.Defaults, onCreate.OnFailure, nil); err != nil {
+			// TODO(mkcp): Remove message on logger release
+			message.Debugf("unable to run component failure action: %s", err.Error())
+			l.Debug("unable to run component failure action", "error", err.Error())
+		}
+	}
+
+	if err := pc.addComponent(ctx, component, dst); err != nil {
+		onFailure()
+		return nil, fmt.Errorf("unable to add component %q: %w", component.Name, err)
+	}
+
+	// TODO(mkcp): Migrate to logger
+	if err := actions.Run(ctx, onCreate.Defaults, onCreate.OnSuccess, nil); err != nil {
+		onFailure()
+		return nil, fmt.Errorf("unable to run component success action: %w", err)
+	}
+
+	if pc.createOpts.SkipSBOM {
+		return nil, nil
+	}
+	componentSBOM, err := pc.getFilesToSBOM(component, dst)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create component SBOM: %w", err)
+	}
+	return componentSBOM, nil
+}
+
 // TODO(mkcp): Refactor addComponent to better segment component handling logic by its type. There's also elaborate
 // if/elses that can be de-nested.
 func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) error {
@@ -391,33 +435,17 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 		dst := filepath.Join(componentPaths.Base, rel)
 		destinationDir := filepath.Dir(dst)
 
-		if helpers.IsURL(file.Source) {
-			if file.ExtractPath != "" {
-				// get the compressedFileName from the source
-				compressedFileName, err := helpers.ExtractBasePathFromURL(file.Source)
-				if err != nil {
-					return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err.Error())
-				}
-
-				compressedFile := filepath.Join(componentPaths.Temp, compressedFileName)
-
-				// If the file is an archive, download it to the componentPath.Temp
-				if err := utils.DownloadToFile(ctx, file.Source, compressedFile, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
-				}
-
-				err = archiver.Extract(compressedFile, file.ExtractPath, destinationDir)
-				if err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, compressedFileName, err.Error())
-				}
-			} else {
-				if err := utils.DownloadToFile(ctx, file.Source, dst, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
-				}
+		if file.Extract {
+			if err := files.Unpack(ctx, file, "", dst, component.DeprecatedCosignKeyPath); err != nil {
+				return err
+			}
+		} else if files.IsRemote(file.Source) {
+			if err := files.Pull(ctx, file, dst, component.DeprecatedCosignKeyPath); err != nil {
+				return err
 			}
 		} else {
 			if file.ExtractPath != "" {
-				if err := archiver.Extract(file.Source, file.ExtractPath, destinationDir); err != nil {
+				if err := files.Extract(file.Source, file.ExtractFormat, file.ExtractPath, destinationDir); err != nil {
 					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
 				}
 			} else {
@@ -437,8 +465,8 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 			}
 		}
 
-		// Abort packaging on invalid shasum (if one is specified).
-		if file.Shasum != "" {
+		// Abort packaging on invalid shasum (if one is specified), extracted archives are verified before they are extracted.
+		if file.Shasum != "" && !file.Extract {
 			if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
 				return err
 			}
@@ -530,7 +558,7 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 				rel := filepath.Join(layout.ManifestsDir, kname)
 				dst := filepath.Join(componentPaths.Base, rel)
 
-				if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory); err != nil {
+				if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory, manifest.KustomizeOptions); err != nil {
 					return fmt.Errorf("unable to build kustomization %s: %w", path, err)
 				}
 			}
@@ -552,7 +580,7 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 
 		for _, url := range component.Repos {
 			// Pull all the references if there is no `@` in the string.
-			_, err := git.Clone(ctx, componentPaths.Repos, url, false)
+			_, err := git.CloneWithOptions(ctx, componentPaths.Repos, url, git.NewCloneOptions(component.GetRepoOptions(url)))
 			if err != nil {
 				return fmt.Errorf("unable to pull git repo %s: %w", url, err)
 			}
@@ -561,6 +589,13 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 		l.Debug("done loading git repos", "component", component.Name, "duration", time.Since(reposStart))
 	}
 
+	for mirrorIdx, mirror := range component.PackageMirrors {
+		l.Info("pulling packages to mirror", "component", component.Name, "type", mirror.Type, "source", mirror.Source)
+		if err := packagemirror.Pull(ctx, mirror, mirror.Source, filepath.Join(componentPaths.Mirrors, strconv.Itoa(mirrorIdx))); err != nil {
+			return err
+		}
+	}
+
 	if err := actions.Run(ctx, onCreate.Defaults, onCreate.After, nil); err != nil {
 		return fmt.Errorf("unable to run component after action: %w", err)
 	}
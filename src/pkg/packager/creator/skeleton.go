@@ -13,10 +13,10 @@ import (
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
-	"github.com/mholt/archiver/v3"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
@@ -118,7 +118,10 @@ func (sc *SkeletonCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 		return fmt.Errorf("unable to write zarf.yaml: %w", err)
 	}
 
-	return dst.SignPackage(sc.publishOpts.SigningKeyPath, sc.publishOpts.SigningKeyPassword, !config.CommonOptions.Confirm)
+	if sc.publishOpts.Keyless {
+		return dst.SignPackageKeyless(utils.KeylessOptions{IdentityToken: sc.publishOpts.IdentityToken})
+	}
+	return dst.SignPackage(sc.publishOpts.SigningKeyPath, sc.publishOpts.SigningKeyPassword, sc.publishOpts.TSAURL, !config.CommonOptions.Confirm)
 }
 
 func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) (updatedComponent *v1alpha1.ZarfComponent, err error) {
@@ -183,10 +186,27 @@ func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.
 				return nil, fmt.Errorf("unable to copy chart values file %s: %w", path, err)
 			}
 		}
+
+		// Values layer files are stored after the values files.
+		valuesIdx := len(chart.ValuesFiles)
+		for layerIdx, layer := range chart.ValuesLayers {
+			for fileIdx, path := range layer.Files {
+				rel := fmt.Sprintf("%s-%d", helm.StandardName(layout.ValuesDir, chart), valuesIdx)
+				valuesIdx++
+				if helpers.IsURL(path) {
+					continue
+				}
+				updatedComponent.Charts[chartIdx].ValuesLayers[layerIdx].Files[fileIdx] = rel
+
+				if err := helpers.CreatePathAndCopy(path, filepath.Join(componentPaths.Base, rel)); err != nil {
+					return nil, fmt.Errorf("unable to copy chart values file %s: %w", path, err)
+				}
+			}
+		}
 	}
 
 	for filesIdx, file := range component.Files {
-		if helpers.IsURL(file.Source) {
+		if files.IsRemote(file.Source) {
 			continue
 		}
 
@@ -194,8 +214,12 @@ func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.
 		dst := filepath.Join(componentPaths.Base, rel)
 		destinationDir := filepath.Dir(dst)
 
-		if file.ExtractPath != "" {
-			if err := archiver.Extract(file.Source, file.ExtractPath, destinationDir); err != nil {
+		if file.Extract {
+			if err := files.Unpack(ctx, file, "", dst, ""); err != nil {
+				return nil, err
+			}
+		} else if file.ExtractPath != "" {
+			if err := files.Extract(file.Source, file.ExtractFormat, file.ExtractPath, destinationDir); err != nil {
 				return nil, fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
 			}
 
@@ -215,11 +239,19 @@ func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.
 		// Change the source to the new relative source directory (any remote files will have been skipped above)
 		updatedComponent.Files[filesIdx].Source = rel
 
-		// Remove the extractPath from a skeleton since it will already extract it
+		// Remove the extract options from a skeleton since it will already extract it
 		updatedComponent.Files[filesIdx].ExtractPath = ""
+		updatedComponent.Files[filesIdx].ExtractFormat = ""
+		if file.Extract {
+			// The shasum of an extracted archive no longer applies to the extracted directory.
+			updatedComponent.Files[filesIdx].Extract = false
+			updatedComponent.Files[filesIdx].StripComponents = 0
+			updatedComponent.Files[filesIdx].ExtractMembers = nil
+			updatedComponent.Files[filesIdx].Shasum = ""
+		}
 
-		// Abort packaging on invalid shasum (if one is specified).
-		if file.Shasum != "" {
+		// Abort packaging on invalid shasum (if one is specified), extracted archives are verified before they are extracted.
+		if file.Shasum != "" && !file.Extract {
 			if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
 				return nil, err
 			}
@@ -253,6 +285,17 @@ func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.
 		spinner.Success()
 	}
 
+	for mirrorIdx, mirror := range component.PackageMirrors {
+		rel := filepath.Join(layout.MirrorsDir, strconv.Itoa(mirrorIdx), filepath.Base(mirror.Source))
+		dst := filepath.Join(componentPaths.Base, rel)
+
+		if err := helpers.CreatePathAndCopy(mirror.Source, dst); err != nil {
+			return nil, fmt.Errorf("unable to copy package mirror source %s: %w", mirror.Source, err)
+		}
+
+		updatedComponent.PackageMirrors[mirrorIdx].Source = rel
+	}
+
 	if len(component.Manifests) > 0 {
 		// Get the proper count of total manifests to add.
 		manifestCount := 0
@@ -292,13 +335,14 @@ func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.
 				rel := filepath.Join(layout.ManifestsDir, kname)
 				dst := filepath.Join(componentPaths.Base, rel)
 
-				if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory); err != nil {
+				if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory, manifest.KustomizeOptions); err != nil {
 					return nil, fmt.Errorf("unable to build kustomization %s: %w", path, err)
 				}
 			}
 
 			// remove kustomizations
 			updatedComponent.Manifests[manifestIdx].Kustomizations = nil
+			updatedComponent.Manifests[manifestIdx].KustomizeOptions = nil
 		}
 
 		spinner.Success()
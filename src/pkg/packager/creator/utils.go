@@ -77,6 +77,8 @@ func recordPackageMetadata(pkg *v1alpha1.ZarfPackage, createOpts types.ZarfCreat
 
 	pkg.Build.RegistryOverrides = createOpts.RegistryOverrides
 
+	pkg.Build.FlattenedImages = createOpts.FlattenImages
+
 	// Record the latest version of Zarf without breaking changes to the package structure.
 	pkg.Build.LastNonBreakingVersion = deprecated.LastNonBreakingVersion
 
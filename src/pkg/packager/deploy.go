@@ -6,7 +6,9 @@ package packager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -22,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cli-utils/pkg/object"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
@@ -29,6 +32,8 @@ import (
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/gitea"
 	"github.com/zarf-dev/zarf/src/internal/healthchecks"
+	"github.com/zarf-dev/zarf/src/internal/packagemirror"
+	"github.com/zarf-dev/zarf/src/internal/packager/files"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
@@ -38,7 +43,11 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/actions"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/variables"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -49,10 +58,19 @@ var (
 
 func (p *Packager) resetRegistryHPA(ctx context.Context) {
 	l := logger.From(ctx)
-	if p.isConnectedToCluster() && p.hpaModified {
-		if err := p.cluster.EnableRegHPAScaleDown(ctx); err != nil {
-			message.Debugf("unable to reenable the registry HPA scale down: %s", err.Error())
-			l.Debug("unable to reenable the registry HPA scale down", "error", err.Error())
+	aliases := []string{p.target}
+	for alias := range p.targets {
+		if alias != p.target {
+			aliases = append(aliases, alias)
+		}
+	}
+	for _, alias := range aliases {
+		p.useTarget(alias)
+		if p.isConnectedToCluster() && p.hpaModified {
+			if err := p.cluster.EnableRegHPAScaleDown(ctx); err != nil {
+				message.Debugf("unable to reenable the registry HPA scale down: %s", err.Error())
+				l.Debug("unable to reenable the registry HPA scale down", "error", err.Error())
+			}
 		}
 	}
 }
@@ -63,7 +81,17 @@ func (p *Packager) Deploy(ctx context.Context) error {
 	start := time.Now()
 	isInteractive := !config.CommonOptions.Confirm
 
+	if _, isOCISource := p.source.(*sources.OCISource); sources.VerifiesAttestations(p.cfg.PkgOpts) && !isOCISource {
+		return sources.ErrAttestationsNotOCI
+	}
+
+	archFilter := &multiArchFilter{
+		nodeArchitectures: func() []string {
+			return p.clusterNodeArchitectures(ctx)
+		},
+	}
 	deployFilter := filters.Combine(
+		archFilter,
 		filters.ByLocalOS(runtime.GOOS),
 		filters.ForDeploy(p.cfg.PkgOpts.OptionalComponents, isInteractive),
 	)
@@ -77,6 +105,11 @@ func (p *Packager) Deploy(ctx context.Context) error {
 		}
 		p.cfg.Pkg = pkg
 		warnings = append(warnings, loadWarnings...)
+		// Only the components of the selected architecture are offered for deployment.
+		p.cfg.Pkg.Components, err = archFilter.Apply(p.cfg.Pkg)
+		if err != nil {
+			return err
+		}
 	} else {
 		pkg, loadWarnings, err := p.source.LoadPackage(ctx, p.layout, deployFilter, true)
 		if err != nil {
@@ -84,16 +117,38 @@ func (p *Packager) Deploy(ctx context.Context) error {
 		}
 		p.cfg.Pkg = pkg
 		warnings = append(warnings, loadWarnings...)
+		if err := p.importPackageVariables(ctx); err != nil {
+			return err
+		}
 		if err := p.populatePackageVariableConfig(); err != nil {
 			return fmt.Errorf("unable to set the active variables: %w", err)
 		}
 	}
 
+	if archFilter.selected != "" {
+		message.Infof("Deploying the %s architecture of the package", archFilter.selected)
+		l.Info("deploying the architecture of the package", "architecture", archFilter.selected)
+		p.cfg.Pkg.Metadata.Architecture = archFilter.selected
+		p.cfg.Pkg.Build.Architecture = archFilter.selected
+	}
+
 	validateWarnings, err := validateLastNonBreakingVersion(config.CLIVersion, p.cfg.Pkg.Build.LastNonBreakingVersion)
 	if err != nil {
 		return err
 	}
 	warnings = append(warnings, validateWarnings...)
+	helmWarnings, err := validateHelmVersion(helm.EngineVersion(), p.cfg.Pkg)
+	if err != nil {
+		return err
+	}
+	warnings = append(warnings, helmWarnings...)
+
+	if err := validateValuesProfiles(p.cfg.Pkg, p.cfg.DeployOpts.ValuesProfiles); err != nil {
+		return err
+	}
+	if err := validateSetHelmValues(p.cfg.Pkg, p.cfg.DeployOpts.SetHelmValues); err != nil {
+		return err
+	}
 
 	sbomViewFiles, sbomWarnings, err := p.layout.SBOMs.StageSBOMViewFiles()
 	if err != nil {
@@ -113,14 +168,22 @@ func (p *Packager) Deploy(ctx context.Context) error {
 		}
 
 		// Set variables and prompt if --confirm is not set
+		if err := p.importPackageVariables(ctx); err != nil {
+			return err
+		}
 		if err := p.populatePackageVariableConfig(); err != nil {
 			return fmt.Errorf("unable to set the active variables: %w", err)
 		}
 	}
 
+	if p.cfg.DeployOpts.ImagePushDryRun {
+		return p.imagePushDryRun(ctx)
+	}
+
 	p.hpaModified = false
 	// Reset registry HPA scale down whether an error occurs or not
 	defer p.resetRegistryHPA(ctx)
+	defer p.closeTunnels()
 
 	// Get a list of all the components we are deploying and actually deploy them
 	deployedComponents, err := p.deployComponents(ctx)
@@ -132,25 +195,170 @@ func (p *Packager) Deploy(ctx context.Context) error {
 		l.Warn("no components were selected for deployment. Inspect the package to view the available components and select components interactively or by name with \"--components\"")
 	}
 
+	notes, err := renderNotes(p.variableConfig, p.cfg.Pkg.Notes)
+	if err != nil {
+		return fmt.Errorf("unable to render the notes of the package: %w", err)
+	}
+	p.recordPackageNotes(ctx, notes)
+	p.recordPackageExports(ctx)
+
 	// Notify all the things about the successful deployment
 	message.Successf("Zarf deployment complete")
 	l.Debug("Zarf deployment complete", "duration", time.Since(start))
 
-	err = p.printTablesForDeployment(ctx, deployedComponents)
+	p.deployResult, err = p.newDeployResult(ctx, deployedComponents, notes, warnings)
 	if err != nil {
 		return err
 	}
+	p.printTablesForDeployment()
+	for _, comp := range deployedComponents {
+		message.PrintNotes(fmt.Sprintf("Notes for component %s", comp.Name), comp.Notes)
+	}
+	message.PrintNotes(fmt.Sprintf("Notes for package %s", p.cfg.Pkg.Metadata.Name), notes)
 
 	return nil
 }
 
+// multiArchFilter selects the architecture to deploy a multi-architecture package with and keeps the components of that
+// architecture, packages created for a single architecture are not filtered.
+type multiArchFilter struct {
+	// nodeArchitectures returns the architectures of the nodes of the target cluster.
+	nodeArchitectures func() []string
+	selected          string
+}
+
+// Apply applies the filter.
+func (f *multiArchFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfComponent, error) {
+	if pkg.Build.Architecture != zoci.MultiArch {
+		return pkg.Components, nil
+	}
+	if f.selected == "" {
+		// The multi architecture only resolves the package in a registry and does not select an architecture.
+		requested := config.CLIArch
+		if requested == zoci.MultiArch {
+			requested = ""
+		}
+		arch, err := filters.SelectArchitecture(pkg.Build.Architectures, requested, f.nodeArchitectures(), runtime.GOARCH)
+		if err != nil {
+			return nil, err
+		}
+		f.selected = arch
+	}
+	return filters.ByArchitecture(f.selected).Apply(pkg)
+}
+
+// clusterNodeArchitectures returns the architectures of the nodes of the target cluster, or none when the cluster can
+// not be reached.
+func (p *Packager) clusterNodeArchitectures(ctx context.Context) []string {
+	l := logger.From(ctx)
+	c := p.cluster
+	if c == nil {
+		var err error
+		c, err = cluster.NewClusterForContext(cluster.TargetKubeContext(p.target, p.cfg.DeployOpts.ClusterContexts))
+		if err != nil {
+			message.Debugf("unable to connect to the cluster to select the package architecture: %s", err.Error())
+			l.Debug("unable to connect to the cluster to select the package architecture", "error", err)
+			return nil
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	architectures, err := nodeArchitectures(ctx, c)
+	if err != nil {
+		message.Debugf("unable to get the cluster node architectures: %s", err.Error())
+		l.Debug("unable to get the cluster node architectures", "error", err)
+		return nil
+	}
+	return architectures
+}
+
+// renderNotes templates notes with the variables of the deployment.
+func renderNotes(variableConfig *variables.VariableConfig, notes string) (string, error) {
+	if notes == "" {
+		return "", nil
+	}
+	rendered, err := variableConfig.ReplaceTemplates(notes)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(rendered), nil
+}
+
+// recordPackageNotes stores the rendered notes of the package in the deployed package of each cluster it was deployed to.
+func (p *Packager) recordPackageNotes(ctx context.Context, notes string) {
+	l := logger.From(ctx)
+	if notes == "" {
+		return
+	}
+	for _, c := range p.deployedClusters() {
+		depPkg, err := c.GetDeployedPackage(ctx, p.cfg.Pkg.Metadata.Name)
+		if err == nil {
+			depPkg.Notes = notes
+			err = c.UpdateDeployedPackage(ctx, *depPkg)
+		}
+		if err != nil {
+			message.Debugf("Unable to record the notes of package %q: %s", p.cfg.Pkg.Metadata.Name, err.Error())
+			l.Debug("unable to record the notes of package", "name", p.cfg.Pkg.Metadata.Name, "error", err.Error())
+		}
+	}
+}
+
+// recordPackageExports stores the values of the variables exported by the package in each cluster it was deployed to.
+func (p *Packager) recordPackageExports(ctx context.Context) {
+	l := logger.From(ctx)
+	if len(p.cfg.Pkg.Exports) == 0 {
+		return
+	}
+	values := map[string]string{}
+	for _, export := range p.cfg.Pkg.Exports {
+		variable, ok := p.variableConfig.GetSetVariable(export.Name)
+		if !ok {
+			message.Warnf("Exported variable %s was not set and is not exported", export.Name)
+			l.Warn("exported variable was not set and is not exported", "name", export.Name)
+			continue
+		}
+		values[export.Name] = variable.Value
+	}
+	for _, c := range p.deployedClusters() {
+		if err := c.RecordPackageExports(ctx, p.cfg.Pkg.Metadata.Name, values); err != nil {
+			message.Warnf("Unable to record the exports of package %q: %s", p.cfg.Pkg.Metadata.Name, err.Error())
+			l.Warn("unable to record the exports of package", "name", p.cfg.Pkg.Metadata.Name, "error", err.Error())
+		}
+	}
+}
+
+// deployedClusters returns the clusters the package was deployed to.
+func (p *Packager) deployedClusters() []*cluster.Cluster {
+	clusters := []*cluster.Cluster{}
+	if p.cluster != nil {
+		clusters = append(clusters, p.cluster)
+	}
+	for alias, target := range p.targets {
+		if alias != p.target && target.cluster != nil {
+			clusters = append(clusters, target.cluster)
+		}
+	}
+	return clusters
+}
+
 // deployComponents loops through a list of ZarfComponents and deploys them.
-func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedComponent, error) {
+func (p *Packager) deployComponents(ctx context.Context) (_ []types.DeployedComponent, err error) {
 	l := logger.From(ctx)
 	deployedComponents := []types.DeployedComponent{}
+	// Each cluster records the components deployed to it.
+	targetComponents := map[string][]types.DeployedComponent{}
+	// Each cluster records the start and completion of the deployment as events.
+	targetEvents := map[string]*deployEvents{}
+	defer func() {
+		for _, events := range targetEvents {
+			events.complete(ctx, p.cfg.Pkg, err)
+		}
+	}()
 
 	// Process all the components we are deploying
 	for _, component := range p.cfg.Pkg.Components {
+		p.useTarget(component.Cluster)
+
 		// Connect to cluster if a component requires it.
 		if component.RequiresCluster() {
 			timeout := cluster.DefaultTimeout
@@ -162,6 +370,9 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 			if err := p.connectToCluster(connectCtx); err != nil {
 				return nil, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
 			}
+			if _, ok := targetEvents[p.target]; !ok {
+				targetEvents[p.target] = p.startDeployEvents(ctx)
+			}
 		}
 
 		deployedComponent := types.DeployedComponent{
@@ -180,6 +391,8 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 
 		deployedComponents = append(deployedComponents, deployedComponent)
 		idx := len(deployedComponents) - 1
+		targetComponents[p.target] = append(targetComponents[p.target], deployedComponent)
+		targetIdx := len(targetComponents[p.target]) - 1
 
 		// Deploy the component
 		var charts []types.InstalledChart
@@ -203,7 +416,7 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 			onFailure()
 
 			if p.isConnectedToCluster() {
-				if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents); err != nil {
+				if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, targetComponents[p.target], p.variableConfig.GetNonSensitiveSetVariables()); err != nil {
 					message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
 					l.Debug("unable to record package deployment", "component", component.Name, "error", err.Error())
 				}
@@ -211,10 +424,19 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 			return nil, fmt.Errorf("unable to deploy component %q: %w", component.Name, deployErr)
 		}
 
+		notes, err := renderNotes(p.variableConfig, component.Notes)
+		if err != nil {
+			onFailure()
+			return nil, fmt.Errorf("unable to render the notes of component %q: %w", component.Name, err)
+		}
+
 		// Update the package secret to indicate that we successfully deployed this component
 		deployedComponents[idx].InstalledCharts = charts
+		deployedComponents[idx].Notes = notes
+		targetComponents[p.target][targetIdx].InstalledCharts = charts
+		targetComponents[p.target][targetIdx].Notes = notes
 		if p.isConnectedToCluster() {
-			if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents); err != nil {
+			if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, targetComponents[p.target], p.variableConfig.GetNonSensitiveSetVariables()); err != nil {
 				message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
 				l.Debug("unable to record package deployment", "component", component.Name, "error", err.Error())
 			}
@@ -229,6 +451,49 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 	return deployedComponents, nil
 }
 
+// deployEvents are the events recorded for the deployment of the package to a cluster.
+type deployEvents struct {
+	cluster    *cluster.Cluster
+	op         cluster.PackageOperation
+	namespaces []string
+	start      time.Time
+}
+
+// startDeployEvents records the start of the deployment to the current cluster, which is an upgrade when a different
+// version of the package is already deployed.
+func (p *Packager) startDeployEvents(ctx context.Context) *deployEvents {
+	components := []v1alpha1.ZarfComponent{}
+	for _, component := range p.cfg.Pkg.Components {
+		if component.Cluster == p.target {
+			components = append(components, component)
+		}
+	}
+	events := &deployEvents{
+		cluster:    p.cluster,
+		op:         cluster.PackageDeploy,
+		namespaces: cluster.PackageNamespaces(components),
+		start:      time.Now(),
+	}
+	existing, err := p.cluster.GetDeployedPackage(ctx, p.cfg.Pkg.Metadata.Name)
+	if err == nil && existing.Data.Metadata.Version != p.cfg.Pkg.Metadata.Version {
+		events.op = cluster.PackageUpgrade
+	}
+	events.cluster.RecordPackageEvent(ctx, p.cfg.Pkg, events.op, cluster.PackageEventStarted, events.namespaces, nil)
+	return events
+}
+
+// complete records the completion of the deployment and its outcome in the deployed package.
+func (e *deployEvents) complete(ctx context.Context, pkg v1alpha1.ZarfPackage, err error) {
+	phase := cluster.PackageEventSucceeded
+	if err != nil {
+		phase = cluster.PackageEventFailed
+	}
+	e.cluster.RecordPackageEvent(ctx, pkg, e.op, phase, e.namespaces, err)
+	if outcomeErr := e.cluster.RecordPackageDeployOutcome(ctx, pkg.Metadata.Name, time.Since(e.start), err); outcomeErr != nil {
+		logger.From(ctx).Debug("unable to record the outcome of the deployment", "name", pkg.Metadata.Name, "error", outcomeErr)
+	}
+}
+
 func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.ZarfComponent) ([]types.InstalledChart, error) {
 	l := logger.From(ctx)
 	hasExternalRegistry := p.cfg.InitOpts.RegistryInfo.Address != ""
@@ -263,7 +528,7 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 
 	// Before deploying the seed registry, start the injector
 	if isSeedRegistry {
-		err := p.cluster.StartInjection(ctx, p.layout.Base, p.layout.Images.Base, component.Images)
+		err := p.cluster.StartInjection(ctx, p.layout.Base, p.layout.Images.Base, component.Images, p.cfg.InitOpts.Injector)
 		if err != nil {
 			return nil, err
 		}
@@ -313,6 +578,14 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 			}
 		}
 
+		if p.cfg.DeployOpts.ScopedCredentials && p.scopedCredentials == nil && !p.cfg.Pkg.IsInitConfig() && !p.cfg.Pkg.Metadata.YOLO {
+			creds, err := p.cluster.MintScopedCredentials(ctx, p.state, p.cfg.Pkg)
+			if err != nil {
+				return nil, fmt.Errorf("unable to mint the scoped credentials: %w", err)
+			}
+			p.scopedCredentials = creds
+		}
+
 		// Disable the registry HPA scale down if we are deploying images and it is not already disabled
 		if hasImages && !p.hpaModified && p.state.RegistryInfo.IsInternal() {
 			if err := p.cluster.DisableRegHPAScaleDown(ctx); err != nil {
@@ -351,6 +624,12 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 		}
 	}
 
+	if len(component.PackageMirrors) > 0 {
+		if err = p.pushPackageMirrors(ctx, componentPath.Mirrors, component.PackageMirrors); err != nil {
+			return nil, fmt.Errorf("unable to push the package mirrors to the artifact server: %w", err)
+		}
+	}
+
 	g, gCtx := errgroup.WithContext(ctx)
 	for idx, data := range component.DataInjections {
 		g.Go(func() error {
@@ -359,8 +638,9 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 	}
 
 	charts := []types.InstalledChart{}
+	readyObjs := []object.ObjMetadata{}
 	if hasCharts || hasManifests {
-		charts, err = p.installChartAndManifests(ctx, componentPath, component)
+		charts, readyObjs, err = p.installChartAndManifests(ctx, componentPath, component)
 		if err != nil {
 			return nil, err
 		}
@@ -370,13 +650,22 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 		return nil, fmt.Errorf("unable to run component after action: %w", err)
 	}
 
-	if len(component.HealthChecks) > 0 {
-		healthCheckContext, cancel := context.WithTimeout(ctx, p.cfg.DeployOpts.Timeout)
+	// Wait for the health checks and every object of the charts and manifests that were waited on to be ready, as the
+	// after actions or later charts may have changed objects that were ready when their chart was installed. The objects
+	// that were ready are also done when they were deleted since, as an action may remove them on purpose.
+	healthCheckObjs, err := healthchecks.ReferenceObjMetadata(component.HealthChecks)
+	if err != nil {
+		return nil, err
+	}
+	deletableObjs := object.ObjMetadataSet(readyObjs).Diff(healthCheckObjs)
+	readyObjs = object.ObjMetadataSet(readyObjs).Union(healthCheckObjs)
+	if len(readyObjs) > 0 {
+		healthCheckContext, cancel := context.WithTimeout(ctx, p.readinessTimeout())
 		defer cancel()
-		spinner := message.NewProgressSpinner("Running health checks")
-		l.Info("running health checks")
+		spinner := message.NewProgressSpinner("Waiting for %d resources to be ready", len(readyObjs))
+		l.Info("waiting for resources to be ready", "count", len(readyObjs), "timeout", p.readinessTimeout())
 		defer spinner.Stop()
-		if err = healthchecks.Run(healthCheckContext, p.cluster.Watcher, component.HealthChecks); err != nil {
+		if err = healthchecks.WaitForReadyOrDeleted(healthCheckContext, p.cluster.Watcher, readyObjs, deletableObjs); err != nil {
 			return nil, fmt.Errorf("health checks failed: %w", err)
 		}
 		spinner.Success()
@@ -398,7 +687,16 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 	l.Info("copying files", "count", len(component.Files))
 	defer spinner.Stop()
 
+	buildData := p.componentBuildData(component.Name)
 	for fileIdx, file := range component.Files {
+		if isDifferential(buildData.Files, fileIdx) {
+			if err := p.validateDifferentialFile(file); err != nil {
+				return err
+			}
+			l.Info("skipping file unchanged from the differential package version", "name", file.Target, "version", p.cfg.Pkg.Build.DifferentialPackageVersion)
+			continue
+		}
+
 		spinner.Updatef("Loading %s", file.Target)
 		l.Info("loading file", "name", file.Target)
 
@@ -407,8 +705,9 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 			fileLocation = filepath.Join(pkgLocation, strconv.Itoa(fileIdx))
 		}
 
-		// If a shasum is specified check it again on deployment as well
-		if file.Shasum != "" {
+		// If a shasum is specified check it again on deployment as well, the shasum of an extracted archive is verified
+		// before it is extracted during create and does not apply to the extracted directory.
+		if file.Shasum != "" && !file.Extract {
 			spinner.Updatef("Validating SHASUM for %s", file.Target)
 			l.Debug("Validating SHASUM", "file", file.Target)
 			if err := helpers.SHAsMatch(fileLocation, file.Shasum); err != nil {
@@ -416,6 +715,16 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 			}
 		}
 
+		// Large binaries are verified in chunks so that corruption is located within the file
+		if fileIdx < len(buildData.Files) && len(buildData.Files[fileIdx].Chunks) > 0 {
+			spinner.Updatef("Validating chunks of %s", file.Target)
+			l.Debug("validating chunks", "file", file.Target, "count", len(buildData.Files[fileIdx].Chunks))
+			content := buildData.Files[fileIdx]
+			if err := files.VerifyChunks(fileLocation, content.ChunkSize, content.Chunks); err != nil {
+				return err
+			}
+		}
+
 		// Replace temp target directory and home directory
 		target, err := config.GetAbsHomePath(strings.Replace(file.Target, "###ZARF_TEMP###", p.layout.Base, 1))
 		if err != nil {
@@ -423,23 +732,31 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 		}
 		file.Target = target
 
-		fileList := []string{}
-		if helpers.IsDir(fileLocation) {
-			files, _ := helpers.RecursiveFileList(fileLocation, nil, false)
-			fileList = append(fileList, files...)
-		} else {
-			fileList = append(fileList, fileLocation)
-		}
-
-		for _, subFile := range fileList {
-			// Check if the file looks like a text file
-			isText, err := helpers.IsTextFile(subFile)
-			if err != nil {
-				return err
+		// Files are templated unless templating is turned off for them, large binaries are never read into memory to be templated
+		if !file.LargeBinary && (file.Templated == nil || *file.Templated) {
+			fileList := []string{}
+			if helpers.IsDir(fileLocation) {
+				dirFiles, _ := helpers.RecursiveFileList(fileLocation, nil, false)
+				fileList = append(fileList, dirFiles...)
+			} else {
+				fileList = append(fileList, fileLocation)
 			}
 
-			// If the file is a text file, template it
-			if isText {
+			for _, subFile := range fileList {
+				// Check if the file looks like a text file
+				isText, err := helpers.IsTextFile(subFile)
+				if err != nil {
+					return err
+				}
+
+				// Binary files are never templated, even when templating is turned on for them
+				if !isText {
+					if file.Templated != nil {
+						l.Debug("skipping template of binary file", "name", subFile, "target", file.Target)
+					}
+					continue
+				}
+
 				spinner.Updatef("Templating %s", file.Target)
 				l.Debug("template file", "name", file.Target)
 				if err := p.variableConfig.ReplaceTextTemplate(subFile); err != nil {
@@ -451,21 +768,34 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 		// Copy the file to the destination
 		spinner.Updatef("Saving %s", file.Target)
 		l.Debug("saving file", "name", file.Target)
-		err = helpers.CreatePathAndCopy(fileLocation, file.Target)
+		if file.LargeBinary && !helpers.IsDir(fileLocation) {
+			err = files.CopySparse(fileLocation, file.Target)
+		} else {
+			err = helpers.CreatePathAndCopy(fileLocation, file.Target)
+		}
 		if err != nil {
 			return fmt.Errorf("unable to copy file %s to %s: %w", fileLocation, file.Target, err)
 		}
 
+		// Set the requested permissions, ownership and SELinux label
+		if file.Mode != "" || file.Owner != "" || file.Group != "" || file.SELinuxLabel != "" {
+			spinner.Updatef("Setting permissions for %s", file.Target)
+			l.Debug("setting file permissions", "name", file.Target, "mode", file.Mode, "owner", file.Owner, "group", file.Group, "selinuxLabel", file.SELinuxLabel)
+			if err := files.ApplyPermissions(ctx, file, file.Target); err != nil {
+				return err
+			}
+		}
+
 		// Loop over all symlinks and create them
 		for _, link := range file.Symlinks {
-			spinner.Updatef("Adding symlink %s->%s", link, file.Target)
-			// Try to remove the filepath if it exists
-			_ = os.RemoveAll(link)
-			// Make sure the parent directory exists
-			_ = helpers.CreateParentDirectory(link)
-			// Create the symlink
-			err := os.Symlink(file.Target, link)
+			// Replace temp target directory and home directory in the same way as the target
+			link, err := config.GetAbsHomePath(strings.Replace(link, "###ZARF_TEMP###", p.layout.Base, 1))
 			if err != nil {
+				return err
+			}
+			spinner.Updatef("Adding symlink %s->%s", link, file.Target)
+			l.Debug("adding symlink", "link", link, "target", file.Target)
+			if err := files.CreateSymlink(file.Target, link); err != nil {
 				return fmt.Errorf("unable to create symlink %s->%s: %w", link, file.Target, err)
 			}
 		}
@@ -480,6 +810,15 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 	return nil
 }
 
+// workloadState returns the state used for the pull secrets and templates of the package, which holds the scoped
+// credentials when they were minted for the package.
+func (p *Packager) workloadState() *types.ZarfState {
+	if p.scopedCredentials == nil {
+		return p.state
+	}
+	return cluster.ScopedState(p.state, p.scopedCredentials)
+}
+
 // setupState fetches the current ZarfState from the k8s cluster and sets the packager to use it
 func (p *Packager) setupState(ctx context.Context) error {
 	l := logger.From(ctx)
@@ -527,21 +866,82 @@ func (p *Packager) setupState(ctx context.Context) error {
 }
 
 func (p *Packager) populateComponentAndStateTemplates(ctx context.Context, componentName string) error {
-	applicationTemplates, err := template.GetZarfTemplates(ctx, componentName, p.state)
+	applicationTemplates, err := template.GetZarfTemplates(ctx, componentName, p.workloadState())
 	if err != nil {
 		return err
 	}
+	// Keep the users of the scoped credentials when the registry is deployed again.
+	if htpasswd, ok := applicationTemplates["###ZARF_HTPASSWD###"]; ok && htpasswd.Value != "" && p.cluster != nil {
+		entries, err := p.cluster.ScopedRegistryHtpasswd(ctx)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			htpasswd.Value += "\\n" + entry
+		}
+	}
 	p.variableConfig.SetApplicationTemplates(applicationTemplates)
 	return nil
 }
 
+// importPackageVariables sets the variables the package imports from the exports of packages deployed before it,
+// variables set by the user take precedence over imported values.
+func (p *Packager) importPackageVariables(ctx context.Context) error {
+	if len(p.cfg.Pkg.Imports) == 0 {
+		return nil
+	}
+	if err := p.connectToCluster(ctx); err != nil {
+		return fmt.Errorf("unable to connect to the cluster to import variables: %w", err)
+	}
+	exports, err := p.cluster.GetPackageExports(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the exports of deployed packages: %w", err)
+	}
+	if p.cfg.PkgOpts.SetVariables == nil {
+		p.cfg.PkgOpts.SetVariables = map[string]string{}
+	}
+	for _, imp := range p.cfg.Pkg.Imports {
+		if _, ok := p.cfg.PkgOpts.SetVariables[imp.Name]; ok {
+			continue
+		}
+		value, ok := exports[fmt.Sprintf("%s.%s", imp.Package, imp.Name)]
+		if !ok {
+			return fmt.Errorf("variable %s is not exported by package %s, deploy %s first or set the variable with --set", imp.Name, imp.Package, imp.Package)
+		}
+		p.cfg.PkgOpts.SetVariables[imp.Name] = value
+	}
+	return nil
+}
+
 func (p *Packager) populatePackageVariableConfig() error {
 	p.variableConfig.SetConstants(p.cfg.Pkg.Constants)
-	return p.variableConfig.PopulateVariables(p.cfg.Pkg.Variables, p.cfg.PkgOpts.SetVariables)
+	return p.variableConfig.PopulateVariables(p.cfg.Pkg.Variables, presetVariables(p.cfg.Pkg.Variables, p.cfg.PkgOpts.SetVariables, p.cfg.PkgOpts.SharedVariables))
+}
+
+// presetVariables returns the variables set for the package, with the shared variables added for the variables the
+// package declares that are not set.
+func presetVariables(declared []v1alpha1.InteractiveVariable, set, shared map[string]string) map[string]string {
+	if len(shared) == 0 {
+		return set
+	}
+	preset := maps.Clone(set)
+	if preset == nil {
+		preset = map[string]string{}
+	}
+	for _, variable := range declared {
+		if _, ok := preset[variable.Name]; ok {
+			continue
+		}
+		if value, ok := shared[variable.Name]; ok {
+			preset[variable.Name] = value
+		}
+	}
+	return preset
 }
 
 // Push all of the components images to the configured container registry.
 func (p *Packager) pushImagesToRegistry(ctx context.Context, componentImages []string, noImgChecksum bool) error {
+	l := logger.From(ctx)
 	var combinedImageList []transform.Image
 	for _, src := range componentImages {
 		ref, err := transform.ParseImageRef(src)
@@ -551,7 +951,19 @@ func (p *Packager) pushImagesToRegistry(ctx context.Context, componentImages []s
 		combinedImageList = append(combinedImageList, ref)
 	}
 
-	imageList := helpers.Unique(combinedImageList)
+	imageList := []transform.Image{}
+	for _, ref := range helpers.Unique(combinedImageList) {
+		// Images shared between components have identical content within a package so only push them once.
+		pushedNoChecksum, ok := p.pushedImages[ref.Reference]
+		if ok && (!pushedNoChecksum || noImgChecksum) {
+			l.Debug("skipping image already pushed by a previous component", "name", ref.Reference)
+			continue
+		}
+		imageList = append(imageList, ref)
+	}
+	if len(imageList) == 0 {
+		return nil
+	}
 
 	pushCfg := images.PushConfig{
 		SourceDirectory: p.layout.Images.Base,
@@ -560,9 +972,109 @@ func (p *Packager) pushImagesToRegistry(ctx context.Context, componentImages []s
 		NoChecksum:      noImgChecksum,
 		Arch:            p.cfg.Pkg.Build.Architecture,
 		Retries:         p.cfg.PkgOpts.Retries,
+		Cluster:         p.cluster,
+		Tunnels:         p.tunnelPool(),
+	}
+
+	if p.cfg.DeployOpts.SkipImagePush {
+		message.Notef("Skipping the push of %d images, they must already be present in the registry", len(imageList))
+		l.Info("skipping image push", "images", len(imageList), "registry", p.state.RegistryInfo.Address)
+	} else if err := images.Push(ctx, pushCfg); err != nil {
+		return err
+	}
+	if p.pushedImages == nil {
+		p.pushedImages = map[string]bool{}
+	}
+	for _, ref := range imageList {
+		p.pushedImages[ref.Reference] = noImgChecksum
+	}
+	return nil
+}
+
+// imagePushDryRun lists the images of the components that would be pushed to the registry of their cluster, with
+// their digests and the names they would be pushed as, without deploying anything.
+func (p *Packager) imagePushDryRun(ctx context.Context) error {
+	planned := false
+	for _, component := range p.cfg.Pkg.Components {
+		if len(component.Images) == 0 {
+			continue
+		}
+		p.useTarget(component.Cluster)
+		if p.state == nil {
+			connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+			defer cancel()
+			if err := p.connectToCluster(connectCtx); err != nil {
+				return fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+			}
+			if err := p.setupState(ctx); err != nil {
+				return err
+			}
+		}
+		imageList := []transform.Image{}
+		for _, src := range helpers.Unique(component.Images) {
+			ref, err := transform.ParseImageRef(src)
+			if err != nil {
+				return fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			imageList = append(imageList, ref)
+		}
+		pushes, err := images.PlanPush(images.PushConfig{
+			SourceDirectory: p.layout.Images.Base,
+			ImageList:       imageList,
+			RegInfo:         p.state.RegistryInfo,
+			Arch:            p.cfg.Pkg.Build.Architecture,
+		})
+		if err != nil {
+			return err
+		}
+		message.HeaderInfof("📦 %s COMPONENT", strings.ToUpper(component.Name))
+		printPlannedPushes(ctx, pushes)
+		planned = true
+	}
+	if !planned {
+		message.Note("The package has no images to push")
+		logger.From(ctx).Info("the package has no images to push")
+	}
+	message.Successf("Image push dry run complete, nothing was deployed")
+	return nil
+}
+
+// printPlannedPushes prints the images that would be pushed with their digests and the names they would be pushed as.
+func printPlannedPushes(ctx context.Context, planned []images.PlannedPush) {
+	l := logger.From(ctx)
+	data := [][]string{}
+	for _, push := range planned {
+		for _, name := range push.Names {
+			data = append(data, []string{push.Reference, push.Digest, name})
+		}
+		l.Info("image push dry run", "name", push.Reference, "digest", push.Digest, "pushedAs", push.Names)
+	}
+	message.Table([]string{"Image", "Digest", "Pushed As"}, data)
+}
+
+// tunnelPool returns the tunnel pool of the cluster the packager is connected to, or nil when it is not connected. The
+// pushes of a deploy reuse the tunnels of the pool until the deploy closes them.
+func (p *Packager) tunnelPool() *cluster.TunnelPool {
+	if p.cluster == nil {
+		return nil
+	}
+	if p.tunnelPools == nil {
+		p.tunnelPools = map[*cluster.Cluster]*cluster.TunnelPool{}
 	}
+	pool, ok := p.tunnelPools[p.cluster]
+	if !ok {
+		pool = p.cluster.NewTunnelPool()
+		p.tunnelPools[p.cluster] = pool
+	}
+	return pool
+}
 
-	return images.Push(ctx, pushCfg)
+// closeTunnels closes the tunnels opened by the pushes of the deploy.
+func (p *Packager) closeTunnels() {
+	for c, pool := range p.tunnelPools {
+		pool.Close()
+		delete(p.tunnelPools, c)
+	}
 }
 
 // Push all of the components git repos to the configured git server.
@@ -588,20 +1100,16 @@ func (p *Packager) pushReposToRepository(ctx context.Context, reposPath string,
 						return err
 					}
 				}
-				tunnel, err := p.cluster.NewTunnel(namespace, cluster.SvcResource, name, "", 0, port)
+				tunnels := p.tunnelPool()
+				tunnel, err := tunnels.Connect(ctx, namespace, cluster.SvcResource, name, port)
 				if err != nil {
 					return err
 				}
-				_, err = tunnel.Connect(ctx)
-				if err != nil {
-					return err
-				}
-				defer tunnel.Close()
 				giteaClient, err := gitea.NewClient(tunnel.HTTPEndpoint(), p.state.GitServer.PushUsername, p.state.GitServer.PushPassword)
 				if err != nil {
 					return err
 				}
-				return tunnel.Wrap(func() error {
+				pushErr := tunnel.Wrap(func() error {
 					err = repository.Push(ctx, tunnel.HTTPEndpoint(), p.state.GitServer.PushUsername, p.state.GitServer.PushPassword)
 					if err != nil {
 						return err
@@ -615,8 +1123,19 @@ func (p *Packager) pushReposToRepository(ctx context.Context, reposPath string,
 					if err != nil {
 						return fmt.Errorf("unable to add the read only user to the repo %s: %w", repoName, err)
 					}
+					if p.scopedCredentials != nil && p.scopedCredentials.GitToken != "" {
+						err = giteaClient.AddReadOnlyUserToRepository(ctx, repoName, p.scopedCredentials.Username)
+						if err != nil {
+							return fmt.Errorf("unable to add the scoped user to the repo %s: %w", repoName, err)
+						}
+					}
 					return nil
 				})
+				if pushErr != nil {
+					// The next attempt reconnects the tunnel when pushing through it fails.
+					tunnels.Discard(tunnel)
+				}
+				return pushErr
 			}
 
 			err = repository.Push(ctx, p.state.GitServer.Address, p.state.GitServer.PushUsername, p.state.GitServer.PushPassword)
@@ -632,6 +1151,52 @@ func (p *Packager) pushReposToRepository(ctx context.Context, reposPath string,
 	return nil
 }
 
+// pushPackageMirrors pushes the packages pulled for the mirrors of a component to the artifact server.
+func (p *Packager) pushPackageMirrors(ctx context.Context, mirrorsPath string, mirrors []v1alpha1.ZarfPackageMirror) error {
+	server := p.state.ArtifactServer
+	for mirrorIdx := range mirrors {
+		dir := filepath.Join(mirrorsPath, strconv.Itoa(mirrorIdx))
+		err := retry.Do(func() error {
+			namespace, name, port, err := serviceInfoFromServiceURL(server.Address)
+
+			// If this is a service, create a port-forward tunnel to that resource
+			if err == nil {
+				if !p.isConnectedToCluster() {
+					connectCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+					defer cancel()
+					err := p.connectToCluster(connectCtx)
+					if err != nil {
+						return err
+					}
+				}
+				address, err := url.Parse(server.Address)
+				if err != nil {
+					return retry.Unrecoverable(err)
+				}
+				tunnels := p.tunnelPool()
+				tunnel, err := tunnels.Connect(ctx, namespace, cluster.SvcResource, name, port)
+				if err != nil {
+					return err
+				}
+				pushErr := tunnel.Wrap(func() error {
+					return packagemirror.Push(ctx, dir, tunnel.HTTPEndpoint()+address.Path, server.PushUsername, server.PushToken)
+				})
+				if pushErr != nil {
+					// The next attempt reconnects the tunnel when pushing through it fails.
+					tunnels.Discard(tunnel)
+				}
+				return pushErr
+			}
+
+			return packagemirror.Push(ctx, dir, server.Address, server.PushUsername, server.PushToken)
+		}, retry.Context(ctx), retry.Attempts(uint(p.cfg.PkgOpts.Retries)), retry.Delay(500*time.Millisecond))
+		if err != nil {
+			return fmt.Errorf("unable to push the packages of %s: %w", mirrors[mirrorIdx].Source, err)
+		}
+	}
+	return nil
+}
+
 // generateValuesOverrides creates a map containing overrides for chart values based on the chart and component
 // Specifically it merges DeployOpts.ValuesOverridesMap over Zarf `variables` for a given component/chart combination
 func (p *Packager) generateValuesOverrides(chart v1alpha1.ZarfChart, componentName string) (map[string]any, error) {
@@ -654,26 +1219,64 @@ func (p *Packager) generateValuesOverrides(chart v1alpha1.ZarfChart, componentNa
 		}
 	}
 
+	// Apply the values set on deploy for the chart over all other overrides
+	setHelmValues, err := chartSetHelmValues(p.cfg.DeployOpts.SetHelmValues, chart.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	// Merge chartOverrides into valuesOverrides to ensure all overrides are applied.
 	// This corrects the logic to ensure that chartOverrides and valuesOverrides are merged correctly.
-	return helpers.MergeMapRecursive(chartOverrides, valuesOverrides), nil
+	return helpers.MergeMapRecursive(helpers.MergeMapRecursive(chartOverrides, valuesOverrides), setHelmValues), nil
 }
 
 // Install all Helm charts and raw k8s manifests into the k8s cluster.
-func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths *layout.ComponentPaths, component v1alpha1.ZarfComponent) ([]types.InstalledChart, error) {
+func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths *layout.ComponentPaths, component v1alpha1.ZarfComponent) ([]types.InstalledChart, []object.ObjMetadata, error) {
 	installedCharts := []types.InstalledChart{}
+	readyObjs := []object.ObjMetadata{}
+
+	buildData := p.componentBuildData(component.Name)
+	hasDifferentialCharts := false
+	for idx := range component.Charts {
+		hasDifferentialCharts = hasDifferentialCharts || isDifferential(buildData.Charts, idx)
+	}
+
+	var previousCharts []types.InstalledChart
+	if len(component.Manifests) > 0 || hasDifferentialCharts {
+		var err error
+		previousCharts, err = p.cluster.GetInstalledChartsForComponent(ctx, p.cfg.Pkg.Metadata.Name, component)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+	}
+
+	for chartIdx, chart := range component.Charts {
+		// Charts left out of a differential package must already be installed by the version it was created against.
+		if isDifferential(buildData.Charts, chartIdx) {
+			installedChart, err := p.differentialChart(chart, component.Name, previousCharts)
+			if err != nil {
+				return nil, nil, err
+			}
+			logger.From(ctx).Info("skipping chart unchanged from the differential package version", "name", chart.Name, "version", p.cfg.Pkg.Build.DifferentialPackageVersion)
+			installedCharts = append(installedCharts, installedChart)
+			continue
+		}
 
-	for _, chart := range component.Charts {
 		// Do not wait for the chart to be ready if data injections are present.
 		if len(component.DataInjections) > 0 {
 			chart.NoWait = true
 		}
 
+		// Charts snapshotted when the package was created must render the same before their values files are templated.
+		if err := p.verifyChartSnapshot(ctx, chart, componentPaths); err != nil {
+			return nil, nil, err
+		}
+
 		// zarf magic for the value file
-		for idx := range chart.ValuesFiles {
+		for idx := range chart.PackagedValuesFiles() {
 			valueFilePath := helm.StandardValuesName(componentPaths.Values, chart, idx)
 			if err := p.variableConfig.ReplaceTextTemplate(valueFilePath); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 
@@ -681,7 +1284,7 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 		// Values overrides are to be applied in order of Helm Chart Defaults -> Zarf `valuesFiles` -> Zarf `variables` -> DeployOpts overrides
 		valuesOverrides, err := p.generateValuesOverrides(chart, component.Name)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		helmCfg := helm.New(
@@ -691,7 +1294,7 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 			helm.WithDeployInfo(
 				p.cfg,
 				p.variableConfig,
-				p.state,
+				p.workloadState(),
 				p.cluster,
 				valuesOverrides,
 				p.cfg.DeployOpts.Timeout,
@@ -700,9 +1303,14 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 
 		connectStrings, installedChartName, err := helmCfg.InstallOrUpgradeChart(ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		installedCharts = append(installedCharts, types.InstalledChart{Namespace: chart.Namespace, ChartName: installedChartName, ConnectStrings: connectStrings})
+		readyObjs = append(readyObjs, helmCfg.ReadyObjects()...)
+
+		if err := p.waitFor(ctx, chart.Namespace, chart.WaitFor); err != nil {
+			return nil, nil, fmt.Errorf("chart %s: %w", chart.Name, err)
+		}
 	}
 
 	for _, manifest := range component.Manifests {
@@ -711,7 +1319,7 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 				// The path is likely invalid because of how we compose OCI components, add an index suffix to the filename
 				manifest.Files[idx] = fmt.Sprintf("%s-%d.yaml", manifest.Name, idx)
 				if helpers.InvalidPath(filepath.Join(componentPaths.Manifests, manifest.Files[idx])) {
-					return nil, fmt.Errorf("unable to find manifest file %s", manifest.Files[idx])
+					return nil, nil, fmt.Errorf("unable to find manifest file %s", manifest.Files[idx])
 				}
 			}
 		}
@@ -735,56 +1343,191 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 			helm.WithDeployInfo(
 				p.cfg,
 				p.variableConfig,
-				p.state,
+				p.workloadState(),
 				p.cluster,
 				nil,
 				p.cfg.DeployOpts.Timeout,
 				p.cfg.PkgOpts.Retries),
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Install the chart.
 		connectStrings, installedChartName, err := helmCfg.InstallOrUpgradeChart(ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		installedChart := types.InstalledChart{
+			Namespace:        manifest.Namespace,
+			ChartName:        installedChartName,
+			ConnectStrings:   connectStrings,
+			CreatedNamespace: helmCfg.CreatedNamespace(),
+		}
+		// Keep track of namespaces created by a previous deployment so they are still cleaned up on remove
+		for _, previous := range previousCharts {
+			if previous.ChartName == installedChart.ChartName && previous.Namespace == installedChart.Namespace && previous.CreatedNamespace {
+				installedChart.CreatedNamespace = true
+			}
+		}
+		installedCharts = append(installedCharts, installedChart)
+		readyObjs = append(readyObjs, helmCfg.ReadyObjects()...)
+
+		if err := p.waitFor(ctx, manifest.Namespace, manifest.WaitFor); err != nil {
+			return nil, nil, fmt.Errorf("manifest %s: %w", manifest.Name, err)
 		}
-		installedCharts = append(installedCharts, types.InstalledChart{Namespace: manifest.Namespace, ChartName: installedChartName, ConnectStrings: connectStrings})
 	}
 
-	return installedCharts, nil
+	return installedCharts, readyObjs, nil
 }
 
-// TODO once deploy is refactored to load the Zarf package and cluster objects in the cmd package
-// table printing should be moved to cmd
-func (p *Packager) printTablesForDeployment(ctx context.Context, componentsToDeploy []types.DeployedComponent) error {
-	// If not init config, print the application connection table
-	if !p.cfg.Pkg.IsInitConfig() {
-		connectStrings := types.ConnectStrings{}
-		for _, comp := range componentsToDeploy {
-			for _, chart := range comp.InstalledCharts {
-				for k, v := range chart.ConnectStrings {
-					connectStrings[k] = v
-				}
-			}
+// readinessTimeout returns how long to wait for the resources of a component to be ready.
+func (p *Packager) readinessTimeout() time.Duration {
+	if p.cfg.DeployOpts.ReadinessTimeout > 0 {
+		return p.cfg.DeployOpts.ReadinessTimeout
+	}
+	return p.cfg.DeployOpts.Timeout
+}
+
+// componentBuildData returns the build data recorded for the component when the package was created.
+func (p *Packager) componentBuildData(name string) v1alpha1.ZarfComponentBuildData {
+	for _, data := range p.cfg.Pkg.Build.Components {
+		if data.Name == name {
+			return data
 		}
-		message.PrintConnectStringTable(connectStrings)
-		return nil
 	}
-	// Don't print if cluster is not configured
-	if p.cluster == nil {
+	return v1alpha1.ZarfComponentBuildData{}
+}
+
+// isDifferential returns if the chart or file at idx was left out of the package because it did not change from the
+// differential package version.
+func isDifferential(contents []v1alpha1.ZarfContentBuildData, idx int) bool {
+	return idx < len(contents) && contents[idx].Differential
+}
+
+// verifyChartSnapshot renders the chart with only the constants of the package and compares the manifests to the snapshot
+// stored when the package was created, guarding against a different helm version rendering the chart differently.
+// Charts without a snapshot are not verified.
+func (p *Packager) verifyChartSnapshot(ctx context.Context, chart v1alpha1.ZarfChart, componentPaths *layout.ComponentPaths) error {
+	snapshot, err := os.ReadFile(helm.SnapshotName(componentPaths.Snapshots, chart))
+	if errors.Is(err, os.ErrNotExist) {
 		return nil
 	}
-	// Grab a fresh copy of the state to print the most up-to-date version of the creds
-	latestState, err := p.cluster.LoadZarfState(ctx)
 	if err != nil {
 		return err
 	}
-	message.PrintCredentialTable(latestState, componentsToDeploy)
+	variableConfig := template.GetZarfVariableConfig(ctx)
+	variableConfig.SetConstants(p.cfg.Pkg.Constants)
+	manifest, err := helm.RenderSnapshot(ctx, chart, componentPaths.Charts, componentPaths.Values, variableConfig)
+	if err != nil {
+		return fmt.Errorf("unable to render chart %s to verify its snapshot: %w", chart.Name, err)
+	}
+	if err := helm.CompareSnapshot(string(snapshot), manifest); err != nil {
+		return fmt.Errorf("chart %s does not render the same as when the package was created, the helm version may have changed: %w", chart.Name, err)
+	}
+	logger.From(ctx).Debug("chart renders the same as its snapshot", "name", chart.Name)
 	return nil
 }
 
+// differentialChart returns the installed chart for a chart left out of a differential package. The chart can not be
+// upgraded, so it is an error to override its values on deploy.
+func (p *Packager) differentialChart(chart v1alpha1.ZarfChart, componentName string, previousCharts []types.InstalledChart) (types.InstalledChart, error) {
+	overrides, err := p.generateValuesOverrides(chart, componentName)
+	if err != nil {
+		return types.InstalledChart{}, err
+	}
+	if len(overrides) > 0 {
+		return types.InstalledChart{}, fmt.Errorf("chart %s is not in this differential package so its values can not be overridden on deploy, deploy the full package to change them", chart.Name)
+	}
+	releaseName := chart.ReleaseName
+	if releaseName == "" {
+		releaseName = chart.Name
+	}
+	for _, previous := range previousCharts {
+		if previous.ChartName == releaseName && previous.Namespace == chart.Namespace {
+			return previous, nil
+		}
+	}
+	return types.InstalledChart{}, fmt.Errorf("chart %s is not in this differential package and is not installed, deploy version %s of the package first", chart.Name, p.cfg.Pkg.Build.DifferentialPackageVersion)
+}
+
+// validateDifferentialFile validates that a file left out of a differential package was placed by a previous deploy.
+func (p *Packager) validateDifferentialFile(file v1alpha1.ZarfFile) error {
+	target, err := config.GetAbsHomePath(file.Target)
+	if err != nil {
+		return err
+	}
+	if helpers.InvalidPath(target) {
+		return fmt.Errorf("file %s is not in this differential package and does not exist, deploy version %s of the package first", file.Target, p.cfg.Pkg.Build.DifferentialPackageVersion)
+	}
+	return nil
+}
+
+// waitFor waits for the resource conditions of a chart or manifest, resources without a namespace are looked up in the
+// namespace of the chart or manifest.
+func (p *Packager) waitFor(ctx context.Context, namespace string, waitFor []v1alpha1.ZarfWaitFor) error {
+	l := logger.From(ctx)
+	for _, wait := range waitFor {
+		start := time.Now()
+		waitNamespace := wait.Namespace
+		if waitNamespace == "" {
+			waitNamespace = namespace
+		}
+		timeout := p.cfg.DeployOpts.Timeout
+		if wait.MaxTotalSeconds > 0 {
+			timeout = time.Duration(wait.MaxTotalSeconds) * time.Second
+		}
+		l.Info("waiting for resource", "kind", wait.Kind, "name", wait.Name, "namespace", waitNamespace, "condition", wait.Condition)
+		err := utils.ExecuteWait(timeout.String(), waitNamespace, wait.Condition, wait.Kind, wait.Name, timeout)
+		if err != nil {
+			return fmt.Errorf("unable to wait for %s %s in the namespace %s: %w", wait.Kind, wait.Name, waitNamespace, err)
+		}
+		l.Debug("done waiting for resource", "kind", wait.Kind, "name", wait.Name, "namespace", waitNamespace, "duration", time.Since(start))
+	}
+	return nil
+}
+
+// newDeployResult describes the deployment of the components for automation and the deployment tables.
+func (p *Packager) newDeployResult(ctx context.Context, deployedComponents []types.DeployedComponent, notes string, warnings []string) (types.DeployResult, error) {
+	result := types.DeployResult{
+		Package:        p.cfg.Pkg.Metadata.Name,
+		Version:        p.cfg.Pkg.Metadata.Version,
+		Components:     deployedComponents,
+		ConnectStrings: types.ConnectStrings{},
+		Notes:          notes,
+		Warnings:       warnings,
+	}
+	for _, comp := range deployedComponents {
+		for _, chart := range comp.InstalledCharts {
+			for k, v := range chart.ConnectStrings {
+				result.ConnectStrings[k] = v
+			}
+		}
+	}
+	// Credentials are only generated by init packages, and can't be read if the cluster is not configured
+	if !p.cfg.Pkg.IsInitConfig() || p.cluster == nil {
+		return result, nil
+	}
+	// Grab a fresh copy of the state to report the most up-to-date version of the creds
+	latestState, err := p.cluster.LoadZarfState(ctx)
+	if err != nil {
+		return types.DeployResult{}, err
+	}
+	result.Credentials = message.Credentials(latestState, deployedComponents)
+	return result, nil
+}
+
+// TODO once deploy is refactored to load the Zarf package and cluster objects in the cmd package
+// table printing should be moved to cmd
+// printTablesForDeployment prints the connect strings of a package or the credentials of an init package.
+func (p *Packager) printTablesForDeployment() {
+	if !p.cfg.Pkg.IsInitConfig() {
+		message.PrintConnectStringTable(p.deployResult.ConnectStrings)
+		return
+	}
+	message.PrintCredentials(p.deployResult.Credentials)
+}
+
 // ServiceInfoFromServiceURL takes a serviceURL and parses it to find the service info for connecting to the cluster. The string is expected to follow the following format:
 // Example serviceURL: http://{SERVICE_NAME}.{NAMESPACE}.svc.cluster.local:{PORT}.
 func serviceInfoFromServiceURL(serviceURL string) (string, string, int, error) {
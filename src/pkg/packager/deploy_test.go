@@ -4,10 +4,16 @@
 package packager
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -196,6 +202,26 @@ func TestGenerateValuesOverrides(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Helm values set on deploy take precedence",
+			chart: v1alpha1.ZarfChart{
+				Name:      "podinfo",
+				Variables: []v1alpha1.ZarfChartVariable{{Name: "REPLICAS", Path: "replicaCount"}},
+			},
+			setVariables: map[string]string{"REPLICAS": "2"},
+			deployOpts: types.ZarfDeployOptions{
+				ValuesOverridesMap: map[string]map[string]map[string]any{
+					"podinfo": {"podinfo": {"storageClass": "standard"}},
+				},
+				SetHelmValues: []string{"podinfo.replicaCount=3", "podinfo.nodeSelector.disk=ssd", "other.storageClass=fast", "podinfo.storageClass=local"},
+			},
+			componentName: "podinfo",
+			want: map[string]any{
+				"replicaCount": int64(3),
+				"nodeSelector": map[string]any{"disk": "ssd"},
+				"storageClass": "local",
+			},
+		},
 		{
 			name:  "Empty chartVariables and non-empty setVariableMap",
 			chart: v1alpha1.ZarfChart{Name: "chart-with-vars"},
@@ -272,3 +298,170 @@ func TestServiceInfoFromServiceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestDifferentialChart(t *testing.T) {
+	t.Parallel()
+
+	p := &Packager{cfg: &types.PackagerConfig{Pkg: v1alpha1.ZarfPackage{Build: v1alpha1.ZarfBuildData{DifferentialPackageVersion: "v0.25.0"}}}}
+	previousCharts := []types.InstalledChart{
+		{Namespace: "podinfo", ChartName: "podinfo"},
+		{Namespace: "podinfo", ChartName: "other-release"},
+	}
+
+	installed, err := p.differentialChart(v1alpha1.ZarfChart{Name: "podinfo", Namespace: "podinfo"}, "podinfo", previousCharts)
+	require.NoError(t, err)
+	require.Equal(t, previousCharts[0], installed)
+
+	installed, err = p.differentialChart(v1alpha1.ZarfChart{Name: "podinfo", ReleaseName: "other-release", Namespace: "podinfo"}, "podinfo", previousCharts)
+	require.NoError(t, err)
+	require.Equal(t, previousCharts[1], installed)
+
+	_, err = p.differentialChart(v1alpha1.ZarfChart{Name: "podinfo", Namespace: "other"}, "podinfo", previousCharts)
+	require.EqualError(t, err, "chart podinfo is not in this differential package and is not installed, deploy version v0.25.0 of the package first")
+
+	// Values set on deploy can not be applied to a chart that is not upgraded.
+	p.cfg.DeployOpts.SetHelmValues = []string{"podinfo.replicaCount=2"}
+	_, err = p.differentialChart(v1alpha1.ZarfChart{Name: "podinfo", Namespace: "podinfo"}, "podinfo", previousCharts)
+	require.EqualError(t, err, "chart podinfo is not in this differential package so its values can not be overridden on deploy, deploy the full package to change them")
+	p.cfg.DeployOpts.SetHelmValues = nil
+	p.cfg.DeployOpts.ValuesOverridesMap = map[string]map[string]map[string]any{"podinfo": {"podinfo": {"replicaCount": 2}}}
+	_, err = p.differentialChart(v1alpha1.ZarfChart{Name: "podinfo", Namespace: "podinfo"}, "podinfo", previousCharts)
+	require.ErrorContains(t, err, "its values can not be overridden on deploy")
+
+	require.True(t, isDifferential([]v1alpha1.ZarfContentBuildData{{}, {Differential: true}}, 1))
+	require.False(t, isDifferential([]v1alpha1.ZarfContentBuildData{{}, {Differential: true}}, 0))
+	require.False(t, isDifferential(nil, 0))
+}
+
+func TestRenderNotes(t *testing.T) {
+	t.Parallel()
+
+	p, err := New(&types.PackagerConfig{}, WithSource(&sources.TarballSource{}))
+	require.NoError(t, err)
+	p.variableConfig.SetConstants([]v1alpha1.Constant{{Name: "PORT", Value: "8080"}})
+	p.variableConfig.SetVariable("HOSTNAME", "podinfo.example.com", false, false, v1alpha1.RawVariableType)
+
+	notes, err := renderNotes(p.variableConfig, "")
+	require.NoError(t, err)
+	require.Empty(t, notes)
+
+	notes, err = renderNotes(p.variableConfig, "Podinfo is served at\n  https://###ZARF_VAR_HOSTNAME###:###ZARF_CONST_PORT###\n")
+	require.NoError(t, err)
+	require.Equal(t, "Podinfo is served at\n  https://podinfo.example.com:8080", notes)
+}
+
+func TestReadinessTimeout(t *testing.T) {
+	t.Parallel()
+
+	p := &Packager{cfg: &types.PackagerConfig{DeployOpts: types.ZarfDeployOptions{Timeout: 15 * time.Minute}}}
+	require.Equal(t, 15*time.Minute, p.readinessTimeout())
+	p.cfg.DeployOpts.ReadinessTimeout = 5 * time.Minute
+	require.Equal(t, 5*time.Minute, p.readinessTimeout())
+}
+
+func TestPresetVariables(t *testing.T) {
+	t.Parallel()
+
+	declared := []v1alpha1.InteractiveVariable{{Variable: v1alpha1.Variable{Name: "DOMAIN"}}, {Variable: v1alpha1.Variable{Name: "REPLICAS"}}}
+	set := map[string]string{"REPLICAS": "3"}
+	shared := map[string]string{"DOMAIN": "example.com", "REPLICAS": "2", "REGION": "east"}
+	require.Equal(t, map[string]string{"DOMAIN": "example.com", "REPLICAS": "3"}, presetVariables(declared, set, shared))
+	require.Equal(t, map[string]string{"REPLICAS": "3"}, set)
+	require.Equal(t, set, presetVariables(declared, set, nil))
+	require.Equal(t, map[string]string{"DOMAIN": "example.com", "REPLICAS": "2"}, presetVariables(declared, nil, shared))
+}
+
+func TestMultiArchFilter(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Build: v1alpha1.ZarfBuildData{
+			Architecture:  "multi",
+			Architectures: []string{"amd64", "arm64"},
+		},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "common"},
+			{Name: "amd64", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: "amd64"}}},
+			{Name: "arm64", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: "arm64"}}},
+		},
+	}
+	lookups := 0
+	filter := &multiArchFilter{
+		nodeArchitectures: func() []string {
+			lookups++
+			return []string{"arm64"}
+		},
+	}
+	components, err := filter.Apply(pkg)
+	require.NoError(t, err)
+	require.Equal(t, "arm64", filter.selected)
+	require.Len(t, components, 2)
+	require.Equal(t, "common", components[0].Name)
+	require.Equal(t, "arm64", components[1].Name)
+
+	// The selected architecture is reused when the filter is applied again.
+	_, err = filter.Apply(pkg)
+	require.NoError(t, err)
+	require.Equal(t, 1, lookups)
+
+	// Packages created for a single architecture are not filtered.
+	pkg.Build.Architecture = "amd64"
+	components, err = (&multiArchFilter{}).Apply(pkg)
+	require.NoError(t, err)
+	require.Len(t, components, 3)
+}
+
+func TestTunnelPool(t *testing.T) {
+	t.Parallel()
+
+	p := &Packager{}
+	require.Nil(t, p.tunnelPool())
+
+	first := &cluster.Cluster{}
+	second := &cluster.Cluster{}
+	p.cluster = first
+	pool := p.tunnelPool()
+	require.NotNil(t, pool)
+	require.Same(t, pool, p.tunnelPool())
+	p.cluster = second
+	require.NotSame(t, pool, p.tunnelPool())
+
+	p.closeTunnels()
+	require.Empty(t, p.tunnelPools)
+}
+
+func TestProcessComponentFilesExtracted(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	pkgLocation := filepath.Join(tmp, "files")
+	target := filepath.Join(tmp, "target", "archive")
+	err := os.MkdirAll(filepath.Join(pkgLocation, "0", "archive"), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(pkgLocation, "0", "archive", "file.txt"), []byte("data"), 0o644)
+	require.NoError(t, err)
+
+	templated := false
+	component := v1alpha1.ZarfComponent{
+		Name: "extract",
+		Files: []v1alpha1.ZarfFile{
+			{
+				Source:    "archive.tar.gz",
+				Target:    target,
+				Extract:   true,
+				Shasum:    "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+				Templated: &templated,
+			},
+		},
+	}
+	p := &Packager{
+		cfg:    &types.PackagerConfig{Pkg: v1alpha1.ZarfPackage{Components: []v1alpha1.ZarfComponent{component}}},
+		layout: layout.New(tmp),
+	}
+	// The shasum of the archive is not checked against the directory it was extracted into.
+	err = p.processComponentFiles(context.Background(), component, pkgLocation)
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(target, "file.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(b))
+}
@@ -26,7 +26,8 @@ func (p *Packager) DevDeploy(ctx context.Context) error {
 	l := logger.From(ctx)
 	start := time.Now()
 	config.CommonOptions.Confirm = true
-	p.cfg.CreateOpts.SkipSBOM = !p.cfg.CreateOpts.NoYOLO
+	// Components are deployed straight from the assembled build directory, so there is no package to attach an SBOM to.
+	p.cfg.CreateOpts.SkipSBOM = true
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -61,15 +62,19 @@ func (p *Packager) DevDeploy(ctx context.Context) error {
 		return fmt.Errorf("package validation failed: %w", err)
 	}
 
+	if err := p.importPackageVariables(ctx); err != nil {
+		return err
+	}
 	if err := p.populatePackageVariableConfig(); err != nil {
 		return fmt.Errorf("unable to set the active variables: %w", err)
 	}
 
-	// If building in yolo mode, strip out all images and repos
+	// If building in yolo mode, strip out all images, repos and package mirrors
 	if !p.cfg.CreateOpts.NoYOLO {
 		for idx := range p.cfg.Pkg.Components {
 			p.cfg.Pkg.Components[idx].Images = []string{}
 			p.cfg.Pkg.Components[idx].Repos = []string{}
+			p.cfg.Pkg.Components[idx].PackageMirrors = nil
 		}
 	}
 
@@ -106,6 +111,8 @@ func (p *Packager) DevDeploy(ctx context.Context) error {
 		defer p.resetRegistryHPA(ctx)
 	}
 
+	defer p.closeTunnels()
+
 	// Get a list of all the components we are deploying and actually deploy them
 	deployedComponents, err := p.deployComponents(ctx)
 	if err != nil {
@@ -116,10 +123,22 @@ func (p *Packager) DevDeploy(ctx context.Context) error {
 		l.Warn("No components were selected for deployment.  Inspect the package to view the available components and select components interactively or by name with \"--components\"")
 	}
 
+	notes, err := renderNotes(p.variableConfig, p.cfg.Pkg.Notes)
+	if err != nil {
+		return fmt.Errorf("unable to render the notes of the package: %w", err)
+	}
+	p.recordPackageNotes(ctx, notes)
+	p.recordPackageExports(ctx)
+
 	// Notify all the things about the successful deployment
 	message.Successf("Zarf dev deployment complete")
 	l.Debug("dev deployment complete", "package", p.cfg.Pkg.Metadata.Name, "duration", time.Since(start))
 
+	for _, comp := range deployedComponents {
+		message.PrintNotes(fmt.Sprintf("Notes for component %s", comp.Name), comp.Notes)
+	}
+	message.PrintNotes(fmt.Sprintf("Notes for package %s", p.cfg.Pkg.Metadata.Name), notes)
+
 	message.HorizontalRule()
 	message.Title("Next steps:", "")
 
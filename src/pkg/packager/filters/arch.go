@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// ByArchitecture creates a new filter that filters components based on the cluster architecture.
+func ByArchitecture(arch string) ComponentFilterStrategy {
+	return &architectureFilter{arch}
+}
+
+// architectureFilter filters components based on the cluster architecture.
+type architectureFilter struct {
+	arch string
+}
+
+// ErrArchitectureRequired is returned when arch is not set.
+var ErrArchitectureRequired = errors.New("architecture is required")
+
+// Apply applies the filter.
+func (f *architectureFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfComponent, error) {
+	if f.arch == "" {
+		return nil, ErrArchitectureRequired
+	}
+
+	filtered := []v1alpha1.ZarfComponent{}
+	for _, component := range pkg.Components {
+		if component.Only.Cluster.Architecture == "" || component.Only.Cluster.Architecture == f.arch {
+			filtered = append(filtered, component)
+		}
+	}
+	return filtered, nil
+}
+
+// SelectArchitecture returns the architecture to deploy a package created for multiple architectures with. The
+// requested architecture is used when it is set, otherwise the first package architecture that one of the cluster
+// nodes has, or the local architecture when the node architectures are not known.
+func SelectArchitecture(pkgArchs []string, requested string, nodeArchs []string, localArch string) (string, error) {
+	if requested != "" {
+		if !slices.Contains(pkgArchs, requested) {
+			return "", fmt.Errorf("the package was not created for architecture %s, available architectures are %s", requested, strings.Join(pkgArchs, ", "))
+		}
+		return requested, nil
+	}
+	if len(nodeArchs) > 0 {
+		for _, arch := range pkgArchs {
+			if slices.Contains(nodeArchs, arch) {
+				return arch, nil
+			}
+		}
+		return "", fmt.Errorf("none of the package architectures %s match the cluster node architectures %s", strings.Join(pkgArchs, ", "), strings.Join(nodeArchs, ", "))
+	}
+	if slices.Contains(pkgArchs, localArch) {
+		return localArch, nil
+	}
+	return "", fmt.Errorf("unable to select an architecture from %s, set one with --architecture", strings.Join(pkgArchs, ", "))
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestArchitectureFilter(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{}
+	for _, arch := range []string{"", "amd64", "arm64"} {
+		pkg.Components = append(pkg.Components, v1alpha1.ZarfComponent{
+			Name: "component-" + arch,
+			Only: v1alpha1.ZarfComponentOnlyTarget{
+				Cluster: v1alpha1.ZarfComponentOnlyCluster{
+					Architecture: arch,
+				},
+			},
+		})
+	}
+
+	_, err := ByArchitecture("").Apply(pkg)
+	require.ErrorIs(t, err, ErrArchitectureRequired)
+
+	result, err := ByArchitecture("arm64").Apply(pkg)
+	require.NoError(t, err)
+	names := []string{}
+	for _, component := range result {
+		names = append(names, component.Name)
+	}
+	require.Equal(t, []string{"component-", "component-arm64"}, names)
+}
+
+func TestSelectArchitecture(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		requested   string
+		nodeArchs   []string
+		localArch   string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:      "requested architecture",
+			requested: "arm64",
+			nodeArchs: []string{"amd64"},
+			localArch: "amd64",
+			expected:  "arm64",
+		},
+		{
+			name:        "requested architecture not in package",
+			requested:   "s390x",
+			expectedErr: "the package was not created for architecture s390x, available architectures are amd64, arm64",
+		},
+		{
+			name:      "first package architecture of the nodes",
+			nodeArchs: []string{"arm64", "amd64"},
+			localArch: "arm64",
+			expected:  "amd64",
+		},
+		{
+			name:        "no node architecture in package",
+			nodeArchs:   []string{"s390x"},
+			localArch:   "amd64",
+			expectedErr: "none of the package architectures amd64, arm64 match the cluster node architectures s390x",
+		},
+		{
+			name:      "local architecture without nodes",
+			localArch: "arm64",
+			expected:  "arm64",
+		},
+		{
+			name:        "local architecture not in package",
+			localArch:   "s390x",
+			expectedErr: "unable to select an architecture from amd64, arm64, set one with --architecture",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			arch, err := SelectArchitecture([]string{"amd64", "arm64"}, tt.requested, tt.nodeArchs, tt.localArch)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, arch)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/pkg/message"
 )
 
 // ForDeploy creates a new deployment filter.
@@ -131,6 +132,27 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 			}
 		}
 	} else {
+		// The optional components are selected together so that packages with many of them are quick to configure.
+		selectedOptional := map[string]bool{}
+		if f.isInteractive {
+			optionalComponents := []v1alpha1.ZarfComponent{}
+			for _, groupKey := range orderedComponentGroups {
+				group := groupedComponents[groupKey]
+				if len(group) == 1 && !group[0].IsRequired() {
+					optionalComponents = append(optionalComponents, group[0])
+				}
+			}
+			if len(optionalComponents) > 0 {
+				selected, err := interactive.SelectOptionalComponents(optionalComponents)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrSelectionCanceled, err)
+				}
+				for _, name := range selected {
+					selectedOptional[name] = true
+				}
+			}
+		}
+
 		for _, groupKey := range orderedComponentGroups {
 			group := groupedComponents[groupKey]
 			if len(group) > 1 {
@@ -167,14 +189,10 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 				}
 
 				if f.isInteractive {
-					selected, err := interactive.SelectOptionalComponent(component)
-					if err != nil {
-						return nil, fmt.Errorf("%w: %w", ErrSelectionCanceled, err)
-					}
-					if selected {
+					if selectedOptional[component.Name] {
 						selectedComponents = append(selectedComponents, component)
-						continue
 					}
+					continue
 				}
 
 				if component.Default {
@@ -183,7 +201,34 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 				}
 			}
 		}
+
+		if f.isInteractive {
+			message.Notef("To deploy the same components without being prompted use --components=%q", componentsFlag(pkg.Components, selectedComponents))
+		}
 	}
 
 	return selectedComponents, nil
 }
+
+// componentsFlag returns the value of --components that selects the same components without prompting. Optional
+// components are listed when they are selected and are not a default, and excluded when they are a default that was not selected.
+func componentsFlag(components []v1alpha1.ZarfComponent, selectedComponents []v1alpha1.ZarfComponent) string {
+	selected := map[string]bool{}
+	for _, component := range selectedComponents {
+		selected[component.Name] = true
+	}
+	requests := []string{}
+	for _, component := range components {
+		if component.IsRequired() {
+			continue
+		}
+		if selected[component.Name] && !component.Default {
+			requests = append(requests, component.Name)
+		}
+		// Only a single component can be selected from a group, so excluding the default of a group is not needed.
+		if !selected[component.Name] && component.Default && component.DeprecatedGroup == "" {
+			requests = append(requests, "-"+component.Name)
+		}
+	}
+	return strings.Join(requests, ",")
+}
@@ -9,15 +9,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/pterm/pterm"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []string, sbomViewFiles []string) bool {
@@ -60,6 +65,28 @@ func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []s
 		}
 	}
 
+	if stage == config.ZarfDeployStage {
+		summaries := variableSummaries(p.cfg.Pkg.Variables, p.cfg.PkgOpts.SetVariables, p.cfg.PkgOpts.ConfigVariables, !config.CommonOptions.Confirm)
+		if len(summaries) > 0 {
+			message.HorizontalRule()
+			message.Title("Deployment Variables", "the values that will be templated into the package")
+			message.PrintVariableTable(summaries)
+			for _, summary := range summaries {
+				l.Info("deployment variable", "name", summary.Name, "source", summary.Source, "value", summary.Value, "default", summary.Default, "changed", summary.Changed, "sensitive", summary.Sensitive)
+			}
+		}
+
+		rows := resourceEstimateRows(p.cfg.Pkg)
+		if len(rows) > 0 {
+			message.HorizontalRule()
+			message.Title("Resource Requests", "the compute resources requested by the workloads of each component, estimated when the package was created")
+			message.Table([]string{"Component", "CPU", "Memory"}, rows)
+			for _, row := range rows {
+				l.Info("estimated resource requests", "component", row[0], "cpu", row[1], "memory", row[2])
+			}
+		}
+	}
+
 	if len(warnings) > 0 {
 		message.HorizontalRule()
 		message.Title("Package Warnings", "the following warnings were flagged while reading the package")
@@ -120,3 +147,89 @@ func (p *Packager) getPackageYAMLHints(stage string) map[string]string {
 
 	return hints
 }
+
+// resourceEstimateRows returns the estimated resource requests of the components in the package that have an estimate,
+// followed by their total when more than one component has an estimate.
+func resourceEstimateRows(pkg v1alpha1.ZarfPackage) [][]string {
+	estimates := map[string]*v1alpha1.ZarfResourceEstimate{}
+	for _, data := range pkg.Build.Components {
+		estimates[data.Name] = data.Resources
+	}
+	rows := [][]string{}
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	for _, component := range pkg.Components {
+		estimate := estimates[component.Name]
+		if estimate == nil {
+			continue
+		}
+		rows = append(rows, []string{component.Name, estimate.CPURequests, estimate.MemoryRequests})
+		if q, err := resource.ParseQuantity(estimate.CPURequests); err == nil {
+			cpu.Add(q)
+		}
+		if q, err := resource.ParseQuantity(estimate.MemoryRequests); err == nil {
+			memory.Add(q)
+		}
+	}
+	if len(rows) > 1 {
+		rows = append(rows, []string{"Total", cpu.String(), memory.String()})
+	}
+	return rows
+}
+
+// variableSummaries describes where the value of each variable comes from, variables that are set but not defined by the package are listed last.
+func variableSummaries(pkgVariables []v1alpha1.InteractiveVariable, setVariables map[string]string, configVariables []string, interactive bool) []types.VariableSummary {
+	summaries := []types.VariableSummary{}
+	defined := map[string]bool{}
+	for _, variable := range pkgVariables {
+		defined[variable.Name] = true
+		summary := types.VariableSummary{
+			Name:      variable.Name,
+			Source:    types.VariableSourceDefault,
+			Value:     variable.Default,
+			Default:   variable.Default,
+			Sensitive: variable.Sensitive,
+		}
+		if value, ok := setVariables[variable.Name]; ok {
+			summary.Source = types.VariableSourceFlag
+			if slices.Contains(configVariables, variable.Name) {
+				summary.Source = types.VariableSourceConfig
+			}
+			summary.Value = value
+			summary.Changed = value != variable.Default
+		} else if variable.Prompt && interactive {
+			summary.Source = types.VariableSourcePrompt
+			summary.Value = ""
+		}
+		if variable.Sensitive {
+			if summary.Value != "" {
+				summary.Value = "**sanitized**"
+			}
+			if summary.Default != "" {
+				summary.Default = "**sanitized**"
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	undefined := []string{}
+	for name := range setVariables {
+		if !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	slices.Sort(undefined)
+	for _, name := range undefined {
+		source := types.VariableSourceFlag
+		if slices.Contains(configVariables, name) {
+			source = types.VariableSourceConfig
+		}
+		summaries = append(summaries, types.VariableSummary{
+			Name:    name,
+			Source:  source,
+			Value:   setVariables[name],
+			Changed: setVariables[name] != "",
+		})
+	}
+	return summaries
+}
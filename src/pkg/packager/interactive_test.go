@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestVariableSummaries(t *testing.T) {
+	t.Parallel()
+
+	pkgVariables := []v1alpha1.InteractiveVariable{
+		{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Default: "uds.dev"},
+		{Variable: v1alpha1.Variable{Name: "REPLICAS"}, Default: "1"},
+		{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}, Default: "changeme", Prompt: true},
+		{Variable: v1alpha1.Variable{Name: "USERNAME"}, Default: "admin", Prompt: true},
+	}
+	setVariables := map[string]string{
+		"REPLICAS": "3",
+		"PASSWORD": "hunter2",
+		"EXTRA":    "value",
+	}
+	configVariables := []string{"PASSWORD"}
+
+	expected := []types.VariableSummary{
+		{Name: "DOMAIN", Source: types.VariableSourceDefault, Value: "uds.dev", Default: "uds.dev"},
+		{Name: "REPLICAS", Source: types.VariableSourceFlag, Value: "3", Default: "1", Changed: true},
+		{Name: "PASSWORD", Source: types.VariableSourceConfig, Value: "**sanitized**", Default: "**sanitized**", Changed: true, Sensitive: true},
+		{Name: "USERNAME", Source: types.VariableSourcePrompt, Value: "", Default: "admin"},
+		{Name: "EXTRA", Source: types.VariableSourceFlag, Value: "value", Changed: true},
+	}
+	require.Equal(t, expected, variableSummaries(pkgVariables, setVariables, configVariables, true))
+
+	// Variables are not prompted for without an interactive deployment.
+	summaries := variableSummaries(pkgVariables, setVariables, configVariables, false)
+	require.Equal(t, types.VariableSummary{Name: "USERNAME", Source: types.VariableSourceDefault, Value: "admin", Default: "admin"}, summaries[3])
+}
+
+func TestResourceEstimateRows(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{{Name: "podinfo"}, {Name: "files"}, {Name: "redis"}},
+		Build: v1alpha1.ZarfBuildData{
+			Components: []v1alpha1.ZarfComponentBuildData{
+				{Name: "podinfo", Resources: &v1alpha1.ZarfResourceEstimate{CPURequests: "500m", MemoryRequests: "128Mi"}},
+				{Name: "files"},
+				{Name: "redis", Resources: &v1alpha1.ZarfResourceEstimate{CPURequests: "1", MemoryRequests: "1Gi"}},
+			},
+		},
+	}
+	expected := [][]string{
+		{"podinfo", "500m", "128Mi"},
+		{"redis", "1", "1Gi"},
+		{"Total", "1500m", "1152Mi"},
+	}
+	require.Equal(t, expected, resourceEstimateRows(pkg))
+
+	pkg.Components = pkg.Components[:2]
+	require.Equal(t, [][]string{{"podinfo", "500m", "128Mi"}}, resourceEstimateRows(pkg))
+}
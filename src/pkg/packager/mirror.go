@@ -46,6 +46,7 @@ func (p *Packager) Mirror(ctx context.Context) error {
 		GitServer:    p.cfg.InitOpts.GitServer,
 	}
 
+	defer p.closeTunnels()
 	for _, component := range p.cfg.Pkg.Components {
 		if err := p.mirrorComponent(ctx, component); err != nil {
 			return err
@@ -220,7 +220,7 @@ func (p *Packager) findImages(ctx context.Context) (map[string][]string, error)
 				kname := fmt.Sprintf("kustomization-%s-%d.yaml", manifest.Name, idx)
 				// Use the temp folder because if "helpers.CreatePathAndCopy" is provided with the same path it will result in the file being empty
 				destination := filepath.Join(componentPaths.Temp, kname)
-				if err := kustomize.Build(k, destination, manifest.KustomizeAllowAnyDirectory); err != nil {
+				if err := kustomize.Build(k, destination, manifest.KustomizeAllowAnyDirectory, manifest.KustomizeOptions); err != nil {
 					return nil, fmt.Errorf("unable to build the kustomization for %s: %w", k, err)
 				}
 				manifest.Files = append(manifest.Files, destination)
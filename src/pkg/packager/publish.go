@@ -5,9 +5,15 @@
 package packager
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,8 +39,12 @@ func (p *Packager) Publish(ctx context.Context) (err error) {
 	start := time.Now()
 	l.Debug("start publish")
 
+	if err := ValidateAttestations(p.cfg.PublishOpts.Attestations, p.cfg.PublishOpts.SigningKeyPath, p.cfg.PublishOpts.Keyless); err != nil {
+		return err
+	}
+
 	_, isOCISource := p.source.(*sources.OCISource)
-	if isOCISource && p.cfg.PublishOpts.SigningKeyPath == "" {
+	if isOCISource && !p.modifiesPackage() {
 		// oci --> oci is a special case, where we will use oci.CopyPackage so that we can transfer the package
 		// w/o layers touching the filesystem
 		srcRemote := p.source.(*sources.OCISource).Remote
@@ -54,6 +64,19 @@ func (p *Packager) Publish(ctx context.Context) (err error) {
 		return zoci.CopyPackage(ctx, srcRemote, dstRemote, config.CommonOptions.OCIConcurrency)
 	}
 
+	if !p.cfg.CreateOpts.IsSkeleton && !p.modifiesPackage() {
+		switch p.source.(type) {
+		case *sources.TarballSource, *sources.SplitTarballSource:
+			// tarball --> oci streams the existing layers out of the archive so that the package
+			// does not need to be extracted and the component tarballs do not need to be recreated
+			err := p.publishArchive(ctx)
+			if !errors.Is(err, errLegacyArchive) {
+				return err
+			}
+			l.Debug("package uses the legacy layout, extracting the package to publish it")
+		}
+	}
+
 	if p.cfg.CreateOpts.IsSkeleton {
 		if err := os.Chdir(p.cfg.CreateOpts.BaseDir); err != nil {
 			return fmt.Errorf("unable to access directory %q: %w", p.cfg.CreateOpts.BaseDir, err)
@@ -84,8 +107,12 @@ func (p *Packager) Publish(ctx context.Context) (err error) {
 			return fmt.Errorf("unable to load the package: %w", err)
 		}
 
-		// Sign the package if a key has been provided
-		if err := p.layout.SignPackage(p.cfg.PublishOpts.SigningKeyPath, p.cfg.PublishOpts.SigningKeyPassword, !config.CommonOptions.Confirm); err != nil {
+		// Sign the package if a key has been provided or keyless signing was requested
+		if p.cfg.PublishOpts.Keyless {
+			if err := p.layout.SignPackageKeyless(utils.KeylessOptions{IdentityToken: p.cfg.PublishOpts.IdentityToken}); err != nil {
+				return err
+			}
+		} else if err := p.layout.SignPackage(p.cfg.PublishOpts.SigningKeyPath, p.cfg.PublishOpts.SigningKeyPassword, p.cfg.PublishOpts.TSAURL, !config.CommonOptions.Confirm); err != nil {
 			return err
 		}
 	}
@@ -108,10 +135,19 @@ func (p *Packager) Publish(ctx context.Context) (err error) {
 
 	message.HeaderInfof("📦 PACKAGE PUBLISH %s:%s", p.cfg.Pkg.Metadata.Name, ref)
 
-	// Publish the package/skeleton to the registry
-	if err := remote.PublishPackage(ctx, &p.cfg.Pkg, p.layout, config.CommonOptions.OCIConcurrency); err != nil {
+	publishOpts, err := p.publishOptions()
+	if err != nil {
 		return err
 	}
+	// Publish the package/skeleton to the registry
+	if err := remote.PublishPackage(ctx, &p.cfg.Pkg, p.layout, publishOpts); err != nil {
+		return p.resumeHint(err)
+	}
+	if len(p.cfg.PublishOpts.Attestations) > 0 {
+		if err := p.attachAttestations(ctx, remote); err != nil {
+			return fmt.Errorf("unable to attach attestations: %w", err)
+		}
+	}
 	if p.cfg.CreateOpts.IsSkeleton {
 		message.Title("How to import components from this skeleton:", "")
 		ex := []v1alpha1.ZarfComponent{}
@@ -136,3 +172,176 @@ func (p *Packager) Publish(ctx context.Context) (err error) {
 	)
 	return nil
 }
+
+// modifiesPackage returns true when publishing signs the package or attaches attestations to it, which the fast paths
+// that copy the package as is can not do.
+func (p *Packager) modifiesPackage() bool {
+	return p.cfg.PublishOpts.SigningKeyPath != "" || p.cfg.PublishOpts.Keyless || len(p.cfg.PublishOpts.Attestations) > 0
+}
+
+// errLegacyArchive is returned when a package archive does not contain checksums for its layers.
+var errLegacyArchive = errors.New("package archive uses the legacy layout")
+
+// publishArchive publishes a package tarball or split tarball set by streaming its files directly as layers.
+// Layer digests are taken from the package checksums so the registry verifies the content as it is pushed.
+func (p *Packager) publishArchive(ctx context.Context) error {
+	l := logger.From(ctx)
+	start := time.Now()
+	source := p.cfg.PkgOpts.PackageSource
+
+	spinner := message.NewProgressSpinner("Loading package from %q", source)
+	defer spinner.Stop()
+	l.Info("loading package", "source", source)
+
+	// Only the package metadata is read to disk, all other files are streamed when publishing.
+	metadataFiles := []string{layout.ZarfYAML, layout.Checksums, layout.Signature, layout.ChecksumsSignature, layout.SignatureTimestamp, layout.ChecksumsSignatureTimestamp, layout.SignatureBundle, layout.ChecksumsSignatureBundle}
+	sizes := map[string]int64{}
+	sum, err := sources.WalkPackageArchive(source, func(name string, size int64, r io.Reader) error {
+		sizes[name] = size
+		if !slices.Contains(metadataFiles, name) {
+			return nil
+		}
+		f, err := os.Create(filepath.Join(p.layout.Base, name))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(f, r)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read the package: %w", err)
+	}
+	if p.cfg.PkgOpts.Shasum != "" && p.cfg.PkgOpts.Shasum != sum {
+		return fmt.Errorf("expected sha256 of %s, got %s", p.cfg.PkgOpts.Shasum, sum)
+	}
+
+	p.layout.SetFromPaths(slices.Sorted(maps.Keys(sizes)))
+	if p.layout.Checksums == "" {
+		return errLegacyArchive
+	}
+	pkg, _, err := p.layout.ReadZarfYAML()
+	if err != nil {
+		return err
+	}
+	if err := helpers.SHAsMatch(p.layout.Checksums, pkg.Metadata.AggregateChecksum); err != nil {
+		return fmt.Errorf("package integrity check failed: %w", err)
+	}
+	if err := sources.ValidatePackageSignature(ctx, p.layout, p.cfg.PkgOpts.PublicKeyPath, sources.CertificateIdentity(p.cfg.PkgOpts), p.cfg.PkgOpts.VerificationPolicy, p.cfg.PkgOpts.PackageSource, p.cfg.PkgOpts.SkipSignatureValidation); err != nil {
+		return err
+	}
+
+	checksums, err := readChecksums(p.layout.Checksums)
+	if err != nil {
+		return err
+	}
+	files := p.layout.Files()
+	for rel := range checksums {
+		if _, ok := files[rel]; !ok {
+			return fmt.Errorf("package integrity check failed: %s is missing from the package", rel)
+		}
+	}
+	descs := []ocispec.Descriptor{}
+	for _, rel := range slices.Sorted(maps.Keys(files)) {
+		sha, ok := checksums[rel]
+		if slices.Contains(metadataFiles, rel) {
+			sha, err = helpers.GetSHA256OfFile(files[rel])
+			if err != nil {
+				return err
+			}
+			ok = true
+		}
+		if !ok {
+			return fmt.Errorf("package integrity check failed: %s has no checksum in %s", rel, layout.Checksums)
+		}
+		descs = append(descs, zoci.NewLayerDescriptor(rel, sha, sizes[rel]))
+	}
+	spinner.Success()
+
+	ref, err := zoci.ReferenceFromMetadata(p.cfg.PublishOpts.PackageDestination, &pkg.Metadata, &pkg.Build)
+	if err != nil {
+		return err
+	}
+	remote, err := zoci.NewRemote(ctx, ref, oci.PlatformForArch(pkg.Build.Architecture))
+	if err != nil {
+		return err
+	}
+
+	message.HeaderInfof("📦 PACKAGE PUBLISH %s:%s", pkg.Metadata.Name, ref)
+
+	stream := func(push func(name string, r io.Reader) error) error {
+		_, err := sources.WalkPackageArchive(source, func(name string, _ int64, r io.Reader) error {
+			return push(name, r)
+		})
+		return err
+	}
+	publishOpts, err := p.publishOptions()
+	if err != nil {
+		return err
+	}
+	if err := remote.PublishPackageStream(ctx, &pkg, descs, stream, publishOpts); err != nil {
+		return p.resumeHint(err)
+	}
+	p.cfg.Pkg = pkg
+
+	l.Info("packaged successfully published",
+		"name", pkg.Metadata.Name,
+		"ref", ref,
+		"duration", time.Since(start),
+	)
+	return nil
+}
+
+// publishOptions returns the options for pushing the package layers to the registry.
+func (p *Packager) publishOptions() (zoci.PublishOptions, error) {
+	manifestType, err := zoci.ParseManifestType(p.cfg.PublishOpts.ManifestType)
+	if err != nil {
+		return zoci.PublishOptions{}, err
+	}
+	cachePath, err := config.GetAbsCachePath()
+	if err != nil {
+		return zoci.PublishOptions{}, err
+	}
+	return zoci.PublishOptions{
+		Concurrency:  config.CommonOptions.OCIConcurrency,
+		Retries:      p.cfg.PublishOpts.Retries,
+		RetryDelay:   zoci.PublishRetryDelay,
+		Resume:       p.cfg.PublishOpts.Resume,
+		CachePath:    cachePath,
+		ManifestType: manifestType,
+	}, nil
+}
+
+// resumeHint adds a hint to resume the publish to a publish error.
+func (p *Packager) resumeHint(err error) error {
+	if p.cfg.PublishOpts.Resume {
+		return err
+	}
+	return fmt.Errorf("%w, run the publish again with --resume to continue from the layers that were already pushed", err)
+}
+
+// readChecksums reads a package checksums file into a map of relative paths to their SHA256 checksums.
+func readChecksums(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			continue
+		}
+		sha, rel, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid checksum line in %s: %q", layout.Checksums, scanner.Text())
+		}
+		checksums[rel] = sha
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestModifiesPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		publishOpts types.ZarfPublishOptions
+		expected    bool
+	}{
+		{
+			name: "copied as is",
+		},
+		{
+			name:        "signing key",
+			publishOpts: types.ZarfPublishOptions{SigningKeyPath: "cosign.key"},
+			expected:    true,
+		},
+		{
+			name:        "keyless",
+			publishOpts: types.ZarfPublishOptions{Keyless: true},
+			expected:    true,
+		},
+		{
+			name:        "attestations",
+			publishOpts: types.ZarfPublishOptions{Attestations: []string{AttestationSBOM}},
+			expected:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Packager{cfg: &types.PackagerConfig{PublishOpts: tt.publishOpts}}
+			require.Equal(t, tt.expected, p.modifiesPackage())
+		})
+	}
+}
@@ -6,7 +6,6 @@ package packager
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"runtime"
@@ -14,13 +13,12 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"helm.sh/helm/v3/pkg/storage/driver"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/actions"
@@ -109,24 +107,10 @@ func (p *Packager) Remove(ctx context.Context) error {
 func (p *Packager) updatePackageSecret(ctx context.Context, deployedPackage types.DeployedPackage) error {
 	// Only attempt to update the package secret if we are actually connected to a cluster
 	if p.cluster != nil {
-		newPackageSecretData, err := json.Marshal(deployedPackage)
-		if err != nil {
-			return err
-		}
-
 		secretName := config.ZarfPackagePrefix + deployedPackage.Name
 
 		// Save the new secret with the removed components removed from the secret
-		newPackageSecret := v1ac.Secret(secretName, cluster.ZarfNamespaceName).
-			WithLabels(map[string]string{
-				cluster.ZarfManagedByLabel:   "zarf",
-				cluster.ZarfPackageInfoLabel: deployedPackage.Name,
-			}).WithType(corev1.SecretTypeOpaque).
-			WithData(map[string][]byte{
-				"data": newPackageSecretData,
-			})
-
-		_, err = p.cluster.Clientset.CoreV1().Secrets(*newPackageSecret.Namespace).Apply(ctx, newPackageSecret, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
+		err := p.cluster.UpdateDeployedPackage(ctx, deployedPackage)
 		// We warn and ignore errors because we may have removed the cluster that this package was inside of
 		if err != nil {
 			message.Warnf("Unable to apply the '%s' package secret: '%s' (this may be normal if the cluster was removed)", secretName, err.Error())
@@ -142,14 +126,16 @@ func (p *Packager) removeComponent(ctx context.Context, deployedPackage *types.D
 		return t.Name == deployedComponent.Name
 	})
 
+	variableConfig := template.GetZarfVariableConfig(ctx)
+	variableConfig.SetConstants(deployedPackage.Data.Constants)
 	onRemove := c.Actions.OnRemove
 	onFailure := func() {
-		if err := actions.Run(ctx, onRemove.Defaults, onRemove.OnFailure, nil); err != nil {
+		if err := actions.Run(ctx, onRemove.Defaults, onRemove.OnFailure, variableConfig); err != nil {
 			message.Debugf("Unable to run the failure action: %s", err)
 		}
 	}
 
-	if err := actions.Run(ctx, onRemove.Defaults, onRemove.Before, nil); err != nil {
+	if err := actions.Run(ctx, onRemove.Defaults, onRemove.Before, variableConfig); err != nil {
 		onFailure()
 		return nil, fmt.Errorf("unable to run the before action for component (%s): %w", c.Name, err)
 	}
@@ -181,12 +167,12 @@ func (p *Packager) removeComponent(ctx context.Context, deployedPackage *types.D
 		}
 	}
 
-	if err := actions.Run(ctx, onRemove.Defaults, onRemove.After, nil); err != nil {
+	if err := actions.Run(ctx, onRemove.Defaults, onRemove.After, variableConfig); err != nil {
 		onFailure()
 		return deployedPackage, fmt.Errorf("unable to run the after action: %w", err)
 	}
 
-	if err := actions.Run(ctx, onRemove.Defaults, onRemove.OnSuccess, nil); err != nil {
+	if err := actions.Run(ctx, onRemove.Defaults, onRemove.OnSuccess, variableConfig); err != nil {
 		onFailure()
 		return deployedPackage, fmt.Errorf("unable to run the success action: %w", err)
 	}
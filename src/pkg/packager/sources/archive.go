@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package sources contains core implementations of the PackageSource interface.
+package sources
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mholt/archiver/v3"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ArchiveWalkFunc is called for every file in a package archive with its slash separated path relative to the package root.
+// The reader is only valid until the function returns.
+type ArchiveWalkFunc func(name string, size int64, r io.Reader) error
+
+// WalkPackageArchive streams every file in a package tarball, or a split package tarball set, to fn
+// without extracting the package to disk. The SHA256 checksum of the full archive is returned.
+func WalkPackageArchive(source string, fn ArchiveWalkFunc) (string, error) {
+	archiveName := source
+	var in io.Reader
+	var expectedSum string
+	if strings.HasSuffix(source, ".part000") {
+		archiveName = strings.TrimSuffix(source, ".part000")
+		parts, pkgData, err := openSplitParts(source)
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			for _, part := range parts {
+				part.Close()
+			}
+		}()
+		readers := []io.Reader{}
+		for _, part := range parts {
+			readers = append(readers, part)
+		}
+		in = io.MultiReader(readers...)
+		expectedSum = pkgData.Sha256Sum
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	format, err := archiver.ByExtension(archiveName)
+	if err != nil {
+		return "", err
+	}
+	reader, ok := format.(archiver.Reader)
+	if !ok {
+		return "", fmt.Errorf("format specified by source filename is not a supported archive format: %s (%T)", archiveName, format)
+	}
+
+	hash := sha256.New()
+	tee := io.TeeReader(in, hash)
+	if err := reader.Open(tee, 0); err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	for {
+		f, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if f.IsDir() {
+			continue
+		}
+		header, ok := f.Header.(*tar.Header)
+		if !ok {
+			return "", fmt.Errorf("expected header to be *tar.Header but was %T", f.Header)
+		}
+		if err := fn(path.Clean(filepath.ToSlash(header.Name)), header.Size, f); err != nil {
+			return "", err
+		}
+	}
+
+	// Drain any trailing padding so that the checksum covers the full archive.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return "", err
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if expectedSum != "" && sum != expectedSum {
+		return "", fmt.Errorf("package integrity check failed: expected checksum %s, got %s", expectedSum, sum)
+	}
+	return sum, nil
+}
+
+// openSplitParts opens the data parts of a split package tarball in order and validates the part count against its metadata.
+func openSplitParts(source string) ([]*os.File, types.ZarfSplitPackageData, error) {
+	var pkgData types.ZarfSplitPackageData
+	b, err := os.ReadFile(source)
+	if err != nil {
+		return nil, pkgData, fmt.Errorf("unable to read file %s: %w", source, err)
+	}
+	if err := json.Unmarshal(b, &pkgData); err != nil {
+		return nil, pkgData, fmt.Errorf("unable to unmarshal file %s: %w", source, err)
+	}
+
+	pattern := strings.Replace(source, ".part000", ".part*", 1)
+	fileList, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, pkgData, fmt.Errorf("unable to find split tarball files: %w", err)
+	}
+	sort.Strings(fileList)
+	count := len(fileList) - 1
+	if count != pkgData.Count {
+		return nil, pkgData, fmt.Errorf("package is missing parts, expected %d, found %d", pkgData.Count, count)
+	}
+
+	parts := []*os.File{}
+	for _, file := range fileList[1:] {
+		f, err := os.Open(file)
+		if err != nil {
+			for _, part := range parts {
+				part.Close()
+			}
+			return nil, pkgData, fmt.Errorf("unable to open file %s: %w", file, err)
+		}
+		parts = append(parts, f)
+	}
+	return parts, pkgData, nil
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package sources contains core implementations of the PackageSource interface.
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestWalkPackageArchive(t *testing.T) {
+	t.Parallel()
+
+	tarPath := filepath.Join("testdata", "zarf-package-wordpress-amd64-16.0.4.tar.zst")
+	expectedSum, err := helpers.GetSHA256OfFile(tarPath)
+	require.NoError(t, err)
+	b, err := os.ReadFile(tarPath)
+	require.NoError(t, err)
+
+	walk := func(source string) (map[string]int64, string, error) {
+		files := map[string]int64{}
+		sum, err := WalkPackageArchive(source, func(name string, size int64, r io.Reader) error {
+			n, err := io.Copy(io.Discard, r)
+			if err != nil {
+				return err
+			}
+			require.Equal(t, size, n)
+			files[name] = size
+			return nil
+		})
+		return files, sum, err
+	}
+	expectedFiles := map[string]int64{
+		"checksums.txt": 75,
+		"sboms.tar":     1536,
+		"zarf.yaml":     532,
+	}
+
+	files, sum, err := walk(tarPath)
+	require.NoError(t, err)
+	require.Equal(t, expectedSum, sum)
+	require.Equal(t, expectedFiles, files)
+
+	writeSplit := func(dir, sha string) string {
+		splitPath := filepath.Join(dir, "zarf-package-wordpress-amd64-16.0.4.tar.zst")
+		chunkSize := len(b)/3 + 1
+		count := 0
+		for i := 0; i < len(b); i += chunkSize {
+			count++
+			part := b[i:min(i+chunkSize, len(b))]
+			err := os.WriteFile(fmt.Sprintf("%s.part%03d", splitPath, count), part, 0o600)
+			require.NoError(t, err)
+		}
+		pkgData, err := json.Marshal(types.ZarfSplitPackageData{Sha256Sum: sha, Bytes: int64(len(b)), Count: count})
+		require.NoError(t, err)
+		err = os.WriteFile(splitPath+".part000", pkgData, 0o600)
+		require.NoError(t, err)
+		return splitPath + ".part000"
+	}
+
+	files, sum, err = walk(writeSplit(t.TempDir(), expectedSum))
+	require.NoError(t, err)
+	require.Equal(t, expectedSum, sum)
+	require.Equal(t, expectedFiles, files)
+
+	_, _, err = walk(writeSplit(t.TempDir(), "bad"))
+	require.EqualError(t, err, fmt.Sprintf("package integrity check failed: expected checksum bad, got %s", expectedSum))
+
+	missingPart := writeSplit(t.TempDir(), expectedSum)
+	err = os.Remove(filepath.Join(filepath.Dir(missingPart), "zarf-package-wordpress-amd64-16.0.4.tar.zst.part002"))
+	require.NoError(t, err)
+	_, _, err = walk(missingPart)
+	require.EqualError(t, err, "package is missing parts, expected 3, found 2")
+}
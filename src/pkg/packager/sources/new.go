@@ -16,6 +16,7 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -77,7 +78,7 @@ func New(ctx context.Context, pkgOpts *types.ZarfPackageOptions) (PackageSource,
 		source = &OCISource{ZarfPackageOptions: pkgOpts, Remote: remote}
 	case "tarball":
 		source = &TarballSource{pkgOpts}
-	case "http", "https", "sget":
+	case "http", "https", "sget", utils.S3URLScheme:
 		source = &URLSource{pkgOpts}
 	case "split":
 		source = &SplitTarballSource{pkgOpts}
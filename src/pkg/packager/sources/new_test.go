@@ -62,6 +62,12 @@ func TestNewPackageSource(t *testing.T) {
 			expectedIdentify: "http",
 			expectedType:     &URLSource{},
 		},
+		{
+			name:             "s3",
+			src:              "s3://zarf-packages/releases/zarf-init-amd64-v1.0.0.tar.zst",
+			expectedIdentify: "s3",
+			expectedType:     &URLSource{},
+		},
 		{
 			name:             "local tar init zst",
 			src:              "zarf-init-amd64-v1.0.0.tar.zst",
@@ -37,6 +37,12 @@ type OCISource struct {
 
 // LoadPackage loads a package from an OCI registry.
 func (s *OCISource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy, unarchiveAll bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	if VerifiesAttestations(*s.ZarfPackageOptions) {
+		if _, err := ValidatePackageAttestations(ctx, s.Remote, s.AttestationKeyPath, CertificateIdentity(*s.ZarfPackageOptions), s.KeylessAttestations); err != nil {
+			return pkg, nil, err
+		}
+	}
+
 	pkg, err = s.FetchZarfYAML(ctx)
 	if err != nil {
 		return pkg, nil, err
@@ -77,13 +83,17 @@ func (s *OCISource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, f
 		if err := ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, isPartial); err != nil {
 			return pkg, nil, err
 		}
+		// Only the requested components are pulled into a partial package, so each of them must be complete.
+		if isPartial {
+			if err := ValidateComponentChecksums(dst, pkg); err != nil {
+				return pkg, nil, err
+			}
+		}
 
 		spinner.Success()
 
-		if !s.SkipSignatureValidation {
-			if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath); err != nil {
-				return pkg, nil, err
-			}
+		if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath, CertificateIdentity(*s.ZarfPackageOptions), s.VerificationPolicy, s.PackageSource, s.SkipSignatureValidation); err != nil {
+			return pkg, nil, err
 		}
 	}
 
@@ -144,14 +154,12 @@ func (s *OCISource) LoadPackageMetadata(ctx context.Context, dst *layout.Package
 			spinner.Success()
 		}
 
-		if !s.SkipSignatureValidation {
-			if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath); err != nil {
-				if errors.Is(err, ErrPkgSigButNoKey) && skipValidation {
-					message.Warn("The package was signed but no public key was provided, skipping signature validation")
-					logger.From(ctx).Warn("the package was signed but no public key was provided, skipping signature validation")
-				} else {
-					return pkg, nil, err
-				}
+		if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath, CertificateIdentity(*s.ZarfPackageOptions), s.VerificationPolicy, s.PackageSource, s.SkipSignatureValidation); err != nil {
+			if (errors.Is(err, ErrPkgSigButNoKey) || errors.Is(err, ErrPkgKeylessButNoIdentity)) && skipValidation {
+				message.Warn("The package was signed but no public key was provided, skipping signature validation")
+				logger.From(ctx).Warn("the package was signed but no public key was provided, skipping signature validation")
+			} else {
+				return pkg, nil, err
 			}
 		}
 	}
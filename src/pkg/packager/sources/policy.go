@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package sources contains core implementations of the PackageSource interface.
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sigstore/cosign/v2/pkg/cosign/pkcs11key"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// LoadVerificationPolicy reads the verification policy at path. Keys that are file paths are read relative to the policy
+// and stored PEM encoded, so that the policy can be stored in the cluster.
+func LoadVerificationPolicy(policyPath string) (types.VerificationPolicy, error) {
+	policy := types.VerificationPolicy{}
+	if err := utils.ReadYaml(policyPath, &policy); err != nil {
+		return types.VerificationPolicy{}, fmt.Errorf("unable to read verification policy %s: %w", policyPath, err)
+	}
+	for i, rule := range policy.Rules {
+		if len(rule.Packages) == 0 && len(rule.Repositories) == 0 {
+			return types.VerificationPolicy{}, fmt.Errorf("verification policy rule %d must match at least one package or repository", i)
+		}
+		if len(rule.Keys) == 0 {
+			return types.VerificationPolicy{}, fmt.Errorf("verification policy rule %d must have at least one key", i)
+		}
+		for _, pattern := range append(rule.Packages, rule.Repositories...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return types.VerificationPolicy{}, fmt.Errorf("verification policy rule %d has invalid pattern %q: %w", i, pattern, err)
+			}
+		}
+		for j, key := range rule.Keys {
+			if isPEM(key) || isKeyProvider(key) {
+				continue
+			}
+			keyPath := key
+			if !filepath.IsAbs(keyPath) {
+				keyPath = filepath.Join(filepath.Dir(policyPath), keyPath)
+			}
+			b, err := os.ReadFile(keyPath)
+			if err != nil {
+				return types.VerificationPolicy{}, fmt.Errorf("unable to read key of verification policy rule %d: %w", i, err)
+			}
+			policy.Rules[i].Keys[j] = string(b)
+		}
+	}
+	for i, certChain := range policy.TimestampAuthorities {
+		if isPEM(certChain) {
+			continue
+		}
+		certChainPath := certChain
+		if !filepath.IsAbs(certChainPath) {
+			certChainPath = filepath.Join(filepath.Dir(policyPath), certChainPath)
+		}
+		b, err := os.ReadFile(certChainPath)
+		if err != nil {
+			return types.VerificationPolicy{}, fmt.Errorf("unable to read timestamp authority %d of the verification policy: %w", i, err)
+		}
+		policy.TimestampAuthorities[i] = string(b)
+	}
+	return policy, nil
+}
+
+// MergeVerificationPolicies returns a policy with the rules of all of the policies.
+func MergeVerificationPolicies(policies ...types.VerificationPolicy) types.VerificationPolicy {
+	merged := types.VerificationPolicy{}
+	for _, policy := range policies {
+		merged.Rules = append(merged.Rules, policy.Rules...)
+		merged.TimestampAuthorities = append(merged.TimestampAuthorities, policy.TimestampAuthorities...)
+	}
+	return merged
+}
+
+// TrustedRules returns the rules that match the package name or the repository of the package source.
+func TrustedRules(policy types.VerificationPolicy, pkgName, source string) []types.VerificationRule {
+	repository := ""
+	if helpers.IsOCIURL(source) {
+		ref, err := name.ParseReference(strings.TrimPrefix(source, helpers.OCIURLPrefix))
+		if err == nil {
+			repository = ref.Context().Name()
+		}
+	}
+	rules := []types.VerificationRule{}
+	for _, rule := range policy.Rules {
+		if !matchesAny(rule.Packages, pkgName) && !matchesAny(rule.Repositories, repository) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// VerifyWithTrustedKeys calls verify with each of the keys of the rules until one succeeds. Keys of rules past their
+// notAfter time are only used when signedAt, the verified timestamp of the signature, is before it. PEM encoded keys are
+// written to a temporary file as verify expects a key reference.
+func VerifyWithTrustedKeys(ctx context.Context, pkgName string, rules []types.VerificationRule, signedAt *time.Time, verify func(keyRef string) error) error {
+	tmpDir, err := utils.MakeTempDir("")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	signingTime := time.Now()
+	if signedAt != nil {
+		signingTime = *signedAt
+	}
+	keys := []string{}
+	errs := []error{}
+	for i, rule := range rules {
+		if rule.NotAfter != nil && signingTime.After(*rule.NotAfter) {
+			errs = append(errs, fmt.Errorf("keys of rule %d are not trusted after %s", i, rule.NotAfter.Format(time.RFC3339)))
+			continue
+		}
+		keys = append(keys, rule.Keys...)
+	}
+	for i, key := range keys {
+		keyRef := key
+		if isPEM(key) {
+			keyRef = filepath.Join(tmpDir, fmt.Sprintf("key-%d.pub", i))
+			if err := os.WriteFile(keyRef, []byte(key), helpers.ReadWriteUser); err != nil {
+				return err
+			}
+		}
+		err := verify(keyRef)
+		if err == nil {
+			// TODO(mkcp): Remove message on logger release
+			message.Debugf("Package %s verified with key %d of the verification policy", pkgName, i)
+			logger.From(ctx).Debug("package verified with the verification policy", "name", pkgName, "key", i)
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("package %s is not signed by a trusted publisher of the verification policy: %w", pkgName, errors.Join(errs...))
+}
+
+// VerifySignatureTimestamps verifies the timestamps of the signatures with the timestamp authorities of the policy.
+// timestamps maps the path of each timestamp to the path of the signature it covers. The latest verified time is
+// returned, or nil when the package has no timestamps or the policy trusts no timestamp authorities.
+func VerifySignatureTimestamps(ctx context.Context, policy types.VerificationPolicy, timestamps map[string]string) (*time.Time, error) {
+	if len(timestamps) == 0 {
+		return nil, nil
+	}
+	if len(policy.TimestampAuthorities) == 0 {
+		// TODO(mkcp): Remove message on logger release
+		message.Warn("The package signatures are timestamped but no timestamp authorities are trusted, the timestamps are not verified")
+		logger.From(ctx).Warn("the package signatures are timestamped but no timestamp authorities are trusted, the timestamps are not verified")
+		return nil, nil
+	}
+	var signedAt *time.Time
+	for timestampPath, sigPath := range timestamps {
+		if helpers.InvalidPath(sigPath) {
+			return nil, fmt.Errorf("package contains %s but not %s", filepath.Base(timestampPath), filepath.Base(sigPath))
+		}
+		ts, err := utils.VerifySignatureTimestamp(sigPath, timestampPath, policy.TimestampAuthorities)
+		if err != nil {
+			return nil, err
+		}
+		// TODO(mkcp): Remove message on logger release
+		message.Debugf("Signature %s was timestamped at %s by %s", filepath.Base(sigPath), ts.Time, ts.Authority)
+		logger.From(ctx).Debug("verified signature timestamp", "signature", filepath.Base(sigPath), "time", ts.Time, "authority", ts.Authority)
+		if signedAt == nil || ts.Time.After(*signedAt) {
+			signedAt = &ts.Time
+		}
+	}
+	return signedAt, nil
+}
+
+// isPEM returns true if the key is PEM encoded.
+func isPEM(key string) bool {
+	return strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN")
+}
+
+// isKeyProvider returns true if the key is a Cosign-supported key provider reference rather than a path.
+func isKeyProvider(key string) bool {
+	return strings.Contains(key, "://") || strings.HasPrefix(key, pkcs11key.ReferenceScheme)
+}
+
+// matchesAny returns true if the value matches any of the glob patterns.
+func matchesAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, value); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
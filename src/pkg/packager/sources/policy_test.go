@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package sources
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestLoadVerificationPolicy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "cosign.pub"), []byte("-----BEGIN PUBLIC KEY-----\n"), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "tsa.pem"), []byte("-----BEGIN CERTIFICATE-----\n"), 0o600)
+	require.NoError(t, err)
+	policyPath := filepath.Join(dir, "policy.yaml")
+	err = os.WriteFile(policyPath, []byte(`rules:
+  - packages: ["podinfo*"]
+    repositories: ["ghcr.io/my-org/*"]
+    keys: ["cosign.pub", "awskms:///alias/zarf"]
+    notAfter: 2025-01-01T00:00:00Z
+timestampAuthorities: ["tsa.pem"]
+`), 0o600)
+	require.NoError(t, err)
+	policy, err := LoadVerificationPolicy(policyPath)
+	require.NoError(t, err)
+	notAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := types.VerificationPolicy{
+		Rules: []types.VerificationRule{
+			{
+				Packages:     []string{"podinfo*"},
+				Repositories: []string{"ghcr.io/my-org/*"},
+				Keys:         []string{"-----BEGIN PUBLIC KEY-----\n", "awskms:///alias/zarf"},
+				NotAfter:     &notAfter,
+			},
+		},
+		TimestampAuthorities: []string{"-----BEGIN CERTIFICATE-----\n"},
+	}
+	require.Equal(t, expected, policy)
+
+	err = os.WriteFile(policyPath, []byte("rules:\n  - keys: [\"cosign.pub\"]\n"), 0o600)
+	require.NoError(t, err)
+	_, err = LoadVerificationPolicy(policyPath)
+	require.EqualError(t, err, "verification policy rule 0 must match at least one package or repository")
+
+	err = os.WriteFile(policyPath, []byte("rules:\n  - packages: [\"podinfo\"]\n"), 0o600)
+	require.NoError(t, err)
+	_, err = LoadVerificationPolicy(policyPath)
+	require.EqualError(t, err, "verification policy rule 0 must have at least one key")
+}
+
+func TestTrustedRules(t *testing.T) {
+	t.Parallel()
+
+	policy := MergeVerificationPolicies(
+		types.VerificationPolicy{Rules: []types.VerificationRule{{Packages: []string{"podinfo*"}, Keys: []string{"a"}}}},
+		types.VerificationPolicy{Rules: []types.VerificationRule{{Repositories: []string{"ghcr.io/my-org/*"}, Keys: []string{"b"}}}},
+	)
+
+	rules := TrustedRules(policy, "podinfo-flux", "zarf-package-podinfo-flux-amd64.tar.zst")
+	require.Equal(t, policy.Rules[:1], rules)
+
+	rules = TrustedRules(policy, "podinfo", "oci://ghcr.io/my-org/podinfo:1.0.0")
+	require.Equal(t, policy.Rules, rules)
+
+	rules = TrustedRules(policy, "dos-games", "oci://ghcr.io/my-org/dos-games@sha256:3b9eb3f4d9f0e0ea5ac1b53e2c57f2ad0d0e0ef1ba4c4b0e3da6b8b4e3c2d1f0")
+	require.Equal(t, policy.Rules[1:], rules)
+
+	require.Empty(t, TrustedRules(policy, "dos-games", "oci://ghcr.io/other-org/dos-games:1.0.0"))
+	require.Empty(t, TrustedRules(policy, "dos-games", "ghcr.io/my-org/dos-games.tar.zst"))
+}
+
+func TestVerifyWithTrustedKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	pem := "-----BEGIN PUBLIC KEY-----\n"
+	keyRefs := []string{}
+	verify := func(keyRef string) error {
+		keyRefs = append(keyRefs, keyRef)
+		if keyRef == "awskms:///alias/zarf" {
+			return nil
+		}
+		return errors.New("invalid signature")
+	}
+	rules := []types.VerificationRule{{Keys: []string{pem, "awskms:///alias/zarf"}}}
+	err := VerifyWithTrustedKeys(ctx, "podinfo", rules, nil, verify)
+	require.NoError(t, err)
+	require.Len(t, keyRefs, 2)
+	require.Equal(t, "key-0.pub", filepath.Base(keyRefs[0]))
+	require.Equal(t, "awskms:///alias/zarf", keyRefs[1])
+
+	err = VerifyWithTrustedKeys(ctx, "podinfo", []types.VerificationRule{{Keys: []string{pem}}}, nil, verify)
+	require.EqualError(t, err, "package podinfo is not signed by a trusted publisher of the verification policy: invalid signature")
+
+	// Keys of expired rules are only trusted for signatures timestamped before the rule expired.
+	notAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expired := []types.VerificationRule{{Keys: []string{"awskms:///alias/zarf"}, NotAfter: &notAfter}}
+	err = VerifyWithTrustedKeys(ctx, "podinfo", expired, nil, verify)
+	require.EqualError(t, err, "package podinfo is not signed by a trusted publisher of the verification policy: keys of rule 0 are not trusted after 2024-01-01T00:00:00Z")
+	signedAt := notAfter.Add(-time.Hour)
+	err = VerifyWithTrustedKeys(ctx, "podinfo", expired, &signedAt, verify)
+	require.NoError(t, err)
+}
@@ -114,10 +114,8 @@ func (s *TarballSource) LoadPackage(ctx context.Context, dst *layout.PackagePath
 		spinner.Success()
 		l.Debug("done validating package checksums", "source", s.PackageSource)
 
-		if !s.SkipSignatureValidation {
-			if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath); err != nil {
-				return pkg, nil, err
-			}
+		if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath, CertificateIdentity(*s.ZarfPackageOptions), s.VerificationPolicy, s.PackageSource, s.SkipSignatureValidation); err != nil {
+			return pkg, nil, err
 		}
 	}
 
@@ -195,14 +193,12 @@ func (s *TarballSource) LoadPackageMetadata(ctx context.Context, dst *layout.Pac
 			spinner.Success()
 		}
 
-		if !s.SkipSignatureValidation {
-			if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath); err != nil {
-				if errors.Is(err, ErrPkgSigButNoKey) && skipValidation {
-					message.Warn("The package was signed but no public key was provided, skipping signature validation")
-					logger.From(ctx).Warn("the package was signed but no public key was provided, skipping signature validation")
-				} else {
-					return pkg, nil, err
-				}
+		if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath, CertificateIdentity(*s.ZarfPackageOptions), s.VerificationPolicy, s.PackageSource, s.SkipSignatureValidation); err != nil {
+			if (errors.Is(err, ErrPkgSigButNoKey) || errors.Is(err, ErrPkgKeylessButNoIdentity)) && skipValidation {
+				message.Warn("The package was signed but no public key was provided, skipping signature validation")
+				logger.From(ctx).Warn("the package was signed but no public key was provided, skipping signature validation")
+			} else {
+				return pkg, nil, err
 			}
 		}
 	}
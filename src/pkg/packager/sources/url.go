@@ -25,14 +25,15 @@ var (
 	_ PackageSource = (*URLSource)(nil)
 )
 
-// URLSource is a package source for http, https and sget URLs.
+// URLSource is a package source for http, https, sget and s3 URLs.
+// Packages in S3 compatible object storage are authenticated with the standard AWS credential chain and do not require a shasum.
 type URLSource struct {
 	*types.ZarfPackageOptions
 }
 
 // Collect downloads a package from the source URL.
 func (s *URLSource) Collect(ctx context.Context, dir string) (string, error) {
-	if s.Shasum == "" && !strings.HasPrefix(s.PackageSource, helpers.SGETURLPrefix) {
+	if s.Shasum == "" && !strings.HasPrefix(s.PackageSource, helpers.SGETURLPrefix) && Identify(s.PackageSource) != utils.S3URLScheme {
 		return "", fmt.Errorf("remote package provided without a shasum, please provide one with --shasum")
 	}
 	var packageURL string
@@ -51,7 +52,7 @@ func (s *URLSource) Collect(ctx context.Context, dir string) (string, error) {
 	return RenameFromMetadata(dstTarball)
 }
 
-// LoadPackage loads a package from an http, https or sget URL.
+// LoadPackage loads a package from an http, https, sget or s3 URL.
 func (s *URLSource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy, unarchiveAll bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
 	tmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
@@ -75,7 +76,7 @@ func (s *URLSource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, f
 	return ts.LoadPackage(ctx, dst, filter, unarchiveAll)
 }
 
-// LoadPackageMetadata loads a package's metadata from an http, https or sget URL.
+// LoadPackageMetadata loads a package's metadata from an http, https, sget or s3 URL.
 func (s *URLSource) LoadPackageMetadata(ctx context.Context, dst *layout.PackagePaths, wantSBOM bool, skipValidation bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
 	tmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
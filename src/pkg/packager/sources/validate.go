@@ -6,19 +6,29 @@ package sources
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/sigstore/cosign/v2/pkg/signature"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 var (
@@ -26,10 +36,159 @@ var (
 	ErrPkgKeyButNoSig = errors.New("a key was provided but the package is not signed - the package may be corrupted or the --key flag was erroneously specified")
 	// ErrPkgSigButNoKey is returned when a package is signed but no key was provided
 	ErrPkgSigButNoKey = errors.New("package is signed but no key was provided - add a key with the --key flag or use the --skip-signature-validation flag and run the command again")
+	// ErrPkgPolicyButNoSig is returned when the verification policy requires a package to be signed but it is not
+	ErrPkgPolicyButNoSig = errors.New("the verification policy requires the package to be signed by a trusted publisher but the package is not signed")
+	// ErrPkgPolicyButSkipped is returned when signature validation is skipped for a package the verification policy requires to be signed
+	ErrPkgPolicyButSkipped = errors.New("the verification policy requires the package to be signed by a trusted publisher, signature validation can not be skipped")
+	// ErrPkgKeylessButNoIdentity is returned when a package is signed keyless but no certificate identity was provided
+	ErrPkgKeylessButNoIdentity = errors.New("package is signed keyless but no certificate identity was provided - add the identity with the --certificate-identity and --certificate-oidc-issuer flags or use the --skip-signature-validation flag and run the command again")
+	// ErrPkgIdentityButNotKeyless is returned when a certificate identity was provided but the package is not signed keyless
+	ErrPkgIdentityButNotKeyless = errors.New("a certificate identity was provided but the package is not signed keyless")
 )
 
-// ValidatePackageSignature validates the signature of a package
-func ValidatePackageSignature(ctx context.Context, paths *layout.PackagePaths, publicKeyPath string) error {
+// ValidatePackageSignature validates the signature of a package. When the verification policy trusts publishers for
+// the package name or source, the package must be signed by one of their keys regardless of the public key provided
+// and signature validation can not be skipped. Signature timestamps are verified with the timestamp authorities of the
+// verification policy. Packages signed keyless are verified against the certificate identity instead.
+func ValidatePackageSignature(ctx context.Context, paths *layout.PackagePaths, publicKeyPath string, identity utils.CertificateIdentity, policy types.VerificationPolicy, source string, skipSignatureValidation bool) error {
+	rules := []types.VerificationRule{}
+	pkgName := ""
+	if len(policy.Rules) > 0 {
+		var pkg v1alpha1.ZarfPackage
+		if err := utils.ReadYaml(paths.ZarfYAML, &pkg); err != nil {
+			return err
+		}
+		pkgName = pkg.Metadata.Name
+		rules = TrustedRules(policy, pkgName, source)
+	}
+	if len(rules) == 0 && skipSignatureValidation {
+		return nil
+	}
+	if len(rules) > 0 && skipSignatureValidation {
+		return ErrPkgPolicyButSkipped
+	}
+	if len(rules) == 0 && (paths.SignatureBundle != "" || !identity.IsEmpty()) {
+		return ValidateKeylessSignature(ctx, paths, identity)
+	}
+
+	timestamps := map[string]string{}
+	if paths.SignatureTimestamp != "" {
+		timestamps[paths.SignatureTimestamp] = paths.Signature
+	}
+	if paths.ChecksumsSignatureTimestamp != "" {
+		timestamps[paths.ChecksumsSignatureTimestamp] = paths.ChecksumsSignature
+	}
+	signedAt, err := VerifySignatureTimestamps(ctx, policy, timestamps)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
+		if paths.Signature == "" {
+			return ErrPkgPolicyButNoSig
+		}
+		if publicKeyPath != "" {
+			logger.From(ctx).Debug("verifying the package with the keys of the verification policy instead of the provided key", "name", pkgName)
+		}
+		return VerifyWithTrustedKeys(ctx, pkgName, rules, signedAt, func(keyRef string) error {
+			return validateSignatureWithKey(ctx, paths, keyRef)
+		})
+	}
+	return validateSignatureWithKey(ctx, paths, publicKeyPath)
+}
+
+// CertificateIdentity returns the identity keyless package signatures must be issued to.
+func CertificateIdentity(opts types.ZarfPackageOptions) utils.CertificateIdentity {
+	return utils.CertificateIdentity{Identity: opts.CertificateIdentity, OIDCIssuer: opts.CertificateOIDCIssuer}
+}
+
+// ValidateKeylessSignature validates the keyless signatures of a package with the bundles of their certificates and
+// transparency log entries. The certificates must be issued to the identity.
+func ValidateKeylessSignature(ctx context.Context, paths *layout.PackagePaths, identity utils.CertificateIdentity) error {
+	if paths.SignatureBundle == "" {
+		return ErrPkgIdentityButNotKeyless
+	}
+	if identity.IsEmpty() {
+		return ErrPkgKeylessButNoIdentity
+	}
+	if paths.Signature == "" {
+		return fmt.Errorf("package contains %s but not %s", layout.SignatureBundle, layout.Signature)
+	}
+
+	// The checksums signature covers all of the package content, packages created before it existed only sign the zarf.yaml.
+	if paths.ChecksumsSignature != "" {
+		if paths.ChecksumsSignatureBundle == "" {
+			return fmt.Errorf("package contains %s but not %s", layout.ChecksumsSignature, layout.ChecksumsSignatureBundle)
+		}
+		if err := utils.CosignVerifyBlobKeyless(ctx, paths.Checksums, paths.ChecksumsSignature, paths.ChecksumsSignatureBundle, identity); err != nil {
+			return fmt.Errorf("package checksums signature did not match the certificate identity: %w", err)
+		}
+	} else {
+		message.Warnf("The package does not contain a signature of %s, only the %s is verified against the certificate identity", layout.Checksums, layout.ZarfYAML)
+		logger.From(ctx).Warn("package does not contain a checksums signature, only the zarf.yaml is verified against the certificate identity")
+	}
+
+	if err := utils.CosignVerifyBlobKeyless(ctx, paths.ZarfYAML, paths.Signature, paths.SignatureBundle, identity); err != nil {
+		return fmt.Errorf("package signature did not match the certificate identity: %w", err)
+	}
+	message.Successf("Package signature validated!")
+	logger.From(ctx).Debug("keyless package signature validated", "identity", identity.Identity, "issuer", identity.OIDCIssuer)
+	return nil
+}
+
+var (
+	// ErrAttestationsNotOCI is returned when attestations are verified for a package that is not in an OCI registry.
+	ErrAttestationsNotOCI = errors.New("attestations are attached to packages published to an OCI registry, the --attestation-key and --keyless-attestations flags can only be used with oci:// packages")
+	// ErrAttestationsKeyAndKeyless is returned when attestations are verified both with a key and keyless.
+	ErrAttestationsKeyAndKeyless = errors.New("--keyless-attestations cannot be used with --attestation-key")
+	// ErrAttestationsKeylessButNoIdentity is returned when attestations are verified keyless but no certificate identity was provided
+	ErrAttestationsKeylessButNoIdentity = errors.New("keyless attestations are verified against the certificate identity - add the identity with the --certificate-identity and --certificate-oidc-issuer flags")
+)
+
+// VerifiesAttestations returns true when the attestations of the package are verified, either with a key or keyless.
+func VerifiesAttestations(opts types.ZarfPackageOptions) bool {
+	return opts.AttestationKeyPath != "" || opts.KeylessAttestations
+}
+
+// ValidatePackageAttestations verifies that the attestations attached to a package in a registry are signed with the key,
+// or keyless by the certificate identity when keyless is set, and are about the package. The package must have at
+// least one attestation.
+func ValidatePackageAttestations(ctx context.Context, remote *zoci.Remote, keyPath string, identity utils.CertificateIdentity, keyless bool) ([]zoci.Attestation, error) {
+	if keyless {
+		if keyPath != "" {
+			return nil, ErrAttestationsKeyAndKeyless
+		}
+		if identity.IsEmpty() {
+			return nil, ErrAttestationsKeylessButNoIdentity
+		}
+		attestations, err := remote.VerifyKeylessAttestations(ctx, identity)
+		if err != nil {
+			return nil, fmt.Errorf("package attestations could not be verified: %w", err)
+		}
+		message.Successf("Verified %d package attestations", len(attestations))
+		logger.From(ctx).Info("package attestations verified", "count", len(attestations), "identity", identity.Identity, "issuer", identity.OIDCIssuer)
+		return attestations, nil
+	}
+
+	if err := utils.CheckCosignKeyRef(keyPath); err != nil {
+		return nil, err
+	}
+	verifier, err := signature.PublicKeyFromKeyRef(ctx, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the attestation key: %w", err)
+	}
+	attestations, err := remote.VerifyAttestations(ctx, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("package attestations could not be verified: %w", err)
+	}
+	message.Successf("Verified %d package attestations", len(attestations))
+	logger.From(ctx).Info("package attestations verified", "count", len(attestations), "key", keyPath)
+	return attestations, nil
+}
+
+// validateSignatureWithKey validates the signatures of a package with the public key.
+func validateSignatureWithKey(ctx context.Context, paths *layout.PackagePaths, publicKeyPath string) error {
+
 	if publicKeyPath != "" {
 		message.Debugf("Using public key %q for signature validation", publicKeyPath)
 		logger.From(ctx).Debug("using public key for signature validation", "key", publicKeyPath)
@@ -37,6 +196,9 @@ func ValidatePackageSignature(ctx context.Context, paths *layout.PackagePaths, p
 
 	// Handle situations where there is no signature within the package
 	sigExist := paths.Signature != ""
+	if paths.ChecksumsSignature != "" && !sigExist {
+		return fmt.Errorf("package contains %s but not %s", layout.ChecksumsSignature, layout.Signature)
+	}
 	if !sigExist && publicKeyPath == "" {
 		// Nobody was expecting a signature, so we can just return
 		return nil
@@ -48,6 +210,16 @@ func ValidatePackageSignature(ctx context.Context, paths *layout.PackagePaths, p
 		return ErrPkgKeyButNoSig
 	}
 
+	// The checksums signature covers all of the package content, packages created before it existed only sign the zarf.yaml.
+	if paths.ChecksumsSignature != "" {
+		if err := utils.CosignVerifyBlob(ctx, paths.Checksums, paths.ChecksumsSignature, publicKeyPath); err != nil {
+			return fmt.Errorf("package checksums signature did not match the provided key: %w", err)
+		}
+	} else {
+		message.Warnf("The package does not contain a signature of %s, only the %s is verified against the provided key", layout.Checksums, layout.ZarfYAML)
+		logger.From(ctx).Warn("package does not contain a checksums signature, only the zarf.yaml is verified against the provided key")
+	}
+
 	// Validate the signature with the key we were provided
 	if err := utils.CosignVerifyBlob(ctx, paths.ZarfYAML, paths.Signature, publicKeyPath); err != nil {
 		return fmt.Errorf("package signature did not match the provided key: %w", err)
@@ -79,6 +251,11 @@ func ValidatePackageIntegrity(loaded *layout.PackagePaths, aggregateChecksum str
 	checkedMap[loaded.ZarfYAML] = true
 	checkedMap[loaded.Checksums] = true
 	checkedMap[loaded.Signature] = true
+	checkedMap[loaded.ChecksumsSignature] = true
+	checkedMap[loaded.SignatureTimestamp] = true
+	checkedMap[loaded.ChecksumsSignatureTimestamp] = true
+	checkedMap[loaded.SignatureBundle] = true
+	checkedMap[loaded.ChecksumsSignatureBundle] = true
 
 	err = lineByLine(checksumPath, func(line string) error {
 		// If the line is empty (i.e. there is no checksum) simply skip it - this can result from a package with no images/components
@@ -179,3 +356,89 @@ func lineByLine(path string, cb func(line string) error) error {
 	}
 	return nil
 }
+
+// ValidateComponentChecksums verifies that the tarball and image blobs of each component of a partially loaded package
+// are present and match the component checksum recorded when the package was created. Components of packages created
+// before component checksums were recorded are skipped.
+func ValidateComponentChecksums(loaded *layout.PackagePaths, pkg v1alpha1.ZarfPackage) error {
+	checksums := map[string]string{}
+	err := lineByLine(loaded.Checksums, func(line string) error {
+		if line == "" {
+			return nil
+		}
+		split := strings.Split(line, " ")
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return fmt.Errorf("invalid checksum line: %s", line)
+		}
+		checksums[split[1]] = split[0]
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, component := range pkg.Components {
+		idx := slices.IndexFunc(pkg.Build.Components, func(data v1alpha1.ZarfComponentBuildData) bool {
+			return data.Name == component.Name
+		})
+		if idx == -1 || pkg.Build.Components[idx].Checksum == "" {
+			continue
+		}
+		data := pkg.Build.Components[idx]
+		paths, err := componentPaths(loaded.Base, checksums, data)
+		if err != nil {
+			return err
+		}
+		lines := []string{}
+		for _, rel := range paths {
+			sha, ok := checksums[rel]
+			if !ok {
+				return fmt.Errorf("component %s: %s is not present in the checksums", data.Name, rel)
+			}
+			if helpers.InvalidPath(filepath.Join(loaded.Base, filepath.FromSlash(rel))) {
+				return fmt.Errorf("unable to validate partial checksums - missing file: %s", rel)
+			}
+			lines = append(lines, fmt.Sprintf("%s %s", sha, rel))
+		}
+		slices.Sort(lines)
+		lines = slices.Compact(lines)
+		sum := sha256.Sum256([]byte(strings.Join(lines, "\n") + "\n"))
+		if hex.EncodeToString(sum[:]) != data.Checksum {
+			return fmt.Errorf("component %s does not match its checksum, expected %s but got %s", data.Name, data.Checksum, hex.EncodeToString(sum[:]))
+		}
+	}
+	return nil
+}
+
+// componentPaths returns the slash separated package paths of the tarball and image blobs of the component. The blobs
+// are found through the image manifests, so they must be present for every image of the component.
+func componentPaths(base string, checksums map[string]string, data v1alpha1.ZarfComponentBuildData) ([]string, error) {
+	paths := []string{}
+	tarPath := path.Join(layout.ComponentsDir, fmt.Sprintf("%s.tar", data.Name))
+	if _, ok := checksums[tarPath]; ok {
+		paths = append(paths, tarPath)
+	}
+	for _, image := range data.Images {
+		digest, err := v1.NewHash(image.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest of image %s: %w", image.Name, err)
+		}
+		manifestPath := path.Join(layout.ImagesDir, "blobs", digest.Algorithm, digest.Hex)
+		b, err := os.ReadFile(filepath.Join(base, filepath.FromSlash(manifestPath)))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("image %s of component %s is missing from the package", image.Name, data.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := v1.ParseManifest(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the manifest of image %s: %w", image.Name, err)
+		}
+		paths = append(paths, manifestPath)
+		for _, desc := range append([]v1.Descriptor{manifest.Config}, manifest.Layers...) {
+			paths = append(paths, path.Join(layout.ImagesDir, "blobs", desc.Digest.Algorithm, desc.Digest.Hex))
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package sources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	ocilayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestValidateKeylessSignature(t *testing.T) {
+	t.Parallel()
+
+	identity := utils.CertificateIdentity{Identity: "https://github.com/my-org/my-repo/.github/workflows/release.yaml@refs/heads/main", OIDCIssuer: "https://token.actions.githubusercontent.com"}
+	tests := []struct {
+		name        string
+		paths       *layout.PackagePaths
+		identity    utils.CertificateIdentity
+		expectedErr error
+		errContains string
+	}{
+		{
+			name:        "identity but not keyless",
+			paths:       &layout.PackagePaths{Signature: "zarf.yaml.sig"},
+			identity:    identity,
+			expectedErr: ErrPkgIdentityButNotKeyless,
+		},
+		{
+			name:        "keyless but no identity",
+			paths:       &layout.PackagePaths{Signature: "zarf.yaml.sig", SignatureBundle: "zarf.yaml.sig.bundle"},
+			expectedErr: ErrPkgKeylessButNoIdentity,
+		},
+		{
+			name:        "keyless but no issuer",
+			paths:       &layout.PackagePaths{Signature: "zarf.yaml.sig", SignatureBundle: "zarf.yaml.sig.bundle"},
+			identity:    utils.CertificateIdentity{Identity: identity.Identity},
+			errContains: "both a certificate identity and a certificate OIDC issuer are required",
+		},
+		{
+			name:        "bundle but no signature",
+			paths:       &layout.PackagePaths{SignatureBundle: "zarf.yaml.sig.bundle"},
+			identity:    identity,
+			errContains: "package contains zarf.yaml.sig.bundle but not zarf.yaml.sig",
+		},
+		{
+			name:        "checksums signature but no bundle",
+			paths:       &layout.PackagePaths{Signature: "zarf.yaml.sig", SignatureBundle: "zarf.yaml.sig.bundle", ChecksumsSignature: "checksums.txt.sig"},
+			identity:    identity,
+			errContains: "package contains checksums.txt.sig but not checksums.txt.sig.bundle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateKeylessSignature(testutil.TestContext(t), tt.paths, tt.identity)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func TestValidatePackageAttestationsKeyless(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	identity := utils.CertificateIdentity{Identity: "https://github.com/my-org/my-repo/.github/workflows/release.yaml@refs/heads/main", OIDCIssuer: "https://token.actions.githubusercontent.com"}
+	_, err := ValidatePackageAttestations(ctx, nil, "cosign.pub", identity, true)
+	require.ErrorIs(t, err, ErrAttestationsKeyAndKeyless)
+	_, err = ValidatePackageAttestations(ctx, nil, "", utils.CertificateIdentity{}, true)
+	require.ErrorIs(t, err, ErrAttestationsKeylessButNoIdentity)
+
+	require.False(t, VerifiesAttestations(types.ZarfPackageOptions{}))
+	require.True(t, VerifiesAttestations(types.ZarfPackageOptions{AttestationKeyPath: "cosign.pub"}))
+	require.True(t, VerifiesAttestations(types.ZarfPackageOptions{KeylessAttestations: true}))
+}
+
+func TestValidatePackageSignaturePolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	zarfYAML := filepath.Join(t.TempDir(), layout.ZarfYAML)
+	err := os.WriteFile(zarfYAML, []byte("kind: ZarfPackageConfig\nmetadata:\n  name: test\n"), 0o644)
+	require.NoError(t, err)
+	paths := &layout.PackagePaths{ZarfYAML: zarfYAML}
+	policy := types.VerificationPolicy{Rules: []types.VerificationRule{{Packages: []string{"te*"}, Keys: []string{"cosign.pub"}}}}
+	other := types.VerificationPolicy{Rules: []types.VerificationRule{{Packages: []string{"other"}, Keys: []string{"cosign.pub"}}}}
+
+	// Signature validation can only be skipped for packages the policy does not trust publishers for.
+	err = ValidatePackageSignature(ctx, paths, "", utils.CertificateIdentity{}, policy, "", true)
+	require.ErrorIs(t, err, ErrPkgPolicyButSkipped)
+	err = ValidatePackageSignature(ctx, paths, "", utils.CertificateIdentity{}, other, "", true)
+	require.NoError(t, err)
+
+	// The policy applies even when a key is provided.
+	err = ValidatePackageSignature(ctx, paths, "cosign.pub", utils.CertificateIdentity{}, policy, "", false)
+	require.ErrorIs(t, err, ErrPkgPolicyButNoSig)
+	err = ValidatePackageSignature(ctx, paths, "cosign.pub", utils.CertificateIdentity{}, other, "", false)
+	require.ErrorIs(t, err, ErrPkgKeyButNoSig)
+}
+
+func TestValidateComponentChecksums(t *testing.T) {
+	t.Parallel()
+
+	// newPartialPackage creates a package with a files component and an images component, with the component
+	// checksums recorded the way they are when the package is created.
+	newPartialPackage := func(t *testing.T) (*layout.PackagePaths, v1alpha1.ZarfPackage) {
+		t.Helper()
+
+		base := t.TempDir()
+		err := os.MkdirAll(filepath.Join(base, layout.ComponentsDir), 0o700)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(base, layout.ComponentsDir, "files.tar"), []byte("files"), helpers.ReadWriteUser)
+		require.NoError(t, err)
+		img, err := random.Image(512, 2)
+		require.NoError(t, err)
+		imgLayout, err := ocilayout.Write(filepath.Join(base, layout.ImagesDir), empty.Index)
+		require.NoError(t, err)
+		err = imgLayout.AppendImage(img)
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		lines := map[string]string{}
+		err = filepath.WalkDir(base, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(base, path)
+			if err != nil {
+				return err
+			}
+			sha := sha256.Sum256(b)
+			lines[filepath.ToSlash(rel)] = fmt.Sprintf("%s %s", hex.EncodeToString(sha[:]), filepath.ToSlash(rel))
+			return nil
+		})
+		require.NoError(t, err)
+		all := []string{}
+		for _, line := range lines {
+			all = append(all, line)
+		}
+		err = os.WriteFile(filepath.Join(base, layout.Checksums), []byte(strings.Join(all, "\n")+"\n"), helpers.ReadWriteUser)
+		require.NoError(t, err)
+
+		checksum := func(paths ...string) string {
+			component := []string{}
+			for _, p := range paths {
+				component = append(component, lines[p])
+			}
+			slices.Sort(component)
+			sha := sha256.Sum256([]byte(strings.Join(component, "\n") + "\n"))
+			return hex.EncodeToString(sha[:])
+		}
+		imagePaths := []string{}
+		for rel := range lines {
+			if strings.HasPrefix(rel, "images/blobs/") {
+				imagePaths = append(imagePaths, rel)
+			}
+		}
+
+		pkg := v1alpha1.ZarfPackage{
+			Components: []v1alpha1.ZarfComponent{{Name: "files"}, {Name: "images"}},
+			Build: v1alpha1.ZarfBuildData{
+				Components: []v1alpha1.ZarfComponentBuildData{
+					{Name: "files", Checksum: checksum("components/files.tar")},
+					{
+						Name:     "images",
+						Images:   []v1alpha1.ZarfImageBuildData{{Name: "ghcr.io/zarf-dev/test:1.0.0", Digest: digest.String()}},
+						Checksum: checksum(imagePaths...),
+					},
+				},
+			},
+		}
+		return &layout.PackagePaths{Base: base, Checksums: filepath.Join(base, layout.Checksums)}, pkg
+	}
+
+	t.Run("requested components are present", func(t *testing.T) {
+		t.Parallel()
+
+		loaded, pkg := newPartialPackage(t)
+		require.NoError(t, ValidateComponentChecksums(loaded, pkg))
+	})
+
+	t.Run("requested component is missing", func(t *testing.T) {
+		t.Parallel()
+
+		loaded, pkg := newPartialPackage(t)
+		err := os.Remove(filepath.Join(loaded.Base, layout.ComponentsDir, "files.tar"))
+		require.NoError(t, err)
+		err = ValidateComponentChecksums(loaded, pkg)
+		require.EqualError(t, err, "unable to validate partial checksums - missing file: components/files.tar")
+
+		loaded, pkg = newPartialPackage(t)
+		err = os.RemoveAll(filepath.Join(loaded.Base, layout.ImagesDir, "blobs"))
+		require.NoError(t, err)
+		err = ValidateComponentChecksums(loaded, pkg)
+		require.EqualError(t, err, "image ghcr.io/zarf-dev/test:1.0.0 of component images is missing from the package")
+	})
+
+	t.Run("unrequested components are not checked", func(t *testing.T) {
+		t.Parallel()
+
+		loaded, pkg := newPartialPackage(t)
+		err := os.Remove(filepath.Join(loaded.Base, layout.ComponentsDir, "files.tar"))
+		require.NoError(t, err)
+		pkg.Components = pkg.Components[1:]
+		require.NoError(t, ValidateComponentChecksums(loaded, pkg))
+	})
+
+	t.Run("component checksum does not match", func(t *testing.T) {
+		t.Parallel()
+
+		loaded, pkg := newPartialPackage(t)
+		pkg.Build.Components[0].Checksum = "invalid"
+		err := ValidateComponentChecksums(loaded, pkg)
+		require.ErrorContains(t, err, "component files does not match its checksum")
+	})
+
+	t.Run("packages without component checksums", func(t *testing.T) {
+		t.Parallel()
+
+		loaded, pkg := newPartialPackage(t)
+		err := os.Remove(filepath.Join(loaded.Base, layout.ComponentsDir, "files.tar"))
+		require.NoError(t, err)
+		pkg.Build.Components = nil
+		require.NoError(t, ValidateComponentChecksums(loaded, pkg))
+	})
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+)
+
+// Verify validates the checksums, signature and image layer digests of a package without deploying it and returns
+// whether the package is signed. The package is loaded into the packager temp directory which is removed with
+// p.ClearTempPaths().
+func (p *Packager) Verify(ctx context.Context) (bool, error) {
+	l := logger.From(ctx)
+
+	// Loading the package validates the checksums and the signature of the package.
+	pkg, _, err := p.source.LoadPackage(ctx, p.layout, filters.Empty(), false)
+	if err != nil {
+		return false, fmt.Errorf("unable to load the package: %w", err)
+	}
+	p.cfg.Pkg = pkg
+	if p.layout.IsLegacyLayout() {
+		return false, fmt.Errorf("package %s uses a legacy layout without %s and cannot be verified", pkg.Metadata.Name, layout.Checksums)
+	}
+
+	spinner := message.NewProgressSpinner("Validating image layer digests")
+	defer spinner.Stop()
+	l.Info("validating image layer digests", "name", pkg.Metadata.Name)
+	count, err := verifyImageBlobs(p.layout.Images.Base)
+	if err != nil {
+		return false, err
+	}
+	spinner.Successf("Validated %d image blobs", count)
+	l.Debug("done validating image layer digests", "name", pkg.Metadata.Name, "blobs", count)
+
+	return p.layout.Signature != "", nil
+}
+
+// verifyImageBlobs validates the digest and size of every blob referenced by the OCI image layout in dir and returns the number of blobs validated.
+func verifyImageBlobs(dir string) (int, error) {
+	indexPath := filepath.Join(dir, "index.json")
+	if helpers.InvalidPath(indexPath) {
+		return 0, nil
+	}
+	b, err := os.ReadFile(indexPath)
+	if err != nil {
+		return 0, err
+	}
+	index, err := v1.ParseIndexManifest(bytes.NewReader(b))
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the image index: %w", err)
+	}
+	verified := map[v1.Hash]bool{}
+	for _, desc := range index.Manifests {
+		if err := verifyDescriptor(dir, desc, verified); err != nil {
+			return 0, err
+		}
+	}
+	return len(verified), nil
+}
+
+// verifyDescriptor validates the blob of a descriptor and, for manifests and indexes, the blobs they reference.
+func verifyDescriptor(dir string, desc v1.Descriptor, verified map[v1.Hash]bool) error {
+	if verified[desc.Digest] {
+		return nil
+	}
+	path := filepath.Join(dir, "blobs", desc.Digest.Algorithm, desc.Digest.Hex)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) && len(desc.URLs) > 0 {
+		// Non-distributable layers are pulled from their URLs and are not stored in the package.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("image blob %s is missing: %w", desc.Digest, err)
+	}
+	if info.Size() != desc.Size {
+		return fmt.Errorf("image blob %s has size %d, expected %d", desc.Digest, info.Size(), desc.Size)
+	}
+	if desc.Digest.Algorithm != "sha256" {
+		return fmt.Errorf("image blob %s uses unsupported digest algorithm %s", desc.Digest, desc.Digest.Algorithm)
+	}
+	if err := helpers.SHAsMatch(path, desc.Digest.Hex); err != nil {
+		return fmt.Errorf("image blob %s is corrupted: %w", desc.Digest, err)
+	}
+	verified[desc.Digest] = true
+
+	switch {
+	case desc.MediaType.IsIndex():
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		index, err := v1.ParseIndexManifest(bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("unable to parse image index %s: %w", desc.Digest, err)
+		}
+		for _, child := range index.Manifests {
+			if err := verifyDescriptor(dir, child, verified); err != nil {
+				return err
+			}
+		}
+	case desc.MediaType.IsImage():
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		manifest, err := v1.ParseManifest(bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("unable to parse image manifest %s: %w", desc.Digest, err)
+		}
+		if err := verifyDescriptor(dir, manifest.Config, verified); err != nil {
+			return err
+		}
+		for _, layer := range manifest.Layers {
+			if err := verifyDescriptor(dir, layer, verified); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyImageBlobs(t *testing.T) {
+	t.Parallel()
+
+	count, err := verifyImageBlobs(t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	dir := t.TempDir()
+	idx, err := random.Index(16, 2, 1)
+	require.NoError(t, err)
+	_, err = layout.Write(dir, idx)
+	require.NoError(t, err)
+
+	// The manifest, the config and the two layers of the image.
+	count, err = verifyImageBlobs(dir)
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+
+	manifest, err := idx.IndexManifest()
+	require.NoError(t, err)
+	img, err := idx.Image(manifest.Manifests[0].Digest)
+	require.NoError(t, err)
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	digest, err := layers[1].Digest()
+	require.NoError(t, err)
+	size, err := layers[1].Size()
+	require.NoError(t, err)
+	blobPath := filepath.Join(dir, "blobs", digest.Algorithm, digest.Hex)
+
+	err = os.WriteFile(blobPath, make([]byte, size), 0o644)
+	require.NoError(t, err)
+	_, err = verifyImageBlobs(dir)
+	require.ErrorContains(t, err, "is corrupted")
+
+	err = os.WriteFile(blobPath, []byte("truncated"), 0o644)
+	require.NoError(t, err)
+	_, err = verifyImageBlobs(dir)
+	require.ErrorContains(t, err, fmt.Sprintf("has size 9, expected %d", size))
+
+	err = os.Remove(blobPath)
+	require.NoError(t, err)
+	_, err = verifyImageBlobs(dir)
+	require.ErrorContains(t, err, "is missing")
+}
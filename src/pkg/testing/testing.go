@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package testing runs the Zarf CLI from Go tests so that package maintainers can create, deploy and remove their
+// packages against ephemeral clusters. Import it with an alias next to the standard library testing package:
+//
+//	import zarftesting "github.com/zarf-dev/zarf/src/pkg/testing"
+package testing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// TB is the subset of testing.TB used by the helpers, *testing.T and *testing.B satisfy it.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	TempDir() string
+	Logf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// Zarf runs Zarf CLI commands for a test.
+type Zarf struct {
+	// BinPath is the path to the Zarf CLI, zarf on the PATH is used when empty.
+	BinPath string
+	// Dir is the directory commands run in, the working directory of the test is used when empty.
+	Dir string
+	// Env are environment variables added to the environment of the commands.
+	Env []string
+}
+
+// Run runs the Zarf command and returns its stdout and stderr. Each command gets its own temporary directory and logs
+// without color so that output can be matched.
+func (z *Zarf) Run(t TB, args ...string) (string, string, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return z.run(ctx, t.TempDir(), args...)
+}
+
+// runInCleanup runs the Zarf command from a cleanup function, where the test can no longer create temporary
+// directories, and logs failures.
+func (z *Zarf) runInCleanup(t TB, args ...string) {
+	tmpDir, err := os.MkdirTemp("", "zarf-")
+	if err != nil {
+		t.Logf("unable to create temporary directory: %v", err)
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+	if _, stderr, err := z.run(context.Background(), tmpDir, args...); err != nil {
+		t.Logf("zarf %v failed: %v\n%s", args, err, stderr)
+	}
+}
+
+func (z *Zarf) run(ctx context.Context, tmpDir string, args ...string) (string, string, error) {
+	cfg := exec.PrintCfg()
+	cfg.Dir = z.Dir
+	cfg.Env = z.Env
+	binPath := z.BinPath
+	if binPath == "" {
+		binPath = "zarf"
+	}
+	return exec.CmdWithContext(ctx, cfg, binPath, commandArgs(args, tmpDir)...)
+}
+
+// MustRun runs the Zarf command like Run and fails the test when the command fails.
+func (z *Zarf) MustRun(t TB, args ...string) string {
+	t.Helper()
+
+	stdout, stderr, err := z.Run(t, args...)
+	if err != nil {
+		t.Fatalf("zarf %v failed: %v\nstdout: %s\nstderr: %s", args, err, stdout, stderr)
+	}
+	return stdout
+}
+
+// Kubectl runs zarf tools kubectl with the arguments.
+func (z *Zarf) Kubectl(t TB, args ...string) (string, string, error) {
+	t.Helper()
+
+	return z.Run(t, append([]string{"tools", "kubectl"}, args...)...)
+}
+
+// CreatePackage creates the package in dir and returns the path to the package tarball. Additional arguments, such as
+// --flavor, are passed to zarf package create.
+func (z *Zarf) CreatePackage(t TB, dir string, args ...string) string {
+	t.Helper()
+
+	outputDir := t.TempDir()
+	createArgs := append([]string{"package", "create", dir, "--output", outputDir, "--confirm"}, args...)
+	z.MustRun(t, createArgs...)
+	path, err := findPackage(outputDir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return path
+}
+
+// DeployPackage deploys the package at source and removes it when the test ends. Additional arguments, such as --set,
+// are passed to zarf package deploy.
+func (z *Zarf) DeployPackage(t TB, source string, args ...string) {
+	t.Helper()
+
+	deployArgs := append([]string{"package", "deploy", source, "--confirm"}, args...)
+	z.MustRun(t, deployArgs...)
+	t.Cleanup(func() {
+		z.runInCleanup(t, "package", "remove", source, "--confirm")
+	})
+}
+
+// RemovePackage removes the package, source is either a package source or the name of a deployed package.
+func (z *Zarf) RemovePackage(t TB, source string, args ...string) {
+	t.Helper()
+
+	removeArgs := append([]string{"package", "remove", source, "--confirm"}, args...)
+	z.MustRun(t, removeArgs...)
+}
+
+// CreateCluster creates a local cluster initialized with Zarf using zarf dev cluster create, and destroys it when the
+// test ends. Additional arguments, such as --provider or --components, are passed to zarf dev cluster create.
+func (z *Zarf) CreateCluster(t TB, name string, args ...string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		z.runInCleanup(t, "dev", "cluster", "destroy", "--name", name)
+	})
+	createArgs := append([]string{"dev", "cluster", "create", "--name", name, "--confirm"}, args...)
+	z.MustRun(t, createArgs...)
+}
+
+// commandArgs returns the arguments with the logging and temporary directory flags added. Tools commands are passed
+// through unchanged as they do not accept the flags.
+func commandArgs(args []string, tmpDir string) []string {
+	if slices.Contains(args, "tools") {
+		return args
+	}
+	args = append(slices.Clone(args), "--log-format=console", "--no-color")
+	if !slices.Contains(args, "--tmpdir") {
+		args = append(args, "--tmpdir", tmpDir)
+	}
+	return args
+}
+
+// findPackage returns the path to the package tarball created in dir.
+func findPackage(dir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "zarf-package-*.tar*"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) != 1 {
+		return "", fmt.Errorf("expected one package in %s, found %d", dir, len(matches))
+	}
+	return matches[0], nil
+}
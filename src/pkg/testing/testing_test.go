@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandArgs(t *testing.T) {
+	t.Parallel()
+
+	args := commandArgs([]string{"package", "deploy", "test.tar.zst"}, "/tmp/zarf")
+	require.Equal(t, []string{"package", "deploy", "test.tar.zst", "--log-format=console", "--no-color", "--tmpdir", "/tmp/zarf"}, args)
+
+	args = commandArgs([]string{"package", "create", "--tmpdir", "/tmp/other"}, "/tmp/zarf")
+	require.Equal(t, []string{"package", "create", "--tmpdir", "/tmp/other", "--log-format=console", "--no-color"}, args)
+
+	args = commandArgs([]string{"tools", "kubectl", "get", "pods"}, "/tmp/zarf")
+	require.Equal(t, []string{"tools", "kubectl", "get", "pods"}, args)
+}
+
+func TestFindPackage(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, err := findPackage(dir)
+	require.EqualError(t, err, "expected one package in "+dir+", found 0")
+
+	path := filepath.Join(dir, "zarf-package-test-amd64-0.0.1.tar.zst")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	found, err := findPackage(dir)
+	require.NoError(t, err)
+	require.Equal(t, path, found)
+}
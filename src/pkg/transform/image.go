@@ -6,6 +6,7 @@ package transform
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
@@ -25,6 +26,7 @@ type Image struct {
 
 // ImageTransformHost replaces the base url for an image and adds a crc32 of the original url to the end of the src (note image refs are not full URLs).
 func ImageTransformHost(targetHost, srcReference string) (string, error) {
+	targetHost = bracketIPv6Host(targetHost)
 	image, err := ParseImageRef(srcReference)
 	if err != nil {
 		return "", err
@@ -48,6 +50,7 @@ func ImageTransformHost(targetHost, srcReference string) (string, error) {
 
 // ImageTransformHostWithoutChecksum replaces the base url for an image but avoids adding a checksum of the original url (note image refs are not full URLs).
 func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string, error) {
+	targetHost = bracketIPv6Host(targetHost)
 	image, err := ParseImageRef(srcReference)
 	if err != nil {
 		return "", err
@@ -61,6 +64,14 @@ func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string,
 	return fmt.Sprintf("%s/%s%s", targetHost, image.Path, image.TagOrDigest), nil
 }
 
+// bracketIPv6Host wraps a bare IPv6 literal host such as fd00::10 in brackets so that it can be used in an image reference.
+func bracketIPv6Host(host string) string {
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		return fmt.Sprintf("[%s]", host)
+	}
+	return host
+}
+
 // ParseImageRef parses a source reference into an Image struct
 func ParseImageRef(srcReference string) (Image, error) {
 	srcReference = strings.TrimPrefix(srcReference, helpers.OCIURLPrefix)
@@ -54,6 +54,54 @@ func TestImageTransformHost(t *testing.T) {
 	}
 }
 
+func TestImageTransformHostIPv6(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		targetHost string
+		ref        string
+		expected   string
+	}{
+		{
+			name:       "bracketed host and port",
+			targetHost: "[fd00::10]:31999",
+			ref:        "nginx:1.23.3",
+			expected:   "[fd00::10]:31999/library/nginx:1.23.3-zarf-3793515731",
+		},
+		{
+			name:       "bare host",
+			targetHost: "fd00::10",
+			ref:        "nginx:1.23.3",
+			expected:   "[fd00::10]/library/nginx:1.23.3-zarf-3793515731",
+		},
+		{
+			name:       "already transformed",
+			targetHost: "[::1]:31999",
+			ref:        "[::1]:31999/library/nginx:1.23.3-zarf-3793515731",
+			expected:   "[::1]:31999/library/nginx:1.23.3-zarf-3793515731",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newRef, err := ImageTransformHost(tt.targetHost, tt.ref)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, newRef)
+		})
+	}
+
+	newRef, err := ImageTransformHostWithoutChecksum("fd00::10", "nginx:1.23.3")
+	require.NoError(t, err)
+	require.Equal(t, "[fd00::10]/library/nginx:1.23.3", newRef)
+
+	img, err := ParseImageRef("[fd00::10]:31999/stefanprodan/podinfo:6.3.3")
+	require.NoError(t, err)
+	require.Equal(t, "[fd00::10]:31999", img.Host)
+	require.Equal(t, "stefanprodan/podinfo", img.Path)
+}
+
 func TestImageTransformHostWithoutChecksum(t *testing.T) {
 	var expectedResult = []string{
 		"gitlab.com/project/library/nginx:latest",
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions.
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/zarf-dev/zarf/src/config"
+)
+
+// DownloadCacheDir is the directory within the Zarf cache that stores downloaded files.
+const DownloadCacheDir = "downloads"
+
+// DownloadCache stores downloaded files in the Zarf cache by the SHA256 checksum of their content.
+// Downloads pinned to a checksum are always reused, other downloads are looked up by their source and are only reused within the TTL.
+type DownloadCache struct {
+	path   string
+	ttl    time.Duration
+	verify bool
+}
+
+// downloadCacheRef records the content of the latest download of a source.
+type downloadCacheRef struct {
+	Source    string    `json:"source"`
+	Digest    string    `json:"digest"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewDownloadCache returns a download cache stored in the given directory, an empty directory disables the cache.
+// A TTL of zero disables reusing downloads that are not pinned to a checksum, verify checks the content of cached files before they are reused.
+func NewDownloadCache(path string, ttl time.Duration, verify bool) *DownloadCache {
+	return &DownloadCache{
+		path:   path,
+		ttl:    ttl,
+		verify: verify,
+	}
+}
+
+// DefaultDownloadCache returns the download cache within the Zarf cache configured by the common options.
+// Downloads are not cached when the Zarf cache path is not set.
+func DefaultDownloadCache() (*DownloadCache, error) {
+	cachePath, err := config.GetAbsCachePath()
+	if err != nil {
+		return nil, err
+	}
+	if cachePath == "" {
+		return NewDownloadCache("", 0, false), nil
+	}
+	return NewDownloadCache(filepath.Join(cachePath, DownloadCacheDir), config.CommonOptions.DownloadCacheTTL, !config.CommonOptions.SkipDownloadCacheVerify), nil
+}
+
+// Get copies the cached download of the source to dst, returning false if there is no usable cached download.
+// Downloads pinned to a checksum are looked up by the checksum, all others by the source.
+func (c *DownloadCache) Get(source, checksum, dst string) (bool, error) {
+	if c.path == "" {
+		return false, nil
+	}
+	digest := checksum
+	if digest == "" {
+		if c.ttl <= 0 {
+			return false, nil
+		}
+		ref, err := c.readRef(source)
+		if err != nil || ref == nil {
+			return false, err
+		}
+		if time.Since(ref.CreatedAt) > c.ttl {
+			return false, nil
+		}
+		digest = ref.Digest
+	}
+
+	blobPath := c.blobPath(digest)
+	if helpers.InvalidPath(blobPath) {
+		return false, nil
+	}
+	if c.verify {
+		received, err := helpers.GetSHA256OfFile(blobPath)
+		if err != nil {
+			return false, err
+		}
+		if received != digest {
+			// Corrupted entries are removed so that the next download replaces them.
+			return false, os.Remove(blobPath)
+		}
+	}
+	if err := copyFile(blobPath, dst); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Put stores the downloaded file at path in the cache as the latest download of the source.
+func (c *DownloadCache) Put(source, path string) error {
+	if c.path == "" {
+		return nil
+	}
+	digest, err := helpers.GetSHA256OfFile(path)
+	if err != nil {
+		return err
+	}
+	blobPath := c.blobPath(digest)
+	if helpers.InvalidPath(blobPath) {
+		if err := atomicWrite(blobPath, func(w io.Writer) error {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(w, f)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	ref := downloadCacheRef{
+		Source:    source,
+		Digest:    digest,
+		CreatedAt: time.Now(),
+	}
+	b, err := json.Marshal(ref)
+	if err != nil {
+		return err
+	}
+	return atomicWrite(c.refPath(source), func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
+}
+
+func (c *DownloadCache) readRef(source string) (*downloadCacheRef, error) {
+	b, err := os.ReadFile(c.refPath(source))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ref downloadCacheRef
+	if err := json.Unmarshal(b, &ref); err != nil {
+		return nil, fmt.Errorf("unable to read the cached download of %s: %w", source, err)
+	}
+	// Sources with colliding paths are treated as not cached.
+	if ref.Source != source {
+		return nil, nil
+	}
+	return &ref, nil
+}
+
+func (c *DownloadCache) blobPath(digest string) string {
+	return filepath.Join(c.path, "blobs", "sha256", digest)
+}
+
+func (c *DownloadCache) refPath(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(c.path, "refs", hex.EncodeToString(sum[:])+".json")
+}
+
+// atomicWrite writes a file through a temporary file in the same directory so that concurrent readers never see a partial file.
+func atomicWrite(path string, write func(w io.Writer) error) (err error) {
+	if err := helpers.CreateDirectory(filepath.Dir(path), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func copyFile(src, dst string) (err error) {
+	if err := helpers.CreateDirectory(filepath.Dir(dst), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, out.Close())
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
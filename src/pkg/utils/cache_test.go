@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic utility functions.
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadCache(t *testing.T) {
+	t.Parallel()
+
+	source := "https://example.com/file.txt"
+	src := filepath.Join(t.TempDir(), "file.txt")
+	err := os.WriteFile(src, []byte("hello world"), helpers.ReadWriteUser)
+	require.NoError(t, err)
+	checksum, err := helpers.GetSHA256OfFile(src)
+	require.NoError(t, err)
+
+	cachePath := t.TempDir()
+	cache := NewDownloadCache(cachePath, time.Hour, true)
+	dst := filepath.Join(t.TempDir(), "dst.txt")
+	hit, err := cache.Get(source, "", dst)
+	require.NoError(t, err)
+	require.False(t, hit)
+
+	err = cache.Put(source, src)
+	require.NoError(t, err)
+
+	// Downloads are found by their source and by their checksum.
+	hit, err = cache.Get(source, "", dst)
+	require.NoError(t, err)
+	require.True(t, hit)
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(b))
+	hit, err = cache.Get("https://example.com/other.txt", checksum, filepath.Join(t.TempDir(), "other.txt"))
+	require.NoError(t, err)
+	require.True(t, hit)
+
+	// Downloads that are not pinned to a checksum are not reused without a TTL or once it has expired.
+	hit, err = NewDownloadCache(cachePath, 0, true).Get(source, "", dst)
+	require.NoError(t, err)
+	require.False(t, hit)
+	ref, err := cache.readRef(source)
+	require.NoError(t, err)
+	ref.CreatedAt = time.Now().Add(-2 * time.Hour)
+	b, err = json.Marshal(ref)
+	require.NoError(t, err)
+	err = os.WriteFile(cache.refPath(source), b, helpers.ReadWriteUser)
+	require.NoError(t, err)
+	hit, err = cache.Get(source, "", dst)
+	require.NoError(t, err)
+	require.False(t, hit)
+
+	// Corrupted downloads are removed when verified and reused as is otherwise.
+	err = os.WriteFile(cache.blobPath(checksum), []byte("corrupted"), helpers.ReadWriteUser)
+	require.NoError(t, err)
+	hit, err = NewDownloadCache(cachePath, 0, false).Get(source, checksum, dst)
+	require.NoError(t, err)
+	require.True(t, hit)
+	hit, err = cache.Get(source, checksum, dst)
+	require.NoError(t, err)
+	require.False(t, hit)
+	require.NoFileExists(t, cache.blobPath(checksum))
+
+	// An empty path disables the cache.
+	cache = NewDownloadCache("", time.Hour, true)
+	err = cache.Put(source, src)
+	require.NoError(t, err)
+	hit, err = cache.Get(source, checksum, dst)
+	require.NoError(t, err)
+	require.False(t, hit)
+}
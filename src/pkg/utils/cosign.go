@@ -5,23 +5,37 @@
 package utils
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/digitorus/timestamp"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/fulcio"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v2/cmd/cosign/cli/rekor"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/sign"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/verify"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
+	cbundle "github.com/sigstore/cosign/v2/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/v2/pkg/cosign/pkcs11key"
 	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
 	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	signatureoptions "github.com/sigstore/sigstore/pkg/signature/options"
+	tsaverification "github.com/sigstore/timestamp-authority/pkg/verification"
 
 	// Register the provider-specific plugins
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"
@@ -177,8 +191,22 @@ func Sget(ctx context.Context, image, key string, out io.Writer) error {
 	return err
 }
 
+// CheckCosignKeyRef returns an error if the key reference can not be used by this build of Zarf.
+//
+// Key references are either a local file path, a KMS URI (awskms://, azurekms://, gcpkms:// or hashivault://), a
+// Kubernetes secret (k8s://) or a PKCS#11 URI (pkcs11:), which is also how keys on a YubiKey are referenced.
+func CheckCosignKeyRef(keyRef string) error {
+	if strings.HasPrefix(keyRef, pkcs11key.ReferenceScheme) && !pkcs11Supported {
+		return fmt.Errorf("key %q is a PKCS#11 key which is not supported by this build of Zarf, build Zarf with CGO_ENABLED=1 and -tags pkcs11key to use PKCS#11 keys", keyRef)
+	}
+	return nil
+}
+
 // CosignVerifyBlob verifies the zarf.yaml.sig was signed with the key provided by the flag
 func CosignVerifyBlob(ctx context.Context, blobRef, sigRef, keyPath string) error {
+	if err := CheckCosignKeyRef(keyPath); err != nil {
+		return err
+	}
 	keyOptions := options.KeyOpts{KeyRef: keyPath}
 	cmd := &verify.VerifyBlobCmd{
 		KeyOpts:    keyOptions,
@@ -197,8 +225,12 @@ func CosignVerifyBlob(ctx context.Context, blobRef, sigRef, keyPath string) erro
 	return nil
 }
 
-// CosignSignBlob signs the provide binary and returns the signature
-func CosignSignBlob(blobPath, outputSigPath, keyPath string, passFn cosign.PassFunc) ([]byte, error) {
+// CosignSignBlob signs the provide binary and returns the signature. When tsaURL is set the signature is timestamped by
+// the RFC3161 timestamp authority and the timestamp is written to outputTimestampPath.
+func CosignSignBlob(blobPath, outputSigPath, keyPath string, passFn cosign.PassFunc, tsaURL, outputTimestampPath string) ([]byte, error) {
+	if err := CheckCosignKeyRef(keyPath); err != nil {
+		return nil, err
+	}
 	rootOptions := &options.RootOptions{
 		Verbose: false,
 		Timeout: options.DefaultTimeout,
@@ -208,6 +240,10 @@ func CosignSignBlob(blobPath, outputSigPath, keyPath string, passFn cosign.PassF
 		KeyRef:   keyPath,
 		PassFunc: passFn,
 	}
+	if tsaURL != "" {
+		keyOptions.TSAServerURL = tsaURL
+		keyOptions.RFC3161TimestampPath = outputTimestampPath
+	}
 
 	sig, err := sign.SignBlobCmd(
 		rootOptions,
@@ -224,6 +260,235 @@ func CosignSignBlob(blobPath, outputSigPath, keyPath string, passFn cosign.PassF
 	return sig, nil
 }
 
+// KeylessOptions are the options to sign keyless with a short-lived Fulcio certificate for an OIDC identity, with the
+// signature recorded in a Rekor transparency log.
+type KeylessOptions struct {
+	// IdentityToken is the OIDC identity token the certificate is requested with. When empty the token of the CI
+	// environment is used, or it is requested interactively from the OIDC issuer.
+	IdentityToken string
+	// FulcioURL is the URL of the Fulcio certificate authority, defaults to the public good instance.
+	FulcioURL string
+	// RekorURL is the URL of the Rekor transparency log, defaults to the public good instance.
+	RekorURL string
+	// OIDCIssuer is the URL of the OIDC issuer a token is requested from interactively.
+	OIDCIssuer string
+}
+
+// CosignSignBlobKeyless signs the provided binary keyless and writes the signature to outputSigPath. The bundle with
+// the certificate and the Rekor transparency log entry is written to outputBundlePath so that the signature can be
+// verified offline.
+func CosignSignBlobKeyless(blobPath, outputSigPath, outputBundlePath string, opts KeylessOptions) error {
+	rootOptions := &options.RootOptions{
+		Verbose: false,
+		Timeout: options.DefaultTimeout,
+	}
+	keyOptions := keylessKeyOpts(opts)
+	keyOptions.BundlePath = outputBundlePath
+	_, err := sign.SignBlobCmd(rootOptions, keyOptions, blobPath, cosignB64Enabled, outputSigPath, cosignOutputCertificate, true)
+	return err
+}
+
+// keylessKeyOpts returns the cosign key options to sign keyless with.
+func keylessKeyOpts(opts KeylessOptions) options.KeyOpts {
+	keyOptions := options.KeyOpts{
+		FulcioURL:        options.DefaultFulcioURL,
+		RekorURL:         options.DefaultRekorURL,
+		OIDCIssuer:       options.DefaultOIDCIssuerURL,
+		OIDCClientID:     "sigstore",
+		IDToken:          opts.IdentityToken,
+		SkipConfirmation: true,
+	}
+	if opts.FulcioURL != "" {
+		keyOptions.FulcioURL = opts.FulcioURL
+	}
+	if opts.RekorURL != "" {
+		keyOptions.RekorURL = opts.RekorURL
+	}
+	if opts.OIDCIssuer != "" {
+		keyOptions.OIDCIssuer = opts.OIDCIssuer
+	}
+	return keyOptions
+}
+
+// KeylessSigner signs blobs keyless with a single short-lived Fulcio certificate, so that the OIDC identity is only
+// authenticated once when many blobs are signed. Each signature is recorded in the Rekor transparency log.
+type KeylessSigner struct {
+	sv       *sign.SignerVerifier
+	rekorURL string
+}
+
+// NewKeylessSigner requests a Fulcio certificate for the OIDC identity to sign blobs keyless with.
+func NewKeylessSigner(ctx context.Context, opts KeylessOptions) (*KeylessSigner, error) {
+	keyOptions := keylessKeyOpts(opts)
+	sv, err := sign.SignerFromKeyOpts(ctx, "", "", keyOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get a certificate to sign keyless with: %w", err)
+	}
+	return &KeylessSigner{sv: sv, rekorURL: keyOptions.RekorURL}, nil
+}
+
+// SignBlob signs the blob, records the signature in the Rekor transparency log and returns the raw signature and the
+// bundle with the certificate and the Rekor entry. The bundle has the format of the bundles written by
+// CosignSignBlobKeyless so that it can be verified with CosignVerifyBlobKeyless.
+func (s *KeylessSigner) SignBlob(ctx context.Context, blob []byte) ([]byte, []byte, error) {
+	sig, err := s.sv.SignMessage(bytes.NewReader(blob), signatureoptions.WithContext(ctx))
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing blob: %w", err)
+	}
+	certPEM, err := s.sv.Bytes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rekorClient, err := rekor.NewClient(s.rekorURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	checksum := sha256.New()
+	checksum.Write(blob)
+	entry, err := cosign.TLogUpload(ctx, rekorClient, sig, checksum, certPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to record the signature in the transparency log: %w", err)
+	}
+	bundle, err := json.Marshal(cosign.LocalSignedPayload{
+		Base64Signature: base64.StdEncoding.EncodeToString(sig),
+		Cert:            base64.StdEncoding.EncodeToString(certPEM),
+		Bundle:          cbundle.EntryToBundle(entry),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, bundle, nil
+}
+
+// Close releases the signer.
+func (s *KeylessSigner) Close() {
+	s.sv.Close()
+}
+
+// CertificateIdentity is the identity the Fulcio certificate of a keyless signature must be issued to.
+type CertificateIdentity struct {
+	// Identity is the subject of the certificate, i.e. an email address or the URI of a CI workflow.
+	Identity string
+	// OIDCIssuer is the OIDC issuer that authenticated the identity.
+	OIDCIssuer string
+}
+
+// IsEmpty returns true when no certificate identity is set.
+func (ci CertificateIdentity) IsEmpty() bool {
+	return ci.Identity == "" && ci.OIDCIssuer == ""
+}
+
+// CosignVerifyBlobKeyless verifies the keyless signature of a blob with the bundle written when it was signed. The
+// certificate must chain to the Fulcio roots and be issued to the identity, and the Rekor entry of the bundle is
+// verified offline so that no access to the transparency log is required.
+func CosignVerifyBlobKeyless(ctx context.Context, blobRef, sigRef, bundleRef string, identity CertificateIdentity) error {
+	if identity.Identity == "" || identity.OIDCIssuer == "" {
+		return errors.New("both a certificate identity and a certificate OIDC issuer are required to verify keyless signatures")
+	}
+	cmd := &verify.VerifyBlobCmd{
+		KeyOpts: options.KeyOpts{BundlePath: bundleRef},
+		CertVerifyOptions: options.CertVerifyOptions{
+			CertIdentity:   identity.Identity,
+			CertOidcIssuer: identity.OIDCIssuer,
+		},
+		SigRef:  sigRef,
+		Offline: true,
+	}
+	if err := cmd.Exec(ctx, blobRef); err != nil {
+		return err
+	}
+	logger.From(ctx).Debug("keyless signature validated", "blob", blobRef, "identity", identity.Identity, "issuer", identity.OIDCIssuer)
+	return nil
+}
+
+// SignatureTimestamp is the RFC3161 timestamp of a signature.
+type SignatureTimestamp struct {
+	// Time the timestamp authority recorded for the signature.
+	Time time.Time
+	// Authority is the subject of the timestamp authority certificate, if the timestamp includes it.
+	Authority string
+}
+
+// ReadSignatureTimestamp reads the RFC3161 timestamp at timestampPath without verifying it.
+func ReadSignatureTimestamp(timestampPath string) (SignatureTimestamp, error) {
+	ts, err := readRFC3161Timestamp(timestampPath)
+	if err != nil {
+		return SignatureTimestamp{}, err
+	}
+	return signatureTimestamp(ts), nil
+}
+
+// VerifySignatureTimestamp verifies that the RFC3161 timestamp at timestampPath covers the signature at sigPath and is
+// signed by a timestamp authority with one of the PEM encoded certificate chains. The first certificate of a chain is
+// the timestamp authority certificate and the last is the root.
+func VerifySignatureTimestamp(sigPath, timestampPath string, certChains []string) (SignatureTimestamp, error) {
+	b, err := os.ReadFile(sigPath)
+	if err != nil {
+		return SignatureTimestamp{}, err
+	}
+	// Signatures are stored base64 encoded while the timestamp covers the raw signature.
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return SignatureTimestamp{}, fmt.Errorf("unable to decode signature %s: %w", sigPath, err)
+	}
+	tsBytes, err := os.ReadFile(timestampPath)
+	if err != nil {
+		return SignatureTimestamp{}, err
+	}
+	rfc3161Timestamp := cbundle.RFC3161Timestamp{}
+	if err := json.Unmarshal(tsBytes, &rfc3161Timestamp); err != nil {
+		return SignatureTimestamp{}, fmt.Errorf("unable to read timestamp %s: %w", timestampPath, err)
+	}
+	errs := []error{}
+	for _, certChain := range certChains {
+		certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(certChain))
+		if err != nil {
+			return SignatureTimestamp{}, fmt.Errorf("unable to read timestamp authority certificate chain: %w", err)
+		}
+		if len(certs) == 0 {
+			return SignatureTimestamp{}, errors.New("timestamp authority certificate chain is empty")
+		}
+		opts := tsaverification.VerifyOpts{
+			TSACertificate: certs[0],
+			Roots:          certs[len(certs)-1:],
+		}
+		if len(certs) > 2 {
+			opts.Intermediates = certs[1 : len(certs)-1]
+		}
+		ts, err := tsaverification.VerifyTimestampResponse(rfc3161Timestamp.SignedRFC3161Timestamp, bytes.NewReader(sig), opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return signatureTimestamp(ts), nil
+	}
+	return SignatureTimestamp{}, fmt.Errorf("timestamp %s is not signed by a trusted timestamp authority: %w", filepath.Base(timestampPath), errors.Join(errs...))
+}
+
+func readRFC3161Timestamp(timestampPath string) (*timestamp.Timestamp, error) {
+	b, err := os.ReadFile(timestampPath)
+	if err != nil {
+		return nil, err
+	}
+	rfc3161Timestamp := cbundle.RFC3161Timestamp{}
+	if err := json.Unmarshal(b, &rfc3161Timestamp); err != nil {
+		return nil, fmt.Errorf("unable to read timestamp %s: %w", timestampPath, err)
+	}
+	ts, err := timestamp.ParseResponse(rfc3161Timestamp.SignedRFC3161Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse timestamp %s: %w", timestampPath, err)
+	}
+	return ts, nil
+}
+
+func signatureTimestamp(ts *timestamp.Timestamp) SignatureTimestamp {
+	st := SignatureTimestamp{Time: ts.Time}
+	if len(ts.Certificates) > 0 {
+		st.Authority = ts.Certificates[0].Subject.String()
+	}
+	return st
+}
+
 // GetCosignArtifacts returns signatures and attestations for the given image
 func GetCosignArtifacts(image string) ([]string, error) {
 	var nameOpts []name.Option
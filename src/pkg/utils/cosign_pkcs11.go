@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build pkcs11key && cgo
+
+// Package utils provides generic utility functions.
+package utils
+
+// pkcs11Supported is true when Zarf is built with PKCS#11 support.
+const pkcs11Supported = true
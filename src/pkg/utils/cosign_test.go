@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic utility functions.
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCosignKeyRef(t *testing.T) {
+	t.Parallel()
+
+	for _, keyRef := range []string{"", "cosign.pub", "awskms:///arn:aws:kms:us-east-1:111122223333:alias/zarf", "hashivault://zarf", "k8s://zarf/cosign"} {
+		require.NoError(t, CheckCosignKeyRef(keyRef))
+	}
+
+	err := CheckCosignKeyRef("pkcs11:token=YubiKey%20PIV;slot-id=0")
+	if pkcs11Supported {
+		require.NoError(t, err)
+	} else {
+		require.ErrorContains(t, err, "is a PKCS#11 key which is not supported by this build of Zarf")
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions.
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+const (
+	// minDownloadPartSize is the smallest part of a file that is downloaded over its own connection.
+	minDownloadPartSize = 64 * 1024 * 1024
+	// downloadRetryDelay is the initial delay before resuming an interrupted download, it doubles with every attempt.
+	downloadRetryDelay = time.Second
+)
+
+// downloadOptions are the options for downloading a file over HTTP.
+type downloadOptions struct {
+	// connections is the maximum number of connections a file is downloaded over.
+	connections int
+	// minPartSize is the smallest part of a file that is downloaded over its own connection.
+	minPartSize int64
+	// attempts is the number of attempts made to resume an interrupted download.
+	attempts int
+	// retryDelay is the initial delay before resuming an interrupted download.
+	retryDelay time.Duration
+}
+
+// partCount returns the number of parts a file of the given size is downloaded in.
+func (o downloadOptions) partCount(size int64) int {
+	if size <= 0 || o.connections <= 1 || o.minPartSize <= 0 {
+		return 1
+	}
+	return int(max(min(int64(o.connections), size/o.minPartSize), 1))
+}
+
+// downloadParts downloads a file of the given size in parts over parallel connections, writing every part at its offset in the file.
+func downloadParts(ctx context.Context, url string, destinationFile *os.File, size int64, parts int, opts downloadOptions) (err error) {
+	l := logger.From(ctx)
+	start := time.Now()
+	l.Debug("downloading over multiple connections", "url", url, "size", size, "connections", parts)
+
+	// TODO(mkcp): Remove message on logger release
+	title := fmt.Sprintf("Downloading %s", filepath.Base(url))
+	progressBar := message.NewProgressBar(size, title)
+	progress := &syncWriter{w: progressBar}
+
+	partSize := size / int64(parts)
+	g, gCtx := errgroup.WithContext(ctx)
+	for i := range parts {
+		first := int64(i) * partSize
+		last := first + partSize - 1
+		if i == parts-1 {
+			last = size - 1
+		}
+		g.Go(func() error {
+			return downloadRange(gCtx, url, destinationFile, first, last, progress, opts)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		progressBar.Failf("Unable to save the file %s: %s", destinationFile.Name(), err.Error())
+		return fmt.Errorf("unable to save the file %s: %w", destinationFile.Name(), err)
+	}
+	progressBar.Successf("Downloaded %s", url)
+	l.Debug("download successful", "url", url, "size", size, "duration", time.Since(start))
+	return nil
+}
+
+// downloadRange downloads the bytes from first to last of the url into the same range of the file.
+// Interrupted attempts are resumed from the last received byte.
+func downloadRange(ctx context.Context, url string, destinationFile *os.File, first, last int64, progress io.Writer, opts downloadOptions) error {
+	l := logger.From(ctx)
+	delay := opts.retryDelay
+	for attempt := 1; ; attempt++ {
+		n, err := downloadRangeOnce(ctx, url, destinationFile, first, last, progress)
+		first += n
+		if err == nil {
+			if first != last+1 {
+				return fmt.Errorf("received %d bytes less than requested from %s", last+1-first, url)
+			}
+			return nil
+		}
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) || attempt >= max(opts.attempts, 1) {
+			return err
+		}
+		l.Debug("download attempt failed", "url", url, "attempt", attempt, "offset", first, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// downloadRangeOnce requests the bytes from first to last of the url and writes them at the same offset of the file,
+// returning the number of bytes written.
+func downloadRangeOnce(ctx context.Context, url string, destinationFile *os.File, first, last int64, progress io.Writer) (n int64, err error) {
+	resp, err := httpGet(ctx, url, fmt.Sprintf("bytes=%d-%d", first, last))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err2 := resp.Body.Close()
+		err = errors.Join(err, err2)
+	}()
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, &httpStatusError{status: resp.Status}
+	}
+	w := io.NewOffsetWriter(destinationFile, first)
+	return io.Copy(w, io.TeeReader(io.LimitReader(resp.Body, last+1-first), progress))
+}
+
+// rangeSize returns the size of the file at the url if the server supports range requests for it, otherwise zero.
+func rangeSize(ctx context.Context, url string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, &httpStatusError{status: resp.Status}
+	}
+	if !acceptsRanges(resp) {
+		return 0, nil
+	}
+	return max(resp.ContentLength, 0), nil
+}
+
+// httpGet sends a GET request for the url, requesting the given byte range if it is not empty.
+func httpGet(ctx context.Context, url, byteRange string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// acceptsRanges returns true if the server supports range requests for the response.
+func acceptsRanges(resp *http.Response) bool {
+	return resp.Header.Get("Accept-Ranges") == "bytes"
+}
+
+// httpStatusError is returned when a server responds with an unexpected status, these are not retried.
+type httpStatusError struct {
+	status string
+}
+
+func (e *httpStatusError) Error() string {
+	return "bad HTTP status: " + e.status
+}
+
+// syncWriter serializes writes from parallel downloads to a single writer.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
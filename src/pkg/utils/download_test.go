@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic utility functions.
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestDownloadHTTP(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 1024*1024)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		opts        downloadOptions
+		interrupt   bool
+		minRequests int32
+	}{
+		{
+			name:        "single connection",
+			opts:        downloadOptions{connections: 1, attempts: 3},
+			minRequests: 1,
+		},
+		{
+			name:        "resume interrupted download",
+			opts:        downloadOptions{connections: 1, attempts: 3, retryDelay: time.Millisecond},
+			interrupt:   true,
+			minRequests: 2,
+		},
+		{
+			name:        "multiple connections",
+			opts:        downloadOptions{connections: 4, minPartSize: 100 * 1024, attempts: 3},
+			minRequests: 5,
+		},
+		{
+			name:        "resume interrupted parts",
+			opts:        downloadOptions{connections: 4, minPartSize: 100 * 1024, attempts: 3, retryDelay: time.Millisecond},
+			interrupt:   true,
+			minRequests: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests, interrupted atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests.Add(1)
+				if tt.interrupt && req.Method == http.MethodGet && interrupted.CompareAndSwap(0, 1) {
+					rw = &abortWriter{ResponseWriter: rw, remaining: 64 * 1024}
+				}
+				http.ServeContent(rw, req, "file", time.Time{}, bytes.NewReader(data))
+			}))
+			t.Cleanup(srv.Close)
+
+			dst := filepath.Join(t.TempDir(), "file")
+			f, err := os.Create(dst)
+			require.NoError(t, err)
+			err = downloadHTTP(testutil.TestContext(t), srv.URL+"/file", f, tt.opts)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			b, err := os.ReadFile(dst)
+			require.NoError(t, err)
+			require.Equal(t, data, b)
+			require.GreaterOrEqual(t, requests.Load(), tt.minRequests)
+		})
+	}
+}
+
+// abortWriter drops the connection after the remaining bytes of the response are written.
+type abortWriter struct {
+	http.ResponseWriter
+	remaining int
+}
+
+func (w *abortWriter) Write(p []byte) (int, error) {
+	if len(p) < w.remaining {
+		w.remaining -= len(p)
+		return w.ResponseWriter.Write(p)
+	}
+	if _, err := w.ResponseWriter.Write(p[:w.remaining]); err != nil {
+		return 0, err
+	}
+	w.ResponseWriter.(http.Flusher).Flush()
+	panic(http.ErrAbortHandler)
+}
+
+func TestDownloadHTTPBadStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "file"))
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	err = downloadHTTP(testutil.TestContext(t), srv.URL, f, downloadOptions{connections: 4, minPartSize: 1, attempts: 3})
+	require.EqualError(t, err, "bad HTTP status: 404 Not Found")
+}
+
+func TestDownloadOptionsPartCount(t *testing.T) {
+	t.Parallel()
+
+	opts := downloadOptions{connections: 4, minPartSize: 100}
+	require.Equal(t, 1, opts.partCount(0))
+	require.Equal(t, 1, opts.partCount(150))
+	require.Equal(t, 2, opts.partCount(250))
+	require.Equal(t, 4, opts.partCount(10000))
+	require.Equal(t, 1, downloadOptions{connections: 1, minPartSize: 100}.partCount(10000))
+}
@@ -21,6 +21,13 @@ import (
 
 // LoadOCIImage returns a v1.Image with the image ref specified from a location provided, or an error if the image cannot be found.
 func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
+	return LoadOCIImageForArch(imgPath, refInfo, "")
+}
+
+// LoadOCIImageForArch returns a v1.Image with the image ref and architecture specified from a location provided, or an
+// error if the image cannot be found. Images without a platform match any architecture, as do all images when the
+// architecture is empty.
+func LoadOCIImageForArch(imgPath string, refInfo transform.Image, arch string) (v1.Image, error) {
 	// Use the manifest within the index.json to load the specific image we want
 	layoutPath := layout.Path(imgPath)
 	imgIdx, err := layoutPath.ImageIndex()
@@ -34,6 +41,9 @@ func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
 
 	// Search through all the manifests within this package until we find the annotation that matches our ref
 	for _, manifest := range idxManifest.Manifests {
+		if arch != "" && manifest.Platform != nil && manifest.Platform.Architecture != arch {
+			continue
+		}
 		if manifest.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Reference ||
 			// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
 			(manifest.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io") {
@@ -42,6 +52,9 @@ func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
 		}
 	}
 
+	if arch != "" {
+		return nil, fmt.Errorf("unable to find image (%s) for architecture %s at the path (%s)", refInfo.Reference, arch, imgPath)
+	}
 	return nil, fmt.Errorf("unable to find image (%s) at the path (%s)", refInfo.Reference, imgPath)
 }
 
@@ -117,8 +130,15 @@ func SortImagesIndex(ociPath string) error {
 	if err != nil {
 		return err
 	}
+	// Images of multi-architecture packages can share a digest, so the name and platform keep the order deterministic.
 	slices.SortFunc(index.Manifests, func(a, b ocispec.Descriptor) int {
-		return strings.Compare(string(a.Digest), string(b.Digest))
+		if c := strings.Compare(string(a.Digest), string(b.Digest)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Annotations[ocispec.AnnotationBaseImageName], b.Annotations[ocispec.AnnotationBaseImageName]); c != 0 {
+			return c
+		}
+		return strings.Compare(platformArchitecture(a.Platform), platformArchitecture(b.Platform))
 	})
 	b, err = json.Marshal(index)
 	if err != nil {
@@ -126,3 +146,10 @@ func SortImagesIndex(ociPath string) error {
 	}
 	return os.WriteFile(indexPath, b, helpers.ReadWriteUser)
 }
+
+func platformArchitecture(platform *ocispec.Platform) string {
+	if platform == nil {
+		return ""
+	}
+	return platform.Architecture
+}
@@ -17,17 +17,24 @@ const (
 	tmpPathPrefix = "zarf-"
 )
 
-// MakeTempDir creates a temp directory with the zarf- prefix.
+// MakeTempDir creates a temp directory with the zarf- prefix. The name of the directory contains the ID of the lock
+// the current process holds, so that the directory can be found and removed by StaleTempDirs once the process exits
+// without removing it.
 func MakeTempDir(basePath string) (string, error) {
 	if basePath != "" {
 		if err := helpers.CreateDirectory(basePath, helpers.ReadWriteExecuteUser); err != nil {
 			return "", err
 		}
 	}
-	tmp, err := os.MkdirTemp(basePath, tmpPathPrefix)
+	lockID, err := tempDirLockID(basePath)
 	if err != nil {
 		return "", err
 	}
+	tmp, err := os.MkdirTemp(basePath, fmt.Sprintf("%s%s-", tmpPathPrefix, lockID))
+	if err != nil {
+		return "", err
+	}
+	TrackTempPath(tmp)
 	return tmp, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
@@ -42,7 +43,10 @@ func parseChecksum(src string) (string, string, error) {
 }
 
 // DownloadToFile downloads a given URL to the target filepath (including the cosign key if necessary).
+// HTTP downloads are stored in the download cache and reused by later downloads of the same URL or checksum.
 func DownloadToFile(ctx context.Context, src, dst, cosignKeyPath string) (err error) {
+	l := logger.From(ctx)
+
 	// check if the parsed URL has a checksum
 	// if so, remove it and use the checksum to validate the file
 	src, checksum, err := parseChecksum(src)
@@ -50,6 +54,32 @@ func DownloadToFile(ctx context.Context, src, dst, cosignKeyPath string) (err er
 		return err
 	}
 
+	parsed, err := url.Parse(src)
+	if err != nil {
+		return fmt.Errorf("unable to parse the URL: %s", src)
+	}
+
+	// Files downloaded with sget are not cached so that their signature is verified on every download.
+	var cache *DownloadCache
+	if parsed.Scheme != helpers.SGETURLScheme {
+		cache, err = DefaultDownloadCache()
+		if err != nil {
+			return err
+		}
+		hit, err := cache.Get(src, checksum, dst)
+		if err != nil {
+			// TODO(mkcp): Remove message on logger release
+			message.Debugf("Unable to use the cached download of %s: %s", src, err.Error())
+			l.Debug("unable to use cached download", "url", src, "error", err.Error())
+		}
+		if hit {
+			// TODO(mkcp): Remove message on logger release
+			message.Debugf("Using the cached download of %s", src)
+			l.Debug("using cached download", "url", src)
+			return nil
+		}
+	}
+
 	err = helpers.CreateDirectory(filepath.Dir(dst), helpers.ReadWriteExecuteUser)
 	if err != nil {
 		return fmt.Errorf(lang.ErrCreatingDir, filepath.Dir(dst), err.Error())
@@ -66,17 +96,19 @@ func DownloadToFile(ctx context.Context, src, dst, cosignKeyPath string) (err er
 		err = errors.Join(err, err2)
 	}(file)
 
-	parsed, err := url.Parse(src)
-	if err != nil {
-		return fmt.Errorf("unable to parse the URL: %s", src)
-	}
-	// If the source url starts with the sget protocol use that, otherwise do a typical GET call
-	if parsed.Scheme == helpers.SGETURLScheme {
+	// If the source url starts with the sget or s3 protocol use that, otherwise do a typical GET call
+	switch parsed.Scheme {
+	case helpers.SGETURLScheme:
 		err = Sget(ctx, src, cosignKeyPath, file)
 		if err != nil {
 			return fmt.Errorf("unable to download file with sget: %s: %w", src, err)
 		}
-	} else {
+	case S3URLScheme:
+		err = s3GetFile(ctx, src, file)
+		if err != nil {
+			return err
+		}
+	default:
 		err = httpGetFile(ctx, src, file)
 		if err != nil {
 			return err
@@ -94,16 +126,46 @@ func DownloadToFile(ctx context.Context, src, dst, cosignKeyPath string) (err er
 		}
 	}
 
+	if cache != nil {
+		if err := cache.Put(src, dst); err != nil {
+			// TODO(mkcp): Remove message on logger release
+			message.Debugf("Unable to cache the download of %s: %s", src, err.Error())
+			l.Debug("unable to cache download", "url", src, "error", err.Error())
+		}
+	}
+
 	return nil
 }
 
-func httpGetFile(ctx context.Context, url string, destinationFile *os.File) (err error) {
+// httpGetFile downloads the url to the destination file. Interrupted downloads are resumed from the last received byte
+// when the server supports range requests, and large files are downloaded over multiple connections when configured.
+func httpGetFile(ctx context.Context, url string, destinationFile *os.File) error {
+	opts := downloadOptions{
+		connections: config.CommonOptions.DownloadConnections,
+		minPartSize: minDownloadPartSize,
+		attempts:    config.ZarfDefaultRetries,
+		retryDelay:  downloadRetryDelay,
+	}
+	return downloadHTTP(ctx, url, destinationFile, opts)
+}
+
+func downloadHTTP(ctx context.Context, url string, destinationFile *os.File, opts downloadOptions) (err error) {
 	l := logger.From(ctx)
 	l.Info("download start", "url", url)
 	start := time.Now()
 
+	if opts.connections > 1 {
+		size, err := rangeSize(ctx, url)
+		if err != nil {
+			l.Debug("unable to get the size of the download, downloading over a single connection", "url", url, "error", err)
+		}
+		if parts := opts.partCount(size); parts > 1 {
+			return downloadParts(ctx, url, destinationFile, size, parts, opts)
+		}
+	}
+
 	// Get the data
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url, "")
 	if err != nil {
 		return fmt.Errorf("unable to download the file %s", url)
 	}
@@ -123,7 +185,14 @@ func httpGetFile(ctx context.Context, url string, destinationFile *os.File) (err
 	progressBar := message.NewProgressBar(resp.ContentLength, title)
 	reader := io.TeeReader(resp.Body, progressBar)
 	// Copy response body to file
-	if _, err = io.Copy(destinationFile, reader); err != nil {
+	written, err := io.Copy(destinationFile, reader)
+	if err != nil && acceptsRanges(resp) && resp.ContentLength > 0 {
+		// TODO(mkcp): Remove message on logger release
+		message.Warnf("Download of %s was interrupted, resuming from byte %d: %s", url, written, err.Error())
+		l.Warn("download interrupted, resuming", "url", url, "offset", written, "error", err)
+		err = downloadRange(ctx, url, destinationFile, written, resp.ContentLength-1, progressBar, opts)
+	}
+	if err != nil {
 		progressBar.Failf("Unable to save the file %s: %s", destinationFile.Name(), err.Error())
 		return fmt.Errorf("unable to save the file %s: %w", destinationFile.Name(), err)
 	}
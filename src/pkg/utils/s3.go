@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions.
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+const (
+	// S3URLScheme is the scheme of files stored in S3 compatible object storage.
+	S3URLScheme = "s3"
+	// defaultS3Region is the region used to look up the region of a bucket when no region is configured.
+	defaultS3Region = "us-east-1"
+)
+
+// parseS3URL returns the bucket and key of an s3://bucket/key URL.
+func parseS3URL(src string) (string, string, error) {
+	parsed, err := url.Parse(src)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to parse the URL: %s", src)
+	}
+	if parsed.Scheme != S3URLScheme {
+		return "", "", fmt.Errorf("%s is not an %s:// URL", src, S3URLScheme)
+	}
+	key := strings.TrimPrefix(parsed.Path, "/")
+	if parsed.Host == "" || key == "" {
+		return "", "", fmt.Errorf("%s must be in the form %s://bucket/key", src, S3URLScheme)
+	}
+	return parsed.Host, key, nil
+}
+
+// s3GetFile downloads the object at the s3:// URL to the destination file.
+// Credentials and the endpoint are resolved from the standard AWS chain, objects are downloaded in parts over multiple connections.
+func s3GetFile(ctx context.Context, src string, destinationFile *os.File) error {
+	bucket, key, err := parseS3URL(src)
+	if err != nil {
+		return err
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to load the AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+		region, err := manager.GetBucketRegion(ctx, newS3Client(cfg), bucket)
+		if err != nil {
+			logger.From(ctx).Debug("unable to look up the bucket region", "bucket", bucket, "error", err)
+		} else {
+			cfg.Region = region
+		}
+	}
+	return downloadS3(ctx, newS3Client(cfg), bucket, key, destinationFile)
+}
+
+// newS3Client returns an S3 client for the configuration.
+// Custom endpoints, set with AWS_ENDPOINT_URL or AWS_ENDPOINT_URL_S3, are addressed by path as most S3 compatible stores do not support virtual hosted buckets.
+func newS3Client(cfg aws.Config) *s3.Client {
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if o.BaseEndpoint != nil {
+			o.UsePathStyle = true
+		}
+	})
+}
+
+// downloadS3 downloads the object to the destination file and validates it against the SHA256 checksum stored with the object, if any.
+func downloadS3(ctx context.Context, client *s3.Client, bucket, key string, destinationFile *os.File) error {
+	l := logger.From(ctx)
+	src := fmt.Sprintf("%s://%s/%s", S3URLScheme, bucket, key)
+	l.Info("download start", "url", src)
+	start := time.Now()
+
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(key),
+		ChecksumMode: s3types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get the object %s: %w", src, err)
+	}
+
+	// TODO(mkcp): Remove message on logger release
+	title := fmt.Sprintf("Downloading %s", path.Base(key))
+	progressBar := message.NewProgressBar(aws.ToInt64(head.ContentLength), title)
+	w := &progressWriterAt{w: destinationFile, progress: &syncWriter{w: progressBar}}
+
+	downloader := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = minDownloadPartSize
+	})
+	_, err = downloader.Download(ctx, w, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		// Parts are requested from the version that was inspected so that an overwrite during the download is not mixed in.
+		VersionId: head.VersionId,
+		IfMatch:   head.ETag,
+	})
+	if err != nil {
+		progressBar.Failf("Unable to save the file %s: %s", destinationFile.Name(), err.Error())
+		return fmt.Errorf("unable to save the file %s: %w", destinationFile.Name(), err)
+	}
+
+	if err := verifyS3Checksum(destinationFile.Name(), aws.ToString(head.ChecksumSHA256)); err != nil {
+		progressBar.Failf("Unable to validate the file %s: %s", destinationFile.Name(), err.Error())
+		return fmt.Errorf("unable to validate the object %s: %w", src, err)
+	}
+
+	progressBar.Successf("Downloaded %s", src)
+	l.Debug("download successful", "url", src, "size", aws.ToInt64(head.ContentLength), "duration", time.Since(start))
+	return nil
+}
+
+// verifyS3Checksum compares the file to the base64 encoded SHA256 checksum stored with an S3 object.
+// Objects uploaded without a checksum, or in parts with a checksum of the part checksums, are not validated.
+func verifyS3Checksum(file, checksum string) error {
+	if checksum == "" || strings.Contains(checksum, "-") {
+		return nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	received := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	if received != checksum {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", checksum, received)
+	}
+	return nil
+}
+
+// progressWriterAt reports the bytes written at any offset of a file to a progress writer.
+type progressWriterAt struct {
+	w        io.WriterAt
+	progress io.Writer
+}
+
+func (p *progressWriterAt) WriteAt(b []byte, off int64) (int, error) {
+	n, err := p.w.WriteAt(b, off)
+	if n > 0 {
+		_, _ = p.progress.Write(b[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic utility functions.
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseS3URL(t *testing.T) {
+	t.Parallel()
+
+	bucket, key, err := parseS3URL("s3://zarf-packages/releases/zarf-package-foo-amd64.tar.zst")
+	require.NoError(t, err)
+	require.Equal(t, "zarf-packages", bucket)
+	require.Equal(t, "releases/zarf-package-foo-amd64.tar.zst", key)
+
+	_, _, err = parseS3URL("s3://zarf-packages")
+	require.EqualError(t, err, "s3://zarf-packages must be in the form s3://bucket/key")
+	_, _, err = parseS3URL("https://zarf-packages/foo.tar.zst")
+	require.EqualError(t, err, "https://zarf-packages/foo.tar.zst is not an s3:// URL")
+}
+
+func TestDownloadS3(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 1024*1024)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+
+	tests := []struct {
+		name        string
+		checksum    string
+		expectedErr bool
+	}{
+		{
+			name: "no checksum",
+		},
+		{
+			name:     "matching checksum",
+			checksum: base64.StdEncoding.EncodeToString(sum[:]),
+		},
+		{
+			name:     "composite checksum",
+			checksum: "Zm9v-2",
+		},
+		{
+			name:        "mismatched checksum",
+			checksum:    base64.StdEncoding.EncodeToString(make([]byte, sha256.Size)),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/zarf-packages/zarf-package-foo.tar.zst" {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("ETag", `"foo"`)
+				if tt.checksum != "" {
+					w.Header().Set("x-amz-checksum-sha256", tt.checksum)
+				}
+				http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+			}))
+			t.Cleanup(srv.Close)
+			client := s3.New(s3.Options{
+				BaseEndpoint: aws.String(srv.URL),
+				UsePathStyle: true,
+				Region:       "us-east-1",
+				Credentials:  aws.AnonymousCredentials{},
+			})
+
+			dst := filepath.Join(t.TempDir(), "zarf-package-foo.tar.zst")
+			f, err := os.Create(dst)
+			require.NoError(t, err)
+			defer f.Close()
+			err = downloadS3(testutil.TestContext(t), client, "zarf-packages", "zarf-package-foo.tar.zst", f)
+			if tt.expectedErr {
+				require.ErrorContains(t, err, "checksum mismatch")
+				return
+			}
+			require.NoError(t, err)
+			b, err := os.ReadFile(dst)
+			require.NoError(t, err)
+			require.Equal(t, data, b)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions.
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// tempDirLockSuffix is the suffix of the lock files held by the Zarf processes that create temp directories.
+const tempDirLockSuffix = ".lock"
+
+// tempDirLockGracePeriod is how long a lock file is kept before it is locked, as it is created before it is locked.
+const tempDirLockGracePeriod = time.Minute
+
+var (
+	// tempDirPattern matches the names of the temp directories created by MakeTempDir. Temp directories created by
+	// older versions of Zarf don't contain the ID of a lock.
+	tempDirPattern = regexp.MustCompile(`^` + tmpPathPrefix + `(?:(\d+)-)?\d+$`)
+	// tempDirLockPattern matches the names of the lock files held by the Zarf processes that create temp directories.
+	tempDirLockPattern = regexp.MustCompile(`^` + tmpPathPrefix + `(\d+)` + regexp.QuoteMeta(tempDirLockSuffix) + `$`)
+)
+
+var (
+	tempPathsMu sync.Mutex
+	tempPaths   []string
+
+	tempDirLocksMu sync.Mutex
+	tempDirLocks   = map[string]tempDirLock{}
+)
+
+// tempDirLock is the lock file the current process holds in a base path. The lock is kept referenced for the lifetime
+// of the process, as the file it holds open is closed, and the lock released, when the lock is garbage collected.
+type tempDirLock struct {
+	id   string
+	lock *flock.Flock
+}
+
+// TrackTempPath registers a temporary path to be removed by RemoveTempPaths.
+func TrackTempPath(path string) {
+	tempPathsMu.Lock()
+	defer tempPathsMu.Unlock()
+	tempPaths = append(tempPaths, path)
+}
+
+// RemoveTempPaths removes the temporary paths created by this process. It is used to clean up when Zarf exits before
+// the paths are removed by the functions that created them, such as when Zarf is interrupted.
+func RemoveTempPaths() error {
+	tempPathsMu.Lock()
+	defer tempPathsMu.Unlock()
+	var errs []error
+	for _, path := range tempPaths {
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	tempPaths = nil
+	return errors.Join(errs...)
+}
+
+// tempDirLockID returns the ID of the lock file the current process holds in the base path, creating and locking it
+// the first time. The lock is held until the process exits, also when it crashes, so that the temp directories of a
+// process are known to be in use no matter which PID namespace or container the process runs in.
+func tempDirLockID(basePath string) (string, error) {
+	if basePath == "" {
+		basePath = os.TempDir()
+	}
+	tempDirLocksMu.Lock()
+	defer tempDirLocksMu.Unlock()
+	if l, ok := tempDirLocks[basePath]; ok {
+		return l.id, nil
+	}
+	f, err := os.CreateTemp(basePath, tmpPathPrefix+"*"+tempDirLockSuffix)
+	if err != nil {
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	// The lock is never released, the operating system releases it when the process exits.
+	lock := flock.New(f.Name())
+	locked, err := lock.TryLock()
+	if err != nil {
+		return "", fmt.Errorf("unable to lock %s: %w", f.Name(), err)
+	}
+	if !locked {
+		return "", fmt.Errorf("unable to lock %s", f.Name())
+	}
+	id := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f.Name()), tmpPathPrefix), tempDirLockSuffix)
+	tempDirLocks[basePath] = tempDirLock{id: id, lock: lock}
+	return id, nil
+}
+
+// lockReleased returns true if the lock file is not held by any process.
+func lockReleased(path string) bool {
+	lock := flock.New(path)
+	locked, err := lock.TryLock()
+	if err != nil || !locked {
+		return false
+	}
+	// Ignore the error as the lock was only taken to check that no process holds it.
+	_ = lock.Unlock()
+	return true
+}
+
+// StaleTempDirs returns the temp directories in the base path left behind by Zarf processes that are no longer
+// running, which no longer hold the lock the directories are named after. Temp directories without a lock file, such
+// as the ones created by older versions of Zarf, are stale when they were not modified within maxAge.
+func StaleTempDirs(basePath string, maxAge time.Duration) ([]string, error) {
+	if basePath == "" {
+		basePath = os.TempDir()
+	}
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	locks := map[string]bool{}
+	for _, entry := range entries {
+		if match := tempDirLockPattern.FindStringSubmatch(entry.Name()); match != nil && entry.Type().IsRegular() {
+			locks[match[1]] = true
+		}
+	}
+	stale := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		match := tempDirPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if id := match[1]; id != "" && locks[id] {
+			if !lockReleased(filepath.Join(basePath, tmpPathPrefix+id+tempDirLockSuffix)) {
+				continue
+			}
+		} else {
+			info, err := entry.Info()
+			if err != nil || time.Since(info.ModTime()) < maxAge {
+				continue
+			}
+		}
+		stale = append(stale, filepath.Join(basePath, entry.Name()))
+	}
+	return stale, nil
+}
+
+// RemoveStaleTempDirs removes the stale temp directories in the base path and the lock files no process holds anymore,
+// and returns the directories it removed.
+func RemoveStaleTempDirs(basePath string, maxAge time.Duration) ([]string, error) {
+	if basePath == "" {
+		basePath = os.TempDir()
+	}
+	dirs, err := StaleTempDirs(basePath, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	removed := []string{}
+	var errs []error
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, dir)
+	}
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		return removed, errors.Join(append(errs, err)...)
+	}
+	for _, entry := range entries {
+		if !tempDirLockPattern.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < tempDirLockGracePeriod {
+			continue
+		}
+		path := filepath.Join(basePath, entry.Name())
+		if !lockReleased(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return removed, errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleTempDirs(t *testing.T) {
+	t.Parallel()
+
+	basePath := t.TempDir()
+	current, err := MakeTempDir(basePath)
+	require.NoError(t, err)
+
+	old := time.Now().Add(-48 * time.Hour)
+	// The lock of a process that is still running, which can run in another container or PID namespace.
+	runningLock := filepath.Join(basePath, "zarf-111111.lock")
+	require.NoError(t, os.WriteFile(runningLock, nil, 0o600))
+	lock := flock.New(runningLock)
+	locked, err := lock.TryLock()
+	require.NoError(t, err)
+	require.True(t, locked)
+	t.Cleanup(func() {
+		// Ignore the error as the lock only serves the test.
+		_ = lock.Close()
+	})
+	// The lock of a process that exited, which the operating system released.
+	exitedLock := filepath.Join(basePath, "zarf-222222.lock")
+	require.NoError(t, os.WriteFile(exitedLock, nil, 0o600))
+	require.NoError(t, os.Chtimes(exitedLock, old, old))
+
+	running := filepath.Join(basePath, "zarf-111111-123456")
+	exited := filepath.Join(basePath, "zarf-222222-123456")
+	oldLegacy := filepath.Join(basePath, "zarf-234567")
+	newLegacy := filepath.Join(basePath, "zarf-345678")
+	oldLegacyPID := filepath.Join(basePath, "zarf-333333-456789")
+	for _, dir := range []string{running, exited, oldLegacy, newLegacy, oldLegacyPID, filepath.Join(basePath, "zarf-sbom"), filepath.Join(basePath, "other")} {
+		require.NoError(t, os.Mkdir(dir, 0o700))
+	}
+	for _, dir := range []string{running, oldLegacy, oldLegacyPID} {
+		require.NoError(t, os.Chtimes(dir, old, old))
+	}
+
+	stale, err := StaleTempDirs(basePath, 24*time.Hour)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{exited, oldLegacy, oldLegacyPID}, stale)
+
+	removed, err := RemoveStaleTempDirs(basePath, time.Hour)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{exited, oldLegacy, oldLegacyPID}, removed)
+	require.DirExists(t, current)
+	require.DirExists(t, running)
+	require.DirExists(t, newLegacy)
+	require.FileExists(t, runningLock)
+	require.NoFileExists(t, exitedLock)
+
+	removed, err = RemoveStaleTempDirs(basePath, 0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{newLegacy}, removed)
+	require.DirExists(t, current)
+	require.DirExists(t, running)
+
+	stale, err = StaleTempDirs(filepath.Join(basePath, "missing"), 0)
+	require.NoError(t, err)
+	require.Empty(t, stale)
+}
+
+func TestTempDirLockSurvivesGC(t *testing.T) {
+	t.Parallel()
+
+	basePath := t.TempDir()
+	id, err := tempDirLockID(basePath)
+	require.NoError(t, err)
+	runtime.GC()
+	runtime.GC()
+	require.False(t, lockReleased(filepath.Join(basePath, tmpPathPrefix+id+tempDirLockSuffix)))
+}
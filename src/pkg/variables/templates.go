@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
@@ -51,9 +52,6 @@ func (vc *VariableConfig) GetAllTemplates() map[string]*TextTemplate {
 
 // ReplaceTextTemplate loads a file from a given path, replaces text in it and writes it back in place.
 func (vc *VariableConfig) ReplaceTextTemplate(path string) (err error) {
-	templateRegex := fmt.Sprintf("###%s_[A-Z0-9_]+###", strings.ToUpper(vc.templatePrefix))
-	templateMap := vc.GetAllTemplates()
-
 	textFile, err := os.Open(path)
 	if err != nil {
 		return err
@@ -63,10 +61,33 @@ func (vc *VariableConfig) ReplaceTextTemplate(path string) (err error) {
 		err = errors.Join(err, err2)
 	}()
 
+	text, err := vc.replaceTemplates(textFile)
+	if err != nil {
+		return err
+	}
+
+	// NOTE(mkcp): The extra if err != nil is not necessary, but is here to be explicit
+	err = os.WriteFile(path, []byte(text), helpers.ReadWriteUser)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceTemplates replaces the templates in the given text.
+func (vc *VariableConfig) ReplaceTemplates(text string) (string, error) {
+	return vc.replaceTemplates(strings.NewReader(text))
+}
+
+// replaceTemplates reads text line by line and replaces the templates in it.
+func (vc *VariableConfig) replaceTemplates(r io.Reader) (string, error) {
+	templateRegex := fmt.Sprintf("###%s_[A-Z0-9_]+###", strings.ToUpper(vc.templatePrefix))
+	templateMap := vc.GetAllTemplates()
+
 	// This regex takes a line and parses the text before and after a discovered template: https://regex101.com/r/ilUxAz/1
 	regexTemplateLine := regexp.MustCompile(fmt.Sprintf("(?P<preTemplate>.*?)(?P<template>%s)(?P<postTemplate>.*)", templateRegex))
 
-	fileScanner := bufio.NewScanner(textFile)
+	fileScanner := bufio.NewScanner(r)
 
 	// Set the buffer to 1 MiB to handle long lines (i.e. base64 text in a secret)
 	// 1 MiB is around the documented maximum size for secrets and configmaps
@@ -134,10 +155,5 @@ func (vc *VariableConfig) ReplaceTextTemplate(path string) (err error) {
 		}
 	}
 
-	// NOTE(mkcp): The extra if err != nil is not necessary, but is here to be explicit
-	err = os.WriteFile(path, []byte(text), helpers.ReadWriteUser)
-	if err != nil {
-		return err
-	}
-	return nil
+	return text, nil
 }
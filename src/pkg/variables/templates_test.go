@@ -157,3 +157,19 @@ func TestReplaceTextTemplate(t *testing.T) {
 		}
 	}
 }
+
+func TestReplaceTemplates(t *testing.T) {
+	vc := VariableConfig{
+		templatePrefix: "PREFIX",
+		setVariableMap: SetVariableMap{
+			"REPLACE_ME": {Value: "VAR_REPLACED"},
+		},
+		constants: []v1alpha1.Constant{{Name: "REPLACE_ME", Value: "CONST_REPLACED"}},
+		applicationTemplates: map[string]*TextTemplate{
+			"###PREFIX_APP_REPLACE_ME###": {Value: "APP_REPLACED"},
+		},
+	}
+	got, err := vc.ReplaceTemplates(start)
+	require.NoError(t, err)
+	require.Equal(t, simple, got)
+}
@@ -20,6 +20,27 @@ func (vc *VariableConfig) GetSetVariable(name string) (*v1alpha1.SetVariable, bo
 	return variable, ok
 }
 
+// GetSetVariables gets the values of all variables set within a VariableConfig by their name
+func (vc *VariableConfig) GetSetVariables() map[string]string {
+	values := map[string]string{}
+	for name, variable := range vc.setVariableMap {
+		values[name] = variable.Value
+	}
+	return values
+}
+
+// GetNonSensitiveSetVariables gets the values of the variables set within a VariableConfig that are not sensitive by their name
+func (vc *VariableConfig) GetNonSensitiveSetVariables() map[string]string {
+	values := map[string]string{}
+	for name, variable := range vc.setVariableMap {
+		if variable.Sensitive {
+			continue
+		}
+		values[name] = variable.Value
+	}
+	return values
+}
+
 // PopulateVariables handles setting the active variables within a VariableConfig's SetVariableMap
 func (vc *VariableConfig) PopulateVariables(variables []v1alpha1.InteractiveVariable, presetVariables map[string]string) error {
 	for name, value := range presetVariables {
@@ -119,6 +119,20 @@ func TestPopulateVariables(t *testing.T) {
 	}
 }
 
+func TestGetNonSensitiveSetVariables(t *testing.T) {
+	t.Parallel()
+
+	vc := VariableConfig{setVariableMap: SetVariableMap{}}
+	err := vc.PopulateVariables([]v1alpha1.InteractiveVariable{
+		{Variable: v1alpha1.Variable{Name: "ENV"}, Default: "dev"},
+		{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}},
+	}, map[string]string{"PASSWORD": "secret"})
+	require.NoError(t, err)
+	vc.SetVariable("FROM_ACTION", "value", false, false, "")
+	vc.SetVariable("TOKEN_FROM_ACTION", "token", true, false, "")
+	require.Equal(t, map[string]string{"ENV": "dev", "FROM_ACTION": "value"}, vc.GetNonSensitiveSetVariables())
+}
+
 func TestCheckVariablePattern(t *testing.T) {
 	type test struct {
 		vc         VariableConfig
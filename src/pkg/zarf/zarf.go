@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zarf is the entrypoint for creating, deploying and removing Zarf packages from Go programs.
+package zarf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Client creates, deploys and removes Zarf packages.
+type Client struct {
+	logger  *slog.Logger
+	cluster *cluster.Cluster
+}
+
+// Option is a function that modifies the client.
+type Option func(*Client)
+
+// WithLogger sets the logger that the client logs to, logs are discarded when it is not set.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithCluster sets the cluster that packages are deployed to and removed from.
+// The cluster of the current kube context is used when it is not set.
+func WithCluster(c *cluster.Cluster) Option {
+	return func(cl *Client) {
+		cl.cluster = c
+	}
+}
+
+/*
+New creates a new client with the provided options.
+
+Note: Zarf writes its progress to the terminal unless it is configured otherwise, creating a client disables this output
+for the whole process so that the client only reports through its logger.
+*/
+func New(opts ...Option) *Client {
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	message.InitializePTerm(io.Discard)
+	message.NoProgress = true
+	if config.CommonOptions.CachePath == "" {
+		config.CommonOptions.CachePath = config.ZarfDefaultCachePath
+	}
+	if config.CommonOptions.OCIConcurrency == 0 {
+		config.CommonOptions.OCIConcurrency = 3
+	}
+	return c
+}
+
+// CreateOptions are the options for Create.
+type CreateOptions struct {
+	// BaseDir is the directory that contains the zarf.yaml of the package
+	BaseDir string
+	// Output is the directory or OCI reference the package is written to
+	Output             string
+	Flavor             string
+	SetVariables       map[string]string
+	RegistryOverrides  map[string]string
+	SigningKeyPath     string
+	SigningKeyPassword string
+	MaxPackageSizeMB   int
+	SkipSBOM           bool
+	// DifferentialPackagePath is the path of a previously built package to create a differential package against
+	DifferentialPackagePath string
+}
+
+// Create creates a package from the zarf.yaml in the base directory.
+// lint.ZarfSchema must be set to the Zarf schema so that the package definition can be validated.
+func (c *Client) Create(ctx context.Context, opt CreateOptions) error {
+	if lint.ZarfSchema == nil {
+		return errors.New("the Zarf schema must be set with lint.ZarfSchema to create packages")
+	}
+	if opt.BaseDir == "" {
+		return errors.New("the base directory of the package is required")
+	}
+	output := opt.Output
+	if output == "" {
+		output = "."
+	}
+	createOpt := packager2.CreateOptions{
+		Flavor:                  opt.Flavor,
+		RegistryOverrides:       opt.RegistryOverrides,
+		SigningKeyPath:          opt.SigningKeyPath,
+		SigningKeyPassword:      opt.SigningKeyPassword,
+		SetVariables:            helpers.TransformMapKeys(opt.SetVariables, strings.ToUpper),
+		MaxPackageSizeMB:        opt.MaxPackageSizeMB,
+		SkipSBOM:                opt.SkipSBOM,
+		Output:                  output,
+		DifferentialPackagePath: opt.DifferentialPackagePath,
+		Concurrency:             1,
+	}
+	err := packager2.Create(c.context(ctx), opt.BaseDir, createOpt)
+	if err != nil {
+		return fmt.Errorf("failed to create package: %w", err)
+	}
+	return nil
+}
+
+// DeployOptions are the options for Deploy.
+type DeployOptions struct {
+	// Source is the path, URL or OCI reference of the package
+	Source string
+	// Shasum is the SHA256 checksum of the package, it is required for packages downloaded over HTTP
+	Shasum string
+	// Components are the optional components to deploy, the default components are deployed when it is empty
+	Components   []string
+	SetVariables map[string]string
+	// ValuesProfiles are the values profiles of the chart values layers to apply
+	ValuesProfiles []string
+	// Timeout for performing Helm operations, defaults to the Zarf default timeout
+	Timeout time.Duration
+	// Retries is the number of retries for operations like image pushes or Helm installs, defaults to the Zarf default
+	Retries                 int
+	AdoptExistingResources  bool
+	ScopedCredentials       bool
+	SkipSignatureValidation bool
+	PublicKeyPath           string
+	VerificationPolicy      types.VerificationPolicy
+	// AttestationKeyPath is the public key the attestations of a package in a registry must be signed with
+	AttestationKeyPath string
+	// ValuesOverrides is a map of component names to chart names containing Helm Chart values to override values on deploy
+	ValuesOverrides map[string]map[string]map[string]interface{}
+}
+
+// Deploy deploys a package to the cluster.
+// Nothing is prompted during the deployment, variables that are not set use their defaults.
+func (c *Client) Deploy(ctx context.Context, opt DeployOptions) error {
+	if opt.Source == "" {
+		return errors.New("the package source is required")
+	}
+	ctx = c.context(ctx)
+	// There is nobody to answer prompts when Zarf is used as a library.
+	config.CommonOptions.Confirm = true
+
+	timeout := opt.Timeout
+	if timeout == 0 {
+		timeout = config.ZarfDefaultTimeout
+	}
+	retries := opt.Retries
+	if retries == 0 {
+		retries = config.ZarfDefaultRetries
+	}
+	pkgConfig := types.PackagerConfig{
+		PkgOpts: types.ZarfPackageOptions{
+			PackageSource:           opt.Source,
+			Shasum:                  opt.Shasum,
+			OptionalComponents:      strings.Join(opt.Components, ","),
+			SetVariables:            helpers.TransformMapKeys(opt.SetVariables, strings.ToUpper),
+			Retries:                 retries,
+			SkipSignatureValidation: opt.SkipSignatureValidation,
+			PublicKeyPath:           opt.PublicKeyPath,
+			VerificationPolicy:      opt.VerificationPolicy,
+			AttestationKeyPath:      opt.AttestationKeyPath,
+		},
+		DeployOpts: types.ZarfDeployOptions{
+			AdoptExistingResources: opt.AdoptExistingResources,
+			Timeout:                timeout,
+			ScopedCredentials:      opt.ScopedCredentials,
+			ValuesProfiles:         opt.ValuesProfiles,
+			ValuesOverridesMap:     opt.ValuesOverrides,
+		},
+	}
+	mods := []packager.Modifier{packager.WithContext(ctx)}
+	if c.cluster != nil {
+		mods = append(mods, packager.WithCluster(c.cluster))
+	}
+	pkgClient, err := packager.New(&pkgConfig, mods...)
+	if err != nil {
+		return err
+	}
+	defer pkgClient.ClearTempPaths()
+	if err := pkgClient.Deploy(ctx); err != nil {
+		return fmt.Errorf("failed to deploy package: %w", err)
+	}
+	return nil
+}
+
+// RemoveOptions are the options for Remove.
+type RemoveOptions struct {
+	// Source is the name of the deployed package, or the path, URL or OCI reference of the package
+	Source string
+	// Components are the components to remove, every deployed component is removed when it is empty
+	Components              []string
+	SkipSignatureValidation bool
+	PublicKeyPath           string
+	VerificationPolicy      types.VerificationPolicy
+	// SetVariables are the package variables used to template the remove actions
+	SetVariables map[string]string
+}
+
+// Remove removes a deployed package from the cluster.
+func (c *Client) Remove(ctx context.Context, opt RemoveOptions) error {
+	if opt.Source == "" {
+		return errors.New("the package source is required")
+	}
+	ctx = c.context(ctx)
+	cl := c.cluster
+	if cl == nil {
+		var err error
+		cl, err = cluster.NewCluster()
+		if err != nil {
+			return err
+		}
+	}
+	removeOpt := packager2.RemoveOptions{
+		Source:  opt.Source,
+		Cluster: cl,
+		Filter: filters.Combine(
+			filters.ByLocalOS(runtime.GOOS),
+			filters.BySelectState(strings.Join(opt.Components, ",")),
+		),
+		SkipSignatureValidation: opt.SkipSignatureValidation,
+		PublicKeyPath:           opt.PublicKeyPath,
+		VerificationPolicy:      opt.VerificationPolicy,
+		SetVariables:            helpers.TransformMapKeys(opt.SetVariables, strings.ToUpper),
+	}
+	err := packager2.Remove(ctx, removeOpt)
+	if err != nil {
+		return fmt.Errorf("failed to remove package: %w", err)
+	}
+	return nil
+}
+
+// context returns a context that logs to the logger of the client.
+func (c *Client) context(ctx context.Context) context.Context {
+	if c.logger == nil {
+		return ctx
+	}
+	ctx = logger.WithContext(ctx, c.logger)
+	return logger.WithLoggingEnabled(ctx, true)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zarf
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../zarf.schema.json")
+
+	baseDir := t.TempDir()
+	definition := `kind: ZarfPackageConfig
+metadata:
+  name: sdk
+  version: 0.0.1
+components:
+  - name: files
+    required: true
+    files:
+      - source: data.txt
+        target: data.txt
+`
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "zarf.yaml"), []byte(definition), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "data.txt"), []byte("hello world"), 0o600))
+
+	buf := &bytes.Buffer{}
+	client := New(WithLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	err := client.Create(ctx, CreateOptions{})
+	require.EqualError(t, err, "the base directory of the package is required")
+
+	output := t.TempDir()
+	err = client.Create(ctx, CreateOptions{BaseDir: baseDir, Output: output, SkipSBOM: true})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(output, fmt.Sprintf("zarf-package-sdk-%s-0.0.1.tar.zst", config.GetArch())))
+	require.NotEmpty(t, buf.String())
+
+	err = client.Deploy(ctx, DeployOptions{})
+	require.EqualError(t, err, "the package source is required")
+	err = client.Remove(ctx, RemoveOptions{})
+	require.EqualError(t, err, "the package source is required")
+}
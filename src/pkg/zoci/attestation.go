@@ -0,0 +1,341 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	sslDSSE "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+const (
+	// AttestationArtifactType is the artifact type of the manifests that attach attestations to a package.
+	AttestationArtifactType = "application/vnd.dsse.envelope.v1+json"
+	// AttestationBundleMediaType is the media type of the layer that holds the bundle of the certificate and the Rekor
+	// transparency log entry of a keyless attestation signature.
+	AttestationBundleMediaType = "application/vnd.dev.zarf.attestation.bundle.v1+json"
+	// AttestationPredicateTypeAnnotation is the manifest annotation that holds the predicate type of an attestation.
+	AttestationPredicateTypeAnnotation = "dev.zarf.attestation.predicate-type"
+	// InTotoPayloadType is the DSSE payload type of in-toto statements.
+	InTotoPayloadType = "application/vnd.in-toto+json"
+	// InTotoStatementType is the type of in-toto v1 statements.
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+	// SBOMPredicateType is the predicate type of SBOM attestations, which hold the Syft SBOM of an image in the package.
+	SBOMPredicateType = "https://syft.dev/bom"
+	// ProvenancePredicateType is the predicate type of SLSA provenance attestations.
+	ProvenancePredicateType = "https://slsa.dev/provenance/v1"
+)
+
+// Predicate is the content of an attestation.
+type Predicate struct {
+	// Type of the predicate, such as SBOMPredicateType or ProvenancePredicateType.
+	Type string
+	// Name of the file the predicate was read from, if any.
+	Name string
+	// Content of the predicate as JSON.
+	Content json.RawMessage
+}
+
+// Attestation is an attestation attached to a package.
+type Attestation struct {
+	// PredicateType is the type of the predicate of the attestation.
+	PredicateType string
+	// Name of the file the predicate was read from, if any.
+	Name string
+	// Digest of the manifest the attestation is attached with.
+	Digest digest.Digest
+}
+
+// InTotoSubject is the subject of an in-toto statement.
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// InTotoStatement is an in-toto v1 statement.
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// dsseEnvelope is a DSSE envelope.
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures,omitempty"`
+}
+
+// dsseSignature is a signature of a DSSE envelope.
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// signStatementFunc signs an in-toto statement and returns its DSSE envelope and, for keyless signatures, the bundle of
+// the certificate and the transparency log entry of the signature.
+type signStatementFunc func(statement []byte) (envelope []byte, bundle []byte, err error)
+
+// verifyEnvelopeFunc verifies the signature of a DSSE envelope with the bundle it was attached with, if any.
+type verifyEnvelopeFunc func(envelope []byte, bundle []byte) error
+
+// AttachAttestations signs an in-toto statement about the package for each of the predicates and attaches them to the
+// package manifest as OCI referrers. The statements are signed as DSSE envelopes so they can be verified with cosign.
+func (r *Remote) AttachAttestations(ctx context.Context, signer signature.Signer, predicates []Predicate) ([]Attestation, error) {
+	dsseSigner := dsse.WrapSigner(signer, InTotoPayloadType)
+	return r.attachAttestations(ctx, predicates, func(statement []byte) ([]byte, []byte, error) {
+		envelope, err := dsseSigner.SignMessage(bytes.NewReader(statement))
+		return envelope, nil, err
+	})
+}
+
+// AttachKeylessAttestations signs an in-toto statement about the package for each of the predicates keyless and
+// attaches them to the package manifest as OCI referrers. The bundle with the certificate and the Rekor transparency log
+// entry of each signature is attached with the DSSE envelope, so that it can be verified offline.
+func (r *Remote) AttachKeylessAttestations(ctx context.Context, signer *utils.KeylessSigner, predicates []Predicate) ([]Attestation, error) {
+	return r.attachAttestations(ctx, predicates, func(statement []byte) ([]byte, []byte, error) {
+		sig, bundle, err := signer.SignBlob(ctx, sslDSSE.PAE(InTotoPayloadType, statement))
+		if err != nil {
+			return nil, nil, err
+		}
+		envelope, err := json.Marshal(dsseEnvelope{
+			PayloadType: InTotoPayloadType,
+			Payload:     base64.StdEncoding.EncodeToString(statement),
+			Signatures:  []dsseSignature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return envelope, bundle, nil
+	})
+}
+
+func (r *Remote) attachAttestations(ctx context.Context, predicates []Predicate, sign signStatementFunc) ([]Attestation, error) {
+	root, err := r.ResolveRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subject := InTotoSubject{
+		Name:   fmt.Sprintf("%s/%s", r.Repo().Reference.Registry, r.Repo().Reference.Repository),
+		Digest: map[string]string{root.Digest.Algorithm().String(): root.Digest.Encoded()},
+	}
+
+	attestations := []Attestation{}
+	for _, predicate := range predicates {
+		statement, err := json.Marshal(InTotoStatement{
+			Type:          InTotoStatementType,
+			Subject:       []InTotoSubject{subject},
+			PredicateType: predicate.Type,
+			Predicate:     predicate.Content,
+		})
+		if err != nil {
+			return nil, err
+		}
+		envelope, bundle, err := sign(statement)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign the %s attestation: %w", predicate.Type, err)
+		}
+		layerDesc, err := oras.PushBytes(ctx, r.Repo(), AttestationArtifactType, envelope)
+		if err != nil {
+			return nil, err
+		}
+		if predicate.Name != "" {
+			layerDesc.Annotations = map[string]string{ocispec.AnnotationTitle: predicate.Name}
+		}
+		layers := []ocispec.Descriptor{layerDesc}
+		if bundle != nil {
+			bundleDesc, err := oras.PushBytes(ctx, r.Repo(), AttestationBundleMediaType, bundle)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, bundleDesc)
+		}
+		manifestDesc, err := oras.PackManifest(ctx, r.Repo(), oras.PackManifestVersion1_1, AttestationArtifactType, oras.PackManifestOptions{
+			Subject: &root,
+			Layers:  layers,
+			ManifestAnnotations: map[string]string{
+				AttestationPredicateTypeAnnotation: predicate.Type,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to attach the %s attestation: %w", predicate.Type, err)
+		}
+		attestations = append(attestations, Attestation{
+			PredicateType: predicate.Type,
+			Name:          predicate.Name,
+			Digest:        manifestDesc.Digest,
+		})
+	}
+	return attestations, nil
+}
+
+// VerifyAttestations verifies the attestations attached to the package manifest with the verifier.
+// It returns an error if the package has no attestations or if any of them is not signed by the verifier or is not about the package.
+func (r *Remote) VerifyAttestations(ctx context.Context, verifier signature.Verifier) ([]Attestation, error) {
+	dsseVerifier := dsse.WrapVerifier(verifier)
+	return r.verifyAttestations(ctx, func(envelope, _ []byte) error {
+		return dsseVerifier.VerifySignature(bytes.NewReader(envelope), nil)
+	})
+}
+
+// VerifyKeylessAttestations verifies the keyless signatures of the attestations attached to the package manifest with
+// the bundles attached with them. The certificates must chain to the Fulcio roots and be issued to the identity.
+// It returns an error if the package has no attestations or if any of them is not signed keyless by the identity or is
+// not about the package.
+func (r *Remote) VerifyKeylessAttestations(ctx context.Context, identity utils.CertificateIdentity) ([]Attestation, error) {
+	return r.verifyAttestations(ctx, func(envelope, bundle []byte) error {
+		return verifyKeylessEnvelope(ctx, envelope, bundle, identity)
+	})
+}
+
+func (r *Remote) verifyAttestations(ctx context.Context, verify verifyEnvelopeFunc) ([]Attestation, error) {
+	root, err := r.ResolveRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	referrers := []ocispec.Descriptor{}
+	err = r.Repo().Referrers(ctx, root, AttestationArtifactType, func(descs []ocispec.Descriptor) error {
+		referrers = append(referrers, descs...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the attestations of the package: %w", err)
+	}
+	if len(referrers) == 0 {
+		return nil, errors.New("the package has no attestations")
+	}
+
+	attestations := []Attestation{}
+	for _, referrer := range referrers {
+		b, err := content.FetchAll(ctx, r.Repo(), referrer)
+		if err != nil {
+			return nil, err
+		}
+		manifest := ocispec.Manifest{}
+		if err := json.Unmarshal(b, &manifest); err != nil {
+			return nil, err
+		}
+		var envelopeDesc, bundleDesc *ocispec.Descriptor
+		for i, layer := range manifest.Layers {
+			switch {
+			case layer.MediaType == AttestationArtifactType && envelopeDesc == nil:
+				envelopeDesc = &manifest.Layers[i]
+			case layer.MediaType == AttestationBundleMediaType && bundleDesc == nil:
+				bundleDesc = &manifest.Layers[i]
+			default:
+				return nil, fmt.Errorf("attestation %s has an unexpected %s layer", referrer.Digest, layer.MediaType)
+			}
+		}
+		if envelopeDesc == nil {
+			return nil, fmt.Errorf("attestation %s has no %s layer", referrer.Digest, AttestationArtifactType)
+		}
+		envelope, err := content.FetchAll(ctx, r.Repo(), *envelopeDesc)
+		if err != nil {
+			return nil, err
+		}
+		var bundle []byte
+		if bundleDesc != nil {
+			bundle, err = content.FetchAll(ctx, r.Repo(), *bundleDesc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		statement, err := verifyAttestation(verify, envelope, bundle, root.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("attestation %s failed verification: %w", referrer.Digest, err)
+		}
+		attestations = append(attestations, Attestation{
+			PredicateType: statement.PredicateType,
+			Name:          envelopeDesc.Annotations[ocispec.AnnotationTitle],
+			Digest:        referrer.Digest,
+		})
+	}
+	return attestations, nil
+}
+
+// verifyKeylessEnvelope verifies the keyless signature of a DSSE envelope with the bundle of its certificate and Rekor
+// transparency log entry. The signature covers the pre-authentication encoding of the envelope payload.
+func verifyKeylessEnvelope(ctx context.Context, envelope, bundle []byte, identity utils.CertificateIdentity) error {
+	if bundle == nil {
+		return errors.New("the attestation is not signed keyless")
+	}
+	env := dsseEnvelope{}
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return err
+	}
+	if len(env.Signatures) != 1 {
+		return fmt.Errorf("the envelope has %d signatures, expected 1", len(env.Signatures))
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	paePath := filepath.Join(tmpDir, "envelope.pae")
+	sigPath := filepath.Join(tmpDir, "envelope.sig")
+	bundlePath := filepath.Join(tmpDir, "envelope.bundle")
+	files := map[string][]byte{
+		paePath:    sslDSSE.PAE(env.PayloadType, payload),
+		sigPath:    []byte(env.Signatures[0].Sig),
+		bundlePath: bundle,
+	}
+	for path, b := range files {
+		if err := os.WriteFile(path, b, helpers.ReadWriteUser); err != nil {
+			return err
+		}
+	}
+	return utils.CosignVerifyBlobKeyless(ctx, paePath, sigPath, bundlePath, identity)
+}
+
+// verifyAttestation verifies the signature of a DSSE envelope and that the in-toto statement it holds is about the subject.
+func verifyAttestation(verify verifyEnvelopeFunc, envelope, bundle []byte, subject digest.Digest) (InTotoStatement, error) {
+	if err := verify(envelope, bundle); err != nil {
+		return InTotoStatement{}, err
+	}
+	env := dsseEnvelope{}
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return InTotoStatement{}, err
+	}
+	if env.PayloadType != InTotoPayloadType {
+		return InTotoStatement{}, fmt.Errorf("unexpected payload type %q", env.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return InTotoStatement{}, err
+	}
+	statement := InTotoStatement{}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return InTotoStatement{}, err
+	}
+	for _, s := range statement.Subject {
+		if s.Digest[subject.Algorithm().String()] == subject.Encoded() {
+			return statement, nil
+		}
+	}
+	return InTotoStatement{}, fmt.Errorf("the statement is not about the package %s", subject)
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+func TestAttestations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	newSignerVerifier := func() signature.SignerVerifier {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		sv, err := signature.LoadSignerVerifier(priv, crypto.SHA256)
+		require.NoError(t, err)
+		return sv
+	}
+	publish := func(name string) *Remote {
+		zarfYAML := []byte("kind: ZarfPackageConfig\n")
+		sum := sha256.Sum256(zarfYAML)
+		descs := []ocispec.Descriptor{NewLayerDescriptor("zarf.yaml", hex.EncodeToString(sum[:]), int64(len(zarfYAML)))}
+		stream := func(push func(name string, r io.Reader) error) error {
+			return push("zarf.yaml", bytes.NewReader(zarfYAML))
+		}
+		remote, err := NewRemote(ctx, u.Host+"/"+name+":0.0.1", oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+		require.NoError(t, err)
+		pkg := &v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: name}}
+		err = remote.PublishPackageStream(ctx, pkg, descs, stream, PublishOptions{})
+		require.NoError(t, err)
+		return remote
+	}
+
+	sv := newSignerVerifier()
+	remote := publish("attested")
+	predicates := []Predicate{
+		{Type: ProvenancePredicateType, Content: []byte(`{"buildDefinition":{}}`)},
+		{Type: SBOMPredicateType, Name: "podinfo.json", Content: []byte(`{"artifacts":[]}`)},
+	}
+	attached, err := remote.AttachAttestations(ctx, sv, predicates)
+	require.NoError(t, err)
+	require.Len(t, attached, 2)
+
+	verifyRemote, err := NewRemote(ctx, u.Host+"/attested:0.0.1", oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	verified, err := verifyRemote.VerifyAttestations(ctx, sv)
+	require.NoError(t, err)
+	require.ElementsMatch(t, attached, verified)
+
+	_, err = verifyRemote.VerifyAttestations(ctx, newSignerVerifier())
+	require.ErrorContains(t, err, "failed verification")
+	_, err = verifyRemote.VerifyKeylessAttestations(ctx, utils.CertificateIdentity{Identity: "user@example.com", OIDCIssuer: "https://accounts.example.com"})
+	require.ErrorContains(t, err, "the attestation is not signed keyless")
+
+	unattested := publish("unattested")
+	_, err = unattested.VerifyAttestations(ctx, sv)
+	require.EqualError(t, err, "the package has no attestations")
+}
+
+func TestVerifyAttestationSubject(t *testing.T) {
+	t.Parallel()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	sv, err := signature.LoadSignerVerifier(priv, crypto.SHA256)
+	require.NoError(t, err)
+
+	statement := []byte(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test","digest":{"sha256":"aaaa"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{}}`)
+	envelope, err := dsse.WrapSigner(sv, InTotoPayloadType).SignMessage(bytes.NewReader(statement))
+	require.NoError(t, err)
+
+	verify := func(envelope, _ []byte) error {
+		return dsse.WrapVerifier(sv).VerifySignature(bytes.NewReader(envelope), nil)
+	}
+	_, err = verifyAttestation(verify, envelope, nil, "sha256:aaaa")
+	require.NoError(t, err)
+	_, err = verifyAttestation(verify, envelope, nil, "sha256:bbbb")
+	require.EqualError(t, err, "the statement is not about the package sha256:bbbb")
+}
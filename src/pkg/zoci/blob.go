@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// PullBlob pulls the single blob referenced by the digest of the remote and saves it to the given path.
+// The content of the blob is verified against the digest while it is written.
+func (r *Remote) PullBlob(ctx context.Context, dst string) (err error) {
+	dgst, err := digest.Parse(r.Repo().Reference.Reference)
+	if err != nil {
+		return fmt.Errorf("%s must reference a blob by digest: %w", r.Repo().Reference, err)
+	}
+	desc, err := r.Repo().Blobs().Resolve(ctx, dgst.String())
+	if err != nil {
+		return err
+	}
+	r.Log().Info(fmt.Sprintf("Pulling %s, size: %s", r.Repo().Reference, utils.ByteFormat(float64(desc.Size), 2)))
+
+	rc, err := r.Repo().Blobs().Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err2 := rc.Close()
+		err = errors.Join(err, err2)
+	}()
+
+	if err := helpers.CreateDirectory(filepath.Dir(dst), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err2 := f.Close()
+		err = errors.Join(err, err2)
+	}()
+	vr := content.NewVerifyReader(rc, desc)
+	if _, err := io.Copy(f, vr); err != nil {
+		return err
+	}
+	return vr.Verify()
+}
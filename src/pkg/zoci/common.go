@@ -22,6 +22,8 @@ const (
 	ZarfLayerMediaTypeBlob = "application/vnd.zarf.layer.v1.blob"
 	// SkeletonArch is the architecture used for skeleton packages
 	SkeletonArch = "skeleton"
+	// MultiArch is the architecture used for packages created for multiple architectures
+	MultiArch = "multi"
 )
 
 // Remote is a wrapper around the Oras remote repository with zarf specific functions
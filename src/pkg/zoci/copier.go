@@ -13,11 +13,30 @@ import (
 	"github.com/defenseunicorns/pkg/oci"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 )
 
-// CopyPackage copies a zarf package from one OCI registry to another
+// CopyPackage copies a zarf package from one OCI registry to another.
+// The skeleton or full package linked to the package is copied along with it.
 func CopyPackage(ctx context.Context, src *Remote, dst *Remote, concurrency int) (err error) {
+	linked, hasLinked, err := src.LinkedPackage(ctx)
+	if err != nil {
+		return err
+	}
+	// The skeleton manifest is copied as is so its subject keeps linking it to the full package.
+	dstRepo, err := dst.subjectRepo()
+	if err != nil {
+		return err
+	}
+	if hasLinked {
+		// The linked package is copied first as copying a skeleton also copies the full package it refers to.
+		src.Log().Info(fmt.Sprintf("Copying the linked %s package %s", linked.Platform.Architecture, linked.Digest))
+		if err := oras.CopyGraph(ctx, src.Repo(), dstRepo, linked, oras.CopyGraphOptions{Concurrency: concurrency}); err != nil {
+			return err
+		}
+	}
+
 	srcManifest, err := src.FetchRoot(ctx)
 	if err != nil {
 		return err
@@ -48,7 +67,7 @@ func CopyPackage(ctx context.Context, src *Remote, dst *Remote, concurrency int)
 	}
 	expected := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, b)
 
-	if err := dst.Repo().Manifests().PushReference(ctx, expected, bytes.NewReader(b), srcRoot.Digest.String()); err != nil {
+	if err := dstRepo.Manifests().PushReference(ctx, expected, bytes.NewReader(b), srcRoot.Digest.String()); err != nil {
 		return err
 	}
 
@@ -56,6 +75,11 @@ func CopyPackage(ctx context.Context, src *Remote, dst *Remote, concurrency int)
 	if err := dst.UpdateIndex(ctx, tag, expected); err != nil {
 		return err
 	}
+	if hasLinked {
+		if err := dst.setIndexManifest(ctx, tag, linked); err != nil {
+			return err
+		}
+	}
 
 	src.Log().Info(fmt.Sprintf("Published %s to %s", src.Repo().Reference, dst.Repo().Reference))
 	return nil
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// LinkedPackage returns the index descriptor of the package linked to the root package of the remote.
+// Skeleton packages are linked to a full package of the same tag through the subject of their manifest,
+// so a skeleton returns the full package it refers to and a full package returns the skeleton that refers to it.
+func (r *Remote) LinkedPackage(ctx context.Context) (ocispec.Descriptor, bool, error) {
+	root, err := r.ResolveRoot(ctx)
+	if err != nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	index, err := r.fetchIndex(ctx, r.Repo().Reference.Reference)
+	if err != nil || index == nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	skeleton, full := splitIndex(index)
+	if skeleton == nil || len(full) == 0 {
+		return ocispec.Descriptor{}, false, nil
+	}
+	var manifest ocispec.Manifest
+	if err := fetchJSON(ctx, r.Repo(), *skeleton, &manifest); err != nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	if manifest.Subject == nil {
+		return ocispec.Descriptor{}, false, nil
+	}
+	if root.Digest == skeleton.Digest {
+		for _, desc := range full {
+			if desc.Digest == manifest.Subject.Digest {
+				return desc, true, nil
+			}
+		}
+		return ocispec.Descriptor{}, false, nil
+	}
+	if root.Digest == manifest.Subject.Digest {
+		return *skeleton, true, nil
+	}
+	return ocispec.Descriptor{}, false, nil
+}
+
+// skeletonSubject returns the subject to publish a skeleton package with, linking it to a full package of the same tag.
+// Registries that garbage collect referrers remove the skeleton together with the full package instead of leaving an orphaned skeleton.
+// The subject is only set when the skeleton is published as setting it afterwards would change the digest of the skeleton,
+// so a skeleton published before any full package of its tag is not linked.
+func (r *Remote) skeletonSubject(ctx context.Context, pkg *v1alpha1.ZarfPackage) (*ocispec.Descriptor, error) {
+	if pkg.Metadata.Architecture != SkeletonArch {
+		return nil, nil
+	}
+	index, err := r.fetchIndex(ctx, r.Repo().Reference.Reference)
+	if err != nil || index == nil {
+		return nil, err
+	}
+	_, full := splitIndex(index)
+	if len(full) == 0 {
+		return nil, nil
+	}
+	subject := full[0]
+	return &ocispec.Descriptor{
+		MediaType: subject.MediaType,
+		Digest:    subject.Digest,
+		Size:      subject.Size,
+	}, nil
+}
+
+// subjectRepo returns a repository for pushing manifests with a subject that leaves the subject to the registry.
+// Registries that support the referrers API link the manifest to its subject, others ignore the subject
+// instead of a sha256-<digest> referrers tag being created next to the package tags.
+func (r *Remote) subjectRepo() (*remote.Repository, error) {
+	repo := r.Repo()
+	subjectRepo := &remote.Repository{
+		Client:               repo.Client,
+		Reference:            repo.Reference,
+		PlainHTTP:            repo.PlainHTTP,
+		ManifestMediaTypes:   repo.ManifestMediaTypes,
+		TagListPageSize:      repo.TagListPageSize,
+		ReferrerListPageSize: repo.ReferrerListPageSize,
+		MaxMetadataBytes:     repo.MaxMetadataBytes,
+		SkipReferrersGC:      true,
+		HandleWarning:        repo.HandleWarning,
+	}
+	if err := subjectRepo.SetReferrersCapability(true); err != nil {
+		return nil, err
+	}
+	return subjectRepo, nil
+}
+
+// fetchIndex returns the index of the tag, nil is returned when the tag does not exist or is not an index.
+func (r *Remote) fetchIndex(ctx context.Context, tag string) (*ocispec.Index, error) {
+	desc, err := r.Repo().Resolve(ctx, tag)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if desc.MediaType != ocispec.MediaTypeImageIndex {
+		return nil, nil
+	}
+	var index ocispec.Index
+	if err := fetchJSON(ctx, r.Repo(), desc, &index); err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// setIndexManifest adds the manifest to the index of the tag, replacing the manifest with the same architecture.
+// Unlike UpdateIndex the platform of the manifest descriptor is used instead of the target platform of the remote.
+func (r *Remote) setIndexManifest(ctx context.Context, tag string, desc ocispec.Descriptor) error {
+	index, err := r.fetchIndex(ctx, tag)
+	if err != nil {
+		return err
+	}
+	if index == nil {
+		index = &ocispec.Index{
+			MediaType: ocispec.MediaTypeImageIndex,
+			Versioned: specs.Versioned{
+				SchemaVersion: 2,
+			},
+		}
+	}
+	entry := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    desc.Digest,
+		Size:      desc.Size,
+		Platform:  desc.Platform,
+	}
+	found := false
+	for i, m := range index.Manifests {
+		if m.Platform != nil && desc.Platform != nil && m.Platform.Architecture == desc.Platform.Architecture {
+			index.Manifests[i] = entry
+			found = true
+			break
+		}
+	}
+	if !found {
+		index.Manifests = append(index.Manifests, entry)
+	}
+	b, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	indexDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, b)
+	return r.Repo().Manifests().PushReference(ctx, indexDesc, bytes.NewReader(b), tag)
+}
+
+// splitIndex returns the skeleton package and the full packages of an index.
+func splitIndex(index *ocispec.Index) (*ocispec.Descriptor, []ocispec.Descriptor) {
+	var skeleton *ocispec.Descriptor
+	full := []ocispec.Descriptor{}
+	for i, desc := range index.Manifests {
+		if desc.Platform == nil {
+			continue
+		}
+		if desc.Platform.Architecture == SkeletonArch {
+			skeleton = &index.Manifests[i]
+			continue
+		}
+		full = append(full, desc)
+	}
+	return skeleton, full
+}
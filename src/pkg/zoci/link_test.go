@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestLinkSkeleton(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		referrers bool
+	}{
+		{name: "referrers API", referrers: true},
+		{name: "no referrers API", referrers: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0)), registry.WithReferrersSupport(tt.referrers)))
+			t.Cleanup(srv.Close)
+			u, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+
+			publish := func(reference string, platform ocispec.Platform, data string) *Remote {
+				t.Helper()
+				b := []byte(data)
+				sum := sha256.Sum256(b)
+				descs := []ocispec.Descriptor{NewLayerDescriptor("zarf.yaml", hex.EncodeToString(sum[:]), int64(len(b)))}
+				pkg := &v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "0.0.1", Architecture: platform.Architecture}}
+				r, err := NewRemote(ctx, reference, platform, oci.WithPlainHTTP(true))
+				require.NoError(t, err)
+				err = r.PublishPackageStream(ctx, pkg, descs, func(push func(string, io.Reader) error) error {
+					return push("zarf.yaml", bytes.NewReader(b))
+				}, PublishOptions{})
+				require.NoError(t, err)
+				return r
+			}
+			requireLinked := func(r *Remote, arch string) ocispec.Descriptor {
+				t.Helper()
+				linked, ok, err := r.LinkedPackage(ctx)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, arch, linked.Platform.Architecture)
+				return linked
+			}
+			requireNoReferrersTags := func(r *Remote) {
+				t.Helper()
+				err := r.Repo().Tags(ctx, "", func(tags []string) error {
+					for _, tag := range tags {
+						require.False(t, strings.HasPrefix(tag, "sha256-"), "unexpected referrers tag %s", tag)
+					}
+					return nil
+				})
+				require.NoError(t, err)
+			}
+
+			// A skeleton published before the full package of its tag is not linked.
+			unlinked := publish(u.Host+"/unlinked:0.0.1", PlatformForSkeleton(), "kind: ZarfPackageConfig\n# skeleton\n")
+			unlinkedRoot, err := unlinked.ResolveRoot(ctx)
+			require.NoError(t, err)
+			publish(u.Host+"/unlinked:0.0.1", oci.PlatformForArch("amd64"), "kind: ZarfPackageConfig\n")
+			_, ok, err := unlinked.LinkedPackage(ctx)
+			require.NoError(t, err)
+			require.False(t, ok)
+			root, err := unlinked.ResolveRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, unlinkedRoot.Digest, root.Digest)
+
+			// A skeleton published after the full package of its tag is linked to it.
+			reference := u.Host + "/test:0.0.1"
+			full := publish(reference, oci.PlatformForArch("amd64"), "kind: ZarfPackageConfig\n")
+			fullRoot, err := full.ResolveRoot(ctx)
+			require.NoError(t, err)
+			skeleton := publish(reference, PlatformForSkeleton(), "kind: ZarfPackageConfig\n# skeleton\n")
+			require.Equal(t, fullRoot.Digest, requireLinked(skeleton, "amd64").Digest)
+			skeletonRoot, err := skeleton.ResolveRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, skeletonRoot.Digest, requireLinked(full, SkeletonArch).Digest)
+			skeletonManifest, err := skeleton.FetchRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, fullRoot.Digest, skeletonManifest.Subject.Digest)
+
+			// Publishing a package for another architecture keeps the existing link and skeleton.
+			arm := publish(reference, oci.PlatformForArch("arm64"), "kind: ZarfPackageConfig\n# arm64\n")
+			require.Equal(t, fullRoot.Digest, requireLinked(skeleton, "amd64").Digest)
+			_, ok, err = arm.LinkedPackage(ctx)
+			require.NoError(t, err)
+			require.False(t, ok)
+			root, err = skeleton.ResolveRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, skeletonRoot.Digest, root.Digest)
+
+			// Copying the full package copies the linked skeleton along with it.
+			dst, err := NewRemote(ctx, u.Host+"/copy:0.0.1", oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+			require.NoError(t, err)
+			full, err = NewRemote(ctx, reference, oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+			require.NoError(t, err)
+			err = CopyPackage(ctx, full, dst, 1)
+			require.NoError(t, err)
+			require.Equal(t, skeletonRoot.Digest, requireLinked(dst, SkeletonArch).Digest)
+			dstSkeleton, err := NewRemote(ctx, u.Host+"/copy:0.0.1", PlatformForSkeleton(), oci.WithPlainHTTP(true))
+			require.NoError(t, err)
+			require.Equal(t, fullRoot.Digest, requireLinked(dstSkeleton, "amd64").Digest)
+
+			requireNoReferrersTags(skeleton)
+			requireNoReferrersTags(dst)
+		})
+	}
+}
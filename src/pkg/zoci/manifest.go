@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// ManifestType is the type of manifest a package is published with.
+type ManifestType string
+
+const (
+	// ManifestTypeDefault publishes packages with the same OCI 1.1 image manifest as previous releases so package digests do not change.
+	ManifestTypeDefault ManifestType = "default"
+	// ManifestTypeImage publishes packages with a plain OCI image manifest that is accepted by all registries.
+	ManifestTypeImage ManifestType = "image"
+	// ManifestTypeArtifact publishes packages with an OCI 1.1 image manifest that declares the Zarf artifact type.
+	ManifestTypeArtifact ManifestType = "artifact"
+	// ManifestTypeAuto publishes packages as artifacts and falls back to an image manifest if the registry rejects the artifact.
+	ManifestTypeAuto ManifestType = "auto"
+
+	// ZarfArtifactType is the artifact type of packages published with an artifact manifest.
+	ZarfArtifactType = "application/vnd.zarf.package.v1"
+	// ManifestTypeAnnotation records the type of manifest a package was published with, it is not set on default manifests.
+	ManifestTypeAnnotation = "dev.zarf.manifest.type"
+)
+
+// ManifestTypes are the supported manifest types.
+var ManifestTypes = []ManifestType{ManifestTypeDefault, ManifestTypeAuto, ManifestTypeImage, ManifestTypeArtifact}
+
+// ParseManifestType returns the manifest type for the given name, an empty name returns the default manifest type.
+func ParseManifestType(name string) (ManifestType, error) {
+	if name == "" {
+		return ManifestTypeDefault, nil
+	}
+	manifestType := ManifestType(name)
+	if !slices.Contains(ManifestTypes, manifestType) {
+		return "", fmt.Errorf("invalid manifest type %q, must be one of %v", name, ManifestTypes)
+	}
+	return manifestType, nil
+}
+
+// manifestTypesToTry returns the manifest types to publish with in order of preference.
+func manifestTypesToTry(manifestType ManifestType) []ManifestType {
+	switch manifestType {
+	case ManifestTypeAuto:
+		return []ManifestType{ManifestTypeArtifact, ManifestTypeImage}
+	case "":
+		return []ManifestType{ManifestTypeDefault}
+	default:
+		return []ManifestType{manifestType}
+	}
+}
+
+// packManifest packs the manifest of a package with the given manifest type and pushes it to the pusher.
+// The subject is left out of image manifests as they do not support one.
+func packManifest(ctx context.Context, pusher content.Pusher, manifestType ManifestType, descs []ocispec.Descriptor, configDesc, subject *ocispec.Descriptor, annotations map[string]string) (ocispec.Descriptor, error) {
+	manifestAnnotations := maps.Clone(annotations)
+	if manifestType != ManifestTypeDefault {
+		manifestAnnotations[ManifestTypeAnnotation] = string(manifestType)
+	}
+	packOpts := oras.PackManifestOptions{
+		Layers:              descs,
+		ConfigDescriptor:    configDesc,
+		ManifestAnnotations: manifestAnnotations,
+	}
+	if manifestType != ManifestTypeImage {
+		packOpts.Subject = subject
+	}
+	switch manifestType {
+	case ManifestTypeDefault:
+		return oras.PackManifest(ctx, pusher, oras.PackManifestVersion1_1, "", packOpts)
+	case ManifestTypeImage:
+		return oras.PackManifest(ctx, pusher, oras.PackManifestVersion1_0, "", packOpts)
+	case ManifestTypeArtifact:
+		return oras.PackManifest(ctx, pusher, oras.PackManifestVersion1_1, ZarfArtifactType, packOpts)
+	default:
+		return ocispec.Descriptor{}, fmt.Errorf("unable to pack a manifest of type %q", manifestType)
+	}
+}
+
+// publishManifest packs and publishes the package manifest with each manifest type to try in order.
+// The next manifest type is only tried when the registry rejects the manifest.
+func (r *Remote) publishManifest(ctx context.Context, manifestType ManifestType, publish func(ManifestType) (ocispec.Descriptor, error)) (ocispec.Descriptor, error) {
+	toTry := manifestTypesToTry(manifestType)
+	var err error
+	for i, mt := range toTry {
+		var root ocispec.Descriptor
+		root, err = publish(mt)
+		if err == nil {
+			r.Log().Debug(fmt.Sprintf("Published the package with an %s manifest", mt))
+			return root, nil
+		}
+		if !isManifestRejected(err) || i == len(toTry)-1 {
+			break
+		}
+		r.Log().Warn(fmt.Sprintf("The registry rejected the %s manifest, publishing with an %s manifest instead", mt, toTry[i+1]))
+	}
+	return ocispec.Descriptor{}, err
+}
+
+// isManifestRejected returns true if the error is a registry rejecting a manifest it does not support.
+func isManifestRejected(err error) bool {
+	var errResp *errcode.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	if errResp.URL == nil || !strings.Contains(errResp.URL.Path, "/manifests/") {
+		return false
+	}
+	return errResp.StatusCode == http.StatusBadRequest || errResp.StatusCode == http.StatusUnsupportedMediaType
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestParseManifestType(t *testing.T) {
+	t.Parallel()
+
+	manifestType, err := ParseManifestType("")
+	require.NoError(t, err)
+	require.Equal(t, ManifestTypeDefault, manifestType)
+	manifestType, err = ParseManifestType("image")
+	require.NoError(t, err)
+	require.Equal(t, ManifestTypeImage, manifestType)
+	_, err = ParseManifestType("index")
+	require.EqualError(t, err, `invalid manifest type "index", must be one of [default auto image artifact]`)
+}
+
+func TestPublishManifestType(t *testing.T) {
+	t.Parallel()
+
+	// The registry rejects manifests that declare an artifact type like some older registries do.
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut && strings.Contains(req.URL.Path, "/manifests/") {
+			b, err := io.ReadAll(req.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if bytes.Contains(b, []byte(`"artifactType"`)) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":[{"code":"MANIFEST_INVALID","message":"manifest invalid"}]}`))
+				return
+			}
+			req.Body = io.NopCloser(bytes.NewReader(b))
+		}
+		reg.ServeHTTP(w, req)
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	b := []byte("kind: ZarfPackageConfig\n")
+	layer := NewLayerDescriptor("zarf.yaml", content.NewDescriptorFromBytes("", b).Digest.Encoded(), int64(len(b)))
+	stream := func(push func(name string, r io.Reader) error) error {
+		return push("zarf.yaml", bytes.NewReader(b))
+	}
+	pkg := &v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}}
+
+	tests := []struct {
+		manifestType   ManifestType
+		expectedType   ManifestType
+		expectRejected bool
+	}{
+		{manifestType: ManifestTypeDefault, expectedType: ManifestTypeDefault},
+		{manifestType: ManifestTypeAuto, expectedType: ManifestTypeImage},
+		{manifestType: ManifestTypeImage, expectedType: ManifestTypeImage},
+		{manifestType: ManifestTypeArtifact, expectRejected: true},
+	}
+	for _, tt := range tests {
+		remote, err := NewRemote(ctx, u.Host+"/"+string(tt.manifestType)+":0.0.1", oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+		require.NoError(t, err)
+		err = remote.PublishPackageStream(ctx, pkg, []ocispec.Descriptor{layer}, stream, PublishOptions{ManifestType: tt.manifestType})
+		if tt.expectRejected {
+			require.True(t, isManifestRejected(err))
+			continue
+		}
+		require.NoError(t, err)
+		root, err := remote.FetchRoot(ctx)
+		require.NoError(t, err)
+		if tt.expectedType == ManifestTypeDefault {
+			require.NotContains(t, root.Annotations, ManifestTypeAnnotation)
+			continue
+		}
+		require.Equal(t, string(tt.expectedType), root.Annotations[ManifestTypeAnnotation])
+	}
+}
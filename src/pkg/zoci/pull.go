@@ -14,6 +14,7 @@ import (
 	"github.com/defenseunicorns/pkg/oci"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
@@ -22,7 +23,7 @@ import (
 
 var (
 	// PackageAlwaysPull is a list of paths that will always be pulled from the remote repository.
-	PackageAlwaysPull = []string{layout.ZarfYAML, layout.Checksums, layout.Signature}
+	PackageAlwaysPull = []string{layout.ZarfYAML, layout.Checksums, layout.Signature, layout.ChecksumsSignature, layout.SignatureTimestamp, layout.ChecksumsSignatureTimestamp, layout.SignatureBundle, layout.ChecksumsSignatureBundle}
 )
 
 // PullPackage pulls the package from the remote repository and saves it to the given path.
@@ -70,15 +71,29 @@ func (r *Remote) PullPackage(ctx context.Context, destinationDir string, concurr
 		err = errors.Join(err, err2)
 	}(dst)
 
+	cachePath, err := config.GetAbsCachePath()
+	if err != nil {
+		return nil, err
+	}
+	cache := newPullCache(cachePath)
+
 	copyOpts := r.GetDefaultCopyOpts()
 	copyOpts.Concurrency = concurrency
+	copyOpts = withPullCache(copyOpts, dst, cache, layersToPull, r.retryLogger())
 
-	err = r.CopyToTarget(ctx, layersToPull, dst, copyOpts)
+	// Layers completed by a failed attempt, or by a previous pull that failed, are not pulled again.
+	target := &pullTarget{Target: dst, cache: cache}
+	err = helpers.RetryWithContext(ctx, func() error {
+		return r.CopyToTarget(ctx, layersToPull, target, copyOpts)
+	}, PullAttempts, PullRetryDelay, r.retryLogger())
 	doneSaving <- err
 	<-doneSaving
 	if err != nil {
 		return nil, err
 	}
+	if err := cache.done(layersToPull); err != nil {
+		r.Log().Debug("unable to clean up the pull cache", "error", err)
+	}
 	return layersToPull, nil
 }
 
@@ -134,27 +149,30 @@ func (r *Remote) LayersFromRequestedComponents(ctx context.Context, requestedCom
 				return nil, fmt.Errorf("failed to parse image ref %q: %w", image, err)
 			}
 
-			manifestDescriptor := helpers.Find(index.Manifests, func(layer ocispec.Descriptor) bool {
-				return layer.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Reference ||
+			// Packages created for multiple architectures have an image manifest for each architecture.
+			for _, manifestDescriptor := range index.Manifests {
+				if manifestDescriptor.Annotations[ocispec.AnnotationBaseImageName] != refInfo.Reference &&
 					// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
-					(layer.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io")
-			})
-
-			// even though these are technically image manifests, we store them as Zarf blobs
-			manifestDescriptor.MediaType = ZarfLayerMediaTypeBlob
-
-			manifest, err := r.FetchManifest(ctx, manifestDescriptor)
-			if err != nil {
-				return nil, err
-			}
-			// Add the manifest and the manifest config layers
-			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifestDescriptor.Digest.Encoded())))
-			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifest.Config.Digest.Encoded())))
-
-			// Add all the layers from the manifest
-			for _, layer := range manifest.Layers {
-				layerPath := filepath.Join(layout.ImagesBlobsDir, layer.Digest.Encoded())
-				layers = append(layers, root.Locate(layerPath))
+					(manifestDescriptor.Annotations[ocispec.AnnotationBaseImageName] != refInfo.Path+refInfo.TagOrDigest || refInfo.Host != "docker.io") {
+					continue
+				}
+
+				// even though these are technically image manifests, we store them as Zarf blobs
+				manifestDescriptor.MediaType = ZarfLayerMediaTypeBlob
+
+				manifest, err := r.FetchManifest(ctx, manifestDescriptor)
+				if err != nil {
+					return nil, err
+				}
+				// Add the manifest and the manifest config layers
+				layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifestDescriptor.Digest.Encoded())))
+				layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifest.Config.Digest.Encoded())))
+
+				// Add all the layers from the manifest
+				for _, layer := range manifest.Layers {
+					layerPath := filepath.Join(layout.ImagesBlobsDir, layer.Digest.Encoded())
+					layers = append(layers, root.Locate(layerPath))
+				}
 			}
 		}
 	}
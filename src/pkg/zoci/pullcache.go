@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+)
+
+const (
+	// PullAttempts is the number of attempts made to pull a package, every attempt resumes from the layers completed by the previous ones.
+	PullAttempts = 3
+	// PullRetryDelay is the initial delay before retrying a failed pull, it doubles with every attempt.
+	PullRetryDelay = 2 * time.Second
+	// pullCacheDir is the directory within the Zarf cache that stores the layers of package pulls until the pull completes.
+	pullCacheDir = "pull"
+)
+
+// pullCache records the layers completed during a package pull so that a failed pull resumes from the completed layers.
+// Layers are stored by digest so that a pull of any reference resumes from layers of the same content.
+type pullCache struct {
+	dir string
+}
+
+// newPullCache returns the pull cache within the cache path, layers are not recorded when the cache path is empty.
+func newPullCache(cachePath string) *pullCache {
+	if cachePath == "" {
+		return &pullCache{}
+	}
+	return &pullCache{dir: filepath.Join(cachePath, pullCacheDir)}
+}
+
+// path returns the path of the layer within the cache.
+func (c *pullCache) path(desc ocispec.Descriptor) string {
+	return filepath.Join(c.dir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+}
+
+// isCached returns true if the layer was completed by a previous pull.
+func (c *pullCache) isCached(desc ocispec.Descriptor) bool {
+	if c.dir == "" || desc.MediaType != ZarfLayerMediaTypeBlob {
+		return false
+	}
+	info, err := os.Stat(c.path(desc))
+	return err == nil && info.Size() == desc.Size
+}
+
+// done removes the layers of a completed pull from the cache.
+func (c *pullCache) done(descs []ocispec.Descriptor) error {
+	if c.dir == "" {
+		return nil
+	}
+	var err error
+	for _, desc := range descs {
+		if desc.MediaType != ZarfLayerMediaTypeBlob {
+			continue
+		}
+		if rmErr := os.Remove(c.path(desc)); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	}
+	return err
+}
+
+// pullTarget records the layers pushed to the target in the pull cache.
+type pullTarget struct {
+	oras.Target
+	cache *pullCache
+}
+
+// Push pushes the content to the target and records it in the pull cache once the target has verified it.
+func (t *pullTarget) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) (err error) {
+	if t.cache.dir == "" || desc.MediaType != ZarfLayerMediaTypeBlob {
+		return t.Target.Push(ctx, desc, r)
+	}
+	path := t.cache.path(desc)
+	if err := helpers.CreateDirectory(filepath.Dir(path), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), desc.Digest.Encoded()+"-*.partial")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+		if err != nil {
+			err = errors.Join(err, os.Remove(f.Name()))
+			return
+		}
+		err = os.Rename(f.Name(), path)
+	}()
+	return t.Target.Push(ctx, desc, io.TeeReader(r, f))
+}
+
+// withPullCache updates the copy options to push the requested layers completed by a previous pull from the cache instead of fetching them.
+func withPullCache(copyOpts oras.CopyOptions, target oras.Target, cache *pullCache, layers []ocispec.Descriptor, log func(format string, args ...any)) oras.CopyOptions {
+	requested := map[digest.Digest]bool{}
+	for _, layer := range layers {
+		requested[layer.Digest] = true
+	}
+	preCopy := copyOpts.PreCopy
+	copyOpts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if preCopy != nil {
+			if err := preCopy(ctx, desc); err != nil {
+				return err
+			}
+		}
+		if !requested[desc.Digest] || !cache.isCached(desc) {
+			return nil
+		}
+		f, err := os.Open(cache.path(desc))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		// The target verifies the digest so a corrupted layer is removed from the cache and pulled again.
+		if err := target.Push(ctx, desc, f); err != nil {
+			log("unable to resume %s from the cache, pulling it again: %s", desc.Annotations[ocispec.AnnotationTitle], err)
+			return os.Remove(cache.path(desc))
+		}
+		return oras.SkipNode
+	}
+	return copyOpts
+}
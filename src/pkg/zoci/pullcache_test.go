@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+// countingStorage counts the layers fetched from the storage.
+type countingStorage struct {
+	*memory.Store
+	fetched int
+}
+
+func (s *countingStorage) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	if desc.MediaType == ZarfLayerMediaTypeBlob {
+		s.fetched++
+	}
+	return s.Store.Fetch(ctx, desc)
+}
+
+func TestPullCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := []byte("hello world")
+	layer := content.NewDescriptorFromBytes(ZarfLayerMediaTypeBlob, b)
+	layer.Annotations = map[string]string{ocispec.AnnotationTitle: "components/hello.tar"}
+	src := &countingStorage{Store: memory.New()}
+	err := src.Push(ctx, layer, bytes.NewReader(b))
+	require.NoError(t, err)
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, ZarfConfigMediaType, oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+	require.NoError(t, err)
+	err = src.Tag(ctx, root, "0.0.1")
+	require.NoError(t, err)
+
+	cache := newPullCache(t.TempDir())
+	pull := func() string {
+		t.Helper()
+		dir := t.TempDir()
+		dst, err := file.New(dir)
+		require.NoError(t, err)
+		defer dst.Close()
+		copyOpts := withPullCache(oras.DefaultCopyOptions, dst, cache, []ocispec.Descriptor{layer}, t.Logf)
+		_, err = oras.Copy(ctx, src, "0.0.1", &pullTarget{Target: dst, cache: cache}, "0.0.1", copyOpts)
+		require.NoError(t, err)
+		pulled, err := os.ReadFile(filepath.Join(dir, "components", "hello.tar"))
+		require.NoError(t, err)
+		return string(pulled)
+	}
+
+	// The first pull fetches the layer and records it in the cache.
+	require.Equal(t, "hello world", pull())
+	require.Equal(t, 1, src.fetched)
+	require.True(t, cache.isCached(layer))
+
+	// Pulls resume from the layers in the cache.
+	require.Equal(t, "hello world", pull())
+	require.Equal(t, 1, src.fetched)
+
+	// Corrupted layers are pulled again.
+	err = os.WriteFile(cache.path(layer), []byte("hello earth"), 0o600)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", pull())
+	require.Equal(t, 2, src.fetched)
+
+	err = cache.done([]ocispec.Descriptor{layer})
+	require.NoError(t, err)
+	require.False(t, cache.isCached(layer))
+
+	// Nothing is recorded without a cache path.
+	cache = newPullCache("")
+	require.Equal(t, "hello world", pull())
+	require.Equal(t, 3, src.fetched)
+	require.False(t, cache.isCached(layer))
+}
@@ -8,20 +8,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
 )
 
 // PublishPackage publishes the zarf package to the remote repository.
-func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage, paths *layout.PackagePaths, concurrency int) (err error) {
+// Failed layer pushes are retried and the pushed layers are recorded so that a failed publish can be resumed.
+func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage, paths *layout.PackagePaths, opts PublishOptions) (err error) {
 	src, err := file.New(paths.Base)
 	if err != nil {
 		return err
@@ -50,11 +54,15 @@ func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage,
 	}
 	spinner.Successf("Prepared all layers")
 
-	copyOpts := r.GetDefaultCopyOpts()
-	copyOpts.Concurrency = concurrency
+	session, err := loadPublishSession(opts.CachePath, r.Repo().Reference.String(), opts.Resume)
+	if err != nil {
+		return err
+	}
+	copyOpts := withPublishSession(r.GetDefaultCopyOpts(), session)
+	copyOpts.Concurrency = opts.Concurrency
 	total := oci.SumDescsSize(descs)
 
-	annotations := annotationsFromMetadata(&pkg.Metadata)
+	annotations := AnnotationsFromMetadata(&pkg.Metadata)
 
 	// assumes referrers API is not supported since OCI artifact
 	// media type is not supported
@@ -68,11 +76,6 @@ func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage,
 	if err != nil {
 		return err
 	}
-	root, err := r.PackAndTagManifest(ctx, src, descs, manifestConfigDesc, annotations)
-	if err != nil {
-		return err
-	}
-
 	total += manifestConfigDesc.Size
 
 	progressBar := message.NewProgressBar(total, fmt.Sprintf("Publishing %s:%s", r.Repo().Reference.Repository, r.Repo().Reference.Reference))
@@ -83,7 +86,45 @@ func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage,
 	r.SetProgressWriter(progressBar)
 	defer r.ClearProgressWriter()
 
-	publishedDesc, err := oras.Copy(ctx, src, root.Digest.String(), r.Repo(), "", copyOpts)
+	resumed := []ocispec.Descriptor{}
+	for _, desc := range descs {
+		if session.isCompleted(desc) {
+			resumed = append(resumed, desc)
+		}
+	}
+	if len(resumed) > 0 {
+		r.Log().Info(fmt.Sprintf("Resuming publish with %d of %d layers already pushed", len(resumed), len(descs)))
+		progressBar.Add(int(oci.SumDescsSize(resumed)))
+	}
+
+	subject, err := r.skeletonSubject(ctx, pkg)
+	if err != nil {
+		return err
+	}
+	var target oras.Target = r.Repo()
+	if subject != nil {
+		target, err = r.subjectRepo()
+		if err != nil {
+			return err
+		}
+	}
+	dst := &retryTarget{
+		Target:   target,
+		src:      src,
+		attempts: opts.Retries,
+		delay:    opts.RetryDelay,
+		log:      r.retryLogger(),
+	}
+	publishedDesc, err := r.publishManifest(ctx, opts.ManifestType, func(manifestType ManifestType) (ocispec.Descriptor, error) {
+		root, err := packManifest(ctx, src, manifestType, descs, manifestConfigDesc, subject, annotations)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if err := src.Tag(ctx, root, root.Digest.String()); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		return oras.Copy(ctx, src, root.Digest.String(), dst, "", copyOpts)
+	})
 	if err != nil {
 		return err
 	}
@@ -91,17 +132,151 @@ func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage,
 	if err := r.UpdateIndex(ctx, r.Repo().Reference.Reference, publishedDesc); err != nil {
 		return err
 	}
+	if err := session.done(); err != nil {
+		return err
+	}
+
+	progressBar.Successf("Published %s [%s]", r.Repo().Reference, ZarfLayerMediaTypeBlob)
+	return nil
+}
+
+// LayerStreamer streams the files of a package, calling push for every file with its path relative to the package root.
+type LayerStreamer func(push func(name string, r io.Reader) error) error
+
+// NewLayerDescriptor returns the descriptor of a package layer with the given path relative to the package root.
+func NewLayerDescriptor(name, sha256Sum string, size int64) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType: ZarfLayerMediaTypeBlob,
+		Digest:    digest.NewDigestFromEncoded(digest.SHA256, sha256Sum),
+		Size:      size,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: name,
+		},
+	}
+}
+
+// PublishPackageStream publishes the zarf package to the remote repository with the layer content streamed
+// from an existing package archive instead of a package directory. The registry verifies every layer against its descriptor.
+// The archive is streamed again when a layer push fails, skipping the layers that have already been pushed.
+func (r *Remote) PublishPackageStream(ctx context.Context, pkg *v1alpha1.ZarfPackage, descs []ocispec.Descriptor, stream LayerStreamer, opts PublishOptions) (err error) {
+	r.Log().Info(fmt.Sprintf("Publishing package to %s", r.Repo().Reference))
+
+	layers := map[string]ocispec.Descriptor{}
+	for _, desc := range descs {
+		layers[desc.Annotations[ocispec.AnnotationTitle]] = desc
+	}
+
+	annotations := AnnotationsFromMetadata(&pkg.Metadata)
+
+	// assumes referrers API is not supported since OCI artifact
+	// media type is not supported
+	err = r.Repo().SetReferrersCapability(false)
+	if err != nil {
+		return err
+	}
+
+	manifestConfigDesc, err := r.CreateAndPushManifestConfig(ctx, annotations, ZarfConfigMediaType)
+	if err != nil {
+		return err
+	}
+	total := oci.SumDescsSize(descs) + manifestConfigDesc.Size
+
+	progressBar := message.NewProgressBar(total, fmt.Sprintf("Publishing %s:%s", r.Repo().Reference.Repository, r.Repo().Reference.Reference))
+	defer func(progressBar *message.ProgressBar) {
+		err2 := progressBar.Close()
+		err = errors.Join(err, err2)
+	}(progressBar)
+	progressBar.Add(int(manifestConfigDesc.Size))
+
+	session, err := loadPublishSession(opts.CachePath, r.Repo().Reference.String(), opts.Resume)
+	if err != nil {
+		return err
+	}
+	pushed := map[string]bool{}
+	for name, desc := range layers {
+		if session.isCompleted(desc) {
+			pushed[name] = true
+			progressBar.Add(int(desc.Size))
+		}
+	}
+	if len(pushed) > 0 {
+		r.Log().Info(fmt.Sprintf("Resuming publish with %d of %d layers already pushed", len(pushed), len(layers)))
+	}
+
+	pushLayers := func() error {
+		return stream(func(name string, rd io.Reader) error {
+			desc, ok := layers[name]
+			if !ok || pushed[name] {
+				return nil
+			}
+			exists, err := r.Repo().Exists(ctx, desc)
+			if err != nil {
+				return err
+			}
+			if exists {
+				progressBar.Add(int(desc.Size))
+			} else if err := r.Repo().Push(ctx, desc, io.TeeReader(rd, progressBar)); err != nil {
+				return fmt.Errorf("unable to push layer %s: %w", name, err)
+			}
+			pushed[name] = true
+			return session.markCompleted(desc)
+		})
+	}
+	err = helpers.RetryWithContext(ctx, pushLayers, max(opts.Retries, 1), opts.RetryDelay, r.retryLogger())
+	if err != nil {
+		return err
+	}
+	for _, desc := range descs {
+		if name := desc.Annotations[ocispec.AnnotationTitle]; !pushed[name] {
+			return fmt.Errorf("layer %s was not found in the package", name)
+		}
+	}
+
+	subject, err := r.skeletonSubject(ctx, pkg)
+	if err != nil {
+		return err
+	}
+	var pusher content.Pusher = r.Repo()
+	if subject != nil {
+		pusher, err = r.subjectRepo()
+		if err != nil {
+			return err
+		}
+	}
+	root, err := r.publishManifest(ctx, opts.ManifestType, func(manifestType ManifestType) (ocispec.Descriptor, error) {
+		return packManifest(ctx, pusher, manifestType, descs, manifestConfigDesc, subject, annotations)
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.UpdateIndex(ctx, r.Repo().Reference.Reference, root); err != nil {
+		return err
+	}
+	if err := session.done(); err != nil {
+		return err
+	}
 
 	progressBar.Successf("Published %s [%s]", r.Repo().Reference, ZarfLayerMediaTypeBlob)
 	return nil
 }
 
-func annotationsFromMetadata(metadata *v1alpha1.ZarfMetadata) map[string]string {
+// retryLogger returns a logger for the attempts made when retrying a layer push.
+func (r *Remote) retryLogger() func(format string, args ...any) {
+	return func(format string, args ...any) {
+		r.Log().Warn(fmt.Sprintf(format, args...))
+	}
+}
+
+// AnnotationsFromMetadata returns the OCI manifest annotations for the package metadata so that registries can display the package information.
+func AnnotationsFromMetadata(metadata *v1alpha1.ZarfMetadata) map[string]string {
 	annotations := map[string]string{
 		ocispec.AnnotationTitle:       metadata.Name,
 		ocispec.AnnotationDescription: metadata.Description,
 	}
 
+	if version := metadata.Version; version != "" {
+		annotations[ocispec.AnnotationVersion] = version
+	}
 	if url := metadata.URL; url != "" {
 		annotations[ocispec.AnnotationURL] = url
 	}
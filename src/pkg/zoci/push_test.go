@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+)
+
+func TestPublishPackageStream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newRegistry := func() string {
+		srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		t.Cleanup(srv.Close)
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+		return u.Host
+	}
+
+	files := map[string][]byte{
+		"zarf.yaml":             []byte("kind: ZarfPackageConfig\n"),
+		"components/hello.tar":  []byte("hello world"),
+		"images/blobs/sha256/a": []byte("image layer"),
+	}
+	descs := []ocispec.Descriptor{}
+	for _, name := range []string{"components/hello.tar", "zarf.yaml"} {
+		sum := sha256.Sum256(files[name])
+		descs = append(descs, NewLayerDescriptor(name, hex.EncodeToString(sum[:]), int64(len(files[name]))))
+	}
+	stream := func(push func(name string, r io.Reader) error) error {
+		for _, name := range []string{"zarf.yaml", "images/blobs/sha256/a", "components/hello.tar"} {
+			if err := push(name, bytes.NewReader(files[name])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	pkg := &v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}}
+	platform := oci.PlatformForArch("amd64")
+
+	remote, err := NewRemote(ctx, newRegistry()+"/test:0.0.1", platform, oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackageStream(ctx, pkg, descs, stream, PublishOptions{})
+	require.NoError(t, err)
+
+	root, err := remote.FetchRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, descs, root.Layers)
+	for _, desc := range descs {
+		b, err := remote.FetchLayer(ctx, desc)
+		require.NoError(t, err)
+		require.Equal(t, files[desc.Annotations[ocispec.AnnotationTitle]], b)
+	}
+
+	// Failed pushes are retried with the archive streamed again.
+	attempts := 0
+	flakyStream := func(push func(name string, r io.Reader) error) error {
+		attempts++
+		if err := push("zarf.yaml", bytes.NewReader(files["zarf.yaml"])); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return errors.New("unexpected EOF")
+		}
+		return push("components/hello.tar", bytes.NewReader(files["components/hello.tar"]))
+	}
+	remote, err = NewRemote(ctx, newRegistry()+"/retry:0.0.1", platform, oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackageStream(ctx, pkg, descs, flakyStream, PublishOptions{Retries: 2, RetryDelay: time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+
+	// Content that does not match its descriptor is rejected by the registry.
+	files["zarf.yaml"] = []byte("kind: Corrupted\n")
+	remote, err = NewRemote(ctx, newRegistry()+"/corrupted:0.0.1", platform, oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackageStream(ctx, pkg, descs, stream, PublishOptions{})
+	require.ErrorContains(t, err, "unable to push layer zarf.yaml")
+
+	// Layers missing from the stream fail the publish before the manifest is pushed.
+	missing := append(descs[:1:1], NewLayerDescriptor("sboms.tar", hex.EncodeToString(make([]byte, 32)), 1))
+	remote, err = NewRemote(ctx, newRegistry()+"/missing:0.0.1", platform, oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackageStream(ctx, pkg, missing, func(push func(string, io.Reader) error) error {
+		return push("components/hello.tar", bytes.NewReader([]byte("hello world")))
+	}, PublishOptions{})
+	require.EqualError(t, err, "layer sboms.tar was not found in the package")
+}
+
+func TestPublishPackageResume(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, layout.ZarfYAML), []byte("kind: ZarfPackageConfig\n"), 0o600)
+	require.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(dir, layout.ComponentsDir), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, layout.ComponentsDir, "hello.tar"), []byte("hello world"), 0o600)
+	require.NoError(t, err)
+	paths := layout.New(dir)
+	paths.SetFromPaths([]string{layout.ZarfYAML, "components/hello.tar"})
+	_, err = paths.GenerateChecksums()
+	require.NoError(t, err)
+
+	pkg := &v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}}
+	reference := u.Host + "/test:0.0.1"
+	opts := PublishOptions{Concurrency: 1, Retries: 1, CachePath: t.TempDir()}
+
+	remote, err := NewRemote(ctx, reference, oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackage(ctx, pkg, paths, opts)
+	require.NoError(t, err)
+
+	// Simulate a previous session that failed after pushing the component layer.
+	session, err := loadPublishSession(opts.CachePath, remote.Repo().Reference.String(), true)
+	require.NoError(t, err)
+	require.Empty(t, session.Completed)
+	b, err := os.ReadFile(filepath.Join(dir, layout.ComponentsDir, "hello.tar"))
+	require.NoError(t, err)
+	err = session.markCompleted(content.NewDescriptorFromBytes(ZarfLayerMediaTypeBlob, b))
+	require.NoError(t, err)
+
+	opts.Resume = true
+	remote, err = NewRemote(ctx, reference, oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackage(ctx, pkg, paths, opts)
+	require.NoError(t, err)
+	root, err := remote.FetchRoot(ctx)
+	require.NoError(t, err)
+	require.Len(t, root.Layers, 3)
+
+	session, err = loadPublishSession(opts.CachePath, remote.Repo().Reference.String(), true)
+	require.NoError(t, err)
+	require.Empty(t, session.Completed)
+}
+
+func TestAnnotationsFromMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := v1alpha1.ZarfMetadata{
+		Name:        "test",
+		Description: "a test package",
+		Version:     "0.0.1",
+		URL:         "https://example.com",
+		Authors:     "zarf",
+		Vendor:      "legacy vendor",
+		Annotations: map[string]string{
+			ocispec.AnnotationVendor: "vendor",
+			"dev.zarf.custom":        "value",
+		},
+	}
+	expected := map[string]string{
+		ocispec.AnnotationTitle:       "test",
+		ocispec.AnnotationDescription: "a test package",
+		ocispec.AnnotationVersion:     "0.0.1",
+		ocispec.AnnotationURL:         "https://example.com",
+		ocispec.AnnotationAuthors:     "zarf",
+		ocispec.AnnotationVendor:      "vendor",
+		"dev.zarf.custom":             "value",
+	}
+	require.Equal(t, expected, AnnotationsFromMetadata(&metadata))
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// PackageSummary describes a Zarf package found in a registry.
+type PackageSummary struct {
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Architecture string `json:"architecture"`
+	Description  string `json:"description,omitempty"`
+	Reference    string `json:"reference"`
+}
+
+// SearchPackages lists the Zarf packages in the registry of the given URL.
+// Only repositories within the path of the URL are searched. Registries that do not support listing
+// their repositories can still be searched when the URL points to a single repository.
+func SearchPackages(ctx context.Context, url string, mods ...oci.Modifier) ([]PackageSummary, error) {
+	host, repoPrefix, _ := strings.Cut(strings.Trim(strings.TrimPrefix(url, helpers.OCIURLPrefix), "/"), "/")
+	ref := registry.Reference{
+		Registry:   host,
+		Repository: repoPrefix,
+	}
+	if err := ref.ValidateRegistry(); err != nil {
+		return nil, err
+	}
+	// The remote is only used to get a client with the configured credentials for the registry.
+	r, err := NewRemote(ctx, path.Join(host, "zarf"), PlatformForSkeleton(), mods...)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := remote.NewRegistry(r.Repo().Reference.Registry)
+	if err != nil {
+		return nil, err
+	}
+	reg.Client = r.Repo().Client
+	reg.PlainHTTP = r.Repo().PlainHTTP
+
+	repositories := []string{}
+	err = reg.Repositories(ctx, "", func(repos []string) error {
+		for _, repo := range repos {
+			if ref.Repository == "" || repo == ref.Repository || strings.HasPrefix(repo, ref.Repository+"/") {
+				repositories = append(repositories, repo)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if ref.Repository == "" {
+			return nil, fmt.Errorf("unable to list the repositories of %s: %w", ref.Registry, err)
+		}
+		r.Log().Debug(fmt.Sprintf("Unable to list the repositories of %s, searching %s only: %s", ref.Registry, ref.Repository, err))
+		repositories = []string{ref.Repository}
+	}
+
+	summaries := []PackageSummary{}
+	for _, repoName := range repositories {
+		repo, err := reg.Repository(ctx, repoName)
+		if err != nil {
+			return nil, err
+		}
+		found, err := searchRepository(ctx, repo, path.Join(ref.Registry, repoName))
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, found...)
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Reference < summaries[j].Reference
+	})
+	return summaries, nil
+}
+
+// searchRepository returns the Zarf packages in all tags of a repository.
+func searchRepository(ctx context.Context, repo registry.Repository, repoRef string) ([]PackageSummary, error) {
+	summaries := []PackageSummary{}
+	tags := []string{}
+	err := repo.Tags(ctx, "", func(t []string) error {
+		tags = append(tags, t...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the tags of %s: %w", repoRef, err)
+	}
+	for _, tag := range tags {
+		desc, err := repo.Resolve(ctx, tag)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve %s:%s: %w", repoRef, tag, err)
+		}
+		manifests := []ocispec.Descriptor{desc}
+		if desc.MediaType == ocispec.MediaTypeImageIndex {
+			var index ocispec.Index
+			if err := fetchJSON(ctx, repo, desc, &index); err != nil {
+				return nil, err
+			}
+			manifests = index.Manifests
+		}
+		for _, manifestDesc := range manifests {
+			summary, ok, err := summarizeManifest(ctx, repo, manifestDesc)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read %s:%s: %w", repoRef, tag, err)
+			}
+			if !ok {
+				continue
+			}
+			if summary.Version == "" {
+				// Packages published before the version annotation was added are tagged with their version.
+				summary.Version = tag
+			}
+			summary.Reference = fmt.Sprintf("%s:%s", repoRef, tag)
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// summarizeManifest returns the summary of a Zarf package manifest, manifests of other artifacts are skipped.
+func summarizeManifest(ctx context.Context, repo registry.Repository, desc ocispec.Descriptor) (PackageSummary, bool, error) {
+	if desc.MediaType != ocispec.MediaTypeImageManifest {
+		return PackageSummary{}, false, nil
+	}
+	var manifest ocispec.Manifest
+	if err := fetchJSON(ctx, repo, desc, &manifest); err != nil {
+		return PackageSummary{}, false, err
+	}
+	if manifest.Config.MediaType != ZarfConfigMediaType && manifest.ArtifactType != ZarfArtifactType {
+		return PackageSummary{}, false, nil
+	}
+	var cfg oci.ConfigPartial
+	if err := fetchJSON(ctx, repo, manifest.Config, &cfg); err != nil {
+		return PackageSummary{}, false, err
+	}
+	arch := cfg.Architecture
+	if desc.Platform != nil && desc.Platform.Architecture != "" {
+		arch = desc.Platform.Architecture
+	}
+	summary := PackageSummary{
+		Name:         manifest.Annotations[ocispec.AnnotationTitle],
+		Version:      manifest.Annotations[ocispec.AnnotationVersion],
+		Architecture: arch,
+		Description:  manifest.Annotations[ocispec.AnnotationDescription],
+	}
+	return summary, true, nil
+}
+
+// fetchJSON fetches and decodes the JSON content of the descriptor.
+func fetchJSON(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor, v any) error {
+	b, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestSearchPackages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	zarfYAML := []byte("kind: ZarfPackageConfig\n")
+	sum := sha256.Sum256(zarfYAML)
+	descs := []ocispec.Descriptor{NewLayerDescriptor("zarf.yaml", hex.EncodeToString(sum[:]), int64(len(zarfYAML)))}
+	stream := func(push func(name string, r io.Reader) error) error {
+		return push("zarf.yaml", bytes.NewReader(zarfYAML))
+	}
+	publish := func(name, version, arch string, manifestType ManifestType) {
+		pkg := &v1alpha1.ZarfPackage{
+			Metadata: v1alpha1.ZarfMetadata{
+				Name:        name,
+				Version:     version,
+				Description: "the " + name + " package",
+			},
+		}
+		r, err := NewRemote(ctx, u.Host+"/packages/"+name+":"+version, oci.PlatformForArch(arch), oci.WithPlainHTTP(true))
+		require.NoError(t, err)
+		err = r.PublishPackageStream(ctx, pkg, descs, stream, PublishOptions{ManifestType: manifestType})
+		require.NoError(t, err)
+	}
+	publish("hello", "0.0.1", "amd64", ManifestTypeImage)
+	publish("world", "1.0.0", "arm64", ManifestTypeArtifact)
+
+	// Images that are not Zarf packages are skipped.
+	repo, err := remote.NewRepository(u.Host + "/images/nginx")
+	require.NoError(t, err)
+	repo.PlainHTTP = true
+	layer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("layer"))
+	err = repo.Push(ctx, layer, bytes.NewReader([]byte("layer")))
+	require.NoError(t, err)
+	root, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, "application/vnd.example", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	require.NoError(t, err)
+	err = repo.Tag(ctx, root, "latest")
+	require.NoError(t, err)
+
+	expected := []PackageSummary{
+		{
+			Name:         "hello",
+			Version:      "0.0.1",
+			Architecture: "amd64",
+			Description:  "the hello package",
+			Reference:    u.Host + "/packages/hello:0.0.1",
+		},
+		{
+			Name:         "world",
+			Version:      "1.0.0",
+			Architecture: "arm64",
+			Description:  "the world package",
+			Reference:    u.Host + "/packages/world:1.0.0",
+		},
+	}
+	summaries, err := SearchPackages(ctx, "oci://"+u.Host, oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	require.Equal(t, expected, summaries)
+
+	summaries, err = SearchPackages(ctx, "oci://"+u.Host+"/packages/world", oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	require.Equal(t, expected[1:], summaries)
+
+	summaries, err = SearchPackages(ctx, "oci://"+u.Host+"/images", oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	require.Empty(t, summaries)
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// PublishRetryDelay is the initial delay before retrying a failed layer push, it doubles with every attempt.
+	PublishRetryDelay = 2 * time.Second
+	// publishSessionDir is the directory within the Zarf cache that stores the progress of package publishes.
+	publishSessionDir = "publish"
+)
+
+// PublishOptions are the options for publishing a package.
+type PublishOptions struct {
+	// Concurrency is the number of layers pushed in parallel.
+	Concurrency int
+	// Retries is the number of attempts made to push a layer before the publish fails.
+	Retries int
+	// RetryDelay is the initial delay between attempts to push a layer.
+	RetryDelay time.Duration
+	// Resume reuses the progress of a previous failed publish to the same reference.
+	Resume bool
+	// CachePath is the directory the publish progress is recorded in. Progress is not recorded when empty.
+	CachePath string
+	// ManifestType is the type of manifest the package is published with.
+	ManifestType ManifestType
+}
+
+// publishSession tracks the layers pushed during a package publish so that a failed publish can be resumed.
+type publishSession struct {
+	mu        sync.Mutex
+	path      string
+	Reference string   `json:"reference"`
+	Completed []string `json:"completed"`
+}
+
+// loadPublishSession returns the publish session for the given reference.
+// A previous session is only reused when resuming, otherwise a new session is started.
+// Sessions are only kept in memory when no cache path is set.
+func loadPublishSession(cachePath, reference string, resume bool) (*publishSession, error) {
+	if cachePath == "" {
+		return &publishSession{Reference: reference, Completed: []string{}}, nil
+	}
+	sum := sha256.Sum256([]byte(reference))
+	path := filepath.Join(cachePath, publishSessionDir, hex.EncodeToString(sum[:])+".json")
+	session := &publishSession{
+		path:      path,
+		Reference: reference,
+		Completed: []string{},
+	}
+	if !resume {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return session, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return session, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, session); err != nil {
+		return nil, fmt.Errorf("unable to read the publish session %s: %w", path, err)
+	}
+	return session, nil
+}
+
+// isCompleted returns true if the layer was pushed during this or a previous session.
+func (s *publishSession) isCompleted(desc ocispec.Descriptor) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.Completed, desc.Digest.String())
+}
+
+// markCompleted records the layer as pushed and saves the session.
+func (s *publishSession) markCompleted(desc ocispec.Descriptor) error {
+	// Manifests are pushed last and are recreated on every publish.
+	if desc.MediaType != ZarfLayerMediaTypeBlob {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if slices.Contains(s.Completed, desc.Digest.String()) {
+		return nil
+	}
+	s.Completed = append(s.Completed, desc.Digest.String())
+	if s.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := helpers.CreateDirectory(filepath.Dir(s.path), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, b, helpers.ReadWriteUser)
+}
+
+// done removes the session once the publish has completed.
+func (s *publishSession) done() error {
+	if s.path == "" {
+		return nil
+	}
+	err := os.Remove(s.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// withPublishSession updates the copy options to record the completed layers in the session
+// and to skip the layers that were completed by a previous session.
+func withPublishSession(copyOpts oras.CopyOptions, session *publishSession) oras.CopyOptions {
+	postCopy := copyOpts.PostCopy
+	copyOpts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if postCopy != nil {
+			if err := postCopy(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return session.markCompleted(desc)
+	}
+	onCopySkipped := copyOpts.OnCopySkipped
+	copyOpts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if onCopySkipped != nil {
+			if err := onCopySkipped(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return session.markCompleted(desc)
+	}
+	copyOpts.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		successors, err := content.Successors(ctx, fetcher, desc)
+		if err != nil {
+			return nil, err
+		}
+		return slices.DeleteFunc(successors, session.isCompleted), nil
+	}
+	return copyOpts
+}
+
+// retryTarget retries failed pushes to the target with an exponential backoff.
+// Failed pushes are retried with the content fetched again from the source.
+type retryTarget struct {
+	oras.Target
+	src      content.Fetcher
+	attempts int
+	delay    time.Duration
+	log      func(format string, args ...any)
+}
+
+// Push pushes the content to the target, retrying with content fetched from the source if the push fails.
+// Only layers are retried, manifests are small and rejected manifests are handled by the manifest type fallback.
+func (t *retryTarget) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	if desc.MediaType != ZarfLayerMediaTypeBlob {
+		return t.push(ctx, desc, r)
+	}
+	first := true
+	return helpers.RetryWithContext(ctx, func() error {
+		if first {
+			first = false
+			return t.push(ctx, desc, r)
+		}
+		rc, err := t.src.Fetch(ctx, desc)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		return t.push(ctx, desc, rc)
+	}, max(t.attempts, 1), t.delay, t.log)
+}
+
+func (t *retryTarget) push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	err := t.Target.Push(ctx, desc, r)
+	if errors.Is(err, errdef.ErrAlreadyExists) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestPublishSession(t *testing.T) {
+	t.Parallel()
+
+	cachePath := t.TempDir()
+	reference := "localhost:5000/test:0.0.1"
+	layer := content.NewDescriptorFromBytes(ZarfLayerMediaTypeBlob, []byte("layer"))
+	manifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("manifest"))
+
+	session, err := loadPublishSession(cachePath, reference, false)
+	require.NoError(t, err)
+	require.NoError(t, session.markCompleted(layer))
+	require.NoError(t, session.markCompleted(manifest))
+	require.True(t, session.isCompleted(layer))
+	require.False(t, session.isCompleted(manifest))
+
+	resumed, err := loadPublishSession(cachePath, reference, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{layer.Digest.String()}, resumed.Completed)
+
+	other, err := loadPublishSession(cachePath, "localhost:5000/other:0.0.1", true)
+	require.NoError(t, err)
+	require.Empty(t, other.Completed)
+
+	restarted, err := loadPublishSession(cachePath, reference, false)
+	require.NoError(t, err)
+	require.Empty(t, restarted.Completed)
+	resumed, err = loadPublishSession(cachePath, reference, true)
+	require.NoError(t, err)
+	require.Empty(t, resumed.Completed)
+
+	require.NoError(t, session.markCompleted(layer))
+	require.NoError(t, session.done())
+	resumed, err = loadPublishSession(cachePath, reference, true)
+	require.NoError(t, err)
+	require.Empty(t, resumed.Completed)
+}
+
+type flakyTarget struct {
+	*memory.Store
+	failures int
+}
+
+func (t *flakyTarget) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	if t.failures > 0 {
+		t.failures--
+		// Consume part of the content like an interrupted upload would.
+		_, _ = io.CopyN(io.Discard, r, 1)
+		return errors.New("connection reset by peer")
+	}
+	return t.Store.Push(ctx, desc, r)
+}
+
+func TestRetryTarget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := []byte("layer content")
+	desc := content.NewDescriptorFromBytes(ZarfLayerMediaTypeBlob, b)
+	src := memory.New()
+	require.NoError(t, src.Push(ctx, desc, bytes.NewReader(b)))
+
+	dst := &flakyTarget{Store: memory.New(), failures: 2}
+	target := &retryTarget{Target: dst, src: src, attempts: 3, delay: time.Millisecond, log: t.Logf}
+	err := target.Push(ctx, desc, bytes.NewReader(b))
+	require.NoError(t, err)
+	exists, err := dst.Exists(ctx, desc)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	dst = &flakyTarget{Store: memory.New(), failures: 3}
+	target = &retryTarget{Target: dst, src: src, attempts: 3, delay: time.Millisecond, log: t.Logf}
+	err = target.Push(ctx, desc, bytes.NewReader(b))
+	require.EqualError(t, err, "connection reset by peer")
+}
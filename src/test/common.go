@@ -21,7 +21,7 @@ import (
 	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory" // used for docker test registry
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
-	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+	zarftesting "github.com/zarf-dev/zarf/src/pkg/testing"
 )
 
 // ZarfE2ETest Struct holding common fields most of the tests will utilize.
@@ -74,20 +74,6 @@ func (e2e *ZarfE2ETest) Zarf(t *testing.T, args ...string) (_ string, _ string,
 
 // ZarfInDir executes a Zarf command in specific directory.
 func (e2e *ZarfE2ETest) ZarfInDir(t *testing.T, dir string, args ...string) (_ string, _ string, err error) {
-	if !slices.Contains(args, "tools") {
-		args = append(args, "--log-format=console", "--no-color")
-	}
-	if !slices.Contains(args, "--tmpdir") && !slices.Contains(args, "tools") {
-		tmpdir, err := os.MkdirTemp("", "zarf-")
-		if err != nil {
-			return "", "", err
-		}
-		defer func(path string) {
-			errRemove := os.RemoveAll(path)
-			err = errors.Join(err, errRemove)
-		}(tmpdir)
-		args = append(args, "--tmpdir", tmpdir)
-	}
 	if !slices.Contains(args, "--zarf-cache") && !slices.Contains(args, "tools") && os.Getenv("CI") == "true" {
 		// We make the cache dir relative to the working directory to make it work on the Windows Runners
 		// - they use two drives which filepath.Rel cannot cope with.
@@ -105,9 +91,8 @@ func (e2e *ZarfE2ETest) ZarfInDir(t *testing.T, dir string, args ...string) (_ s
 			err = errors.Join(err, errRemove)
 		}(cacheDir)
 	}
-	cfg := exec.PrintCfg()
-	cfg.Dir = dir
-	return exec.CmdWithTesting(t, cfg, e2e.ZarfBinPath, args...)
+	z := zarftesting.Zarf{BinPath: e2e.ZarfBinPath, Dir: dir}
+	return z.Run(t, args...)
 }
 
 // Kubectl executes `zarf tools kubectl ...`
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package types
+
+// JSONIOVersion is the version of the JSON documents read and written by `zarf package deploy --json-io`.
+// It is only incremented when fields are removed or change meaning.
+const JSONIOVersion = "v1"
+
+// DeployAction is the operation requested from `zarf package deploy --json-io`.
+type DeployAction string
+
+const (
+	// DeployActionPlan reports the changes a deployment would make without deploying.
+	DeployActionPlan DeployAction = "plan"
+	// DeployActionApply deploys the package when it differs from the deployed package.
+	DeployActionApply DeployAction = "apply"
+	// DeployActionRead returns the deployed package without deploying.
+	DeployActionRead DeployAction = "read"
+)
+
+// ComponentAction is the change a deployment makes to a component.
+type ComponentAction string
+
+const (
+	// ComponentActionCreate deploys a component that is not deployed.
+	ComponentActionCreate ComponentAction = "create"
+	// ComponentActionUpdate deploys a component from a different build of the package.
+	ComponentActionUpdate ComponentAction = "update"
+	// ComponentActionNoOp leaves a component deployed from the same build of the package as is.
+	ComponentActionNoOp ComponentAction = "no-op"
+	// ComponentActionUntrack drops a deployed component that is not selected from the deployed package record,
+	// its resources are left in the cluster.
+	ComponentActionUntrack ComponentAction = "untrack"
+)
+
+// ComponentPlan is the change a deployment makes to a single component.
+type ComponentPlan struct {
+	Name   string          `json:"name"`
+	Action ComponentAction `json:"action"`
+}
+
+// DeployPlan describes the changes deploying a package makes to the package deployed in the cluster.
+type DeployPlan struct {
+	Package         string          `json:"package"`
+	Version         string          `json:"version,omitempty"`
+	DeployedVersion string          `json:"deployedVersion,omitempty"`
+	Components      []ComponentPlan `json:"components"`
+}
+
+// HasChanges returns true when deploying the package changes any component.
+func (p DeployPlan) HasChanges() bool {
+	for _, c := range p.Components {
+		if c.Action != ComponentActionNoOp {
+			return true
+		}
+	}
+	return false
+}
+
+// DeployInput is the request read by `zarf package deploy --json-io`.
+type DeployInput struct {
+	// Version of the document, defaults to the current version
+	Version string `json:"version,omitempty"`
+	// Operation to perform, defaults to apply
+	Action DeployAction `json:"action,omitempty"`
+	// Name of the deployed package to read
+	Name string `json:"name,omitempty"`
+	// Path, URL or OCI reference of the package to plan or apply
+	Source string `json:"source,omitempty"`
+	// Comma separated list of optional components to deploy
+	Components string `json:"components,omitempty"`
+	// Deploy time variables to set
+	SetVariables map[string]string `json:"setVariables,omitempty"`
+	// SHA256 checksum of the package
+	Shasum string `json:"shasum,omitempty"`
+	// Number of retries for operations like image pushes or Helm installs
+	Retries int `json:"retries,omitempty"`
+	// Timeout of Helm operations such as "15m"
+	Timeout string `json:"timeout,omitempty"`
+	// Adopt pre-existing resources into the Helm charts managed by Zarf
+	AdoptExistingResources bool `json:"adoptExistingResources,omitempty"`
+	// Mint pull credentials scoped to the package instead of using the ones generated during init
+	ScopedCredentials bool `json:"scopedCredentials,omitempty"`
+	// Kube contexts of the component cluster aliases
+	ClusterContexts map[string]string `json:"clusterContexts,omitempty"`
+	// Values profiles of the chart values layers to apply
+	ValuesProfiles []string `json:"valuesProfiles,omitempty"`
+	// Skip validating the signature of the package
+	SkipSignatureValidation bool `json:"skipSignatureValidation,omitempty"`
+	// Deploy even when the plan has no changes, such as to apply different variables
+	Force bool `json:"force,omitempty"`
+}
+
+// DeployOutput is the response written by `zarf package deploy --json-io`.
+type DeployOutput struct {
+	Version string       `json:"version"`
+	Action  DeployAction `json:"action"`
+	// Whether the cluster was changed
+	Changed bool `json:"changed"`
+	// Changes the deployment makes, only set for plan and apply
+	Plan *DeployPlan `json:"plan,omitempty"`
+	// Deployed package after the action, null when the package is not deployed
+	Package *DeployedPackage `json:"package"`
+	Error   string           `json:"error,omitempty"`
+}
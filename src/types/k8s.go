@@ -6,6 +6,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
@@ -36,6 +37,13 @@ const (
 
 	ZarfInClusterGitServiceURL      = "http://zarf-gitea-http.zarf.svc.cluster.local:3000"
 	ZarfInClusterArtifactServiceURL = ZarfInClusterGitServiceURL + "/api/packages/" + ZarfGitPushUser
+
+	// ZarfCredentialLifetime is how long registry, git and artifact credentials are used before they should be rotated
+	ZarfCredentialLifetime = 365 * 24 * time.Hour
+	// ZarfCredentialExpiryWarning is how long before expiry Zarf starts warning about credentials
+	ZarfCredentialExpiryWarning = 30 * 24 * time.Hour
+	// IPV6Localhost is the loopback address tunnels listen on when the host can not listen on the IPv4 loopback address
+	IPV6Localhost = "::1"
 )
 
 // GeneratedPKI is a struct for storing generated PKI data.
@@ -64,6 +72,36 @@ type ZarfState struct {
 	RegistryInfo RegistryInfo `json:"registryInfo"`
 	// Information about the artifact registry Zarf is configured to use
 	ArtifactServer ArtifactServerInfo `json:"artifactServer"`
+	// Creation and expiry timestamps of the credentials Zarf manages, keyed by service (agent, registry, git, artifact)
+	CredentialTimestamps map[string]CredentialTimestamps `json:"credentialTimestamps,omitempty"`
+	// Trusted publishers that packages deployed to the cluster must be signed by
+	VerificationPolicy VerificationPolicy `json:"verificationPolicy,omitempty"`
+}
+
+// VerificationPolicy maps packages to the public keys of their trusted publishers.
+type VerificationPolicy struct {
+	// Rules for which keys packages must be signed with
+	Rules []VerificationRule `json:"rules,omitempty"`
+	// PEM encoded certificate chains of the trusted RFC3161 timestamp authorities, starting with the timestamp authority certificate and ending with the root
+	TimestampAuthorities []string `json:"timestampAuthorities,omitempty"`
+}
+
+// VerificationRule requires packages matching any of its package names or repositories to be signed by one of its keys.
+type VerificationRule struct {
+	// Glob patterns matched against the package name (metadata.name)
+	Packages []string `json:"packages,omitempty"`
+	// Glob patterns matched against the repository of OCI sources, without the oci:// prefix and the tag (e.g. ghcr.io/my-org/*)
+	Repositories []string `json:"repositories,omitempty"`
+	// Public keys of the trusted publishers, either PEM encoded or a Cosign-supported key provider reference
+	Keys []string `json:"keys"`
+	// Time after which the keys are no longer trusted, signatures timestamped before it by a trusted timestamp authority remain valid
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+}
+
+// CredentialTimestamps tracks when a set of credentials was created and when it should be rotated.
+type CredentialTimestamps struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
 // DeployedPackage contains information about a Zarf Package that has been deployed to a cluster
@@ -74,6 +112,33 @@ type DeployedPackage struct {
 	CLIVersion         string               `json:"cliVersion"`
 	DeployedComponents []DeployedComponent  `json:"deployedComponents"`
 	ConnectStrings     ConnectStrings       `json:"connectStrings,omitempty"`
+	// Generation is incremented every time a different version of the package is deployed
+	Generation int `json:"generation,omitempty"`
+	// ScopedCredentials are the pull credentials minted for the package, they are revoked when the package is removed
+	ScopedCredentials *ScopedCredentials `json:"scopedCredentials,omitempty"`
+	// DeployedAt is when the package was last deployed, it is not set for packages deployed by older versions of Zarf
+	DeployedAt *time.Time `json:"deployedAt,omitempty"`
+	// Notes are the rendered notes of the package, printed after it was deployed
+	Notes string `json:"notes,omitempty"`
+	// Variables are the values of the non-sensitive variables the package was last deployed with, remove actions are templated with them
+	Variables map[string]string `json:"variables,omitempty"`
+	// SucceededAt is when the last deployment of the package succeeded
+	SucceededAt *time.Time `json:"succeededAt,omitempty"`
+	// DeployDuration is how long the last successful deployment of the package took
+	DeployDuration time.Duration `json:"deployDuration,omitempty"`
+	// FailedDeployments counts the deployments of the package that failed after it was first recorded
+	FailedDeployments int `json:"failedDeployments,omitempty"`
+}
+
+// ScopedCredentials are read-only credentials for the Zarf registry and git server that are minted for a single
+// package so that its workloads do not share the pull credentials generated during init.
+type ScopedCredentials struct {
+	// Username of the registry and git server users minted for the package
+	Username string `json:"username"`
+	// Password of the registry user, empty when the registry is not the internal Zarf registry
+	RegistryPassword string `json:"registryPassword,omitempty"`
+	// Read-only access token of the git server user, empty when the git server is not the internal Gitea server
+	GitToken string `json:"gitToken,omitempty"`
 }
 
 // ConnectString contains information about a connection made with Zarf connect.
@@ -91,6 +156,37 @@ type ConnectStrings map[string]ConnectString
 type DeployedComponent struct {
 	Name            string           `json:"name"`
 	InstalledCharts []InstalledChart `json:"installedCharts"`
+	// Notes are the rendered notes of the component, printed after it was deployed
+	Notes string `json:"notes,omitempty"`
+}
+
+// DeployResult describes a successful package deployment for automation, written by `zarf package deploy --output`.
+type DeployResult struct {
+	// Name of the deployed package
+	Package string `json:"package"`
+	// Version of the deployed package
+	Version string `json:"version,omitempty"`
+	// Components deployed and the chart releases they installed
+	Components []DeployedComponent `json:"components"`
+	// Connect strings of the charts installed by the deployment
+	ConnectStrings ConnectStrings `json:"connectStrings,omitempty"`
+	// Credentials generated for the Zarf services, only set when deploying an init package
+	Credentials []Credential `json:"credentials,omitempty"`
+	// Rendered notes of the package
+	Notes string `json:"notes,omitempty"`
+	// Warnings raised while loading and validating the package
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Credential is a generated credential of a Zarf service.
+type Credential struct {
+	Application string `json:"application"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	// Command to connect to the service
+	Connect string `json:"connect"`
+	// Key to print the credential with `zarf tools get-creds`
+	Key string `json:"key"`
 }
 
 // InstalledChart contains information about a Helm Chart that has been deployed to a cluster.
@@ -98,6 +194,8 @@ type InstalledChart struct {
 	Namespace      string         `json:"namespace"`
 	ChartName      string         `json:"chartName"`
 	ConnectStrings ConnectStrings `json:"connectStrings,omitempty"`
+	// Whether Zarf created the namespace, created namespaces are deleted on remove when nothing else is deployed to them
+	CreatedNamespace bool `json:"createdNamespace,omitempty"`
 }
 
 // GitServerInfo contains information Zarf uses to communicate with a git repository to push/pull repositories to.
@@ -5,6 +5,8 @@
 package types
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -24,6 +26,58 @@ type ZarfCommonOptions struct {
 	TempDirectory string
 	// Number of concurrent layer operations to perform when interacting with a remote package
 	OCIConcurrency int
+	// Path to the age key that decrypts the Zarf state and deployed package secrets when they are encrypted
+	StateKeyPath string
+	// How long downloads that are not pinned to a checksum are reused from the cache, zero disables reusing them
+	DownloadCacheTTL time.Duration
+	// Skip verifying the checksum of cached downloads before reusing them
+	SkipDownloadCacheVerify bool
+	// Maximum number of connections large files are downloaded over
+	DownloadConnections int
+	// Labels added to every resource Zarf creates in the cluster, labels set by Zarf or the package take precedence
+	Labels map[string]string
+	// Annotations added to every resource Zarf creates in the cluster, annotations set by Zarf or the package take precedence
+	Annotations map[string]string
+	// Credentials of the private helm chart repositories that charts are downloaded from
+	ChartRepoCredentials []ChartRepoCredential
+}
+
+// ChartRepoCredential authenticates the downloads of published helm charts from a private chart repository, such as a
+// ChartMuseum or Nexus repository, configured in the Zarf config file.
+type ChartRepoCredential struct {
+	// Name of the credential
+	Name string
+	// URL prefix of the charts the credential is used for (e.g. https://nexus.example.com/repository/helm)
+	URL string
+	// Username used with basic authentication
+	Username string
+	// Password used with basic authentication
+	Password string
+	// Bearer token used instead of the username and password, for OCI registries it is used as the password
+	Token string
+	// Path of the CA bundle that verifies the certificate of the repository
+	CAFile string
+	// Path of the client certificate used for mutual TLS
+	CertFile string
+	// Path of the key of the client certificate
+	KeyFile string
+}
+
+// MatchChartRepoCredential returns the credential with the longest URL that the chart URL is equal to or nested under.
+func MatchChartRepoCredential(creds []ChartRepoCredential, chartURL string) (ChartRepoCredential, bool) {
+	var match ChartRepoCredential
+	found := false
+	for _, cred := range creds {
+		prefix := strings.TrimSuffix(cred.URL, "/")
+		if prefix == "" || (chartURL != prefix && !strings.HasPrefix(chartURL, prefix+"/")) {
+			continue
+		}
+		if !found || len(cred.URL) > len(match.URL) {
+			match = cred
+			found = true
+		}
+	}
+	return match, found
 }
 
 // ZarfPackageOptions tracks the user-defined preferences during common package operations.
@@ -38,12 +92,28 @@ type ZarfPackageOptions struct {
 	SGetKeyPath string
 	// Key-Value map of variable names and their corresponding values that will be used to template manifests and files in the Zarf package
 	SetVariables map[string]string
+	// Names of the variables in SetVariables that were set in the Zarf config file instead of with a flag
+	ConfigVariables []string
+	// Variables set by the packages deployed before this one in a bundle, only applied to the variables the package declares
+	SharedVariables map[string]string
 	// Location where the public key component of a cosign key-pair can be found
 	PublicKeyPath string
 	// The number of retries to perform for Zarf deploy operations like image pushes or Helm installs
 	Retries int
 	// Skip validating the signature of the Zarf package
 	SkipSignatureValidation bool
+	// Location of the verification policy mapping packages to the keys of their trusted publishers
+	VerificationPolicyPath string
+	// Trusted publishers the package must be signed by when no public key is provided
+	VerificationPolicy VerificationPolicy
+	// Location where the public key that signed the attestations of the package can be found
+	AttestationKeyPath string
+	// Verify the attestations of the package keyless against the certificate identity instead of with a key
+	KeylessAttestations bool
+	// Identity the certificate of a keyless package signature must be issued to
+	CertificateIdentity string
+	// OIDC issuer that authenticated the identity of a keyless package signature
+	CertificateOIDCIssuer string
 }
 
 // ZarfInspectOptions tracks the user-defined preferences during a package inspection.
@@ -54,6 +124,14 @@ type ZarfInspectOptions struct {
 	SBOMOutputDir string
 	// ListImages will list the images in the package
 	ListImages bool
+	// ListAnnotations will list the OCI manifest annotations of the package
+	ListAnnotations bool
+	// ListSizes will list the size and image digests of each component in the package
+	ListSizes bool
+	// ListSignatures will list the signatures of the package and their timestamps
+	ListSignatures bool
+	// ChartSnapshots will print the rendered manifests of the charts snapshotted when the package was created
+	ChartSnapshots bool
 }
 
 // ZarfFindImagesOptions tracks the user-defined preferences during a prepare find-images search.
@@ -76,6 +154,24 @@ type ZarfDeployOptions struct {
 	AdoptExistingResources bool
 	// Timeout for performing Helm operations
 	Timeout time.Duration
+	// ReadinessTimeout is how long to wait for the resources of a component to be ready, defaults to Timeout
+	ReadinessTimeout time.Duration
+	// Whether to mint pull credentials scoped to the package instead of using the ones generated during init
+	ScopedCredentials bool
+	// Fail the deploy instead of warning when Zarf managed credentials are expired or near expiry
+	StrictCredentialExpiry bool
+	// A map of component cluster aliases to the kube contexts of the clusters
+	ClusterContexts map[string]string
+	// The values profiles of the chart values layers to apply
+	ValuesProfiles []string
+	// Whether to skip pushing images to the registry, for registries where the images are staged by other tooling
+	SkipImagePush bool
+	// Whether to list the images that would be pushed to the registry instead of pushing them
+	ImagePushDryRun bool
+	// Helm chart values to set on deploy as CHART_NAME.KEY=VALUE, using the format of helm --set
+	SetHelmValues []string
+	// Path to an executable that post-renders the manifests of every chart after Zarf templates them
+	PostRenderer string
 	// [Library Only] A map of component names to chart names containing Helm Chart values to override values on deploy
 	ValuesOverridesMap map[string]map[string]map[string]interface{}
 	// [Dev Deploy Only] Manual override for ###ZARF_REGISTRY###
@@ -96,6 +192,20 @@ type ZarfPublishOptions struct {
 	SigningKeyPassword string
 	// Location where the private key component of a cosign key-pair can be found
 	SigningKeyPath string
+	// URL of the RFC3161 timestamp authority that timestamps the signatures
+	TSAURL string
+	// Whether to sign the package keyless with a Fulcio certificate for an OIDC identity instead of a signing key
+	Keyless bool
+	// OIDC identity token to request the Fulcio certificate of a keyless signature with
+	IdentityToken string
+	// The number of attempts made to push each layer of the package
+	Retries int
+	// Continue a failed publish from the layers it already pushed
+	Resume bool
+	// The type of manifest to publish the package with (image, artifact or auto)
+	ManifestType string
+	// The attestations (sbom or provenance) to attach to the published package
+	Attestations []string
 }
 
 // ZarfPullOptions tracks the user-defined preferences during a package pull.
@@ -130,12 +240,57 @@ type ZarfInitOptions struct {
 	ArtifactServer ArtifactServerInfo
 	// StorageClass of the k8s cluster Zarf is initializing
 	StorageClass string
+	// Information about how the Zarf injector should bootstrap the seed registry
+	Injector InjectorOptions
+	// Trusted publishers that packages deployed to the cluster must be signed by
+	VerificationPolicy VerificationPolicy
+	// The age recipients the Zarf state and deployed package secrets are encrypted to, they are not encrypted when empty
+	StateRecipients []string
+}
+
+// Default values for the Zarf injector pod.
+const (
+	DefaultInjectorCPURequest    = ".5"
+	DefaultInjectorMemoryRequest = "64Mi"
+	DefaultInjectorCPULimit      = "1"
+	DefaultInjectorMemoryLimit   = "256Mi"
+	DefaultInjectorTimeout       = 60 * time.Second
+	// DefaultInjectorPayloadChunkSize is also the largest chunk size as chunks have to fit the etcd 1MB limit once base64 encoded
+	DefaultInjectorPayloadChunkSize = "768Ki"
+	// MinInjectorPayloadChunkSize keeps the number of payload configmaps, and the volumes of the injector pod, small
+	MinInjectorPayloadChunkSize = "64Ki"
+)
+
+// InjectorOptions tracks the user-defined overrides for the Zarf injector during cluster initialization.
+type InjectorOptions struct {
+	// Image already present on the cluster nodes to run the injector in (defaults to an image discovered from a running pod)
+	Image string
+	// Path to a local zarf-injector binary to use instead of the one included in the init package
+	BinaryPath string
+	// SHA256 checksum the injector binary override must match
+	BinaryShasum string
+	// Path to an image tarball to seed the registry with instead of the seed image included in the init package
+	SeedImagePath string
+	// CPU request for the injector pod
+	CPURequest string
+	// Memory request for the injector pod
+	MemoryRequest string
+	// CPU limit for the injector pod
+	CPULimit string
+	// Memory limit for the injector pod
+	MemoryLimit string
+	// Time to wait for the injector pod to become ready
+	Timeout time.Duration
+	// Size of the chunks the seed image payload is split into, one configmap is created per chunk
+	PayloadChunkSize string
 }
 
 // ZarfCreateOptions tracks the user-defined options used to create the package.
 type ZarfCreateOptions struct {
 	// Disable the generation of SBOM materials during package creation
 	SkipSBOM bool
+	// Leave the HTML SBOM viewers out of the package, keeping only the SBOM JSON
+	SkipSBOMViewer bool
 	// Location where the Zarf package will be created from
 	BaseDir string
 	// Location where the finalized Zarf package will be placed
@@ -156,12 +311,86 @@ type ZarfCreateOptions struct {
 	DifferentialPackagePath string
 	// A map of domains to override on package create when pulling images
 	RegistryOverrides map[string]string
+	// A list of image references to flatten into a single layer on package create
+	FlattenImages []string
 	// An optional variant that controls which components will be included in a package
 	Flavor string
 	// Whether to create a skeleton package
 	IsSkeleton bool
 	// Whether to create a YOLO package
 	NoYOLO bool
+	// Number of components to assemble in parallel
+	CreateConcurrency int
+	// Whether to also sign checksums.txt so the signature covers all of the package content
+	SignChecksums bool
+	// URL of the RFC3161 timestamp authority that timestamps the signatures
+	TSAURL string
+	// Whether to sign the package keyless with a Fulcio certificate for an OIDC identity instead of a signing key
+	Keyless bool
+	// OIDC identity token to request the Fulcio certificate of a keyless signature with
+	IdentityToken string
+	// Whether to store the rendered manifests of each chart in the package to verify the charts against on deploy
+	SnapshotCharts bool
+}
+
+// Authentication methods of registry presets.
+const (
+	// RegistryPresetAuthDocker uses the credentials of the docker config and its credential helpers
+	RegistryPresetAuthDocker = "docker"
+	// RegistryPresetAuthBasic uses the username and password of the preset
+	RegistryPresetAuthBasic = "basic"
+	// RegistryPresetAuthAnonymous pulls without credentials
+	RegistryPresetAuthAnonymous = "anonymous"
+)
+
+// RegistryPreset is a named hardened image source, such as Iron Bank, configured in the Zarf config file.
+type RegistryPreset struct {
+	// Name of the preset
+	Name string
+	// URL of the registry, optionally followed by a repository prefix (e.g. registry1.dso.mil/ironbank)
+	URL string
+	// Method used to authenticate to the registry, one of docker, basic or anonymous (defaults to docker)
+	Auth string
+	// Username used with basic authentication
+	Username string
+	// Password used with basic authentication
+	Password string
+	// Labels that every image pulled from the registry must have in its config
+	RequiredLabels []string
+}
+
+// Validate returns an error if the preset is not usable.
+func (rp RegistryPreset) Validate() error {
+	if rp.URL == "" {
+		return fmt.Errorf("registry preset %s has no url", rp.Name)
+	}
+	switch rp.Auth {
+	case "", RegistryPresetAuthDocker, RegistryPresetAuthAnonymous:
+		return nil
+	case RegistryPresetAuthBasic:
+		if rp.Username == "" || rp.Password == "" {
+			return fmt.Errorf("registry preset %s uses basic auth without a username and password", rp.Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("registry preset %s has the unknown auth method %q, must be one of %s, %s or %s", rp.Name, rp.Auth,
+			RegistryPresetAuthDocker, RegistryPresetAuthBasic, RegistryPresetAuthAnonymous)
+	}
+}
+
+// Matches returns true if the fully qualified image reference is from the registry of the preset.
+func (rp RegistryPreset) Matches(ref string) bool {
+	return strings.HasPrefix(ref, strings.TrimSuffix(rp.URL, "/")+"/")
+}
+
+// MatchRegistryPreset returns the first of the presets the fully qualified image reference is from.
+func MatchRegistryPreset(presets []RegistryPreset, ref string) (RegistryPreset, bool) {
+	for _, preset := range presets {
+		if preset.Matches(ref) {
+			return preset, true
+		}
+	}
+	return RegistryPreset{}, false
 }
 
 // ZarfSplitPackageData contains info about a split package.
@@ -180,3 +409,30 @@ type DifferentialData struct {
 	DifferentialRepos          map[string]bool
 	DifferentialPackageVersion string
 }
+
+// VariableSource is where the value of a deploy time variable comes from.
+type VariableSource string
+
+const (
+	// VariableSourceDefault is the default value of the variable in the package.
+	VariableSourceDefault VariableSource = "default"
+	// VariableSourceFlag is a value set with a flag on the command line.
+	VariableSourceFlag VariableSource = "flag"
+	// VariableSourceConfig is a value set in the Zarf config file.
+	VariableSourceConfig VariableSource = "config"
+	// VariableSourcePrompt is a value the user is prompted for.
+	VariableSourcePrompt VariableSource = "prompt"
+)
+
+// VariableSummary describes the value of a variable that is templated during a deployment.
+type VariableSummary struct {
+	Name   string         `json:"name"`
+	Source VariableSource `json:"source"`
+	// Value of the variable, masked when the variable is sensitive and empty when the user is prompted for it later
+	Value string `json:"value"`
+	// Default value of the variable in the package, masked when the variable is sensitive
+	Default string `json:"default"`
+	// Whether the value differs from the default value
+	Changed   bool `json:"changed"`
+	Sensitive bool `json:"sensitive"`
+}